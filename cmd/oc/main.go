@@ -17,6 +17,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -78,7 +79,7 @@ func main() {
 
 	request := ping.NewPing(timeoutDuration, targetIPAddress, testPingCount)
 	chain := []reel.Handler{request}
-	test, err := tnf.NewTest(oc.GetExpecter(), request, chain, ch)
+	test, err := tnf.NewTest(context.Background(), oc.GetExpecter(), request, chain, ch)
 
 	if err == nil {
 		result, err = test.Run()
@@ -17,6 +17,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -67,13 +68,13 @@ func main() {
 	pingReel, timeoutDuration := parseArgs()
 	goExpectSpawner := interactive.NewGoExpectSpawner()
 	var spawner interactive.Spawner = goExpectSpawner
-	context, err := interactive.SpawnShell(&spawner, timeoutDuration, interactive.Verbose(true))
+	shell, err := interactive.SpawnShell(&spawner, timeoutDuration, interactive.Verbose(true))
 
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(tnf.ExitCodeMap[result])
 	}
-	tester, err := tnf.NewTest(context.GetExpecter(), pingReel, []reel.Handler{pingReel}, context.GetErrorChannel())
+	tester, err := tnf.NewTest(context.Background(), shell.GetExpecter(), pingReel, []reel.Handler{pingReel}, shell.GetErrorChannel())
 
 	if err == nil {
 		result, _ = tester.Run()
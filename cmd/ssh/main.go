@@ -17,6 +17,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -66,7 +67,7 @@ func parseArgs() (*interactive.Context, string, time.Duration, error) {
 // Execute a ping to the target IP address and print interaction with the controlled subprocess.
 func main() {
 	result := tnf.ERROR
-	context, targetIPAddress, timeoutDuration, err := parseArgs()
+	session, targetIPAddress, timeoutDuration, err := parseArgs()
 
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -75,7 +76,7 @@ func main() {
 
 	request := ping.NewPing(timeoutDuration, targetIPAddress, testPingCount)
 	chain := []reel.Handler{request}
-	test, err := tnf.NewTest(context.GetExpecter(), request, chain, context.GetErrorChannel())
+	test, err := tnf.NewTest(context.Background(), session.GetExpecter(), request, chain, session.GetErrorChannel())
 
 	if err == nil {
 		result, err = test.Run()
@@ -9,7 +9,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
 	"github.com/test-network-function/test-network-function/pkg/junit"
 )
 
@@ -37,12 +37,11 @@ func claimUpdate(cmd *cobra.Command, args []string) error {
 	claimFileTextPtr := &Claim
 	reportFilesTextPtr := &Reportdir
 	fileUpdated := false
-	dat, err := os.ReadFile(*claimFileTextPtr)
+	// the claim is migrated to the current format version as it is read.
+	claimRoot, err := claim.ReadClaimFile(*claimFileTextPtr)
 	if err != nil {
 		log.Fatalf("Error reading claim file :%v", err)
 	}
-
-	claimRoot := readClaim(&dat)
 	junitMap := claimRoot.Claim.RawResults
 
 	items, _ := os.ReadDir(*reportFilesTextPtr)
@@ -80,15 +79,6 @@ func claimUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func readClaim(contents *[]byte) *claim.Root {
-	var claimRoot claim.Root
-	err := json.Unmarshal(*contents, &claimRoot)
-	if err != nil {
-		log.Fatalf("Error reading claim constents file into type: %v", err)
-	}
-	return &claimRoot
-}
-
 func NewCommand() *cobra.Command {
 	claimAddFile.Flags().StringVarP(
 		&Reportdir, "reportdir", "r", "",
@@ -109,5 +99,8 @@ func NewCommand() *cobra.Command {
 		return nil
 	}
 	addcalim.AddCommand(claimAddFile)
+	addcalim.AddCommand(newReportCommand())
+	addcalim.AddCommand(newCompareCommand())
+	addcalim.AddCommand(newMigrateCommand())
 	return addcalim
 }
@@ -0,0 +1,48 @@
+package claim
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/claim/compare"
+)
+
+var (
+	OldClaim string
+	NewClaim string
+
+	claimCompare = &cobra.Command{
+		Use:   "compare",
+		Short: "Compares two \"claim\" files and prints the tests that changed state and the version/configuration differences",
+		RunE:  claimCompareFiles,
+	}
+)
+
+func claimCompareFiles(cmd *cobra.Command, args []string) error {
+	diff, err := compare.CompareFiles(OldClaim, NewClaim)
+	if err != nil {
+		log.Fatalf("Error comparing claim files: %v", err)
+	}
+	return diff.Write(os.Stdout)
+}
+
+func newCompareCommand() *cobra.Command {
+	claimCompare.Flags().StringVarP(
+		&OldClaim, "old", "o", "",
+		"claim file of the previous run. (Required)",
+	)
+	err := claimCompare.MarkFlagRequired("old")
+	if err != nil {
+		return nil
+	}
+	claimCompare.Flags().StringVarP(
+		&NewClaim, "new", "n", "",
+		"claim file of the current run. (Required)",
+	)
+	err = claimCompare.MarkFlagRequired("new")
+	if err != nil {
+		return nil
+	}
+	return claimCompare
+}
@@ -0,0 +1,57 @@
+package claim
+
+import (
+	"encoding/json"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+)
+
+var (
+	MigrateOutput string
+
+	claimMigrate = &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrates a \"claim\" file written by an older version of the test suite to the current claim format",
+		RunE:  claimMigrateFile,
+	}
+)
+
+func claimMigrateFile(cmd *cobra.Command, args []string) error {
+	// the claim is migrated as it is read.
+	claimRoot, err := claim.ReadClaimFile(Claim)
+	if err != nil {
+		log.Fatalf("Error migrating the claim file: %v", err)
+	}
+	payload, err := json.MarshalIndent(claimRoot, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to generate the claim: %v", err)
+	}
+	output := MigrateOutput
+	if output == "" {
+		output = Claim
+	}
+	if err = os.WriteFile(output, payload, claimFilePermissions); err != nil {
+		log.Fatalf("Error writing the migrated claim file: %v", err)
+	}
+	log.Printf("Claim file `%s` written with the claim format %s\n", output, claim.FormatVersion)
+	return nil
+}
+
+func newMigrateCommand() *cobra.Command {
+	claimMigrate.Flags().StringVarP(
+		&Claim, "claim", "c", "",
+		"existing claim file. (Required)",
+	)
+	err := claimMigrate.MarkFlagRequired("claim")
+	if err != nil {
+		return nil
+	}
+	claimMigrate.Flags().StringVarP(
+		&MigrateOutput, "output", "o", "",
+		"path of the migrated claim file, the claim file is migrated in place by default.",
+	)
+	return claimMigrate
+}
@@ -0,0 +1,42 @@
+package claim
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/claim/report"
+)
+
+var (
+	ReportOutput string
+
+	claimReport = &cobra.Command{
+		Use:   "report",
+		Short: "Generates a self-contained HTML report from a \"claim\" file",
+		RunE:  claimHTMLReport,
+	}
+)
+
+func claimHTMLReport(cmd *cobra.Command, args []string) error {
+	err := report.GenerateHTMLReport(Claim, ReportOutput)
+	if err != nil {
+		log.Fatalf("Error generating the HTML report: %v", err)
+	}
+	log.Printf("HTML report written to `%s`\n", ReportOutput)
+	return nil
+}
+
+func newReportCommand() *cobra.Command {
+	claimReport.Flags().StringVarP(
+		&Claim, "claim", "c", "",
+		"existing claim file. (Required)",
+	)
+	err := claimReport.MarkFlagRequired("claim")
+	if err != nil {
+		return nil
+	}
+	claimReport.Flags().StringVarP(
+		&ReportOutput, "output", "o", "report.html",
+		"path of the generated HTML report.",
+	)
+	return claimReport
+}
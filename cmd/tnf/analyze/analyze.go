@@ -0,0 +1,88 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package analyze provides the "tnf analyze" commands, analyzing the claims of past runs.
+package analyze
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/claim/flakes"
+)
+
+// defaultTop is the number of flaky test cases listed by default.
+const defaultTop = 20
+
+var (
+	historyDir    string
+	versionFields []string
+	top           int
+
+	analyzeCmd = &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyzes the claims of past runs",
+	}
+
+	flakesCmd = &cobra.Command{
+		Use:   "flakes",
+		Short: "Ranks the test cases whose outcome varies between runs of the same CNF version",
+		Long: `Reads the claim files of past runs found in a directory and its sub-directories, groups the runs by CNF
+version, and ranks the test cases whose outcome varies between runs of the same CNF version by flip rate: the ratio of
+consecutive runs, by start time, with different outcomes.  These test cases depend on the cluster rather than on the
+CNF, and are candidates for longer timeouts or retries.
+
+The CNF version of a run is identified by the values of claim fields, given as dotted paths, the operators and the
+deployments under test by default.`,
+		Example: `  tnf analyze flakes --history /var/lib/tnf/claims
+  tnf analyze flakes --history claims/ --version-field configurations.testTarget.operators --top 10`,
+		Args: cobra.NoArgs,
+		RunE: analyzeFlakes,
+	}
+)
+
+func analyzeFlakes(cmd *cobra.Command, args []string) error {
+	runs, err := flakes.ReadHistory(historyDir, func(path string, err error) {
+		log.Warnf("skipping %s: %v", path, err)
+	})
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		return fmt.Errorf("no claim file found in %s", historyDir)
+	}
+	results, versions, err := flakes.Analyze(runs, versionFields)
+	if err != nil {
+		return err
+	}
+	flakes.Print(os.Stdout, results, len(runs), versions, top)
+	return nil
+}
+
+// NewCommand returns the "analyze" command.
+func NewCommand() *cobra.Command {
+	flakesCmd.Flags().StringVar(&historyDir, "history", "", "directory of the claim files of past runs (Required)")
+	flakesCmd.Flags().StringSliceVar(&versionFields, "version-field", flakes.DefaultVersionFields, "claim fields "+
+		"identifying the CNF version, as dotted paths")
+	flakesCmd.Flags().IntVar(&top, "top", defaultTop, "number of flaky test cases listed, 0 for all")
+	if err := flakesCmd.MarkFlagRequired("history"); err != nil {
+		return nil
+	}
+	analyzeCmd.AddCommand(flakesCmd)
+	return analyzeCmd
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package annotate provides the "tnf annotate" commands, setting the labels and annotations read by the
+// autodiscovery on the resources under test.
+package annotate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
+)
+
+const (
+	// partnerPrefix is the prefix of the labels of the partner pods, which does not follow TNF_LABEL_DOMAIN.
+	partnerPrefix = autodiscover.DefaultLabelDomain + "/"
+	// ocBinaryName is the OpenShift client running the label and annotate commands.
+	ocBinaryName = "oc"
+)
+
+var (
+	namespace               string
+	dryRun                  bool
+	target                  bool
+	orchestrator            bool
+	skipConnectivityTests   bool
+	defaultNetworkInterface string
+	hostResourceTests       []string
+	hostNamespaceExemptions []string
+	apiAccess               bool
+	latencySensitive        bool
+	sigtermHandler          bool
+	containerTestSkips      []string
+	operatorTests           []string
+	subscriptionName        string
+
+	annotate = &cobra.Command{
+		Use:   "annotate",
+		Short: "Sets the labels and annotations read by the autodiscovery",
+		Long: `Sets the labels and annotations read by the autodiscovery.  The labels added to a running pod are lost when the
+pod is re-created, they should be set in the pod template instead for a lasting setup.`,
+	}
+
+	pod = &cobra.Command{
+		Use:   "pod <name>...",
+		Short: "Labels and annotates pods",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  annotatePods,
+	}
+
+	csv = &cobra.Command{
+		Use:   "csv <name>...",
+		Short: "Labels and annotates operator ClusterServiceVersions",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  annotateCSVs,
+	}
+)
+
+// jsonValue encodes an annotation value, the autodiscovery decodes the annotations as JSON.
+func jsonValue(v interface{}) (string, error) {
+	value, err := json.Marshal(v)
+	return string(value), err
+}
+
+// buildPodCommands returns the oc commands labeling and annotating a pod from the flags.
+func buildPodCommands(name string) ([][]string, error) {
+	var labels, annotations []string
+	tnfPrefix := autodiscover.GetLabelDomain() + "/"
+	if target {
+		labels = append(labels, tnfPrefix+"generic=target")
+	}
+	if orchestrator {
+		labels = append(labels, partnerPrefix+"generic=orchestrator")
+	}
+	if skipConnectivityTests {
+		labels = append(labels, tnfPrefix+"skip_connectivity_tests=")
+	}
+	if defaultNetworkInterface != "" {
+		value, err := jsonValue(defaultNetworkInterface)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, tnfPrefix+"defaultnetworkinterface="+value)
+	}
+	if len(hostResourceTests) != 0 {
+		value, err := jsonValue(hostResourceTests)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, tnfPrefix+"host_resource_tests="+value)
+	}
+	if len(hostNamespaceExemptions) != 0 {
+		value, err := jsonValue(hostNamespaceExemptions)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, tnfPrefix+"host_namespace_exemptions="+value)
+	}
+	if apiAccess {
+		annotations = append(annotations, tnfPrefix+"api_access=true")
+	}
+	if latencySensitive {
+		annotations = append(annotations, tnfPrefix+"latency_sensitive=true")
+	}
+	if sigtermHandler {
+		annotations = append(annotations, tnfPrefix+"sigterm_handler=true")
+	}
+	if len(containerTestSkips) != 0 {
+		skips, err := parseContainerTestSkips(containerTestSkips)
+		if err != nil {
+			return nil, err
+		}
+		value, err := jsonValue(skips)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, tnfPrefix+"container_test_skips="+value)
+	}
+	return buildCommands("pod", name, labels, annotations), nil
+}
+
+// parseContainerTestSkips parses the "container=test,test" values of the --container-test-skips flag into the test
+// case IDs skipped by container name.
+func parseContainerTestSkips(values []string) (map[string][]string, error) {
+	skips := map[string][]string{}
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid container test skip %q, expected container=test,test", value)
+		}
+		skips[parts[0]] = append(skips[parts[0]], strings.Split(parts[1], ",")...)
+	}
+	return skips, nil
+}
+
+// buildCSVCommands returns the oc commands labeling and annotating a CSV from the flags.
+func buildCSVCommands(name string) ([][]string, error) {
+	tnfPrefix := autodiscover.GetLabelDomain() + "/"
+	labels := []string{tnfPrefix + "operator=target"}
+	var annotations []string
+	if len(operatorTests) != 0 {
+		value, err := jsonValue(operatorTests)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, tnfPrefix+"operator_tests="+value)
+	}
+	if subscriptionName != "" {
+		value, err := jsonValue([]string{subscriptionName})
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, tnfPrefix+"subscription_name="+value)
+	}
+	return buildCommands("csv", name, labels, annotations), nil
+}
+
+func buildCommands(kind, name string, labels, annotations []string) [][]string {
+	var commands [][]string
+	if len(labels) != 0 {
+		commands = append(commands, append([]string{"label", kind, name, "-n", namespace, "--overwrite"}, labels...))
+	}
+	if len(annotations) != 0 {
+		commands = append(commands, append([]string{"annotate", kind, name, "-n", namespace, "--overwrite"}, annotations...))
+	}
+	return commands
+}
+
+// runCommands runs the oc commands, or only prints them in dry-run mode.
+func runCommands(commands [][]string) error {
+	for _, args := range commands {
+		if dryRun {
+			fmt.Println(ocBinaryName, strings.Join(args, " "))
+			continue
+		}
+		log.Debugf("running %s %s", ocBinaryName, strings.Join(args, " "))
+		ocCmd := exec.Command(ocBinaryName, args...)
+		ocCmd.Stdout = os.Stdout
+		ocCmd.Stderr = os.Stderr
+		if err := ocCmd.Run(); err != nil {
+			return fmt.Errorf("%s %s: %w", ocBinaryName, strings.Join(args, " "), err)
+		}
+	}
+	return nil
+}
+
+func annotatePods(cmd *cobra.Command, args []string) error {
+	for _, name := range args {
+		commands, err := buildPodCommands(name)
+		if err != nil {
+			return err
+		}
+		if len(commands) == 0 {
+			return fmt.Errorf("nothing to set, see \"tnf annotate pod --help\"")
+		}
+		if err := runCommands(commands); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func annotateCSVs(cmd *cobra.Command, args []string) error {
+	for _, name := range args {
+		commands, err := buildCSVCommands(name)
+		if err != nil {
+			return err
+		}
+		if err := runCommands(commands); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewCommand returns the "annotate" command.
+func NewCommand() *cobra.Command {
+	annotate.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "namespace of the resources (Required)")
+	if err := annotate.MarkPersistentFlagRequired("namespace"); err != nil {
+		return nil
+	}
+	annotate.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "only print the oc commands")
+
+	pod.Flags().BoolVar(&target, "target", false, "label the pods as targets of the tests")
+	pod.Flags().BoolVar(&orchestrator, "orchestrator", false, "label the pod as the test orchestrator")
+	pod.Flags().BoolVar(&skipConnectivityTests, "skip-connectivity-tests", false, "exclude the pods from the "+
+		"connectivity tests")
+	pod.Flags().StringVar(&defaultNetworkInterface, "default-network-interface", "", "network interface of the "+
+		"pods on the default network")
+	pod.Flags().StringSliceVar(&hostResourceTests, "host-resource-tests", nil, "host resource tests to run on the "+
+		"pods, all by default")
+	pod.Flags().StringSliceVar(&hostNamespaceExemptions, "host-namespace-exemptions", nil, "host namespaces the "+
+		"pods are allowed to share, among hostNetwork, hostPID and hostIPC")
+	pod.Flags().BoolVar(&apiAccess, "api-access", false, "declare that the pods access the Kubernetes API, and "+
+		"thus need their service account token")
+	pod.Flags().BoolVar(&latencySensitive, "latency-sensitive", false, "declare that the pods are latency-sensitive, "+
+		"and thus need the Guaranteed QoS class")
+	pod.Flags().BoolVar(&sigtermHandler, "sigterm-handler", false, "declare that the containers of the pods handle "+
+		"SIGTERM to shut down gracefully, and thus need no preStop hook")
+	pod.Flags().StringArrayVar(&containerTestSkips, "container-test-skips", nil, "opt a container of the pods out of "+
+		"container test cases, as container=test,test where the test case IDs may be shell patterns, e.g. "+
+		"istio-proxy=lifecycle-*-probe")
+	annotate.AddCommand(pod)
+
+	csv.Flags().StringSliceVar(&operatorTests, "operator-tests", nil, "operator tests to run, all by default")
+	csv.Flags().StringVar(&subscriptionName, "subscription-name", "", "name of the subscription of the operator")
+	annotate.AddCommand(csv)
+	return annotate
+}
@@ -0,0 +1,220 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package catalog provides the "tnf catalog" commands, browsing the test cases of the catalog.
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/cmd/tnf/completion"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+)
+
+var (
+	suite  string
+	output string
+
+	catalog = &cobra.Command{
+		Use:   "catalog",
+		Short: "Browses the test cases of the catalog",
+	}
+
+	list = &cobra.Command{
+		Use:   "list",
+		Short: "Lists the test cases with their classification and resource types, as a table, JSON or CSV",
+		Args:  cobra.NoArgs,
+		RunE:  listTestCases,
+	}
+
+	describe = &cobra.Command{
+		Use:               "describe <test-case>...",
+		Short:             "Describes test cases",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.TestCaseNames,
+		RunE:              describeTestCases,
+	}
+)
+
+// getSortedIdentifiers returns the identifiers of the catalog sorted by URL, restricted to a suite unless empty.
+func getSortedIdentifiers(suiteName string) []claim.Identifier {
+	ids := make([]claim.Identifier, 0, len(identifiers.Catalog))
+	for id := range identifiers.Catalog {
+		if suiteName == "" || identifiers.GetSuite(id) == suiteName {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].Url < ids[j].Url
+	})
+	return ids
+}
+
+// testCaseEntry is a test case of the machine-readable catalog.
+type testCaseEntry struct {
+	ID                string   `json:"id"`
+	URL               string   `json:"url"`
+	Version           string   `json:"version"`
+	Suite             string   `json:"suite"`
+	Type              string   `json:"type"`
+	Classification    string   `json:"classification"`
+	Intrusive         bool     `json:"intrusive"`
+	ResourceTypes     []string `json:"resourceTypes"`
+	Tags              []string `json:"tags"`
+	RequiresOpenShift bool     `json:"requiresOpenShift"`
+	MinVersion        string   `json:"minVersion,omitempty"`
+	MaxVersion        string   `json:"maxVersion,omitempty"`
+	Description       string   `json:"description"`
+}
+
+// newTestCaseEntry returns the catalog entry of a test case.
+func newTestCaseEntry(id *claim.Identifier) testCaseEntry {
+	description := identifiers.Catalog[*id]
+	classification := identifiers.GetClassification(*id)
+	entry := testCaseEntry{
+		ID:                groups.TestCaseName(id),
+		URL:               id.Url,
+		Version:           id.Version,
+		Suite:             identifiers.GetSuite(*id),
+		Type:              description.Type,
+		Classification:    string(classification),
+		Intrusive:         classification.IsIntrusive(),
+		ResourceTypes:     []string{},
+		Tags:              []string{},
+		RequiresOpenShift: identifiers.RequiresOpenShift(*id),
+		Description:       strings.ReplaceAll(description.Description, "\n", " "),
+	}
+	entry.MinVersion, entry.MaxVersion = identifiers.GetSupportedVersions(*id)
+	for _, resourceType := range identifiers.GetResourceTypes(*id) {
+		entry.ResourceTypes = append(entry.ResourceTypes, string(resourceType))
+	}
+	for _, tag := range identifiers.GetTags(*id) {
+		entry.Tags = append(entry.Tags, string(tag))
+	}
+	return entry
+}
+
+func listTestCases(cmd *cobra.Command, args []string) error {
+	ids := getSortedIdentifiers(suite)
+	if len(ids) == 0 {
+		return fmt.Errorf("unknown suite %q", suite)
+	}
+	entries := make([]testCaseEntry, 0, len(ids))
+	for i := range ids {
+		entries = append(entries, newTestCaseEntry(&ids[i]))
+	}
+	switch output {
+	case outputTable:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TEST CASE\tCLASSIFICATION\tTYPE\tRESOURCE TYPES\tTAGS")
+		for i := range entries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", entries[i].ID, entries[i].Classification, entries[i].Type,
+				strings.Join(entries[i].ResourceTypes, ","), strings.Join(entries[i].Tags, ","))
+		}
+		return w.Flush()
+	case outputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case outputCSV:
+		return writeCSV(entries)
+	default:
+		return fmt.Errorf("unknown output %q, expected %s, %s or %s", output, outputTable, outputJSON, outputCSV)
+	}
+}
+
+// writeCSV writes the entries as CSV with a header line, the resource types and tags being separated by semicolons.
+func writeCSV(entries []testCaseEntry) error {
+	w := csv.NewWriter(os.Stdout)
+	records := [][]string{{"id", "url", "version", "suite", "type", "classification", "intrusive", "resourceTypes",
+		"tags", "requiresOpenShift", "minVersion", "maxVersion", "description"}}
+	for i := range entries {
+		e := &entries[i]
+		records = append(records, []string{e.ID, e.URL, e.Version, e.Suite, e.Type, e.Classification,
+			strconv.FormatBool(e.Intrusive), strings.Join(e.ResourceTypes, ";"), strings.Join(e.Tags, ";"),
+			strconv.FormatBool(e.RequiresOpenShift), e.MinVersion, e.MaxVersion, e.Description})
+	}
+	return w.WriteAll(records)
+}
+
+func describeTestCases(cmd *cobra.Command, args []string) error {
+	ids := getSortedIdentifiers("")
+	byName := make(map[string]claim.Identifier, len(ids))
+	for i := range ids {
+		byName[groups.TestCaseName(&ids[i])] = ids[i]
+	}
+	for _, name := range args {
+		id, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unknown test case %q, see \"tnf catalog list\"", name)
+		}
+		description := identifiers.Catalog[id]
+		fmt.Printf("%s\n", name)
+		fmt.Printf("  URL:                     %s\n", id.Url)
+		fmt.Printf("  Version:                 %s\n", id.Version)
+		fmt.Printf("  Type:                    %s\n", description.Type)
+		fmt.Printf("  Classification:          %s\n", identifiers.GetClassification(id))
+		fmt.Printf("  Resource Types:          %s\n", identifiers.FormatResourceTypes(id))
+		fmt.Printf("  Tags:                    %s\n", formatTags(id))
+		if versions := identifiers.FormatSupportedVersions(id); versions != "" {
+			fmt.Printf("  Supported Versions:      %s\n", versions)
+		}
+		if identifiers.RequiresOpenShift(id) {
+			fmt.Printf("  Requires OpenShift:      %t\n", true)
+		}
+		fmt.Printf("  Description:             %s\n", strings.ReplaceAll(description.Description, "\n", " "))
+		fmt.Printf("  Suggested Remediation:   %s\n", strings.ReplaceAll(description.Remediation, "\n", " "))
+		fmt.Printf("  Best Practice Reference: %s\n", strings.ReplaceAll(description.BestPracticeReference, "\n", " "))
+	}
+	return nil
+}
+
+// formatTags returns the tags of a test case, separated by commas.
+func formatTags(id claim.Identifier) string {
+	var tags []string
+	for _, tag := range identifiers.GetTags(id) {
+		tags = append(tags, string(tag))
+	}
+	return strings.Join(tags, ", ")
+}
+
+// NewCommand returns the "catalog" command.
+func NewCommand() *cobra.Command {
+	list.Flags().StringVarP(&suite, "suite", "s", "", "only list the test cases of a suite")
+	list.Flags().StringVarP(&output, "output", "o", outputTable, "output format: table, json or csv")
+	if err := list.RegisterFlagCompletionFunc("suite", completion.SuiteNames); err != nil {
+		return nil
+	}
+	catalog.AddCommand(list)
+	catalog.AddCommand(describe)
+	return catalog
+}
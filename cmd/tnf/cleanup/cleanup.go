@@ -0,0 +1,74 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package cleanup provides the "tnf cleanup" command, removing what the test suites left on the cluster.
+package cleanup
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/debugpods"
+)
+
+const ocBinaryName = "oc"
+
+var (
+	dryRun               bool
+	deleteDebugDaemonSet bool
+
+	cleanup = &cobra.Command{
+		Use:   "cleanup",
+		Short: "Removes the debug labels of the nodes, and optionally the debug daemonset",
+		Long: `Removes the label set on the nodes by the test suites to schedule the debug pods, which stops the debug pods.
+The debug daemonset itself, deployed with the partner pods or by the test suites when missing, is only deleted with
+--delete-debug-daemonset.  The test suites delete the debug daemonset they deployed at the end of the run.`,
+		Args: cobra.NoArgs,
+		RunE: runCleanup,
+	}
+)
+
+func runCleanup(cmd *cobra.Command, args []string) error {
+	commands := [][]string{{"label", "node", "-l", debugpods.NodeLabelName, debugpods.NodeLabelName + "-"}}
+	if deleteDebugDaemonSet {
+		commands = append(commands, []string{"delete", "daemonset/" + debugpods.Name,
+			"serviceaccount/" + debugpods.ServiceAccountName, "rolebinding/" + debugpods.ServiceAccountName + "-privileged",
+			"-n", debugpods.Namespace, "--ignore-not-found"})
+	}
+	for _, args := range commands {
+		if dryRun {
+			fmt.Println(ocBinaryName, strings.Join(args, " "))
+			continue
+		}
+		ocCmd := exec.Command(ocBinaryName, args...)
+		ocCmd.Stdout = os.Stdout
+		ocCmd.Stderr = os.Stderr
+		if err := ocCmd.Run(); err != nil {
+			return fmt.Errorf("%s %s: %w", ocBinaryName, strings.Join(args, " "), err)
+		}
+	}
+	return nil
+}
+
+// NewCommand returns the "cleanup" command.
+func NewCommand() *cobra.Command {
+	cleanup.Flags().BoolVar(&dryRun, "dry-run", false, "only print the oc commands")
+	cleanup.Flags().BoolVar(&deleteDebugDaemonSet, "delete-debug-daemonset", false, "also delete the debug daemonset")
+	return cleanup
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package completion provides the shell completion of the suite names and test case names of the catalog.
+package completion
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+)
+
+// GetSuiteNames returns the sorted names of the suites of the catalog.
+func GetSuiteNames() []string {
+	suites := map[string]bool{}
+	for id := range identifiers.Catalog {
+		// the identifier URLs are http://test-network-function.com/testcases/<suite>/<name>.
+		suites[path.Base(path.Dir(id.Url))] = true
+	}
+	return sortedKeys(suites)
+}
+
+// GetTestCaseNames returns the sorted "<suite>-<name>" names of the test cases of the catalog.
+func GetTestCaseNames() []string {
+	names := map[string]bool{}
+	for id := range identifiers.Catalog {
+		id := id
+		names[groups.TestCaseName(&id)] = true
+	}
+	return sortedKeys(names)
+}
+
+// SuiteNames completes suite names.
+func SuiteNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterPrefix(GetSuiteNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// TestCaseNames completes test case names.
+func TestCaseNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterPrefix(GetTestCaseNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func filterPrefix(values []string, prefix string) []string {
+	var filtered []string
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			filtered = append(filtered, value)
+		}
+	}
+	return filtered
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package config provides the "tnf config" commands, checking the test configuration files.
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Checks the test configuration files",
+	}
+
+	validate = &cobra.Command{
+		Use:   "validate [config-file]",
+		Short: "Validates a configuration file, rejecting the unknown fields",
+		Long: `Validates a configuration file, rejecting the unknown fields, e.g. misspelled ones, the values of the wrong
+type and the missing required fields, which are all listed with their path, e.g. targetPodLabels[0].name.  The
+configuration file is $TNF_CONFIGURATION_PATH, or tnf_config.yml, unless set, and may be an HTTP(S) URL or a ConfigMap
+or Secret of the cluster, e.g. configmap:tnf/tnf-config.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: validateConfig,
+	}
+
+	show = &cobra.Command{
+		Use:   "show [config-file]",
+		Short: "Shows a configuration file as understood by the test suites",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  showConfig,
+	}
+)
+
+// getConfigPath returns the configuration file args[0], or the default configuration file.
+func getConfigPath(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return config.GetConfigurationFilePath()
+}
+
+// loadConfig strictly loads the configuration file args[0], or the default configuration file, with the overrides of
+// the TNF_CONFIG_ environment variables applied.
+func loadConfig(args []string) (string, *configsections.TestConfiguration, error) {
+	filePath := getConfigPath(args)
+	contents, err := config.ReadConfigurationSource(filePath)
+	if err != nil {
+		return filePath, nil, err
+	}
+	if contents, err = configsections.ApplyOverrides(contents, config.GetConfigurationOverrides()); err != nil {
+		return filePath, nil, err
+	}
+	var testConfig configsections.TestConfiguration
+	if err := yaml.UnmarshalStrict(contents, &testConfig); err != nil {
+		return filePath, nil, fmt.Errorf("invalid configuration file %s: %w", filePath, err)
+	}
+	return filePath, &testConfig, nil
+}
+
+func validateConfig(cmd *cobra.Command, args []string) error {
+	filePath := getConfigPath(args)
+	if err := config.CheckConfigurationFile(filePath); err != nil {
+		return err
+	}
+	fmt.Printf("%s is valid\n", filePath)
+	return nil
+}
+
+func showConfig(cmd *cobra.Command, args []string) error {
+	_, testConfig, err := loadConfig(args)
+	if err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(testConfig)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(out))
+	return nil
+}
+
+// NewCommand returns the "config" command.
+func NewCommand() *cobra.Command {
+	configCmd.AddCommand(validate)
+	configCmd.AddCommand(show)
+	return configCmd
+}
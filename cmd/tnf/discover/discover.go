@@ -0,0 +1,129 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package discover provides the "tnf discover" command, running the autodiscovery without the test suites.
+package discover
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"gopkg.in/yaml.v2"
+)
+
+// errNoPodUnderTest is returned when the autodiscovery finds no pod under test.
+var errNoPodUnderTest = errors.New("no pod under test was found, check the targetPodLabels of the configuration " +
+	"and the labels of the pods")
+
+var (
+	clusterName    string
+	kubeconfigFile string
+	kubeContext    string
+	snapshotFile   string
+
+	discover = &cobra.Command{
+		Use:   "discover [config-file]",
+		Short: "Discovers the resources under test and prints them, without running the suites",
+		Long: `Discovers the resources under test, as the suites would, and prints them as the testTarget section of a
+configuration file, so that the labels and annotations can be checked before a run.  The configuration file is
+$TNF_CONFIGURATION_PATH, or tnf_config.yml, unless set.  The command fails when no pod under test is found.`,
+		Example: `  tnf discover
+  tnf discover tnf_config.yml --cluster edge
+  tnf discover --kubeconfig ~/.kube/lab --snapshot /tmp/discovery-snapshot.json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runDiscovery,
+	}
+)
+
+func runDiscovery(cmd *cobra.Command, args []string) error {
+	filePath := config.GetConfigurationFilePath()
+	if len(args) > 0 {
+		filePath = args[0]
+	}
+	testConfig, err := config.ReadConfigurationFileFrom(filePath)
+	if err != nil {
+		return fmt.Errorf("cannot read the configuration file %s: %w", filePath, err)
+	}
+	if len(testConfig.TargetNameSpaces) != 1 {
+		return fmt.Errorf("a single namespace should be specified in the configuration file %s", filePath)
+	}
+	cluster, err := testConfig.SelectCluster(clusterName)
+	if err != nil {
+		return err
+	}
+	if kubeconfigFile != "" {
+		cluster.Kubeconfig = kubeconfigFile
+	}
+	if kubeContext != "" {
+		cluster.Context = kubeContext
+	}
+	removeKubeconfig, err := config.UseCluster(cluster)
+	if err != nil {
+		return err
+	}
+	defer removeKubeconfig()
+
+	namespace := testConfig.TargetNameSpaces[0].Name
+	target := &testConfig.TestTarget
+	autodiscover.SetTimeouts(testConfig.Timeouts)
+	autodiscover.DetectVersions()
+	autodiscover.DetectFlavor()
+	if autodiscover.PerformAutoDiscovery() {
+		autodiscover.FindTestTarget(testConfig.TargetPodLabels, target, namespace)
+	} else {
+		log.Info("The autodiscovery is disabled, the testTarget section of the configuration is printed as is")
+	}
+	if snapshotFile != "" {
+		autodiscover.FindTestPartner(&testConfig.Partner, namespace)
+		autodiscover.CaptureSnapshot(namespace, target, &testConfig.Partner)
+		if err = autodiscover.SaveSnapshot(snapshotFile); err != nil {
+			return fmt.Errorf("cannot save the discovery snapshot: %w", err)
+		}
+	}
+
+	out, err := yaml.Marshal(struct {
+		TestTarget *configsections.TestTarget `yaml:"testTarget"`
+	}{target})
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(out))
+	log.Infof("Discovered %d pods, %d containers, %d deployments, %d statefulsets, %d operators and %d nodes in the "+
+		"namespace %s", len(target.PodsUnderTest), len(target.ContainerConfigList), len(target.DeploymentsUnderTest),
+		len(target.StatefulSetsUnderTest), len(target.Operators), len(target.Nodes), namespace)
+	if len(target.PodsUnderTest) == 0 {
+		return errNoPodUnderTest
+	}
+	return nil
+}
+
+// NewCommand returns the "discover" command.
+func NewCommand() *cobra.Command {
+	discover.Flags().StringVar(&clusterName, "cluster", "", "name of the cluster to discover among the clusters "+
+		"section of the configuration, overriding targetCluster")
+	discover.Flags().StringVar(&kubeconfigFile, "kubeconfig", "", "kubeconfig file of the cluster to discover, or "+
+		"list of kubeconfig files, overriding the configuration and $KUBECONFIG")
+	discover.Flags().StringVar(&kubeContext, "kube-context", "", "kubeconfig context of the cluster to discover, "+
+		"overriding the configuration and the current context")
+	discover.Flags().StringVar(&snapshotFile, "snapshot", "", "also write the discovery snapshot, with the partner "+
+		"containers, to this file, to be loaded by a run with --discovery-snapshot")
+	return discover
+}
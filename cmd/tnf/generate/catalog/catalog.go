@@ -20,7 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -29,6 +29,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 )
 
 const (
@@ -48,17 +49,17 @@ const (
 
 var (
 	// introMDFile is the path to the file that contains the test case catalog section introductory text for CATALOG.md.
-	introMDFile = path.Join(mdDirectory, introMDFilename)
+	introMDFile = filepath.Join(mdDirectory, introMDFilename)
 
 	// mdDirectory is the path to the directory of files that contain static text for CATALOG.md.
-	mdDirectory = path.Join("cmd", "tnf", "generate", "catalog")
+	mdDirectory = filepath.Join("cmd", "tnf", "generate", "catalog")
 
 	// tccFile is the path to the file that contains the test case catalog section introductory text for CATALOG.md.
-	tccFile = path.Join(mdDirectory, tccFilename)
+	tccFile = filepath.Join(mdDirectory, tccFilename)
 
 	// tccbbFile is the path to the file that contains the test case catalog building blocks section introductory text
 	// for CATALOG.md
-	tccbbFile = path.Join(mdDirectory, tccbbFilename)
+	tccbbFile = filepath.Join(mdDirectory, tccbbFilename)
 
 	// generateCmd is the root of the "catalog generate" CLI program.
 	generateCmd = &cobra.Command{
@@ -116,6 +117,15 @@ func emitTextFromFile(filename string) error {
 	return nil
 }
 
+// joinTags returns the tags separated by commas.
+func joinTags(tags []testcases.Tag) string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, string(tag))
+	}
+	return strings.Join(names, ", ")
+}
+
 // outputTestCases outputs the Markdown representation for test cases from the catalog to stdout.
 func outputTestCases() {
 	// Building a separate data structure to store the key order for the map
@@ -138,6 +148,15 @@ func outputTestCases() {
 		fmt.Fprintf(os.Stdout, "Version|%s\n", identifiers.Catalog[k].Identifier.Version)
 		fmt.Fprintf(os.Stdout, "Description|%s\n", strings.ReplaceAll(identifiers.Catalog[k].Description, "\n", " "))
 		fmt.Fprintf(os.Stdout, "Result Type|%s\n", identifiers.Catalog[k].Type)
+		fmt.Fprintf(os.Stdout, "Classification|%s\n", identifiers.GetClassification(k))
+		fmt.Fprintf(os.Stdout, "Resource Types|%s\n", identifiers.FormatResourceTypes(k))
+		fmt.Fprintf(os.Stdout, "Tags|%s\n", joinTags(identifiers.GetTags(k)))
+		if versions := identifiers.FormatSupportedVersions(k); versions != "" {
+			fmt.Fprintf(os.Stdout, "Supported Versions|%s\n", versions)
+		}
+		if identifiers.RequiresOpenShift(k) {
+			fmt.Fprintf(os.Stdout, "Requires OpenShift|%t\n", true)
+		}
 		fmt.Fprintf(os.Stdout, "Suggested Remediation|%s\n", strings.ReplaceAll(identifiers.Catalog[k].Remediation, "\n", " "))
 		fmt.Fprintf(os.Stdout, "Best Practice Reference|%s\n", strings.ReplaceAll(identifiers.Catalog[k].BestPracticeReference, "\n", " "))
 	}
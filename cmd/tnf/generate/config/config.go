@@ -0,0 +1,306 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package config provides the "tnf generate config" command, an interactive wizard writing a configuration file from
+// the resources of the cluster.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ocBinaryName = "oc"
+	// defaultOutputFile is the configuration file read by the test suites unless $TNF_CONFIGURATION_PATH is set.
+	defaultOutputFile     = "tnf_config.yml"
+	configFilePermissions = 0644
+	// labelPrefixSeparator separates the prefix and the name of a label key.
+	labelPrefixSeparator = "/"
+)
+
+var (
+	outputFile       string
+	namespace        string
+	force            bool
+	systemNamespaces bool
+
+	// systemNamespacePrefixes are the prefixes of the namespaces of the platform, only listed with
+	// --system-namespaces.
+	systemNamespacePrefixes = []string{"openshift", "kube-"}
+	// podSpecificLabels are set by the controllers on each pod, they do not select the pods of a CNF.
+	podSpecificLabels = map[string]bool{
+		"controller-revision-hash":           true,
+		"pod-template-generation":            true,
+		"pod-template-hash":                  true,
+		"statefulset.kubernetes.io/pod-name": true,
+	}
+
+	generateConfig = &cobra.Command{
+		Use:   "config",
+		Short: "Interactively generates a configuration file from the resources of the cluster",
+		Long: `Interactively generates a configuration file from the resources of the cluster:  the namespace under test,
+the labels selecting the pods under test and the operators under test are picked from menus listing the namespaces,
+the labels of their pods and their ClusterServiceVersions.  The configuration file is written to tnf_config.yml unless
+set, the other sections keep their defaults and can be added later.`,
+		Example: `  tnf generate config
+  tnf generate config --namespace my-cnf --output test-network-function/tnf_config.yml --force`,
+		Args: cobra.NoArgs,
+		RunE: runGenerateConfig,
+	}
+)
+
+// resourceList is the output of "oc get -o json", with the fields read by the wizard.
+type resourceList struct {
+	Items []struct {
+		Metadata struct {
+			Name   string            `json:"name"`
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		// Spec is read for the package of the subscriptions.
+		Spec struct {
+			Name string `json:"name"`
+		} `json:"spec"`
+		// Status is read for the CSV installed by the subscriptions.
+		Status struct {
+			InstalledCSV string `json:"installedCSV"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// generatedConfig is the configuration written by the wizard.
+type generatedConfig struct {
+	TargetNameSpaces []configsections.Namespace `yaml:"targetNameSpaces"`
+	TargetPodLabels  []configsections.Label     `yaml:"targetPodLabels,omitempty"`
+	TestTarget       *generatedTestTarget       `yaml:"testTarget,omitempty"`
+}
+
+// generatedTestTarget holds the operators under test, the pods are discovered with the targetPodLabels.
+type generatedTestTarget struct {
+	Operators []configsections.Operator `yaml:"operators"`
+}
+
+// getResources runs "oc get <kind> -o json", in namespace unless empty.
+func getResources(kind, namespace string) (*resourceList, error) {
+	args := []string{"get", kind, "-o", "json"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+	out, err := exec.Command(ocBinaryName, args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", ocBinaryName, strings.Join(args, " "), err)
+	}
+	list := &resourceList{}
+	if err = json.Unmarshal(out, list); err != nil {
+		return nil, fmt.Errorf("cannot parse the output of %s %s: %w", ocBinaryName, strings.Join(args, " "), err)
+	}
+	return list, nil
+}
+
+// isSystemNamespace returns true for the namespaces of the platform.
+func isSystemNamespace(name string) bool {
+	for _, prefix := range systemNamespacePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// chooseNamespace returns the namespace under test, set by --namespace or chosen among the namespaces of the cluster.
+func chooseNamespace(p *prompter) (string, error) {
+	if namespace != "" {
+		return namespace, nil
+	}
+	list, err := getResources("namespaces", "")
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for i := range list.Items {
+		if name := list.Items[i].Metadata.Name; systemNamespaces || !isSystemNamespace(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("no namespace found, see --system-namespaces")
+	}
+	sort.Strings(names)
+	choice, err := p.chooseOne("Namespace under test:", names)
+	if err != nil {
+		return "", err
+	}
+	return names[choice], nil
+}
+
+// choosePodLabels returns the labels selecting the pods under test, chosen among the labels of the pods of the
+// namespace.
+func choosePodLabels(p *prompter, namespace string) ([]configsections.Label, error) {
+	list, err := getResources("pods", namespace)
+	if err != nil {
+		return nil, err
+	}
+	podCounts := map[string]int{}
+	for i := range list.Items {
+		for key, value := range list.Items[i].Metadata.Labels {
+			if !podSpecificLabels[key] {
+				podCounts[key+"="+value]++
+			}
+		}
+	}
+	if len(podCounts) == 0 {
+		fmt.Fprintf(p.out, "No labeled pod in %s, label the pods under test, e.g. with \"tnf annotate pod\"\n", namespace)
+		return nil, nil
+	}
+	var labels, options []string
+	for label := range podCounts {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		options = append(options, fmt.Sprintf("%s (pods: %d)", label, podCounts[label]))
+	}
+	choices, err := p.chooseMany("Labels selecting the pods under test:", options)
+	if err != nil {
+		return nil, err
+	}
+	var podLabels []configsections.Label
+	for _, choice := range choices {
+		podLabels = append(podLabels, parseLabel(labels[choice]))
+	}
+	return podLabels, nil
+}
+
+// parseLabel parses a "[prefix/]name=value" label.
+func parseLabel(label string) configsections.Label {
+	parts := strings.SplitN(label, "=", 2)
+	key, value := parts[0], parts[1]
+	if i := strings.LastIndex(key, labelPrefixSeparator); i >= 0 {
+		return configsections.Label{Prefix: key[:i], Name: key[i+1:], Value: value}
+	}
+	return configsections.Label{Name: key, Value: value}
+}
+
+// chooseOperators returns the operators under test, chosen among the CSVs of the namespace.  No operator is returned
+// when the cluster has no OLM.
+func chooseOperators(p *prompter, namespace string) ([]configsections.Operator, error) {
+	csvs, err := getResources("csv", namespace)
+	if err != nil {
+		fmt.Fprintf(p.out, "Skipping the operators, the ClusterServiceVersions cannot be listed: %v\n", err)
+		return nil, nil
+	}
+	if len(csvs.Items) == 0 {
+		return nil, nil
+	}
+	var names []string
+	for i := range csvs.Items {
+		names = append(names, csvs.Items[i].Metadata.Name)
+	}
+	sort.Strings(names)
+	choices, err := p.chooseMany("Operators under test:", names)
+	if err != nil || len(choices) == 0 {
+		return nil, err
+	}
+	// the subscription of an operator is the one which installed its CSV.
+	subscriptions, err := getResources("subscriptions.operators.coreos.com", namespace)
+	if err != nil {
+		return nil, err
+	}
+	var operators []configsections.Operator
+	for _, choice := range choices {
+		operator := configsections.Operator{Name: names[choice], Namespace: namespace, Tests: []string{}}
+		for i := range subscriptions.Items {
+			if subscription := &subscriptions.Items[i]; subscription.Status.InstalledCSV == operator.Name {
+				operator.SubscriptionName = subscription.Metadata.Name
+				operator.Package = subscription.Spec.Name
+			}
+		}
+		if operator.SubscriptionName == "" {
+			fmt.Fprintf(p.out, "No subscription installed %s, set its subscriptionName in the configuration\n",
+				operator.Name)
+		}
+		operators = append(operators, operator)
+	}
+	return operators, nil
+}
+
+// marshalConfig renders the generated configuration, checking the test suites read it.
+func marshalConfig(generated *generatedConfig) ([]byte, error) {
+	out, err := yaml.Marshal(generated)
+	if err != nil {
+		return nil, err
+	}
+	var testConfig configsections.TestConfiguration
+	if err = yaml.UnmarshalStrict(out, &testConfig); err != nil {
+		return nil, fmt.Errorf("the generated configuration is invalid: %w", err)
+	}
+	return out, nil
+}
+
+func runGenerateConfig(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(outputFile); err == nil && !force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", outputFile)
+	}
+	p := newPrompter(os.Stdin, os.Stdout)
+	targetNamespace, err := chooseNamespace(p)
+	if err != nil {
+		return err
+	}
+	generated := &generatedConfig{TargetNameSpaces: []configsections.Namespace{{Name: targetNamespace}}}
+	if generated.TargetPodLabels, err = choosePodLabels(p, targetNamespace); err != nil {
+		return err
+	}
+	operators, err := chooseOperators(p, targetNamespace)
+	if err != nil {
+		return err
+	}
+	if len(operators) != 0 {
+		generated.TestTarget = &generatedTestTarget{Operators: operators}
+	}
+	out, err := marshalConfig(generated)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(p.out, "\n%s\n", out)
+	write, err := p.confirm(fmt.Sprintf("Write the configuration to %s?", outputFile))
+	if err != nil || !write {
+		return err
+	}
+	if err = os.WriteFile(outputFile, out, configFilePermissions); err != nil {
+		return err
+	}
+	fmt.Fprintf(p.out, "Wrote %s, check the pods it selects with \"tnf discover %s\"\n", outputFile, outputFile)
+	return nil
+}
+
+// NewCommand returns the "generate config" command.
+func NewCommand() *cobra.Command {
+	generateConfig.Flags().StringVarP(&outputFile, "output", "o", defaultOutputFile, "configuration file to write")
+	generateConfig.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace under test, chosen from a menu "+
+		"unless set")
+	generateConfig.Flags().BoolVar(&force, "force", false, "overwrite an existing configuration file")
+	generateConfig.Flags().BoolVar(&systemNamespaces, "system-namespaces", false, "also list the namespaces of the "+
+		"platform, e.g. openshift-* and kube-*")
+	return generateConfig
+}
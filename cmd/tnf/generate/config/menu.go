@@ -0,0 +1,151 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// allChoice selects all the options of a multiple choice menu.
+	allChoice = "all"
+	// rangeSeparator separates the bounds of a range of options, e.g. 2-4.
+	rangeSeparator = "-"
+	// rangeParts is the number of bounds of a range of options.
+	rangeParts = 2
+)
+
+var (
+	// errInvalidChoice is returned for an answer which does not select valid options.
+	errInvalidChoice = errors.New("invalid choice")
+	// errNoAnswer is returned when the input is closed before a question is answered.
+	errNoAnswer = errors.New("the input ended before the question was answered")
+)
+
+// prompter asks the questions of the wizard, the invalid answers are asked again.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(in), out: out}
+}
+
+// readLine reads an answer, errNoAnswer once the input is closed.
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if errors.Is(err, io.EOF) && line == "" {
+		return "", errNoAnswer
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// printOptions prints the numbered options of a menu.
+func (p *prompter) printOptions(title string, options []string) {
+	fmt.Fprintln(p.out, title)
+	for i, option := range options {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, option)
+	}
+}
+
+// chooseOne returns the index of the option chosen in a menu.
+func (p *prompter) chooseOne(title string, options []string) (int, error) {
+	p.printOptions(title, options)
+	for {
+		fmt.Fprint(p.out, "Choose one: ")
+		answer, err := p.readLine()
+		if err != nil {
+			return 0, err
+		}
+		choice, err := strconv.Atoi(answer)
+		if err == nil && choice >= 1 && choice <= len(options) {
+			return choice - 1, nil
+		}
+		fmt.Fprintf(p.out, "Enter a number between 1 and %d\n", len(options))
+	}
+}
+
+// chooseMany returns the indexes of the options chosen in a menu, none when the answer is empty.
+func (p *prompter) chooseMany(title string, options []string) ([]int, error) {
+	p.printOptions(title, options)
+	for {
+		fmt.Fprint(p.out, "Choose any, e.g. 1,3-4, \"all\", or none: ")
+		answer, err := p.readLine()
+		if err != nil {
+			return nil, err
+		}
+		choices, err := parseChoices(answer, len(options))
+		if err == nil {
+			return choices, nil
+		}
+		fmt.Fprintln(p.out, err)
+	}
+}
+
+// confirm asks a yes/no question, no being the default.
+func (p *prompter) confirm(question string) (bool, error) {
+	fmt.Fprintf(p.out, "%s [y/N]: ", question)
+	answer, err := p.readLine()
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// parseChoices parses the numbers and ranges of options separated by commas or spaces, or allChoice, into the sorted
+// indexes of the options.
+func parseChoices(answer string, count int) ([]int, error) {
+	if strings.EqualFold(answer, allChoice) {
+		choices := make([]int, count)
+		for i := range choices {
+			choices[i] = i
+		}
+		return choices, nil
+	}
+	chosen := make([]bool, count)
+	for _, field := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }) {
+		bounds := strings.SplitN(field, rangeSeparator, rangeParts)
+		first, err := strconv.Atoi(bounds[0])
+		last := first
+		if err == nil && len(bounds) == rangeParts {
+			last, err = strconv.Atoi(bounds[1])
+		}
+		if err != nil || first < 1 || last > count || first > last {
+			return nil, fmt.Errorf("%w %q, enter numbers between 1 and %d", errInvalidChoice, field, count)
+		}
+		for i := first; i <= last; i++ {
+			chosen[i-1] = true
+		}
+	}
+	var choices []int
+	for i, isChosen := range chosen {
+		if isChosen {
+			choices = append(choices, i)
+		}
+	}
+	return choices, nil
+}
@@ -0,0 +1,260 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package handler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+	resultError   = "error"
+
+	testParameterPrefix = "test"
+	dependenciesPackage = "dependencies."
+	binaryNameSuffix    = "BinaryName"
+)
+
+var (
+	// goIdentifierRegex matches the names usable for handlers, parameters and patterns.
+	goIdentifierRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+	// parameterReferenceRegex matches the "${name}" references to parameters in the command of a description.
+	parameterReferenceRegex = regexp.MustCompile(`\$\{([A-Za-z][A-Za-z0-9]*)\}`)
+
+	// results maps the results of a description to the tnf results.
+	results = map[string]string{
+		resultSuccess: "tnf.SUCCESS",
+		resultFailure: "tnf.FAILURE",
+		resultError:   "tnf.ERROR",
+	}
+)
+
+// handlerDescription is the short YAML description a handler is scaffolded from.
+type handlerDescription struct {
+	// Name is the name of the handler type, e.g. "NodeSelector".  The package is named after it in lowercase.
+	Name string `yaml:"name"`
+	// Description completes the sentence "<Name> provides a test which ...".
+	Description string `yaml:"description"`
+	// Parameters are the names of the string parameters of the command.
+	Parameters []string `yaml:"parameters"`
+	// Command is the command line of the test.  "${parameter}" references are substituted with the parameters.
+	Command []string `yaml:"command"`
+	// Patterns are the regular expressions expected from the command, in order.
+	Patterns []patternDescription `yaml:"patterns"`
+	// BinaryDependencies are the binaries needed by the test, recorded in the test catalog.
+	BinaryDependencies []string `yaml:"binaryDependencies"`
+	// ModifiesSystem records whether the test makes changes to the target system.
+	ModifiesSystem bool `yaml:"modifiesSystem"`
+}
+
+// patternDescription describes a regular expression expected from the command, and the result of matching it.
+type patternDescription struct {
+	// Name is the name of the pattern;  the pattern is exported as the "<Name>Regex" constant.
+	Name string `yaml:"name"`
+	// Regex is the regular expression.
+	Regex string `yaml:"regex"`
+	// Result is the test result on match:  success, failure or error.
+	Result string `yaml:"result"`
+}
+
+// handlerPattern is a pattern as rendered by the handler templates.
+type handlerPattern struct {
+	ConstName    string
+	RegexLiteral string
+	Result       string
+}
+
+// loadHandlerDescription reads and validates the handler description file, whose name is replaced by name unless
+// empty.
+func loadHandlerDescription(file, name string) (*handlerDescription, error) {
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	description := &handlerDescription{}
+	if err = yaml.UnmarshalStrict(contents, description); err != nil {
+		return nil, fmt.Errorf("cannot parse the handler description %s: %s", file, err)
+	}
+	if name != "" {
+		description.Name = name
+	}
+	if err = description.validate(); err != nil {
+		return nil, fmt.Errorf("invalid handler description %s: %s", file, err)
+	}
+	return description, nil
+}
+
+// validate checks that the description can be rendered into a handler which builds.
+func (d *handlerDescription) validate() error {
+	if !goIdentifierRegex.MatchString(d.Name) {
+		return fmt.Errorf("the name \"%s\" is not an alphanumeric Go identifier", d.Name)
+	}
+	if d.Description == "" {
+		return fmt.Errorf("the description is missing")
+	}
+	parameters := map[string]bool{}
+	for _, parameter := range d.Parameters {
+		if !goIdentifierRegex.MatchString(parameter) {
+			return fmt.Errorf("the parameter \"%s\" is not an alphanumeric Go identifier", parameter)
+		}
+		if parameters[parameter] {
+			return fmt.Errorf("the parameter \"%s\" is declared twice", parameter)
+		}
+		parameters[parameter] = true
+	}
+	if len(d.Command) == 0 {
+		return fmt.Errorf("the command is missing")
+	}
+	for _, arg := range d.Command {
+		for _, reference := range parameterReferenceRegex.FindAllStringSubmatch(arg, -1) {
+			if !parameters[reference[1]] {
+				return fmt.Errorf("the command references the undeclared parameter \"%s\"", reference[1])
+			}
+		}
+	}
+	if len(d.Patterns) == 0 {
+		return fmt.Errorf("at least one pattern is needed")
+	}
+	for _, pattern := range d.Patterns {
+		if !goIdentifierRegex.MatchString(pattern.Name) {
+			return fmt.Errorf("the pattern name \"%s\" is not an alphanumeric Go identifier", pattern.Name)
+		}
+		if _, err := regexp.Compile(pattern.Regex); err != nil {
+			return fmt.Errorf("the pattern %s is not a valid regular expression: %s", pattern.Name, err)
+		}
+		if _, ok := results[pattern.Result]; !ok {
+			return fmt.Errorf("the result \"%s\" of pattern %s is not one of %s, %s or %s", pattern.Result, pattern.Name,
+				resultSuccess, resultFailure, resultError)
+		}
+	}
+	return nil
+}
+
+// newDescribedHandler builds the template data of a handler from its description.  binaryNames maps the binary names
+// to the names of the constants of the dependencies package, so that the generated code refers to the constants.
+func newDescribedHandler(d *handlerDescription, binaryNames map[string]string) myHandler {
+	h := myHandler{
+		UpperHandlername: strings.Title(d.Name),
+		LowerHandlername: strings.ToLower(d.Name),
+		Description:      strings.TrimSuffix(strings.TrimSpace(d.Description), "."),
+		Parameters:       strings.Join(d.Parameters, ", "),
+		ModifiesSystem:   d.ModifiesSystem,
+	}
+
+	testParameters := map[string]string{}
+	var testArgs []string
+	for _, parameter := range d.Parameters {
+		constName := testParameterPrefix + strings.Title(parameter)
+		value := testParameterPrefix + "-" + strings.ToLower(parameter)
+		h.TestParameters = append(h.TestParameters, testParameter{ConstName: constName, Value: strconv.Quote(value)})
+		testParameters[parameter] = value
+		testArgs = append(testArgs, constName)
+	}
+	h.TestArgs = strings.Join(testArgs, ", ")
+
+	var expectedCommand []string
+	for i, arg := range d.Command {
+		h.CommandArgs = append(h.CommandArgs, commandArgExpression(arg, i == 0, binaryNames))
+		expectedCommand = append(expectedCommand, parameterReferenceRegex.ReplaceAllStringFunc(arg, func(reference string) string {
+			return testParameters[parameterReferenceRegex.FindStringSubmatch(reference)[1]]
+		}))
+	}
+	h.ExpectedCommand = stringLiteral(strings.Join(expectedCommand, " "))
+	h.UsesDependencies = strings.HasPrefix(h.CommandArgs[0], dependenciesPackage)
+
+	for _, pattern := range d.Patterns {
+		h.Patterns = append(h.Patterns, handlerPattern{
+			ConstName:    strings.Title(pattern.Name) + "Regex",
+			RegexLiteral: stringLiteral(pattern.Regex),
+			Result:       results[pattern.Result],
+		})
+	}
+
+	for _, binary := range d.BinaryDependencies {
+		if constName, ok := binaryNames[binary]; ok {
+			h.BinaryDependencies = append(h.BinaryDependencies, dependenciesPackage+constName)
+		} else {
+			h.BinaryDependencies = append(h.BinaryDependencies, strconv.Quote(binary))
+		}
+	}
+	return h
+}
+
+// commandArgExpression returns the Go expression of a command line argument:  the binary is the dependencies constant
+// when one exists, and "${parameter}" references are concatenated with the literal parts.
+func commandArgExpression(arg string, isBinary bool, binaryNames map[string]string) string {
+	if constName, ok := binaryNames[arg]; ok && isBinary {
+		return dependenciesPackage + constName
+	}
+	var parts []string
+	last := 0
+	for _, indexes := range parameterReferenceRegex.FindAllStringSubmatchIndex(arg, -1) {
+		if indexes[0] > last {
+			parts = append(parts, stringLiteral(arg[last:indexes[0]]))
+		}
+		parts = append(parts, arg[indexes[2]:indexes[3]])
+		last = indexes[1]
+	}
+	if last < len(arg) || len(parts) == 0 {
+		parts = append(parts, stringLiteral(arg[last:]))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// stringLiteral returns s as a raw string literal when possible, which keeps regular expressions readable.
+func stringLiteral(s string) string {
+	if strings.ContainsAny(s, "`\r\n") || !strings.ContainsAny(s, `\"`) {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// loadBinaryNames maps the binary names to the names of the "...BinaryName" constants of the dependencies package.
+func loadBinaryNames(dependenciesDirectory string) (map[string]string, error) {
+	packages, err := parser.ParseDir(token.NewFileSet(), dependenciesDirectory, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	binaryNames := map[string]string{}
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				spec, ok := node.(*ast.ValueSpec)
+				if !ok || len(spec.Names) != 1 || len(spec.Values) != 1 || !strings.HasSuffix(spec.Names[0].Name, binaryNameSuffix) {
+					return true
+				}
+				if literal, isLiteral := spec.Values[0].(*ast.BasicLit); isLiteral && literal.Kind == token.STRING {
+					if value, unquoteErr := strconv.Unquote(literal.Value); unquoteErr == nil {
+						binaryNames[value] = spec.Names[0].Name
+					}
+				}
+				return true
+			})
+		}
+	}
+	return binaryNames, nil
+}
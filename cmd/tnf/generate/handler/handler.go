@@ -2,8 +2,11 @@ package handler
 
 import (
 	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 	"text/template"
 
@@ -14,21 +17,62 @@ import (
 type myHandler struct {
 	UpperHandlername string
 	LowerHandlername string
+
+	// The following fields are only set for handlers scaffolded from a description, see handlerDescription.
+	Description        string
+	Parameters         string
+	CommandArgs        []string
+	UsesDependencies   bool
+	Patterns           []handlerPattern
+	TestParameters     []testParameter
+	TestArgs           string
+	ExpectedCommand    string
+	BinaryDependencies []string
+	ModifiesSystem     bool
+}
+
+// testParameter is a constant used as a parameter value by the generated unit test.
+type testParameter struct {
+	ConstName string
+	Value     string
+}
+
+// IdentifierURLName returns the name of the constant holding the URL of the identifier of the handler.
+func (h myHandler) IdentifierURLName() string {
+	return strings.ToLower(h.UpperHandlername[:1]) + h.UpperHandlername[1:] + "IdentifierURL"
+}
+
+// IdentifierURL returns the URL of the identifier of the handler.
+func (h myHandler) IdentifierURL() string {
+	return identifierURLPrefix + h.LowerHandlername
+}
+
+// CatalogDescription returns the description of the handler in the test catalog.
+func (h myHandler) CatalogDescription() string {
+	return "A test which " + h.Description + "."
 }
 
 const (
-	envHandlersFolder  = "TNF_HANDLERS_SRC"
-	docFileName        = "doc.go"
-	handlerFolderPerms = 0755
+	envHandlersFolder    = "TNF_HANDLERS_SRC"
+	docFileName          = "doc.go"
+	handlerFolderPerms   = 0755
+	identifiersFilePerms = 0644
+	fromFlagName         = "from"
 )
 
 var (
 	handler = &cobra.Command{
-		Use:   "handler",
+		Use:   "handler [name]",
 		Short: "adding new handler.",
-		RunE:  generateHandlerFiles,
+		Long: `Adds a new handler package.  With --from, the handler is scaffolded from a short YAML description:  the
+command builder, the ReelFirst/ReelMatch skeleton, a unit test and the identifier registration are generated.`,
+		Example: "tnf generate handler MyHandler\ntnf generate handler --from examples/handler/priorityclass.yaml",
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    generateHandlerFiles,
 	}
-	defaultHandlersFolder = path.Join("pkg", "tnf", "handlers")
+	defaultHandlersFolder = filepath.Join("pkg", "tnf", "handlers")
+
+	descriptionFile string
 )
 
 func getHandlersDirectory() (string, error) {
@@ -44,28 +88,32 @@ func getHandlersDirectory() (string, error) {
 	}
 
 	// Convert to absolute path.
-	if !path.IsAbs(handlersDirectory) {
+	if !filepath.IsAbs(handlersDirectory) {
 		cwd, err := os.Getwd()
 		if err != nil {
 			return "", err
 		}
 
-		handlersDirectory = path.Join(cwd, handlersDirectory)
+		handlersDirectory = filepath.Join(cwd, handlersDirectory)
 	}
 
 	return handlersDirectory, nil
 }
 
-func generateHandlerFilesFromTemplates(handlerTemplatesDirectory, newHandlerDirectory string, myhandler myHandler) error {
+func generateHandlerFilesFromTemplates(handlerTemplatesDirectory, newHandlerDirectory string, myhandler myHandler, described bool) error {
 	type fileToRender struct {
 		templatePath     string
 		renderedFileName string
 	}
 
+	handlerTemplate, handlerTestTemplate := "handler.tmpl", "handler_test.tmpl"
+	if described {
+		handlerTemplate, handlerTestTemplate = "described_handler.tmpl", "described_handler_test.tmpl"
+	}
 	filesToRender := []fileToRender{
-		{templatePath: path.Join(handlerTemplatesDirectory, "doc.tmpl"), renderedFileName: docFileName},
-		{templatePath: path.Join(handlerTemplatesDirectory, "handler_test.tmpl"), renderedFileName: myhandler.LowerHandlername + "_test.go"},
-		{templatePath: path.Join(handlerTemplatesDirectory, "handler.tmpl"), renderedFileName: myhandler.LowerHandlername + ".go"},
+		{templatePath: filepath.Join(handlerTemplatesDirectory, "doc.tmpl"), renderedFileName: docFileName},
+		{templatePath: filepath.Join(handlerTemplatesDirectory, handlerTestTemplate), renderedFileName: myhandler.LowerHandlername + "_test.go"},
+		{templatePath: filepath.Join(handlerTemplatesDirectory, handlerTemplate), renderedFileName: myhandler.LowerHandlername + ".go"},
 	}
 
 	for _, renderedFileName := range filesToRender {
@@ -79,19 +127,39 @@ func generateHandlerFilesFromTemplates(handlerTemplatesDirectory, newHandlerDire
 }
 
 func generateHandlerFiles(cmd *cobra.Command, args []string) error {
-	handlername := args[0]
-	myhandler := myHandler{LowerHandlername: strings.ToLower(handlername), UpperHandlername: strings.Title(handlername)}
-
 	handlersDirectory, err := getHandlersDirectory()
 	if err != nil {
 		log.Fatalf("Unable to get handlers path.")
 		return err
 	}
 
-	handlerTemplatesDirectory := path.Join(handlersDirectory, "handler_template")
+	var myhandler myHandler
+	described := descriptionFile != ""
+	switch {
+	case described:
+		var name string
+		if len(args) > 0 {
+			name = args[0]
+		}
+		description, loadErr := loadHandlerDescription(descriptionFile, name)
+		if loadErr != nil {
+			return loadErr
+		}
+		binaryNames, loadErr := loadBinaryNames(filepath.Join(handlersDirectory, "..", "dependencies"))
+		if loadErr != nil {
+			return loadErr
+		}
+		myhandler = newDescribedHandler(description, binaryNames)
+	case len(args) > 0:
+		myhandler = myHandler{LowerHandlername: strings.ToLower(args[0]), UpperHandlername: strings.Title(args[0])}
+	default:
+		return fmt.Errorf("either the handler name or --%s is needed", fromFlagName)
+	}
+
+	handlerTemplatesDirectory := filepath.Join(handlersDirectory, "handler_template")
 
 	log.Infof("Using absolute path for tnf handlers directory: %s", handlersDirectory)
-	newHandlerDirectory := path.Join(handlersDirectory, myhandler.LowerHandlername)
+	newHandlerDirectory := filepath.Join(handlersDirectory, myhandler.LowerHandlername)
 
 	err = os.Mkdir(newHandlerDirectory, handlerFolderPerms)
 	if err != nil {
@@ -99,12 +167,21 @@ func generateHandlerFiles(cmd *cobra.Command, args []string) error {
 		os.Exit(1)
 	}
 
-	err = generateHandlerFilesFromTemplates(handlerTemplatesDirectory, newHandlerDirectory, myhandler)
+	err = generateHandlerFilesFromTemplates(handlerTemplatesDirectory, newHandlerDirectory, myhandler, described)
 	if err != nil {
 		return err
 	}
 
-	log.Infof("Handler files for %s successfully created in %s\n", myhandler.UpperHandlername, path.Join(newHandlerDirectory))
+	if described {
+		identifiersFile := filepath.Join(handlersDirectory, "..", "identifier", "identifiers.go")
+		if err = registerIdentifier(identifiersFile, myhandler); err != nil {
+			return err
+		}
+		log.Infof("Identifier %sIdentifier registered in %s, regenerate CATALOG.md with \"make build-catalog-md\"",
+			myhandler.UpperHandlername, identifiersFile)
+	}
+
+	log.Infof("Handler files for %s successfully created in %s\n", myhandler.UpperHandlername, filepath.Join(newHandlerDirectory))
 	return nil
 }
 
@@ -114,7 +191,18 @@ func createfile(templateFilePath, outputFileName string, myhandler myHandler, ne
 		return err
 	}
 
-	temp := path.Join(newHandlerDirectory, outputFileName)
+	var buf bytes.Buffer
+	err = ftpl.Execute(&buf, myhandler)
+	if err != nil {
+		return err
+	}
+	// The rendered files are gofmt-ed, so that the templates do not need to care about alignment.
+	rendered, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("the rendered file %s is not valid Go: %s", outputFileName, err)
+	}
+
+	temp := filepath.Join(newHandlerDirectory, outputFileName)
 	f, err := os.Create(temp)
 	if err != nil {
 		return err
@@ -123,7 +211,7 @@ func createfile(templateFilePath, outputFileName string, myhandler myHandler, ne
 	defer f.Close()
 	w := bufio.NewWriter(f)
 
-	err = ftpl.Execute(w, myhandler)
+	_, err = w.Write(rendered)
 	if err != nil {
 		return err
 	}
@@ -133,5 +221,6 @@ func createfile(templateFilePath, outputFileName string, myhandler myHandler, ne
 }
 
 func NewCommand() *cobra.Command {
+	handler.Flags().StringVar(&descriptionFile, fromFlagName, "", "scaffold the handler from the given YAML description")
 	return handler
 }
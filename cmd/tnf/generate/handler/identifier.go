@@ -0,0 +1,125 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+const (
+	identifierURLPrefix = "http://test-network-function.com/tests/"
+	catalogDeclaration  = "var Catalog = map[string]TestCatalogEntry{\n"
+	declarationEnd      = "\n}\n"
+)
+
+// versionOneRegex matches the declaration of the versionOne constant, which closes the identifier URL constants.
+var versionOneRegex = regexp.MustCompile(`(?m)^\tversionOne\s+=`)
+
+// identifierURLTemplate, catalogEntryTemplate and identifierTemplate render the declarations registering the
+// identifier of a handler, in the layout of identifiers.go.
+var (
+	identifierURLTemplate = template.Must(template.New("url").Parse(
+		"\t{{ .IdentifierURLName }} = \"{{ .IdentifierURL }}\"\n"))
+	catalogEntryTemplate = template.Must(template.New("entry").Parse(`	{{ .IdentifierURLName }}: {
+		Identifier:  {{ .UpperHandlername }}Identifier,
+		Description: {{ printf "%q" .CatalogDescription }},
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           {{ .ModifiesSystem }},
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+{{- range .BinaryDependencies }}
+			{{ . }},
+{{- end }}
+		},
+	},
+`))
+	identifierTemplate = template.Must(template.New("identifier").Parse(`
+// {{ .UpperHandlername }}Identifier is the Identifier used to represent the {{ .LowerHandlername }} test.
+var {{ .UpperHandlername }}Identifier = Identifier{
+	URL:             {{ .IdentifierURLName }},
+	SemanticVersion: versionOne,
+}
+`))
+)
+
+// renderDeclaration renders one of the identifier templates.
+func renderDeclaration(tpl *template.Template, myhandler myHandler) (string, error) {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, myhandler); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// registerIdentifier adds the identifier URL constant, the test catalog entry and the Identifier of the handler to
+// identifiersFile.
+func registerIdentifier(identifiersFile string, myhandler myHandler) error {
+	contents, err := os.ReadFile(identifiersFile)
+	if err != nil {
+		return err
+	}
+	source := string(contents)
+	if strings.Contains(source, "var "+myhandler.UpperHandlername+"Identifier ") ||
+		strings.Contains(source, "\t"+myhandler.IdentifierURLName()+" ") {
+		return fmt.Errorf("the identifier of %s is already registered in %s", myhandler.UpperHandlername, identifiersFile)
+	}
+
+	urlDeclaration, err := renderDeclaration(identifierURLTemplate, myhandler)
+	if err != nil {
+		return err
+	}
+	versionOne := versionOneRegex.FindStringIndex(source)
+	if versionOne == nil {
+		return fmt.Errorf("cannot find the identifier URL constants in %s", identifiersFile)
+	}
+	source = source[:versionOne[0]] + urlDeclaration + source[versionOne[0]:]
+
+	catalogEntry, err := renderDeclaration(catalogEntryTemplate, myhandler)
+	if err != nil {
+		return err
+	}
+	catalogStart := strings.Index(source, catalogDeclaration)
+	if catalogStart < 0 {
+		return fmt.Errorf("cannot find the test catalog in %s", identifiersFile)
+	}
+	catalogLength := strings.Index(source[catalogStart:], declarationEnd)
+	if catalogLength < 0 {
+		return fmt.Errorf("cannot find the end of the test catalog in %s", identifiersFile)
+	}
+	catalogEnd := catalogStart + catalogLength + 1
+	source = source[:catalogEnd] + catalogEntry + source[catalogEnd:]
+
+	identifierDeclaration, err := renderDeclaration(identifierTemplate, myhandler)
+	if err != nil {
+		return err
+	}
+	source += identifierDeclaration
+
+	formatted, err := format.Source([]byte(source))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(identifiersFile, formatted, identifiersFilePerms)
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package images provides the "tnf images" commands, listing and checking the auxiliary images of the test suites.
+package images
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/images"
+)
+
+var (
+	imagesCmd = &cobra.Command{
+		Use:   "images",
+		Short: "Lists and checks the auxiliary images the test suites may deploy",
+	}
+
+	list = &cobra.Command{
+		Use:   "list",
+		Short: "Lists the auxiliary images, with the TNF_PARTNER_REPO and TNF_OC_DEBUG_IMAGE_ID overrides applied",
+		Args:  cobra.NoArgs,
+		RunE:  listImages,
+	}
+
+	check = &cobra.Command{
+		Use:   "check",
+		Short: `Checks the auxiliary images can be pulled, using "oc image info" and the local registry credentials`,
+		Args:  cobra.NoArgs,
+		RunE:  checkImages,
+	}
+)
+
+func listImages(cmd *cobra.Command, args []string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tREFERENCE\tDESCRIPTION")
+	for _, image := range images.GetManifest() {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", image.Name, image.Reference, image.Description)
+	}
+	return w.Flush()
+}
+
+func checkImages(cmd *cobra.Command, args []string) error {
+	manifest := images.GetManifest()
+	errs := images.Check(manifest, images.OcImageInfo)
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d images cannot be pulled", len(errs), len(manifest))
+	}
+	fmt.Printf("all %d images can be pulled\n", len(manifest))
+	return nil
+}
+
+// NewCommand returns the "images" command.
+func NewCommand() *cobra.Command {
+	imagesCmd.AddCommand(list)
+	imagesCmd.AddCommand(check)
+	return imagesCmd
+}
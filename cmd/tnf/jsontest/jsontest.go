@@ -17,11 +17,12 @@
 package jsontest
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
 
 	expect "github.com/google/goexpect"
 	"github.com/google/goterm/term"
@@ -135,10 +136,10 @@ var (
 	}
 
 	// genericTestSchemaPath is the path to the generic-test.schema.json JSON schema relative to the program entrypoint.
-	genericTestSchemaPath = path.Join("schemas", generic.TestSchemaFileName)
+	genericTestSchemaPath = filepath.Join("schemas", generic.TestSchemaFileName)
 
 	// ptySchemaPath is the path to the generic-pty.schema.json JSON schema relative to the program entrypoint.
-	ptySchemaPath = path.Join("schemas", interactive.PTYSchemaFileName)
+	ptySchemaPath = filepath.Join("schemas", interactive.PTYSchemaFileName)
 )
 
 // fatalError reports a fatal error to stdout and exits.
@@ -149,7 +150,7 @@ func fatalError(msg string, err error, exitCode int) {
 
 // createTest creates a tnf.Test using tester and handlers in the expecter context.
 func createTest(expecter *expect.Expecter, tester *tnf.Tester, handlers []reel.Handler, ch <-chan error) (*tnf.Test, error) {
-	return tnf.NewTest(expecter, *tester, handlers, ch)
+	return tnf.NewTest(context.Background(), expecter, *tester, handlers, ch)
 }
 
 // reportResults is a helper function used to log results to the console.
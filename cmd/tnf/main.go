@@ -5,16 +5,30 @@ import (
 	"github.com/spf13/cobra"
 
 	claim "github.com/test-network-function/test-network-function/cmd/tnf/addclaim"
-	"github.com/test-network-function/test-network-function/cmd/tnf/generate/catalog"
+	"github.com/test-network-function/test-network-function/cmd/tnf/analyze"
+	"github.com/test-network-function/test-network-function/cmd/tnf/annotate"
+	"github.com/test-network-function/test-network-function/cmd/tnf/catalog"
+	"github.com/test-network-function/test-network-function/cmd/tnf/cleanup"
+	"github.com/test-network-function/test-network-function/cmd/tnf/config"
+	"github.com/test-network-function/test-network-function/cmd/tnf/discover"
+	generatecatalog "github.com/test-network-function/test-network-function/cmd/tnf/generate/catalog"
+	generateconfig "github.com/test-network-function/test-network-function/cmd/tnf/generate/config"
 	"github.com/test-network-function/test-network-function/cmd/tnf/generate/handler"
 	"github.com/test-network-function/test-network-function/cmd/tnf/grade"
+	"github.com/test-network-function/test-network-function/cmd/tnf/images"
 	"github.com/test-network-function/test-network-function/cmd/tnf/jsontest"
+	"github.com/test-network-function/test-network-function/cmd/tnf/run"
+	"github.com/test-network-function/test-network-function/cmd/tnf/tickets"
 )
 
 var (
 	rootCmd = &cobra.Command{
 		Use:   "tnf",
 		Short: "A CLI for creating, validating , and test-network-function tests.",
+		Long: `A CLI for creating, validating , and test-network-function tests.
+
+Shell completion of the commands, suite names and test case names is enabled by sourcing the output of
+"tnf completion <shell>", e.g. "source <(tnf completion bash)".`,
 	}
 
 	generate = &cobra.Command{
@@ -26,10 +40,20 @@ var (
 func main() {
 	rootCmd.AddCommand(claim.NewCommand())
 	rootCmd.AddCommand(generate)
-	generate.AddCommand(catalog.NewCommand())
+	generate.AddCommand(generatecatalog.NewCommand())
+	generate.AddCommand(generateconfig.NewCommand())
 	generate.AddCommand(handler.NewCommand())
 	rootCmd.AddCommand(jsontest.NewCommand())
 	rootCmd.AddCommand(grade.NewCommand())
+	rootCmd.AddCommand(run.NewCommand())
+	rootCmd.AddCommand(catalog.NewCommand())
+	rootCmd.AddCommand(config.NewCommand())
+	rootCmd.AddCommand(discover.NewCommand())
+	rootCmd.AddCommand(annotate.NewCommand())
+	rootCmd.AddCommand(cleanup.NewCommand())
+	rootCmd.AddCommand(images.NewCommand())
+	rootCmd.AddCommand(tickets.NewCommand())
+	rootCmd.AddCommand(analyze.NewCommand())
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,114 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package run
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/canary"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// canaryDirName is the directory of the output of the reference workload run, within the output directory.
+	canaryDirName = "canary"
+	// canaryVerdictFileName is the file the verdict of the reference workload run is written to.
+	canaryVerdictFileName = "verdict.json"
+	// canaryConfigFileName is the configuration of the reference workload run.
+	canaryConfigFileName = "tnf_config.yml"
+	// canaryClaimFileName is the name of the claim file written by the test executable.
+	canaryClaimFileName = "claim.json"
+	// configurationPathEnvVar is the environment variable setting the configuration file of the test executable.
+	configurationPathEnvVar = "TNF_CONFIGURATION_PATH"
+	// canaryFilePermissions are the permissions of the files of the reference workload run.
+	canaryFilePermissions = 0644
+	canaryDirPermissions  = 0755
+	// defaultCanaryTimeout is how long the reference workload is given to be ready.
+	defaultCanaryTimeout = 5 * time.Minute
+)
+
+// runCanary deploys the reference workload, tests it with the canary suites and writes the verdict of the run,
+// returning the path of the verdict file.  The failures to deploy or test the reference workload make the environment
+// suspect too.
+func runCanary(binary, output string) (string, error) {
+	dir := filepath.Join(output, canaryDirName)
+	if err := os.MkdirAll(dir, canaryDirPermissions); err != nil {
+		return "", err
+	}
+	verdictFile := filepath.Join(dir, canaryVerdictFileName)
+	verdict := testReferenceWorkload(binary, dir)
+	if verdict.IsSuspect() {
+		log.Warnf("the environment is suspect, %s: the failures of the CNF may not be genuine", verdict.Reason)
+	} else {
+		log.Infof("the reference workload passed")
+	}
+	return verdictFile, canary.WriteVerdict(verdictFile, &verdict)
+}
+
+// testReferenceWorkload deploys the reference workload in its namespace and runs the canary suites against it, with
+// the output written to dir.
+func testReferenceWorkload(binary, dir string) canary.Verdict {
+	log.Infof("deploying the reference workload in namespace %s", canaryNamespace)
+	if err := canary.Deploy(canaryNamespace, canaryTimeout); err != nil {
+		return canary.Suspect(canaryNamespace, fmt.Errorf("cannot deploy the reference workload: %w", err))
+	}
+	if !keepCanary {
+		defer func() {
+			if err := canary.Delete(canaryNamespace); err != nil {
+				log.Warnf("cannot delete the reference workload: %s", err)
+			}
+		}()
+	}
+	config, err := yaml.Marshal(canary.Configuration(canaryNamespace))
+	if err != nil {
+		return canary.Suspect(canaryNamespace, err)
+	}
+	configFile := filepath.Join(dir, canaryConfigFileName)
+	if err = os.WriteFile(configFile, config, canaryFilePermissions); err != nil {
+		return canary.Suspect(canaryNamespace, err)
+	}
+	args := []string{"-junit", dir, "-claimloc", dir, "--ginkgo.junit-report", filepath.Join(dir, junitReportFileName),
+		"-ginkgo.v", "-test.v", "-ginkgo.focus=" + focusRegex(canary.Suites, nil)}
+	log.Infof("testing the reference workload: %s %v", binary, args)
+	testCmd := exec.Command(binary, args...)
+	testCmd.Dir = filepath.Dir(binary)
+	testCmd.Env = append(os.Environ(), configurationPathEnvVar+"="+configFile)
+	testCmd.Stdout = os.Stdout
+	testCmd.Stderr = os.Stderr
+	// the test executable fails when tests fail, the claim tells which ones.
+	if err = testCmd.Run(); err != nil {
+		log.Debugf("the reference workload run failed: %s", err)
+	}
+	claimFile := filepath.Join(dir, canaryClaimFileName)
+	claimRoot, err := claim.ReadClaimFile(claimFile)
+	if err != nil {
+		return canary.Suspect(canaryNamespace, fmt.Errorf("the reference workload run produced no claim: %w", err))
+	}
+	results, err := claim.GetResults(claimRoot.Claim)
+	if err != nil {
+		return canary.Suspect(canaryNamespace, err)
+	}
+	verdict := canary.Evaluate(results, canaryNamespace)
+	verdict.Claim = claimFile
+	return verdict
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/cmd/tnf/completion"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/multicluster"
+	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	// claimFileName is the name of the claim file written by the test executable, and of the merged claim.
+	claimFileName = "claim.json"
+	// clusterDirPermissions are the permissions of the output directories of the clusters.
+	clusterDirPermissions = 0755
+	// mergedClaimPermissions are the permissions of the merged claim.
+	mergedClaimPermissions = 0644
+)
+
+// runClusters runs the suites against the clusters of --clusters, or all the clusters of the configuration, one after
+// the other.  The output of each cluster is written to a directory of the output directory named after the cluster,
+// and the claims of the clusters are merged into the claim of the output directory, see multicluster.Merge.  The runs
+// against the next clusters go on when the run against a cluster fails.
+func runClusters(binary, output string) error {
+	testConfig, err := config.ReadConfigurationFileFrom(getConfigurationPath(filepath.Dir(binary)))
+	if err != nil {
+		return fmt.Errorf("cannot read the clusters of the configuration: %w", err)
+	}
+	clusters, err := testConfig.SelectClusters(clusterNames)
+	if err != nil {
+		return err
+	}
+	var claims []multicluster.ClusterClaim
+	var failed []string
+	for i := range clusters {
+		cluster := &clusters[i]
+		clusterClaim, runErr := runCluster(binary, output, cluster)
+		if runErr != nil {
+			log.Errorf("the run against cluster %s failed: %v", cluster.Name, runErr)
+			failed = append(failed, cluster.Name)
+		}
+		if clusterClaim != nil {
+			claims = append(claims, *clusterClaim)
+		}
+	}
+	if len(claims) != 0 {
+		if err = writeMergedClaim(filepath.Join(output, claimFileName), claims); err != nil {
+			return err
+		}
+	}
+	if len(failed) != 0 {
+		return fmt.Errorf("the runs against the clusters %s failed", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// runCluster runs the suites applicable to a cluster against it, returning the claim of the run, or nil when none of
+// the focused suites is applicable to the cluster.  The claim is returned along with the error of the test executable
+// when tests failed, and the error alone when the run produced no claim.
+func runCluster(binary, output string, cluster *configsections.Cluster) (*multicluster.ClusterClaim, error) {
+	if len(focusSuites) != 0 && !runsAnySuite(cluster, focusSuites) {
+		log.Infof("none of the focused suites is applicable to cluster %s", cluster.Name)
+		return nil, nil
+	}
+	skip := append([]string{}, skipSuites...)
+	for _, suite := range completion.GetSuiteNames() {
+		if !cluster.RunsSuite(suite) {
+			skip = append(skip, suite)
+		}
+	}
+	dir := filepath.Join(output, cluster.Name)
+	if err := os.MkdirAll(dir, clusterDirPermissions); err != nil {
+		return nil, err
+	}
+	args, err := buildArgs(dir, skip)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, "-cluster", cluster.Name)
+	log.Infof("testing cluster %s", cluster.Name)
+	runErr := runTestExecutable(binary, args)
+	claimRoot, err := claim.ReadClaimFile(filepath.Join(dir, claimFileName))
+	if err != nil {
+		// the failure of the test executable explains the missing claim better.
+		if runErr != nil {
+			return nil, runErr
+		}
+		return nil, fmt.Errorf("the run produced no claim: %w", err)
+	}
+	return &multicluster.ClusterClaim{Name: cluster.Name, Role: cluster.Role, Context: cluster.Context,
+		Claim: claimRoot.Claim}, runErr
+}
+
+// runsAnySuite returns true when one of the suites is applicable to the cluster.
+func runsAnySuite(cluster *configsections.Cluster, suites []string) bool {
+	for _, suite := range suites {
+		if cluster.RunsSuite(suite) {
+			return true
+		}
+	}
+	return false
+}
+
+// getConfigurationPath returns the configuration file of the test executable, which resolves the relative paths from
+// its own directory.
+func getConfigurationPath(binaryDir string) string {
+	path := config.GetConfigurationFilePath()
+	if filepath.IsAbs(path) || config.IsRemoteConfigurationSource(path) {
+		return path
+	}
+	return filepath.Join(binaryDir, path)
+}
+
+// writeMergedClaim merges the claims of the clusters into the claim file path.
+func writeMergedClaim(path string, claims []multicluster.ClusterClaim) error {
+	root, err := multicluster.Merge(claims)
+	if err != nil {
+		return err
+	}
+	payload, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(path, payload, mergedClaimPermissions); err != nil {
+		return err
+	}
+	log.Infof("the claims of the clusters are merged into %s", path)
+	return nil
+}
@@ -0,0 +1,499 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package run provides the "tnf run" command, running the CNF certification suites with the test executable.
+package run
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/cmd/tnf/completion"
+	"github.com/test-network-function/test-network-function/pkg/canary"
+	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/incluster"
+	"github.com/test-network-function/test-network-function/pkg/kubeconfig"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+)
+
+const (
+	// junitReportFileName is the name of the JUnit report of the ginkgo specs, as set by run-cnf-suites.sh.
+	junitReportFileName = "cnf-certification-tests_junit.xml"
+	// kubeconfigFileName is the name of the kubeconfig of the in-cluster runs, in a temporary directory.
+	kubeconfigFileName = "tnf-kubeconfig"
+)
+
+var (
+	binaryPath      string
+	outputDir       string
+	focusSuites     []string
+	skipSuites      []string
+	testCases       []string
+	includeTags     []string
+	excludeTags     []string
+	rerunFailed     string
+	waivers         string
+	testCasePacks   string
+	allowIntrusive  bool
+	allowLoad       bool
+	deadline        time.Duration
+	preflight       bool
+	withCanary      bool
+	canaryNamespace string
+	canaryTimeout   time.Duration
+	keepCanary      bool
+	requireCatalog  string
+	stateBundles    bool
+	transcripts     bool
+	diagnostics     bool
+	archiveRun      bool
+	uploadClaim     bool
+	showDashboard   bool
+	inCluster       bool
+	kubeconfigFile  string
+	kubeContext     string
+	clusterName     string
+	clusterNames    []string
+	allClusters     bool
+	snapshotFile    string
+	saveSnapshot    bool
+	validateConfig  bool
+	configSettings  []string
+
+	run = &cobra.Command{
+		Use:   "run",
+		Short: "Runs the CNF certification suites with the test executable",
+		Long: `Runs the CNF certification suites with the test executable built by "make build-cnf-tests".
+The suites (--focus), the test cases (--test) or the tags (--include-tags, --exclude-tags) of the test cases to run must
+be selected, unless the failed tests of a previous claim file are re-run (--rerun-failed) or the configuration file is
+only validated (--validate-config).`,
+		Example: `  tnf run --focus access-control,lifecycle
+  tnf run --test networking-icmpv4-connectivity --output /tmp/tnf
+  tnf run --rerun-failed test-network-function/claim.json
+  tnf run --include-tags telco,security --exclude-tags intrusive
+  tnf run --focus access-control,lifecycle --canary
+  tnf run --focus access-control,lifecycle --require-catalog-version published
+  tnf run --focus access-control,lifecycle --dashboard
+  tnf run --focus access-control,lifecycle --in-cluster --output /usr/tnf/claim
+  tnf run --focus access-control,lifecycle --kubeconfig ~/.kube/lab --kube-context lab-admin
+  tnf run --focus access-control,lifecycle --cluster edge
+  tnf run --focus access-control,lifecycle,platform-alteration --clusters hub,spoke-1,spoke-2
+  tnf run --focus lifecycle --discovery-snapshot /tmp/tnf/discovery-snapshot.json
+  tnf run --focus lifecycle --set timeouts.default=1m --set targetNameSpaces='[{name: cnf}]'
+  tnf run --validate-config`,
+		RunE: runSuites,
+	}
+)
+
+func runSuites(cmd *cobra.Command, args []string) error {
+	// the configuration is read with the overrides by tnf too, e.g. to select the clusters.
+	if err := config.SetConfigurationOverrides(configSettings); err != nil {
+		return fmt.Errorf("invalid --set: %w", err)
+	}
+	if validateConfig {
+		binary, err := filepath.Abs(binaryPath)
+		if err != nil {
+			return err
+		}
+		return validateConfiguration(getConfigurationPath(filepath.Dir(binary)))
+	}
+	byTags := len(includeTags) != 0 || len(excludeTags) != 0
+	if len(focusSuites) == 0 && len(testCases) == 0 && rerunFailed == "" && !byTags {
+		return fmt.Errorf("no suite or test case selected, use --focus, --test, --include-tags, --exclude-tags or " +
+			"--rerun-failed")
+	}
+	if rerunFailed != "" && (len(focusSuites) != 0 || len(testCases) != 0 || byTags) {
+		return fmt.Errorf("--rerun-failed cannot be combined with --focus, --test or the tags")
+	}
+	if byTags && (len(focusSuites) != 0 || len(testCases) != 0) {
+		return fmt.Errorf("--include-tags and --exclude-tags cannot be combined with --focus or --test")
+	}
+	if inCluster && (kubeconfigFile != "" || kubeContext != "" || clusterName != "") {
+		return fmt.Errorf("--in-cluster cannot be combined with --kubeconfig, --kube-context or --cluster")
+	}
+	// the cluster is selected from the configuration by the test executable, after the reference workload was tested.
+	if withCanary && clusterName != "" {
+		return fmt.Errorf("--canary cannot be combined with --cluster, use --kubeconfig and --kube-context instead")
+	}
+	multiCluster := len(clusterNames) != 0 || allClusters
+	if multiCluster && (clusterName != "" || kubeconfigFile != "" || kubeContext != "" || inCluster || withCanary) {
+		return fmt.Errorf("--clusters and --all-clusters cannot be combined with --cluster, --kubeconfig, " +
+			"--kube-context, --in-cluster or --canary")
+	}
+	// a snapshot holds the resources discovered in a single cluster.
+	if multiCluster && snapshotFile != "" {
+		return fmt.Errorf("--clusters and --all-clusters cannot be combined with --discovery-snapshot")
+	}
+	binary, err := filepath.Abs(binaryPath)
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(binary); err != nil {
+		return fmt.Errorf("cannot find the test executable, build it with \"make build-cnf-tests\": %w", err)
+	}
+	output, err := getOutputDir(filepath.Dir(binary))
+	if err != nil {
+		return err
+	}
+	if multiCluster {
+		return runClusters(binary, output)
+	}
+	testArgs, err := buildArgs(output, skipSuites)
+	if err != nil {
+		return err
+	}
+	removeKubeconfig, err := setupKubeconfig()
+	if err != nil {
+		return err
+	}
+	defer removeKubeconfig()
+	if withCanary {
+		verdictFile, err := runCanary(binary, output)
+		if err != nil {
+			return err
+		}
+		testArgs = append(testArgs, "-canary-verdict", verdictFile)
+	}
+	return runTestExecutable(binary, testArgs)
+}
+
+// validateConfiguration validates the configuration file the test executable would read, listing its problems, see
+// configsections.Validate.
+func validateConfiguration(path string) error {
+	if err := config.CheckConfigurationFile(path); err != nil {
+		return err
+	}
+	log.Infof("%s is valid", path)
+	return nil
+}
+
+// runTestExecutable runs the test executable with args.
+func runTestExecutable(binary string, args []string) error {
+	log.Infof("running %s %s", binary, strings.Join(args, " "))
+	// the test executable looks its resources up relatively to its own directory.
+	testCmd := exec.Command(binary, args...)
+	testCmd.Dir = filepath.Dir(binary)
+	testCmd.Stdin = os.Stdin
+	testCmd.Stdout = os.Stdout
+	testCmd.Stderr = os.Stderr
+	return testCmd.Run()
+}
+
+// setupKubeconfig makes the test executable, and the oc commands it runs, access the cluster of --kubeconfig and
+// --kube-context, if any.  Otherwise, they access the cluster with the service account of the pod tnf runs in, e.g. a
+// Job, when --in-cluster is set or when no kubeconfig is found in a pod.  It returns the function removing the
+// kubeconfig files it writes, once the test executable is done.
+func setupKubeconfig() (removeKubeconfig func(), err error) {
+	if kubeconfigFile != "" || kubeContext != "" {
+		// the reference workload is tested with the same kubeconfig as the suites.
+		return kubeconfig.Use(kubeconfigFile, kubeContext)
+	}
+	if clusterName != "" || (!inCluster && incluster.HasKubeconfig()) {
+		return func() {}, nil
+	}
+	config, err := incluster.Detect(incluster.ServiceAccountDir)
+	if err != nil {
+		if inCluster {
+			return nil, fmt.Errorf("--in-cluster: %w", err)
+		}
+		// outside of a pod, oc reports the missing kubeconfig.
+		return func() {}, nil
+	}
+	dir, removeKubeconfig, err := kubeconfig.MkdirTemp()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, kubeconfigFileName)
+	if err = config.WriteKubeconfig(path); err != nil {
+		removeKubeconfig()
+		return nil, fmt.Errorf("cannot write the in-cluster kubeconfig: %w", err)
+	}
+	log.Infof("accessing the cluster %s with the service account of the pod", config.Server)
+	if err = os.Setenv(incluster.KubeconfigEnvVar, path); err != nil {
+		removeKubeconfig()
+		return nil, err
+	}
+	return removeKubeconfig, nil
+}
+
+// getOutputDir returns the absolute path of the output directory, the directory of the test executable by default.
+func getOutputDir(binaryDir string) (string, error) {
+	output := outputDir
+	if output == "" {
+		output = binaryDir
+	}
+	return filepath.Abs(output)
+}
+
+// buildArgs builds the arguments of the test executable writing its output to the output directory and skipping the
+// skip suites or test cases, the relative paths are made absolute as the test executable runs in its own directory.
+func buildArgs(output string, skip []string) ([]string, error) {
+	args := []string{"-junit", output, "-claimloc", output,
+		"--ginkgo.junit-report", filepath.Join(output, junitReportFileName)}
+	// the verbose output of the specs would scroll the dashboard away.
+	if showDashboard {
+		args = append(args, "-dashboard")
+	} else {
+		args = append(args, "-ginkgo.v", "-test.v")
+	}
+	if rerunFailed != "" {
+		claimFile, err := filepath.Abs(rerunFailed)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-rerun-failed", claimFile)
+	} else if len(includeTags) != 0 || len(excludeTags) != 0 {
+		args = append(args, "-include-tags="+strings.Join(includeTags, ","),
+			"-exclude-tags="+strings.Join(excludeTags, ","))
+	} else {
+		focus, err := testCasesFocusRegex(focusSuites, testCases)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-ginkgo.focus="+focus)
+	}
+	if len(skip) != 0 {
+		args = append(args, "-ginkgo.skip="+focusRegex(skip, nil))
+	}
+	if waivers != "" {
+		waiversFile, err := filepath.Abs(waivers)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-waivers", waiversFile)
+	}
+	if testCasePacks != "" {
+		packsDir, err := filepath.Abs(testCasePacks)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-testcase-packs", packsDir)
+	}
+	if allowIntrusive {
+		args = append(args, "-allow-intrusive")
+	}
+	if allowLoad {
+		args = append(args, "-allow-load")
+	}
+	if deadline != 0 {
+		args = append(args, "-deadline", deadline.String())
+	}
+	if preflight {
+		args = append(args, "-images-preflight")
+	}
+	if requireCatalog != "" {
+		args = append(args, "-require-catalog-version", requireCatalog)
+	}
+	if stateBundles {
+		args = append(args, "-state-bundles")
+	}
+	if transcripts {
+		args = append(args, "-session-transcripts")
+	}
+	if diagnostics {
+		args = append(args, "-failure-diagnostics")
+	}
+	if archiveRun {
+		args = append(args, "-archive")
+	}
+	if uploadClaim {
+		args = append(args, "-upload")
+	}
+	if kubeconfigFile != "" {
+		kubeconfigPaths, err := absPaths(kubeconfigFile)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-kubeconfig", kubeconfigPaths)
+	}
+	if kubeContext != "" {
+		args = append(args, "-kube-context", kubeContext)
+	}
+	if clusterName != "" {
+		args = append(args, "-cluster", clusterName)
+	}
+	if snapshotFile != "" {
+		snapshotPath, err := filepath.Abs(snapshotFile)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-discovery-snapshot", snapshotPath)
+	}
+	if saveSnapshot {
+		args = append(args, "-save-discovery-snapshot")
+	}
+	for _, setting := range configSettings {
+		args = append(args, "-set", setting)
+	}
+	return args, nil
+}
+
+// absPaths makes the paths of a list, e.g. of kubeconfig files, absolute.
+func absPaths(list string) (string, error) {
+	var paths []string
+	for _, path := range filepath.SplitList(list) {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return "", err
+		}
+		paths = append(paths, absPath)
+	}
+	return strings.Join(paths, string(filepath.ListSeparator)), nil
+}
+
+// focusRegex builds the ginkgo focus regular expression of suites and test cases.
+func focusRegex(suites, tests []string) string {
+	var patterns []string
+	for _, suite := range suites {
+		patterns = append(patterns, regexp.QuoteMeta(suite))
+	}
+	for _, test := range tests {
+		patterns = append(patterns, regexp.QuoteMeta(test))
+	}
+	return strings.Join(patterns, "|")
+}
+
+// testCasesFocusRegex builds the ginkgo focus regular expression of suites and test cases, adding the suite of each
+// test case, matched alone, as the suites only register their specs when their name is in focus, see
+// testcases.IsInFocus.
+func testCasesFocusRegex(suites, tests []string) (string, error) {
+	var testSuites []string
+	for _, test := range tests {
+		suite, err := getTestCaseSuite(test)
+		if err != nil {
+			return "", err
+		}
+		testSuites = append(testSuites, testcases.SuiteFocusString(suite))
+	}
+	focus := focusRegex(suites, tests)
+	if len(testSuites) == 0 {
+		return focus, nil
+	}
+	if focus != "" {
+		focus += "|"
+	}
+	return focus + strings.Join(testSuites, "|"), nil
+}
+
+// getTestCaseSuite returns the suite of a test case, e.g. "access-control" for
+// access-control-host-resource-PRIVILEGED_POD, the longest suite of the catalog prefixing its name.
+func getTestCaseSuite(test string) (string, error) {
+	suite := ""
+	for id := range identifiers.Catalog {
+		name := identifiers.GetSuite(id)
+		if (test == name || strings.HasPrefix(test, name+"-")) && len(name) > len(suite) {
+			suite = name
+		}
+	}
+	if suite == "" {
+		return "", fmt.Errorf("unknown test case %s, see \"tnf catalog list\"", test)
+	}
+	return suite, nil
+}
+
+// NewCommand returns the "run" command.
+func NewCommand() *cobra.Command {
+	run.Flags().StringVarP(&binaryPath, "binary", "b", filepath.Join("test-network-function", "test-network-function.test"),
+		"path of the test executable")
+	run.Flags().StringVarP(&outputDir, "output", "o", "", "directory of the claim file and JUnit reports, the directory "+
+		"of the test executable by default")
+	run.Flags().StringSliceVarP(&focusSuites, "focus", "f", nil, "suites to run")
+	run.Flags().StringSliceVarP(&skipSuites, "skip", "s", nil, "suites or test cases to skip")
+	run.Flags().StringSliceVarP(&testCases, "test", "t", nil, "test cases to run")
+	run.Flags().StringSliceVar(&includeTags, "include-tags", nil, "only run the test cases having one of these tags, "+
+		"e.g. telco, security or a suite name, see \"tnf catalog list\"")
+	run.Flags().StringSliceVar(&excludeTags, "exclude-tags", nil, "do not run the test cases having one of these "+
+		"tags, e.g. intrusive")
+	run.Flags().StringVarP(&rerunFailed, "rerun-failed", "r", "", "claim file of a previous run, only its failed "+
+		"tests are run")
+	run.Flags().StringVarP(&waivers, "waivers", "w", "", "waivers file, the failures matching an active waiver are "+
+		"reported as waived")
+	run.Flags().StringVar(&testCasePacks, "testcase-packs", "", "directory of the YAML and JSON files of "+
+		"user-defined test case packs, run alongside the built-in test cases of the access-control and operator suites")
+	run.Flags().BoolVarP(&allowIntrusive, "allow-intrusive", "i", false, "also run the intrusive and destructive tests")
+	run.Flags().BoolVarP(&allowLoad, "allow-load", "l", false, "also run the load-generating tests, e.g. the "+
+		"throughput measurement")
+	run.Flags().DurationVarP(&deadline, "deadline", "d", 0, "maximum duration of the run, e.g. 2h, the running test "+
+		"is aborted and the remaining ones skipped once it expires")
+	run.Flags().BoolVarP(&preflight, "images-preflight", "p", false, "first check the auxiliary images of the suites "+
+		"can be pulled, see \"tnf images list\"")
+	run.Flags().BoolVarP(&withCanary, "canary", "c", false, "first deploy and test a compliant reference workload, the "+
+		"run is marked environment-suspect in the claim when it fails")
+	run.Flags().StringVar(&canaryNamespace, "canary-namespace", canary.DefaultNamespace, "namespace of the reference "+
+		"workload, created and deleted by the run")
+	run.Flags().DurationVar(&canaryTimeout, "canary-timeout", defaultCanaryTimeout, "how long the reference workload "+
+		"is given to be ready")
+	run.Flags().BoolVar(&keepCanary, "keep-canary", false, "keep the namespace of the reference workload once tested")
+	run.Flags().StringVar(&requireCatalog, "require-catalog-version", "", "minimum catalog version of an official "+
+		"run, or \"published\" for the published certification policy version, the run does not start with an "+
+		"older catalog")
+	run.Flags().BoolVar(&stateBundles, "state-bundles", false, "write the commands run, their outputs, the target "+
+		"objects and the environment of each failed test into the state-bundles directory of the output directory")
+	run.Flags().BoolVar(&transcripts, "session-transcripts", false, "record the commands sent to the interactive "+
+		"sessions and their raw outputs, with timestamps, into one file per test in the session-transcripts directory "+
+		"of the output directory")
+	run.Flags().BoolVar(&diagnostics, "failure-diagnostics", false, "gather the description, the recent logs and the "+
+		"events of the targets of each failed test into the failure-diagnostics directory of the output directory")
+	run.Flags().BoolVar(&archiveRun, "archive", false, "package the claim, the JUnit reports, the transcripts, the "+
+		"state bundles and the diagnostics into a single timestamped tar.gz file of the output directory at the end "+
+		"of the run")
+	run.Flags().BoolVar(&uploadClaim, "upload", false, "upload the claim to the collector of the claimUpload section "+
+		"of the configuration at the end of the run, the claim is not uploaded by default")
+	run.Flags().BoolVar(&showDashboard, "dashboard", false, "show a live dashboard of the suites, the running test "+
+		"and its output instead of the logs, which are written to the tnf-execution.log file of the output directory")
+	run.Flags().BoolVar(&inCluster, "in-cluster", false, "access the cluster with the service account of the pod tnf "+
+		"runs in, e.g. a Job, which is the default in a pod without a kubeconfig")
+	run.Flags().StringVar(&kubeconfigFile, "kubeconfig", "", "kubeconfig file of the cluster under test, or list of "+
+		"kubeconfig files, overriding the configuration and $KUBECONFIG")
+	run.Flags().StringVar(&kubeContext, "kube-context", "", "kubeconfig context of the cluster under test, "+
+		"overriding the configuration and the current context")
+	run.Flags().StringVar(&clusterName, "cluster", "", "name of the cluster under test among the clusters section of "+
+		"the configuration, overriding targetCluster")
+	run.Flags().StringSliceVar(&clusterNames, "clusters", nil, "clusters of the clusters section of the "+
+		"configuration to test one after the other, e.g. a hub and its spokes, with the claims of the clusters merged "+
+		"into a single claim")
+	run.Flags().BoolVar(&allClusters, "all-clusters", false, "test all the clusters of the clusters section of the "+
+		"configuration, like --clusters")
+	run.Flags().StringVar(&snapshotFile, "discovery-snapshot", "", "discovery snapshot saved by a previous run, the "+
+		"resources under test are taken from it instead of being discovered, e.g. to re-run the tests against the "+
+		"same resources")
+	run.Flags().BoolVar(&saveSnapshot, "save-discovery-snapshot", false, "write the result of the autodiscovery into "+
+		"the discovery-snapshot.json file of the output directory")
+	run.Flags().StringArrayVar(&configSettings, "set", nil, "override a field of the configuration as path=value, "+
+		"e.g. timeouts.default=1m or targetPodLabels[0].value=target, taking precedence over the TNF_CONFIG_ "+
+		"environment variables and the configuration file, may be repeated")
+	run.Flags().BoolVar(&validateConfig, "validate-config", false, "validate the configuration file, listing its "+
+		"unknown fields, its values of the wrong type and its missing required fields, and exit without running the "+
+		"suites")
+	for flag, completionFunc := range map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+		"focus": completion.SuiteNames,
+		"skip":  completion.SuiteNames,
+		"test":  completion.TestCaseNames,
+	} {
+		if err := run.RegisterFlagCompletionFunc(flag, completionFunc); err != nil {
+			log.Fatal(err)
+		}
+	}
+	return run
+}
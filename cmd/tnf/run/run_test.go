@@ -0,0 +1,64 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package run
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+	"github.com/test-network-function/test-network-function/test-network-function/common"
+)
+
+// getFocus returns the focus the test executable would receive from buildArgs.
+func getFocus(t *testing.T) []string {
+	args, err := buildArgs("/tmp/tnf", nil)
+	assert.Nil(t, err)
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-ginkgo.focus=") {
+			return []string{strings.TrimPrefix(arg, "-ginkgo.focus=")}
+		}
+	}
+	return nil
+}
+
+func TestBuildArgsTestCasesFocus(t *testing.T) {
+	defer func() { testCases = nil }()
+	testCases = []string{"networking-icmpv4-connectivity", "access-control-host-resource-PRIVILEGED_POD"}
+	focus := getFocus(t)
+	// the suites owning the test cases register their specs, the others do not.
+	assert.True(t, testcases.IsInFocus(focus, common.NetworkingTestKey))
+	assert.True(t, testcases.IsInFocus(focus, common.AccessControlTestKey))
+	assert.False(t, testcases.IsInFocus(focus, common.LifecycleTestKey))
+	assert.False(t, testcases.IsInFocus(focus, common.PlatformAlterationTestKey))
+	// the specs of the test cases only are run.
+	assert.True(t, testcases.IsInFocus(focus, "networking networking-icmpv4-connectivity"))
+	assert.False(t, testcases.IsInFocus(focus, "networking networking-icmpv6-connectivity"))
+
+	testCases = []string{"no-such-test"}
+	_, err := buildArgs("/tmp/tnf", nil)
+	assert.NotNil(t, err)
+}
+
+func TestBuildArgsSuitesFocus(t *testing.T) {
+	defer func() { focusSuites = nil }()
+	focusSuites = []string{common.LifecycleTestKey}
+	focus := getFocus(t)
+	assert.True(t, testcases.IsInFocus(focus, common.LifecycleTestKey))
+	assert.False(t, testcases.IsInFocus(focus, common.NetworkingTestKey))
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package tickets provides the "tnf tickets" commands, filing tickets for the failed test cases of a claim.
+package tickets
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/tickets"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+)
+
+const (
+	// tokenEnvVar is the environment variable holding the token of the tracker, kept out of the command line.
+	tokenEnvVar   = "TNF_TRACKER_TOKEN"
+	trackerGitHub = "github"
+	trackerJira   = "jira"
+)
+
+var (
+	claimFile string
+	tracker   string
+	endpoint  string
+	project   string
+	issueType string
+	target    string
+	labels    []string
+	dryRun    bool
+
+	ticketsCmd = &cobra.Command{
+		Use:   "tickets",
+		Short: "Files tickets in an issue tracker for the failed test cases of a claim",
+	}
+
+	file = &cobra.Command{
+		Use:   "file",
+		Short: "Files a ticket per failed test case, unless an open ticket already tracks it",
+		Long: `Files a ticket per failed test case of a claim in GitHub or Jira, with the failed runs and the remediation of
+the test case.  The tickets are titled "[tnf] <test case> failed on <target>", the target being the namespaces under
+test unless set, and no ticket is filed when an open ticket has the same title.  The token of the tracker is read from
+the ` + tokenEnvVar + ` environment variable.`,
+		Example: `  tnf tickets file --claim claim.json --tracker github --project my-org/my-cnf --label cnf-certification
+  tnf tickets file --claim claim.json --tracker jira --endpoint https://issues.example.com --project CNF --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: fileTickets,
+	}
+)
+
+// lookupCatalog returns the description and the remediation of a test case from the catalog, by URL as the claim
+// may have been produced by another version.
+func lookupCatalog(id *schema.Identifier) (description, remediation string, ok bool) {
+	for catalogID, entry := range identifiers.Catalog {
+		if catalogID.Url == id.Url {
+			return entry.Description, entry.Remediation, true
+		}
+	}
+	return "", "", false
+}
+
+func newTracker() (tickets.Tracker, error) {
+	token := os.Getenv(tokenEnvVar)
+	switch tracker {
+	case trackerGitHub:
+		return tickets.NewGitHub(endpoint, project, token, labels), nil
+	case trackerJira:
+		if endpoint == "" {
+			return nil, fmt.Errorf("the Jira tracker requires --endpoint")
+		}
+		return tickets.NewJira(endpoint, project, issueType, token, labels), nil
+	default:
+		return nil, fmt.Errorf("unknown tracker %q, use %q or %q", tracker, trackerGitHub, trackerJira)
+	}
+}
+
+func fileTickets(cmd *cobra.Command, args []string) error {
+	t, err := newTracker()
+	if err != nil {
+		return err
+	}
+	claimRoot, err := claim.ReadClaimFile(claimFile)
+	if err != nil {
+		return err
+	}
+	if target == "" {
+		target = tickets.GetTarget(claimRoot.Claim)
+	}
+	toFile, err := tickets.Build(claimRoot.Claim, target, lookupCatalog)
+	if err != nil {
+		return err
+	}
+	filed, err := tickets.File(t, toFile, dryRun)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TEST CASE\tTICKET\tSTATUS")
+	for i := range filed {
+		status := "created"
+		switch {
+		case filed[i].Existing:
+			status = "already open"
+		case dryRun:
+			status = "to create"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", filed[i].Ticket.TestCase, filed[i].URL, status)
+	}
+	if flushErr := w.Flush(); flushErr != nil && err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// NewCommand returns the "tickets" command.
+func NewCommand() *cobra.Command {
+	file.Flags().StringVarP(&claimFile, "claim", "c", "", "claim file of the run (Required)")
+	file.Flags().StringVarP(&tracker, "tracker", "t", trackerGitHub, "issue tracker, \""+trackerGitHub+"\" or \""+
+		trackerJira+"\"")
+	file.Flags().StringVarP(&endpoint, "endpoint", "e", "", "URL of the tracker API, "+tickets.DefaultGitHubEndpoint+
+		" by default for GitHub")
+	file.Flags().StringVarP(&project, "project", "p", "", "GitHub repository (owner/name) or Jira project key (Required)")
+	file.Flags().StringVar(&issueType, "issue-type", tickets.DefaultJiraIssueType, "type of the Jira issues")
+	file.Flags().StringVar(&target, "target", "", "target of the tickets, the namespaces under test of the claim by "+
+		"default")
+	file.Flags().StringSliceVarP(&labels, "label", "l", nil, "labels of the tickets")
+	file.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "only list the tickets that would be filed")
+	for _, flag := range []string{"claim", "project"} {
+		if err := file.MarkFlagRequired(flag); err != nil {
+			return nil
+		}
+	}
+	ticketsCmd.AddCommand(file)
+	return ticketsCmd
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+)
+
+const (
+	// defaultRegistry is the registry of the image references without registry, e.g. "nginx".
+	defaultRegistry = "docker.io"
+	// defaultRepositoryNamespace is the namespace of the repositories of defaultRegistry without namespace.
+	defaultRepositoryNamespace = "library"
+)
+
+// errorNoDigest is returned when checking an image referenced by tag only, as tags are mutable.
+var errorNoDigest = errors.New("the image is not referenced by digest")
+
+// registryAliases are the registries serving the images the catalog publishes under another registry.
+var registryAliases = map[string]string{
+	"registry.redhat.io": "registry.access.redhat.com",
+}
+
+// ImageReference identifies a container image in the catalog.
+type ImageReference struct {
+	Registry   string `json:"registry"`
+	Repository string `json:"repository"`
+	// Digest is the digest of the image manifest, or of its manifest list, e.g. "sha256:...".
+	Digest string `json:"digest"`
+}
+
+// ImageCertificationChecker tells whether an image is certified.
+type ImageCertificationChecker interface {
+	IsImageCertified(image ImageReference) (bool, error)
+}
+
+// ParseImageReference splits an image reference, e.g. "registry.connect.redhat.com/vendor/cnf@sha256:...", into its
+// registry, repository and digest.  The registry defaults to docker.io, the digest is empty for an image referenced by
+// tag only.
+func ParseImageReference(image string) ImageReference {
+	name, digest := image, ""
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, digest = name[:i], name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i:], "/") {
+		name = name[:i]
+	}
+	registry := defaultRegistry
+	if i := strings.Index(name, "/"); i >= 0 && (strings.ContainsAny(name[:i], ".:") || name[:i] == "localhost") {
+		registry, name = name[:i], name[i+1:]
+	}
+	if alias, ok := registryAliases[registry]; ok {
+		registry = alias
+	}
+	if registry == defaultRegistry && !strings.Contains(name, "/") {
+		name = defaultRepositoryNamespace + "/" + name
+	}
+	return ImageReference{Registry: registry, Repository: name, Digest: digest}
+}
+
+// String returns the reference by digest of the image.
+func (i ImageReference) String() string {
+	return i.Registry + "/" + i.Repository + "@" + i.Digest
+}
+
+// IsImageCertified looks up the image by digest in the catalog of its repository, and returns true when it is
+// published there, i.e. certified.  An unknown repository is not certified.
+func (api CertAPIClient) IsImageCertified(image ImageReference) (bool, error) {
+	if image.Digest == "" {
+		return false, errorNoDigest
+	}
+	filter := fmt.Sprintf("image_id==%[1]s,docker_image_digest==%[1]s,repositories.manifest_list_digest==%[1]s", image.Digest)
+	requestURL := fmt.Sprintf("%s/repositories/registry/%s/repository/%s/images?page_size=1&filter=%s",
+		apiContainerCatalogExternalBaseEndPoint, image.Registry, image.Repository, url.QueryEscape(filter))
+	responseData, err := api.getRequest(requestURL)
+	if errors.Is(err, errorContainer404) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	imageID, err := api.getIDFromResponse(responseData)
+	if err != nil {
+		return false, err
+	}
+	return imageID != "", nil
+}
+
+// catalogImage is an image of the catalog, as returned by its images endpoints.
+type catalogImage struct {
+	ImageID           string `json:"image_id"`
+	DockerImageDigest string `json:"docker_image_digest"`
+	Repositories      []struct {
+		Registry           string `json:"registry"`
+		Repository         string `json:"repository"`
+		ManifestListDigest string `json:"manifest_list_digest"`
+	} `json:"repositories"`
+}
+
+// OfflineCatalog is a dump of the images of the catalog, checked instead of querying the catalog, e.g. in a
+// disconnected environment.
+type OfflineCatalog struct {
+	images []catalogImage
+}
+
+// LoadOfflineCatalog reads the dump of the catalog at path, the images as returned by the images endpoints of the
+// catalog API, i.e. a JSON object holding them in its "data" array.
+func LoadOfflineCatalog(path string) (*OfflineCatalog, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var dump struct {
+		Data []catalogImage `json:"data"`
+	}
+	if err = json.Unmarshal(contents, &dump); err != nil {
+		return nil, fmt.Errorf("cannot parse the catalog dump %s: %w", path, err)
+	}
+	return &OfflineCatalog{images: dump.Data}, nil
+}
+
+// IsImageCertified returns true when the image is published in the catalog dump, by digest, in its repository.
+func (c *OfflineCatalog) IsImageCertified(image ImageReference) (bool, error) {
+	if image.Digest == "" {
+		return false, errorNoDigest
+	}
+	for i := range c.images {
+		catalogImage := &c.images[i]
+		for _, repository := range catalogImage.Repositories {
+			if repository.Registry != image.Registry || repository.Repository != image.Repository {
+				continue
+			}
+			if catalogImage.ImageID == image.Digest || catalogImage.DockerImageDigest == image.Digest ||
+				repository.ManifestListDigest == image.Digest {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
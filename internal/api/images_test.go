@@ -0,0 +1,101 @@
+package api_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/internal/api"
+)
+
+const (
+	imageDigest        = "sha256:9c1b1b4a1ec1a8e4d8c6d3d7a3e0bd1e7b5a6e0c4d2f8a9b3c5d7e9f1a2b3c4d"
+	manifestListDigest = "sha256:1f2e3d4c5b6a79881f2e3d4c5b6a79881f2e3d4c5b6a79881f2e3d4c5b6a7988"
+	jsonImagesFound    = `{
+	"data": [{
+		"_id": "5ea8cf595a13466876a10215",
+		"image_id": "sha256:9c1b1b4a1ec1a8e4d8c6d3d7a3e0bd1e7b5a6e0c4d2f8a9b3c5d7e9f1a2b3c4d",
+		"repositories": [{
+			"registry": "registry.access.redhat.com",
+			"repository": "rhel8/nginx-116",
+			"manifest_list_digest": "sha256:1f2e3d4c5b6a79881f2e3d4c5b6a79881f2e3d4c5b6a79881f2e3d4c5b6a7988"
+		}]
+	}]
+}`
+	jsonImagesNotFound = `{"data": [], "page": 0, "page_size": 1, "total": 0}`
+)
+
+var certifiedImage = api.ImageReference{
+	Registry:   "registry.access.redhat.com",
+	Repository: "rhel8/nginx-116",
+	Digest:     imageDigest,
+}
+
+func TestParseImageReference(t *testing.T) {
+	testCases := []struct {
+		image    string
+		expected api.ImageReference
+	}{
+		{image: "registry.redhat.io/rhel8/nginx-116@" + imageDigest, expected: certifiedImage},
+		{image: "registry.connect.redhat.com/vendor/cnf:1.2", expected: api.ImageReference{
+			Registry: "registry.connect.redhat.com", Repository: "vendor/cnf"}},
+		{image: "registry:5000/cnf:1.2@" + imageDigest, expected: api.ImageReference{
+			Registry: "registry:5000", Repository: "cnf", Digest: imageDigest}},
+		{image: "nginx", expected: api.ImageReference{Registry: "docker.io", Repository: "library/nginx"}},
+		{image: "tnf/cnf:latest", expected: api.ImageReference{Registry: "docker.io", Repository: "tnf/cnf"}},
+	}
+	for _, c := range testCases {
+		assert.Equal(t, c.expected, api.ParseImageReference(c.image), c.image)
+	}
+}
+
+func TestApiClient_IsImageCertified(t *testing.T) {
+	testCases := []struct {
+		responseData   string
+		responseStatus int
+		expectedResult bool
+	}{
+		{responseData: jsonImagesFound, responseStatus: http.StatusOK, expectedResult: true},
+		{responseData: jsonImagesNotFound, responseStatus: http.StatusOK, expectedResult: false},
+		{responseData: jsonResponseNotFound, responseStatus: http.StatusNotFound, expectedResult: false},
+	}
+	for _, c := range testCases {
+		GetDoFunc = getDoFunc(c.responseData, c.responseStatus) //nolint:bodyclose
+		result, err := client.IsImageCertified(certifiedImage)
+		assert.Nil(t, err)
+		assert.Equal(t, c.expectedResult, result)
+	}
+	_, err := client.IsImageCertified(api.ImageReference{Registry: "docker.io", Repository: "library/nginx"})
+	assert.NotNil(t, err)
+}
+
+func TestOfflineCatalog_IsImageCertified(t *testing.T) {
+	dir, err := ioutil.TempDir("", "catalog")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "catalog.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(jsonImagesFound), 0600))
+	catalog, err := api.LoadOfflineCatalog(path)
+	assert.Nil(t, err)
+
+	certified, err := catalog.IsImageCertified(certifiedImage)
+	assert.Nil(t, err)
+	assert.True(t, certified)
+	// referenced by its manifest list.
+	image := certifiedImage
+	image.Digest = manifestListDigest
+	certified, err = catalog.IsImageCertified(image)
+	assert.Nil(t, err)
+	assert.True(t, certified)
+	// published in another repository.
+	image.Repository = "rhel8/nginx-118"
+	certified, err = catalog.IsImageCertified(image)
+	assert.Nil(t, err)
+	assert.False(t, certified)
+
+	_, err = api.LoadOfflineCatalog(filepath.Join(dir, "missing.json"))
+	assert.NotNil(t, err)
+}
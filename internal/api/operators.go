@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// certifiedOperatorsOrganization is the organization of the certified operators index in the catalog.
+	certifiedOperatorsOrganization = "certified-operators"
+	// bundlesPageSize bounds the bundles returned for a version of a package, one per channel.
+	bundlesPageSize = 100
+	// cacheFileMode restricts the cache file to its owner.
+	cacheFileMode = 0600
+	// cacheDirMode restricts the cache directory to its owner.
+	cacheDirMode = 0700
+)
+
+// OperatorBundle is a bundle of an operator published in the catalog, in one of the channels of its package.
+type OperatorBundle struct {
+	Package string `json:"package"`
+	CSVName string `json:"csv_name"`
+	Version string `json:"version"`
+	Channel string `json:"channel_name"`
+}
+
+// OperatorBundleGetter returns the bundles of the certified operators.
+type OperatorBundleGetter interface {
+	GetCertifiedOperatorBundles(packageName, version string) ([]OperatorBundle, error)
+}
+
+// GetCertifiedOperatorBundles returns the bundles of the version of the package published in the certified operators
+// index, one per channel, empty when the version is not certified.
+func (api CertAPIClient) GetCertifiedOperatorBundles(packageName, version string) ([]OperatorBundle, error) {
+	filter := fmt.Sprintf("organization==%s;package==%s;version==%s", certifiedOperatorsOrganization, packageName, version)
+	requestURL := fmt.Sprintf("%s/bundles?page_size=%d&filter=%s", apiOperatorCatalogExternalBaseEndPoint, bundlesPageSize,
+		url.QueryEscape(filter))
+	responseData, err := api.getRequest(requestURL)
+	if errors.Is(err, errorContainer404) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Data []OperatorBundle `json:"data"`
+	}
+	if err = json.Unmarshal(responseData, &response); err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+// bundleCacheEntry is the bundles of a version of a package, as returned by the catalog at FetchedAt.
+type bundleCacheEntry struct {
+	Bundles   []OperatorBundle `json:"bundles"`
+	FetchedAt time.Time        `json:"fetchedAt"`
+}
+
+// CachedBundleGetter caches the bundles returned by an OperatorBundleGetter in a file, so that the repeated runs only
+// query the catalog once the cached bundles are older than the TTL.
+type CachedBundleGetter struct {
+	getter  OperatorBundleGetter
+	path    string
+	ttl     time.Duration
+	mutex   sync.Mutex
+	entries map[string]bundleCacheEntry
+	now     func() time.Time
+}
+
+// NewCachedBundleGetter returns a CachedBundleGetter over getter, caching in the file at path, which is created when
+// missing.  The bundles are only cached in memory when path is empty.
+func NewCachedBundleGetter(getter OperatorBundleGetter, path string, ttl time.Duration) *CachedBundleGetter {
+	c := &CachedBundleGetter{getter: getter, path: path, ttl: ttl, entries: map[string]bundleCacheEntry{}, now: time.Now}
+	if path == "" {
+		return c
+	}
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warnf("Cannot read the operator bundles cache %s, ignoring it: %v", path, err)
+		}
+		return c
+	}
+	if err = json.Unmarshal(contents, &c.entries); err != nil {
+		log.Warnf("Cannot parse the operator bundles cache %s, ignoring it: %v", path, err)
+		c.entries = map[string]bundleCacheEntry{}
+	}
+	return c
+}
+
+// GetCertifiedOperatorBundles returns the cached bundles of the version of the package when they are recent enough,
+// otherwise gets and caches them.
+func (c *CachedBundleGetter) GetCertifiedOperatorBundles(packageName, version string) ([]OperatorBundle, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := packageName + "/" + version
+	if entry, ok := c.entries[key]; ok && c.now().Sub(entry.FetchedAt) < c.ttl {
+		return entry.Bundles, nil
+	}
+	bundles, err := c.getter.GetCertifiedOperatorBundles(packageName, version)
+	if err != nil {
+		return nil, err
+	}
+	c.entries[key] = bundleCacheEntry{Bundles: bundles, FetchedAt: c.now()}
+	if err = c.save(); err != nil {
+		log.Warnf("Cannot write the operator bundles cache %s: %v", c.path, err)
+	}
+	return bundles, nil
+}
+
+// save writes the cached bundles to the cache file, if any.
+func (c *CachedBundleGetter) save() error {
+	if c.path == "" {
+		return nil
+	}
+	contents, err := json.Marshal(c.entries)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(c.path), cacheDirMode); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, contents, cacheFileMode)
+}
@@ -0,0 +1,96 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/internal/api"
+)
+
+const jsonBundlesFound = `{
+	"data": [{
+		"package": "nginx-operator",
+		"csv_name": "nginx-operator.v0.0.1",
+		"version": "0.0.1",
+		"channel_name": "stable"
+	}, {
+		"package": "nginx-operator",
+		"csv_name": "nginx-operator.v0.0.1",
+		"version": "0.0.1",
+		"channel_name": "alpha"
+	}]
+}`
+
+var nginxBundles = []api.OperatorBundle{
+	{Package: "nginx-operator", CSVName: "nginx-operator.v0.0.1", Version: "0.0.1", Channel: "stable"},
+	{Package: "nginx-operator", CSVName: "nginx-operator.v0.0.1", Version: "0.0.1", Channel: "alpha"},
+}
+
+// countingBundleGetter returns bundles, or err, and counts the calls.
+type countingBundleGetter struct {
+	bundles []api.OperatorBundle
+	err     error
+	calls   int
+}
+
+func (g *countingBundleGetter) GetCertifiedOperatorBundles(_, _ string) ([]api.OperatorBundle, error) {
+	g.calls++
+	return g.bundles, g.err
+}
+
+func TestApiClient_GetCertifiedOperatorBundles(t *testing.T) {
+	GetDoFunc = getDoFunc(jsonBundlesFound, http.StatusOK) //nolint:bodyclose
+	bundles, err := client.GetCertifiedOperatorBundles("nginx-operator", "0.0.1")
+	assert.Nil(t, err)
+	assert.Equal(t, nginxBundles, bundles)
+
+	GetDoFunc = getDoFunc(jsonResponseNotFound, http.StatusNotFound) //nolint:bodyclose
+	bundles, err = client.GetCertifiedOperatorBundles("unknown-operator", "0.0.1")
+	assert.Nil(t, err)
+	assert.Empty(t, bundles)
+}
+
+func TestCachedBundleGetter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundles")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cache", "bundles.json")
+
+	getter := &countingBundleGetter{bundles: nginxBundles}
+	cached := api.NewCachedBundleGetter(getter, path, time.Hour)
+	for i := 0; i < 2; i++ {
+		bundles, getErr := cached.GetCertifiedOperatorBundles("nginx-operator", "0.0.1")
+		assert.Nil(t, getErr)
+		assert.Equal(t, nginxBundles, bundles)
+	}
+	assert.Equal(t, 1, getter.calls)
+
+	// the next run reads the cache file.
+	cached = api.NewCachedBundleGetter(getter, path, time.Hour)
+	_, err = cached.GetCertifiedOperatorBundles("nginx-operator", "0.0.1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, getter.calls)
+
+	// expired.
+	cached = api.NewCachedBundleGetter(getter, path, 0)
+	_, err = cached.GetCertifiedOperatorBundles("nginx-operator", "0.0.1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, getter.calls)
+}
+
+func TestCachedBundleGetter_Error(t *testing.T) {
+	getter := &countingBundleGetter{err: errors.New("catalog unavailable")}
+	cached := api.NewCachedBundleGetter(getter, "", time.Hour)
+	for i := 0; i < 2; i++ {
+		_, err := cached.GetCertifiedOperatorBundles("nginx-operator", "0.0.1")
+		assert.NotNil(t, err)
+	}
+	// the errors are not cached.
+	assert.Equal(t, 2, getter.calls)
+}
@@ -0,0 +1,210 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// ManifestVersion is the version of the format of the manifest, bumped on incompatible changes.
+	ManifestVersion = "v1"
+	// ManifestFileName is the name of the manifest in the archive.
+	ManifestFileName = "manifest.json"
+
+	fileNamePrefix  = "tnf-artifacts-"
+	timeFormat      = "20060102T150405Z"
+	filePermissions = 0644
+)
+
+// Source is a file or directory to archive.
+type Source struct {
+	// Path is the path of the file or directory on disk, it is skipped when missing.
+	Path string
+	// Name is its path in the archive, relative to the root directory of the archive.
+	Name string
+}
+
+// File is a file of the archive listed in the manifest.
+type File struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest lists the files of an archive, sorted by name.
+type Manifest struct {
+	ManifestVersion string    `json:"manifestVersion"`
+	CreatedAt       time.Time `json:"createdAt"`
+	Files           []File    `json:"files"`
+}
+
+// FileName returns the name of the archive created at createdAt, its root directory is the same without extension.
+func FileName(createdAt time.Time) string {
+	return RootDir(createdAt) + ".tar.gz"
+}
+
+// RootDir returns the root directory of the files of the archive created at createdAt.
+func RootDir(createdAt time.Time) string {
+	return fileNamePrefix + createdAt.UTC().Format(timeFormat)
+}
+
+// entry is a regular file to archive.
+type entry struct {
+	path string
+	name string
+}
+
+// listEntries returns the regular files of sources, walking the directories, sorted by name.
+func listEntries(sources []Source) ([]entry, error) {
+	var entries []entry
+	for _, source := range sources {
+		err := filepath.WalkDir(source.Path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			relative, err := filepath.Rel(source.Path, p)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry{path: p, name: path.Join(source.Name, filepath.ToSlash(relative))})
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries, nil
+}
+
+// Write writes the files of sources and their manifest into the archive path created at createdAt, under the root
+// directory of the archive, and returns the manifest.  The manifest is the first file of the archive.
+func Write(archivePath string, createdAt time.Time, sources []Source) (*Manifest, error) {
+	entries, err := listEntries(sources)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{ManifestVersion: ManifestVersion, CreatedAt: createdAt.UTC(), Files: []File{}}
+	for _, e := range entries {
+		file, err := describe(e)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := os.OpenFile(archivePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+	gzipWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzipWriter)
+	root := RootDir(createdAt)
+	if err = writeFile(tarWriter, path.Join(root, ManifestFileName), int64(len(manifestData)), createdAt,
+		writeBytes(manifestData)); err != nil {
+		return nil, err
+	}
+	for i, e := range entries {
+		if err = copyFile(tarWriter, path.Join(root, e.name), manifest.Files[i].Size, createdAt, e.path); err != nil {
+			return nil, err
+		}
+	}
+	if err = tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, out.Close()
+}
+
+// describe returns the manifest entry of e.
+func describe(e entry) (File, error) {
+	f, err := os.Open(e.path)
+	if err != nil {
+		return File{}, err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return File{}, err
+	}
+	return File{Name: e.name, Size: size, SHA256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// writeBytes returns a function writing data.
+func writeBytes(data []byte) func(io.Writer) (int64, error) {
+	return func(w io.Writer) (int64, error) {
+		n, err := w.Write(data)
+		return int64(n), err
+	}
+}
+
+// copyFile writes the file at filePath into the archive as name, which fails when its size changed since it was
+// listed in the manifest.
+func copyFile(tarWriter *tar.Writer, name string, size int64, modTime time.Time, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeFile(tarWriter, name, size, modTime, func(w io.Writer) (int64, error) {
+		return io.CopyN(w, f, size)
+	})
+}
+
+// writeFile writes a regular file named name of size bytes into the archive, with the content written by write.
+func writeFile(tarWriter *tar.Writer, name string, size int64, modTime time.Time,
+	write func(io.Writer) (int64, error)) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     filePermissions,
+		ModTime:  modTime.Truncate(time.Second),
+		Format:   tar.FormatPAX,
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	if _, err := write(tarWriter); err != nil {
+		return fmt.Errorf("cannot archive %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package archive_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/archive"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "claim.json"), []byte("{}"), 0600))
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "bundles", "spec"), 0700))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "bundles", "spec", "logs.txt"), []byte("logs"), 0600))
+	createdAt := time.Date(2021, 11, 2, 10, 30, 0, 0, time.UTC)
+	assert.Equal(t, "tnf-artifacts-20211102T103000Z.tar.gz", archive.FileName(createdAt))
+
+	archivePath := filepath.Join(dir, archive.FileName(createdAt))
+	manifest, err := archive.Write(archivePath, createdAt, []archive.Source{
+		{Path: filepath.Join(dir, "claim.json"), Name: "claim.json"},
+		{Path: filepath.Join(dir, "bundles"), Name: "state-bundles"},
+		{Path: filepath.Join(dir, "missing"), Name: "missing"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, archive.ManifestVersion, manifest.ManifestVersion)
+	assert.Equal(t, []archive.File{
+		{Name: "claim.json", Size: 2, SHA256: "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"},
+		{Name: "state-bundles/spec/logs.txt", Size: 4,
+			SHA256: "98f38f12db221a8cf8ca7aadfdcd759b01d52eb4ebb3eedbb2d97e92805c6960"},
+	}, manifest.Files)
+
+	f, err := os.Open(archivePath)
+	assert.Nil(t, err)
+	defer f.Close()
+	gzipReader, err := gzip.NewReader(f)
+	assert.Nil(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	var names []string
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		names = append(names, header.Name)
+		if header.Name == "tnf-artifacts-20211102T103000Z/manifest.json" {
+			var written archive.Manifest
+			assert.Nil(t, json.NewDecoder(tarReader).Decode(&written))
+			assert.Equal(t, manifest.Files, written.Files)
+		}
+	}
+	assert.Equal(t, []string{
+		"tnf-artifacts-20211102T103000Z/manifest.json",
+		"tnf-artifacts-20211102T103000Z/claim.json",
+		"tnf-artifacts-20211102T103000Z/state-bundles/spec/logs.txt",
+	}, names)
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package canary
+
+import (
+	"bytes"
+	_ "embed" // the reference workload manifest is embedded
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/images"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+)
+
+const (
+	// DefaultNamespace is the namespace the reference workload is deployed in.
+	DefaultNamespace = "tnf-canary"
+	// StatusPassed is the status of a run whose reference workload passed.
+	StatusPassed = "passed"
+	// StatusEnvironmentSuspect is the status of a run whose reference workload failed.
+	StatusEnvironmentSuspect = "environment-suspect"
+
+	referenceName          = "tnf-reference"
+	verdictFilePermissions = 0644
+	ocBinaryName           = "oc"
+)
+
+// Suites are the suites testing the reference workload, those checking the workload rather than the cluster or the
+// operators.
+var Suites = []string{"access-control", "lifecycle", "networking", "observability"}
+
+//go:embed reference.yaml
+var referenceTemplate string
+
+// Verdict is the outcome of the test of the reference workload, recorded in the claim of the CNF run.
+type Verdict struct {
+	// Status is StatusPassed or StatusEnvironmentSuspect.
+	Status string `json:"status" yaml:"status"`
+	// Namespace is the namespace of the reference workload.
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// Reason tells why the environment is suspect.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// FailedTests are the test cases failed by the reference workload.
+	FailedTests []string `json:"failedTests,omitempty" yaml:"failedTests,omitempty"`
+	// Claim is the path of the claim file of the reference workload run.
+	Claim string `json:"claim,omitempty" yaml:"claim,omitempty"`
+}
+
+// IsSuspect returns true when the reference workload failed.
+func (v *Verdict) IsSuspect() bool {
+	return v.Status == StatusEnvironmentSuspect
+}
+
+// Manifest returns the manifest of the reference workload in namespace, using the partner image.
+func Manifest(namespace string) (string, error) {
+	image := ""
+	for _, i := range images.GetManifest() {
+		if i.Name == images.PartnerImageName {
+			image = i.Reference
+		}
+	}
+	tmpl, err := template.New("reference").Parse(referenceTemplate)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, struct{ Name, Namespace, Image string }{referenceName, namespace, image})
+	return b.String(), err
+}
+
+// Configuration returns the test configuration targeting the reference workload in namespace.
+func Configuration(namespace string) configsections.TestConfiguration {
+	return configsections.TestConfiguration{
+		TargetNameSpaces: []configsections.Namespace{{Name: namespace}},
+		TargetPodLabels:  []configsections.Label{{Prefix: "test-network-function.com", Name: "generic", Value: "target"}},
+	}
+}
+
+// runOc runs an oc command, with stdin as its input unless empty.
+func runOc(stdin string, args ...string) error {
+	cmd := interactive.Command(ocBinaryName, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("oc %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Deploy deploys the reference workload in namespace, creating it if needed, and waits for it to be ready.
+func Deploy(namespace string, timeout time.Duration) error {
+	manifest, err := Manifest(namespace)
+	if err != nil {
+		return err
+	}
+	if err = runOc("", "get", "namespace", namespace); err != nil {
+		if err = runOc("", "create", "namespace", namespace); err != nil {
+			return err
+		}
+	}
+	if err = runOc(manifest, "apply", "-f", "-"); err != nil {
+		return err
+	}
+	return runOc("", "rollout", "status", "deployment/"+referenceName, "-n", namespace, "--timeout="+timeout.String())
+}
+
+// Delete deletes the namespace of the reference workload, without waiting for its deletion.
+func Delete(namespace string) error {
+	return runOc("", "delete", "namespace", namespace, "--wait=false", "--ignore-not-found")
+}
+
+// isFailed returns true for any state that is neither a success, a skip nor a pending test (failed, panicked,
+// interrupted...).  The reference workload has no waivers.
+func isFailed(state string) bool {
+	return state != claim.StatePassed && state != claim.StateSkipped && state != claim.StatePending
+}
+
+// Evaluate returns the verdict of the results of the reference workload run.
+func Evaluate(results map[string][]schema.Result, namespace string) Verdict {
+	verdict := Verdict{Status: StatusPassed, Namespace: namespace}
+	for key, runs := range results {
+		for i := range runs {
+			if isFailed(runs[i].State) {
+				verdict.FailedTests = append(verdict.FailedTests, key)
+				break
+			}
+		}
+	}
+	if len(verdict.FailedTests) > 0 {
+		sort.Strings(verdict.FailedTests)
+		verdict.Status = StatusEnvironmentSuspect
+		verdict.Reason = fmt.Sprintf("the reference workload failed %d test cases", len(verdict.FailedTests))
+	}
+	return verdict
+}
+
+// Suspect returns the verdict of a run whose reference workload could not be tested, e.g. not deployed.
+func Suspect(namespace string, err error) Verdict {
+	return Verdict{Status: StatusEnvironmentSuspect, Namespace: namespace, Reason: err.Error()}
+}
+
+// WriteVerdict writes a verdict to a JSON file.
+func WriteVerdict(path string, verdict *Verdict) error {
+	payload, err := json.MarshalIndent(verdict, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, payload, verdictFilePermissions)
+}
+
+// ReadVerdict reads a verdict written by WriteVerdict.
+func ReadVerdict(path string) (*Verdict, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var verdict Verdict
+	if err = json.Unmarshal(contents, &verdict); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal canary verdict %s: %w", path, err)
+	}
+	return &verdict, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package canary_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/canary"
+	"gopkg.in/yaml.v2"
+)
+
+func TestManifest(t *testing.T) {
+	t.Setenv("TNF_PARTNER_REPO", "registry.example.com/tnf")
+	manifest, err := canary.Manifest("my-canary")
+	assert.Nil(t, err)
+	assert.Contains(t, manifest, "namespace: my-canary")
+	assert.Contains(t, manifest, "image: registry.example.com/tnf/cnf-test-partner:latest")
+	// the manifest is valid YAML, and the pods match the target labels of the configuration.
+	var deployment map[string]interface{}
+	assert.Nil(t, yaml.Unmarshal([]byte(manifest), &deployment))
+	assert.Equal(t, "Deployment", deployment["kind"])
+	config := canary.Configuration("my-canary")
+	assert.Equal(t, "my-canary", config.TargetNameSpaces[0].Name)
+	label := config.TargetPodLabels[0]
+	assert.True(t, strings.Contains(manifest, label.Prefix+"/"+label.Name+": "+label.Value))
+}
+
+func TestEvaluate(t *testing.T) {
+	results := map[string][]schema.Result{
+		"access-control-access-control-namespace": {{State: "passed"}},
+		"lifecycle-lifecycle-scaling":             {{State: "skipped"}},
+	}
+	verdict := canary.Evaluate(results, canary.DefaultNamespace)
+	assert.Equal(t, canary.Verdict{Status: canary.StatusPassed, Namespace: canary.DefaultNamespace}, verdict)
+	assert.False(t, verdict.IsSuspect())
+
+	results["lifecycle-lifecycle-pod-owner-type"] = []schema.Result{{State: "passed"}, {State: "failed"}}
+	results["networking-networking-icmpv4-connectivity"] = []schema.Result{{State: "panicked"}}
+	verdict = canary.Evaluate(results, canary.DefaultNamespace)
+	assert.True(t, verdict.IsSuspect())
+	assert.Equal(t, []string{"lifecycle-lifecycle-pod-owner-type", "networking-networking-icmpv4-connectivity"},
+		verdict.FailedTests)
+	assert.Equal(t, "the reference workload failed 2 test cases", verdict.Reason)
+}
+
+func TestVerdictFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "verdict.json")
+	verdict := canary.Suspect(canary.DefaultNamespace, errors.New("deployment timed out"))
+	assert.True(t, verdict.IsSuspect())
+	assert.Nil(t, canary.WriteVerdict(path, &verdict))
+	read, err := canary.ReadVerdict(path)
+	assert.Nil(t, err)
+	assert.Equal(t, verdict, *read)
+
+	_, err = canary.ReadVerdict(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,23 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+/*
+Package canary implements the canary mode of the runs: a built-in reference workload, compliant with the best
+practices checked by the workload suites, is deployed in its own namespace and tested before the CNF.  When the
+reference workload fails, the cluster or the test tooling is suspect, and the failures of the CNF may not be genuine
+non-compliances.
+*/
+package canary
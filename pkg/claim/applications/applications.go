@@ -0,0 +1,112 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package applications
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+)
+
+// Summary holds the results of the test cases for the pods of an application.
+type Summary struct {
+	Name        string   `json:"name"`
+	Pods        []string `json:"pods"`
+	Passed      int      `json:"passed"`
+	Failed      int      `json:"failed"`
+	Skipped     int      `json:"skipped"`
+	Waived      int      `json:"waived,omitempty"`
+	PassRate    float64  `json:"passRate"`
+	FailedTests []string `json:"failedTests,omitempty"`
+}
+
+// Summarize counts the results of the test cases for each application, given the "namespace/pod" names of its pods.
+// A failed test case counts as failed for the applications owning one of its failedTargets, by test case name (see
+// groups.TestCaseName), and as passed for the others.  A failed test case without recorded targets cannot be
+// attributed, and counts as failed for every application.  The summaries are sorted by application name.
+func Summarize(applications map[string][]string, results map[string][]schema.Result,
+	failedTargets map[string][]string) []Summary {
+	names := make([]string, 0, len(applications))
+	for name := range applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	summaries := make([]Summary, 0, len(names))
+	for _, name := range names {
+		pods := append([]string{}, applications[name]...)
+		sort.Strings(pods)
+		summary := Summary{Name: name, Pods: pods}
+		for key := range results {
+			for i := range results[key] {
+				result := &results[key][i]
+				switch result.State {
+				case claim.StatePassed:
+					summary.Passed++
+				case claim.StateSkipped, claim.StatePending:
+					summary.Skipped++
+				case claim.StateWaived:
+					summary.Waived++
+				default:
+					testCase := groups.TestCaseName(result.TestID)
+					targets := failedTargets[testCase]
+					if len(targets) > 0 && !ownsAny(pods, targets) {
+						summary.Passed++
+						continue
+					}
+					summary.Failed++
+					summary.FailedTests = append(summary.FailedTests, testCase)
+				}
+			}
+		}
+		sort.Strings(summary.FailedTests)
+		if executed := summary.Passed + summary.Failed; executed > 0 {
+			summary.PassRate = float64(summary.Passed) * 100 / float64(executed)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// ownsAny returns true when one of the targets, e.g. "namespace/pod" or "namespace/pod/container", is one of the pods.
+func ownsAny(pods, targets []string) bool {
+	for _, target := range targets {
+		for _, pod := range pods {
+			if target == pod || strings.HasPrefix(target, pod+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Print writes the summaries to w, e.g. the console at the end of the run.
+func Print(w io.Writer, summaries []Summary) {
+	if len(summaries) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Application summary:")
+	for i := range summaries {
+		summary := &summaries[i]
+		fmt.Fprintf(w, "  %s (%d pods): %d passed, %d failed, %d skipped, %.0f%% pass rate\n", summary.Name,
+			len(summary.Pods), summary.Passed, summary.Failed, summary.Skipped, summary.PassRate)
+	}
+}
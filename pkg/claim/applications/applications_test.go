@@ -0,0 +1,74 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package applications_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/applications"
+)
+
+func result(url, state string) schema.Result {
+	return schema.Result{TestID: &schema.Identifier{Url: url}, State: state}
+}
+
+func TestSummarize(t *testing.T) {
+	apps := map[string][]string{"du": {"tnf/du-1", "tnf/du-0"}, "cu": {"tnf/cu-0"}}
+	results := map[string][]schema.Result{
+		"access-control-host-pid": {result("http://test-network-function.com/testcases/access-control/host-pid", "failed")},
+		"platform-alteration-selinux": {
+			result("http://test-network-function.com/testcases/platform-alteration/selinux", "failed"),
+		},
+		"lifecycle-pod-owner-type":   {result("http://test-network-function.com/testcases/lifecycle/pod-owner-type", "passed")},
+		"lifecycle-startup-ordering": {result("http://test-network-function.com/testcases/lifecycle/startup-ordering", "skipped")},
+		"operator-install-status":    {result("http://test-network-function.com/testcases/operator/install-status", "waived")},
+	}
+	failedTargets := map[string][]string{
+		// pods and containers are attributed, nodes are not.
+		"access-control-host-pid": {"tnf/du-0", "tnf/du-10", "worker-0"},
+	}
+	summaries := applications.Summarize(apps, results, failedTargets)
+	assert.Equal(t, []applications.Summary{
+		// the unattributed selinux failure counts for every application.
+		{Name: "cu", Pods: []string{"tnf/cu-0"}, Passed: 2, Failed: 1, Skipped: 1, Waived: 1, PassRate: 200.0 / 3,
+			FailedTests: []string{"platform-alteration-selinux"}},
+		{Name: "du", Pods: []string{"tnf/du-0", "tnf/du-1"}, Passed: 1, Failed: 2, Skipped: 1, Waived: 1, PassRate: 100.0 / 3,
+			FailedTests: []string{"access-control-host-pid", "platform-alteration-selinux"}},
+	}, summaries)
+
+	failedTargets["platform-alteration-selinux"] = []string{"tnf/du-1/app"}
+	summaries = applications.Summarize(apps, results, failedTargets)
+	assert.Equal(t, 3, summaries[0].Passed)
+	assert.Equal(t, 100.0, summaries[0].PassRate)
+	assert.Equal(t, 2, summaries[1].Failed)
+}
+
+func TestSummarizeNoResult(t *testing.T) {
+	summaries := applications.Summarize(map[string][]string{"du": {"tnf/du-0"}}, nil, nil)
+	assert.Equal(t, []applications.Summary{{Name: "du", Pods: []string{"tnf/du-0"}}}, summaries)
+	assert.Empty(t, applications.Summarize(nil, nil, nil))
+}
+
+func TestPrint(t *testing.T) {
+	var buf bytes.Buffer
+	applications.Print(&buf, nil)
+	assert.Empty(t, buf.String())
+	applications.Print(&buf, []applications.Summary{{Name: "du", Pods: []string{"tnf/du-0"}, Passed: 3, Failed: 1, PassRate: 75}})
+	assert.Equal(t, "Application summary:\n  du (1 pods): 3 passed, 1 failed, 0 skipped, 75% pass rate\n", buf.String())
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claim
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+)
+
+// The states of the test case results of a claim.
+const (
+	StatePassed  = "passed"
+	StateFailed  = "failed"
+	StateSkipped = "skipped"
+	StatePending = "pending"
+	// StateWaived is the state of a failed result matching an active waiver, see waiver.Apply.
+	StateWaived = "waived"
+)
+
+// IsFailed returns true for any state that is neither a success, a skip, a pending test nor a waived failure, e.g.
+// failed, panicked or interrupted.
+func IsFailed(state string) bool {
+	return state != StatePassed && state != StateSkipped && state != StatePending && state != StateWaived
+}
+
+// ReadClaimFile reads and unmarshals the claim file at claimFilePath, migrated to the current format version, see
+// Migrate.
+func ReadClaimFile(claimFilePath string) (*schema.Root, error) {
+	contents, err := os.ReadFile(claimFilePath)
+	if err != nil {
+		return nil, err
+	}
+	var claimRoot schema.Root
+	err = json.Unmarshal(contents, &claimRoot)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal claim file %s: %w", claimFilePath, err)
+	}
+	if claimRoot.Claim == nil {
+		return nil, fmt.Errorf("claim file %s has no claim section", claimFilePath)
+	}
+	if err = Migrate(claimRoot.Claim); err != nil {
+		return nil, fmt.Errorf("claim file %s: %w", claimFilePath, err)
+	}
+	return &claimRoot, nil
+}
+
+// GetResults converts the generic results section of a claim into typed results, keyed like the claim file.
+func GetResults(c *schema.Claim) (map[string][]schema.Result, error) {
+	results := map[string][]schema.Result{}
+	if c == nil || c.Results == nil {
+		return results, nil
+	}
+	// Results are generalized to map[string]interface{} by the claim Go client; a JSON round trip is the simplest way
+	// to get back the typed form.
+	payload, err := json.Marshal(c.Results)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(payload, &results)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode claim results: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claim_test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+)
+
+func TestReadClaimFile(t *testing.T) {
+	claimRoot, err := claim.ReadClaimFile(path.Join("testdata", "claim.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, "v3.0.0", claimRoot.Claim.Versions.Tnf)
+
+	_, err = claim.ReadClaimFile(path.Join("testdata", "missing.json"))
+	assert.NotNil(t, err)
+}
+
+func TestGetResults(t *testing.T) {
+	claimRoot, err := claim.ReadClaimFile(path.Join("testdata", "claim.json"))
+	assert.Nil(t, err)
+	results, err := claim.GetResults(claimRoot.Claim)
+	assert.Nil(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, "failed", results["lifecycle-lifecycle-pod-owner-type"][0].State)
+	assert.Equal(t, "http://test-network-function.com/testcases/lifecycle/scaling", results["lifecycle-lifecycle-scaling"][0].TestID.Url)
+}
+
+func TestIsFailed(t *testing.T) {
+	assert.True(t, claim.IsFailed(claim.StateFailed))
+	assert.True(t, claim.IsFailed("panicked"))
+	for _, state := range []string{claim.StatePassed, claim.StateSkipped, claim.StatePending, claim.StateWaived} {
+		assert.False(t, claim.IsFailed(state))
+	}
+}
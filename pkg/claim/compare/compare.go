@@ -0,0 +1,251 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+)
+
+// missingValue is displayed for a field that only exists in one of the claims.
+const missingValue = "<none>"
+
+// TestChange describes a test whose state differs between the two claims.  OldState is empty for a new test, and
+// NewState is empty for a removed test.
+type TestChange struct {
+	Name     string
+	OldState string
+	NewState string
+}
+
+// FieldChange describes a version or configuration field whose value differs between the two claims.
+type FieldChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// Diff holds the differences between an old and a new claim.
+type Diff struct {
+	ChangedTests         []TestChange
+	AddedTests           []TestChange
+	RemovedTests         []TestChange
+	VersionChanges       []FieldChange
+	ConfigurationChanges []FieldChange
+}
+
+// IsEmpty returns true when the two claims have no difference.
+func (d *Diff) IsEmpty() bool {
+	return len(d.ChangedTests) == 0 && len(d.AddedTests) == 0 && len(d.RemovedTests) == 0 &&
+		len(d.VersionChanges) == 0 && len(d.ConfigurationChanges) == 0
+}
+
+// CompareFiles reads two claim files and compares them.
+func CompareFiles(oldClaimPath, newClaimPath string) (*Diff, error) {
+	oldClaim, err := claim.ReadClaimFile(oldClaimPath)
+	if err != nil {
+		return nil, err
+	}
+	newClaim, err := claim.ReadClaimFile(newClaimPath)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(oldClaim.Claim, newClaim.Claim)
+}
+
+// Compare computes the differences between two claims.
+func Compare(oldClaim, newClaim *schema.Claim) (*Diff, error) {
+	oldResults, err := claim.GetResults(oldClaim)
+	if err != nil {
+		return nil, err
+	}
+	newResults, err := claim.GetResults(newClaim)
+	if err != nil {
+		return nil, err
+	}
+	diff := &Diff{}
+	compareResults(diff, testStates(oldResults), testStates(newResults))
+	diff.VersionChanges = compareFields(flatten(toGeneric(oldClaim.Versions)), flatten(toGeneric(newClaim.Versions)))
+	diff.ConfigurationChanges = compareFields(flatten(toGeneric(oldClaim.Configurations)), flatten(toGeneric(newClaim.Configurations)))
+	return diff, nil
+}
+
+// testStates maps each test to its state.  A test recorded several times keeps its last state.
+func testStates(results map[string][]schema.Result) map[string]string {
+	states := map[string]string{}
+	for name, runs := range results {
+		if len(runs) > 0 {
+			states[name] = runs[len(runs)-1].State
+		}
+	}
+	return states
+}
+
+func compareResults(diff *Diff, oldStates, newStates map[string]string) {
+	for _, name := range sortedKeys(oldStates) {
+		newState, ok := newStates[name]
+		switch {
+		case !ok:
+			diff.RemovedTests = append(diff.RemovedTests, TestChange{Name: name, OldState: oldStates[name]})
+		case newState != oldStates[name]:
+			diff.ChangedTests = append(diff.ChangedTests, TestChange{Name: name, OldState: oldStates[name], NewState: newState})
+		}
+	}
+	for _, name := range sortedKeys(newStates) {
+		if _, ok := oldStates[name]; !ok {
+			diff.AddedTests = append(diff.AddedTests, TestChange{Name: name, NewState: newStates[name]})
+		}
+	}
+}
+
+// toGeneric converts a claim section into its generic JSON form, so that typed and untyped sections are flattened
+// the same way.
+func toGeneric(section interface{}) interface{} {
+	var generic interface{}
+	payload, err := json.Marshal(section)
+	if err != nil {
+		return nil
+	}
+	_ = json.Unmarshal(payload, &generic)
+	return generic
+}
+
+// flatten turns a generic JSON value into a map of dotted paths (e.g. "targetNameSpaces[0].name") to leaf values.
+func flatten(value interface{}) map[string]string {
+	fields := map[string]string{}
+	flattenInto(fields, "", value)
+	return fields
+}
+
+func flattenInto(fields map[string]string, prefix string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if prefix == "" {
+				flattenInto(fields, key, child)
+			} else {
+				flattenInto(fields, prefix+"."+key, child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenInto(fields, prefix+"["+strconv.Itoa(i)+"]", child)
+		}
+	case nil:
+		if prefix != "" {
+			fields[prefix] = "null"
+		}
+	default:
+		fields[prefix] = fmt.Sprint(v)
+	}
+}
+
+func compareFields(oldFields, newFields map[string]string) []FieldChange {
+	var changes []FieldChange
+	all := map[string]string{}
+	for k, v := range oldFields {
+		all[k] = v
+	}
+	for k, v := range newFields {
+		all[k] = v
+	}
+	for _, field := range sortedKeys(all) {
+		oldValue, oldOk := oldFields[field]
+		newValue, newOk := newFields[field]
+		if oldOk && newOk && oldValue == newValue {
+			continue
+		}
+		if !oldOk {
+			oldValue = missingValue
+		}
+		if !newOk {
+			newValue = missingValue
+		}
+		changes = append(changes, FieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+	}
+	return changes
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Write prints a human readable summary of the differences.
+func (d *Diff) Write(w io.Writer) error {
+	if d.IsEmpty() {
+		_, err := fmt.Fprintln(w, "No differences found.")
+		return err
+	}
+	sections := []struct {
+		title string
+		lines []string
+	}{
+		{"Tests that changed state", testLines(d.ChangedTests)},
+		{"New tests", testLines(d.AddedTests)},
+		{"Removed tests", testLines(d.RemovedTests)},
+		{"Version differences", fieldLines(d.VersionChanges)},
+		{"Configuration differences", fieldLines(d.ConfigurationChanges)},
+	}
+	for _, section := range sections {
+		if len(section.lines) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s (%d):\n", section.title, len(section.lines)); err != nil {
+			return err
+		}
+		for _, line := range section.lines {
+			if _, err := fmt.Fprintf(w, "  %s\n", line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func testLines(changes []TestChange) []string {
+	lines := make([]string, 0, len(changes))
+	for _, c := range changes {
+		switch {
+		case c.OldState == "":
+			lines = append(lines, fmt.Sprintf("%s: %s", c.Name, c.NewState))
+		case c.NewState == "":
+			lines = append(lines, fmt.Sprintf("%s: %s", c.Name, c.OldState))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: %s -> %s", c.Name, c.OldState, c.NewState))
+		}
+	}
+	return lines
+}
+
+func fieldLines(changes []FieldChange) []string {
+	lines := make([]string, 0, len(changes))
+	for _, c := range changes {
+		lines = append(lines, fmt.Sprintf("%s: %s -> %s", c.Field, c.OldValue, c.NewValue))
+	}
+	return lines
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package compare_test
+
+import (
+	"bytes"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/claim/compare"
+)
+
+var (
+	testOldClaimPath = path.Join("..", "testdata", "claim.json")
+	testNewClaimPath = path.Join("..", "testdata", "claim-new.json")
+)
+
+func TestCompareFiles(t *testing.T) {
+	diff, err := compare.CompareFiles(testOldClaimPath, testNewClaimPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []compare.TestChange{
+		{Name: "lifecycle-lifecycle-pod-owner-type", OldState: "failed", NewState: "passed"},
+	}, diff.ChangedTests)
+	assert.Equal(t, []compare.TestChange{
+		{Name: "networking-networking-icmpv4-connectivity", NewState: "passed"},
+	}, diff.AddedTests)
+	assert.Equal(t, []compare.TestChange{
+		{Name: "lifecycle-lifecycle-scaling", OldState: "skipped"},
+	}, diff.RemovedTests)
+	assert.Equal(t, []compare.FieldChange{
+		{Field: "tnf", OldValue: "v3.0.0", NewValue: "v3.1.0"},
+	}, diff.VersionChanges)
+	assert.Equal(t, []compare.FieldChange{
+		{Field: "targetNameSpaces[1].name", OldValue: "<none>", NewValue: "tnf2"},
+	}, diff.ConfigurationChanges)
+}
+
+func TestCompareFilesIdentical(t *testing.T) {
+	diff, err := compare.CompareFiles(testOldClaimPath, testOldClaimPath)
+	assert.Nil(t, err)
+	assert.True(t, diff.IsEmpty())
+	var out bytes.Buffer
+	assert.Nil(t, diff.Write(&out))
+	assert.Equal(t, "No differences found.\n", out.String())
+}
+
+func TestDiffWrite(t *testing.T) {
+	diff, err := compare.CompareFiles(testOldClaimPath, testNewClaimPath)
+	assert.Nil(t, err)
+	var out bytes.Buffer
+	assert.Nil(t, diff.Write(&out))
+	assert.Equal(t, `Tests that changed state (1):
+  lifecycle-lifecycle-pod-owner-type: failed -> passed
+New tests (1):
+  networking-networking-icmpv4-connectivity: passed
+Removed tests (1):
+  lifecycle-lifecycle-scaling: skipped
+Version differences (1):
+  tnf: v3.0.0 -> v3.1.0
+Configuration differences (1):
+  targetNameSpaces[1].name: <none> -> tnf2
+`, out.String())
+}
+
+func TestCompareFilesMissing(t *testing.T) {
+	_, err := compare.CompareFiles(testOldClaimPath, "does-not-exist.json")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,261 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package flakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+)
+
+const (
+	// fieldSeparator separates the keys of the path of a claim field, e.g. "configurations.testTarget.operators".
+	fieldSeparator = "."
+	jsonExtension  = ".json"
+)
+
+// DefaultVersionFields are the claim fields identifying the CNF version by default: the operators, whose CSV names
+// hold their version, the deployments under test, the chart versions of the Helm releases, the digests of the images
+// under test and their version label.  The images are recorded by the image certification and provenance tests.
+var DefaultVersionFields = []string{
+	"configurations.testTarget.operators",
+	"configurations.testTarget.deploymentsUnderTest",
+	"configurations.testTarget.helmReleases.chartVersion",
+	"rawResults.imageCertification.digest",
+	"rawResults.imageProvenance.version",
+}
+
+// Run is the claim of a past run.
+type Run struct {
+	// Path is the claim file, used to order the runs started at the same time.
+	Path  string
+	Claim *schema.Claim
+}
+
+// Flake holds the outcomes of a test case whose outcome varied between the runs of the same CNF version, counted over
+// the CNF versions in which it varied.
+type Flake struct {
+	Name     string `json:"name"`
+	Runs     int    `json:"runs"`
+	Passed   int    `json:"passed"`
+	Failed   int    `json:"failed"`
+	Versions int    `json:"versions"`
+	// Flips is the number of consecutive runs of the same CNF version with different outcomes.
+	Flips int `json:"flips"`
+	// FlipRate is the ratio of Flips to the pairs of consecutive runs, from 0 for a stable test case to 1 for a test
+	// case whose outcome changes on every run.
+	FlipRate float64 `json:"flipRate"`
+}
+
+// ReadHistory reads the claim files found in dir and its sub-directories, i.e. the JSON files with a claim section.
+// skip is called with the other JSON files, e.g. the state bundles, and the error reading them.
+func ReadHistory(dir string, skip func(path string, err error)) ([]Run, error) {
+	var runs []Run
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(path) != jsonExtension {
+			return err
+		}
+		root, err := claim.ReadClaimFile(path)
+		if err != nil {
+			skip(path, err)
+			return nil
+		}
+		runs = append(runs, Run{Path: path, Claim: root.Claim})
+		return nil
+	})
+	return runs, err
+}
+
+// Analyze groups the runs by CNF version, i.e. by the values of versionFields, dotted paths of claim fields, and
+// returns the test cases whose outcome varied within a CNF version, the test cases flipping the most first.  The
+// skipped, pending and waived results have no outcome.  It also returns the number of CNF versions.
+func Analyze(runs []Run, versionFields []string) (flakes []Flake, versions int, err error) {
+	byVersion := map[string][]*Run{}
+	for i := range runs {
+		version, err := getVersion(runs[i].Claim, versionFields)
+		if err != nil {
+			return nil, 0, fmt.Errorf("claim %s: %w", runs[i].Path, err)
+		}
+		byVersion[version] = append(byVersion[version], &runs[i])
+	}
+	byName := map[string]*Flake{}
+	for _, versionRuns := range byVersion {
+		sort.Slice(versionRuns, func(i, j int) bool {
+			if start := startTime(versionRuns[i]); start != startTime(versionRuns[j]) {
+				return start < startTime(versionRuns[j])
+			}
+			return versionRuns[i].Path < versionRuns[j].Path
+		})
+		outcomes, err := getOutcomes(versionRuns)
+		if err != nil {
+			return nil, 0, err
+		}
+		for name, passed := range outcomes {
+			addOutcomes(byName, name, passed)
+		}
+	}
+	flakes = make([]Flake, 0, len(byName))
+	for _, flake := range byName {
+		flake.FlipRate = float64(flake.Flips) / float64(flake.Runs-flake.Versions)
+		flakes = append(flakes, *flake)
+	}
+	sort.Slice(flakes, func(i, j int) bool {
+		if flakes[i].FlipRate != flakes[j].FlipRate {
+			return flakes[i].FlipRate > flakes[j].FlipRate
+		}
+		if flakes[i].Runs != flakes[j].Runs {
+			return flakes[i].Runs > flakes[j].Runs
+		}
+		return flakes[i].Name < flakes[j].Name
+	})
+	return flakes, len(byVersion), nil
+}
+
+// addOutcomes adds the outcomes of a test case in the runs of a CNF version, in order, if they vary.
+func addOutcomes(byName map[string]*Flake, name string, passed []bool) {
+	flips := 0
+	for i := 1; i < len(passed); i++ {
+		if passed[i] != passed[i-1] {
+			flips++
+		}
+	}
+	if flips == 0 {
+		return
+	}
+	flake, ok := byName[name]
+	if !ok {
+		flake = &Flake{Name: name}
+		byName[name] = flake
+	}
+	flake.Versions++
+	flake.Runs += len(passed)
+	flake.Flips += flips
+	for _, p := range passed {
+		if p {
+			flake.Passed++
+		} else {
+			flake.Failed++
+		}
+	}
+}
+
+// getOutcomes returns whether each test case passed in the runs, in order, the runs without an outcome being left
+// out.  A test case recorded several times in a run keeps its last result.
+func getOutcomes(runs []*Run) (map[string][]bool, error) {
+	outcomes := map[string][]bool{}
+	for _, run := range runs {
+		results, err := claim.GetResults(run.Claim)
+		if err != nil {
+			return nil, fmt.Errorf("claim %s: %w", run.Path, err)
+		}
+		for key, testResults := range results {
+			if len(testResults) == 0 {
+				continue
+			}
+			result := &testResults[len(testResults)-1]
+			switch result.State {
+			case claim.StateSkipped, claim.StatePending, claim.StateWaived:
+				continue
+			}
+			name := groups.TestCaseName(result.TestID)
+			if name == "" {
+				name = key
+			}
+			outcomes[name] = append(outcomes[name], result.State == claim.StatePassed)
+		}
+	}
+	return outcomes, nil
+}
+
+// getVersion returns the values of the fields of c, as JSON, the missing fields being null, see getField.
+func getVersion(c *schema.Claim, fields []string) (string, error) {
+	// the claim itself cannot be marshaled without its required sections.
+	generic := map[string]interface{}{
+		"configurations": c.Configurations,
+		"rawResults":     c.RawResults,
+	}
+	if c.Versions != nil {
+		var versions map[string]interface{}
+		payload, err := json.Marshal(c.Versions)
+		if err != nil {
+			return "", err
+		}
+		if err := json.Unmarshal(payload, &versions); err != nil {
+			return "", err
+		}
+		generic["versions"] = versions
+	}
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		values[i] = getField(generic, strings.Split(field, fieldSeparator))
+	}
+	// the keys of the JSON objects are sorted, the version does not depend on their order in the claim.
+	version, err := json.Marshal(values)
+	return string(version), err
+}
+
+// getField returns the field of value at the path of keys, nil when missing.  The rest of the path applies to each
+// element of the arrays along the path, e.g. "helmReleases.chartVersion" is the array of the chart versions.
+func getField(value interface{}, keys []string) interface{} {
+	if len(keys) == 0 {
+		return value
+	}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return getField(typed[keys[0]], keys[1:])
+	case []interface{}:
+		fields := make([]interface{}, len(typed))
+		for i := range typed {
+			fields[i] = getField(typed[i], keys)
+		}
+		return fields
+	default:
+		return nil
+	}
+}
+
+func startTime(run *Run) string {
+	if run.Claim.Metadata == nil {
+		return ""
+	}
+	return run.Claim.Metadata.StartTime
+}
+
+// Print writes the ranking of the flaky test cases to w, at most top of them unless top is 0.
+func Print(w io.Writer, flakes []Flake, runs, versions, top int) {
+	if len(flakes) == 0 {
+		fmt.Fprintf(w, "No flaky test case in %d runs of %d CNF versions\n", runs, versions)
+		return
+	}
+	fmt.Fprintf(w, "Flaky test cases in %d runs of %d CNF versions, by decreasing flip rate:\n", runs, versions)
+	for i := range flakes {
+		if top > 0 && i == top {
+			fmt.Fprintf(w, "  ... %d more\n", len(flakes)-top)
+			break
+		}
+		flake := &flakes[i]
+		fmt.Fprintf(w, "  %s: flip rate %.0f%%, %d passed, %d failed, in %d CNF versions\n", flake.Name,
+			flake.FlipRate*100, flake.Passed, flake.Failed, flake.Versions)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package flakes_test
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/flakes"
+)
+
+const (
+	testScalingURL  = "http://test-network-function.com/testcases/lifecycle/scaling"
+	testOwnerURL    = "http://test-network-function.com/testcases/lifecycle/pod-owner-type"
+	testRecreateURL = "http://test-network-function.com/testcases/lifecycle/pod-recreation"
+)
+
+var testClaimDir = path.Join("..", "testdata")
+
+// newRun returns a run of the CNF version of the operator csv, with the states of the test cases by URL.
+func newRun(name, startTime, csv string, states map[string]string) flakes.Run {
+	results := map[string]interface{}{}
+	for url, state := range states {
+		results[url] = []interface{}{map[string]interface{}{
+			"CapturedTestOutput": "",
+			"duration":           0,
+			"failureLineContent": "",
+			"failureLocation":    "",
+			"failureReason":      "",
+			"startTime":          startTime,
+			"state":              state,
+			"testID":             map[string]interface{}{"url": url, "version": "v1.0.0"},
+			"testText":           "",
+		}}
+	}
+	return flakes.Run{Path: name, Claim: &schema.Claim{
+		Configurations: map[string]interface{}{"testTarget": map[string]interface{}{
+			"operators": []interface{}{map[string]interface{}{"name": csv, "namespace": "tnf"}},
+		}},
+		Metadata: &schema.Metadata{StartTime: startTime},
+		Results:  results,
+	}}
+}
+
+func TestAnalyze(t *testing.T) {
+	runs := []flakes.Run{
+		newRun("3", "2021-11-03", "cnf.v1", map[string]string{testScalingURL: "passed", testOwnerURL: "failed", testRecreateURL: "passed"}),
+		newRun("1", "2021-11-01", "cnf.v1", map[string]string{testScalingURL: "failed", testOwnerURL: "failed", testRecreateURL: "passed"}),
+		newRun("2", "2021-11-02", "cnf.v1", map[string]string{testScalingURL: "passed", testOwnerURL: "skipped", testRecreateURL: "failed"}),
+		// a new CNF version fixing the owner: not a flake.
+		newRun("4", "2021-11-04", "cnf.v2", map[string]string{testScalingURL: "passed", testOwnerURL: "passed", testRecreateURL: "failed"}),
+		newRun("5", "2021-11-05", "cnf.v2", map[string]string{testScalingURL: "failed", testOwnerURL: "passed", testRecreateURL: "passed"}),
+	}
+	results, versions, err := flakes.Analyze(runs, flakes.DefaultVersionFields)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, versions)
+	assert.Equal(t, []flakes.Flake{
+		// failed, passed, failed then passed, failed.
+		{Name: "lifecycle-pod-recreation", Runs: 5, Passed: 3, Failed: 2, Versions: 2, Flips: 3, FlipRate: 1},
+		// failed, passed, passed then passed, failed.
+		{Name: "lifecycle-scaling", Runs: 5, Passed: 3, Failed: 2, Versions: 2, Flips: 2, FlipRate: 2.0 / 3},
+	}, results)
+
+	var out bytes.Buffer
+	flakes.Print(&out, results, len(runs), versions, 1)
+	assert.Equal(t, `Flaky test cases in 5 runs of 2 CNF versions, by decreasing flip rate:
+  lifecycle-pod-recreation: flip rate 100%, 3 passed, 2 failed, in 2 CNF versions
+  ... 1 more
+`, out.String())
+
+	// all the runs are of the same version without version field.
+	results, versions, err = flakes.Analyze(runs, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, versions)
+	assert.Len(t, results, 3)
+}
+
+func TestAnalyzeNoFlake(t *testing.T) {
+	results, versions, err := flakes.Analyze(nil, flakes.DefaultVersionFields)
+	assert.Nil(t, err)
+	assert.Empty(t, results)
+	var out bytes.Buffer
+	flakes.Print(&out, results, 0, versions, 0)
+	assert.Equal(t, "No flaky test case in 0 runs of 0 CNF versions\n", out.String())
+}
+
+func TestReadHistory(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"claim.json", "claim-new.json"} {
+		payload, err := os.ReadFile(filepath.Join(testClaimDir, name))
+		assert.Nil(t, err)
+		assert.Nil(t, os.MkdirAll(filepath.Join(dir, name+".d"), 0o755))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, name+".d", "claim.json"), payload, 0o600))
+	}
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "verdict.json"), []byte(`{"verdict": "passed"}`), 0o600))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a claim"), 0o600))
+
+	var skipped []string
+	runs, err := flakes.ReadHistory(dir, func(path string, err error) {
+		skipped = append(skipped, filepath.Base(path))
+	})
+	assert.Nil(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, []string{"verdict.json"}, skipped)
+
+	results, versions, err := flakes.Analyze(runs, flakes.DefaultVersionFields)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, versions)
+	assert.Equal(t, []flakes.Flake{
+		{Name: "lifecycle-pod-owner-type", Runs: 2, Passed: 1, Failed: 1, Versions: 1, Flips: 1, FlipRate: 1},
+	}, results)
+
+	_, err = flakes.ReadHistory(filepath.Join(dir, "missing"), nil)
+	assert.NotNil(t, err)
+}
+
+func TestAnalyzeImageDigests(t *testing.T) {
+	withImage := func(run flakes.Run, digest, version string) flakes.Run {
+		run.Claim.RawResults = map[string]interface{}{
+			"imageCertification": []interface{}{map[string]interface{}{"registry": "quay.io", "repository": "tnf/cnf",
+				"digest": digest, "containers": []interface{}{"tnf/" + run.Path + "/cnf"}, "certified": true}},
+			"imageProvenance": []interface{}{map[string]interface{}{"image": "quay.io/tnf/cnf@" + digest,
+				"version": version}},
+		}
+		return run
+	}
+	// the pods and the operator are the same, the images differ.
+	runs := []flakes.Run{
+		withImage(newRun("1", "2021-11-01", "cnf.v1", map[string]string{testScalingURL: "failed"}), "sha256:1", "1.0"),
+		withImage(newRun("2", "2021-11-02", "cnf.v1", map[string]string{testScalingURL: "passed"}), "sha256:2", "1.1"),
+		withImage(newRun("3", "2021-11-03", "cnf.v1", map[string]string{testScalingURL: "passed"}), "sha256:2", "1.1"),
+	}
+	results, versions, err := flakes.Analyze(runs, flakes.DefaultVersionFields)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, versions)
+	assert.Empty(t, results)
+
+	// the same image rebuilt with the same version label.
+	results, versions, err = flakes.Analyze(runs, []string{"rawResults.imageProvenance.version"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, versions)
+	assert.Empty(t, results)
+	runs[1] = withImage(runs[1], "sha256:3", "1.0")
+	results, versions, err = flakes.Analyze(runs, []string{"rawResults.imageProvenance.version"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, versions)
+	assert.Len(t, results, 1)
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package groups
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	// testGroupsConfigurationKey is the key of the test groups in the claim configurations.
+	testGroupsConfigurationKey = "testGroups"
+)
+
+// Summary holds the results of the test cases of a group.
+type Summary struct {
+	Name        string   `json:"name"`
+	Owners      []string `json:"owners,omitempty"`
+	Passed      int      `json:"passed"`
+	Failed      int      `json:"failed"`
+	Skipped     int      `json:"skipped"`
+	Waived      int      `json:"waived,omitempty"`
+	FailedTests []string `json:"failedTests,omitempty"`
+}
+
+// TestCaseName returns the "<suite>-<name>" name of a test case from its identifier URL
+// http://test-network-function.com/testcases/<suite>/<name>.
+func TestCaseName(id *schema.Identifier) string {
+	if id == nil || id.Url == "" {
+		return ""
+	}
+	return path.Base(path.Dir(id.Url)) + "-" + path.Base(id.Url)
+}
+
+// compile builds one anchored regular expression out of the test case patterns of a group.
+func compile(group *configsections.TestGroup) (*regexp.Regexp, error) {
+	if len(group.TestCases) == 0 {
+		return nil, fmt.Errorf("test group %s has no test case", group.Name)
+	}
+	re, err := regexp.Compile("^(" + strings.Join(group.TestCases, "|") + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid test case pattern in test group %s: %w", group.Name, err)
+	}
+	return re, nil
+}
+
+// Summarize counts the results of each group.  A test case may belong to several groups.
+func Summarize(testGroups []configsections.TestGroup, results map[string][]schema.Result) ([]Summary, error) {
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	summaries := make([]Summary, 0, len(testGroups))
+	for i := range testGroups {
+		group := &testGroups[i]
+		re, err := compile(group)
+		if err != nil {
+			return nil, err
+		}
+		summary := Summary{Name: group.Name, Owners: group.Owners}
+		for _, key := range keys {
+			for j := range results[key] {
+				result := &results[key][j]
+				if !re.MatchString(TestCaseName(result.TestID)) {
+					continue
+				}
+				switch result.State {
+				case claim.StatePassed:
+					summary.Passed++
+				case claim.StateSkipped, claim.StatePending:
+					summary.Skipped++
+				case claim.StateWaived:
+					summary.Waived++
+				default:
+					summary.Failed++
+					summary.FailedTests = append(summary.FailedTests, key)
+				}
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// GetTestGroups returns the test groups of the configuration recorded in a claim.
+func GetTestGroups(c *schema.Claim) ([]configsections.TestGroup, error) {
+	var testGroups []configsections.TestGroup
+	section, ok := c.Configurations[testGroupsConfigurationKey]
+	if !ok {
+		return testGroups, nil
+	}
+	payload, err := json.Marshal(section)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(payload, &testGroups)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode claim test groups: %w", err)
+	}
+	return testGroups, nil
+}
+
+// SummarizeClaim breaks down the results of a claim per the test groups of its configuration.
+func SummarizeClaim(c *schema.Claim) ([]Summary, error) {
+	testGroups, err := GetTestGroups(c)
+	if err != nil {
+		return nil, err
+	}
+	results, err := claim.GetResults(c)
+	if err != nil {
+		return nil, err
+	}
+	return Summarize(testGroups, results)
+}
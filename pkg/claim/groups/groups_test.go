@@ -0,0 +1,61 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package groups_test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+var testClaimPath = path.Join("..", "testdata", "claim.json")
+
+func TestTestCaseName(t *testing.T) {
+	assert.Equal(t, "access-control-namespace",
+		groups.TestCaseName(&schema.Identifier{Url: "http://test-network-function.com/testcases/access-control/namespace"}))
+	assert.Equal(t, "", groups.TestCaseName(nil))
+}
+
+func TestSummarizeClaim(t *testing.T) {
+	claimRoot, err := claim.ReadClaimFile(testClaimPath)
+	assert.Nil(t, err)
+	summaries, err := groups.SummarizeClaim(claimRoot.Claim)
+	assert.Nil(t, err)
+	assert.Equal(t, []groups.Summary{
+		{Name: "security-team", Owners: []string{"security@example.com"}, Passed: 1},
+		{Name: "netops", Owners: []string{"netops@example.com"}, Failed: 1,
+			FailedTests: []string{"lifecycle-lifecycle-pod-owner-type"}},
+	}, summaries)
+}
+
+func TestSummarizeNoGroup(t *testing.T) {
+	summaries, err := groups.SummarizeClaim(&schema.Claim{})
+	assert.Nil(t, err)
+	assert.Empty(t, summaries)
+}
+
+func TestSummarizeInvalidGroup(t *testing.T) {
+	_, err := groups.Summarize([]configsections.TestGroup{{Name: "empty"}}, nil)
+	assert.NotNil(t, err)
+	_, err = groups.Summarize([]configsections.TestGroup{{Name: "invalid", TestCases: []string{"access-control-("}}}, nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package multicluster
+
+import (
+	"fmt"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+)
+
+// ClustersKey is the key of the sections of the clusters in the raw results of a merged claim.
+const ClustersKey = "clusters"
+
+// ClusterClaim is the claim of the run against one of the clusters.
+type ClusterClaim struct {
+	// Name is the name of the cluster, as in the clusters section of the configuration.
+	Name string
+	// Role is the role of the cluster, e.g. hub or spoke.
+	Role string
+	// Context is the kubeconfig context of the cluster.
+	Context string
+	Claim   *schema.Claim
+}
+
+// Section is the section of a cluster in the raw results of a merged claim.
+type Section struct {
+	Role       string                 `json:"role,omitempty"`
+	Context    string                 `json:"context,omitempty"`
+	Metadata   *schema.Metadata       `json:"metadata,omitempty"`
+	Versions   *schema.Versions       `json:"versions,omitempty"`
+	RawResults map[string]interface{} `json:"rawResults"`
+	Results    map[string]interface{} `json:"results,omitempty"`
+}
+
+// Merge merges the claims of the clusters into a single claim.  The configurations and the nodes of each cluster are
+// keyed by the cluster name, and the raw results hold a section per cluster under ClustersKey.  The results of a test
+// case gather its results on all the clusters, in the order of claims, so that a test case failing on any cluster
+// fails in the merged claim;  the results of each cluster remain in its section.  The versions are the ones of the
+// first claim, e.g. the hub, and the metadata span all the runs.
+func Merge(claims []ClusterClaim) (*schema.Root, error) {
+	if len(claims) == 0 {
+		return nil, fmt.Errorf("no claim to merge")
+	}
+	merged := &schema.Claim{
+		Configurations: map[string]interface{}{},
+		Nodes:          map[string]interface{}{},
+		RawResults:     map[string]interface{}{},
+		Metadata:       &schema.Metadata{},
+		Versions:       claims[0].Claim.Versions,
+	}
+	sections := map[string]Section{}
+	results := map[string][]schema.Result{}
+	for i := range claims {
+		cluster := &claims[i]
+		if _, ok := sections[cluster.Name]; ok {
+			return nil, fmt.Errorf("the claim of cluster %s is merged twice", cluster.Name)
+		}
+		clusterResults, err := claim.GetResults(cluster.Claim)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", cluster.Name, err)
+		}
+		for key := range clusterResults {
+			results[key] = append(results[key], clusterResults[key]...)
+		}
+		merged.Configurations[cluster.Name] = cluster.Claim.Configurations
+		merged.Nodes[cluster.Name] = cluster.Claim.Nodes
+		sections[cluster.Name] = Section{
+			Role:       cluster.Role,
+			Context:    cluster.Context,
+			Metadata:   cluster.Claim.Metadata,
+			Versions:   cluster.Claim.Versions,
+			RawResults: cluster.Claim.RawResults,
+			Results:    cluster.Claim.Results,
+		}
+		mergeMetadata(merged.Metadata, cluster.Claim.Metadata)
+	}
+	merged.RawResults[ClustersKey] = sections
+	merged.RawResults[claim.FormatVersionKey] = claim.FormatVersion
+	merged.Results = map[string]interface{}{}
+	for key := range results {
+		merged.Results[key] = results[key]
+	}
+	return &schema.Root{Claim: merged}, nil
+}
+
+// mergeMetadata extends the span of merged with the one of a run.  The times are ISO 8601 UTC times, which sort like
+// strings.
+func mergeMetadata(merged, metadata *schema.Metadata) {
+	if metadata == nil {
+		return
+	}
+	if merged.StartTime == "" || (metadata.StartTime != "" && metadata.StartTime < merged.StartTime) {
+		merged.StartTime = metadata.StartTime
+	}
+	if metadata.EndTime > merged.EndTime {
+		merged.EndTime = metadata.EndTime
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package multicluster_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/multicluster"
+)
+
+func TestMerge(t *testing.T) {
+	hub, err := claim.ReadClaimFile(filepath.Join("..", "testdata", "claim.json"))
+	assert.Nil(t, err)
+	spoke, err := claim.ReadClaimFile(filepath.Join("..", "testdata", "claim-new.json"))
+	assert.Nil(t, err)
+	spoke.Claim.Metadata.EndTime = "2021-11-02T10:20:00+00:00"
+
+	_, err = multicluster.Merge(nil)
+	assert.NotNil(t, err)
+	_, err = multicluster.Merge([]multicluster.ClusterClaim{{Name: "hub", Claim: hub.Claim}, {Name: "hub", Claim: spoke.Claim}})
+	assert.NotNil(t, err)
+
+	root, err := multicluster.Merge([]multicluster.ClusterClaim{
+		{Name: "hub", Role: "hub", Context: "hub-admin", Claim: hub.Claim},
+		{Name: "spoke-1", Role: "spoke", Context: "spoke-1-admin", Claim: spoke.Claim},
+	})
+	assert.Nil(t, err)
+	merged := root.Claim
+	assert.Equal(t, "2021-11-02T10:00:00+00:00", merged.Metadata.StartTime)
+	assert.Equal(t, "2021-11-02T10:20:00+00:00", merged.Metadata.EndTime)
+	assert.Equal(t, hub.Claim.Versions, merged.Versions)
+	assert.Equal(t, hub.Claim.Configurations, merged.Configurations["hub"])
+	assert.Equal(t, spoke.Claim.Nodes, merged.Nodes["spoke-1"])
+
+	// the results of the test cases run on both clusters are gathered.
+	results, err := claim.GetResults(merged)
+	assert.Nil(t, err)
+	assert.Len(t, results, 4)
+	assert.Len(t, results["access-control-access-control-namespace"], 2)
+	assert.Len(t, results["lifecycle-lifecycle-scaling"], 1)
+	assert.Len(t, results["networking-networking-icmpv4-connectivity"], 1)
+
+	sections, ok := merged.RawResults[multicluster.ClustersKey].(map[string]multicluster.Section)
+	assert.True(t, ok)
+	assert.Equal(t, "spoke", sections["spoke-1"].Role)
+	assert.Equal(t, "spoke-1-admin", sections["spoke-1"].Context)
+	assert.Equal(t, spoke.Claim.Versions, sections["spoke-1"].Versions)
+	assert.Equal(t, hub.Claim.Results, sections["hub"].Results)
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package remediation
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+)
+
+// Theme groups the test cases sharing the same kind of remediation.
+type Theme string
+
+const (
+	// SecurityContext covers the privileges, capabilities, service accounts and RBAC of the pods.
+	SecurityContext Theme = "security context"
+	// Probes covers the liveness, readiness and startup probes of the containers.
+	Probes Theme = "probes"
+	// Images covers the base images, certification and content of the container images.
+	Images Theme = "images"
+	// Networking covers the connectivity and services of the pods.
+	Networking Theme = "networking"
+	// Platform covers the alterations of the nodes, e.g. kernel, hugepages or sysctls.
+	Platform Theme = "platform"
+	// Lifecycle covers the scheduling, scaling and termination of the pods.
+	Lifecycle Theme = "lifecycle"
+	// Operators covers the installation of the operators.
+	Operators Theme = "operators"
+	// Observability covers the logging and the status of the custom resources.
+	Observability Theme = "observability"
+	// Other covers the test cases without a theme.
+	Other Theme = "other"
+)
+
+// TargetFailures counts the failures of a target, e.g. a pod or a node.
+type TargetFailures struct {
+	Target   string `json:"target"`
+	Failures int    `json:"failures"`
+}
+
+// Summary holds the failures of the test cases of a theme.
+type Summary struct {
+	Theme       Theme            `json:"theme"`
+	Failed      int              `json:"failed"`
+	FailedTests []string         `json:"failedTests"`
+	TopTargets  []TargetFailures `json:"topTargets,omitempty"`
+}
+
+// Summarize groups the failed results per theme, as returned by themeOf for the test case names (see
+// groups.TestCaseName).  failedTargets are the targets which failed, by test case name, the topTargets targets with
+// the most failures are kept per theme.  The summaries are sorted by decreasing number of failures, the themes without
+// failures are omitted.
+func Summarize(results map[string][]schema.Result, themeOf func(id *schema.Identifier) Theme,
+	failedTargets map[string][]string, topTargets int) []Summary {
+	byTheme := map[Theme]*Summary{}
+	targets := map[Theme]map[string]int{}
+	for key := range results {
+		for i := range results[key] {
+			result := &results[key][i]
+			switch result.State {
+			case claim.StatePassed, claim.StateSkipped, claim.StatePending, claim.StateWaived:
+				continue
+			}
+			theme := themeOf(result.TestID)
+			summary, ok := byTheme[theme]
+			if !ok {
+				summary = &Summary{Theme: theme}
+				byTheme[theme] = summary
+				targets[theme] = map[string]int{}
+			}
+			summary.Failed++
+			name := groups.TestCaseName(result.TestID)
+			summary.FailedTests = append(summary.FailedTests, name)
+			for _, target := range failedTargets[name] {
+				targets[theme][target]++
+			}
+		}
+	}
+	summaries := make([]Summary, 0, len(byTheme))
+	for theme, summary := range byTheme {
+		sort.Strings(summary.FailedTests)
+		summary.TopTargets = top(targets[theme], topTargets)
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Failed != summaries[j].Failed {
+			return summaries[i].Failed > summaries[j].Failed
+		}
+		return summaries[i].Theme < summaries[j].Theme
+	})
+	return summaries
+}
+
+// top returns the n targets with the most failures, by decreasing number of failures.
+func top(failures map[string]int, n int) []TargetFailures {
+	targets := make([]TargetFailures, 0, len(failures))
+	for target, count := range failures {
+		targets = append(targets, TargetFailures{Target: target, Failures: count})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Failures != targets[j].Failures {
+			return targets[i].Failures > targets[j].Failures
+		}
+		return targets[i].Target < targets[j].Target
+	})
+	if len(targets) > n {
+		targets = targets[:n]
+	}
+	return targets
+}
+
+// Print writes the summaries to w, e.g. the console at the end of the run.
+func Print(w io.Writer, summaries []Summary) {
+	if len(summaries) == 0 {
+		fmt.Fprintln(w, "Remediation summary: no failure")
+		return
+	}
+	fmt.Fprintln(w, "Remediation summary, by number of failures:")
+	for i := range summaries {
+		summary := &summaries[i]
+		fmt.Fprintf(w, "  %s: %d failed\n", summary.Theme, summary.Failed)
+		fmt.Fprintf(w, "    test cases: %s\n", strings.Join(unique(summary.FailedTests), ", "))
+		if len(summary.TopTargets) > 0 {
+			targets := make([]string, len(summary.TopTargets))
+			for j, target := range summary.TopTargets {
+				targets[j] = fmt.Sprintf("%s (%d)", target.Target, target.Failures)
+			}
+			fmt.Fprintf(w, "    top targets: %s\n", strings.Join(targets, ", "))
+		}
+	}
+	fmt.Fprintln(w, `Run "tnf catalog describe <test case>" for the suggested remediation of a test case.`)
+}
+
+// unique removes the consecutive duplicates of a sorted list.
+func unique(values []string) []string {
+	var uniq []string
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			uniq = append(uniq, value)
+		}
+	}
+	return uniq
+}
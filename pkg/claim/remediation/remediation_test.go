@@ -0,0 +1,77 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package remediation_test
+
+import (
+	"bytes"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/remediation"
+)
+
+func result(suite, name, state string) schema.Result {
+	return schema.Result{State: state, TestID: &schema.Identifier{Url: "http://test-network-function.com/testcases/" + suite + "/" + name}}
+}
+
+func themeOf(id *schema.Identifier) remediation.Theme {
+	if path.Base(path.Dir(id.Url)) == "networking" {
+		return remediation.Networking
+	}
+	return remediation.SecurityContext
+}
+
+func TestSummarize(t *testing.T) {
+	results := map[string][]schema.Result{
+		"access-control-namespace":     {result("access-control", "namespace", "failed")},
+		"access-control-host-resource": {result("access-control", "host-resource", "failed"), result("access-control", "host-resource", "passed")},
+		"access-control-pod-role":      {result("access-control", "pod-role", "waived")},
+		"networking-icmpv4":            {result("networking", "icmpv4-connectivity", "failed")},
+		"networking-nodeport":          {result("networking", "service-type", "skipped")},
+	}
+	failedTargets := map[string][]string{
+		"access-control-namespace":     {"tnf/test-0", "tnf/test-1"},
+		"access-control-host-resource": {"tnf/test-1", "tnf/test-2"},
+	}
+	summaries := remediation.Summarize(results, themeOf, failedTargets, 2)
+	assert.Equal(t, []remediation.Summary{
+		{Theme: remediation.SecurityContext, Failed: 2,
+			FailedTests: []string{"access-control-host-resource", "access-control-namespace"},
+			TopTargets:  []remediation.TargetFailures{{Target: "tnf/test-1", Failures: 2}, {Target: "tnf/test-0", Failures: 1}}},
+		{Theme: remediation.Networking, Failed: 1, FailedTests: []string{"networking-icmpv4-connectivity"},
+			TopTargets: []remediation.TargetFailures{}},
+	}, summaries)
+
+	var out bytes.Buffer
+	remediation.Print(&out, summaries)
+	assert.Contains(t, out.String(), "security context: 2 failed")
+	assert.Contains(t, out.String(), "top targets: tnf/test-1 (2), tnf/test-0 (1)")
+	assert.Contains(t, out.String(), "networking: 1 failed")
+}
+
+func TestSummarizeNoFailure(t *testing.T) {
+	summaries := remediation.Summarize(map[string][]schema.Result{
+		"networking-icmpv4": {result("networking", "icmpv4-connectivity", "passed")},
+	}, themeOf, nil, 3)
+	assert.Empty(t, summaries)
+
+	var out bytes.Buffer
+	remediation.Print(&out, summaries)
+	assert.Equal(t, "Remediation summary: no failure\n", out.String())
+}
@@ -0,0 +1,224 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package report
+
+import (
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+	"github.com/test-network-function/test-network-function/pkg/claim/waiver"
+	"github.com/test-network-function/test-network-function/pkg/junit"
+)
+
+const (
+	reportFilePermissions = 0644
+	nodeSummaryKey        = "nodeSummary"
+	nodeRoleLabelPrefix   = "node-role.kubernetes.io/"
+)
+
+// Result is a single test result row of the report.
+type Result struct {
+	Name            string
+	State           string
+	Duration        time.Duration
+	TestText        string
+	FailureReason   string
+	FailureLocation string
+	Output          string
+}
+
+// Suite groups the results of one test suite.
+type Suite struct {
+	Name    string
+	Passed  int
+	Failed  int
+	Skipped int
+	Waived  int
+	Results []Result
+}
+
+// Node is a row of the node inventory.
+type Node struct {
+	Name           string
+	Roles          string
+	OSImage        string
+	KernelVersion  string
+	KubeletVersion string
+}
+
+// Report is the data model rendered by the HTML template.
+type Report struct {
+	Metadata *schema.Metadata
+	Versions *schema.Versions
+	Suites   []Suite
+	Groups   []groups.Summary
+	Waivers  []waiver.Applied
+	Nodes    []Node
+}
+
+// NewReport builds the report data model from a claim.
+func NewReport(c *schema.Claim) (*Report, error) {
+	results, err := claim.GetResults(c)
+	if err != nil {
+		return nil, err
+	}
+	summaries, err := groups.SummarizeClaim(c)
+	if err != nil {
+		return nil, err
+	}
+	waivers, err := waiver.GetApplied(c, waiver.RawResultsKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{
+		Metadata: c.Metadata,
+		Versions: c.Versions,
+		Suites:   buildSuites(results),
+		Groups:   summaries,
+		Waivers:  waivers,
+		Nodes:    buildNodes(c.Nodes),
+	}, nil
+}
+
+// buildSuites groups results per suite, sorted by suite and test names.
+func buildSuites(results map[string][]schema.Result) []Suite {
+	suites := map[string]*Suite{}
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for i := range results[key] {
+			r := &results[key][i]
+			suiteName := junit.SuiteName(r.TestID)
+			suite, ok := suites[suiteName]
+			if !ok {
+				suite = &Suite{Name: suiteName}
+				suites[suiteName] = suite
+			}
+			switch r.State {
+			case claim.StatePassed:
+				suite.Passed++
+			case claim.StateSkipped, claim.StatePending:
+				suite.Skipped++
+			case claim.StateWaived:
+				suite.Waived++
+			default:
+				suite.Failed++
+			}
+			suite.Results = append(suite.Results, Result{
+				Name:            key,
+				State:           r.State,
+				Duration:        time.Duration(r.Duration),
+				TestText:        r.TestText,
+				FailureReason:   r.FailureReason,
+				FailureLocation: r.FailureLocation,
+				Output:          r.CapturedTestOutput,
+			})
+		}
+	}
+	names := make([]string, 0, len(suites))
+	for name := range suites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sorted := make([]Suite, 0, len(names))
+	for _, name := range names {
+		sorted = append(sorted, *suites[name])
+	}
+	return sorted
+}
+
+// buildNodes extracts the node inventory from the `oc get nodes -o json` output stored in the claim.
+func buildNodes(nodes map[string]interface{}) []Node {
+	var inventory []Node
+	summary, ok := nodes[nodeSummaryKey].(map[string]interface{})
+	if !ok {
+		return inventory
+	}
+	items, _ := summary["items"].([]interface{})
+	for _, item := range items {
+		node, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var roles []string
+		labels, _ := lookup(node, "metadata", "labels").(map[string]interface{})
+		for label := range labels {
+			if strings.HasPrefix(label, nodeRoleLabelPrefix) {
+				roles = append(roles, strings.TrimPrefix(label, nodeRoleLabelPrefix))
+			}
+		}
+		sort.Strings(roles)
+		inventory = append(inventory, Node{
+			Name:           lookupString(node, "metadata", "name"),
+			Roles:          strings.Join(roles, ","),
+			OSImage:        lookupString(node, "status", "nodeInfo", "osImage"),
+			KernelVersion:  lookupString(node, "status", "nodeInfo", "kernelVersion"),
+			KubeletVersion: lookupString(node, "status", "nodeInfo", "kubeletVersion"),
+		})
+	}
+	sort.Slice(inventory, func(i, j int) bool { return inventory[i].Name < inventory[j].Name })
+	return inventory
+}
+
+// lookup walks a generic JSON object following keys, returning nil when a key is missing.
+func lookup(obj interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		m, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		obj = m[key]
+	}
+	return obj
+}
+
+func lookupString(obj interface{}, keys ...string) string {
+	s, _ := lookup(obj, keys...).(string)
+	return s
+}
+
+// Render writes the HTML report for the claim to w.
+func Render(c *schema.Claim, w io.Writer) error {
+	r, err := NewReport(c)
+	if err != nil {
+		return err
+	}
+	return reportTemplate.Execute(w, r)
+}
+
+// GenerateHTMLReport reads the claim file at claimFilePath and writes its HTML report to outputPath.
+func GenerateHTMLReport(claimFilePath, outputPath string) error {
+	claimRoot, err := claim.ReadClaimFile(claimFilePath)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(outputPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, reportFilePermissions)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return Render(claimRoot.Claim, f)
+}
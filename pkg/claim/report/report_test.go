@@ -0,0 +1,88 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package report_test
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/report"
+	"github.com/test-network-function/test-network-function/pkg/claim/waiver"
+)
+
+var testClaimPath = path.Join("..", "testdata", "claim.json")
+
+func TestNewReport(t *testing.T) {
+	claimRoot, err := claim.ReadClaimFile(testClaimPath)
+	assert.Nil(t, err)
+	r, err := report.NewReport(claimRoot.Claim)
+	assert.Nil(t, err)
+	assert.Len(t, r.Suites, 2)
+	assert.Equal(t, "access-control", r.Suites[0].Name)
+	assert.Equal(t, 1, r.Suites[0].Passed)
+	assert.Equal(t, "lifecycle", r.Suites[1].Name)
+	assert.Equal(t, 1, r.Suites[1].Failed)
+	assert.Equal(t, 1, r.Suites[1].Skipped)
+	assert.Len(t, r.Groups, 2)
+	assert.Equal(t, "netops", r.Groups[1].Name)
+	assert.Equal(t, 1, r.Groups[1].Failed)
+	assert.Len(t, r.Nodes, 2)
+	assert.Equal(t, "master-0", r.Nodes[0].Name)
+	assert.Equal(t, "master", r.Nodes[0].Roles)
+	assert.Equal(t, "v1.22.0", r.Nodes[1].KubeletVersion)
+}
+
+func TestNewReportWaivers(t *testing.T) {
+	claimRoot, err := claim.ReadClaimFile(testClaimPath)
+	assert.Nil(t, err)
+	applied := []waiver.Applied{{Waiver: waiver.Waiver{TestID: "lifecycle-pod-owner-type", Justification: "accepted",
+		Expiry: "2021-12-31"}, WaivedTests: []string{"lifecycle-lifecycle-pod-owner-type"}}}
+	claimRoot.Claim.RawResults["waivers"] = applied
+	results, err := claim.GetResults(claimRoot.Claim)
+	assert.Nil(t, err)
+	results["lifecycle-lifecycle-pod-owner-type"][0].State = claim.StateWaived
+	claimRoot.Claim.Results["lifecycle-lifecycle-pod-owner-type"] = results["lifecycle-lifecycle-pod-owner-type"]
+
+	r, err := report.NewReport(claimRoot.Claim)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, r.Suites[1].Failed)
+	assert.Equal(t, 1, r.Suites[1].Waived)
+	assert.Equal(t, applied, r.Waivers)
+
+	var html strings.Builder
+	assert.Nil(t, report.Render(claimRoot.Claim, &html))
+	assert.True(t, strings.Contains(html.String(), "<h2>Active waivers</h2>"))
+	assert.True(t, strings.Contains(html.String(), "<td>lifecycle-pod-owner-type</td><td></td><td>accepted</td><td>2021-12-31</td>"))
+}
+
+func TestGenerateHTMLReport(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "report.html")
+	assert.Nil(t, report.GenerateHTMLReport(testClaimPath, output))
+	contents, err := os.ReadFile(output)
+	assert.Nil(t, err)
+	html := string(contents)
+	assert.True(t, strings.Contains(html, `<h2 id="suite-lifecycle">lifecycle</h2>`))
+	assert.True(t, strings.Contains(html, "Expected &lt;int&gt;: 2 to equal &lt;int&gt;: 1"))
+	assert.True(t, strings.Contains(html, "<td>worker-0</td>"))
+	assert.True(t, strings.Contains(html, "<details>"))
+	assert.True(t, strings.Contains(html, "<td>netops</td><td>netops@example.com</td>"))
+}
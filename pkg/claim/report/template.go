@@ -0,0 +1,116 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package report
+
+import (
+	"html/template"
+	"strings"
+)
+
+// reportTemplate is self-contained on purpose: styles are inlined and no external resource is referenced, so that the
+// generated file can be shared as is.
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{"join": strings.Join}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>CNF Certification Test Report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+.passed { color: #2e7d32; font-weight: bold; }
+.failed, .panicked, .interrupted, .aborted { color: #c62828; font-weight: bold; }
+.skipped, .pending { color: #9e9e9e; font-weight: bold; }
+.waived { color: #ef6c00; font-weight: bold; }
+pre { background: #f7f7f7; padding: 0.5em; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>CNF Certification Test Report</h1>
+<table>
+{{- with .Versions }}
+<tr><th>TNF version</th><td>{{ .Tnf }} ({{ .TnfGitCommit }})</td></tr>
+<tr><th>OCP version</th><td>{{ .Ocp }}</td></tr>
+<tr><th>Kubernetes version</th><td>{{ .K8s }}</td></tr>
+<tr><th>oc client version</th><td>{{ .OcClient }}</td></tr>
+{{- end }}
+{{- with .Metadata }}
+<tr><th>Start time</th><td>{{ .StartTime }}</td></tr>
+<tr><th>End time</th><td>{{ .EndTime }}</td></tr>
+{{- end }}
+</table>
+<h2>Summary</h2>
+<table>
+<tr><th>Suite</th><th>Passed</th><th>Failed</th><th>Skipped</th><th>Waived</th></tr>
+{{- range .Suites }}
+<tr><td><a href="#suite-{{ .Name }}">{{ .Name }}</a></td><td>{{ .Passed }}</td><td>{{ .Failed }}</td><td>{{ .Skipped }}</td><td>{{ .Waived }}</td></tr>
+{{- end }}
+</table>
+{{- if .Groups }}
+<h2>Test groups</h2>
+<table>
+<tr><th>Group</th><th>Owners</th><th>Passed</th><th>Failed</th><th>Skipped</th><th>Waived</th><th>Failed tests</th></tr>
+{{- range .Groups }}
+<tr><td>{{ .Name }}</td><td>{{ join .Owners ", " }}</td><td>{{ .Passed }}</td><td>{{ .Failed }}</td><td>{{ .Skipped }}</td><td>{{ .Waived }}</td><td>{{ join .FailedTests ", " }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if .Waivers }}
+<h2>Active waivers</h2>
+<table>
+<tr><th>Test case</th><th>Target</th><th>Justification</th><th>Expiry</th><th>Waived tests</th></tr>
+{{- range .Waivers }}
+<tr><td>{{ .TestID }}</td><td>{{ .Target }}</td><td>{{ .Justification }}</td><td>{{ .Expiry }}</td><td>{{ join .WaivedTests ", " }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- range .Suites }}
+<h2 id="suite-{{ .Name }}">{{ .Name }}</h2>
+<table>
+<tr><th>Test</th><th>State</th><th>Duration</th><th>Details</th></tr>
+{{- range .Results }}
+<tr>
+<td>{{ .Name }}</td>
+<td class="{{ .State }}">{{ .State }}</td>
+<td>{{ .Duration }}</td>
+<td>
+<details>
+<summary>{{ .TestText }}</summary>
+{{- if .FailureReason }}
+<p><b>Reason:</b> {{ .FailureReason }}</p>
+<p><b>Location:</b> {{ .FailureLocation }}</p>
+{{- end }}
+{{- if .Output }}
+<pre>{{ .Output }}</pre>
+{{- end }}
+</details>
+</td>
+</tr>
+{{- end }}
+</table>
+{{- end }}
+<h2>Node inventory</h2>
+<table>
+<tr><th>Name</th><th>Roles</th><th>OS image</th><th>Kernel</th><th>Kubelet</th></tr>
+{{- range .Nodes }}
+<tr><td>{{ .Name }}</td><td>{{ .Roles }}</td><td>{{ .OSImage }}</td><td>{{ .KernelVersion }}</td><td>{{ .KubeletVersion }}</td></tr>
+{{- end }}
+</table>
+</body>
+</html>
+`))
@@ -0,0 +1,87 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rerun
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+)
+
+// FailedTests returns the sorted keys of the claim results having at least one failed run.
+func FailedTests(results map[string][]schema.Result) []string {
+	var failed []string
+	for key, runs := range results {
+		for i := range runs {
+			if claim.IsFailed(runs[i].State) {
+				failed = append(failed, key)
+				break
+			}
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// suiteAndLeaf extracts the suite (top level Describe text) and the It text of a result.  Test IDs have the form
+// http://test-network-function.com/testcases/<suite>/<name> and the It text starts with "<suite>-<name>", possibly
+// followed by an extra suffix, and ends the result key.
+func suiteAndLeaf(key string, result *schema.Result) (suite, leaf string, err error) {
+	if result.TestID == nil || result.TestID.Url == "" {
+		return "", "", fmt.Errorf("result %s has no test ID", key)
+	}
+	suite = path.Base(path.Dir(result.TestID.Url))
+	itID := suite + "-" + path.Base(result.TestID.Url)
+	i := strings.LastIndex(key, itID)
+	if i < 0 {
+		return "", "", fmt.Errorf("result %s does not match its test ID %s", key, result.TestID.Url)
+	}
+	return suite, key[i:], nil
+}
+
+// FocusStrings returns the Ginkgo focus regular expressions selecting only the failed tests of the results, see
+// testcases.SpecsFocusStrings.
+func FocusStrings(results map[string][]schema.Result) ([]string, error) {
+	var specs []testcases.SpecFocus
+	for _, key := range FailedTests(results) {
+		suite, leaf, err := suiteAndLeaf(key, &results[key][0])
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, testcases.SpecFocus{Suite: suite, Leaf: regexp.QuoteMeta(leaf)})
+	}
+	return testcases.SpecsFocusStrings(specs), nil
+}
+
+// FocusStringsFromFile reads a claim file and returns the focus selecting its failed tests.
+func FocusStringsFromFile(claimFilePath string) ([]string, error) {
+	claimRoot, err := claim.ReadClaimFile(claimFilePath)
+	if err != nil {
+		return nil, err
+	}
+	results, err := claim.GetResults(claimRoot.Claim)
+	if err != nil {
+		return nil, err
+	}
+	return FocusStrings(results)
+}
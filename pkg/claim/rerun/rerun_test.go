@@ -0,0 +1,75 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rerun_test
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/rerun"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+)
+
+var testClaimPath = path.Join("..", "testdata", "claim.json")
+
+func TestFocusStringsFromFile(t *testing.T) {
+	focus, err := rerun.FocusStringsFromFile(testClaimPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`^lifecycle$`, `(^| )lifecycle (.* )?lifecycle-pod-owner-type$`}, focus)
+
+	// the failed suite registers its specs, the others do not.
+	assert.True(t, testcases.IsInFocus(focus, "lifecycle"))
+	assert.False(t, testcases.IsInFocus(focus, "access-control"))
+
+	// only the failed spec is focused; Ginkgo matches the suite description followed by the spec text.
+	re := regexp.MustCompile(strings.Join(focus, "|"))
+	assert.True(t, re.MatchString("CNF Certification Test Suite lifecycle lifecycle-pod-owner-type"))
+	assert.True(t, re.MatchString("CNF Certification Test Suite lifecycle when Testing owners lifecycle-pod-owner-type"))
+	assert.False(t, re.MatchString("CNF Certification Test Suite lifecycle lifecycle-scaling"))
+	assert.False(t, re.MatchString("CNF Certification Test Suite lifecycle lifecycle-pod-owner-type-extra"))
+}
+
+func TestFocusStringsExtendedLeaf(t *testing.T) {
+	id := schema.Identifier{Url: "http://test-network-function.com/testcases/access-control/pod-role-bindings"}
+	results := map[string][]schema.Result{
+		"access-control-access-control-pod-role-bindings-cnf-ns": {{State: "passed", TestID: &id}, {State: "panicked", TestID: &id}},
+	}
+	focus, err := rerun.FocusStrings(results)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`^access-control$`, `(^| )access-control (.* )?access-control-pod-role-bindings-cnf-ns$`}, focus)
+}
+
+func TestFocusStringsNoFailure(t *testing.T) {
+	focus, err := rerun.FocusStrings(map[string][]schema.Result{})
+	assert.Nil(t, err)
+	assert.Empty(t, focus)
+}
+
+func TestFocusStringsWaived(t *testing.T) {
+	focus, err := rerun.FocusStrings(map[string][]schema.Result{"some-test": {{State: "waived"}}})
+	assert.Nil(t, err)
+	assert.Empty(t, focus)
+}
+
+func TestFocusStringsMissingTestID(t *testing.T) {
+	_, err := rerun.FocusStrings(map[string][]schema.Result{"some-test": {{State: "failed"}}})
+	assert.NotNil(t, err)
+}
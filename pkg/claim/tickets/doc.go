@@ -0,0 +1,23 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+/*
+Package tickets files a ticket per test case failing in a claim in an issue tracker, GitHub or Jira, closing the loop
+from a certification run to the engineering backlog.  The tickets are titled after the test case and the target, the
+CNF under test, and a ticket is only filed when no open ticket has the same title, so that successive runs do not
+duplicate the tickets.  Each ticket holds the failed runs of the claim and the remediation of the test case.
+*/
+package tickets
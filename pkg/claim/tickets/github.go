@@ -0,0 +1,98 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tickets
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/internal/api"
+)
+
+// DefaultGitHubEndpoint is the API endpoint of github.com, GitHub Enterprise servers serve the API under /api/v3.
+const DefaultGitHubEndpoint = "https://api.github.com"
+
+// GitHub files the tickets as the issues of a GitHub repository.
+type GitHub struct {
+	Client api.HTTPClient
+	// Endpoint is the URL of the API, e.g. DefaultGitHubEndpoint.
+	Endpoint string
+	// Repository is the repository of the issues, "owner/name".
+	Repository string
+	// Token is a personal access token allowed to create issues in the repository.
+	Token string
+	// Labels are the labels of the created issues.
+	Labels []string
+}
+
+type gitHubIssue struct {
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+// NewGitHub returns a GitHub tracker filing the issues of a repository.
+func NewGitHub(endpoint, repository, token string, labels []string) *GitHub {
+	if endpoint == "" {
+		endpoint = DefaultGitHubEndpoint
+	}
+	return &GitHub{Client: &http.Client{}, Endpoint: strings.TrimSuffix(endpoint, "/"), Repository: repository,
+		Token: token, Labels: labels}
+}
+
+func (g *GitHub) authorization() string {
+	if g.Token == "" {
+		return ""
+	}
+	return "token " + g.Token
+}
+
+// FindOpen searches the open issues of the repository by title.  The search matches the words of the title, so the
+// results are filtered on the exact title.
+func (g *GitHub) FindOpen(title string) (string, error) {
+	query := fmt.Sprintf("repo:%s is:issue is:open in:title %q", g.Repository, title)
+	var found struct {
+		Items []gitHubIssue `json:"items"`
+	}
+	err := request(g.Client, http.MethodGet, g.Endpoint+"/search/issues?q="+url.QueryEscape(query), g.authorization(),
+		nil, &found)
+	if err != nil {
+		return "", err
+	}
+	for _, issue := range found.Items {
+		if issue.Title == title {
+			return issue.HTMLURL, nil
+		}
+	}
+	return "", nil
+}
+
+// Create opens an issue.
+func (g *GitHub) Create(ticket *Ticket) (string, error) {
+	in := struct {
+		Title  string   `json:"title"`
+		Body   string   `json:"body"`
+		Labels []string `json:"labels,omitempty"`
+	}{Title: ticket.Title, Body: ticket.Body, Labels: g.Labels}
+	var issue gitHubIssue
+	if err := request(g.Client, http.MethodPost, fmt.Sprintf("%s/repos/%s/issues", g.Endpoint, g.Repository),
+		g.authorization(), in, &issue); err != nil {
+		return "", err
+	}
+	return issue.HTMLURL, nil
+}
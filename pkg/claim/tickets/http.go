@@ -0,0 +1,75 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tickets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/test-network-function/test-network-function/internal/api"
+)
+
+// maxErrorBodyLength is the length of the tracker responses quoted in the errors.
+const maxErrorBodyLength = 512
+
+// request sends a JSON request to a tracker and decodes its JSON response into out, unless nil.
+func request(client api.HTTPClient, method, url, authorization string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, url, body) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		if len(payload) > maxErrorBodyLength {
+			payload = payload[:maxErrorBodyLength]
+		}
+		return fmt.Errorf("%s %s: %s: %s", method, url, resp.Status, payload)
+	}
+	if out == nil {
+		return nil
+	}
+	if err = json.Unmarshal(payload, out); err != nil {
+		return fmt.Errorf("cannot decode the response of %s %s: %w", method, url, err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tickets
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/internal/api"
+)
+
+// DefaultJiraIssueType is the type of the created Jira issues.
+const DefaultJiraIssueType = "Bug"
+
+// Jira files the tickets as the issues of a Jira project.
+type Jira struct {
+	Client api.HTTPClient
+	// Endpoint is the URL of the Jira server, e.g. "https://issues.example.com".
+	Endpoint string
+	// Project is the key of the project of the issues.
+	Project string
+	// IssueType is the type of the created issues, e.g. DefaultJiraIssueType.
+	IssueType string
+	// Token is a personal access token allowed to create issues in the project.
+	Token string
+	// Labels are the labels of the created issues.
+	Labels []string
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+	} `json:"fields"`
+}
+
+// NewJira returns a Jira tracker filing the issues of a project.
+func NewJira(endpoint, project, issueType, token string, labels []string) *Jira {
+	if issueType == "" {
+		issueType = DefaultJiraIssueType
+	}
+	return &Jira{Client: &http.Client{}, Endpoint: strings.TrimSuffix(endpoint, "/"), Project: project,
+		IssueType: issueType, Token: token, Labels: labels}
+}
+
+func (j *Jira) authorization() string {
+	if j.Token == "" {
+		return ""
+	}
+	return "Bearer " + j.Token
+}
+
+// browseURL returns the URL of an issue.
+func (j *Jira) browseURL(key string) string {
+	return j.Endpoint + "/browse/" + key
+}
+
+// quoteJQL quotes a JQL string, the text searches require the phrases to be quoted again within the string.
+func quoteJQL(s string) string {
+	phrase := `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(phrase) + `"`
+}
+
+// FindOpen searches the unresolved issues of the project by summary.  The search matches the words of the title, so
+// the results are filtered on the exact title.
+func (j *Jira) FindOpen(title string) (string, error) {
+	jql := fmt.Sprintf("project = %q AND statusCategory != Done AND summary ~ %s", j.Project, quoteJQL(title))
+	var found struct {
+		Issues []jiraIssue `json:"issues"`
+	}
+	err := request(j.Client, http.MethodGet, j.Endpoint+"/rest/api/2/search?fields=summary&jql="+url.QueryEscape(jql),
+		j.authorization(), nil, &found)
+	if err != nil {
+		return "", err
+	}
+	for _, issue := range found.Issues {
+		if issue.Fields.Summary == title {
+			return j.browseURL(issue.Key), nil
+		}
+	}
+	return "", nil
+}
+
+// Create opens an issue.
+func (j *Jira) Create(ticket *Ticket) (string, error) {
+	type named struct {
+		Name string `json:"name,omitempty"`
+		Key  string `json:"key,omitempty"`
+	}
+	fields := map[string]interface{}{
+		"project":     named{Key: j.Project},
+		"issuetype":   named{Name: j.IssueType},
+		"summary":     ticket.Title,
+		"description": ticket.Body,
+	}
+	if len(j.Labels) > 0 {
+		fields["labels"] = j.Labels
+	}
+	in := map[string]interface{}{"fields": fields}
+	var issue jiraIssue
+	if err := request(j.Client, http.MethodPost, j.Endpoint+"/rest/api/2/issue", j.authorization(), in, &issue); err != nil {
+		return "", err
+	}
+	return j.browseURL(issue.Key), nil
+}
@@ -0,0 +1,229 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tickets
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+)
+
+const (
+	// failedTargetsKey is the key of the raw results of the claim recording the failed targets of each test case.
+	failedTargetsKey = "failedTargets"
+	// titlePrefix starts the title of the tickets, it identifies them in the trackers.
+	titlePrefix = "[tnf]"
+)
+
+// Ticket is the ticket of a test case failing on a target.
+type Ticket struct {
+	TestCase string
+	Target   string
+	// Title identifies the ticket, the open tickets having the same title are duplicates.
+	Title string
+	// Body is the Markdown description of the failure.
+	Body string
+}
+
+// runExcerpt is the excerpt of a failed run of the claim in a ticket.  The captured output is left out, it can be too
+// long for the trackers.
+type runExcerpt struct {
+	TestID             string `json:"testID"`
+	TestText           string `json:"testText"`
+	State              string `json:"state"`
+	StartTime          string `json:"startTime"`
+	EndTime            string `json:"endTime,omitempty"`
+	FailureReason      string `json:"failureReason"`
+	FailureLocation    string `json:"failureLocation"`
+	FailureLineContent string `json:"failureLineContent"`
+}
+
+// CatalogLookup returns the description and the remediation of a test case, ok being false for the unknown ones.
+type CatalogLookup func(id *schema.Identifier) (description, remediation string, ok bool)
+
+// Tracker is an issue tracker.
+type Tracker interface {
+	// FindOpen returns the URL of the open ticket titled title, or an empty string when there is none.
+	FindOpen(title string) (string, error)
+	// Create files a ticket, returning its URL.
+	Create(ticket *Ticket) (string, error)
+}
+
+// Filed is the outcome of filing a ticket.
+type Filed struct {
+	Ticket Ticket
+	// URL is the URL of the ticket, empty for the tickets not created in dry-run mode.
+	URL string
+	// Existing is true when an open ticket already tracked the failure.
+	Existing bool
+}
+
+// Title returns the title of the ticket of a test case failing on a target.
+func Title(testCase, target string) string {
+	return fmt.Sprintf("%s %s failed on %s", titlePrefix, testCase, target)
+}
+
+// GetTarget returns the target of a claim, its namespaces under test.
+func GetTarget(c *schema.Claim) string {
+	var namespaces []string
+	if list, ok := c.Configurations["targetNameSpaces"].([]interface{}); ok {
+		for _, item := range list {
+			if namespace, ok := item.(map[string]interface{}); ok {
+				if name, ok := namespace["name"].(string); ok && name != "" {
+					namespaces = append(namespaces, name)
+				}
+			}
+		}
+	}
+	return strings.Join(namespaces, ",")
+}
+
+// getFailedTargets returns the failed targets recorded in the raw results of a claim, by test case name.
+func getFailedTargets(c *schema.Claim) (map[string][]string, error) {
+	failedTargets := map[string][]string{}
+	section, ok := c.RawResults[failedTargetsKey]
+	if !ok {
+		return failedTargets, nil
+	}
+	payload, err := json.Marshal(section)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(payload, &failedTargets); err != nil {
+		return nil, fmt.Errorf("cannot decode claim failed targets: %w", err)
+	}
+	return failedTargets, nil
+}
+
+// Build returns the tickets of the test cases failing in a claim, sorted by test case, for a target, e.g. GetTarget.
+func Build(c *schema.Claim, target string, lookup CatalogLookup) ([]Ticket, error) {
+	results, err := claim.GetResults(c)
+	if err != nil {
+		return nil, err
+	}
+	failedTargets, err := getFailedTargets(c)
+	if err != nil {
+		return nil, err
+	}
+	// a test case run several times, e.g. per operator, has several keys.
+	failedRuns := map[string][]schema.Result{}
+	ids := map[string]*schema.Identifier{}
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for i := range results[key] {
+			result := results[key][i]
+			if result.TestID == nil || !claim.IsFailed(result.State) {
+				continue
+			}
+			name := groups.TestCaseName(result.TestID)
+			failedRuns[name] = append(failedRuns[name], result)
+			ids[name] = result.TestID
+		}
+	}
+	names := make([]string, 0, len(failedRuns))
+	for name := range failedRuns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tickets := make([]Ticket, 0, len(names))
+	for _, name := range names {
+		body, err := buildBody(c, name, target, failedRuns[name], failedTargets[name], ids[name], lookup)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, Ticket{TestCase: name, Target: target, Title: Title(name, target), Body: body})
+	}
+	return tickets, nil
+}
+
+// buildBody returns the Markdown body of the ticket of a test case.
+func buildBody(c *schema.Claim, testCase, target string, runs []schema.Result, failedTargets []string,
+	id *schema.Identifier, lookup CatalogLookup) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "The test case `%s` failed on `%s`", testCase, target)
+	if c.Metadata != nil && c.Metadata.StartTime != "" {
+		fmt.Fprintf(&b, " in the run started at %s", c.Metadata.StartTime)
+	}
+	if c.Versions != nil && c.Versions.Tnf != "" {
+		fmt.Fprintf(&b, " (tnf %s)", c.Versions.Tnf)
+	}
+	fmt.Fprintln(&b, ".")
+	if len(failedTargets) > 0 {
+		fmt.Fprintln(&b, "\n## Failed targets")
+		for _, failedTarget := range failedTargets {
+			fmt.Fprintf(&b, "- `%s`\n", failedTarget)
+		}
+	}
+	if description, remediation, ok := lookup(id); ok {
+		fmt.Fprintf(&b, "\n## Description\n%s\n", strings.TrimSpace(description))
+		fmt.Fprintf(&b, "\n## Remediation\n%s\n", strings.TrimSpace(remediation))
+	}
+	excerpt := make([]runExcerpt, len(runs))
+	for i := range runs {
+		excerpt[i] = runExcerpt{
+			TestID:             runs[i].TestID.Url,
+			TestText:           runs[i].TestText,
+			State:              runs[i].State,
+			StartTime:          runs[i].StartTime,
+			EndTime:            runs[i].EndTime,
+			FailureReason:      runs[i].FailureReason,
+			FailureLocation:    runs[i].FailureLocation,
+			FailureLineContent: runs[i].FailureLineContent,
+		}
+	}
+	var payload strings.Builder
+	encoder := json.NewEncoder(&payload)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(excerpt); err != nil {
+		return "", err
+	}
+	fmt.Fprintf(&b, "\n## Claim excerpt\n```json\n%s```\n", payload.String())
+	return b.String(), nil
+}
+
+// File files the tickets not already tracked by an open ticket.  In dry-run mode, the tickets are only looked up.  The
+// tickets filed before an error are returned with it.
+func File(tracker Tracker, tickets []Ticket, dryRun bool) ([]Filed, error) {
+	var filed []Filed
+	for i := range tickets {
+		url, err := tracker.FindOpen(tickets[i].Title)
+		if err != nil {
+			return filed, fmt.Errorf("cannot look up the ticket of %s: %w", tickets[i].TestCase, err)
+		}
+		if url != "" {
+			filed = append(filed, Filed{Ticket: tickets[i], URL: url, Existing: true})
+			continue
+		}
+		if !dryRun {
+			if url, err = tracker.Create(&tickets[i]); err != nil {
+				return filed, fmt.Errorf("cannot file the ticket of %s: %w", tickets[i].TestCase, err)
+			}
+		}
+		filed = append(filed, Filed{Ticket: tickets[i], URL: url})
+	}
+	return filed, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tickets_test
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/tickets"
+)
+
+var testClaimPath = path.Join("..", "testdata", "claim.json")
+
+func lookup(id *schema.Identifier) (description, remediation string, ok bool) {
+	if id.Url == "http://test-network-function.com/testcases/lifecycle/pod-owner-type" {
+		return "tests that the pods are owned by a ReplicaSet or a StatefulSet", "Deploy the pods with a Deployment", true
+	}
+	return "", "", false
+}
+
+func TestBuild(t *testing.T) {
+	claimRoot, err := claim.ReadClaimFile(testClaimPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "tnf", tickets.GetTarget(claimRoot.Claim))
+	claimRoot.Claim.RawResults["failedTargets"] = map[string]interface{}{
+		"lifecycle-pod-owner-type": []interface{}{"tnf/test-0", "tnf/test-1"},
+	}
+
+	built, err := tickets.Build(claimRoot.Claim, "tnf", lookup)
+	assert.Nil(t, err)
+	// the passed and skipped tests have no ticket.
+	assert.Len(t, built, 1)
+	ticket := built[0]
+	assert.Equal(t, "lifecycle-pod-owner-type", ticket.TestCase)
+	assert.Equal(t, "tnf", ticket.Target)
+	assert.Equal(t, "[tnf] lifecycle-pod-owner-type failed on tnf", ticket.Title)
+	assert.True(t, strings.HasPrefix(ticket.Body, "The test case `lifecycle-pod-owner-type` failed on `tnf` in the run "+
+		"started at 2021-11-02T10:00:00+00:00 (tnf v3.0.0).\n"))
+	assert.Contains(t, ticket.Body, "## Failed targets\n- `tnf/test-0`\n- `tnf/test-1`\n")
+	assert.Contains(t, ticket.Body, "## Remediation\nDeploy the pods with a Deployment\n")
+	assert.Contains(t, ticket.Body, `"failureReason": "Expected <int>: 2 to equal <int>: 1"`)
+}
+
+func TestBuildBadFailedTargets(t *testing.T) {
+	claimRoot, err := claim.ReadClaimFile(testClaimPath)
+	assert.Nil(t, err)
+	claimRoot.Claim.RawResults["failedTargets"] = []interface{}{"tnf/test-0"}
+	_, err = tickets.Build(claimRoot.Claim, "tnf", lookup)
+	assert.NotNil(t, err)
+}
+
+// fakeTracker tracks tickets in memory.
+type fakeTracker struct {
+	open    map[string]string
+	created []string
+	err     error
+}
+
+func (f *fakeTracker) FindOpen(title string) (string, error) {
+	return f.open[title], f.err
+}
+
+func (f *fakeTracker) Create(ticket *tickets.Ticket) (string, error) {
+	f.created = append(f.created, ticket.Title)
+	return "https://tracker/" + ticket.TestCase, nil
+}
+
+func TestFile(t *testing.T) {
+	toFile := []tickets.Ticket{
+		{TestCase: "a", Title: tickets.Title("a", "tnf")},
+		{TestCase: "b", Title: tickets.Title("b", "tnf")},
+	}
+	tracker := &fakeTracker{open: map[string]string{tickets.Title("a", "tnf"): "https://tracker/1"}}
+
+	filed, err := tickets.File(tracker, toFile, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []tickets.Filed{
+		{Ticket: toFile[0], URL: "https://tracker/1", Existing: true},
+		{Ticket: toFile[1]},
+	}, filed)
+	assert.Empty(t, tracker.created)
+
+	filed, err = tickets.File(tracker, toFile, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []tickets.Filed{
+		{Ticket: toFile[0], URL: "https://tracker/1", Existing: true},
+		{Ticket: toFile[1], URL: "https://tracker/b"},
+	}, filed)
+	assert.Equal(t, []string{"[tnf] b failed on tnf"}, tracker.created)
+
+	tracker.err = errors.New("unauthorized")
+	filed, err = tickets.File(tracker, toFile, false)
+	assert.NotNil(t, err)
+	assert.Empty(t, filed)
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tickets_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/claim/tickets"
+)
+
+const testTitle = "[tnf] lifecycle-pod-owner-type failed on tnf"
+
+func TestGitHub(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/search/issues":
+			assert.Equal(t, fmt.Sprintf("repo:owner/cnf is:issue is:open in:title %q", testTitle), r.URL.Query().Get("q"))
+			fmt.Fprintf(w, `{"items": [{"title": "%s (old)", "html_url": "https://github.com/owner/cnf/issues/1"}]}`, testTitle)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/owner/cnf/issues":
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"html_url": "https://github.com/owner/cnf/issues/2"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	github := tickets.NewGitHub(server.URL+"/", "owner/cnf", "secret", []string{"tnf"})
+	// only the exact title matches.
+	url, err := github.FindOpen(testTitle)
+	assert.Nil(t, err)
+	assert.Equal(t, "", url)
+	url, err = github.Create(&tickets.Ticket{Title: testTitle, Body: "body"})
+	assert.Nil(t, err)
+	assert.Equal(t, "https://github.com/owner/cnf/issues/2", url)
+	assert.Equal(t, map[string]interface{}{"title": testTitle, "body": "body", "labels": []interface{}{"tnf"}}, created)
+
+	github.Repository = "owner/unknown"
+	_, err = github.Create(&tickets.Ticket{Title: testTitle})
+	assert.NotNil(t, err)
+}
+
+func TestJira(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/search":
+			assert.Equal(t, `project = "CNF" AND statusCategory != Done AND summary ~ "\"`+testTitle+`\""`,
+				r.URL.Query().Get("jql"))
+			fmt.Fprintf(w, `{"issues": [{"key": "CNF-7", "fields": {"summary": "%s"}}]}`, testTitle)
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"key": "CNF-8"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	jira := tickets.NewJira(server.URL, "CNF", "", "secret", nil)
+	url, err := jira.FindOpen(testTitle)
+	assert.Nil(t, err)
+	assert.Equal(t, server.URL+"/browse/CNF-7", url)
+	url, err = jira.Create(&tickets.Ticket{Title: testTitle, Body: "body"})
+	assert.Nil(t, err)
+	assert.Equal(t, server.URL+"/browse/CNF-8", url)
+	assert.Equal(t, map[string]interface{}{"fields": map[string]interface{}{
+		"project":     map[string]interface{}{"key": "CNF"},
+		"issuetype":   map[string]interface{}{"name": tickets.DefaultJiraIssueType},
+		"summary":     testTitle,
+		"description": "body",
+	}}, created)
+}
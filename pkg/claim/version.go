@@ -0,0 +1,140 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claim
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+)
+
+const (
+	// FormatVersionKey is the key of the format version of the claim in its raw results, as the claim schema allows
+	// no other field.
+	FormatVersionKey = "claimFormatVersion"
+	// FormatVersion is the format version of the claims written by the test suites, as major.minor.  The minor version
+	// is bumped when fields are added, which the consumers of older claims ignore, and the major version when fields
+	// are renamed, moved or removed, with a migration of the claims of the previous major version.
+	FormatVersion = "1.0"
+	// unversionedFormat is the format version of the claims written before the claims were versioned.
+	unversionedFormat = "0.0"
+	// versionParts are the major and the minor versions of a format version.
+	versionParts = 2
+)
+
+// ErrNewerFormat is returned for the claims of a major format version newer than FormatVersion, which the consumers
+// of the claims cannot read reliably.
+var ErrNewerFormat = errors.New("the claim format is newer than the supported format, upgrade tnf")
+
+// Migration migrates a claim of a major format version to the next major version.
+type Migration func(c *schema.Claim) error
+
+// migrations are the migrations from each major format version to the next one.
+var migrations = map[int]Migration{
+	0: migrateUnversioned,
+}
+
+// GetFormatVersion returns the format version of a claim, unversionedFormat for the claims written before the claims
+// were versioned.
+func GetFormatVersion(c *schema.Claim) string {
+	if version, ok := c.RawResults[FormatVersionKey].(string); ok {
+		return version
+	}
+	return unversionedFormat
+}
+
+// parseFormatVersion parses a major.minor format version.
+func parseFormatVersion(version string) (major, minor int, err error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != versionParts {
+		return 0, 0, fmt.Errorf("invalid claim format version %q, expected major.minor", version)
+	}
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid claim format version %q: %w", version, err)
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid claim format version %q: %w", version, err)
+	}
+	return major, minor, nil
+}
+
+// CheckFormatVersion checks a claim can be read by the consumers of the claims of FormatVersion:  the claims of an
+// older format version are migrated, see Migrate, and the ones of a newer minor version only add fields, but the
+// claims of a newer major version are rejected with ErrNewerFormat.
+func CheckFormatVersion(c *schema.Claim) error {
+	major, _, err := parseFormatVersion(GetFormatVersion(c))
+	if err != nil {
+		return err
+	}
+	currentMajor, _, _ := parseFormatVersion(FormatVersion)
+	if major > currentMajor {
+		return fmt.Errorf("%w: the claim format is %s, the supported format is %s", ErrNewerFormat,
+			GetFormatVersion(c), FormatVersion)
+	}
+	return nil
+}
+
+// Migrate migrates a claim of an older major format version to the major version of FormatVersion, one major version
+// after the other, and records its new format version.  The claims of the current major version are left unchanged.
+func Migrate(c *schema.Claim) error {
+	if err := CheckFormatVersion(c); err != nil {
+		return err
+	}
+	major, _, _ := parseFormatVersion(GetFormatVersion(c))
+	currentMajor, _, _ := parseFormatVersion(FormatVersion)
+	if major == currentMajor {
+		return nil
+	}
+	for ; major < currentMajor; major++ {
+		migrate, ok := migrations[major]
+		if !ok {
+			return fmt.Errorf("no migration of the claims of format version %d", major)
+		}
+		if err := migrate(c); err != nil {
+			return fmt.Errorf("cannot migrate the claim from format version %d: %w", major, err)
+		}
+	}
+	c.RawResults[FormatVersionKey] = FormatVersion
+	return nil
+}
+
+// migrateUnversioned migrates the claims written before the claims were versioned, whose optional sections may be
+// null, e.g. the results of a run which tested nothing, which the consumers of the claims then fail to update.
+func migrateUnversioned(c *schema.Claim) error {
+	if c.Configurations == nil {
+		c.Configurations = map[string]interface{}{}
+	}
+	if c.Nodes == nil {
+		c.Nodes = map[string]interface{}{}
+	}
+	if c.RawResults == nil {
+		c.RawResults = map[string]interface{}{}
+	}
+	if c.Results == nil {
+		c.Results = map[string]interface{}{}
+	}
+	if c.Metadata == nil {
+		c.Metadata = &schema.Metadata{}
+	}
+	if c.Versions == nil {
+		c.Versions = &schema.Versions{}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claim_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+)
+
+func TestReadClaimFileMigrates(t *testing.T) {
+	// the claims of the test data were written before the claims were versioned.
+	claimRoot, err := claim.ReadClaimFile(path.Join("testdata", "claim.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, claim.FormatVersion, claim.GetFormatVersion(claimRoot.Claim))
+}
+
+func TestMigrate(t *testing.T) {
+	unversioned := &schema.Claim{Metadata: &schema.Metadata{}}
+	assert.Equal(t, "0.0", claim.GetFormatVersion(unversioned))
+	assert.Nil(t, claim.Migrate(unversioned))
+	assert.Equal(t, claim.FormatVersion, claim.GetFormatVersion(unversioned))
+	assert.NotNil(t, unversioned.Results)
+	assert.NotNil(t, unversioned.Nodes)
+	assert.NotNil(t, unversioned.Configurations)
+	assert.NotNil(t, unversioned.Versions)
+	results, err := claim.GetResults(unversioned)
+	assert.Nil(t, err)
+	assert.Empty(t, results)
+
+	// a newer minor version only adds fields.
+	newerMinor := &schema.Claim{RawResults: map[string]interface{}{claim.FormatVersionKey: "1.7"}}
+	assert.Nil(t, claim.Migrate(newerMinor))
+	assert.Equal(t, "1.7", claim.GetFormatVersion(newerMinor))
+
+	newerMajor := &schema.Claim{RawResults: map[string]interface{}{claim.FormatVersionKey: "2.0"}}
+	err = claim.Migrate(newerMajor)
+	assert.True(t, errors.Is(err, claim.ErrNewerFormat))
+
+	invalid := &schema.Claim{RawResults: map[string]interface{}{claim.FormatVersionKey: "one"}}
+	assert.NotNil(t, claim.CheckFormatVersion(invalid))
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package waiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ExpiryDateFormat is the layout of the waiver expiry dates, e.g. 2022-06-30.
+	ExpiryDateFormat = "2006-01-02"
+	// RawResultsKey is the key of the applied waivers in the claim raw results, see GetApplied.
+	RawResultsKey = "waivers"
+)
+
+// Waiver accepts the risk of a failing test case until its expiry date.
+type Waiver struct {
+	// TestID is the test case name "<suite>-<name>" of http://test-network-function.com/testcases/<suite>/<name>.
+	TestID string `yaml:"testID" json:"testID"`
+	// Target optionally restricts the waiver to a failed target of the test case, e.g. a pod as "namespace/name", a
+	// container as "namespace/pod/container" or an operator as "namespace/name".  It must equal the target recorded in
+	// the failed targets of the test case.
+	Target string `yaml:"target,omitempty" json:"target,omitempty"`
+	// Justification explains why the risk is accepted.
+	Justification string `yaml:"justification" json:"justification"`
+	// Expiry is the last day the waiver applies, formatted as ExpiryDateFormat.
+	Expiry string `yaml:"expiry" json:"expiry"`
+
+	expiry time.Time
+}
+
+// File is the content of a waivers file.
+type File struct {
+	Waivers []Waiver `yaml:"waivers" json:"waivers"`
+}
+
+// Applied records an active waiver along with the results it waived.
+type Applied struct {
+	Waiver
+	WaivedTests []string `json:"waivedTests"`
+}
+
+// IsExpired returns true when now is after the last day of the waiver.
+func (w *Waiver) IsExpired(now time.Time) bool {
+	return !now.Before(w.expiry.AddDate(0, 0, 1))
+}
+
+// validate checks the mandatory fields and parses the expiry date.
+func (w *Waiver) validate() error {
+	if w.TestID == "" {
+		return fmt.Errorf("waiver has no testID")
+	}
+	if strings.TrimSpace(w.Justification) == "" {
+		return fmt.Errorf("waiver for %s has no justification", w.TestID)
+	}
+	expiry, err := time.Parse(ExpiryDateFormat, w.Expiry)
+	if err != nil {
+		return fmt.Errorf("waiver for %s has an invalid expiry date %q, expected %s: %w", w.TestID, w.Expiry, ExpiryDateFormat, err)
+	}
+	w.expiry = expiry
+	return nil
+}
+
+// LoadFile reads and validates a waivers file.
+func LoadFile(filePath string) ([]Waiver, error) {
+	contents, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var file File
+	err = yaml.Unmarshal(contents, &file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal waivers file %s: %w", filePath, err)
+	}
+	for i := range file.Waivers {
+		if err := file.Waivers[i].validate(); err != nil {
+			return nil, fmt.Errorf("invalid waivers file %s: %w", filePath, err)
+		}
+	}
+	return file.Waivers, nil
+}
+
+// covers returns the active waivers waiving the failure of the test case name, whose failed targets are targets: a
+// waiver without target, or the waivers of all the failed targets.  It returns nil when the failure is not waived.
+func covers(active []Applied, name string, targets []string) []int {
+	var covering []int
+	uncovered := map[string]bool{}
+	for _, target := range targets {
+		uncovered[target] = true
+	}
+	for i := range active {
+		if active[i].TestID != name {
+			continue
+		}
+		if active[i].Target == "" {
+			return []int{i}
+		}
+		if uncovered[active[i].Target] {
+			delete(uncovered, active[i].Target)
+			covering = append(covering, i)
+		}
+	}
+	if len(covering) == 0 || len(uncovered) > 0 {
+		return nil
+	}
+	return covering
+}
+
+// Apply turns the failed results of the test cases whose failure is covered by the active waivers into waived
+// results: a waiver without target waives the failures of its test case, the waivers with a target waive them when
+// they name all the failed targets of the test case, by test case name.  It returns the active waivers along with the
+// results they waived, and the expired waivers, which are never applied.
+func Apply(waivers []Waiver, results map[string][]schema.Result, failedTargets map[string][]string,
+	now time.Time) (applied []Applied, expired []Waiver) {
+	for i := range waivers {
+		if waivers[i].IsExpired(now) {
+			expired = append(expired, waivers[i])
+			continue
+		}
+		applied = append(applied, Applied{Waiver: waivers[i], WaivedTests: []string{}})
+	}
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for j := range results[key] {
+			result := &results[key][j]
+			if !claim.IsFailed(result.State) {
+				continue
+			}
+			name := groups.TestCaseName(result.TestID)
+			for _, i := range covers(applied, name, failedTargets[name]) {
+				result.State = claim.StateWaived
+				if n := len(applied[i].WaivedTests); n == 0 || applied[i].WaivedTests[n-1] != key {
+					applied[i].WaivedTests = append(applied[i].WaivedTests, key)
+				}
+			}
+		}
+	}
+	return applied, expired
+}
+
+// GetApplied returns the waivers recorded in the raw results of a claim under key.
+func GetApplied(c *schema.Claim, key string) ([]Applied, error) {
+	var applied []Applied
+	section, ok := c.RawResults[key]
+	if !ok {
+		return applied, nil
+	}
+	payload, err := json.Marshal(section)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(payload, &applied)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode claim waivers: %w", err)
+	}
+	return applied, nil
+}
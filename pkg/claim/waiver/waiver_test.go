@@ -0,0 +1,122 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package waiver_test
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/waiver"
+)
+
+var (
+	testWaiversPath = path.Join("testdata", "waivers.yml")
+	testPodOwnerID  = schema.Identifier{Url: "http://test-network-function.com/testcases/lifecycle/pod-owner-type"}
+	testNamespaceID = schema.Identifier{Url: "http://test-network-function.com/testcases/access-control/namespace"}
+	testNow         = time.Date(2021, 11, 15, 10, 0, 0, 0, time.UTC)
+)
+
+func newTestResults() map[string][]schema.Result {
+	return map[string][]schema.Result{
+		"lifecycle-lifecycle-pod-owner-type": {
+			{State: "failed", FailureReason: "pod tnf/test-0 has no owner", TestID: &testPodOwnerID},
+		},
+		"access-control-access-control-namespace": {
+			{State: "failed", TestID: &testNamespaceID},
+		},
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	waivers, err := waiver.LoadFile(testWaiversPath)
+	assert.Nil(t, err)
+	assert.Len(t, waivers, 2)
+	assert.Equal(t, "lifecycle-pod-owner-type", waivers[0].TestID)
+	assert.Equal(t, "tnf/test-0", waivers[0].Target)
+
+	_, err = waiver.LoadFile(path.Join("testdata", "invalid_expiry.yml"))
+	assert.NotNil(t, err)
+	_, err = waiver.LoadFile(path.Join("testdata", "does-not-exist.yml"))
+	assert.NotNil(t, err)
+}
+
+func TestIsExpired(t *testing.T) {
+	waivers, err := waiver.LoadFile(testWaiversPath)
+	assert.Nil(t, err)
+	// a waiver applies until the end of its expiry day.
+	assert.False(t, waivers[0].IsExpired(time.Date(2021, 12, 31, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, waivers[0].IsExpired(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestApply(t *testing.T) {
+	waivers, err := waiver.LoadFile(testWaiversPath)
+	assert.Nil(t, err)
+	results := newTestResults()
+	failedTargets := map[string][]string{"lifecycle-pod-owner-type": {"tnf/test-0"}}
+	applied, expired := waiver.Apply(waivers, results, failedTargets, testNow)
+
+	assert.Len(t, applied, 1)
+	assert.Equal(t, "lifecycle-pod-owner-type", applied[0].TestID)
+	assert.Equal(t, []string{"lifecycle-lifecycle-pod-owner-type"}, applied[0].WaivedTests)
+	assert.Len(t, expired, 1)
+	assert.Equal(t, "access-control-namespace", expired[0].TestID)
+
+	// the failure of the waived target is waived.
+	assert.Equal(t, claim.StateWaived, results["lifecycle-lifecycle-pod-owner-type"][0].State)
+	// expired waivers are not applied.
+	assert.Equal(t, "failed", results["access-control-access-control-namespace"][0].State)
+
+	// the failure is not waived while another target fails.
+	results = newTestResults()
+	failedTargets = map[string][]string{"lifecycle-pod-owner-type": {"tnf/test-0", "tnf/test-1"}}
+	applied, _ = waiver.Apply(waivers, results, failedTargets, testNow)
+	assert.Empty(t, applied[0].WaivedTests)
+	assert.Equal(t, "failed", results["lifecycle-lifecycle-pod-owner-type"][0].State)
+}
+
+func TestApplyTargetIsExact(t *testing.T) {
+	waivers, err := waiver.LoadFile(testWaiversPath)
+	assert.Nil(t, err)
+	waivers[0].Target = "test"
+	results := newTestResults()
+	failedTargets := map[string][]string{"lifecycle-pod-owner-type": {"tnf/test-0"}}
+	applied, _ := waiver.Apply(waivers, results, failedTargets, testNow)
+	assert.Empty(t, applied[0].WaivedTests)
+	assert.Equal(t, "failed", results["lifecycle-lifecycle-pod-owner-type"][0].State)
+
+	// a waiver without target waives any failure of its test case.
+	waivers[0].Target = ""
+	applied, _ = waiver.Apply(waivers, results, failedTargets, testNow)
+	assert.Equal(t, []string{"lifecycle-lifecycle-pod-owner-type"}, applied[0].WaivedTests)
+}
+
+func TestGetApplied(t *testing.T) {
+	applied := []waiver.Applied{{Waiver: waiver.Waiver{TestID: "lifecycle-pod-owner-type", Justification: "accepted",
+		Expiry: "2021-12-31"}, WaivedTests: []string{"lifecycle-lifecycle-pod-owner-type"}}}
+	c := &schema.Claim{RawResults: map[string]interface{}{"waivers": applied}}
+	decoded, err := waiver.GetApplied(c, "waivers")
+	assert.Nil(t, err)
+	assert.Equal(t, applied, decoded)
+
+	decoded, err = waiver.GetApplied(&schema.Claim{}, "waivers")
+	assert.Nil(t, err)
+	assert.Empty(t, decoded)
+}
@@ -17,31 +17,146 @@
 package autodiscover
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
 	"github.com/test-network-function/test-network-function/pkg/utils"
 )
 
 const (
 	disableAutodiscoverEnvVar = "TNF_DISABLE_CONFIG_AUTODISCOVER"
-	tnfLabelPrefix            = "test-network-function.com"
-	labelTemplate             = "%s/%s"
+	// DefaultLabelDomain is the domain of the labels and annotations read by the autodiscovery, unless overridden with
+	// TNF_LABEL_DOMAIN.  It is also the domain of the labels of the partner and debug pods, which is not configurable.
+	DefaultLabelDomain = "test-network-function.com"
+	// clusterFlavorEnvVar overrides the detected flavor of the cluster, openshift or kubernetes.
+	clusterFlavorEnvVar = "TNF_CLUSTER_FLAVOR"
+	// labelDomainEnvVar overrides the domain of the labels and annotations of the CNF, e.g. for downstream forks.
+	labelDomainEnvVar = "TNF_LABEL_DOMAIN"
+	// maxLabelDomainLength is the maximum length of the prefix of a label, a DNS subdomain.
+	maxLabelDomainLength = 253
+	labelTemplate        = "%s/%s"
 	// anyLabelValue is the value that will allow any value for a label when building the label query.
 	anyLabelValue    = ""
 	ocCommand        = "oc get %s -n %s -o json -l %s"
 	ocCommandTimeOut = time.Second * 10
+	// commandHandlerName is the name of the generic command handler running the oc commands, in the timeouts.
+	commandHandlerName = "command"
 )
 
 var (
 	expectersVerboseModeEnabled = false
+	// timeouts overrides the timeouts of the autodiscovery commands, as set in the configuration file.
+	timeouts configsections.Timeouts
+	// sessions are the shell sessions reused by the autodiscovery commands instead of spawning a shell per command.
+	sessions = interactive.NewSessionPool(spawnSession, interactive.DefaultMaxIdleSessions, interactive.DefaultSessionKeepAlivePeriod,
+		interactive.DefaultSessionProbeTimeout)
+	// detectVersionsOnce detects the versions once, they do not change during a run.
+	detectVersionsOnce sync.Once
+	// detectFlavorOnce detects the flavor once, it does not change during a run.
+	detectFlavorOnce sync.Once
+	// labelDomainRegex matches a DNS subdomain, as required for the prefix of a label.
+	labelDomainRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	// labelDomain is the domain of the labels and annotations of the CNF, read once from the environment.
+	labelDomain = loadLabelDomain()
 )
 
+func spawnSession() (*interactive.Context, error) {
+	return utils.SpawnShell(ocCommandTimeOut, interactive.Verbose(expectersVerboseModeEnabled),
+		interactive.SendTimeout(ocCommandTimeOut))
+}
+
+// runWithSession runs fn on a pooled shell session.  The session is returned to the pool unless fn failed, in which
+// case its output may still be pending and the session is discarded.
+func runWithSession(fn func(context *interactive.Context) error) error {
+	context, err := sessions.Get()
+	if err != nil {
+		return fmt.Errorf("cannot get a shell session: %w", err)
+	}
+	if err := fn(context); err != nil {
+		sessions.Discard(context)
+		return err
+	}
+	sessions.Put(context)
+	return nil
+}
+
+// executeCommand runs an arbitrary command on a pooled shell session, returning its output.
+func executeCommand(command string, failureCallbackFun func()) (out string, err error) {
+	err = runWithSession(func(session *interactive.Context) error {
+		out, err = utils.ExecuteCommand(context.Background(), command, getTimeout(commandHandlerName, ocCommandTimeOut), session, failureCallbackFun)
+		return err
+	})
+	return out, err
+}
+
+// DetectVersions detects the versions of the oc client and of the cluster, once, so that the command lines are adapted
+// to the client.  The latest command lines are used when the versions cannot be detected.
+func DetectVersions() {
+	detectVersionsOnce.Do(func() {
+		out, err := executeCommand(occompat.VersionCommand, nil)
+		var versions occompat.Versions
+		if err == nil {
+			versions, err = occompat.ParseVersions(out)
+		}
+		if err != nil {
+			log.Warnf("cannot detect the version of the oc client, the latest command lines are used: %v", err)
+			return
+		}
+		occompat.SetVersions(versions)
+		log.Infof("Detected oc client version %s, cluster version %s", versions.Client, versions.Server)
+		if !versions.IsSkewSupported() {
+			log.Warnf("The oc client version %s is more than one minor version apart from the cluster version %s, "+
+				"some commands may fail", versions.Client, versions.Server)
+		}
+	})
+}
+
+// DetectFlavor detects the flavor of the cluster once, unless set with TNF_CLUSTER_FLAVOR, so that the OpenShift
+// specific discovery and tests are skipped on the upstream Kubernetes clusters.  A minikube cluster is an upstream
+// Kubernetes cluster, and the cluster is assumed to be an OpenShift cluster when its flavor cannot be detected.
+func DetectFlavor() {
+	detectFlavorOnce.Do(func() {
+		flavor, ok := occompat.ParseFlavorName(os.Getenv(clusterFlavorEnvVar))
+		switch {
+		case ok:
+		case IsMinikube():
+			flavor = occompat.FlavorKubernetes
+		default:
+			out, err := executeCommand(occompat.APIVersionsCommand, nil)
+			if err != nil {
+				log.Warnf("cannot detect the flavor of the cluster, assuming %s: %v", occompat.FlavorOpenShift, err)
+				return
+			}
+			flavor = occompat.ParseFlavor(out)
+		}
+		occompat.SetFlavor(flavor)
+		log.Infof("Detected cluster flavor %s", flavor)
+		if occompat.UsesKubectl() {
+			log.Info("oc is not installed, the oc command lines are run by kubectl")
+		}
+	})
+}
+
+// SetTimeouts sets the timeouts of the autodiscovery commands, the suite timeouts do not apply.
+func SetTimeouts(t configsections.Timeouts) {
+	timeouts = t
+}
+
+// getTimeout returns the timeout of the commands run with handler, fallback unless overridden.
+func getTimeout(handler string, fallback time.Duration) time.Duration {
+	return timeouts.Get("", handler, fallback)
+}
+
 // PerformAutoDiscovery checks the environment variable to see if autodiscovery should be performed
 func PerformAutoDiscovery() (doAuto bool) {
 	doAuto, _ = strconv.ParseBool(os.Getenv(disableAutodiscoverEnvVar))
@@ -55,8 +170,27 @@ func buildLabelName(labelPrefix, labelName string) string {
 	return fmt.Sprintf(labelTemplate, labelPrefix, labelName)
 }
 
+// GetLabelDomain returns the domain of the labels and annotations of the CNF read by the autodiscovery, e.g.
+// "test-network-function.com" for "test-network-function.com/api_access".
+func GetLabelDomain() string {
+	return labelDomain
+}
+
+// loadLabelDomain returns TNF_LABEL_DOMAIN when set to a valid DNS subdomain, DefaultLabelDomain otherwise.
+func loadLabelDomain() string {
+	domain := strings.TrimSuffix(strings.TrimSpace(os.Getenv(labelDomainEnvVar)), "/")
+	if domain == "" {
+		return DefaultLabelDomain
+	}
+	if len(domain) > maxLabelDomainLength || !labelDomainRegex.MatchString(domain) {
+		log.Warnf("%s=%q is not a valid DNS subdomain, using %s", labelDomainEnvVar, domain, DefaultLabelDomain)
+		return DefaultLabelDomain
+	}
+	return domain
+}
+
 func buildAnnotationName(annotationName string) string {
-	return buildLabelName(tnfLabelPrefix, annotationName)
+	return buildLabelName(GetLabelDomain(), annotationName)
 }
 
 func buildLabelQuery(label configsections.Label) string {
@@ -67,12 +201,11 @@ func buildLabelQuery(label configsections.Label) string {
 	return fullLabelName
 }
 
-func executeOcGetCommand(resourceType, labelQuery, namespace string) string {
+func executeOcGetCommand(resourceType, labelQuery, namespace string) (string, error) {
 	ocCommandToExecute := fmt.Sprintf(ocCommand, resourceType, namespace, labelQuery)
-	match := utils.ExecuteCommand(ocCommandToExecute, ocCommandTimeOut, interactive.GetContext(expectersVerboseModeEnabled), func() {
+	return executeCommand(ocCommandToExecute, func() {
 		log.Error("can't run command: ", ocCommandToExecute)
 	})
-	return match
 }
 
 // getContainersByLabel builds `config.Container`s from containers in pods matching a label.
@@ -130,6 +263,11 @@ func buildContainersFromPodResource(pr *PodResource) (containers []configsection
 			log.Warnf("error encountered getting multus IPs: %s", err)
 			err = nil
 		}
+		container.PodIPAddresses = pr.getPodStatusIPs()
+		container.NetworkAttachments, err = pr.getNetworkAttachments()
+		if err != nil {
+			log.Warnf("error encountered getting network attachments: %s", err)
+		}
 
 		containers = append(containers, container)
 	}
@@ -17,17 +17,17 @@
 package autodiscover
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	ds "github.com/test-network-function/test-network-function/pkg/tnf/handlers/daemonset"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
-	"github.com/test-network-function/test-network-function/pkg/utils"
 )
 
 const (
@@ -39,61 +39,76 @@ const (
 	nodeLabelValue     = "target"
 	addlabelCommand    = "oc label node %s %s=%s --overwrite=true"
 	deletelabelCommand = "oc label node %s %s- --overwrite=true"
+
+	debugDaemonSetTimeout       = 60 * time.Second
+	debugDaemonSetPollingPeriod = 2 * time.Second
 )
 
 // FindDebugPods completes a `configsections.TestPartner.ContainersDebugList` from the current state of the cluster,
 // using labels and annotations to populate the data, if it's not fully configured
-func FindDebugPods(tp *configsections.TestPartner) {
+func FindDebugPods(tp *configsections.TestPartner) error {
 	label := configsections.Label{Name: debugLabelName, Value: debugLabelValue}
 	pods, err := GetPodsByLabel(label, defaultNamespace)
 	if err != nil {
-		log.Panic("can't find debug pods")
+		return fmt.Errorf("can't find debug pods: %w", err)
 	}
 	if len(pods.Items) == 0 {
-		log.Panic("can't find debug pods, make sure daemonset debug is deployed properly")
+		return errors.New("can't find debug pods, make sure daemonset debug is deployed properly")
 	}
 	for _, pod := range pods.Items {
 		tp.ContainersDebugList = append(tp.ContainersDebugList, buildContainersFromPodResource(pod)[0])
 	}
+	return nil
 }
 
 // AddDebugLabel add debug label to node
 func AddDebugLabel(nodeName string) {
 	log.Info("add label", nodeLabelName, "=", nodeLabelValue, " to node ", nodeName)
 	ocCommand := fmt.Sprintf(addlabelCommand, nodeName, nodeLabelName, nodeLabelValue)
-	_ = utils.ExecuteCommand(ocCommand, ocCommandTimeOut, interactive.GetContext(expectersVerboseModeEnabled), func() {
-		log.Error("error in adding label to node ", nodeName)
-	})
+	_, err := executeCommand(ocCommand, nil)
+	if err != nil {
+		log.Error("error in adding label to node ", nodeName, ": ", err)
+	}
 }
 
 // AddDebugLabel remove debug label from node
 func DeleteDebugLabel(nodeName string) {
 	log.Info("delete label", nodeLabelName, "=", nodeLabelValue, "to node ", nodeName)
 	ocCommand := fmt.Sprintf(deletelabelCommand, nodeName, nodeLabelName)
-	_ = utils.ExecuteCommand(ocCommand, ocCommandTimeOut, interactive.GetContext(expectersVerboseModeEnabled), func() {
-		log.Error("error in removing label from node ", nodeName)
-	})
+	_, err := executeCommand(ocCommand, nil)
+	if err != nil {
+		log.Error("error in removing label from node ", nodeName, ": ", err)
+	}
 }
 
 // CheckDebugDaemonset checks if the debug pods are deployed properly
-// the function will try DefaultTimeout/time.Second times
-func CheckDebugDaemonset(expectedDebugPods int) {
-	gomega.Eventually(func() bool {
+// the function polls every debugDaemonSetPollingPeriod until debugDaemonSetTimeout expires
+func CheckDebugDaemonset(expectedDebugPods int) error {
+	deadline := time.Now().Add(debugDaemonSetTimeout)
+	for {
 		log.Debug("check debug daemonset status")
-		return checkDebugPodsReadiness(expectedDebugPods)
-	}, 60*time.Second, 2*time.Second).Should(gomega.Equal(true)) //nolint: gomnd
+		if checkDebugPodsReadiness(expectedDebugPods) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("daemonset %s is not ready after %s, expected %d debug pods", debugDaemonSet, debugDaemonSetTimeout, expectedDebugPods)
+		}
+		time.Sleep(debugDaemonSetPollingPeriod)
+	}
 }
 
 // checkDebugPodsReadiness helper function that returns true if the daemonset debug is deployed properly
 func checkDebugPodsReadiness(expectedDebugPods int) bool {
-	context := interactive.GetContext(expectersVerboseModeEnabled)
-	tester := ds.NewDaemonSet(DefaultTimeout, debugDaemonSet, defaultNamespace)
-	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
-	if err != nil {
-		log.Error("can't run test to detect daemonset status")
-		return false
-	}
-	_, err = test.Run()
+	tester := ds.NewDaemonSet(getTimeout("daemonset", DefaultTimeout), debugDaemonSet, defaultNamespace)
+	err := runWithSession(func(session *interactive.Context) error {
+		test, err := tnf.NewTest(context.Background(), session.GetExpecter(), tester, []reel.Handler{tester}, session.GetErrorChannel())
+		if err != nil {
+			log.Error("can't run test to detect daemonset status")
+			return err
+		}
+		_, err = test.Run()
+		return err
+	})
 	if err != nil {
 		return false
 	}
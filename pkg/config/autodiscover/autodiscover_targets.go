@@ -18,30 +18,36 @@ package autodiscover
 
 import (
 	"encoding/json"
-	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
-	"github.com/test-network-function/test-network-function/pkg/tnf"
-	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodenames"
-	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
-	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
-	"github.com/test-network-function/test-network-function/pkg/utils"
 )
 
 const (
-	operatorLabelName           = "operator"
-	skipConnectivityTestsLabel  = "skip_connectivity_tests"
-	ocGetClusterCrdNamesCommand = "kubectl get crd -o json | jq '[.items[].metadata.name]'"
-	DefaultTimeout              = 10 * time.Second
+	operatorLabelName          = "operator"
+	skipConnectivityTestsLabel = "skip_connectivity_tests"
+	// ocGetClusterCrdsCommand prints the name, the group, the plural, the served versions, those with the status and
+	// the scale subresources, and the scope of the CRDs.
+	ocGetClusterCrdsCommand = "kubectl get crd -o json | jq '[.items[] | {name: .metadata.name, group: .spec.group, " +
+		"plural: .spec.names.plural, versions: [.spec.versions[] | select(.served) | .name], " +
+		"statusVersions: [.spec.versions[] | select(.served and .subresources.status != null) | .name], " +
+		"scaleVersions: [.spec.versions[] | select(.served and .subresources.scale != null) | .name], " +
+		"scope: .spec.scope}]'"
+	DefaultTimeout = 10 * time.Second
 )
 
 var (
-	operatorTestsAnnotationName    = buildAnnotationName("operator_tests")
-	subscriptionNameAnnotationName = buildAnnotationName("subscription_name")
-	podTestsAnnotationName         = buildAnnotationName("host_resource_tests")
+	operatorTestsAnnotationName           = buildAnnotationName("operator_tests")
+	subscriptionNameAnnotationName        = buildAnnotationName("subscription_name")
+	podTestsAnnotationName                = buildAnnotationName("host_resource_tests")
+	hostNamespaceExemptionsAnnotationName = buildAnnotationName("host_namespace_exemptions")
+	apiAccessAnnotationName               = buildAnnotationName("api_access")
+	latencySensitiveAnnotationName        = buildAnnotationName("latency_sensitive")
+	sigtermHandlerAnnotationName          = buildAnnotationName("sigterm_handler")
+	containerTestSkipsAnnotationName      = buildAnnotationName("container_test_skips")
 )
 
 // FindTestTarget finds test targets from the current state of the cluster,
@@ -60,67 +66,56 @@ func FindTestTarget(labels []configsections.Label, target *configsections.TestTa
 		}
 	}
 	// Containers to exclude from connectivity tests are optional
-	identifiers, err := getContainerIdentifiersByLabel(configsections.Label{Prefix: tnfLabelPrefix, Name: skipConnectivityTestsLabel, Value: anyLabelValue}, namespace)
+	identifiers, err := getContainerIdentifiersByLabel(configsections.Label{Prefix: GetLabelDomain(), Name: skipConnectivityTestsLabel, Value: anyLabelValue}, namespace)
 	target.ExcludeContainersFromConnectivityTests = identifiers
 
 	if err != nil {
 		log.Warnf("an error (%s) occurred when getting the containers to exclude from connectivity tests. Attempting to continue", err)
 	}
 
-	csvs, err := GetCSVsByLabel(operatorLabelName, anyLabelValue, namespace)
-	if err == nil {
-		for i := range csvs.Items {
-			target.Operators = append(target.Operators, buildOperatorFromCSVResource(&csvs.Items[i]))
+	// The operators are installed by OLM, which only comes with the OpenShift clusters
+	if occompat.IsOpenShift() {
+		var csvs []CSVResource
+		csvs, err = getOperatorCSVs(operatorLabelName, anyLabelValue, namespace)
+		if err == nil {
+			for i := range csvs {
+				target.Operators = append(target.Operators, buildOperatorFromCSVResource(&csvs[i]))
+			}
+		} else {
+			log.Warnf("an error (%s) occurred when looking for operaters by label", err)
 		}
 	} else {
-		log.Warnf("an error (%s) occurred when looking for operaters by label", err)
+		log.Info("Skipping the discovery of the operators, the cluster is not an OpenShift cluster")
 	}
 
 	target.DeploymentsUnderTest = append(target.DeploymentsUnderTest, FindTestDeployments(labels, target, namespace)...)
-	target.Nodes = GetNodesList()
-}
-
-// GetNodesList Function that return a list of node and what is the type of them.
-func GetNodesList() (nodes map[string]configsections.Node) {
-	nodes = make(map[string]configsections.Node)
-	var nodeNames []string
-	context := interactive.GetContext(expectersVerboseModeEnabled)
-	tester := nodenames.NewNodeNames(DefaultTimeout, map[string]*string{configsections.MasterLabel: nil})
-	test, _ := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
-	_, err := test.Run()
+	target.StatefulSetsUnderTest = append(target.StatefulSetsUnderTest, FindTestStatefulSets(labels, namespace)...)
+	// Multus is optional, the NetworkAttachmentDefinition resource type may not exist
+	target.NetworkAttachmentDefinitions, err = GetNetworkAttachmentDefinitions(namespace)
 	if err != nil {
-		log.Error("Unable to get node list ", ". Error: ", err)
-		return
-	}
-	nodeNames = tester.GetNodeNames()
-	for i := range nodeNames {
-		nodes[nodeNames[i]] = configsections.Node{
-			Name:   nodeNames[i],
-			Labels: []string{configsections.MasterLabel},
-		}
+		log.Warnf("an error (%s) occurred when getting the network attachment definitions", err)
 	}
-
-	tester = nodenames.NewNodeNames(DefaultTimeout, map[string]*string{configsections.WorkerLabel: nil})
-	test, _ = tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
-	_, err = test.Run()
+	target.Services, err = GetServices(namespace)
 	if err != nil {
-		log.Error("Unable to get node list ", ". Error: ", err)
-	} else {
-		nodeNames = tester.GetNodeNames()
-		for i := range nodeNames {
-			if _, ok := nodes[nodeNames[i]]; ok {
-				var node = nodes[nodeNames[i]]
-				node.Labels = append(node.Labels, configsections.WorkerLabel)
-				nodes[nodeNames[i]] = node
-			} else {
-				nodes[nodeNames[i]] = configsections.Node{
-					Name:   nodeNames[i],
-					Labels: []string{configsections.WorkerLabel},
-				}
-			}
-		}
+		log.Warnf("an error (%s) occurred when getting the services", err)
 	}
-	return nodes
+	target.NetworkPolicies, err = GetNetworkPolicies(namespace)
+	if err != nil {
+		log.Warnf("an error (%s) occurred when getting the network policies", err)
+	}
+	target.PodDisruptionBudgets, err = GetPodDisruptionBudgets(namespace)
+	if err != nil {
+		log.Warnf("an error (%s) occurred when getting the pod disruption budgets", err)
+	}
+	target.RoleBindings, target.Roles, err = GetRBAC(target.PodsUnderTest)
+	if err != nil {
+		log.Warnf("an error (%s) occurred when getting the role bindings", err)
+	}
+	target.HelmReleases, err = GetHelmReleases(namespace)
+	if err != nil {
+		log.Warnf("an error (%s) occurred when getting the helm releases", err)
+	}
+	target.Nodes = GetNodesList()
 }
 
 // FindTestDeployments uses the containers' namespace to get its parent deployment. Filters out non CNF test deployments,
@@ -136,6 +131,7 @@ func FindTestDeployments(targetLabels []configsections.Label, target *configsect
 					Name:      deploymentResource.GetName(),
 					Namespace: deploymentResource.GetNamespace(),
 					Replicas:  deploymentResource.GetReplicas(),
+					PodLabels: deploymentResource.GetPodLabels(),
 				}
 
 				deployments = append(deployments, deployment)
@@ -145,6 +141,26 @@ func FindTestDeployments(targetLabels []configsections.Label, target *configsect
 	return deployments
 }
 
+// FindTestStatefulSets returns the statefulsets of namespace whose pods have one of the target labels.
+func FindTestStatefulSets(targetLabels []configsections.Label, namespace string) (statefulSets []configsections.StatefulSet) {
+	for _, label := range targetLabels {
+		statefulSetResourceList, err := GetTargetStatefulSetsByNamespace(namespace, label)
+		if err != nil {
+			log.Error("Unable to get statefulset list from namespace ", namespace, ". Error: ", err)
+			continue
+		}
+		for _, statefulSetResource := range statefulSetResourceList.Items {
+			statefulSets = append(statefulSets, configsections.StatefulSet{
+				Name:      statefulSetResource.GetName(),
+				Namespace: statefulSetResource.GetNamespace(),
+				Replicas:  statefulSetResource.GetReplicas(),
+				PodLabels: statefulSetResource.GetPodLabels(),
+			})
+		}
+	}
+	return statefulSets
+}
+
 // buildPodUnderTest builds a single `configsections.Pod` from a PodResource
 func buildPodUnderTest(pr *PodResource) (podUnderTest configsections.Pod) {
 	var err error
@@ -152,6 +168,11 @@ func buildPodUnderTest(pr *PodResource) (podUnderTest configsections.Pod) {
 	podUnderTest.Name = pr.Metadata.Name
 	podUnderTest.ServiceAccount = pr.Spec.ServiceAccount
 	podUnderTest.ContainerCount = len(pr.Spec.Containers)
+	podUnderTest.Labels = pr.Metadata.Labels
+	for _, container := range pr.Spec.Containers {
+		podUnderTest.ContainerNames = append(podUnderTest.ContainerNames, container.Name)
+		podUnderTest.Ports = append(podUnderTest.Ports, container.Ports...)
+	}
 	var tests []string
 	err = pr.GetAnnotationValue(podTestsAnnotationName, &tests)
 	if err != nil {
@@ -160,6 +181,41 @@ func buildPodUnderTest(pr *PodResource) (podUnderTest configsections.Pod) {
 	} else {
 		podUnderTest.Tests = tests
 	}
+	if pr.hasAnnotation(hostNamespaceExemptionsAnnotationName) {
+		err = pr.GetAnnotationValue(hostNamespaceExemptionsAnnotationName, &podUnderTest.HostNamespaceExemptions)
+		if err != nil {
+			log.Warnf("unable to extract the host namespace exemptions of '%s/%s' (error: %s), none is exempted", podUnderTest.Namespace, podUnderTest.Name, err)
+			podUnderTest.HostNamespaceExemptions = nil
+		}
+	}
+	if pr.hasAnnotation(apiAccessAnnotationName) {
+		err = pr.GetAnnotationValue(apiAccessAnnotationName, &podUnderTest.APIAccess)
+		if err != nil {
+			log.Warnf("unable to extract the API access of '%s/%s' (error: %s), no access is declared", podUnderTest.Namespace, podUnderTest.Name, err)
+			podUnderTest.APIAccess = false
+		}
+	}
+	if pr.hasAnnotation(latencySensitiveAnnotationName) {
+		err = pr.GetAnnotationValue(latencySensitiveAnnotationName, &podUnderTest.LatencySensitive)
+		if err != nil {
+			log.Warnf("unable to extract the latency sensitivity of '%s/%s' (error: %s), it is not latency-sensitive", podUnderTest.Namespace, podUnderTest.Name, err)
+			podUnderTest.LatencySensitive = false
+		}
+	}
+	if pr.hasAnnotation(sigtermHandlerAnnotationName) {
+		err = pr.GetAnnotationValue(sigtermHandlerAnnotationName, &podUnderTest.SIGTERMHandler)
+		if err != nil {
+			log.Warnf("unable to extract the SIGTERM handling of '%s/%s' (error: %s), it does not handle SIGTERM", podUnderTest.Namespace, podUnderTest.Name, err)
+			podUnderTest.SIGTERMHandler = false
+		}
+	}
+	if pr.hasAnnotation(containerTestSkipsAnnotationName) {
+		err = pr.GetAnnotationValue(containerTestSkipsAnnotationName, &podUnderTest.ContainerTestSkips)
+		if err != nil {
+			log.Warnf("unable to extract the container test skips of '%s/%s' (error: %s), no container is skipped", podUnderTest.Namespace, podUnderTest.Name, err)
+			podUnderTest.ContainerTestSkips = nil
+		}
+	}
 	return
 }
 
@@ -168,6 +224,9 @@ func buildOperatorFromCSVResource(csv *CSVResource) (op configsections.Operator)
 	var err error
 	op.Name = csv.Metadata.Name
 	op.Namespace = csv.Metadata.Namespace
+	op.Package = csv.GetPackageName()
+	op.Version = csv.Spec.Version
+	op.InstallModes = csv.GetSupportedInstallModes()
 
 	var tests []string
 	err = csv.GetAnnotationValue(operatorTestsAnnotationName, &tests)
@@ -203,37 +262,44 @@ func getConfiguredOperatorTests() (opTests []string) {
 	return opTests
 }
 
-// getClusterCrdNames returns a list of crd names found in the cluster.
-func getClusterCrdNames() ([]string, error) {
-	out := utils.ExecuteCommand(ocGetClusterCrdNamesCommand, ocCommandTimeOut, interactive.GetContext(expectersVerboseModeEnabled), func() {
-		log.Error("can't run command: ", ocGetClusterCrdNamesCommand)
+// getClusterCrds returns the metadata of the CRDs found in the cluster.
+func getClusterCrds() ([]configsections.Crd, error) {
+	out, err := executeCommand(ocGetClusterCrdsCommand, func() {
+		log.Error("can't run command: ", ocGetClusterCrdsCommand)
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	var crdNamesList []string
-	err := json.Unmarshal([]byte(out), &crdNamesList)
+	var crds []configsections.Crd
+	err = json.Unmarshal([]byte(out), &crds)
 	if err != nil {
 		return nil, err
 	}
 
-	return crdNamesList, nil
+	return crds, nil
 }
 
-// FindTestCrdNames gets a list of CRD names based on configured groups.
-func FindTestCrdNames(crdFilters []configsections.CrdFilter) []string {
-	clusterCrdNames, err := getClusterCrdNames()
+// FindTestCrds gets the metadata of the CRDs matching the configured filters.
+func FindTestCrds(crdFilters []configsections.CrdFilter) []configsections.Crd {
+	clusterCrds, err := getClusterCrds()
 	if err != nil {
 		log.Errorf("Unable to get cluster CRD.")
-		return []string{}
+		return []configsections.Crd{}
 	}
+	return filterCrds(clusterCrds, crdFilters)
+}
 
-	var targetCrdNames []string
-	for _, crdName := range clusterCrdNames {
-		for _, crdFilter := range crdFilters {
-			if strings.HasSuffix(crdName, crdFilter.NameSuffix) {
-				targetCrdNames = append(targetCrdNames, crdName)
+// filterCrds returns the CRDs matching any of the filters.
+func filterCrds(crds []configsections.Crd, crdFilters []configsections.CrdFilter) []configsections.Crd {
+	var targetCrds []configsections.Crd
+	for i := range crds {
+		for j := range crdFilters {
+			if crdFilters[j].Matches(&crds[i]) {
+				targetCrds = append(targetCrds, crds[i])
 				break
 			}
 		}
 	}
-	return targetCrdNames
+	return targetCrds
 }
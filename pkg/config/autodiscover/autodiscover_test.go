@@ -0,0 +1,63 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package autodiscover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestLoadLabelDomain(t *testing.T) {
+	t.Setenv(labelDomainEnvVar, "")
+	assert.Equal(t, DefaultLabelDomain, loadLabelDomain())
+	t.Setenv(labelDomainEnvVar, "cnf.example.com/")
+	assert.Equal(t, "cnf.example.com", loadLabelDomain())
+	for _, invalid := range []string{"CNF.example.com", "cnf_example.com", "cnf.example.com/sub", "-cnf.example.com"} {
+		t.Setenv(labelDomainEnvVar, invalid)
+		assert.Equal(t, DefaultLabelDomain, loadLabelDomain(), invalid)
+	}
+}
+
+func TestBuildAnnotationName(t *testing.T) {
+	assert.Equal(t, "test-network-function.com/api_access", buildAnnotationName("api_access"))
+	assert.Equal(t, "api_access", buildLabelName("", "api_access"))
+}
+
+func TestFilterCrds(t *testing.T) {
+	crds := []configsections.Crd{
+		{Name: "crdexamples.group1.tnf.com", Group: "group1.tnf.com", Versions: []string{"v1"}, Scope: "Namespaced"},
+		{Name: "clusterexamples.group1.tnf.com", Group: "group1.tnf.com", Versions: []string{"v1beta1"}, Scope: "Cluster"},
+		{Name: "others.example.com", Group: "example.com", Versions: []string{"v1"}, Scope: "Namespaced"},
+	}
+	assert.Equal(t, []string{"crdexamples.group1.tnf.com", "clusterexamples.group1.tnf.com"},
+		crdNames(filterCrds(crds, []configsections.CrdFilter{{NameSuffix: "tnf.com"}})))
+	assert.Equal(t, []string{"crdexamples.group1.tnf.com", "others.example.com"},
+		crdNames(filterCrds(crds, []configsections.CrdFilter{{Version: "v1", Scope: "Namespaced"}})))
+	assert.Equal(t, []string{"clusterexamples.group1.tnf.com", "others.example.com"},
+		crdNames(filterCrds(crds, []configsections.CrdFilter{{Group: "group1.tnf.com", Scope: "Cluster"}, {Group: "example.com"}})))
+	assert.Empty(t, filterCrds(crds, nil))
+}
+
+// crdNames returns the names of the CRDs.
+func crdNames(crds []configsections.Crd) []string {
+	names := make([]string, len(crds))
+	for i := range crds {
+		names[i] = crds[i].Name
+	}
+	return names
+}
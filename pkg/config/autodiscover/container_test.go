@@ -46,4 +46,8 @@ func TestBuildContainersFromPodResource(t *testing.T) {
 	assert.Equal(t, 2, len(subjectContainers[0].MultusIPAddresses))
 	assert.Equal(t, "3.3.3.3", subjectContainers[0].MultusIPAddresses[0])
 	assert.Equal(t, "4.4.4.4", subjectContainers[0].MultusIPAddresses[1])
+
+	// Check pod IPs of both address families are collected from the pod status
+	assert.Equal(t, []string{"2.2.2.2", "fd00:10:128::5"}, orchestratorContainers[0].PodIPAddresses)
+	assert.Equal(t, []string{"10.217.1.89"}, subjectContainers[0].PodIPAddresses)
 }
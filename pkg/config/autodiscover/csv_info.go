@@ -18,6 +18,8 @@ package autodiscover
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -26,6 +28,19 @@ import (
 
 const (
 	resourceTypeCSV = "csv"
+	// olmPackageLabelPrefix prefixes the label OLM sets on the CSVs it installs, "operators.coreos.com/<package>.<namespace>".
+	olmPackageLabelPrefix = "operators.coreos.com/"
+	// olmCopiedFromLabel is the label OLM sets on the copies of the CSV of an operator watching other namespaces than its
+	// own, to the namespace of the original CSV.
+	olmCopiedFromLabel = "olm.copiedFrom"
+	// ocGetAllNamespacesCommand lists the resources matching a label in all the namespaces.
+	ocGetAllNamespacesCommand = "oc get %s --all-namespaces -o json -l %s"
+	// operatorDiscoveryEnvVar selects how the CSVs of the operators under test are discovered, see
+	// OperatorDiscoveryAllNamespaces.
+	operatorDiscoveryEnvVar = "TNF_OPERATOR_DISCOVERY"
+	// OperatorDiscoveryAllNamespaces discovers the CSVs in all the namespaces, e.g. the operators installed in
+	// openshift-operators for all the namespaces, instead of the namespace under test only.
+	OperatorDiscoveryAllNamespaces = "all-namespaces"
 )
 
 // CSVList holds the data from an `oc get csv -o json` command
@@ -41,6 +56,13 @@ type CSVResource struct {
 		Labels      map[string]string `json:"labels"`
 		Annotations map[string]string `json:"annotations"`
 	} `json:"metadata"`
+	Spec struct {
+		Version      string `json:"version"`
+		InstallModes []struct {
+			Type      string `json:"type"`
+			Supported bool   `json:"supported"`
+		} `json:"installModes"`
+	} `json:"spec"`
 }
 
 func (csv *CSVResource) hasAnnotation(annotationKey string) (present bool) {
@@ -62,6 +84,34 @@ func (csv *CSVResource) GetAnnotationValue(annotationKey string, v interface{})
 	return
 }
 
+// GetSupportedInstallModes returns the install modes the CSV supports, e.g. "OwnNamespace" or "AllNamespaces".
+func (csv *CSVResource) GetSupportedInstallModes() []string {
+	var modes []string
+	for _, mode := range csv.Spec.InstallModes {
+		if mode.Supported {
+			modes = append(modes, mode.Type)
+		}
+	}
+	return modes
+}
+
+// GetPackageName returns the name of the package of the operator, from the label OLM sets on the CSV, empty when the
+// CSV was not installed by OLM.
+func (csv *CSVResource) GetPackageName() string {
+	suffix := "." + csv.Metadata.Namespace
+	for label := range csv.Metadata.Labels {
+		if strings.HasPrefix(label, olmPackageLabelPrefix) && strings.HasSuffix(label, suffix) {
+			return strings.TrimSuffix(strings.TrimPrefix(label, olmPackageLabelPrefix), suffix)
+		}
+	}
+	return ""
+}
+
+// GetCopiedFrom returns the namespace of the original CSV when the CSV is a copy made by OLM, empty otherwise.
+func (csv *CSVResource) GetCopiedFrom() string {
+	return csv.Metadata.Labels[olmCopiedFromLabel]
+}
+
 func (csv *CSVResource) annotationUnmarshalError(annotationKey string, err error) error {
 	return fmt.Errorf("error (%s) attempting to unmarshal value of annotation '%s' on CSV '%s/%s'",
 		err, annotationKey, csv.Metadata.Namespace, csv.Metadata.Name)
@@ -70,16 +120,86 @@ func (csv *CSVResource) annotationUnmarshalError(annotationKey string, err error
 // GetCSVsByLabel will return all CSVs with a given label value. If `labelValue` is an empty string, all CSVs with that
 // label will be returned, regardless of the labels value.
 func GetCSVsByLabel(labelName, labelValue, namespace string) (*CSVList, error) {
-	out := executeOcGetCommand(resourceTypeCSV, buildLabelQuery(configsections.Label{Prefix: tnfLabelPrefix, Name: labelName, Value: labelValue}), namespace)
+	out, err := executeOcGetCommand(resourceTypeCSV, buildLabelQuery(configsections.Label{Prefix: GetLabelDomain(), Name: labelName, Value: labelValue}), namespace)
+	if err != nil {
+		return nil, err
+	}
 
 	log.Debug("JSON output for all pods labeled with: ", labelName)
 	log.Debug("Command: ", out)
 
 	var csvList CSVList
-	err := jsonUnmarshal([]byte(out), &csvList)
+	err = jsonUnmarshal([]byte(out), &csvList)
 	if err != nil {
 		return nil, err
 	}
 
 	return &csvList, nil
 }
+
+// GetCSVsByLabelAllNamespaces returns the CSVs with a given label value in all the namespaces, the original CSVs as well
+// as their copies.  See GetCSVsByLabel.
+func GetCSVsByLabelAllNamespaces(labelName, labelValue string) (*CSVList, error) {
+	command := fmt.Sprintf(ocGetAllNamespacesCommand, resourceTypeCSV,
+		buildLabelQuery(configsections.Label{Prefix: GetLabelDomain(), Name: labelName, Value: labelValue}))
+	out, err := getJSON(command)
+	if err != nil {
+		return nil, err
+	}
+	var csvList CSVList
+	err = jsonUnmarshal(out, &csvList)
+	if err != nil {
+		return nil, err
+	}
+	return &csvList, nil
+}
+
+// getOperatorCSVs returns the CSVs of the operators under test, with a given label value.  They are the CSVs of the
+// namespace under test, unless TNF_OPERATOR_DISCOVERY is OperatorDiscoveryAllNamespaces, in which case they are the
+// original CSVs of the operators installed in the namespace or watching it, see attributeCSVs.
+func getOperatorCSVs(labelName, labelValue, namespace string) ([]CSVResource, error) {
+	if os.Getenv(operatorDiscoveryEnvVar) != OperatorDiscoveryAllNamespaces {
+		csvs, err := GetCSVsByLabel(labelName, labelValue, namespace)
+		if err != nil {
+			return nil, err
+		}
+		return csvs.Items, nil
+	}
+	csvs, err := GetCSVsByLabelAllNamespaces(labelName, labelValue)
+	if err != nil {
+		return nil, err
+	}
+	return attributeCSVs(csvs.Items, namespace), nil
+}
+
+// attributeCSVs de-duplicates the CSVs of all the namespaces, and returns the original CSVs of the operators installed
+// in namespace, or watching it as OLM copied their CSV into it, once each.
+func attributeCSVs(csvs []CSVResource, namespace string) []CSVResource {
+	var originals []*CSVResource
+	watched := map[string]bool{}
+	for i := range csvs {
+		csv := &csvs[i]
+		if copiedFrom := csv.GetCopiedFrom(); copiedFrom != "" {
+			if csv.Metadata.Namespace == namespace {
+				watched[copiedFrom+"/"+csv.Metadata.Name] = true
+			}
+			continue
+		}
+		originals = append(originals, csv)
+		if csv.Metadata.Namespace == namespace {
+			watched[csv.Metadata.Namespace+"/"+csv.Metadata.Name] = true
+		}
+	}
+	var attributed []CSVResource
+	for _, csv := range originals {
+		key := csv.Metadata.Namespace + "/" + csv.Metadata.Name
+		if watched[key] {
+			attributed = append(attributed, *csv)
+			delete(watched, key)
+		}
+	}
+	for key := range watched {
+		log.Warnf("the original CSV %s of a CSV copied into namespace %s was not found", key, namespace)
+	}
+	return attributed
+}
@@ -26,11 +26,13 @@ import (
 )
 
 const (
-	csvFile = "csv.json"
+	csvFile  = "csv.json"
+	csvsFile = "csvs.json"
 )
 
 var (
-	csvFilePath = path.Join(filePath, csvFile)
+	csvFilePath  = path.Join(filePath, csvFile)
+	csvsFilePath = path.Join(filePath, csvsFile)
 )
 
 func loadCSVResource(filePath string) (csv CSVResource) {
@@ -57,3 +59,28 @@ func TestCSVGetAnnotationValue(t *testing.T) {
 	assert.Equal(t, []string{"OPERATOR_STATUS", "ANOTHER_TEST"}, val)
 	assert.Nil(t, err)
 }
+
+func TestAttributeCSVs(t *testing.T) {
+	contents, err := os.ReadFile(csvsFilePath)
+	assert.Nil(t, err)
+	var csvs CSVList
+	assert.Nil(t, jsonUnmarshal(contents, &csvs))
+	assert.Equal(t, "openshift-operators", csvs.Items[2].GetCopiedFrom())
+	assert.Equal(t, "", csvs.Items[0].GetCopiedFrom())
+
+	// the cluster-wide operator is attributed once, from its original CSV, the operator of another namespace is not.
+	attributed := attributeCSVs(csvs.Items, "tnf")
+	assert.Equal(t, 2, len(attributed))
+	assert.Equal(t, "openshift-operators", attributed[0].Metadata.Namespace)
+	assert.Equal(t, "cluster-operator.v1.0.0", attributed[0].Metadata.Name)
+	assert.Equal(t, "cluster-operator", attributed[0].GetPackageName())
+	assert.Equal(t, "tnf", attributed[1].Metadata.Namespace)
+	assert.Equal(t, "nginx-operator.v0.0.1", attributed[1].Metadata.Name)
+
+	attributed = attributeCSVs(csvs.Items, "openshift-operators")
+	assert.Equal(t, 1, len(attributed))
+	assert.Equal(t, "cluster-operator.v1.0.0", attributed[0].Metadata.Name)
+
+	// the copy without its original is dropped.
+	assert.Empty(t, attributeCSVs(csvs.Items[1:3], "tnf"))
+}
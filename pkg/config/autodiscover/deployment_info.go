@@ -22,24 +22,24 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
-	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
-	"github.com/test-network-function/test-network-function/pkg/utils"
 )
 
 const (
-	resourceTypeDeployment = "deployment"
+	resourceTypeDeployment  = "deployment"
+	resourceTypeStatefulSet = "statefulset"
 )
 
 var (
 	jsonUnmarshal     = json.Unmarshal
-	execCommandOutput = func(command string) string {
-		return utils.ExecuteCommand(command, ocCommandTimeOut, interactive.GetContext(expectersVerboseModeEnabled), func() {
+	execCommandOutput = func(command string) (string, error) {
+		return executeCommand(command, func() {
 			log.Error("can't run command: ", command)
 		})
 	}
 )
 
-// DeploymentList holds the data from an `oc get deployments -o json` command
+// DeploymentList holds the data from an `oc get deployments -o json` command, or `oc get statefulsets -o json` as the
+// fields read are common to both
 type DeploymentList struct {
 	Items []DeploymentResource `json:"items"`
 }
@@ -55,6 +55,11 @@ type DeploymentResource struct {
 
 	Spec struct {
 		Replicas int `json:"replicas"`
+		Template struct {
+			Metadata struct {
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+		} `json:"template"`
 	}
 }
 
@@ -78,16 +83,35 @@ func (deployment *DeploymentResource) GetLabels() map[string]string {
 	return deployment.Metadata.Labels
 }
 
+// GetPodLabels returns a map with the labels of the deployment's pod template.
+func (deployment *DeploymentResource) GetPodLabels() map[string]string {
+	return deployment.Spec.Template.Metadata.Labels
+}
+
 // GetTargetDeploymentsByNamespace will return all deployments that have pods with a given label.
 func GetTargetDeploymentsByNamespace(namespace string, targetLabel configsections.Label) (*DeploymentList, error) {
+	return getTargetWorkloadsByNamespace(resourceTypeDeployment, namespace, targetLabel)
+}
+
+// GetTargetStatefulSetsByNamespace will return all statefulsets that have pods with a given label.
+func GetTargetStatefulSetsByNamespace(namespace string, targetLabel configsections.Label) (*DeploymentList, error) {
+	return getTargetWorkloadsByNamespace(resourceTypeStatefulSet, namespace, targetLabel)
+}
+
+// getTargetWorkloadsByNamespace returns the workloads of resourceType, e.g. deployments, that have pods with a given
+// label.
+func getTargetWorkloadsByNamespace(resourceType, namespace string, targetLabel configsections.Label) (*DeploymentList, error) {
 	labelQuery := fmt.Sprintf("\"%s\"==\"%s\"", buildLabelName(targetLabel.Prefix, targetLabel.Name), targetLabel.Value)
 	jqArgs := fmt.Sprintf("'[.items[] | select(.spec.template.metadata.labels.%s)]'", labelQuery)
-	ocCmd := fmt.Sprintf("oc get %s -n %s -o json | jq %s", resourceTypeDeployment, namespace, jqArgs)
+	ocCmd := fmt.Sprintf("oc get %s -n %s -o json | jq %s", resourceType, namespace, jqArgs)
 
-	out := execCommandOutput(ocCmd)
+	out, err := execCommandOutput(ocCmd)
+	if err != nil {
+		return nil, err
+	}
 
 	var deploymentList DeploymentList
-	err := jsonUnmarshal([]byte(out), &deploymentList.Items)
+	err = jsonUnmarshal([]byte(out), &deploymentList.Items)
 	if err != nil {
 		return nil, err
 	}
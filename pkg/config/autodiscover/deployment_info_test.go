@@ -60,6 +60,9 @@ func TestPodGetAnnotationValue1(t *testing.T) {
 	labels := deployment.GetLabels()
 	assert.Equal(t, 1, len(labels))
 	assert.Equal(t, "test", labels["app"])
+
+	assert.Equal(t, map[string]string{"app": "test", "test-network-function.com/generic": "target"},
+		deployment.GetPodLabels())
 }
 
 //nolint:funlen
@@ -93,14 +96,14 @@ func TestGetTargetDeploymentByNamespace(t *testing.T) {
 	for _, tc := range testCases {
 		// Setup the mock functions
 		if tc.badExec {
-			execCommandOutput = func(command string) string {
-				return ""
+			execCommandOutput = func(command string) (string, error) {
+				return "", tc.execErr
 			}
 		} else {
-			execCommandOutput = func(command string) string {
+			execCommandOutput = func(command string) (string, error) {
 				contents, err := os.ReadFile(testJQFilePath)
 				assert.Nil(t, err)
-				return string(contents)
+				return string(contents), nil
 			}
 		}
 		if tc.badJSONUnmarshal {
@@ -135,3 +138,21 @@ func TestGetTargetDeploymentByNamespace(t *testing.T) {
 		}
 	}
 }
+
+func TestGetTargetStatefulSetsByNamespace(t *testing.T) {
+	origExecFunc := execCommandOutput
+	defer func() {
+		execCommandOutput = origExecFunc
+	}()
+	var command string
+	execCommandOutput = func(c string) (string, error) {
+		command = c
+		contents, err := os.ReadFile(testJQFilePath)
+		assert.Nil(t, err)
+		return string(contents), nil
+	}
+	list, err := GetTargetStatefulSetsByNamespace("test", configsections.Label{Prefix: "prefix1", Name: "name1", Value: "value1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "my-test1", list.Items[0].GetName())
+	assert.Equal(t, `oc get statefulset -n test -o json | jq '[.items[] | select(.spec.template.metadata.labels."prefix1/name1"=="value1")]'`, command)
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package autodiscover
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	// ocGetHelmStorageCommand lists the secrets or the configmaps in which Helm stores the revisions of its releases.
+	ocGetHelmStorageCommand = "oc get %s -n %s -l owner=helm -o json"
+	// helmReleaseDataKey is the key of the encoded release in the data of the secrets and configmaps.
+	helmReleaseDataKey = "release"
+)
+
+// gzipMagic prefixes the gzipped releases.
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// helmStorage is a kind of resource Helm stores its releases in, depending on its storage driver.
+type helmStorage struct {
+	resourceType string
+	// base64Data is true when the data of the resource is itself base64 encoded, i.e. for the secrets.
+	base64Data bool
+}
+
+// helmStorages are the resources of the secret driver, the default one, and of the configmap driver.
+var helmStorages = []helmStorage{
+	{resourceType: "secrets", base64Data: true},
+	{resourceType: "configmaps", base64Data: false},
+}
+
+// helmStorageList holds the data from an `oc get secrets -l owner=helm -o json` command, or configmaps.
+type helmStorageList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Data map[string]string `json:"data"`
+	} `json:"items"`
+}
+
+// helmRelease is a revision of a release, as stored by Helm.
+type helmRelease struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Info      struct {
+		Status string `json:"status"`
+	} `json:"info"`
+	Chart struct {
+		Metadata struct {
+			Name       string   `json:"name"`
+			Version    string   `json:"version"`
+			AppVersion string   `json:"appVersion"`
+			Home       string   `json:"home"`
+			Sources    []string `json:"sources"`
+		} `json:"metadata"`
+	} `json:"chart"`
+	Config map[string]interface{} `json:"config"`
+}
+
+// GetHelmReleases returns the latest revision of each Helm release installed in a namespace.
+func GetHelmReleases(namespace string) ([]configsections.HelmRelease, error) {
+	var releases []configsections.HelmRelease
+	for _, storage := range helmStorages {
+		command := fmt.Sprintf(ocGetHelmStorageCommand, storage.resourceType, namespace)
+		out, err := executeCommand(command, func() {
+			log.Error("can't run command: ", command)
+		})
+		if err != nil {
+			return nil, err
+		}
+		revisions, err := parseHelmReleases([]byte(out), storage.base64Data)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, revisions...)
+	}
+	return latestHelmRevisions(releases), nil
+}
+
+// parseHelmReleases parses the output of an `oc get secrets -l owner=helm -o json` command, or configmaps, into the
+// revisions of the releases.
+func parseHelmReleases(out []byte, base64Data bool) (releases []configsections.HelmRelease, err error) {
+	var list helmStorageList
+	if err = jsonUnmarshal(out, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		data, ok := list.Items[i].Data[helmReleaseDataKey]
+		if !ok {
+			continue
+		}
+		// a release which cannot be decoded, e.g. written by an unsupported version of Helm, does not prevent the
+		// discovery of the others.
+		if base64Data {
+			decoded, decodeErr := base64.StdEncoding.DecodeString(data)
+			if decodeErr != nil {
+				log.Warnf("skipping the Helm release stored in %s: %v", list.Items[i].Metadata.Name, decodeErr)
+				continue
+			}
+			data = string(decoded)
+		}
+		release, decodeErr := decodeHelmRelease(data)
+		if decodeErr != nil {
+			log.Warnf("skipping the Helm release stored in %s: %v", list.Items[i].Metadata.Name, decodeErr)
+			continue
+		}
+		metadata := &release.Chart.Metadata
+		releases = append(releases, configsections.HelmRelease{
+			Namespace:    release.Namespace,
+			Name:         release.Name,
+			Revision:     release.Version,
+			Status:       release.Info.Status,
+			Chart:        metadata.Name,
+			ChartVersion: metadata.Version,
+			AppVersion:   metadata.AppVersion,
+			Home:         metadata.Home,
+			Sources:      metadata.Sources,
+			// the values may hold secrets, only their keys are recorded.
+			OverriddenKeys: configsections.GetValueKeys(release.Config),
+		})
+	}
+	return releases, nil
+}
+
+// decodeHelmRelease decodes a release the way Helm encodes it: JSON, gzipped then base64 encoded.
+func decodeHelmRelease(data string) (*helmRelease, error) {
+	contents, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(contents, gzipMagic) {
+		if contents, err = gunzip(contents); err != nil {
+			return nil, err
+		}
+	}
+	release := &helmRelease{}
+	if err = jsonUnmarshal(contents, release); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// gunzip returns the decompressed contents.
+func gunzip(contents []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(contents))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// latestHelmRevisions returns the latest revision of each release, sorted by name.
+func latestHelmRevisions(revisions []configsections.HelmRelease) []configsections.HelmRelease {
+	latest := map[string]configsections.HelmRelease{}
+	for i := range revisions {
+		name := revisions[i].FullName()
+		if current, ok := latest[name]; !ok || revisions[i].Revision > current.Revision {
+			latest[name] = revisions[i]
+		}
+	}
+	releases := make([]configsections.HelmRelease, 0, len(latest))
+	for name := range latest {
+		releases = append(releases, latest[name])
+	}
+	sort.Slice(releases, func(i, j int) bool { return releases[i].FullName() < releases[j].FullName() })
+	return releases
+}
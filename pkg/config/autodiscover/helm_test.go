@@ -0,0 +1,60 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package autodiscover
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	testHelmSecretsFile    = "helmsecrets.json"
+	testHelmConfigMapsFile = "helmconfigmaps.json"
+)
+
+func TestParseHelmReleases(t *testing.T) {
+	contents, err := os.ReadFile(path.Join(filePath, testHelmSecretsFile))
+	assert.Nil(t, err)
+	revisions, err := parseHelmReleases(contents, true)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+
+	contents, err = os.ReadFile(path.Join(filePath, testHelmConfigMapsFile))
+	assert.Nil(t, err)
+	configMapRevisions, err := parseHelmReleases(contents, false)
+	assert.Nil(t, err)
+	revisions = append(revisions, configMapRevisions...)
+
+	assert.Equal(t, []configsections.HelmRelease{
+		{Namespace: "tnf", Name: "cnf", Revision: 2, Status: "deployed", Chart: "cnf", ChartVersion: "0.2.0",
+			AppVersion: "1.2.0", Sources: []string{"https://github.com/example/cnf"},
+			OverriddenKeys: []string{"image.tag", "replicaCount"}},
+		{Namespace: "tnf", Name: "database", Revision: 1, Status: "deployed", Chart: "cnf", ChartVersion: "0.1.0",
+			AppVersion: "1.1.0"},
+	}, latestHelmRevisions(revisions))
+
+	// the releases which cannot be decoded are skipped.
+	revisions, err = parseHelmReleases([]byte(`{"items": [{"metadata": {"name": "sh.helm.release.v1.cnf.v3"}, `+
+		`"data": {"release": "not base64"}}]}`), false)
+	assert.Nil(t, err)
+	assert.Empty(t, revisions)
+	_, err = parseHelmReleases([]byte(`{"items": [`), false)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	cniNetworksKey                           = "k8s.v1.cni.cncf.io/networks"
+	ocGetNetworkAttachmentDefinitionsCommand = "oc get network-attachment-definitions -n %s -o json"
+)
+
+// networkSelection is an entry of the JSON form of the "k8s.v1.cni.cncf.io/networks" annotation.
+type networkSelection struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Interface string `json:"interface"`
+}
+
+// networkAttachmentDefinitionList holds the data from an `oc get network-attachment-definitions -o json` command.
+type networkAttachmentDefinitionList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			// Config is the JSON encoded CNI configuration of the network.
+			Config string `json:"config"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// cniConfig is the part of a CNI configuration, or of a CNI configuration list, giving the type of the plugin.
+type cniConfig struct {
+	Type    string `json:"type"`
+	Plugins []struct {
+		Type string `json:"type"`
+	} `json:"plugins"`
+}
+
+// qualifyNetworkName returns a network name as "namespace/name", the networks without a namespace being in the
+// namespace of the pod.
+func (pr *PodResource) qualifyNetworkName(name string) string {
+	if strings.Contains(name, "/") {
+		return name
+	}
+	return pr.Metadata.Namespace + "/" + name
+}
+
+// getRequestedNetworks returns the networks requested by the "k8s.v1.cni.cncf.io/networks" annotation, as
+// "namespace/name".  The annotation is either a comma separated list of "[namespace/]name[@interface]", or a JSON
+// list of network selections.
+func (pr *PodResource) getRequestedNetworks() (networks []string, err error) {
+	val, present := pr.Metadata.Annotations[cniNetworksKey]
+	if !present || strings.TrimSpace(val) == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(strings.TrimSpace(val), "[") {
+		var selections []networkSelection
+		if err = jsonUnmarshal([]byte(val), &selections); err != nil {
+			return nil, pr.annotationUnmarshalError(cniNetworksKey, err)
+		}
+		for _, selection := range selections {
+			name := selection.Name
+			if selection.Namespace != "" {
+				name = selection.Namespace + "/" + name
+			}
+			networks = append(networks, pr.qualifyNetworkName(name))
+		}
+		return networks, nil
+	}
+	for _, network := range strings.Split(val, ",") {
+		name := strings.SplitN(strings.TrimSpace(network), "@", 2)[0]
+		if name != "" {
+			networks = append(networks, pr.qualifyNetworkName(name))
+		}
+	}
+	return networks, nil
+}
+
+// getNetworkAttachments returns the secondary network interfaces of a pod from the
+// "k8s.v1.cni.cncf.io/networks-status" annotation.  A warning is logged for the requested networks the pod is not
+// attached to.
+func (pr *PodResource) getNetworkAttachments() (attachments []configsections.NetworkAttachment, err error) {
+	requested, err := pr.getRequestedNetworks()
+	if err != nil {
+		return nil, err
+	}
+	if val, present := pr.Metadata.Annotations[cniNetworksStatusKey]; present {
+		var cniInfo []cniNetworkInterface
+		if err = jsonUnmarshal([]byte(val), &cniInfo); err != nil {
+			return nil, pr.annotationUnmarshalError(cniNetworksStatusKey, err)
+		}
+		for _, cniInterface := range cniInfo {
+			if !cniInterface.Default {
+				attachments = append(attachments, configsections.NetworkAttachment{
+					Network:     pr.qualifyNetworkName(cniInterface.Name),
+					Interface:   cniInterface.Interface,
+					IPAddresses: cniInterface.IPs,
+				})
+			}
+		}
+	}
+	for _, network := range requested {
+		if !hasNetworkAttachment(attachments, network) {
+			log.Warnf("pod %s/%s requests the network %s but is not attached to it", pr.Metadata.Namespace,
+				pr.Metadata.Name, network)
+		}
+	}
+	return attachments, nil
+}
+
+func hasNetworkAttachment(attachments []configsections.NetworkAttachment, network string) bool {
+	for i := range attachments {
+		if attachments[i].Network == network {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNetworkAttachmentDefinitions returns the Multus networks defined in a namespace.
+func GetNetworkAttachmentDefinitions(namespace string) ([]configsections.NetworkAttachmentDefinition, error) {
+	command := fmt.Sprintf(ocGetNetworkAttachmentDefinitionsCommand, namespace)
+	out, err := executeCommand(command, func() {
+		log.Error("can't run command: ", command)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseNetworkAttachmentDefinitions([]byte(out))
+}
+
+// parseNetworkAttachmentDefinitions parses the output of an `oc get network-attachment-definitions -o json` command.
+func parseNetworkAttachmentDefinitions(out []byte) (definitions []configsections.NetworkAttachmentDefinition, err error) {
+	var list networkAttachmentDefinitionList
+	if err = jsonUnmarshal(out, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		definition := configsections.NetworkAttachmentDefinition{Namespace: item.Metadata.Namespace, Name: item.Metadata.Name}
+		var config cniConfig
+		if item.Spec.Config == "" {
+			// the configuration is then in a file on the nodes, named after the network.
+			log.Debugf("network %s has no inline CNI configuration", definition.FullName())
+		} else if err := jsonUnmarshal([]byte(item.Spec.Config), &config); err != nil {
+			log.Warnf("cannot parse the CNI configuration of network %s: %s", definition.FullName(), err)
+		} else {
+			definition.Type = config.Type
+			if definition.Type == "" && len(config.Plugins) > 0 {
+				definition.Type = config.Plugins[0].Type
+			}
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
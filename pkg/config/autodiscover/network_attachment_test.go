@@ -0,0 +1,89 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	testMultusFile                       = "testmultus.json"
+	testNetworkAttachmentDefinitionsFile = "networkattachmentdefinitions.json"
+)
+
+func TestGetRequestedNetworks(t *testing.T) {
+	testCases := []struct {
+		annotation string
+		expected   []string
+	}{
+		{annotation: "", expected: nil},
+		{annotation: "net1", expected: []string{"tnf/net1"}},
+		{annotation: "net1, other/net2@eth1", expected: []string{"tnf/net1", "other/net2"}},
+		{annotation: `[{"name": "net1", "interface": "eth1"}, {"name": "net2", "namespace": "other"}]`,
+			expected: []string{"tnf/net1", "other/net2"}},
+	}
+	for _, tc := range testCases {
+		pod := PodResource{}
+		pod.Metadata.Namespace = "tnf"
+		pod.Metadata.Annotations = map[string]string{cniNetworksKey: tc.annotation}
+		networks, err := pod.getRequestedNetworks()
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expected, networks)
+	}
+
+	pod := PodResource{}
+	pod.Metadata.Annotations = map[string]string{cniNetworksKey: "[not json"}
+	_, err := pod.getRequestedNetworks()
+	assert.NotNil(t, err)
+}
+
+func TestGetNetworkAttachments(t *testing.T) {
+	pod := loadPodResource(path.Join(filePath, testMultusFile))
+	attachments, err := pod.getNetworkAttachments()
+	assert.Nil(t, err)
+	assert.Equal(t, []configsections.NetworkAttachment{
+		{Network: "tnf/macvlan-net", Interface: "net1", IPAddresses: []string{"192.168.10.2", "fd00:10::2"}},
+		{Network: "other/sriov-net", Interface: "net2", IPAddresses: []string{"192.168.20.2"}},
+	}, attachments)
+
+	// the pods without Multus networks have no attachment.
+	pod = loadPodResource(testSubjectFilePath)
+	attachments, err = pod.getNetworkAttachments()
+	assert.Nil(t, err)
+	assert.Empty(t, attachments)
+}
+
+func TestParseNetworkAttachmentDefinitions(t *testing.T) {
+	contents, err := os.ReadFile(path.Join(filePath, testNetworkAttachmentDefinitionsFile))
+	assert.Nil(t, err)
+	definitions, err := parseNetworkAttachmentDefinitions(contents)
+	assert.Nil(t, err)
+	assert.Equal(t, []configsections.NetworkAttachmentDefinition{
+		{Namespace: "tnf", Name: "macvlan-net", Type: "macvlan"},
+		{Namespace: "tnf", Name: "bridge-net", Type: "bridge"},
+		{Namespace: "tnf", Name: "file-net"},
+	}, definitions)
+	assert.Equal(t, "tnf/macvlan-net", definitions[0].FullName())
+
+	_, err = parseNetworkAttachmentDefinitions([]byte("error: the server doesn't have a resource type"))
+	assert.NotNil(t, err)
+}
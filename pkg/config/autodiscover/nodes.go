@@ -0,0 +1,120 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package autodiscover
+
+import (
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	ocGetNodesCommand = "oc get nodes -o json"
+	// nodeInternalIPType is the type of the internal IP addresses of the nodes.
+	nodeInternalIPType = "InternalIP"
+)
+
+// nodeList holds the data from an `oc get nodes -o json` command.
+type nodeList struct {
+	Items []struct {
+		Metadata struct {
+			Name   string            `json:"name"`
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Spec struct {
+			Taints []configsections.Taint `json:"taints"`
+		} `json:"spec"`
+		Status struct {
+			Addresses []struct {
+				Type    string `json:"type"`
+				Address string `json:"address"`
+			} `json:"addresses"`
+			Allocatable map[string]string `json:"allocatable"`
+			NodeInfo    struct {
+				Architecture            string `json:"architecture"`
+				KernelVersion           string `json:"kernelVersion"`
+				OSImage                 string `json:"osImage"`
+				ContainerRuntimeVersion string `json:"containerRuntimeVersion"`
+			} `json:"nodeInfo"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// GetNodesList returns the nodes of the cluster having a role, e.g. master, worker or infra, with their role labels and
+// their inventory.  The nodes of the discovery snapshot are returned once it is captured or loaded.
+func GetNodesList() (nodes map[string]configsections.Node) {
+	if snapshotNodes, ok := getSnapshotNodes(); ok {
+		return snapshotNodes
+	}
+	out, err := executeCommand(ocGetNodesCommand, func() {
+		log.Error("can't run command: ", ocGetNodesCommand)
+	})
+	if err == nil {
+		nodes, err = parseNodes([]byte(out))
+	}
+	if err != nil {
+		log.Error("Unable to get node list ", ". Error: ", err)
+		return make(map[string]configsections.Node)
+	}
+	return nodes
+}
+
+// parseNodes parses the output of an `oc get nodes -o json` command.  The nodes without any
+// node-role.kubernetes.io/<role> label are left out.
+func parseNodes(out []byte) (map[string]configsections.Node, error) {
+	var list nodeList
+	if err := jsonUnmarshal(out, &list); err != nil {
+		return nil, err
+	}
+	nodes := make(map[string]configsections.Node)
+	for i := range list.Items {
+		item := &list.Items[i]
+		var labels []string
+		for label := range item.Metadata.Labels {
+			if strings.HasPrefix(label, configsections.NodeRoleLabelPrefix) {
+				labels = append(labels, label)
+			}
+		}
+		if len(labels) == 0 {
+			continue
+		}
+		sort.Strings(labels)
+		sort.Slice(item.Spec.Taints, func(a, b int) bool {
+			return item.Spec.Taints[a].Key < item.Spec.Taints[b].Key
+		})
+		var internalIP string
+		for _, address := range item.Status.Addresses {
+			if address.Type == nodeInternalIPType {
+				internalIP = address.Address
+				break
+			}
+		}
+		nodes[item.Metadata.Name] = configsections.Node{
+			Name:                    item.Metadata.Name,
+			Labels:                  labels,
+			Architecture:            item.Status.NodeInfo.Architecture,
+			KernelVersion:           item.Status.NodeInfo.KernelVersion,
+			OSImage:                 item.Status.NodeInfo.OSImage,
+			ContainerRuntimeVersion: item.Status.NodeInfo.ContainerRuntimeVersion,
+			InternalIP:              internalIP,
+			Allocatable:             item.Status.Allocatable,
+			Taints:                  item.Spec.Taints,
+		}
+	}
+	return nodes, nil
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package autodiscover
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	testNodesFile = "nodes.json"
+)
+
+func TestParseNodes(t *testing.T) {
+	contents, err := os.ReadFile(path.Join(filePath, testNodesFile))
+	assert.Nil(t, err)
+	nodes, err := parseNodes(contents)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]configsections.Node{
+		"master-0": {
+			Name:                    "master-0",
+			Labels:                  []string{configsections.MasterLabel},
+			Architecture:            "amd64",
+			KernelVersion:           "4.18.0-305.10.2.el8_4.x86_64",
+			OSImage:                 "Red Hat Enterprise Linux CoreOS 48.84.202108161759-0 (Ootpa)",
+			ContainerRuntimeVersion: "cri-o://1.21.2-5.rhaos4.8.gitb27d974.el8",
+			InternalIP:              "10.0.0.10",
+			Allocatable:             map[string]string{"cpu": "7500m", "hugepages-1Gi": "0", "memory": "15257052Ki", "pods": "250"},
+			Taints:                  []configsections.Taint{{Key: configsections.MasterLabel, Effect: "NoSchedule"}},
+		},
+		"worker-0": {
+			Name:                    "worker-0",
+			Labels:                  []string{configsections.MasterLabel, configsections.WorkerLabel},
+			Architecture:            "arm64",
+			KernelVersion:           "4.18.0-305.10.2.el8_4.aarch64",
+			OSImage:                 "Red Hat Enterprise Linux CoreOS 48.84.202108161759-0 (Ootpa)",
+			ContainerRuntimeVersion: "cri-o://1.21.2-5.rhaos4.8.gitb27d974.el8",
+			Allocatable:             map[string]string{"cpu": "15500m", "hugepages-1Gi": "4Gi", "memory": "27525304Ki", "pods": "250"},
+		},
+		"infra-0": {
+			Name:   "infra-0",
+			Labels: []string{configsections.NodeRoleLabelPrefix + "infra"},
+		},
+	}, nodes)
+
+	_, err = parseNodes([]byte(`{"items": [{"spec": {"taints": "none"}}]}`))
+	assert.NotNil(t, err)
+}
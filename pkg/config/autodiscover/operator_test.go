@@ -29,4 +29,7 @@ func TestBuildOperatorFromCSVResource(t *testing.T) {
 	assert.Equal(t, "CSVNamespace", operator.Namespace)
 	assert.Equal(t, "CSVName", operator.Name)
 	assert.Equal(t, []string{"OPERATOR_STATUS", "ANOTHER_TEST"}, operator.Tests)
+	assert.Equal(t, "nginx-operator", operator.Package)
+	assert.Equal(t, "0.0.1", operator.Version)
+	assert.Equal(t, []string{"OwnNamespace", "SingleNamespace"}, operator.InstallModes)
 }
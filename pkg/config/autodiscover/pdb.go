@@ -0,0 +1,73 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package autodiscover
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	ocGetPodDisruptionBudgetsCommand = "oc get poddisruptionbudgets -n %s -o json"
+)
+
+// podDisruptionBudgetList holds the data from an `oc get poddisruptionbudgets -o json` command.
+type podDisruptionBudgetList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			Selector       *configsections.LabelSelector `json:"selector"`
+			MinAvailable   intOrString                   `json:"minAvailable"`
+			MaxUnavailable intOrString                   `json:"maxUnavailable"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// GetPodDisruptionBudgets returns the PodDisruptionBudgets defined in a namespace.
+func GetPodDisruptionBudgets(namespace string) ([]configsections.PodDisruptionBudget, error) {
+	command := fmt.Sprintf(ocGetPodDisruptionBudgetsCommand, namespace)
+	out, err := executeCommand(command, func() {
+		log.Error("can't run command: ", command)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parsePodDisruptionBudgets([]byte(out))
+}
+
+// parsePodDisruptionBudgets parses the output of an `oc get poddisruptionbudgets -o json` command.
+func parsePodDisruptionBudgets(out []byte) (budgets []configsections.PodDisruptionBudget, err error) {
+	var list podDisruptionBudgetList
+	if err = jsonUnmarshal(out, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		budgets = append(budgets, configsections.PodDisruptionBudget{
+			Namespace:      item.Metadata.Namespace,
+			Name:           item.Metadata.Name,
+			Selector:       item.Spec.Selector,
+			MinAvailable:   string(item.Spec.MinAvailable),
+			MaxUnavailable: string(item.Spec.MaxUnavailable),
+		})
+	}
+	return budgets, nil
+}
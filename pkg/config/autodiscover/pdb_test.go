@@ -0,0 +1,47 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package autodiscover
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	testPodDisruptionBudgetsFile = "poddisruptionbudgets.json"
+)
+
+func TestParsePodDisruptionBudgets(t *testing.T) {
+	contents, err := os.ReadFile(path.Join(filePath, testPodDisruptionBudgetsFile))
+	assert.Nil(t, err)
+	budgets, err := parsePodDisruptionBudgets(contents)
+	assert.Nil(t, err)
+	assert.Equal(t, []configsections.PodDisruptionBudget{
+		{Namespace: "tnf", Name: "test", MinAvailable: "1",
+			Selector: &configsections.LabelSelector{MatchLabels: map[string]string{"app": "test"}}},
+		{Namespace: "tnf", Name: "database", MaxUnavailable: "25%",
+			Selector: &configsections.LabelSelector{MatchExpressions: []configsections.LabelSelectorRequirement{
+				{Key: "tier", Operator: "In", Values: []string{"database"}},
+			}}},
+	}, budgets)
+
+	_, err = parsePodDisruptionBudgets([]byte(`{"items": [{"spec": {"minAvailable": true}}]}`))
+	assert.NotNil(t, err)
+}
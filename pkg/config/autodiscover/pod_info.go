@@ -53,7 +53,8 @@ type PodResource struct {
 	Spec struct {
 		ServiceAccount string `json:"serviceaccountname"`
 		Containers     []struct {
-			Name string `json:"name"`
+			Name  string                         `json:"name"`
+			Ports []configsections.ContainerPort `json:"ports"`
 		} `json:"containers"`
 		NodeName string `json:"nodeName"`
 	} `json:"spec"`
@@ -148,6 +149,17 @@ func (pr *PodResource) getPodIPs() (ips []string, err error) {
 	return
 }
 
+// getPodStatusIPs gets the cluster network IPs of a pod from `pod.status.podIPs`.  Dual-stack pods have one IP per
+// address family.
+func (pr *PodResource) getPodStatusIPs() (ips []string) {
+	for _, podIP := range pr.Status.PodIPs {
+		if ip, ok := podIP["ip"]; ok {
+			ips = append(ips, ip)
+		}
+	}
+	return
+}
+
 func (pr *PodResource) annotationUnmarshalError(annotationKey string, err error) error {
 	return fmt.Errorf("error (%s) attempting to unmarshal value of annotation '%s' on pod '%s/%s'",
 		err, annotationKey, pr.Metadata.Namespace, pr.Metadata.Name)
@@ -156,13 +168,16 @@ func (pr *PodResource) annotationUnmarshalError(annotationKey string, err error)
 // GetPodsByLabel will return all pods with a given label value. If `labelValue` is an empty string, all pods with that
 // label will be returned, regardless of the labels value.
 func GetPodsByLabel(label configsections.Label, namespace string) (*PodList, error) {
-	out := executeOcGetCommand(resourceTypePods, buildLabelQuery(label), namespace)
+	out, err := executeOcGetCommand(resourceTypePods, buildLabelQuery(label), namespace)
+	if err != nil {
+		return nil, err
+	}
 
 	log.Debug("JSON output for all pods labeled with: ", label)
 	log.Debug("Command: ", out)
 
 	var podList PodList
-	err := jsonUnmarshal([]byte(out), &podList)
+	err = jsonUnmarshal([]byte(out), &podList)
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 )
 
 func TestBuildPodUnderTest(t *testing.T) {
@@ -34,8 +35,31 @@ func TestBuildPodUnderTest(t *testing.T) {
 	assert.NotEqual(t, "I'mAContainer", orchestratorPod.Name)
 	// no tests set on pod and the config file will not be loaded from the unit test context: no tests should be set.
 	assert.Equal(t, []string{}, orchestratorPod.Tests)
+	assert.Nil(t, orchestratorPod.HostNamespaceExemptions)
+	assert.False(t, orchestratorPod.APIAccess)
+	assert.False(t, orchestratorPod.LatencySensitive)
+	assert.False(t, orchestratorPod.SIGTERMHandler)
+	assert.Nil(t, orchestratorPod.ContainerTestSkips)
 
 	assert.Equal(t, "tnf", subjectPod.Namespace)
 	assert.Equal(t, "test", subjectPod.Name)
 	assert.Equal(t, []string{"OneTestName", "AnotherTestName"}, subjectPod.Tests)
+	assert.Equal(t, "test", subjectPod.Labels["app"])
+	assert.Equal(t, []string{"test"}, subjectPod.ContainerNames)
+	assert.Equal(t, "test", subjectPod.GetContainerName(0))
+	assert.Equal(t, "", subjectPod.GetContainerName(1))
+	assert.Equal(t, []configsections.ContainerPort{
+		{Name: "http", ContainerPort: 8080, Protocol: "TCP"},
+		{Name: "metrics", ContainerPort: 9100, Protocol: "TCP", HostPort: 9100},
+	}, subjectPod.Ports)
+	assert.Equal(t, []string{"hostNetwork"}, subjectPod.HostNamespaceExemptions)
+	assert.True(t, subjectPod.IsHostNamespaceExempted("hostNetwork"))
+	assert.False(t, subjectPod.IsHostNamespaceExempted("hostPID"))
+	assert.True(t, subjectPod.APIAccess)
+	assert.True(t, subjectPod.LatencySensitive)
+	assert.True(t, subjectPod.SIGTERMHandler)
+	assert.Equal(t, map[string][]string{"istio-proxy": {"lifecycle-*-probe"}}, subjectPod.ContainerTestSkips)
+	assert.True(t, subjectPod.SkipsContainerTest("lifecycle-liveness-probe", "istio-proxy"))
+	assert.False(t, subjectPod.SkipsContainerTest("lifecycle-container-shutdown", "istio-proxy"))
+	assert.False(t, subjectPod.SkipsContainerTest("lifecycle-liveness-probe", "test"))
 }
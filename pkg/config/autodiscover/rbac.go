@@ -0,0 +1,161 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	ocGetRoleBindingsCommand        = "oc get rolebindings --all-namespaces -o json"
+	ocGetClusterRoleBindingsCommand = "oc get clusterrolebindings -o json"
+	ocGetRolesCommand               = "oc get roles --all-namespaces -o json"
+	ocGetClusterRolesCommand        = "oc get clusterroles -o json"
+)
+
+// roleBindingList holds the data from an `oc get rolebindings -o json` or `oc get clusterrolebindings -o json`
+// command.
+type roleBindingList struct {
+	Items []struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		RoleRef  configsections.RoleRef       `json:"roleRef"`
+		Subjects []configsections.RBACSubject `json:"subjects"`
+	} `json:"items"`
+}
+
+// roleList holds the data from an `oc get roles -o json` or `oc get clusterroles -o json` command.
+type roleList struct {
+	Items []struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Rules []configsections.PolicyRule `json:"rules"`
+	} `json:"items"`
+}
+
+// getJSON runs an `oc get -o json` command.
+func getJSON(command string) ([]byte, error) {
+	out, err := executeCommand(command, func() {
+		log.Error("can't run command: ", command)
+	})
+	return []byte(out), err
+}
+
+// GetRBAC returns the RoleBindings and ClusterRoleBindings granting a role to the service accounts of the pods, and
+// the Roles and ClusterRoles they grant.
+func GetRBAC(pods []configsections.Pod) ([]configsections.RoleBinding, []configsections.Role, error) {
+	var bindings []configsections.RoleBinding
+	for _, command := range []string{ocGetRoleBindingsCommand, ocGetClusterRoleBindingsCommand} {
+		out, err := getJSON(command)
+		if err != nil {
+			return nil, nil, err
+		}
+		parsed, err := parseRoleBindings(out)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", command, err)
+		}
+		bindings = append(bindings, filterRoleBindings(parsed, pods)...)
+	}
+	var roles []configsections.Role
+	for _, command := range []string{ocGetRolesCommand, ocGetClusterRolesCommand} {
+		out, err := getJSON(command)
+		if err != nil {
+			return nil, nil, err
+		}
+		parsed, err := parseRoles(out)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", command, err)
+		}
+		roles = append(roles, filterRoles(parsed, bindings)...)
+	}
+	return bindings, roles, nil
+}
+
+// parseRoleBindings parses the output of an `oc get rolebindings -o json` or `oc get clusterrolebindings -o json`
+// command.
+func parseRoleBindings(out []byte) (bindings []configsections.RoleBinding, err error) {
+	var list roleBindingList
+	if err = jsonUnmarshal(out, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		bindings = append(bindings, configsections.RoleBinding{
+			Kind:      item.Kind,
+			Namespace: item.Metadata.Namespace,
+			Name:      item.Metadata.Name,
+			RoleRef:   item.RoleRef,
+			Subjects:  item.Subjects,
+		})
+	}
+	return bindings, nil
+}
+
+// parseRoles parses the output of an `oc get roles -o json` or `oc get clusterroles -o json` command.
+func parseRoles(out []byte) (roles []configsections.Role, err error) {
+	var list roleList
+	if err = jsonUnmarshal(out, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		roles = append(roles, configsections.Role{
+			Kind:      item.Kind,
+			Namespace: item.Metadata.Namespace,
+			Name:      item.Metadata.Name,
+			Rules:     item.Rules,
+		})
+	}
+	return roles, nil
+}
+
+// filterRoleBindings returns the bindings granting a role to the service account of one of the pods.
+func filterRoleBindings(bindings []configsections.RoleBinding, pods []configsections.Pod) []configsections.RoleBinding {
+	var filtered []configsections.RoleBinding
+	for i := range bindings {
+		for j := range pods {
+			if bindings[i].BindsServiceAccount(pods[j].Namespace, pods[j].ServiceAccount) {
+				filtered = append(filtered, bindings[i])
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// filterRoles returns the roles granted by one of the bindings.
+func filterRoles(roles []configsections.Role, bindings []configsections.RoleBinding) []configsections.Role {
+	var filtered []configsections.Role
+	for i := range roles {
+		for j := range bindings {
+			if bindings[j].FindRole(roles[i:i+1]) != nil {
+				filtered = append(filtered, roles[i])
+				break
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	testRoleBindingsFile = "rolebindings.json"
+	testRolesFile        = "roles.json"
+)
+
+var testReaderRoleBinding = configsections.RoleBinding{
+	Kind: configsections.KindRoleBinding, Namespace: "tnf", Name: "test-reader",
+	RoleRef:  configsections.RoleRef{Kind: configsections.KindRole, Name: "reader"},
+	Subjects: []configsections.RBACSubject{{Kind: configsections.KindServiceAccount, Name: "test"}},
+}
+
+var testAdminClusterRoleBinding = configsections.RoleBinding{
+	Kind: configsections.KindClusterRoleBinding, Name: "test-admin",
+	RoleRef: configsections.RoleRef{Kind: configsections.KindClusterRole, Name: configsections.ClusterAdminRole},
+	Subjects: []configsections.RBACSubject{
+		{Kind: "Group", Name: "system:masters"},
+		{Kind: configsections.KindServiceAccount, Namespace: "tnf", Name: "test"},
+	},
+}
+
+func TestParseRoleBindings(t *testing.T) {
+	contents, err := os.ReadFile(path.Join(filePath, testRoleBindingsFile))
+	assert.Nil(t, err)
+	bindings, err := parseRoleBindings(contents)
+	assert.Nil(t, err)
+	assert.Len(t, bindings, 3)
+	assert.Equal(t, testReaderRoleBinding, bindings[0])
+	assert.Equal(t, testAdminClusterRoleBinding, bindings[2])
+
+	// the service accounts of the "other" namespace are not under test.
+	pods := []configsections.Pod{{Namespace: "tnf", Name: "test-0", ServiceAccount: "test"}}
+	assert.Equal(t, []configsections.RoleBinding{testReaderRoleBinding, testAdminClusterRoleBinding},
+		filterRoleBindings(bindings, pods))
+	assert.Nil(t, filterRoleBindings(bindings, []configsections.Pod{{Namespace: "tnf", Name: "test-1"}}))
+}
+
+func TestParseRoles(t *testing.T) {
+	contents, err := os.ReadFile(path.Join(filePath, testRolesFile))
+	assert.Nil(t, err)
+	roles, err := parseRoles(contents)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 3)
+	assert.Equal(t, configsections.Role{Kind: configsections.KindRole, Namespace: "tnf", Name: "reader",
+		Rules: []configsections.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"pods", "services"}, Verbs: []string{"get", "list"}},
+		}}, roles[0])
+
+	filtered := filterRoles(roles, []configsections.RoleBinding{testReaderRoleBinding, testAdminClusterRoleBinding})
+	assert.Equal(t, []configsections.Role{roles[0], roles[1]}, filtered)
+	assert.True(t, filtered[1].HasWildcardVerb())
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package autodiscover
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	ocGetServicesCommand        = "oc get services -n %s -o json"
+	ocGetNetworkPoliciesCommand = "oc get networkpolicies -n %s -o json"
+)
+
+// intOrString is a port given by number or by name, kept as a string.
+type intOrString string
+
+// UnmarshalJSON decodes a port number or name.
+func (p *intOrString) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := json.Unmarshal(data, &number); err == nil {
+		*p = intOrString(strconv.Itoa(number))
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("invalid port %s: %w", data, err)
+	}
+	*p = intOrString(name)
+	return nil
+}
+
+// serviceList holds the data from an `oc get services -o json` command.
+type serviceList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			Type     string            `json:"type"`
+			Selector map[string]string `json:"selector"`
+			Ports    []struct {
+				Name       string      `json:"name"`
+				Protocol   string      `json:"protocol"`
+				Port       int         `json:"port"`
+				TargetPort intOrString `json:"targetPort"`
+				NodePort   int         `json:"nodePort"`
+			} `json:"ports"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// networkPolicyList holds the data from an `oc get networkpolicies -o json` command.
+type networkPolicyList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			PodSelector configsections.LabelSelector `json:"podSelector"`
+			PolicyTypes []string                     `json:"policyTypes"`
+			Ingress     []struct {
+				Ports []struct {
+					Protocol string      `json:"protocol"`
+					Port     intOrString `json:"port"`
+					EndPort  int         `json:"endPort"`
+				} `json:"ports"`
+			} `json:"ingress"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// GetServices returns the Services defined in a namespace.
+func GetServices(namespace string) ([]configsections.Service, error) {
+	command := fmt.Sprintf(ocGetServicesCommand, namespace)
+	out, err := executeCommand(command, func() {
+		log.Error("can't run command: ", command)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseServices([]byte(out))
+}
+
+// parseServices parses the output of an `oc get services -o json` command.
+func parseServices(out []byte) (services []configsections.Service, err error) {
+	var list serviceList
+	if err = jsonUnmarshal(out, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		service := configsections.Service{
+			Namespace: item.Metadata.Namespace,
+			Name:      item.Metadata.Name,
+			Type:      item.Spec.Type,
+			Selector:  item.Spec.Selector,
+		}
+		for _, port := range item.Spec.Ports {
+			service.Ports = append(service.Ports, configsections.ServicePort{
+				Name:       port.Name,
+				Protocol:   port.Protocol,
+				Port:       port.Port,
+				TargetPort: string(port.TargetPort),
+				NodePort:   port.NodePort,
+			})
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+// GetNetworkPolicies returns the NetworkPolicies defined in a namespace.
+func GetNetworkPolicies(namespace string) ([]configsections.NetworkPolicy, error) {
+	command := fmt.Sprintf(ocGetNetworkPoliciesCommand, namespace)
+	out, err := executeCommand(command, func() {
+		log.Error("can't run command: ", command)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseNetworkPolicies([]byte(out))
+}
+
+// parseNetworkPolicies parses the output of an `oc get networkpolicies -o json` command.  Only the ports of the ingress
+// rules are kept, the peers they allow are not.
+func parseNetworkPolicies(out []byte) (policies []configsections.NetworkPolicy, err error) {
+	var list networkPolicyList
+	if err = jsonUnmarshal(out, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		policy := configsections.NetworkPolicy{
+			Namespace:   item.Metadata.Namespace,
+			Name:        item.Metadata.Name,
+			PodSelector: item.Spec.PodSelector,
+			PolicyTypes: item.Spec.PolicyTypes,
+		}
+		for _, ingress := range item.Spec.Ingress {
+			rule := configsections.NetworkPolicyRule{}
+			for _, port := range ingress.Ports {
+				rule.Ports = append(rule.Ports, configsections.NetworkPolicyPort{
+					Protocol: port.Protocol,
+					Port:     string(port.Port),
+					EndPort:  port.EndPort,
+				})
+			}
+			policy.Ingress = append(policy.Ingress, rule)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
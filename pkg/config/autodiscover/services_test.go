@@ -0,0 +1,69 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	testServicesFile        = "services.json"
+	testNetworkPoliciesFile = "networkpolicies.json"
+)
+
+func TestParseServices(t *testing.T) {
+	contents, err := os.ReadFile(path.Join(filePath, testServicesFile))
+	assert.Nil(t, err)
+	services, err := parseServices(contents)
+	assert.Nil(t, err)
+	assert.Equal(t, []configsections.Service{
+		{Namespace: "tnf", Name: "test", Type: "NodePort", Selector: map[string]string{"app": "test"},
+			Ports: []configsections.ServicePort{
+				{Name: "http", Protocol: "TCP", Port: 80, TargetPort: "http"},
+				{Name: "metrics", Protocol: "TCP", Port: 9090, TargetPort: "9090", NodePort: 30090},
+			}},
+		{Namespace: "tnf", Name: "external", Type: "ClusterIP",
+			Ports: []configsections.ServicePort{{Protocol: "TCP", Port: 5432}}},
+	}, services)
+
+	_, err = parseServices([]byte(`{"items": [{"spec": {"ports": [{"targetPort": true}]}}]}`))
+	assert.NotNil(t, err)
+}
+
+func TestParseNetworkPolicies(t *testing.T) {
+	contents, err := os.ReadFile(path.Join(filePath, testNetworkPoliciesFile))
+	assert.Nil(t, err)
+	policies, err := parseNetworkPolicies(contents)
+	assert.Nil(t, err)
+	assert.Equal(t, []configsections.NetworkPolicy{
+		{Namespace: "tnf", Name: "allow-http",
+			PodSelector: configsections.LabelSelector{MatchExpressions: []configsections.LabelSelectorRequirement{
+				{Key: "app", Operator: "In", Values: []string{"test"}},
+			}},
+			PolicyTypes: []string{"Ingress"},
+			Ingress: []configsections.NetworkPolicyRule{{Ports: []configsections.NetworkPolicyPort{
+				{Protocol: "TCP", Port: "http"},
+				{Port: "9000", EndPort: 9100},
+			}}}},
+		{Namespace: "tnf", Name: "deny-all"},
+	}, policies)
+}
@@ -0,0 +1,210 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+)
+
+const (
+	// snapshotFilePermissions are the permissions of the saved snapshots.
+	snapshotFilePermissions = 0644
+	// workloadsFingerprintCommand lists the identity of the pods, deployments and statefulsets of a namespace, which
+	// changes when they are re-created, re-scheduled or scaled.
+	workloadsFingerprintCommand = `oc get pods,deployments,statefulsets -n %s -o ` +
+		`jsonpath='{range .items[*]}{.metadata.uid} {.metadata.generation} {.status.podIP} {.status.phase}{"\n"}{end}'`
+)
+
+// ErrNoSnapshot is returned when saving the snapshot before the autodiscovery ran.
+var ErrNoSnapshot = errors.New("no discovery snapshot was captured")
+
+// Snapshot is the result of the autodiscovery, captured once at the start of a run:  the versions and flavor of the
+// cluster, its nodes, and the resources under test and the partner containers of the namespace under test.  A saved
+// snapshot can be loaded instead of querying the cluster, e.g. to re-process a run offline or to re-run the tests
+// against the same resources.
+type Snapshot struct {
+	// Namespace is the namespace under test.
+	Namespace string `json:"namespace"`
+	// Versions are the versions of the oc client and of the cluster.
+	Versions occompat.Versions `json:"versions"`
+	// Flavor is the flavor of the cluster.
+	Flavor occompat.Flavor `json:"flavor"`
+	// TestTarget holds the resources under test, and the nodes of the cluster.
+	TestTarget configsections.TestTarget `json:"testTarget"`
+	// Partner holds the partner containers.
+	Partner configsections.TestPartner `json:"testPartner"`
+}
+
+// discovery is the result of a discovery of the targets, see DiscoverTargets.
+type discovery struct {
+	namespace string
+	// fingerprint is the output of workloadsFingerprintCommand before the discovery.
+	fingerprint string
+	target      configsections.TestTarget
+	partner     configsections.TestPartner
+}
+
+var (
+	// lastDiscovery is the last discovery of the targets, reused while the workloads are unchanged.
+	lastDiscovery *discovery
+	// snapshot is the snapshot of the run, none until captured or loaded.
+	snapshot *Snapshot
+	// snapshotLoaded is set when the snapshot was loaded from a file, the cluster is not discovered again then.
+	snapshotLoaded bool
+	snapshotLock   sync.Mutex
+)
+
+// LoadSnapshot loads a saved snapshot, which replaces the autodiscovery for the rest of the run:  the versions and the
+// flavor of the cluster are no longer detected, and GetLoadedSnapshot returns the resources under test.
+func LoadSnapshot(path string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read the discovery snapshot: %w", err)
+	}
+	loaded := &Snapshot{}
+	if err = json.Unmarshal(contents, loaded); err != nil {
+		return fmt.Errorf("cannot parse the discovery snapshot %s: %w", path, err)
+	}
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+	snapshot, snapshotLoaded = loaded, true
+	occompat.SetVersions(loaded.Versions)
+	occompat.SetFlavor(loaded.Flavor)
+	// the versions and the flavor of the snapshot are not overwritten by the detection.
+	detectVersionsOnce.Do(func() {})
+	detectFlavorOnce.Do(func() {})
+	log.Infof("Loaded the discovery snapshot %s of the namespace %s", path, loaded.Namespace)
+	return nil
+}
+
+// SaveSnapshot writes the snapshot of the run to path, as JSON.
+func SaveSnapshot(path string) error {
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+	if snapshot == nil {
+		return ErrNoSnapshot
+	}
+	contents, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, contents, snapshotFilePermissions)
+}
+
+// CaptureSnapshot records the first autodiscovery of the run as its snapshot, the later ones, e.g. after the pods
+// under test were re-created, leave it unchanged.
+func CaptureSnapshot(namespace string, target *configsections.TestTarget, partner *configsections.TestPartner) {
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+	if snapshot != nil {
+		return
+	}
+	// the snapshot is a deep copy, the discovered resources are updated during the run.
+	captured := &Snapshot{}
+	if err := deepCopy(&Snapshot{Namespace: namespace, Versions: occompat.GetVersions(), Flavor: occompat.GetFlavor(),
+		TestTarget: *target, Partner: *partner}, captured); err != nil {
+		log.Warnf("Cannot capture the discovery snapshot: %v", err)
+		return
+	}
+	snapshot = captured
+}
+
+// DiscoverTargets finds the resources under test, when the autodiscovery is enabled, and the partner containers of
+// namespace, and adds them to target and partner, see FindTestTarget and FindTestPartner.  The results are reused by
+// the later discoveries, e.g. on the refreshes after the intrusive tests, as long as the pods, deployments and
+// statefulsets of namespace are unchanged.
+func DiscoverTargets(labels []configsections.Label, namespace string, target *configsections.TestTarget,
+	partner *configsections.TestPartner) {
+	fingerprint, err := execCommandOutput(fmt.Sprintf(workloadsFingerprintCommand, namespace))
+	if err != nil {
+		log.Warnf("Cannot list the workloads of %s, discovering the targets again: %v", namespace, err)
+		fingerprint = ""
+	}
+	snapshotLock.Lock()
+	last := lastDiscovery
+	snapshotLock.Unlock()
+	if fingerprint != "" && last != nil && last.namespace == namespace && last.fingerprint == fingerprint {
+		if err = deepCopy(&last.target, target); err == nil {
+			err = deepCopy(&last.partner, partner)
+		}
+		if err == nil {
+			log.Infof("The workloads of %s are unchanged, reusing the discovered targets", namespace)
+			return
+		}
+		log.Warnf("Cannot reuse the discovered targets: %v", err)
+	}
+	if PerformAutoDiscovery() {
+		FindTestTarget(labels, target, namespace)
+	}
+	FindTestPartner(partner, namespace)
+	if fingerprint == "" {
+		return
+	}
+	// the targets are copied, they are updated during the run.
+	discovered := &discovery{namespace: namespace, fingerprint: fingerprint}
+	if err = deepCopy(target, &discovered.target); err == nil {
+		err = deepCopy(partner, &discovered.partner)
+	}
+	if err != nil {
+		log.Warnf("Cannot record the discovered targets: %v", err)
+		return
+	}
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+	lastDiscovery = discovered
+}
+
+// deepCopy copies src to dst, both pointers to the same JSON serializable type.
+func deepCopy(src, dst interface{}) error {
+	contents, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(contents, dst)
+}
+
+// GetLoadedSnapshot returns the snapshot loaded with LoadSnapshot, nil when the cluster is discovered.
+func GetLoadedSnapshot() *Snapshot {
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+	if !snapshotLoaded {
+		return nil
+	}
+	return snapshot
+}
+
+// getSnapshotNodes returns the nodes of the snapshot, false until a snapshot is captured or loaded.
+func getSnapshotNodes() (map[string]configsections.Node, bool) {
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+	if snapshot == nil || snapshot.TestTarget.Nodes == nil {
+		return nil, false
+	}
+	nodes := make(map[string]configsections.Node, len(snapshot.TestTarget.Nodes))
+	for name, node := range snapshot.TestTarget.Nodes {
+		nodes[name] = node
+	}
+	return nodes, true
+}
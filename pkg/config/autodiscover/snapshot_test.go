@@ -0,0 +1,108 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+)
+
+func resetSnapshot() {
+	snapshot, snapshotLoaded, lastDiscovery = nil, false, nil
+}
+
+func TestSnapshot(t *testing.T) {
+	defer resetSnapshot()
+	defer occompat.SetVersions(occompat.Versions{})
+	defer occompat.SetFlavor("")
+	path := filepath.Join(t.TempDir(), "discovery-snapshot.json")
+	assert.Equal(t, ErrNoSnapshot, SaveSnapshot(path))
+
+	occompat.SetVersions(occompat.Versions{Client: occompat.Version{Major: 1, Minor: 22},
+		Server: occompat.Version{Major: 1, Minor: 23}})
+	occompat.SetFlavor(occompat.FlavorKubernetes)
+	target := configsections.TestTarget{
+		PodsUnderTest: []configsections.Pod{{Name: "test-0", Namespace: "tnf"}},
+		Nodes:         map[string]configsections.Node{"worker-0": {Name: "worker-0", Labels: []string{"worker"}}},
+	}
+	partner := configsections.TestPartner{TestOrchestratorID: configsections.ContainerIdentifier{Namespace: "tnf",
+		PodName: "partner", ContainerName: "partner"}}
+	CaptureSnapshot("tnf", &target, &partner)
+	// the later discoveries, and the changes of the discovered resources, leave the snapshot unchanged.
+	target.PodsUnderTest[0].Name = "test-1"
+	CaptureSnapshot("other", &configsections.TestTarget{}, &configsections.TestPartner{})
+	assert.Nil(t, GetLoadedSnapshot())
+	nodes := GetNodesList()
+	assert.Equal(t, []string{"worker"}, nodes["worker-0"].Labels)
+	assert.Nil(t, SaveSnapshot(path))
+
+	resetSnapshot()
+	occompat.SetVersions(occompat.Versions{})
+	occompat.SetFlavor(occompat.FlavorOpenShift)
+	assert.Nil(t, LoadSnapshot(path))
+	loaded := GetLoadedSnapshot()
+	assert.NotNil(t, loaded)
+	assert.Equal(t, "tnf", loaded.Namespace)
+	assert.Equal(t, "test-0", loaded.TestTarget.PodsUnderTest[0].Name)
+	assert.Equal(t, "partner", loaded.Partner.TestOrchestratorID.PodName)
+	assert.Equal(t, occompat.Version{Major: 1, Minor: 23}, occompat.GetVersions().Server)
+	assert.Equal(t, occompat.FlavorKubernetes, occompat.GetFlavor())
+	assert.Equal(t, nodes, GetNodesList())
+
+	assert.NotNil(t, LoadSnapshot(filepath.Join(t.TempDir(), "missing.json")))
+}
+
+func TestDiscoverTargets(t *testing.T) {
+	defer resetSnapshot()
+	defer func(saved func(string) (string, error)) {
+		execCommandOutput = saved
+	}(execCommandOutput)
+	// the targets are not discovered from the cluster, only the given ones are recorded.
+	t.Setenv(disableAutodiscoverEnvVar, "true")
+	fingerprint := "uid-0 1  \n"
+	var commands []string
+	execCommandOutput = func(command string) (string, error) {
+		commands = append(commands, command)
+		return fingerprint, nil
+	}
+	orchestrator := configsections.ContainerIdentifier{Namespace: "tnf", PodName: "partner", ContainerName: "partner"}
+
+	target := configsections.TestTarget{PodsUnderTest: []configsections.Pod{{Name: "test-0", Namespace: "tnf"}}}
+	DiscoverTargets(nil, "tnf", &target, &configsections.TestPartner{TestOrchestratorID: orchestrator})
+	assert.Len(t, commands, 1)
+	assert.Contains(t, commands[0], "oc get pods,deployments,statefulsets -n tnf")
+	// the recorded discovery is a copy.
+	target.PodsUnderTest[0].Name = "test-1"
+
+	// the workloads are unchanged, the discovery is reused.
+	var reused configsections.TestTarget
+	var partner configsections.TestPartner
+	DiscoverTargets(nil, "tnf", &reused, &partner)
+	assert.Equal(t, []configsections.Pod{{Name: "test-0", Namespace: "tnf"}}, reused.PodsUnderTest)
+	assert.Equal(t, orchestrator, partner.TestOrchestratorID)
+
+	// the pods were re-created, the targets are discovered again.
+	fingerprint = "uid-1 1  \n"
+	var discovered configsections.TestTarget
+	DiscoverTargets(nil, "tnf", &discovered, &configsections.TestPartner{TestOrchestratorID: orchestrator})
+	assert.Empty(t, discovered.PodsUnderTest)
+	assert.Len(t, commands, 3)
+}
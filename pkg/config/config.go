@@ -17,18 +17,24 @@
 package config
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/debugpods"
+	"github.com/test-network-function/test-network-function/pkg/kubeconfig"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ipaddr"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+	"github.com/test-network-function/test-network-function/pkg/utils"
 	"gopkg.in/yaml.v2"
 )
 
@@ -40,12 +46,18 @@ const (
 
 var (
 	expectersVerboseModeEnabled = false
+	// cliOverrides are the overrides of the fields of the configuration set on the command line, see
+	// SetConfigurationOverrides
+	cliOverrides []configsections.Override
+	// ignoredEnvOverridesWarning warns once about the TNF_CONFIG_ environment variables matching no field
+	ignoredEnvOverridesWarning sync.Once
 	// testEnvironment is the singleton instance of `TestEnvironment`, accessed through `GetTestEnvironment`
 	testEnvironment TestEnvironment
 )
 
-// getConfigurationFilePathFromEnvironment returns the test configuration file.
-func getConfigurationFilePathFromEnvironment() string {
+// GetConfigurationFilePath returns the test configuration file, set by $TNF_CONFIGURATION_PATH or tnf_config.yml, which
+// may be a remote source, see ReadConfigurationSource.
+func GetConfigurationFilePath() string {
 	environmentSourcedConfigurationFilePath := os.Getenv(configurationFilePathEnvironmentVariableKey)
 	if environmentSourcedConfigurationFilePath != "" {
 		return environmentSourcedConfigurationFilePath
@@ -53,6 +65,128 @@ func getConfigurationFilePathFromEnvironment() string {
 	return defaultConfigurationFilePath
 }
 
+// ReadConfigurationFile reads the test configuration file without loading the test environment, e.g. to set up the
+// reporting of a run before the autodiscovery.
+func ReadConfigurationFile() (*configsections.TestConfiguration, error) {
+	return ReadConfigurationFileFrom(GetConfigurationFilePath())
+}
+
+// SetConfigurationOverrides sets the overrides of the fields of the configuration set on the command line, as
+// path=value, e.g. timeouts.default=1m, which take precedence over the TNF_CONFIG_ environment variables and the
+// configuration file.
+func SetConfigurationOverrides(settings []string) error {
+	var overrides []configsections.Override
+	for _, setting := range settings {
+		override, err := configsections.ParseOverride(setting)
+		if err != nil {
+			return err
+		}
+		overrides = append(overrides, override)
+	}
+	cliOverrides = overrides
+	return nil
+}
+
+// GetConfigurationOverrides returns the overrides of the fields of the configuration, the ones set by the TNF_CONFIG_
+// environment variables, see configsections.GetEnvOverrides, followed by the ones set on the command line.  The
+// TNF_CONFIG_ variables matching no field are logged as warnings and ignored.
+func GetConfigurationOverrides() []configsections.Override {
+	overrides, ignored := configsections.GetEnvOverrides(os.Environ())
+	ignoredEnvOverridesWarning.Do(func() {
+		for _, variable := range ignored {
+			log.Warnf("ignoring the environment variable %s: it matches no field of the configuration", variable)
+		}
+	})
+	return append(overrides, cliOverrides...)
+}
+
+// readConfigurationContents reads the test configuration file at filePath, or from a remote source, see
+// ReadConfigurationSource, with its overrides applied.
+func readConfigurationContents(filePath string) ([]byte, error) {
+	contents, err := ReadConfigurationSource(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return configsections.ApplyOverrides(contents, GetConfigurationOverrides())
+}
+
+// ReadConfigurationFileFrom reads the test configuration file at filePath, like ReadConfigurationFile.
+func ReadConfigurationFileFrom(filePath string) (*configsections.TestConfiguration, error) {
+	contents, err := readConfigurationContents(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var testConfig configsections.TestConfiguration
+	if err = yaml.Unmarshal(contents, &testConfig); err != nil {
+		return nil, err
+	}
+	return &testConfig, nil
+}
+
+// InvalidConfigurationError is the error of a configuration file failing the validation, see CheckConfigurationFile.
+type InvalidConfigurationError struct {
+	// FilePath is the configuration file.
+	FilePath string
+	// Problems are the unknown fields, the values of the wrong type and the missing required fields of the file.
+	Problems []configsections.ValidationError
+}
+
+// Error lists the problems of the configuration file, one per line.
+func (e *InvalidConfigurationError) Error() string {
+	lines := []string{fmt.Sprintf("invalid configuration file %s: %d problems", e.FilePath, len(e.Problems))}
+	for _, problem := range e.Problems {
+		lines = append(lines, "  "+problem.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CheckConfigurationFile validates the test configuration file at filePath with its overrides applied, see
+// configsections.Validate.  It returns an *InvalidConfigurationError listing the problems of an invalid file, and the
+// error reading or parsing the file otherwise.  The CLI, tnf run --validate-config and the test suite all validate the
+// configuration with it.
+func CheckConfigurationFile(filePath string) error {
+	contents, err := readConfigurationContents(filePath)
+	if err != nil {
+		return fmt.Errorf("invalid configuration file %s: %w", filePath, err)
+	}
+	problems, err := configsections.Validate(contents)
+	if err != nil {
+		return fmt.Errorf("invalid configuration file %s: %w", filePath, err)
+	}
+	if len(problems) != 0 {
+		return &InvalidConfigurationError{FilePath: filePath, Problems: problems}
+	}
+	return nil
+}
+
+// UseCluster makes the sessions spawned afterwards access a cluster selected by configsections.SelectCluster:  through
+// its bastion, if any, with its kubeconfig and context on the bastion, or else with its kubeconfig and context, which
+// are selected with a kubeconfig file written to a temporary directory, see kubeconfig.Use.  The returned function
+// removes the directory, once the sessions are done.
+func UseCluster(cluster *configsections.Cluster) (remove func(), err error) {
+	if bastion := cluster.Bastion; bastion.IsSet() {
+		log.Infof("Spawning the sessions of the cluster %q through the bastion %s, with the context %q of %s", cluster.Name,
+			bastion.Host, cluster.Context, cluster.Kubeconfig)
+		interactive.SetBastion(&interactive.Bastion{
+			SSHTarget: interactive.SSHTarget{User: bastion.User, Host: bastion.Host, Port: bastion.Port,
+				KeyFile: bastion.KeyFile, Jumphost: bastion.Jumphost},
+			Kubeconfig: cluster.Kubeconfig,
+			Context:    cluster.Context,
+		})
+		return func() {}, nil
+	}
+	if cluster.Kubeconfig == "" && cluster.Context == "" {
+		return func() {}, nil
+	}
+	remove, err = kubeconfig.Use(cluster.Kubeconfig, cluster.Context)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Testing the cluster %q with the context %q of %s", cluster.Name, cluster.Context,
+		os.Getenv(kubeconfig.EnvVar))
+	return remove, nil
+}
+
 // Container is a construct which follows the Container design pattern.  Essentially, a Container holds the
 // pertinent information to perform a test against or using an Operating System Container.  This includes facets such
 // as the reference to the interactive.Oc instance, the reference to the test configuration, and the default network
@@ -61,7 +195,19 @@ type Container struct {
 	ContainerConfiguration  configsections.ContainerConfig
 	Oc                      *interactive.Oc
 	DefaultNetworkIPAddress string
-	ContainerIdentifier     configsections.ContainerIdentifier
+	// DefaultNetworkIPAddresses are all the addresses of the default network device, IPv4 and IPv6 for dual-stack
+	// pods, DefaultNetworkIPAddress being the first one.
+	DefaultNetworkIPAddresses []string
+	ContainerIdentifier       configsections.ContainerIdentifier
+}
+
+// GetDefaultNetworkIPAddress returns the first default network IP address of an address family (utils.IPv4Family or
+// utils.IPv6Family), or an empty string when the container has none.
+func (c *Container) GetDefaultNetworkIPAddress(family string) string {
+	if addresses := utils.FilterIPFamily(c.DefaultNetworkIPAddresses, family); len(addresses) > 0 {
+		return addresses[0]
+	}
+	return ""
 }
 
 type NodeConfig struct {
@@ -94,67 +240,105 @@ func (n NodeConfig) HasDebugPod() bool {
 // DefaultTimeout for creating new interactive sessions (oc, ssh, tty)
 var DefaultTimeout = time.Duration(defaultTimeoutSeconds) * time.Second
 
+// spawnedSession is the result of spawning an interactive session in a goroutine.
+type spawnedSession struct {
+	oc  *interactive.Oc
+	err error
+}
+
 // Helper used to instantiate an OpenShift Client Session.
-func getOcSession(pod, container, namespace string, timeout time.Duration, options ...interactive.Option) *interactive.Oc {
+func getOcSession(pod, container, namespace string, timeout time.Duration, options ...interactive.Option) (*interactive.Oc, error) {
 	// Spawn an interactive OC shell using a goroutine (needed to avoid cross expect.Expecter interaction).  Extract the
 	// Oc reference from the goroutine through a channel.  Performs basic sanity checking that the Oc session is set up
 	// correctly.
-	var containerOc *interactive.Oc
-	ocChan := make(chan *interactive.Oc)
+	ocChan := make(chan spawnedSession)
 
 	goExpectSpawner := interactive.NewGoExpectSpawner()
 	var spawner interactive.Spawner = goExpectSpawner
 
 	go func() {
 		oc, outCh, err := interactive.SpawnOc(&spawner, pod, container, namespace, timeout, options...)
-		gomega.Expect(outCh).ToNot(gomega.BeNil())
-		gomega.Expect(err).To(gomega.BeNil())
-		// Set up a go routine which reads from the error channel
+		if err != nil {
+			ocChan <- spawnedSession{err: fmt.Errorf("cannot open an OC session to container %s/%s in ns=%s: %w", pod, container, namespace, err)}
+			return
+		}
+		// Set up a go routine which reads from the error channel.  The commands sent to a broken session fail, so
+		// the tests using it fail instead of the whole run.
 		go func() {
 			log.Debugf("start watching the session with container %s/%s", oc.GetPodName(), oc.GetPodContainerName())
 			select {
 			case err := <-outCh:
-				log.Fatalf("OC session to container %s/%s is broken due to: %v, aborting the test run", oc.GetPodName(), oc.GetPodContainerName(), err)
+				log.Errorf("OC session to container %s/%s is broken due to: %v", oc.GetPodName(), oc.GetPodContainerName(), err)
 			case <-oc.GetDoneChannel():
 				log.Debugf("stop watching the session with container %s/%s", oc.GetPodName(), oc.GetPodContainerName())
 			}
 		}()
-		ocChan <- oc
+		ocChan <- spawnedSession{oc: oc}
 	}()
 
-	containerOc = <-ocChan
+	session := <-ocChan
+	return session.oc, session.err
+}
+
+// getNodeSSHSession opens an SSH session to a node, like getOcSession, and watches it.
+func getNodeSSHSession(node string, target *interactive.SSHTarget, timeout time.Duration, options ...interactive.Option) (*interactive.Oc, error) {
+	ocChan := make(chan spawnedSession)
+	var spawner interactive.Spawner = interactive.NewGoExpectSpawner()
 
-	gomega.Expect(containerOc).ToNot(gomega.BeNil())
+	go func() {
+		oc, outCh, err := interactive.SpawnNodeSSH(&spawner, node, target, timeout, options...)
+		if err != nil {
+			ocChan <- spawnedSession{err: fmt.Errorf("cannot open an SSH session to node %s at %s@%s: %w", node, target.User, target.Host, err)}
+			return
+		}
+		go func() {
+			log.Debugf("start watching the SSH session with node %s", node)
+			select {
+			case err := <-outCh:
+				log.Errorf("SSH session to node %s is broken due to: %v", node, err)
+			case <-oc.GetDoneChannel():
+				log.Debugf("stop watching the SSH session with node %s", node)
+			}
+		}()
+		ocChan <- spawnedSession{oc: oc}
+	}()
 
-	return containerOc
+	session := <-ocChan
+	return session.oc, session.err
 }
 
-// Extract a container IP address for a particular device.  This is needed since container default network IP address
-// is served by dhcp, and thus is ephemeral.
-func getContainerDefaultNetworkIPAddress(oc *interactive.Oc, dev string) (string, error) {
+// Extract the container IP addresses of a particular device, the IPv4 ones first.  This is needed since container
+// default network IP address is served by dhcp, and thus is ephemeral.
+func getContainerDefaultNetworkIPAddresses(ctx context.Context, oc *interactive.Oc, dev string, timeout time.Duration) ([]string, error) {
 	log.Infof("Getting IP Information for: %s(%s) in ns=%s", oc.GetPodName(), oc.GetPodContainerName(), oc.GetPodNamespace())
-	ipTester := ipaddr.NewIPAddr(DefaultTimeout, dev)
-	test, err := tnf.NewTest(oc.GetExpecter(), ipTester, []reel.Handler{ipTester}, oc.GetErrorChannel())
-	gomega.Expect(err).To(gomega.BeNil())
+	ipTester := ipaddr.NewIPAddr(timeout, dev)
+	test, err := tnf.NewTest(ctx, oc.GetExpecter(), ipTester, []reel.Handler{ipTester}, oc.GetErrorChannel())
+	if err != nil {
+		return nil, err
+	}
 	result, err := test.Run()
 	if result == tnf.SUCCESS && err == nil {
-		return ipTester.GetIPv4Address(), nil
+		return ipTester.GetIPAddresses(), nil
 	}
-	return "", fmt.Errorf("failed to get IP information for %s(%s) in ns=%s, result=%v, err=%v",
+	return nil, fmt.Errorf("failed to get IP information for %s(%s) in ns=%s, result=%v, err=%v",
 		oc.GetPodName(), oc.GetPodContainerName(), oc.GetPodNamespace(), result, err)
 }
 
 // TestEnvironment includes the representation of the current state of the test targets and partners as well as the test configuration
 type TestEnvironment struct {
-	ContainersUnderTest  map[configsections.ContainerIdentifier]*Container
-	PartnerContainers    map[configsections.ContainerIdentifier]*Container
-	DebugContainers      map[configsections.ContainerIdentifier]*Container
-	PodsUnderTest        []configsections.Pod
-	DeploymentsUnderTest []configsections.Deployment
-	OperatorsUnderTest   []configsections.Operator
-	NameSpaceUnderTest   string
-	CrdNames             []string
-	NodesUnderTest       map[string]*NodeConfig
+	ContainersUnderTest   map[configsections.ContainerIdentifier]*Container
+	PartnerContainers     map[configsections.ContainerIdentifier]*Container
+	DebugContainers       map[configsections.ContainerIdentifier]*Container
+	PodsUnderTest         []configsections.Pod
+	DeploymentsUnderTest  []configsections.Deployment
+	StatefulSetsUnderTest []configsections.StatefulSet
+	OperatorsUnderTest    []configsections.Operator
+	NameSpaceUnderTest    string
+	// Crds are the CRDs under test, matching the targetCrdFilters.
+	Crds           []configsections.Crd
+	NodesUnderTest map[string]*NodeConfig
+	// IPFamilies are the address families (utils.IPv4Family, utils.IPv6Family) detected on the pods under test.
+	IPFamilies []string
 
 	// ContainersToExcludeFromConnectivityTests is a set used for storing the containers that should be excluded from
 	// connectivity testing.
@@ -165,6 +349,8 @@ type TestEnvironment struct {
 	loaded bool
 	// set when an intrusive test has done something that would cause Pod/Container to be recreated
 	needsRefresh bool
+	// ctx is the context of the run, see SetContext.
+	ctx context.Context
 }
 
 // loadConfigFromFile loads a config file once.
@@ -174,7 +360,7 @@ func (env *TestEnvironment) loadConfigFromFile(filePath string) error {
 	}
 	log.Info("Loading config from file: ", filePath)
 
-	contents, err := os.ReadFile(filePath)
+	contents, err := readConfigurationContents(filePath)
 	if err != nil {
 		return err
 	}
@@ -183,24 +369,34 @@ func (env *TestEnvironment) loadConfigFromFile(filePath string) error {
 	if err != nil {
 		return err
 	}
+	// the problems which do not prevent loading the file, e.g. a misspelled field, would otherwise go unnoticed.
+	var invalid *InvalidConfigurationError
+	if errors.As(CheckConfigurationFile(filePath), &invalid) {
+		for _, problem := range invalid.Problems {
+			log.Warnf("configuration file %s: %v", filePath, problem)
+		}
+	}
 	env.loaded = true
 	return nil
 }
 
-// LoadAndRefresh loads the config file if not loaded already and performs autodiscovery if needed
-func (env *TestEnvironment) LoadAndRefresh() {
+// LoadAndRefresh loads the config file if not loaded already and performs autodiscovery if needed.  It is up to the
+// caller to decide whether an error aborts the run.
+func (env *TestEnvironment) LoadAndRefresh() error {
 	if !env.loaded {
-		filePath := getConfigurationFilePathFromEnvironment()
+		filePath := GetConfigurationFilePath()
 		log.Debugf("GetConfigInstance before config loaded, loading from file: %s", filePath)
 		err := env.loadConfigFromFile(filePath)
 		if err != nil {
-			log.Fatalf("unable to load configuration file: %s", err)
+			return fmt.Errorf("unable to load configuration file: %w", err)
 		}
-		env.doAutodiscover()
+		autodiscover.SetTimeouts(env.Config.Timeouts)
+		return env.doAutodiscover()
 	} else if env.needsRefresh {
 		env.reset()
-		env.doAutodiscover()
+		return env.doAutodiscover()
 	}
+	return nil
 }
 
 // Resets the environment during the drain test since all the connections are affected
@@ -245,15 +441,15 @@ func (env *TestEnvironment) ResetOc() {
 	}
 }
 
-func (env *TestEnvironment) doAutodiscover() {
+func (env *TestEnvironment) doAutodiscover() error {
 	log.Debug("start auto discovery")
 	if len(env.Config.TargetNameSpaces) != 1 {
-		log.Fatal("a single namespace should be specified in config file")
+		return errors.New("a single namespace should be specified in config file")
 	}
 	env.NameSpaceUnderTest = env.Config.TargetNameSpaces[0].Name
-	if autodiscover.PerformAutoDiscovery() {
-		autodiscover.FindTestTarget(env.Config.TargetPodLabels, &env.Config.TestTarget, env.NameSpaceUnderTest)
-	}
+	autodiscover.DetectVersions()
+	autodiscover.DetectFlavor()
+	env.discoverTargets()
 
 	env.ContainersToExcludeFromConnectivityTests = make(map[configsections.ContainerIdentifier]interface{})
 
@@ -261,54 +457,105 @@ func (env *TestEnvironment) doAutodiscover() {
 		env.ContainersToExcludeFromConnectivityTests[cid] = ""
 	}
 
-	env.ContainersUnderTest = env.createContainers(env.Config.ContainerConfigList)
+	var err error
+	env.ContainersUnderTest, err = env.createContainers(env.Config.ContainerConfigList)
+	if err != nil {
+		return err
+	}
 	env.PodsUnderTest = env.Config.PodsUnderTest
+	env.IPFamilies = detectIPFamilies(env.ContainersUnderTest)
+	log.Infof("Detected IP families: %v", env.IPFamilies)
 
 	for _, cid := range env.Config.Partner.ContainersDebugList {
 		env.ContainersToExcludeFromConnectivityTests[cid.ContainerIdentifier] = ""
 	}
-	autodiscover.FindTestPartner(&env.Config.Partner, env.NameSpaceUnderTest)
-	env.PartnerContainers = env.createContainers(env.Config.Partner.ContainerConfigList)
+	env.PartnerContainers, err = env.createContainers(env.Config.Partner.ContainerConfigList)
+	if err != nil {
+		return err
+	}
 	env.TestOrchestrator = env.PartnerContainers[env.Config.Partner.TestOrchestratorID]
 	env.DeploymentsUnderTest = env.Config.DeploymentsUnderTest
+	env.StatefulSetsUnderTest = env.Config.StatefulSetsUnderTest
 	env.OperatorsUnderTest = env.Config.Operators
+	if len(env.Config.CrdFilters) > 0 {
+		env.Crds = autodiscover.FindTestCrds(env.Config.CrdFilters)
+	}
 
-	env.discoverNodes()
+	if err = env.discoverNodes(); err != nil {
+		return err
+	}
 	log.Infof("Test Configuration: %+v", *env)
 
 	env.needsRefresh = false
+	return nil
 }
 
-// labelNodes add label to specific nodes so that node selector in debug daemonset
-// can be scheduled
-func (env *TestEnvironment) labelNodes() {
-	var masterNode, workerNode string
-	// make sure at least one worker and one master has debug set to true
-	for name, node := range env.NodesUnderTest {
-		if node.IsMaster() && masterNode == "" {
-			masterNode = name
-		}
-		if node.IsMaster() && node.HasDebugPod() {
-			masterNode = ""
-			break
+// discoverTargets discovers the resources under test and the partner containers, reusing the previous discovery while
+// the workloads are unchanged, and captures them in the discovery snapshot.  They are taken from the snapshot instead when one was loaded, see autodiscover.LoadSnapshot.
+func (env *TestEnvironment) discoverTargets() {
+	if snapshot := autodiscover.GetLoadedSnapshot(); snapshot != nil {
+		if snapshot.Namespace != env.NameSpaceUnderTest {
+			log.Warnf("The discovery snapshot was captured in the namespace %s, not in %s", snapshot.Namespace,
+				env.NameSpaceUnderTest)
 		}
+		env.Config.TestTarget = snapshot.TestTarget
+		env.Config.Partner = snapshot.Partner
+		return
 	}
-	for name, node := range env.NodesUnderTest {
-		if node.IsWorker() && workerNode == "" {
-			workerNode = name
-		}
-		if node.IsWorker() && node.HasDebugPod() {
-			workerNode = ""
-			break
+	autodiscover.DiscoverTargets(env.Config.TargetPodLabels, env.NameSpaceUnderTest, &env.Config.TestTarget,
+		&env.Config.Partner)
+	autodiscover.CaptureSnapshot(env.NameSpaceUnderTest, &env.Config.TestTarget, &env.Config.Partner)
+}
+
+// SkipsContainerTest returns true when the container under test is opted out of the test case testID, by the
+// container_test_skips annotation of its pod or by the containerTestSkips section of the configuration.
+func (env *TestEnvironment) SkipsContainerTest(testID string, container *configsections.ContainerIdentifier) bool {
+	skipped := env.Config.ContainerTestSkips.Skips(testID, container)
+	for i := range env.PodsUnderTest {
+		pod := &env.PodsUnderTest[i]
+		if !skipped && pod.Namespace == container.Namespace && pod.Name == container.PodName {
+			skipped = pod.SkipsContainerTest(testID, container.ContainerName)
 		}
 	}
-	if masterNode != "" {
-		env.NodesUnderTest[masterNode].debug = true
+	if skipped {
+		log.Infof("The container %s/%s/%s is opted out of %s", container.Namespace, container.PodName,
+			container.ContainerName, testID)
+	}
+	return skipped
+}
+
+// detectIPFamilies returns the address families used by the containers, based on their pod IPs and on their default
+// network IP addresses.
+func detectIPFamilies(containers map[configsections.ContainerIdentifier]*Container) []string {
+	var addresses []string
+	for _, c := range containers {
+		addresses = append(addresses, c.ContainerConfiguration.PodIPAddresses...)
+		addresses = append(addresses, c.DefaultNetworkIPAddresses...)
 	}
-	if workerNode != "" {
-		env.NodesUnderTest[workerNode].debug = true
+	return utils.IPFamilies(addresses)
+}
+
+// IsIPv6Only returns true when the pods under test only have IPv6 addresses.
+func (env *TestEnvironment) IsIPv6Only() bool {
+	return len(env.IPFamilies) == 1 && env.IPFamilies[0] == utils.IPv6Family
+}
+
+// selectDebugNodes selects the nodes accessed by the tests, with a debug pod or over SSH
+func (env *TestEnvironment) selectDebugNodes() {
+	// make sure at least one worker, one master and one node of each configured role has debug set to true
+	env.ensureDebugNode((*NodeConfig).IsMaster)
+	env.ensureDebugNode((*NodeConfig).IsWorker)
+	for i := range env.Config.NodeRoles {
+		role := env.Config.NodeRoles[i].Role
+		env.ensureDebugNode(func(node *NodeConfig) bool {
+			return node.Node.HasRole(role)
+		})
 	}
-	// label all nodes
+}
+
+// labelNodes add label to specific nodes so that node selector in debug daemonset
+// can be scheduled
+func (env *TestEnvironment) labelNodes() {
 	for nodeName, node := range env.NodesUnderTest {
 		if node.HasDebugPod() {
 			autodiscover.AddDebugLabel(nodeName)
@@ -316,6 +563,25 @@ func (env *TestEnvironment) labelNodes() {
 	}
 }
 
+// ensureDebugNode sets debug to true on one of the nodes selected by a function, unless one of them already has it.
+func (env *TestEnvironment) ensureDebugNode(selected func(*NodeConfig) bool) {
+	var debugNode string
+	for name, node := range env.NodesUnderTest {
+		if !selected(node) {
+			continue
+		}
+		if node.HasDebugPod() {
+			return
+		}
+		if debugNode == "" {
+			debugNode = name
+		}
+	}
+	if debugNode != "" {
+		env.NodesUnderTest[debugNode].debug = true
+	}
+}
+
 // create Nodes data from deployment
 func (env *TestEnvironment) createNodes(nodes map[string]configsections.Node) map[string]*NodeConfig {
 	log.Debug("autodiscovery: create nodes  start")
@@ -346,54 +612,102 @@ func (env *TestEnvironment) AttachDebugPodsToNodes() {
 	}
 }
 
+// attachSSHSessionsToNodes opens the SSH sessions to the selected nodes, running their node commands instead of the
+// debug pods
+func (env *TestEnvironment) attachSSHSessionsToNodes() error {
+	sshConfig := &env.Config.NodeSSH
+	timeout := env.Config.Timeouts.Get("", "ssh", DefaultTimeout)
+	for name, node := range env.NodesUnderTest {
+		if !node.HasDebugPod() {
+			continue
+		}
+		target := &interactive.SSHTarget{User: sshConfig.GetUser(), Host: sshConfig.GetHost(&node.Node),
+			Port: sshConfig.Port, KeyFile: sshConfig.KeyFile, Jumphost: sshConfig.Jumphost}
+		log.Infof("Opening an SSH session to node %s at %s@%s", name, target.User, target.Host)
+		oc, err := getNodeSSHSession(name, target, timeout, interactive.Verbose(expectersVerboseModeEnabled),
+			interactive.SendTimeout(timeout))
+		if err != nil {
+			return err
+		}
+		node.Oc = oc
+	}
+	return nil
+}
+
 // discoverNodes find all the nodes in the cluster
-// label the ones with deployment
-// attach them to debug pods
-func (env *TestEnvironment) discoverNodes() {
+// select the ones with deployment and open SSH sessions to them, when configured
+// otherwise label them, deploy the debug daemonset unless present, and attach them to debug pods
+func (env *TestEnvironment) discoverNodes() error {
 	env.NodesUnderTest = env.createNodes(env.Config.Nodes)
+	env.selectDebugNodes()
+	if env.Config.NodeSSH.Enabled {
+		return env.attachSSHSessionsToNodes()
+	}
 	env.labelNodes()
 
 	if !autodiscover.IsMinikube() {
+		if !env.Config.DebugDaemonSet.SkipDeploy {
+			if err := debugpods.Deploy(&env.Config.DebugDaemonSet); err != nil {
+				return err
+			}
+		}
 		expectedDebugPods := 0
 		for _, node := range env.NodesUnderTest {
 			if node.HasDebugPod() {
 				expectedDebugPods++
 			}
 		}
-		autodiscover.CheckDebugDaemonset(expectedDebugPods)
-		autodiscover.FindDebugPods(&env.Config.Partner)
+		if err := autodiscover.CheckDebugDaemonset(expectedDebugPods); err != nil {
+			return err
+		}
+		if err := autodiscover.FindDebugPods(&env.Config.Partner); err != nil {
+			return err
+		}
 		for _, debugPod := range env.Config.Partner.ContainersDebugList {
 			env.ContainersToExcludeFromConnectivityTests[debugPod.ContainerIdentifier] = ""
 		}
-		env.DebugContainers = env.createContainers(env.Config.Partner.ContainersDebugList)
+		debugContainers, err := env.createContainers(env.Config.Partner.ContainersDebugList)
+		if err != nil {
+			return err
+		}
+		env.DebugContainers = debugContainers
 	}
 
 	env.AttachDebugPodsToNodes()
+	return nil
 }
 
 // createContainers contains the general steps involved in creating "oc" sessions and other configuration. A map of the
 // aggregate information is returned.
-func (env *TestEnvironment) createContainers(containerDefinitions []configsections.ContainerConfig) map[configsections.ContainerIdentifier]*Container {
+func (env *TestEnvironment) createContainers(containerDefinitions []configsections.ContainerConfig) (map[configsections.ContainerIdentifier]*Container, error) {
 	createdContainers := make(map[configsections.ContainerIdentifier]*Container)
 	for _, c := range containerDefinitions {
-		oc := getOcSession(c.PodName, c.ContainerName, c.Namespace, DefaultTimeout, interactive.Verbose(expectersVerboseModeEnabled), interactive.SendTimeout(DefaultTimeout))
+		timeout := env.Config.Timeouts.Get("", "oc", DefaultTimeout)
+		oc, err := getOcSession(c.PodName, c.ContainerName, c.Namespace, timeout, interactive.Verbose(expectersVerboseModeEnabled), interactive.SendTimeout(timeout))
+		if err != nil {
+			return nil, err
+		}
 		var defaultIPAddress = "UNKNOWN"
-		var err error
+		var defaultIPAddresses []string
 		if _, ok := env.ContainersToExcludeFromConnectivityTests[c.ContainerIdentifier]; !ok {
-			defaultIPAddress, err = getContainerDefaultNetworkIPAddress(oc, c.DefaultNetworkDevice)
+			addresses, err := getContainerDefaultNetworkIPAddresses(env.Context(), oc, c.DefaultNetworkDevice, env.Config.Timeouts.Get("", "ipaddr", DefaultTimeout))
 			if err != nil {
 				log.Warnf("Adding container to the ExcludeFromConnectivityTests list due to: %v", err)
 				env.ContainersToExcludeFromConnectivityTests[c.ContainerIdentifier] = ""
+			} else {
+				defaultIPAddress = addresses[0]
+				defaultIPAddresses = addresses
 			}
 		}
 		createdContainers[c.ContainerIdentifier] = &Container{
-			ContainerConfiguration:  c,
-			Oc:                      oc,
-			DefaultNetworkIPAddress: defaultIPAddress,
-			ContainerIdentifier:     c.ContainerIdentifier,
+			ContainerConfiguration:    c,
+			Oc:                        oc,
+			DefaultNetworkIPAddress:   defaultIPAddress,
+			DefaultNetworkIPAddresses: defaultIPAddresses,
+			ContainerIdentifier:       c.ContainerIdentifier,
 		}
 	}
-	return createdContainers
+	return createdContainers, nil
 }
 
 // SetNeedsRefresh marks the config stale so that the next getInstance call will redo discovery
@@ -401,6 +715,19 @@ func (env *TestEnvironment) SetNeedsRefresh() {
 	env.needsRefresh = true
 }
 
+// SetContext sets the context of the run, e.g. a context canceled on SIGINT or when the deadline of the run expires.
+func (env *TestEnvironment) SetContext(ctx context.Context) {
+	env.ctx = ctx
+}
+
+// Context returns the context of the run the tests are created with, context.Background() unless set by SetContext.
+func (env *TestEnvironment) Context() context.Context {
+	if env.ctx == nil {
+		return context.Background()
+	}
+	return env.ctx
+}
+
 // GetTestEnvironment provides the current state of test environment
 func GetTestEnvironment() *TestEnvironment {
 	return &testEnvironment
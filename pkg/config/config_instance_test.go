@@ -17,6 +17,9 @@
 package config
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,3 +64,20 @@ func TestLoadConfigFromFile(t *testing.T) {
 	testLoadedDeployments(t, env.Config.DeploymentsUnderTest)
 	testLoadedCrds(t, env.Config.CrdFilters)
 }
+
+func TestCheckConfigurationFile(t *testing.T) {
+	dir := t.TempDir()
+	validFilePath := filepath.Join(dir, "valid.yml")
+	assert.Nil(t, os.WriteFile(validFilePath, []byte("targetNameSpaces:\n  - name: cnf\n"), 0600))
+	assert.Nil(t, CheckConfigurationFile(validFilePath))
+
+	invalidFilePath := filepath.Join(dir, "invalid.yml")
+	assert.Nil(t, os.WriteFile(invalidFilePath, []byte("targetNameSpace:\n  - name: cnf\n"), 0600))
+	err := CheckConfigurationFile(invalidFilePath)
+	var invalid *InvalidConfigurationError
+	assert.True(t, errors.As(err, &invalid))
+	assert.Equal(t, invalidFilePath, invalid.FilePath)
+	assert.Len(t, invalid.Problems, 1)
+
+	assert.NotNil(t, CheckConfigurationFile(filepath.Join(dir, "missing.yml")))
+}
@@ -16,6 +16,13 @@
 
 package configsections
 
+import (
+	"strings"
+)
+
+// NodeRoleLabelPrefix is the prefix of the node-role.kubernetes.io/<role> labels giving the roles of a node.
+const NodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
 // WorkerLabel const for k8s worker
 const WorkerLabel = "node-role.kubernetes.io/worker"
 
@@ -24,8 +31,27 @@ const MasterLabel = "node-role.kubernetes.io/master"
 
 // Node defines in the cluster. with name of the node and the type of this node master/worker,,,,.
 type Node struct {
-	Name   string
+	Name string
+	// Labels are the node-role.kubernetes.io/<role> labels of the node, e.g. of the master, worker, infra or rt-worker
+	// roles.
 	Labels []string
+	// Architecture, KernelVersion, OSImage and ContainerRuntimeVersion come from the node info of the node status.
+	Architecture            string `yaml:"architecture,omitempty" json:"architecture,omitempty"`
+	KernelVersion           string `yaml:"kernelVersion,omitempty" json:"kernelVersion,omitempty"`
+	OSImage                 string `yaml:"osImage,omitempty" json:"osImage,omitempty"`
+	ContainerRuntimeVersion string `yaml:"containerRuntimeVersion,omitempty" json:"containerRuntimeVersion,omitempty"`
+	// InternalIP is the first internal IP address of the node.
+	InternalIP string `yaml:"internalIP,omitempty" json:"internalIP,omitempty"`
+	// Allocatable maps the resources, e.g. cpu, memory or hugepages-1Gi, to the quantities available for the pods.
+	Allocatable map[string]string `yaml:"allocatable,omitempty" json:"allocatable,omitempty"`
+	Taints      []Taint           `yaml:"taints,omitempty" json:"taints,omitempty"`
+}
+
+// Taint is a taint of a node, which repels the pods not tolerating it.
+type Taint struct {
+	Key    string `yaml:"key" json:"key"`
+	Value  string `yaml:"value,omitempty" json:"value,omitempty"`
+	Effect string `yaml:"effect" json:"effect"`
 }
 
 // IsMaster Function that return if the node is master
@@ -47,3 +73,24 @@ func (node Node) IsWorker() bool {
 	}
 	return false
 }
+
+// Roles returns the roles of the node, e.g. "master" or "infra".
+func (node Node) Roles() []string {
+	var roles []string
+	for _, label := range node.Labels {
+		if strings.HasPrefix(label, NodeRoleLabelPrefix) {
+			roles = append(roles, strings.TrimPrefix(label, NodeRoleLabelPrefix))
+		}
+	}
+	return roles
+}
+
+// HasRole returns true when the node has a role, e.g. "infra".
+func (node Node) HasRole(role string) bool {
+	for _, label := range node.Labels {
+		if label == NodeRoleLabelPrefix+role {
+			return true
+		}
+	}
+	return false
+}
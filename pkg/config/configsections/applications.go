@@ -0,0 +1,48 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+// DefaultApplicationLabel is the label grouping the pods under test into applications, unless configured.
+const DefaultApplicationLabel = "app.kubernetes.io/part-of"
+
+// Applications configures the grouping of the pods under test into applications, e.g. when a single namespace hosts
+// several CNFs.
+type Applications struct {
+	// GroupingLabel is the pod label whose value names the application of a pod.
+	GroupingLabel string `yaml:"groupingLabel,omitempty" json:"groupingLabel,omitempty"`
+}
+
+// GetGroupingLabel returns the pod label whose value names the application of a pod.
+func (a *Applications) GetGroupingLabel() string {
+	if a.GroupingLabel == "" {
+		return DefaultApplicationLabel
+	}
+	return a.GroupingLabel
+}
+
+// Group returns the "namespace/pod" names of the pods, by application.  The pods without the grouping label belong to
+// no application.
+func (a *Applications) Group(pods []Pod) map[string][]string {
+	label := a.GetGroupingLabel()
+	applications := map[string][]string{}
+	for i := range pods {
+		pod := &pods[i]
+		if application, ok := pod.Labels[label]; ok && application != "" {
+			applications[application] = append(applications[application], pod.Namespace+"/"+pod.Name)
+		}
+	}
+	return applications
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestApplications_GetGroupingLabel(t *testing.T) {
+	assert.Equal(t, configsections.DefaultApplicationLabel, (&configsections.Applications{}).GetGroupingLabel())
+	assert.Equal(t, "app", (&configsections.Applications{GroupingLabel: "app"}).GetGroupingLabel())
+}
+
+func TestApplications_Group(t *testing.T) {
+	pods := []configsections.Pod{
+		{Namespace: "tnf", Name: "du-0", Labels: map[string]string{configsections.DefaultApplicationLabel: "du"}},
+		{Namespace: "tnf", Name: "du-1", Labels: map[string]string{configsections.DefaultApplicationLabel: "du"}},
+		{Namespace: "tnf", Name: "cu-0", Labels: map[string]string{configsections.DefaultApplicationLabel: "cu", "app": "cu-cp"}},
+		{Namespace: "tnf", Name: "other"},
+	}
+	assert.Equal(t, map[string][]string{"du": {"tnf/du-0", "tnf/du-1"}, "cu": {"tnf/cu-0"}},
+		(&configsections.Applications{}).Group(pods))
+	assert.Equal(t, map[string][]string{"cu-cp": {"tnf/cu-0"}}, (&configsections.Applications{GroupingLabel: "app"}).Group(pods))
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+// Bastion is the jump host the cluster under test is only reachable from: the oc and kubectl sessions of the tests run
+// on it over SSH, with its own oc, kubectl and kubeconfig, and the SSH sessions to the nodes jump through it.
+type Bastion struct {
+	// Host is the address of the bastion, no bastion is used when empty.
+	Host string `yaml:"host,omitempty" json:"host,omitempty"`
+	// User is the user of the sessions, the default of the ssh client when empty.
+	User string `yaml:"user,omitempty" json:"user,omitempty"`
+	// Port is the SSH port of the bastion, the default of the ssh client when 0.
+	Port int `yaml:"port,omitempty" json:"port,omitempty"`
+	// KeyFile is the private key authenticating the user, the default keys of the ssh client when empty.  The jumps
+	// through the bastion authenticate with the ssh agent or the ssh client configuration instead.
+	KeyFile string `yaml:"keyFile,omitempty" json:"keyFile,omitempty"`
+	// Jumphost is the host the bastion itself is reached through, as [user@]host[:port], none when empty.
+	Jumphost string `yaml:"jumphost,omitempty" json:"jumphost,omitempty"`
+}
+
+// IsSet returns true when a bastion is configured.
+func (b *Bastion) IsSet() bool {
+	return b != nil && b.Host != ""
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultClaimUploadAttempts   = 3
+	defaultClaimUploadBackoff    = 10 * time.Second
+	defaultClaimUploadMultiplier = 2
+)
+
+// ClaimUpload configures the upload of the finished claim to a results collector, e.g. DCI or a partner portal, with a
+// POST request.  The token is read from the environment variable it names, the configuration being recorded in the
+// claim.
+type ClaimUpload struct {
+	// URL is the endpoint the claim is POSTed to.
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+	// TokenEnv is the environment variable holding the bearer token of the requests, if any.
+	TokenEnv string `yaml:"tokenEnv,omitempty" json:"tokenEnv,omitempty"`
+	// CAFile is a PEM bundle of the certificate authorities trusted in addition to the system ones.
+	CAFile string `yaml:"caFile,omitempty" json:"caFile,omitempty"`
+	// CertFile and KeyFile are the PEM client certificate and key, when the collector requires mutual TLS.
+	CertFile string `yaml:"certFile,omitempty" json:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty" json:"keyFile,omitempty"`
+	// InsecureSkipVerify disables the verification of the certificate of the collector, for test collectors only.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty" json:"insecureSkipVerify,omitempty"`
+	// Retry is the retry policy of the failed uploads, 3 attempts 10s then 20s apart when not set.
+	Retry RetryPolicy `yaml:"retry,omitempty" json:"retry,omitempty"`
+}
+
+// Validate returns an error when the URL is missing or the client certificate is not paired with its key.
+func (c *ClaimUpload) Validate() error {
+	if c.URL == "" {
+		return fmt.Errorf("claim upload has no url")
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("claim upload %s requires both certFile and keyFile", c.URL)
+	}
+	return nil
+}
+
+// GetRetryPolicy returns the retry policy of the uploads.
+func (c *ClaimUpload) GetRetryPolicy() RetryPolicy {
+	if c.Retry.Attempts > 0 {
+		return c.Retry
+	}
+	return RetryPolicy{Attempts: defaultClaimUploadAttempts, Backoff: defaultClaimUploadBackoff,
+		Multiplier: defaultClaimUploadMultiplier}
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestClaimUpload_Validate(t *testing.T) {
+	testCases := []struct {
+		upload configsections.ClaimUpload
+		valid  bool
+	}{
+		{configsections.ClaimUpload{URL: "https://collector.example.com"}, true},
+		{configsections.ClaimUpload{URL: "https://collector.example.com", CertFile: "c.pem", KeyFile: "k.pem"}, true},
+		{configsections.ClaimUpload{URL: "https://collector.example.com", CertFile: "c.pem"}, false},
+		{configsections.ClaimUpload{TokenEnv: "TOKEN"}, false},
+	}
+	for i := range testCases {
+		assert.Equal(t, testCases[i].valid, testCases[i].upload.Validate() == nil, testCases[i].upload)
+	}
+}
+
+func TestClaimUpload_GetRetryPolicy(t *testing.T) {
+	upload := configsections.ClaimUpload{}
+	assert.Equal(t, configsections.RetryPolicy{Attempts: 3, Backoff: 10 * time.Second, Multiplier: 2},
+		upload.GetRetryPolicy())
+	upload.Retry = configsections.RetryPolicy{Attempts: 1}
+	assert.Equal(t, configsections.RetryPolicy{Attempts: 1}, upload.GetRetryPolicy())
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"fmt"
+)
+
+const (
+	// HubClusterRole is the role of the hub cluster of a RAN deployment, managing the spoke clusters.
+	HubClusterRole = "hub"
+	// SpokeClusterRole is the role of the spoke clusters of a RAN deployment.
+	SpokeClusterRole = "spoke"
+)
+
+// Cluster is a cluster which can be tested, reached with a kubeconfig file and one of its contexts.
+type Cluster struct {
+	// Name identifies the cluster in the targetCluster field and the -cluster flag.
+	Name string `yaml:"name" json:"name" required:"true"`
+	// Kubeconfig is the path of the kubeconfig file, on the bastion of the cluster if any, the kubeconfig field of the
+	// configuration when empty.
+	Kubeconfig string `yaml:"kubeconfig,omitempty" json:"kubeconfig,omitempty"`
+	// Context is the kubeconfig context, the kubeconfigContext field of the configuration when empty.
+	Context string `yaml:"context,omitempty" json:"context,omitempty"`
+	// Role is the role of the cluster in a multi-cluster deployment, HubClusterRole or SpokeClusterRole, recorded in
+	// the merged claim of the clusters.
+	Role string `yaml:"role,omitempty" json:"role,omitempty"`
+	// Suites are the suites applicable to the cluster when several clusters are tested, all of them when empty.
+	Suites []string `yaml:"suites,omitempty" json:"suites,omitempty"`
+	// Bastion is the jump host the cluster is reached through, the bastion field of the configuration when nil.
+	Bastion *Bastion `yaml:"bastion,omitempty" json:"bastion,omitempty"`
+}
+
+// RunsSuite returns true when the suite is applicable to the cluster.
+func (c *Cluster) RunsSuite(suite string) bool {
+	if len(c.Suites) == 0 {
+		return true
+	}
+	for _, s := range c.Suites {
+		if s == suite {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectCluster returns the cluster to test:  the cluster named name, or targetCluster when name is empty, with the
+// kubeconfig, kubeconfigContext and bastion fields as defaults.  When neither selects a cluster, the only one of the clusters
+// section is tested, or else the cluster of the kubeconfig and kubeconfigContext fields, whose empty values leave the
+// defaults of oc, i.e. $KUBECONFIG and the current context, unchanged.
+func (c *TestConfiguration) SelectCluster(name string) (*Cluster, error) {
+	if name == "" {
+		name = c.TargetCluster
+	}
+	selected := Cluster{}
+	switch {
+	case name != "":
+		found := false
+		for _, cluster := range c.Clusters {
+			if cluster.Name == name {
+				selected, found = cluster, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no cluster named %q in the clusters section of the configuration", name)
+		}
+	case len(c.Clusters) == 1:
+		selected = c.Clusters[0]
+	case len(c.Clusters) > 1:
+		return nil, fmt.Errorf("%d clusters are configured, select one with targetCluster or -cluster", len(c.Clusters))
+	}
+	if selected.Kubeconfig == "" {
+		selected.Kubeconfig = c.Kubeconfig
+	}
+	if selected.Context == "" {
+		selected.Context = c.KubeconfigContext
+	}
+	if selected.Bastion == nil && c.Bastion.IsSet() {
+		bastion := c.Bastion
+		selected.Bastion = &bastion
+	}
+	return &selected, nil
+}
+
+// SelectClusters returns the clusters named names, or all the clusters of the clusters section when names is empty,
+// with the defaults of SelectCluster.
+func (c *TestConfiguration) SelectClusters(names []string) ([]Cluster, error) {
+	if len(names) == 0 {
+		for i := range c.Clusters {
+			names = append(names, c.Clusters[i].Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no cluster in the clusters section of the configuration")
+	}
+	var clusters []Cluster
+	for _, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("a cluster of the clusters section of the configuration has no name")
+		}
+		cluster, err := c.SelectCluster(name)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, *cluster)
+	}
+	return clusters, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectCluster(t *testing.T) {
+	config := TestConfiguration{Kubeconfig: "/kube/config", KubeconfigContext: "admin"}
+	cluster, err := config.SelectCluster("")
+	assert.Nil(t, err)
+	assert.Equal(t, Cluster{Kubeconfig: "/kube/config", Context: "admin"}, *cluster)
+
+	// the only cluster is selected by default, the kubeconfig fields are its defaults.
+	config.Clusters = []Cluster{{Name: "lab", Context: "lab-admin"}}
+	cluster, err = config.SelectCluster("")
+	assert.Nil(t, err)
+	assert.Equal(t, Cluster{Name: "lab", Kubeconfig: "/kube/config", Context: "lab-admin"}, *cluster)
+
+	config.Clusters = append(config.Clusters, Cluster{Name: "edge", Kubeconfig: "/kube/edge"})
+	_, err = config.SelectCluster("")
+	assert.NotNil(t, err)
+	config.TargetCluster = "edge"
+	cluster, err = config.SelectCluster("")
+	assert.Nil(t, err)
+	assert.Equal(t, Cluster{Name: "edge", Kubeconfig: "/kube/edge", Context: "admin"}, *cluster)
+
+	// the name takes precedence over targetCluster.
+	cluster, err = config.SelectCluster("lab")
+	assert.Nil(t, err)
+	assert.Equal(t, "lab", cluster.Name)
+	_, err = config.SelectCluster("unknown")
+	assert.NotNil(t, err)
+}
+
+func TestSelectClusters(t *testing.T) {
+	config := TestConfiguration{KubeconfigContext: "admin"}
+	_, err := config.SelectClusters(nil)
+	assert.NotNil(t, err)
+
+	config.Clusters = []Cluster{
+		{Name: "hub", Role: HubClusterRole},
+		{Name: "spoke-1", Role: SpokeClusterRole, Context: "spoke-1-admin", Suites: []string{"lifecycle"}},
+	}
+	clusters, err := config.SelectClusters(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []Cluster{
+		{Name: "hub", Role: HubClusterRole, Context: "admin"},
+		{Name: "spoke-1", Role: SpokeClusterRole, Context: "spoke-1-admin", Suites: []string{"lifecycle"}},
+	}, clusters)
+	assert.True(t, clusters[0].RunsSuite("platform-alteration"))
+	assert.True(t, clusters[1].RunsSuite("lifecycle"))
+	assert.False(t, clusters[1].RunsSuite("platform-alteration"))
+
+	clusters, err = config.SelectClusters([]string{"spoke-1"})
+	assert.Nil(t, err)
+	assert.Len(t, clusters, 1)
+	_, err = config.SelectClusters([]string{"spoke-2"})
+	assert.NotNil(t, err)
+}
+
+func TestSelectClusterBastion(t *testing.T) {
+	config := TestConfiguration{Bastion: Bastion{Host: "bastion.example.com", User: "admin"}}
+	cluster, err := config.SelectCluster("")
+	assert.Nil(t, err)
+	assert.Equal(t, &Bastion{Host: "bastion.example.com", User: "admin"}, cluster.Bastion)
+	assert.True(t, cluster.Bastion.IsSet())
+
+	// the bastion of a cluster takes precedence, an empty one reaches the cluster directly.
+	config.Clusters = []Cluster{
+		{Name: "edge", Bastion: &Bastion{Host: "edge-bastion.example.com"}},
+		{Name: "lab", Bastion: &Bastion{}},
+	}
+	cluster, err = config.SelectCluster("edge")
+	assert.Nil(t, err)
+	assert.Equal(t, "edge-bastion.example.com", cluster.Bastion.Host)
+	cluster, err = config.SelectCluster("lab")
+	assert.Nil(t, err)
+	assert.False(t, cluster.Bastion.IsSet())
+
+	config = TestConfiguration{}
+	cluster, err = config.SelectCluster("")
+	assert.Nil(t, err)
+	assert.Nil(t, cluster.Bastion)
+}
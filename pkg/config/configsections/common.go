@@ -19,7 +19,7 @@ package configsections
 // Label ns/name/value for resource lookup
 type Label struct {
 	Prefix string `yaml:"prefix" json:"prefix"`
-	Name   string `yaml:"name" json:"name"`
+	Name   string `yaml:"name" json:"name" required:"true"`
 	Value  string `yaml:"value" json:"value"`
 }
 
@@ -27,22 +27,31 @@ type Label struct {
 type Operator struct {
 
 	// Name is a required field, Name of the csv .
-	Name string `yaml:"name" json:"name"`
+	Name string `yaml:"name" json:"name" required:"true"`
 
 	// Namespace is a required field , namespace is where the csv is installed.
 	// If its all namespace then you can replace it with ALL_NAMESPACE TODO: add check for ALL_NAMESPACE
-	Namespace string `yaml:"namespace" json:"namespace"`
+	Namespace string `yaml:"namespace" json:"namespace" required:"true"`
 
 	// Tests this is list of test that need to run against the operator.
 	Tests []string `yaml:"tests" json:"tests"`
 
 	// Subscription name is required field, Name of used subscription.
 	SubscriptionName string `yaml:"subscriptionName" json:"subscriptionName"`
+
+	// Package is the name of the package of the operator in its catalog, e.g. "nginx-operator".
+	Package string `yaml:"package,omitempty" json:"package,omitempty"`
+
+	// Version is the version of the operator, from its CSV.
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
+
+	// InstallModes are the install modes supported by the operator, from its CSV, e.g. "OwnNamespace".
+	InstallModes []string `yaml:"installModes,omitempty" json:"installModes,omitempty"`
 }
 
 // Namespace struct defines namespace properties
 type Namespace struct {
-	Name string `yaml:"name" json:"name"`
+	Name string `yaml:"name" json:"name" required:"true"`
 }
 
 // TestConfiguration provides test related configuration
@@ -62,6 +71,71 @@ type TestConfiguration struct {
 	CertifiedOperatorInfo []CertifiedOperatorRequestInfo `yaml:"certifiedoperatorinfo,omitempty" json:"certifiedoperatorinfo,omitempty"`
 	// CRDs section.
 	CrdFilters []CrdFilter `yaml:"targetCrdFilters" json:"targetCrdFilters"`
+	// TestGroups tags test cases with owners and groups them into custom suites.
+	TestGroups []TestGroup `yaml:"testGroups,omitempty" json:"testGroups,omitempty"`
+	// Timeouts overrides the default timeout of the tests.
+	Timeouts Timeouts `yaml:"timeouts,omitempty" json:"timeouts,omitempty"`
+	// Retries configures the retry policies of the tests which fail or error.
+	Retries Retries `yaml:"retries,omitempty" json:"retries,omitempty"`
+	// PlatformRequirements are the platform features required by the CNF.
+	PlatformRequirements PlatformRequirements `yaml:"platformRequirements,omitempty" json:"platformRequirements,omitempty"`
+	// DNS configures the DNS resolution test.
+	DNS DNS `yaml:"dns,omitempty" json:"dns,omitempty"`
+	// NodeExposure lists the accepted hostPorts and NodePort Services of the pods under test.
+	NodeExposure NodeExposure `yaml:"nodeExposure,omitempty" json:"nodeExposure,omitempty"`
+	// Egress configures the inventory of the external destinations of the pods under test.
+	Egress Egress `yaml:"egress,omitempty" json:"egress,omitempty"`
+	// WritableLayer configures the writable layer growth test.
+	WritableLayer WritableLayer `yaml:"writableLayer,omitempty" json:"writableLayer,omitempty"`
+	// SecurityContext lists the containers accepted to run with elevated privileges.
+	SecurityContext SecurityContext `yaml:"securityContext,omitempty" json:"securityContext,omitempty"`
+	// ContainerTestSkips opts containers under test out of container test cases, in addition to the
+	// container_test_skips annotations of their pods.
+	ContainerTestSkips ContainerTestSkips `yaml:"containerTestSkips,omitempty" json:"containerTestSkips,omitempty"`
+	// PidsLimit configures the pids limit test.
+	PidsLimit PidsLimit `yaml:"pidsLimit,omitempty" json:"pidsLimit,omitempty"`
+	// ImagePolicy configures the image tag, digest and pull policy checks of the containers under test.
+	ImagePolicy ImagePolicy `yaml:"imagePolicy,omitempty" json:"imagePolicy,omitempty"`
+	// ImageCertification configures the certification check of the images of the containers under test.
+	ImageCertification ImageCertification `yaml:"imageCertification,omitempty" json:"imageCertification,omitempty"`
+	// OperatorCertification configures the certification check of the operators under test in the catalog.
+	OperatorCertification OperatorCertification `yaml:"operatorCertification,omitempty" json:"operatorCertification,omitempty"`
+	// Subscription configures the health check of the OLM subscriptions of the operators under test.
+	Subscription Subscription `yaml:"subscription,omitempty" json:"subscription,omitempty"`
+	// OperatorInstallMode declares the install mode of the operators under test.
+	OperatorInstallMode OperatorInstallMode `yaml:"operatorInstallMode,omitempty" json:"operatorInstallMode,omitempty"`
+	// Helm configures the tests of the Helm releases under test.
+	Helm Helm `yaml:"helm,omitempty" json:"helm,omitempty"`
+	// SELinux lists the containers accepted to run with another SELinux type than container_t.
+	SELinux SELinux `yaml:"selinux,omitempty" json:"selinux,omitempty"`
+	// Applications configures the grouping of the pods under test into applications.
+	Applications Applications `yaml:"applications,omitempty" json:"applications,omitempty"`
+	// DebugDaemonSet configures the deployment of the debug pods running the node commands of the tests.
+	DebugDaemonSet DebugDaemonSet `yaml:"debugDaemonSet,omitempty" json:"debugDaemonSet,omitempty"`
+	// NodeSSH configures the SSH access to the nodes, replacing the debug pods.
+	NodeSSH NodeSSH `yaml:"nodeSSH,omitempty" json:"nodeSSH,omitempty"`
+	// NodeRoles selects the node test cases run on the nodes of some roles, e.g. infra or rt-worker.
+	NodeRoles []NodeRole `yaml:"nodeRoles,omitempty" json:"nodeRoles,omitempty"`
+	// OutputSinks are the destinations of the claim and of the JUnit reports, in addition to the local files.
+	OutputSinks []OutputSink `yaml:"outputSinks,omitempty" json:"outputSinks,omitempty"`
+	// ClaimUpload configures the upload of the finished claim to a results collector.
+	ClaimUpload ClaimUpload `yaml:"claimUpload,omitempty" json:"claimUpload,omitempty"`
+	// ProgressEvents configures the streaming of the lifecycle events of the tests to a webhook or a file.
+	ProgressEvents ProgressEvents `yaml:"progressEvents,omitempty" json:"progressEvents,omitempty"`
+	// Metrics configures the Prometheus metrics endpoint and Pushgateway of the run.
+	Metrics Metrics `yaml:"metrics,omitempty" json:"metrics,omitempty"`
+	// Kubeconfig is the path of the kubeconfig file of the cluster under test, $KUBECONFIG when empty.
+	Kubeconfig string `yaml:"kubeconfig,omitempty" json:"kubeconfig,omitempty"`
+	// KubeconfigContext is the kubeconfig context of the cluster under test, the current context when empty.
+	KubeconfigContext string `yaml:"kubeconfigContext,omitempty" json:"kubeconfigContext,omitempty"`
+	// Clusters are the clusters which can be tested, the one to test is selected by TargetCluster.
+	Clusters []Cluster `yaml:"clusters,omitempty" json:"clusters,omitempty"`
+	// TargetCluster is the name of the cluster to test among Clusters.
+	TargetCluster string `yaml:"targetCluster,omitempty" json:"targetCluster,omitempty"`
+	// Bastion is the jump host the cluster under test is reached through, none unless set.
+	Bastion Bastion `yaml:"bastion,omitempty" json:"bastion,omitempty"`
+	// LogLevel is the log level of the test suites, e.g. info, LOG_LEVEL overrides it, debug when both are unset.
+	LogLevel string `yaml:"logLevel,omitempty" json:"logLevel,omitempty"`
 }
 
 // TestPartner contains the helper containers that can be used to facilitate tests
@@ -78,6 +152,8 @@ type TestPartner struct {
 type TestTarget struct {
 	// DeploymentsUnderTest is the list of deployments that contain pods under test.
 	DeploymentsUnderTest []Deployment `yaml:"deploymentsUnderTest" json:"deploymentsUnderTest"`
+	// StatefulSetsUnderTest is the list of statefulsets that contain pods under test.
+	StatefulSetsUnderTest []StatefulSet `yaml:"statefulSetsUnderTest,omitempty" json:"statefulSetsUnderTest,omitempty"`
 	// PodsUnderTest is the list of the pods that needs to be tested. Each entry is a single pod to be tested.
 	PodsUnderTest []Pod `yaml:"podsUnderTest,omitempty" json:"podsUnderTest,omitempty"`
 	// ContainerConfigList is the list of containers that needs to be tested.
@@ -88,4 +164,18 @@ type TestTarget struct {
 	Operators []Operator `yaml:"operators,omitempty"  json:"operators,omitempty"`
 	// Node list
 	Nodes map[string]Node `yaml:"Nodes"  json:"Nodes"`
+	// NetworkAttachmentDefinitions are the Multus networks defined in the target namespace.
+	NetworkAttachmentDefinitions []NetworkAttachmentDefinition `yaml:"networkAttachmentDefinitions,omitempty" json:"networkAttachmentDefinitions,omitempty"`
+	// Services are the Services defined in the target namespace.
+	Services []Service `yaml:"services,omitempty" json:"services,omitempty"`
+	// NetworkPolicies are the NetworkPolicies defined in the target namespace.
+	NetworkPolicies []NetworkPolicy `yaml:"networkPolicies,omitempty" json:"networkPolicies,omitempty"`
+	// PodDisruptionBudgets are the PodDisruptionBudgets defined in the target namespace.
+	PodDisruptionBudgets []PodDisruptionBudget `yaml:"podDisruptionBudgets,omitempty" json:"podDisruptionBudgets,omitempty"`
+	// RoleBindings are the RoleBindings and ClusterRoleBindings granting a role to the service accounts of the pods.
+	RoleBindings []RoleBinding `yaml:"roleBindings,omitempty" json:"roleBindings,omitempty"`
+	// Roles are the Roles and ClusterRoles granted by RoleBindings.
+	Roles []Role `yaml:"roles,omitempty" json:"roles,omitempty"`
+	// HelmReleases are the latest revisions of the Helm releases installed in the target namespace.
+	HelmReleases []HelmRelease `yaml:"helmReleases,omitempty" json:"helmReleases,omitempty"`
 }
@@ -31,4 +31,8 @@ type ContainerConfig struct {
 	DefaultNetworkDevice string `yaml:"defaultNetworkDevice" json:"defaultNetworkDevice"`
 	// MultusIPAddresses are the overlay IPs.
 	MultusIPAddresses []string `yaml:"multusIpAddresses" json:"multusIpAddresses"`
+	// PodIPAddresses are the IPs of the pod on the cluster network, one per address family.
+	PodIPAddresses []string `yaml:"podIpAddresses" json:"podIpAddresses"`
+	// NetworkAttachments are the secondary (Multus) network interfaces of the pod.
+	NetworkAttachments []NetworkAttachment `yaml:"networkAttachments,omitempty" json:"networkAttachments,omitempty"`
 }
@@ -0,0 +1,34 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import "path"
+
+// ContainerTestSkips opts containers under test out of container test cases, e.g. the sidecars injected in the pods
+// of the CNF.  The containers are listed by test case ID, e.g. "lifecycle-liveness-probe", and matched as
+// "namespace/pod/container"; both may be shell patterns, e.g. "*": ["tnf/*/istio-proxy"].
+type ContainerTestSkips map[string][]string
+
+// Skips returns true when the container is opted out of the test case testID.
+func (s ContainerTestSkips) Skips(testID string, container *ContainerIdentifier) bool {
+	for pattern, containers := range s {
+		if matched, err := path.Match(pattern, testID); err == nil && matched && matchesContainer(containers, container) {
+			return true
+		}
+	}
+	return false
+}
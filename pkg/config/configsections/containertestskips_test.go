@@ -0,0 +1,45 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestContainerTestSkips_Skips(t *testing.T) {
+	skips := configsections.ContainerTestSkips{
+		"lifecycle-*-probe":       {"tnf/*/istio-proxy"},
+		"platform-alteration-*":   {"tnf/test-0/logger"},
+		"lifecycle-image-policy[": {"tnf/*/*"},
+	}
+	sidecar := &configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test-1", ContainerName: "istio-proxy"}
+	logger := &configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test-0", ContainerName: "logger"}
+	other := &configsections.ContainerIdentifier{Namespace: "other", PodName: "test-1", ContainerName: "istio-proxy"}
+
+	assert.True(t, skips.Skips("lifecycle-liveness-probe", sidecar))
+	assert.True(t, skips.Skips("lifecycle-readiness-probe", sidecar))
+	assert.False(t, skips.Skips("lifecycle-container-shutdown", sidecar))
+	assert.False(t, skips.Skips("lifecycle-liveness-probe", other))
+	assert.True(t, skips.Skips("platform-alteration-base-image", logger))
+	assert.False(t, skips.Skips("platform-alteration-base-image", sidecar))
+	// malformed patterns match nothing
+	assert.False(t, skips.Skips("lifecycle-image-policy", logger))
+	assert.False(t, configsections.ContainerTestSkips(nil).Skips("lifecycle-liveness-probe", sidecar))
+}
@@ -13,11 +13,90 @@
 // You should have received a copy of the GNU General Public License along
 // with this program; if not, write to the Free Software Foundation, Inc.,
 // 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
-
 package configsections
 
-// CrdFilter defines a CustomResourceDefinition config filter.
+import "strings"
+
+const (
+	// CrdScopeNamespaced is the scope of the CRDs whose custom resources belong to a namespace.
+	CrdScopeNamespaced = "Namespaced"
+	// CrdScopeCluster is the scope of the CRDs whose custom resources are cluster-wide.
+	CrdScopeCluster = "Cluster"
+)
+
+// Crd is the metadata of a CustomResourceDefinition of the cluster, matched by the CrdFilters.
+type Crd struct {
+	Name   string `json:"name"`
+	Group  string `json:"group"`
+	Plural string `json:"plural"`
+	// Versions are the served versions of the CRD, e.g. "v1alpha1".
+	Versions []string `json:"versions"`
+	// StatusVersions and ScaleVersions are the served versions defining the status and the scale subresources.
+	StatusVersions []string `json:"statusVersions"`
+	ScaleVersions  []string `json:"scaleVersions"`
+	// Scope is CrdScopeNamespaced or CrdScopeCluster.
+	Scope string `json:"scope"`
+}
+
+// Resource returns the resource type of the custom resources of the CRD for oc, e.g. "foos.example.com".
+func (c *Crd) Resource() string {
+	return c.Plural + "." + c.Group
+}
+
+// HasStatus returns true when the served version of the CRD defines the status subresource.
+func (c *Crd) HasStatus(version string) bool {
+	return contains(c.StatusVersions, version)
+}
+
+// HasScale returns true when the served version of the CRD defines the scale subresource.
+func (c *Crd) HasScale(version string) bool {
+	return contains(c.ScaleVersions, version)
+}
+
+// CrdFilter defines a CustomResourceDefinition config filter.  A CRD matches the filter when it matches all the fields
+// which are set, a filter without fields matches all the CRDs.
 type CrdFilter struct {
-	NameSuffix string `yaml:"nameSuffix" json:"nameSuffix"`
+	NameSuffix string `yaml:"nameSuffix" json:"nameSuffix" required:"true"`
+	// Group is the API group of the CRDs, e.g. "tnf.example.com".
+	Group string `yaml:"group,omitempty" json:"group,omitempty"`
+	// Version is a version the CRDs serve, e.g. "v1".
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
+	// Scope is the scope of the CRDs, CrdScopeNamespaced or CrdScopeCluster.
+	Scope string `yaml:"scope,omitempty" json:"scope,omitempty"`
+	// Scalable declares the custom resources of the CRDs matching the filter scalable, their CRDs must then define a
+	// scale subresource.
+	Scalable bool `yaml:"scalable,omitempty" json:"scalable,omitempty"`
 	// labels []Label
 }
+
+// Matches returns true when the CRD matches the filter.
+func (f *CrdFilter) Matches(crd *Crd) bool {
+	if !strings.HasSuffix(crd.Name, f.NameSuffix) {
+		return false
+	}
+	if f.Group != "" && f.Group != crd.Group {
+		return false
+	}
+	if f.Scope != "" && !strings.EqualFold(f.Scope, crd.Scope) {
+		return false
+	}
+	if f.Version == "" {
+		return true
+	}
+	for _, version := range crd.Versions {
+		if version == f.Version {
+			return true
+		}
+	}
+	return false
+}
+
+// IsScalableCrd returns true when the CRD matches a filter declaring its custom resources scalable.
+func IsScalableCrd(filters []CrdFilter, crd *Crd) bool {
+	for i := range filters {
+		if filters[i].Scalable && filters[i].Matches(crd) {
+			return true
+		}
+	}
+	return false
+}
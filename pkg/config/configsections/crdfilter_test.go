@@ -0,0 +1,53 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestCrdFilter_Matches(t *testing.T) {
+	crd := configsections.Crd{Name: "crdexamples.tnf.example.com", Group: "tnf.example.com",
+		Versions: []string{"v1alpha1", "v1"}, Scope: configsections.CrdScopeNamespaced}
+	assert.True(t, (&configsections.CrdFilter{}).Matches(&crd))
+	assert.True(t, (&configsections.CrdFilter{NameSuffix: "example.com"}).Matches(&crd))
+	assert.False(t, (&configsections.CrdFilter{NameSuffix: "other.com"}).Matches(&crd))
+	assert.True(t, (&configsections.CrdFilter{Group: "tnf.example.com", Version: "v1"}).Matches(&crd))
+	assert.False(t, (&configsections.CrdFilter{Group: "example.com"}).Matches(&crd))
+	assert.False(t, (&configsections.CrdFilter{Version: "v2"}).Matches(&crd))
+	assert.True(t, (&configsections.CrdFilter{Scope: "namespaced"}).Matches(&crd))
+	assert.False(t, (&configsections.CrdFilter{NameSuffix: "example.com", Scope: configsections.CrdScopeCluster}).Matches(&crd))
+}
+
+func TestIsScalableCrd(t *testing.T) {
+	crd := configsections.Crd{Name: "crdexamples.tnf.example.com", Group: "tnf.example.com", Versions: []string{"v1"}}
+	filters := []configsections.CrdFilter{{NameSuffix: "example.com"}, {Group: "other.example.com", Scalable: true}}
+	assert.False(t, configsections.IsScalableCrd(filters, &crd))
+	filters = append(filters, configsections.CrdFilter{Group: "tnf.example.com", Scalable: true})
+	assert.True(t, configsections.IsScalableCrd(filters, &crd))
+}
+
+func TestCrd_Subresources(t *testing.T) {
+	crd := configsections.Crd{Name: "crdexamples.tnf.example.com", Group: "tnf.example.com", Plural: "crdexamples",
+		Versions: []string{"v1alpha1", "v1"}, StatusVersions: []string{"v1alpha1", "v1"}, ScaleVersions: []string{"v1"}}
+	assert.Equal(t, "crdexamples.tnf.example.com", crd.Resource())
+	assert.True(t, crd.HasStatus("v1alpha1"))
+	assert.False(t, crd.HasScale("v1alpha1"))
+	assert.True(t, crd.HasScale("v1"))
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+// DebugDaemonSet configures the debug daemonset, whose privileged pods run the node commands of the tests.  It is
+// deployed at the start of the run unless already present, and then deleted at the end of the run.
+type DebugDaemonSet struct {
+	// SkipDeploy expects the debug daemonset to be deployed beforehand, e.g. with the partner pods.
+	SkipDeploy bool `yaml:"skipDeploy,omitempty" json:"skipDeploy,omitempty"`
+	// Image of the debug pods, the debug-partner image of the partner repository by default.
+	Image string `yaml:"image,omitempty" json:"image,omitempty"`
+	// Tolerations of the debug pods, all the taints are tolerated by default so that they run on any node.
+	Tolerations []Toleration `yaml:"tolerations,omitempty" json:"tolerations,omitempty"`
+}
+
+// Toleration lets a pod run on the nodes with a matching taint.
+type Toleration struct {
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
+	// Operator is Exists or Equal.
+	Operator string `yaml:"operator,omitempty" json:"operator,omitempty"`
+	Value    string `yaml:"value,omitempty" json:"value,omitempty"`
+	// Effect is NoSchedule, PreferNoSchedule or NoExecute, all of them when empty.
+	Effect string `yaml:"effect,omitempty" json:"effect,omitempty"`
+}
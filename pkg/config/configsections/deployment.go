@@ -21,4 +21,15 @@ type Deployment struct {
 	Name      string
 	Namespace string
 	Replicas  int
+	// PodLabels are the labels of the pod template, matched by the PodDisruptionBudget selectors.
+	PodLabels map[string]string
+}
+
+// StatefulSet defines a statefulset in the cluster.
+type StatefulSet struct {
+	Name      string
+	Namespace string
+	Replicas  int
+	// PodLabels are the labels of the pod template, matched by the PodDisruptionBudget selectors.
+	PodLabels map[string]string
 }
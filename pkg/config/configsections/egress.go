@@ -0,0 +1,113 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"net"
+	"strings"
+	"time"
+)
+
+// DefaultEgressWindow is how long the traffic of the pods under test is observed by the egress inventory, unless
+// configured.
+const DefaultEgressWindow = 30 * time.Second
+
+// EgressDestination is an external destination the pods under test are allowed to reach.
+type EgressDestination struct {
+	// CIDR is the network of the destination, e.g. "203.0.113.0/24", or a single address.
+	CIDR string `yaml:"cidr" json:"cidr"`
+	// Ports are the allowed destination ports, any port when empty.
+	Ports []int `yaml:"ports,omitempty" json:"ports,omitempty"`
+	// Protocol is the allowed protocol, e.g. "tcp", any protocol when empty.
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+}
+
+// Allows returns true when the destination covers a connection to address and port over protocol.
+func (d *EgressDestination) Allows(protocol string, address net.IP, port int) bool {
+	if d.Protocol != "" && !strings.EqualFold(d.Protocol, protocol) {
+		return false
+	}
+	if len(d.Ports) > 0 && !containsPort(d.Ports, port) {
+		return false
+	}
+	return inNetwork(d.CIDR, address)
+}
+
+// Egress configures the inventory of the external destinations the pods under test reach.
+type Egress struct {
+	// Window is how long the traffic is observed, DefaultEgressWindow when not set.
+	Window time.Duration `yaml:"window,omitempty" json:"window,omitempty"`
+	// InternalNetworks are the networks, as CIDRs, which are not external besides the cluster and service networks,
+	// e.g. the network of the nodes.
+	InternalNetworks []string `yaml:"internalNetworks,omitempty" json:"internalNetworks,omitempty"`
+	// AllowList are the external destinations the pods under test are allowed to reach, none when empty.
+	AllowList []EgressDestination `yaml:"allowList,omitempty" json:"allowList,omitempty"`
+}
+
+// GetWindow returns how long the traffic is observed.
+func (e *Egress) GetWindow() time.Duration {
+	if e.Window == 0 {
+		return DefaultEgressWindow
+	}
+	return e.Window
+}
+
+// IsExternal returns true when address is outside of clusterNetworks, e.g. the cluster and service networks, and of
+// the internal networks.  The loopback, link-local and multicast addresses are not external.
+func (e *Egress) IsExternal(address net.IP, clusterNetworks []string) bool {
+	if address.IsLoopback() || address.IsLinkLocalUnicast() || address.IsMulticast() || address.IsUnspecified() {
+		return false
+	}
+	for _, networks := range [][]string{clusterNetworks, e.InternalNetworks} {
+		for _, network := range networks {
+			if inNetwork(network, address) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Allows returns true when a destination of the allow list covers a connection to address and port over protocol.
+func (e *Egress) Allows(protocol string, address net.IP, port int) bool {
+	for i := range e.AllowList {
+		if e.AllowList[i].Allows(protocol, address, port) {
+			return true
+		}
+	}
+	return false
+}
+
+// inNetwork returns true when address is in network, a CIDR or a single address.  An invalid network contains no
+// address.
+func inNetwork(network string, address net.IP) bool {
+	if !strings.Contains(network, "/") {
+		ip := net.ParseIP(network)
+		return ip != nil && ip.Equal(address)
+	}
+	_, ipNet, err := net.ParseCIDR(network)
+	return err == nil && ipNet.Contains(address)
+}
+
+func containsPort(ports []int, port int) bool {
+	for _, p := range ports {
+		if p == port {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestEgress_GetWindow(t *testing.T) {
+	assert.Equal(t, configsections.DefaultEgressWindow, (&configsections.Egress{}).GetWindow())
+	assert.Equal(t, time.Minute, (&configsections.Egress{Window: time.Minute}).GetWindow())
+}
+
+func TestEgress_IsExternal(t *testing.T) {
+	egress := configsections.Egress{InternalNetworks: []string{"192.168.1.0/24", "198.51.100.7", "invalid/cidr"}}
+	clusterNetworks := []string{"10.128.0.0/14", "172.30.0.0/16", "fd01::/48"}
+	for address, external := range map[string]bool{
+		"10.128.2.5":    false,
+		"172.30.0.1":    false,
+		"fd01::2":       false,
+		"192.168.1.20":  false,
+		"198.51.100.7":  false,
+		"127.0.0.1":     false,
+		"169.254.0.1":   false,
+		"224.0.0.251":   false,
+		"198.51.100.8":  true,
+		"203.0.113.10":  true,
+		"2001:db8::1":   true,
+		"10.0.0.1":      true,
+		"192.168.2.20":  true,
+		"172.31.255.10": true,
+	} {
+		assert.Equal(t, external, egress.IsExternal(net.ParseIP(address), clusterNetworks), address)
+	}
+}
+
+func TestEgress_Allows(t *testing.T) {
+	egress := configsections.Egress{AllowList: []configsections.EgressDestination{
+		{CIDR: "203.0.113.0/24", Ports: []int{443}, Protocol: "tcp"},
+		{CIDR: "198.51.100.53", Protocol: "UDP"},
+		{CIDR: "2001:db8::/32"},
+	}}
+	assert.True(t, egress.Allows("tcp", net.ParseIP("203.0.113.10"), 443))
+	assert.False(t, egress.Allows("tcp", net.ParseIP("203.0.113.10"), 80))
+	assert.False(t, egress.Allows("udp", net.ParseIP("203.0.113.10"), 443))
+	assert.True(t, egress.Allows("udp", net.ParseIP("198.51.100.53"), 53))
+	assert.False(t, egress.Allows("tcp", net.ParseIP("198.51.100.53"), 53))
+	assert.True(t, egress.Allows("icmp", net.ParseIP("2001:db8::1"), 0))
+	assert.False(t, egress.Allows("tcp", net.ParseIP("192.0.2.1"), 443))
+	assert.False(t, (&configsections.Egress{}).Allows("tcp", net.ParseIP("203.0.113.10"), 443))
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// imageValueKeys are the last elements of the values keys referencing an image, e.g. "image.tag".
+var imageValueKeys = map[string]bool{
+	"image":      true,
+	"images":     true,
+	"registry":   true,
+	"repository": true,
+	"tag":        true,
+	"digest":     true,
+}
+
+// HelmRelease is the latest revision of a release of a Helm chart installed in the target namespace.
+type HelmRelease struct {
+	Namespace string `yaml:"namespace" json:"namespace"`
+	Name      string `yaml:"name" json:"name"`
+	Revision  int    `yaml:"revision" json:"revision"`
+	// Status is the status of the revision, e.g. "deployed" or "failed".
+	Status       string `yaml:"status" json:"status"`
+	Chart        string `yaml:"chart" json:"chart"`
+	ChartVersion string `yaml:"chartVersion" json:"chartVersion"`
+	AppVersion   string `yaml:"appVersion,omitempty" json:"appVersion,omitempty"`
+	// Home and Sources are the URLs of the project and of the sources of the chart.
+	Home    string   `yaml:"home,omitempty" json:"home,omitempty"`
+	Sources []string `yaml:"sources,omitempty" json:"sources,omitempty"`
+	// OverriddenKeys are the keys of the values the release overrides, i.e. passed to helm with --set or --values,
+	// flattened and sorted, e.g. "image.tag", see GetValueKeys.  The values themselves, which may hold secrets, are not
+	// recorded.
+	OverriddenKeys []string `yaml:"overriddenKeys,omitempty" json:"overriddenKeys,omitempty"`
+}
+
+// FullName returns the release name prefixed with its namespace.
+func (h *HelmRelease) FullName() string {
+	return h.Namespace + "/" + h.Name
+}
+
+// GetProvenanceIssues returns why the chart of the release cannot be traced back to its sources, empty when it can: the
+// chart must declare its sources or its home, and the version of the application it deploys.
+func (h *HelmRelease) GetProvenanceIssues() []string {
+	var issues []string
+	if len(h.Sources) == 0 && h.Home == "" {
+		issues = append(issues, fmt.Sprintf("the chart %s declares neither sources nor home", h.Chart))
+	}
+	if h.AppVersion == "" {
+		issues = append(issues, fmt.Sprintf("the chart %s declares no appVersion", h.Chart))
+	}
+	return issues
+}
+
+// GetValueKeys returns the keys of the leaves of Helm values, flattened and sorted, e.g. "image.tag".
+func GetValueKeys(values map[string]interface{}) []string {
+	var keys []string
+	flattenValues("", values, &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// GetOverriddenImageKeys returns the keys of the values the release overrides which reference an image, e.g.
+// "image.tag", and which are not allowed by one of the allowed patterns, e.g. "*.tag".
+func (h *HelmRelease) GetOverriddenImageKeys(allowed []string) []string {
+	var keys []string
+	for _, key := range h.OverriddenKeys {
+		if imageValueKeys[key[strings.LastIndex(key, ".")+1:]] && !matchesAny(allowed, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Helm configures the tests of the Helm releases under test.
+type Helm struct {
+	// AllowedImageOverrides are the keys of the values referencing an image the releases may override, where each
+	// element may be a shell pattern, e.g. "sidecar.image.tag" or "*.tag".
+	AllowedImageOverrides []string `yaml:"allowedImageOverrides,omitempty" json:"allowedImageOverrides,omitempty"`
+}
+
+// flattenValues appends the dotted keys of the leaves of values to keys, prefixed with prefix.
+func flattenValues(prefix string, values map[string]interface{}, keys *[]string) {
+	for name, value := range values {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenValues(key, nested, keys)
+			continue
+		}
+		*keys = append(*keys, key)
+	}
+}
+
+// matchesAny returns true when one of the shell patterns matches name.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
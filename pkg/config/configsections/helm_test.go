@@ -0,0 +1,49 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestHelmRelease_GetProvenanceIssues(t *testing.T) {
+	release := configsections.HelmRelease{Chart: "cnf", AppVersion: "1.2.0", Sources: []string{"https://github.com/example/cnf"}}
+	assert.Empty(t, release.GetProvenanceIssues())
+	release = configsections.HelmRelease{Chart: "cnf", AppVersion: "1.2.0", Home: "https://example.com/cnf"}
+	assert.Empty(t, release.GetProvenanceIssues())
+	release = configsections.HelmRelease{Chart: "cnf"}
+	assert.Len(t, release.GetProvenanceIssues(), 2)
+}
+
+func TestGetValueKeys(t *testing.T) {
+	assert.Equal(t, []string{"image.repository", "image.tag", "replicaCount", "sidecar.image", "sidecar.resources"},
+		configsections.GetValueKeys(map[string]interface{}{
+			"replicaCount": 3,
+			"image":        map[string]interface{}{"repository": "quay.io/example/cnf", "tag": "1.2.1"},
+			"sidecar":      map[string]interface{}{"image": "quay.io/example/sidecar:1.0", "resources": map[string]interface{}{}},
+		}))
+	assert.Empty(t, configsections.GetValueKeys(nil))
+}
+
+func TestHelmRelease_GetOverriddenImageKeys(t *testing.T) {
+	release := configsections.HelmRelease{OverriddenKeys: []string{"image.repository", "image.tag", "replicaCount",
+		"sidecar.image", "sidecar.resources"}}
+	assert.Equal(t, []string{"image.repository", "image.tag", "sidecar.image"}, release.GetOverriddenImageKeys(nil))
+	assert.Equal(t, []string{"image.repository"}, release.GetOverriddenImageKeys([]string{"*.tag", "sidecar.*"}))
+}
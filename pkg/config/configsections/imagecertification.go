@@ -0,0 +1,23 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+// ImageCertification configures the certification check of the images of the containers under test.
+type ImageCertification struct {
+	// OfflineCatalog is the path of a dump of the images of the Red Hat container catalog, checked instead of querying
+	// the catalog API, e.g. in a disconnected environment.
+	OfflineCatalog string `yaml:"offlineCatalog,omitempty" json:"offlineCatalog,omitempty"`
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+// ImagePolicyAction is what the image policy test does with the containers breaking one of the image policies.
+type ImagePolicyAction string
+
+const (
+	// ImagePolicyFail fails the containers breaking the policy.
+	ImagePolicyFail ImagePolicyAction = "fail"
+	// ImagePolicyWarn only logs a warning for the containers breaking the policy.
+	ImagePolicyWarn ImagePolicyAction = "warn"
+	// ImagePolicyIgnore does not check the policy.
+	ImagePolicyIgnore ImagePolicyAction = "ignore"
+
+	// DefaultLatestTagAction, DefaultMissingDigestAction and DefaultPullPolicyAction are the actions of the image
+	// policies, unless configured.
+	DefaultLatestTagAction     = ImagePolicyFail
+	DefaultMissingDigestAction = ImagePolicyWarn
+	DefaultPullPolicyAction    = ImagePolicyFail
+)
+
+// ImagePolicy configures the action of each policy of the image policy test on the containers under test.
+type ImagePolicy struct {
+	// LatestTag applies to the images referenced by the latest tag, or by neither a tag nor a digest.
+	LatestTag ImagePolicyAction `yaml:"latestTag,omitempty" json:"latestTag,omitempty"`
+	// MissingDigest applies to the images not referenced by digest.
+	MissingDigest ImagePolicyAction `yaml:"missingDigest,omitempty" json:"missingDigest,omitempty"`
+	// PullPolicy applies to the containers whose imagePullPolicy does not match their image reference.
+	PullPolicy ImagePolicyAction `yaml:"pullPolicy,omitempty" json:"pullPolicy,omitempty"`
+}
+
+// GetLatestTagAction returns the action on the images referenced by the latest tag.
+func (i *ImagePolicy) GetLatestTagAction() ImagePolicyAction {
+	return actionOrDefault(i.LatestTag, DefaultLatestTagAction)
+}
+
+// GetMissingDigestAction returns the action on the images not referenced by digest.
+func (i *ImagePolicy) GetMissingDigestAction() ImagePolicyAction {
+	return actionOrDefault(i.MissingDigest, DefaultMissingDigestAction)
+}
+
+// GetPullPolicyAction returns the action on the containers whose imagePullPolicy does not match their image reference.
+func (i *ImagePolicy) GetPullPolicyAction() ImagePolicyAction {
+	return actionOrDefault(i.PullPolicy, DefaultPullPolicyAction)
+}
+
+// actionOrDefault returns action, or defaultAction when action is not set or unknown.
+func actionOrDefault(action, defaultAction ImagePolicyAction) ImagePolicyAction {
+	switch action {
+	case ImagePolicyFail, ImagePolicyWarn, ImagePolicyIgnore:
+		return action
+	default:
+		return defaultAction
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestImagePolicy_Defaults(t *testing.T) {
+	imagePolicy := configsections.ImagePolicy{}
+	assert.Equal(t, configsections.DefaultLatestTagAction, imagePolicy.GetLatestTagAction())
+	assert.Equal(t, configsections.DefaultMissingDigestAction, imagePolicy.GetMissingDigestAction())
+	assert.Equal(t, configsections.DefaultPullPolicyAction, imagePolicy.GetPullPolicyAction())
+}
+
+func TestImagePolicy_Actions(t *testing.T) {
+	imagePolicy := configsections.ImagePolicy{
+		LatestTag:     configsections.ImagePolicyWarn,
+		MissingDigest: configsections.ImagePolicyFail,
+		PullPolicy:    "skip",
+	}
+	assert.Equal(t, configsections.ImagePolicyWarn, imagePolicy.GetLatestTagAction())
+	assert.Equal(t, configsections.ImagePolicyFail, imagePolicy.GetMissingDigestAction())
+	// unknown action.
+	assert.Equal(t, configsections.DefaultPullPolicyAction, imagePolicy.GetPullPolicyAction())
+}
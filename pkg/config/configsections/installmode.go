@@ -0,0 +1,59 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+// The install modes of an operator, see its CSV and its OperatorGroup.
+const (
+	// InstallModeOwnNamespace watches the namespace of the operator only.
+	InstallModeOwnNamespace = "OwnNamespace"
+	// InstallModeSingleNamespace watches a single namespace, other than the namespace of the operator.
+	InstallModeSingleNamespace = "SingleNamespace"
+	// InstallModeMultiNamespace watches several namespaces.
+	InstallModeMultiNamespace = "MultiNamespace"
+	// InstallModeAllNamespaces watches all the namespaces of the cluster.
+	InstallModeAllNamespaces = "AllNamespaces"
+)
+
+// OperatorInstallMode declares the install mode the operators under test are deployed with.
+type OperatorInstallMode struct {
+	// Target is the install mode the operators under test must support, e.g. "AllNamespaces".  Not checked when empty.
+	Target string `yaml:"target,omitempty" json:"target,omitempty"`
+}
+
+// SupportsInstallMode returns true when the CSV of the operator supports the install mode.
+func (o *Operator) SupportsInstallMode(mode string) bool {
+	for _, supported := range o.InstallModes {
+		if supported == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// GetInstallMode returns the install mode of an operator installed in namespace, from the target namespaces of its
+// OperatorGroup: AllNamespaces when they are empty, OwnNamespace when they are namespace only.
+func GetInstallMode(namespace string, targetNamespaces []string) string {
+	switch {
+	case len(targetNamespaces) == 0:
+		return InstallModeAllNamespaces
+	case len(targetNamespaces) > 1:
+		return InstallModeMultiNamespace
+	case targetNamespaces[0] == namespace:
+		return InstallModeOwnNamespace
+	default:
+		return InstallModeSingleNamespace
+	}
+}
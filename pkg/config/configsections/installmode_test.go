@@ -0,0 +1,36 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestOperator_SupportsInstallMode(t *testing.T) {
+	operator := configsections.Operator{InstallModes: []string{configsections.InstallModeOwnNamespace}}
+	assert.True(t, operator.SupportsInstallMode(configsections.InstallModeOwnNamespace))
+	assert.False(t, operator.SupportsInstallMode(configsections.InstallModeAllNamespaces))
+}
+
+func TestGetInstallMode(t *testing.T) {
+	assert.Equal(t, configsections.InstallModeAllNamespaces, configsections.GetInstallMode("tnf", nil))
+	assert.Equal(t, configsections.InstallModeOwnNamespace, configsections.GetInstallMode("tnf", []string{"tnf"}))
+	assert.Equal(t, configsections.InstallModeSingleNamespace, configsections.GetInstallMode("tnf", []string{"cnf"}))
+	assert.Equal(t, configsections.InstallModeMultiNamespace, configsections.GetInstallMode("tnf", []string{"tnf", "cnf"}))
+}
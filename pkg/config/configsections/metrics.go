@@ -0,0 +1,42 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+import "time"
+
+// DefaultMetricsJob is the job of the metrics pushed to a Pushgateway when not configured.
+const DefaultMetricsJob = "tnf"
+
+// Metrics configures the Prometheus metrics of a run: the tests per suite and state, the duration of the suites and
+// the sizes of the discovery.
+type Metrics struct {
+	// ListenAddress is the address of the HTTP endpoint serving the metrics at /metrics during the run, e.g. ":9100".
+	ListenAddress string `yaml:"listenAddress,omitempty" json:"listenAddress,omitempty"`
+	// Linger keeps the endpoint serving the final metrics for that long after the run, e.g. "10m".
+	Linger time.Duration `yaml:"linger,omitempty" json:"linger,omitempty"`
+	// PushgatewayURL is the Pushgateway the final metrics are pushed to at the end of the run, if any.
+	PushgatewayURL string `yaml:"pushgatewayURL,omitempty" json:"pushgatewayURL,omitempty"`
+	// Job is the job the metrics are pushed under, DefaultMetricsJob when not set.
+	Job string `yaml:"job,omitempty" json:"job,omitempty"`
+}
+
+// GetJob returns the job the metrics are pushed under.
+func (m *Metrics) GetJob() string {
+	if m.Job == "" {
+		return DefaultMetricsJob
+	}
+	return m.Job
+}
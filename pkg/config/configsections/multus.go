@@ -0,0 +1,40 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+// NetworkAttachment is a secondary (Multus) network interface of a container.
+type NetworkAttachment struct {
+	// Network is the NetworkAttachmentDefinition of the interface, as "namespace/name".
+	Network string `yaml:"network" json:"network"`
+	// Interface is the name of the interface in the pod, e.g. "net1".
+	Interface string `yaml:"interface,omitempty" json:"interface,omitempty"`
+	// IPAddresses are the addresses of the interface.
+	IPAddresses []string `yaml:"ipAddresses,omitempty" json:"ipAddresses,omitempty"`
+}
+
+// NetworkAttachmentDefinition is a Multus network the pods under test can be attached to.
+type NetworkAttachmentDefinition struct {
+	Namespace string `yaml:"namespace" json:"namespace"`
+	Name      string `yaml:"name" json:"name"`
+	// Type is the type of the CNI plugin of the network, e.g. "macvlan" or "sriov".
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+}
+
+// FullName returns the name of the network as referenced by the pods and the network attachments, "namespace/name".
+func (n *NetworkAttachmentDefinition) FullName() string {
+	return n.Namespace + "/" + n.Name
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import "strconv"
+
+// NodeExposure lists the exposures of the pods under test on the node ports which are accepted, e.g. the monitoring
+// agents which must be reached on each node.
+type NodeExposure struct {
+	// AllowedHostPorts are the accepted hostPorts, as "port/protocol", e.g. "9100/TCP", or as "port" for TCP.
+	AllowedHostPorts []string `yaml:"allowedHostPorts,omitempty" json:"allowedHostPorts,omitempty"`
+	// AllowedNodePortServices are the accepted Services allocating node ports, as "namespace/name".
+	AllowedNodePortServices []string `yaml:"allowedNodePortServices,omitempty" json:"allowedNodePortServices,omitempty"`
+}
+
+// AllowsHostPort returns true when the hostPort of port is accepted.
+func (n *NodeExposure) AllowsHostPort(port ContainerPort) bool {
+	hostPort := strconv.Itoa(port.HostPort)
+	for _, allowed := range n.AllowedHostPorts {
+		if allowed == hostPort+"/"+defaultProtocol(port.Protocol) ||
+			(allowed == hostPort && defaultProtocol(port.Protocol) == ProtocolTCP) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsNodePortService returns true when the node ports of service are accepted.
+func (n *NodeExposure) AllowsNodePortService(service *Service) bool {
+	return contains(n.AllowedNodePortServices, service.FullName())
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestNodeExposure_AllowsHostPort(t *testing.T) {
+	exposure := configsections.NodeExposure{AllowedHostPorts: []string{"9100", "5060/UDP"}}
+	assert.True(t, exposure.AllowsHostPort(configsections.ContainerPort{ContainerPort: 9100, HostPort: 9100}))
+	assert.False(t, exposure.AllowsHostPort(configsections.ContainerPort{ContainerPort: 9100, HostPort: 9100, Protocol: "UDP"}))
+	assert.True(t, exposure.AllowsHostPort(configsections.ContainerPort{ContainerPort: 5060, HostPort: 5060, Protocol: "UDP"}))
+	assert.False(t, exposure.AllowsHostPort(configsections.ContainerPort{ContainerPort: 5060, HostPort: 5060}))
+	assert.False(t, exposure.AllowsHostPort(configsections.ContainerPort{ContainerPort: 8080, HostPort: 30080}))
+}
+
+func TestNodeExposure_AllowsNodePortService(t *testing.T) {
+	exposure := configsections.NodeExposure{AllowedNodePortServices: []string{"tnf/monitoring"}}
+	assert.True(t, exposure.AllowsNodePortService(&configsections.Service{Namespace: "tnf", Name: "monitoring"}))
+	assert.False(t, exposure.AllowsNodePortService(&configsections.Service{Namespace: "other", Name: "monitoring"}))
+}
+
+func TestService_NodePorts(t *testing.T) {
+	service := configsections.Service{Type: "NodePort", Ports: []configsections.ServicePort{
+		{Port: 80, NodePort: 30080},
+		{Port: 443},
+	}}
+	assert.Equal(t, []int{30080}, service.NodePorts())
+	assert.Empty(t, (&configsections.Service{Type: "ClusterIP"}).NodePorts())
+}
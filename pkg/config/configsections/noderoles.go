@@ -0,0 +1,60 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+import (
+	"regexp"
+)
+
+// NodeRole selects the node test cases run on the nodes having a role, e.g. the nodes of a custom MachineConfigPool.
+type NodeRole struct {
+	// Role is the name of the role, e.g. "infra" for the nodes with the node-role.kubernetes.io/infra label.
+	Role string `yaml:"role" json:"role"`
+	// TestCases are regular expressions matching the names of the node test cases run on the nodes with the role,
+	// e.g. "platform-alteration-.*".  No node test case runs on them when it is empty.
+	TestCases []string `yaml:"testCases,omitempty" json:"testCases,omitempty"`
+}
+
+// matches returns true when a test case name matches one of the patterns of the role.  The invalid patterns match
+// nothing.
+func (r *NodeRole) matches(testCase string) bool {
+	for _, pattern := range r.TestCases {
+		if ok, err := regexp.MatchString("^(?:"+pattern+")$", testCase); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RunsOnNode returns true when a node test case, named "<suite>-<name>", runs on a node.  When some roles of the node
+// are configured, the test case runs on it if it matches the test cases of one of them, otherwise byDefault is
+// returned.
+func RunsOnNode(nodeRoles []NodeRole, node *Node, testCase string, byDefault bool) bool {
+	configured := false
+	for i := range nodeRoles {
+		if !node.HasRole(nodeRoles[i].Role) {
+			continue
+		}
+		configured = true
+		if nodeRoles[i].matches(testCase) {
+			return true
+		}
+	}
+	if configured {
+		return false
+	}
+	return byDefault
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeRoles(t *testing.T) {
+	node := Node{Name: "infra-0", Labels: []string{NodeRoleLabelPrefix + "infra", WorkerLabel}}
+	assert.Equal(t, []string{"infra", "worker"}, node.Roles())
+	assert.True(t, node.HasRole("infra"))
+	assert.True(t, node.IsWorker())
+	assert.False(t, node.HasRole("master"))
+}
+
+func TestRunsOnNode(t *testing.T) {
+	nodeRoles := []NodeRole{
+		{Role: "infra", TestCases: []string{"platform-alteration-tainted-node-kernel", "platform-alteration-time.*"}},
+		{Role: "rt-worker", TestCases: []string{"platform-alteration-.*"}},
+		{Role: "storage"},
+		{Role: "invalid", TestCases: []string{"platform-alteration-("}},
+	}
+	infra := Node{Name: "infra-0", Labels: []string{NodeRoleLabelPrefix + "infra", WorkerLabel}}
+	rtWorker := Node{Name: "rt-worker-0", Labels: []string{NodeRoleLabelPrefix + "rt-worker", WorkerLabel}}
+	storage := Node{Name: "storage-0", Labels: []string{NodeRoleLabelPrefix + "storage"}}
+	worker := Node{Name: "worker-0", Labels: []string{WorkerLabel}}
+	invalid := Node{Name: "invalid-0", Labels: []string{NodeRoleLabelPrefix + "invalid"}}
+
+	testCases := []struct {
+		node      *Node
+		testCase  string
+		byDefault bool
+		expected  bool
+	}{
+		{node: &infra, testCase: "platform-alteration-tainted-node-kernel", expected: true},
+		{node: &infra, testCase: "platform-alteration-timezone", expected: true},
+		{node: &infra, testCase: "platform-alteration-hugepages-config", byDefault: true, expected: false},
+		{node: &rtWorker, testCase: "platform-alteration-hugepages-config", expected: true},
+		{node: &storage, testCase: "platform-alteration-tainted-node-kernel", byDefault: true, expected: false},
+		{node: &worker, testCase: "platform-alteration-hugepages-config", byDefault: true, expected: true},
+		{node: &worker, testCase: "platform-alteration-hugepages-config", byDefault: false, expected: false},
+		{node: &invalid, testCase: "platform-alteration-timezone", byDefault: true, expected: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, RunsOnNode(nodeRoles, tc.node, tc.testCase, tc.byDefault), tc.node.Name+" "+tc.testCase)
+	}
+	assert.True(t, RunsOnNode(nil, &infra, "platform-alteration-timezone", true))
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+// DefaultNodeSSHUser is the user of the SSH sessions to the nodes unless configured, the user of the RHCOS nodes.
+const DefaultNodeSSHUser = "core"
+
+// NodeSSH configures the SSH access to the nodes, which replaces the debug pods to run the node commands of the tests
+// in the environments forbidding "oc debug" or the privileged pods.
+type NodeSSH struct {
+	// Enabled runs the node commands over SSH instead of in the debug pods.
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	// User is the user of the sessions, which runs the commands as root with sudo.
+	User string `yaml:"user,omitempty" json:"user,omitempty"`
+	// Port is the SSH port of the nodes, the default of the ssh client when 0.
+	Port int `yaml:"port,omitempty" json:"port,omitempty"`
+	// KeyFile is the private key authenticating the user, the default keys of the ssh client when empty.
+	KeyFile string `yaml:"keyFile,omitempty" json:"keyFile,omitempty"`
+	// Jumphost is the bastion the nodes are reached through, as [user@]host[:port], none when empty.
+	Jumphost string `yaml:"jumphost,omitempty" json:"jumphost,omitempty"`
+	// Hosts maps node names to their SSH addresses, the nodes are reached at their internal IP address, or at their
+	// name, otherwise.
+	Hosts map[string]string `yaml:"hosts,omitempty" json:"hosts,omitempty"`
+}
+
+// GetUser returns the user of the sessions.
+func (n *NodeSSH) GetUser() string {
+	if n.User == "" {
+		return DefaultNodeSSHUser
+	}
+	return n.User
+}
+
+// GetHost returns the SSH address of a node.
+func (n *NodeSSH) GetHost(node *Node) string {
+	if host, ok := n.Hosts[node.Name]; ok {
+		return host
+	}
+	if node.InternalIP != "" {
+		return node.InternalIP
+	}
+	return node.Name
+}
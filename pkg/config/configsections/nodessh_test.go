@@ -0,0 +1,33 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeSSH(t *testing.T) {
+	nodeSSH := NodeSSH{Hosts: map[string]string{"worker-0": "worker-0.example.com"}}
+	assert.Equal(t, DefaultNodeSSHUser, nodeSSH.GetUser())
+	assert.Equal(t, "worker-0.example.com", nodeSSH.GetHost(&Node{Name: "worker-0", InternalIP: "10.0.0.10"}))
+	assert.Equal(t, "10.0.0.11", nodeSSH.GetHost(&Node{Name: "worker-1", InternalIP: "10.0.0.11"}))
+	assert.Equal(t, "worker-2", nodeSSH.GetHost(&Node{Name: "worker-2"}))
+
+	nodeSSH.User = "admin"
+	assert.Equal(t, "admin", nodeSSH.GetUser())
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DefaultOperatorCertificationCacheTTL is how long the certified operator bundles read from the catalog are
+	// cached, unless configured.
+	DefaultOperatorCertificationCacheTTL = 24 * time.Hour
+	// operatorCertificationCacheFile is the name of the default cache file, in the cache directory of the user.
+	operatorCertificationCacheFile = "tnf/certified-operator-bundles.json"
+)
+
+// OperatorCertification configures the certification check of the operators under test in the catalog.
+type OperatorCertification struct {
+	// CacheFile is the file caching the certified operator bundles read from the catalog across the runs.
+	CacheFile string `yaml:"cacheFile,omitempty" json:"cacheFile,omitempty"`
+	// CacheTTL is how long the cached bundles are used before reading them again, e.g. "12h".
+	CacheTTL time.Duration `yaml:"cacheTTL,omitempty" json:"cacheTTL,omitempty"`
+}
+
+// GetCacheFile returns the file caching the certified operator bundles, by default in the cache directory of the user,
+// empty when there is none.
+func (o *OperatorCertification) GetCacheFile() string {
+	if o.CacheFile != "" {
+		return o.CacheFile
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cacheDir, operatorCertificationCacheFile)
+}
+
+// GetCacheTTL returns how long the cached bundles are used.
+func (o *OperatorCertification) GetCacheTTL() time.Duration {
+	if o.CacheTTL == 0 {
+		return DefaultOperatorCertificationCacheTTL
+	}
+	return o.CacheTTL
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import "fmt"
+
+const (
+	// OutputSinkStdout writes the artifacts on the standard output.
+	OutputSinkStdout = "stdout"
+	// OutputSinkFile writes the artifacts in a local directory.
+	OutputSinkFile = "file"
+	// OutputSinkS3 uploads the artifacts to an S3-compatible bucket.
+	OutputSinkS3 = "s3"
+	// OutputSinkHTTP uploads the artifacts to an HTTP endpoint.
+	OutputSinkHTTP = "http"
+)
+
+// OutputSink is a destination of the claim and of the other artifacts of a run, in addition to the local files.  The
+// credentials are read from the environment variables named by the sink, the configuration being recorded in the
+// claim.
+type OutputSink struct {
+	// Type is OutputSinkStdout, OutputSinkFile, OutputSinkS3 or OutputSinkHTTP.
+	Type string `yaml:"type" json:"type" required:"true"`
+	// Path is the directory of a file sink.
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+	// URL is the endpoint of an HTTP or S3 sink.  The artifacts are PUT to <url>/<name>, or to
+	// <url>/<bucket>/<prefix><name> for S3.
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+	// Bucket is the bucket of an S3 sink.
+	Bucket string `yaml:"bucket,omitempty" json:"bucket,omitempty"`
+	// Region is the region of an S3 sink, us-east-1 when not set.
+	Region string `yaml:"region,omitempty" json:"region,omitempty"`
+	// Prefix is prepended to the artifact names of an S3 sink, e.g. "runs/42/".
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+	// TokenEnv is the environment variable holding the bearer token of an HTTP sink, if any.
+	TokenEnv string `yaml:"tokenEnv,omitempty" json:"tokenEnv,omitempty"`
+	// AccessKeyIDEnv is the environment variable holding the access key ID of an S3 sink.
+	AccessKeyIDEnv string `yaml:"accessKeyIDEnv,omitempty" json:"accessKeyIDEnv,omitempty"`
+	// SecretAccessKeyEnv is the environment variable holding the secret access key of an S3 sink.
+	SecretAccessKeyEnv string `yaml:"secretAccessKeyEnv,omitempty" json:"secretAccessKeyEnv,omitempty"`
+}
+
+// Validate returns an error when a setting required by the type of the sink is missing.
+func (o *OutputSink) Validate() error {
+	switch o.Type {
+	case OutputSinkStdout:
+		return nil
+	case OutputSinkFile:
+		if o.Path == "" {
+			return fmt.Errorf("file output sink has no path")
+		}
+	case OutputSinkHTTP:
+		if o.URL == "" {
+			return fmt.Errorf("http output sink has no url")
+		}
+	case OutputSinkS3:
+		if o.URL == "" || o.Bucket == "" {
+			return fmt.Errorf("s3 output sink requires a url and a bucket")
+		}
+		if o.AccessKeyIDEnv == "" || o.SecretAccessKeyEnv == "" {
+			return fmt.Errorf("s3 output sink %s requires accessKeyIDEnv and secretAccessKeyEnv", o.Bucket)
+		}
+	default:
+		return fmt.Errorf("unknown output sink type %q", o.Type)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestOutputSink_Validate(t *testing.T) {
+	testCases := []struct {
+		sink  configsections.OutputSink
+		valid bool
+	}{
+		{configsections.OutputSink{Type: configsections.OutputSinkStdout}, true},
+		{configsections.OutputSink{Type: configsections.OutputSinkFile, Path: "/tmp/tnf"}, true},
+		{configsections.OutputSink{Type: configsections.OutputSinkFile}, false},
+		{configsections.OutputSink{Type: configsections.OutputSinkHTTP, URL: "https://results.example.com"}, true},
+		{configsections.OutputSink{Type: configsections.OutputSinkHTTP}, false},
+		{configsections.OutputSink{Type: configsections.OutputSinkS3, URL: "https://s3.example.com", Bucket: "tnf",
+			AccessKeyIDEnv: "AWS_ACCESS_KEY_ID", SecretAccessKeyEnv: "AWS_SECRET_ACCESS_KEY"}, true},
+		{configsections.OutputSink{Type: configsections.OutputSinkS3, URL: "https://s3.example.com", Bucket: "tnf"}, false},
+		{configsections.OutputSink{Type: configsections.OutputSinkS3, Bucket: "tnf",
+			AccessKeyIDEnv: "AWS_ACCESS_KEY_ID", SecretAccessKeyEnv: "AWS_SECRET_ACCESS_KEY"}, false},
+		{configsections.OutputSink{Type: "ftp"}, false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.valid, tc.sink.Validate() == nil, tc.sink)
+	}
+}
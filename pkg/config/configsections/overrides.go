@@ -0,0 +1,311 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// OverrideSourceCLI is the source of the overrides set on the command line, e.g. -set timeouts.default=1m.
+	OverrideSourceCLI = "cli"
+	// OverrideSourceEnv is the source of the overrides set with environment variables, e.g.
+	// TNF_CONFIG_TIMEOUTS_DEFAULT=1m.
+	OverrideSourceEnv = "env"
+	// OverrideEnvPrefix is the prefix of the environment variables overriding the fields of the configuration.
+	OverrideEnvPrefix = "TNF_CONFIG_"
+	// overrideSeparator separates the path of an override from its value, e.g. timeouts.default=1m.
+	overrideSeparator = "="
+	// envWordSeparator separates the words of the environment variables, e.g. TNF_CONFIG_TARGET_NAME_SPACES.
+	envWordSeparator = "_"
+	// overrideParts are the path and the value of an override.
+	overrideParts = 2
+)
+
+// legacyOverrideEnvVars are the environment variables which predate the overrides, by path, they are overridden by the
+// TNF_CONFIG_ variable of their path.
+var legacyOverrideEnvVars = map[string]string{
+	"logLevel": "LOG_LEVEL",
+}
+
+// overridePathSegment is a segment of the path of an override:  the key of a mapping, e.g. a field, or the index of a
+// list, e.g. [0].
+var overridePathSegment = regexp.MustCompile(`^([^\[\]]+)((?:\[[0-9]+\])*)$`)
+
+// Override sets a field of the configuration, replacing the value of the configuration file.
+type Override struct {
+	// Path is the path of the field, e.g. timeouts.default or targetPodLabels[0].name.
+	Path string `json:"path"`
+	// Value is the YAML value of the field, e.g. 1m or "[{name: tnf}]".
+	Value string `json:"value"`
+	// Source is OverrideSourceCLI or OverrideSourceEnv.
+	Source string `json:"source"`
+	// Variable is the environment variable of the overrides of OverrideSourceEnv.
+	Variable string `json:"variable,omitempty"`
+}
+
+// ParseOverride parses an override set on the command line as path=value, e.g. timeouts.default=1m.
+func ParseOverride(setting string) (Override, error) {
+	parts := strings.SplitN(setting, overrideSeparator, overrideParts)
+	if len(parts) != overrideParts || parts[0] == "" {
+		return Override{}, fmt.Errorf("invalid override %q, expected path=value, e.g. timeouts.default=1m", setting)
+	}
+	if _, err := parseOverridePath(parts[0]); err != nil {
+		return Override{}, err
+	}
+	return Override{Path: parts[0], Value: parts[1], Source: OverrideSourceCLI}, nil
+}
+
+// GetEnvOverrides returns the overrides set by the TNF_CONFIG_ environment variables of environ, as returned by
+// os.Environ, sorted by variable.  The words of a variable are the words of the fields of its path, e.g.
+// TNF_CONFIG_TARGET_NAME_SPACES for targetNameSpaces and TNF_CONFIG_TIMEOUTS_DEFAULT for timeouts.default, or a field
+// as a single word, e.g. TNF_CONFIG_TARGETNAMESPACES.  The variables cannot reach into the lists and the mappings,
+// which are set as a whole.  The TNF_CONFIG_ variables which match no field, e.g. misspelled ones or TNF_CONFIG_DIR of
+// the CI workflows, are ignored and returned, sorted, for the callers to warn about them.
+func GetEnvOverrides(environ []string) (overrides []Override, ignored []string) {
+	variables := map[string]string{}
+	for _, entry := range environ {
+		parts := strings.SplitN(entry, overrideSeparator, overrideParts)
+		if len(parts) == overrideParts {
+			variables[parts[0]] = parts[1]
+		}
+	}
+	paths := map[string]bool{}
+	configType := reflect.TypeOf(TestConfiguration{})
+	for variable, value := range variables {
+		if !strings.HasPrefix(variable, OverrideEnvPrefix) {
+			continue
+		}
+		words := strings.Split(strings.TrimPrefix(variable, OverrideEnvPrefix), envWordSeparator)
+		path, ok := resolveEnvPath(configType, words)
+		if !ok {
+			ignored = append(ignored, variable)
+			continue
+		}
+		overrides = append(overrides, Override{Path: path, Value: value, Source: OverrideSourceEnv, Variable: variable})
+		paths[path] = true
+	}
+	for path, variable := range legacyOverrideEnvVars {
+		if value, ok := variables[variable]; ok && !paths[path] {
+			overrides = append(overrides, Override{Path: path, Value: value, Source: OverrideSourceEnv,
+				Variable: variable})
+		}
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].Variable < overrides[j].Variable
+	})
+	sort.Strings(ignored)
+	return overrides, ignored
+}
+
+// resolveEnvPath returns the path of the field of a struct the words of an environment variable match.
+func resolveEnvPath(t reflect.Type, words []string) (string, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if len(words) == 0 || t.Kind() != reflect.Struct {
+		return "", false
+	}
+	fields := map[string]yamlField{}
+	getYAMLFields(t, fields)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, form := range [][]string{splitWords(name), {strings.ToUpper(name)}} {
+			if len(form) > len(words) || strings.Join(form, envWordSeparator) !=
+				strings.Join(words[:len(form)], envWordSeparator) {
+				continue
+			}
+			if len(form) == len(words) {
+				return name, true
+			}
+			if path, ok := resolveEnvPath(fields[name].t, words[len(form):]); ok {
+				return name + "." + path, true
+			}
+		}
+	}
+	return "", false
+}
+
+// splitWords splits a camel case field name into upper case words, e.g. targetNameSpaces into TARGET, NAME and
+// SPACES, and pushgatewayURL into PUSHGATEWAY and URL.
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		lowerBefore := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+		acronymEnd := unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsUpper(runes[i]) && (lowerBefore || acronymEnd) {
+			words = append(words, strings.ToUpper(string(runes[start:i])))
+			start = i
+		}
+	}
+	return append(words, strings.ToUpper(string(runes[start:])))
+}
+
+// pathSegment is a key of a mapping, or the index of a list when isIndex is set.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseOverridePath parses the path of an override, e.g. targetPodLabels[0].name.
+func parseOverridePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		match := overridePathSegment.FindStringSubmatch(part)
+		if match == nil {
+			return nil, fmt.Errorf("invalid override path %q", path)
+		}
+		segments = append(segments, pathSegment{key: match[1]})
+		for _, index := range strings.Split(strings.Trim(match[2], "[]"), "][") {
+			if index == "" {
+				continue
+			}
+			i, err := strconv.Atoi(index)
+			if err != nil {
+				return nil, fmt.Errorf("invalid override path %q: %w", path, err)
+			}
+			segments = append(segments, pathSegment{index: i, isIndex: true})
+		}
+	}
+	return segments, nil
+}
+
+// checkOverridePath checks the segments of the path of an override reach a field of the configuration.
+func checkOverridePath(t reflect.Type, segments []pathSegment) error {
+	path := ""
+	for _, segment := range segments {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch {
+		case segment.isIndex && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+			t = t.Elem()
+		case !segment.isIndex && t.Kind() == reflect.Map:
+			t = t.Elem()
+		case !segment.isIndex && t.Kind() == reflect.Struct:
+			fields := map[string]yamlField{}
+			getYAMLFields(t, fields)
+			field, ok := fields[segment.key]
+			if !ok {
+				return unknownField(joinPath(path, segment.key), segment.key, fields)
+			}
+			t = field.t
+		case segment.isIndex:
+			return fmt.Errorf("%s is not a list", path)
+		default:
+			return fmt.Errorf("%s is not a mapping", path)
+		}
+		if segment.isIndex {
+			path = fmt.Sprintf("%s[%d]", path, segment.index)
+		} else {
+			path = joinPath(path, segment.key)
+		}
+	}
+	return nil
+}
+
+// ApplyOverrides applies overrides to the contents of a configuration file in turn, the last override of a field
+// wins, and returns the resulting configuration file.
+func ApplyOverrides(contents []byte, overrides []Override) ([]byte, error) {
+	if len(overrides) == 0 {
+		return contents, nil
+	}
+	var document interface{}
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		return nil, err
+	}
+	configType := reflect.TypeOf(TestConfiguration{})
+	for _, override := range overrides {
+		segments, err := parseOverridePath(override.Path)
+		if err == nil {
+			err = checkOverridePath(configType, segments)
+		}
+		var value interface{}
+		if err == nil {
+			err = yaml.Unmarshal([]byte(override.Value), &value)
+		}
+		if err == nil {
+			document, err = setOverride(document, segments, value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid override of %s from %s: %w", override.Path, describeSource(override), err)
+		}
+	}
+	return yaml.Marshal(document)
+}
+
+func describeSource(override Override) string {
+	if override.Source == OverrideSourceEnv {
+		return "the environment variable " + override.Variable
+	}
+	return "the command line"
+}
+
+// setOverride sets the value at the path of the segments of a node of the configuration file, creating the missing
+// mappings, and returns the node.  A list can only be extended by one item.
+func setOverride(node interface{}, segments []pathSegment, value interface{}) (interface{}, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+	segment := segments[0]
+	if segment.isIndex {
+		items, ok := node.([]interface{})
+		if !ok && node != nil {
+			return nil, fmt.Errorf("[%d] is not in a list", segment.index)
+		}
+		if segment.index > len(items) {
+			return nil, fmt.Errorf("index %d is out of the %d items of the list", segment.index, len(items))
+		}
+		if segment.index == len(items) {
+			items = append(items, nil)
+		}
+		item, err := setOverride(items[segment.index], segments[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		items[segment.index] = item
+		return items, nil
+	}
+	fields, ok := node.(map[interface{}]interface{})
+	if !ok && node != nil {
+		return nil, fmt.Errorf("%s is not in a mapping", segment.key)
+	}
+	if fields == nil {
+		fields = map[interface{}]interface{}{}
+	}
+	field, err := setOverride(fields[segment.key], segments[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	fields[segment.key] = field
+	return fields, nil
+}
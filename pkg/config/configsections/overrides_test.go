@@ -0,0 +1,119 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"gopkg.in/yaml.v2"
+)
+
+const overriddenConfigYAML = `
+targetNameSpaces:
+  - name: tnf
+targetPodLabels:
+  - prefix: test-network-function.com
+    name: generic
+    value: target
+timeouts:
+  default: 30s
+`
+
+func TestParseOverride(t *testing.T) {
+	override, err := configsections.ParseOverride("targetPodLabels[0].value=cnf=1")
+	assert.Nil(t, err)
+	assert.Equal(t, configsections.Override{Path: "targetPodLabels[0].value", Value: "cnf=1",
+		Source: configsections.OverrideSourceCLI}, override)
+
+	for _, setting := range []string{"timeouts.default", "=1m", "timeouts..default=1m", "targetPodLabels[a]=x"} {
+		_, err = configsections.ParseOverride(setting)
+		assert.NotNil(t, err, setting)
+	}
+}
+
+func TestGetEnvOverrides(t *testing.T) {
+	overrides, ignored := configsections.GetEnvOverrides([]string{
+		"HOME=/root",
+		"TNF_CONFIGURATION_PATH=tnf_config.yml",
+		"TNF_CONFIG_TIMEOUTS_DEFAULT=1m",
+		"TNF_CONFIG_TARGETNAMESPACES=[{name: cnf}]",
+		"TNF_CONFIG_METRICS_PUSHGATEWAY_URL=http://pushgateway:9091",
+		"LOG_LEVEL=info",
+	})
+	assert.Empty(t, ignored)
+	assert.Equal(t, []configsections.Override{
+		{Path: "logLevel", Value: "info", Source: configsections.OverrideSourceEnv, Variable: "LOG_LEVEL"},
+		{Path: "metrics.pushgatewayURL", Value: "http://pushgateway:9091", Source: configsections.OverrideSourceEnv,
+			Variable: "TNF_CONFIG_METRICS_PUSHGATEWAY_URL"},
+		{Path: "targetNameSpaces", Value: "[{name: cnf}]", Source: configsections.OverrideSourceEnv,
+			Variable: "TNF_CONFIG_TARGETNAMESPACES"},
+		{Path: "timeouts.default", Value: "1m", Source: configsections.OverrideSourceEnv,
+			Variable: "TNF_CONFIG_TIMEOUTS_DEFAULT"},
+	}, overrides)
+
+	// the TNF_CONFIG_ variable of a path overrides its legacy variable.
+	overrides, ignored = configsections.GetEnvOverrides([]string{"LOG_LEVEL=info", "TNF_CONFIG_LOG_LEVEL=warn"})
+	assert.Empty(t, ignored)
+	assert.Equal(t, []configsections.Override{
+		{Path: "logLevel", Value: "warn", Source: configsections.OverrideSourceEnv, Variable: "TNF_CONFIG_LOG_LEVEL"},
+	}, overrides)
+
+	// the variables matching no field, e.g. TNF_CONFIG_DIR of the CI workflows, are ignored.
+	overrides, ignored = configsections.GetEnvOverrides([]string{
+		"TNF_CONFIG_DIR=/tmp/tnf/config",
+		"TNF_CONFIG_TIMEOUT_DEFAULT=1m",
+		"TNF_CONFIG_TIMEOUTS_DEFAULT=2m",
+	})
+	assert.Equal(t, []string{"TNF_CONFIG_DIR", "TNF_CONFIG_TIMEOUT_DEFAULT"}, ignored)
+	assert.Equal(t, []configsections.Override{
+		{Path: "timeouts.default", Value: "2m", Source: configsections.OverrideSourceEnv,
+			Variable: "TNF_CONFIG_TIMEOUTS_DEFAULT"},
+	}, overrides)
+}
+
+func TestApplyOverrides(t *testing.T) {
+	contents, err := configsections.ApplyOverrides([]byte(overriddenConfigYAML), []configsections.Override{
+		{Path: "timeouts.default", Value: "1m", Source: configsections.OverrideSourceEnv},
+		{Path: "timeouts.default", Value: "2m", Source: configsections.OverrideSourceCLI},
+		{Path: "timeouts.suites.lifecycle", Value: "5m", Source: configsections.OverrideSourceCLI},
+		{Path: "targetPodLabels[0].value", Value: "cnf", Source: configsections.OverrideSourceCLI},
+		{Path: "targetNameSpaces[1]", Value: "{name: cnf}", Source: configsections.OverrideSourceCLI},
+		{Path: "logLevel", Value: "info", Source: configsections.OverrideSourceEnv},
+	})
+	assert.Nil(t, err)
+	var testConfig configsections.TestConfiguration
+	assert.Nil(t, yaml.UnmarshalStrict(contents, &testConfig))
+	assert.Equal(t, 2*time.Minute, testConfig.Timeouts.Default)
+	assert.Equal(t, 5*time.Minute, testConfig.Timeouts.Suites["lifecycle"])
+	assert.Equal(t, "cnf", testConfig.TargetPodLabels[0].Value)
+	assert.Equal(t, "generic", testConfig.TargetPodLabels[0].Name)
+	assert.Equal(t, []configsections.Namespace{{Name: "tnf"}, {Name: "cnf"}}, testConfig.TargetNameSpaces)
+	assert.Equal(t, "info", testConfig.LogLevel)
+
+	for _, override := range []configsections.Override{
+		{Path: "timeout.default", Value: "1m"},
+		{Path: "targetNameSpaces[3].name", Value: "cnf"},
+		{Path: "timeouts.default.suites", Value: "1m"},
+		{Path: "timeouts[0]", Value: "1m"},
+	} {
+		_, err = configsections.ApplyOverrides([]byte(overriddenConfigYAML), []configsections.Override{override})
+		assert.NotNil(t, err, override.Path)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MinCheckedReplicas is the least number of replicas of a workload for its PodDisruptionBudget to be checked.
+const MinCheckedReplicas = 2
+
+// PodDisruptionBudget is a PodDisruptionBudget of the target namespace, limiting the voluntary disruptions of the pods
+// it selects, e.g. the evictions of a node drain.
+type PodDisruptionBudget struct {
+	Namespace string `yaml:"namespace" json:"namespace"`
+	Name      string `yaml:"name" json:"name"`
+	// Selector selects the pods of the budget, none when not set and all the pods of the namespace when empty.
+	Selector *LabelSelector `yaml:"selector,omitempty" json:"selector,omitempty"`
+	// MinAvailable is a number or a percentage of pods, e.g. "2" or "50%", empty when not set.
+	MinAvailable string `yaml:"minAvailable,omitempty" json:"minAvailable,omitempty"`
+	// MaxUnavailable is a number or a percentage of pods, e.g. "1" or "25%", empty when not set.
+	MaxUnavailable string `yaml:"maxUnavailable,omitempty" json:"maxUnavailable,omitempty"`
+}
+
+// FullName returns the PodDisruptionBudget name prefixed with its namespace.
+func (p *PodDisruptionBudget) FullName() string {
+	return p.Namespace + "/" + p.Name
+}
+
+// Selects returns true when the budget selects the pods of namespace with labels.
+func (p *PodDisruptionBudget) Selects(namespace string, labels map[string]string) bool {
+	return p.Namespace == namespace && p.Selector != nil && p.Selector.Matches(labels)
+}
+
+// ErrSingleReplica is returned by CheckReplicas for a workload with a single replica, which no budget can keep
+// available while allowing its disruption.  Such a workload is not checked.
+var ErrSingleReplica = errors.New("cannot keep a single replica available while allowing its disruption")
+
+// CheckReplicas returns an error unless the budget keeps at least one of replicas pods available while allowing at
+// least one of them to be disrupted.  A budget allowing no disruption blocks the node drains, and a budget allowing
+// all the pods to be disrupted does not protect them.  As Kubernetes does, the percentages are rounded up.  It returns
+// ErrSingleReplica when replicas is less than 2.
+func (p *PodDisruptionBudget) CheckReplicas(replicas int) error {
+	if replicas < MinCheckedReplicas {
+		return ErrSingleReplica
+	}
+	var minAvailable int
+	switch {
+	case p.MinAvailable != "":
+		value, err := scaleIntOrPercent(p.MinAvailable, replicas)
+		if err != nil {
+			return fmt.Errorf("has an invalid minAvailable: %w", err)
+		}
+		minAvailable = value
+	case p.MaxUnavailable != "":
+		value, err := scaleIntOrPercent(p.MaxUnavailable, replicas)
+		if err != nil {
+			return fmt.Errorf("has an invalid maxUnavailable: %w", err)
+		}
+		minAvailable = replicas - value
+	default:
+		return errors.New("sets neither minAvailable nor maxUnavailable")
+	}
+	if minAvailable >= replicas {
+		return fmt.Errorf("allows no disruption of the %d replicas, the node drains are blocked", replicas)
+	}
+	if minAvailable < 1 {
+		return fmt.Errorf("allows the disruption of all the %d replicas", replicas)
+	}
+	return nil
+}
+
+// scaleIntOrPercent returns a number of pods, or a percentage of total rounded up.
+func scaleIntOrPercent(value string, total int) (int, error) {
+	if strings.HasSuffix(value, "%") {
+		percent, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil {
+			return 0, err
+		}
+		return int(math.Ceil(float64(percent) * float64(total) / 100)), nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestPodDisruptionBudget_Selects(t *testing.T) {
+	labels := map[string]string{"app": "test", "tier": "backend"}
+	pdb := configsections.PodDisruptionBudget{Namespace: "tnf", Name: "test",
+		Selector: &configsections.LabelSelector{MatchLabels: map[string]string{"app": "test"}}}
+	assert.True(t, pdb.Selects("tnf", labels))
+	assert.False(t, pdb.Selects("other", labels))
+	assert.False(t, pdb.Selects("tnf", map[string]string{"app": "other"}))
+	// an empty selector selects all the pods of the namespace, a missing one none.
+	assert.True(t, (&configsections.PodDisruptionBudget{Namespace: "tnf", Selector: &configsections.LabelSelector{}}).Selects("tnf", labels))
+	assert.False(t, (&configsections.PodDisruptionBudget{Namespace: "tnf"}).Selects("tnf", labels))
+}
+
+func TestPodDisruptionBudget_CheckReplicas(t *testing.T) {
+	for _, tc := range []struct {
+		minAvailable   string
+		maxUnavailable string
+		replicas       int
+		consistent     bool
+		singleReplica  bool
+	}{
+		{minAvailable: "1", replicas: 1, singleReplica: true},
+		{minAvailable: "0", replicas: 1, singleReplica: true},
+		{minAvailable: "100%", replicas: 1, singleReplica: true},
+		{maxUnavailable: "0", replicas: 1, singleReplica: true},
+		{maxUnavailable: "1", replicas: 1, singleReplica: true},
+		{maxUnavailable: "50%", replicas: 1, singleReplica: true},
+		{replicas: 1, singleReplica: true},
+		{minAvailable: "1", replicas: 2, consistent: true},
+		{minAvailable: "2", replicas: 2},
+		{minAvailable: "0", replicas: 2},
+		{minAvailable: "50%", replicas: 2, consistent: true},
+		{minAvailable: "51%", replicas: 2},
+		{minAvailable: "100%", replicas: 2},
+		{maxUnavailable: "1", replicas: 2, consistent: true},
+		{maxUnavailable: "0", replicas: 2},
+		{maxUnavailable: "2", replicas: 2},
+		{maxUnavailable: "50%", replicas: 2, consistent: true},
+		{maxUnavailable: "1%", replicas: 2, consistent: true},
+		{maxUnavailable: "51%", replicas: 2},
+		{minAvailable: "50%", replicas: 3, consistent: true},
+		{minAvailable: "100%", replicas: 3},
+		{minAvailable: "10%", replicas: 3, consistent: true},
+		{maxUnavailable: "1", replicas: 3, consistent: true},
+		{maxUnavailable: "0", replicas: 3},
+		{maxUnavailable: "3", replicas: 3},
+		{maxUnavailable: "25%", replicas: 4, consistent: true},
+		{maxUnavailable: "90%", replicas: 4},
+		{minAvailable: "a%", replicas: 3},
+		{maxUnavailable: "one", replicas: 3},
+		{replicas: 3},
+	} {
+		pdb := configsections.PodDisruptionBudget{MinAvailable: tc.minAvailable, MaxUnavailable: tc.maxUnavailable}
+		err := pdb.CheckReplicas(tc.replicas)
+		switch {
+		case tc.consistent:
+			assert.Nil(t, err, "%+v", tc)
+		case tc.singleReplica:
+			assert.ErrorIs(t, err, configsections.ErrSingleReplica, "%+v", tc)
+		default:
+			assert.NotNil(t, err, "%+v", tc)
+			assert.NotErrorIs(t, err, configsections.ErrSingleReplica, "%+v", tc)
+		}
+	}
+}
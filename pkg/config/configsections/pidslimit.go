@@ -0,0 +1,39 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+// DefaultMaxPidsUsagePercent is the share of the pids limit the processes of a container or pod may use, unless
+// configured.
+const DefaultMaxPidsUsagePercent = 80
+
+// PidsLimit configures the pids limit test of the containers under test.
+type PidsLimit struct {
+	// MaxUsagePercent is the share of the pids limit the processes of a container or pod may use, in percent.
+	MaxUsagePercent int `yaml:"maxUsagePercent,omitempty" json:"maxUsagePercent,omitempty"`
+}
+
+// GetMaxUsagePercent returns the share of the pids limit the processes of a container or pod may use, in percent.
+func (p *PidsLimit) GetMaxUsagePercent() int {
+	if p.MaxUsagePercent <= 0 || p.MaxUsagePercent > 100 {
+		return DefaultMaxPidsUsagePercent
+	}
+	return p.MaxUsagePercent
+}
+
+// ApproachesLimit returns true when processes exceeds the accepted share of limit, false when there is no limit.
+func (p *PidsLimit) ApproachesLimit(processes, limit int) bool {
+	return limit > 0 && processes*100 > limit*p.GetMaxUsagePercent()
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestPidsLimit_GetMaxUsagePercent(t *testing.T) {
+	assert.Equal(t, configsections.DefaultMaxPidsUsagePercent, (&configsections.PidsLimit{}).GetMaxUsagePercent())
+	assert.Equal(t, configsections.DefaultMaxPidsUsagePercent, (&configsections.PidsLimit{MaxUsagePercent: 150}).GetMaxUsagePercent())
+	assert.Equal(t, 50, (&configsections.PidsLimit{MaxUsagePercent: 50}).GetMaxUsagePercent())
+}
+
+func TestPidsLimit_ApproachesLimit(t *testing.T) {
+	pidsLimit := configsections.PidsLimit{}
+	assert.False(t, pidsLimit.ApproachesLimit(800, 1024))
+	assert.True(t, pidsLimit.ApproachesLimit(820, 1024))
+	// no limit.
+	assert.False(t, pidsLimit.ApproachesLimit(100000, 0))
+}
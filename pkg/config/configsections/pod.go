@@ -16,6 +16,11 @@
 
 package configsections
 
+import (
+	"path"
+	"strconv"
+)
+
 // Pod defines cloud network function in the cluster
 type Pod struct {
 	// Name is the name of a single Pod to test
@@ -30,6 +35,103 @@ type Pod struct {
 	// ContainerCount is the count of containers inside the pod
 	ContainerCount int `yaml:"containercount" json:"containercount"`
 
+	// ContainerNames are the names of the containers inside the pod, in the order of its spec
+	ContainerNames []string `yaml:"containerNames,omitempty" json:"containerNames,omitempty"`
+
 	// Tests this is list of test that need to run against the Pod.
 	Tests []string `yaml:"tests" json:"tests"`
+
+	// Labels are the labels of the Pod, matched by the Service and NetworkPolicy selectors
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// Ports are the ports declared by the containers of the Pod
+	Ports []ContainerPort `yaml:"ports,omitempty" json:"ports,omitempty"`
+
+	// HostNamespaceExemptions are the host namespaces the Pod is allowed to share with its node, among hostNetwork,
+	// hostPID and hostIPC
+	HostNamespaceExemptions []string `yaml:"hostNamespaceExemptions,omitempty" json:"hostNamespaceExemptions,omitempty"`
+
+	// APIAccess declares that the Pod accesses the Kubernetes API, and thus needs its service account token
+	APIAccess bool `yaml:"apiAccess,omitempty" json:"apiAccess,omitempty"`
+
+	// LatencySensitive declares that the Pod is latency-sensitive, and thus needs the Guaranteed QoS class
+	LatencySensitive bool `yaml:"latencySensitive,omitempty" json:"latencySensitive,omitempty"`
+
+	// SIGTERMHandler declares that the containers of the Pod handle SIGTERM to shut down gracefully, and thus do not
+	// need a preStop hook
+	SIGTERMHandler bool `yaml:"sigtermHandler,omitempty" json:"sigtermHandler,omitempty"`
+
+	// ContainerTestSkips are the container test cases the containers of the Pod are opted out of, by container name,
+	// e.g. a sidecar not part of the CNF.  The test case IDs may be shell patterns, e.g. "lifecycle-*".
+	ContainerTestSkips map[string][]string `yaml:"containerTestSkips,omitempty" json:"containerTestSkips,omitempty"`
+}
+
+// ContainerPort is a port declared by a container of a Pod.
+type ContainerPort struct {
+	Name          string `yaml:"name,omitempty" json:"name,omitempty"`
+	ContainerPort int    `yaml:"containerPort" json:"containerPort"`
+	// Protocol is TCP, UDP or SCTP, TCP when not set.
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	// HostPort is the port of the node the container port is bound to, if any.
+	HostPort int `yaml:"hostPort,omitempty" json:"hostPort,omitempty"`
+}
+
+// String returns the port as "number/protocol", e.g. "8080/TCP".
+func (c ContainerPort) String() string {
+	return strconv.Itoa(c.ContainerPort) + "/" + defaultProtocol(c.Protocol)
+}
+
+// FullName returns the name of the Pod as "namespace/name".
+func (p *Pod) FullName() string {
+	return p.Namespace + "/" + p.Name
+}
+
+// IsHostNamespaceExempted returns true when the Pod is allowed to share the host namespace, e.g. "hostNetwork".
+func (p *Pod) IsHostNamespaceExempted(hostNamespace string) bool {
+	for _, exempted := range p.HostNamespaceExemptions {
+		if exempted == hostNamespace {
+			return true
+		}
+	}
+	return false
+}
+
+// SkipsContainerTest returns true when the container of the Pod is opted out of the test case testID.
+func (p *Pod) SkipsContainerTest(testID, containerName string) bool {
+	for _, pattern := range p.ContainerTestSkips[containerName] {
+		if matched, err := path.Match(pattern, testID); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// GetContainerName returns the name of the container of the Pod at index in its spec, empty when unknown.
+func (p *Pod) GetContainerName(index int) string {
+	if index < len(p.ContainerNames) {
+		return p.ContainerNames[index]
+	}
+	return ""
+}
+
+// FindPort returns the declared port a Service or NetworkPolicy port refers to, by number or by name, with protocol.
+func (p *Pod) FindPort(port, protocol string) (ContainerPort, bool) {
+	protocol = defaultProtocol(protocol)
+	for _, declared := range p.Ports {
+		if defaultProtocol(declared.Protocol) != protocol {
+			continue
+		}
+		if port == declared.Name || port == strconv.Itoa(declared.ContainerPort) {
+			return declared, true
+		}
+	}
+	return ContainerPort{}, false
+}
+
+// defaultProtocol returns protocol, TCP when not set.
+func defaultProtocol(protocol string) string {
+	if protocol == "" {
+		return ProtocolTCP
+	}
+	return protocol
 }
@@ -0,0 +1,29 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+// ProgressEvents configures the streaming of the lifecycle events of the tests, e.g. to show the live progress of long
+// runs on a dashboard.  The token is read from the environment variable it names, the configuration being recorded in
+// the claim.
+type ProgressEvents struct {
+	// File is the path the events are appended to, one JSON event per line, e.g. /dev/fd/3 for a file descriptor
+	// opened by the caller.  The standard output is left to the logs and the reports.
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+	// URL is the webhook the events are POSTed to, one JSON event per request.
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+	// TokenEnv is the environment variable holding the bearer token of the webhook requests, if any.
+	TokenEnv string `yaml:"tokenEnv,omitempty" json:"tokenEnv,omitempty"`
+}
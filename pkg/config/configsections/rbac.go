@@ -0,0 +1,145 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+const (
+	// KindRole is the kind of the namespaced roles.
+	KindRole = "Role"
+	// KindClusterRole is the kind of the cluster roles.
+	KindClusterRole = "ClusterRole"
+	// KindRoleBinding is the kind of the namespaced role bindings.
+	KindRoleBinding = "RoleBinding"
+	// KindClusterRoleBinding is the kind of the cluster role bindings.
+	KindClusterRoleBinding = "ClusterRoleBinding"
+	// KindServiceAccount is the kind of the service account subjects.
+	KindServiceAccount = "ServiceAccount"
+	// ClusterAdminRole is the ClusterRole granting every permission on the cluster.
+	ClusterAdminRole = "cluster-admin"
+	// DefaultServiceAccount is the service account of the pods which do not set one.
+	DefaultServiceAccount = "default"
+
+	rbacWildcard = "*"
+)
+
+// RoleRef is the role granted by a binding.
+type RoleRef struct {
+	// Kind is KindRole or KindClusterRole.
+	Kind string `yaml:"kind" json:"kind"`
+	Name string `yaml:"name" json:"name"`
+}
+
+// RBACSubject is a user, group or service account a binding grants a role to.
+type RBACSubject struct {
+	Kind      string `yaml:"kind" json:"kind"`
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Name      string `yaml:"name" json:"name"`
+}
+
+// RoleBinding is a RoleBinding or a ClusterRoleBinding granting a role to service accounts of the pods under test.
+type RoleBinding struct {
+	// Kind is KindRoleBinding or KindClusterRoleBinding.
+	Kind string `yaml:"kind" json:"kind"`
+	// Namespace is empty for a ClusterRoleBinding.
+	Namespace string        `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Name      string        `yaml:"name" json:"name"`
+	RoleRef   RoleRef       `yaml:"roleRef" json:"roleRef"`
+	Subjects  []RBACSubject `yaml:"subjects,omitempty" json:"subjects,omitempty"`
+}
+
+// PolicyRule is a set of permissions of a role.
+type PolicyRule struct {
+	APIGroups []string `yaml:"apiGroups,omitempty" json:"apiGroups,omitempty"`
+	Resources []string `yaml:"resources,omitempty" json:"resources,omitempty"`
+	Verbs     []string `yaml:"verbs" json:"verbs"`
+}
+
+// Role is a Role or a ClusterRole granted to service accounts of the pods under test.
+type Role struct {
+	// Kind is KindRole or KindClusterRole.
+	Kind string `yaml:"kind" json:"kind"`
+	// Namespace is empty for a ClusterRole.
+	Namespace string       `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Name      string       `yaml:"name" json:"name"`
+	Rules     []PolicyRule `yaml:"rules,omitempty" json:"rules,omitempty"`
+}
+
+// String returns the kind and the name of the binding, e.g. "RoleBinding tnf/test" or "ClusterRoleBinding test".
+func (b *RoleBinding) String() string {
+	if b.Namespace == "" {
+		return b.Kind + " " + b.Name
+	}
+	return b.Kind + " " + b.Namespace + "/" + b.Name
+}
+
+// BindsServiceAccount returns true when the binding grants its role to the service account, "default" when empty.
+// The service account subjects of a RoleBinding without namespace belong to the namespace of the binding.
+func (b *RoleBinding) BindsServiceAccount(namespace, name string) bool {
+	if name == "" {
+		name = DefaultServiceAccount
+	}
+	for _, subject := range b.Subjects {
+		if subject.Kind != KindServiceAccount || subject.Name != name {
+			continue
+		}
+		subjectNamespace := subject.Namespace
+		if subjectNamespace == "" {
+			subjectNamespace = b.Namespace
+		}
+		if subjectNamespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// FindRole returns the role granted by the binding among roles, nil when not found: the Role of the namespace of the
+// binding, or the ClusterRole.
+func (b *RoleBinding) FindRole(roles []Role) *Role {
+	for i := range roles {
+		role := &roles[i]
+		if role.Kind != b.RoleRef.Kind || role.Name != b.RoleRef.Name {
+			continue
+		}
+		if role.Kind == KindClusterRole || role.Namespace == b.Namespace {
+			return role
+		}
+	}
+	return nil
+}
+
+// HasWildcardVerb returns true when a rule of the role allows every verb.
+func (r *Role) HasWildcardVerb() bool {
+	for _, rule := range r.Rules {
+		for _, verb := range rule.Verbs {
+			if verb == rbacWildcard {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetServiceAccountBindings returns the bindings granting a role to the service account of the pod.
+func (t *TestTarget) GetServiceAccountBindings(pod *Pod) []RoleBinding {
+	var bindings []RoleBinding
+	for i := range t.RoleBindings {
+		if t.RoleBindings[i].BindsServiceAccount(pod.Namespace, pod.ServiceAccount) {
+			bindings = append(bindings, t.RoleBindings[i])
+		}
+	}
+	return bindings
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+var (
+	testClusterRoleBinding = configsections.RoleBinding{
+		Kind: configsections.KindClusterRoleBinding, Name: "tnf-admin",
+		RoleRef:  configsections.RoleRef{Kind: configsections.KindClusterRole, Name: configsections.ClusterAdminRole},
+		Subjects: []configsections.RBACSubject{{Kind: configsections.KindServiceAccount, Namespace: "tnf", Name: "test"}},
+	}
+	testRoleBinding = configsections.RoleBinding{
+		Kind: configsections.KindRoleBinding, Namespace: "tnf", Name: "tnf-reader",
+		RoleRef: configsections.RoleRef{Kind: configsections.KindRole, Name: "reader"},
+		Subjects: []configsections.RBACSubject{
+			{Kind: "User", Name: "default"},
+			{Kind: configsections.KindServiceAccount, Name: "default"},
+		},
+	}
+	testRoles = []configsections.Role{
+		{Kind: configsections.KindRole, Namespace: "other", Name: "reader"},
+		{Kind: configsections.KindRole, Namespace: "tnf", Name: "reader",
+			Rules: []configsections.PolicyRule{{Resources: []string{"pods"}, Verbs: []string{"get", "list"}}}},
+		{Kind: configsections.KindClusterRole, Name: configsections.ClusterAdminRole,
+			Rules: []configsections.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}}},
+	}
+)
+
+func TestRoleBinding_String(t *testing.T) {
+	assert.Equal(t, "ClusterRoleBinding tnf-admin", testClusterRoleBinding.String())
+	assert.Equal(t, "RoleBinding tnf/tnf-reader", testRoleBinding.String())
+}
+
+func TestRoleBinding_BindsServiceAccount(t *testing.T) {
+	assert.True(t, testClusterRoleBinding.BindsServiceAccount("tnf", "test"))
+	assert.False(t, testClusterRoleBinding.BindsServiceAccount("other", "test"))
+	// the subject namespace defaults to the namespace of the RoleBinding, the service account to "default".
+	assert.True(t, testRoleBinding.BindsServiceAccount("tnf", ""))
+	assert.False(t, testRoleBinding.BindsServiceAccount("other", "default"))
+}
+
+func TestRoleBinding_FindRole(t *testing.T) {
+	assert.Equal(t, &testRoles[1], testRoleBinding.FindRole(testRoles))
+	assert.Equal(t, &testRoles[2], testClusterRoleBinding.FindRole(testRoles))
+	assert.Nil(t, testRoleBinding.FindRole(testRoles[2:]))
+}
+
+func TestRole_HasWildcardVerb(t *testing.T) {
+	assert.False(t, testRoles[1].HasWildcardVerb())
+	assert.True(t, testRoles[2].HasWildcardVerb())
+}
+
+func TestTestTarget_GetServiceAccountBindings(t *testing.T) {
+	target := configsections.TestTarget{RoleBindings: []configsections.RoleBinding{testClusterRoleBinding, testRoleBinding}}
+	assert.Equal(t, []configsections.RoleBinding{testClusterRoleBinding},
+		target.GetServiceAccountBindings(&configsections.Pod{Namespace: "tnf", Name: "test-0", ServiceAccount: "test"}))
+	assert.Equal(t, []configsections.RoleBinding{testRoleBinding},
+		target.GetServiceAccountBindings(&configsections.Pod{Namespace: "tnf", Name: "test-1"}))
+	assert.Nil(t, target.GetServiceAccountBindings(&configsections.Pod{Namespace: "other", Name: "test-0"}))
+}
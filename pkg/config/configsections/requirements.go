@@ -0,0 +1,36 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+// PlatformRequirements are the platform features a CNF requires from the nodes of the cluster.
+type PlatformRequirements struct {
+	// SCTP requires the sctp kernel module to be loaded.
+	SCTP bool `yaml:"sctp,omitempty" json:"sctp,omitempty"`
+	// SRIOV requires SR-IOV capable network interfaces, i.e. supporting virtual functions.
+	SRIOV bool `yaml:"sriov,omitempty" json:"sriov,omitempty"`
+	// HugepageSizes are the required hugepage sizes, e.g. "2M" or "1G".
+	HugepageSizes []string `yaml:"hugepageSizes,omitempty" json:"hugepageSizes,omitempty"`
+	// KernelModules are the kernel modules required to be loaded.
+	KernelModules []string `yaml:"kernelModules,omitempty" json:"kernelModules,omitempty"`
+	// MinKernelVersion is the minimum kernel version, e.g. "4.18.0-305".
+	MinKernelVersion string `yaml:"minKernelVersion,omitempty" json:"minKernelVersion,omitempty"`
+}
+
+// IsEmpty returns true when no requirement is declared.
+func (r *PlatformRequirements) IsEmpty() bool {
+	return !r.SCTP && !r.SRIOV && len(r.HugepageSizes) == 0 && len(r.KernelModules) == 0 && r.MinKernelVersion == ""
+}
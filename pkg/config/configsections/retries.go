@@ -0,0 +1,56 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import "time"
+
+// RetryPolicy retries the tests which fail or error, e.g. the connectivity tests failing because of transient CNI
+// issues.  Every attempt is recorded in the claim.
+type RetryPolicy struct {
+	// Attempts is the maximum number of runs of a test.
+	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty"`
+	// Backoff is the delay before the second attempt, e.g. "5s".
+	Backoff time.Duration `yaml:"backoff,omitempty" json:"backoff,omitempty"`
+	// Multiplier multiplies the delay before each subsequent attempt, the delay is constant unless it is above 1.
+	Multiplier float64 `yaml:"multiplier,omitempty" json:"multiplier,omitempty"`
+}
+
+// Retries configures the retry policies of the tests, the tests are not retried by default.
+type Retries struct {
+	// Default is the policy of all the tests.
+	Default RetryPolicy `yaml:"default,omitempty" json:"default,omitempty"`
+	// Suites are the policies of the tests run by a suite, by suite name, e.g. "networking".
+	Suites map[string]RetryPolicy `yaml:"suites,omitempty" json:"suites,omitempty"`
+	// Handlers are the policies of the tests run by a handler, by handler name, e.g. "ping".  They take precedence
+	// over the suite policies.
+	Handlers map[string]RetryPolicy `yaml:"handlers,omitempty" json:"handlers,omitempty"`
+}
+
+// Get returns the retry policy of a handler run by a suite: the handler policy if set, else the suite policy, else the
+// default policy, else a policy running the tests once.
+func (r *Retries) Get(suite, handler string) RetryPolicy {
+	if policy, ok := r.Handlers[handler]; ok {
+		return policy
+	}
+	if policy, ok := r.Suites[suite]; ok {
+		return policy
+	}
+	if r.Default.Attempts > 0 {
+		return r.Default
+	}
+	return RetryPolicy{Attempts: 1}
+}
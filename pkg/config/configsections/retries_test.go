@@ -0,0 +1,52 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"gopkg.in/yaml.v2"
+)
+
+const retriesYAML = `
+default:
+  attempts: 2
+  backoff: 1s
+suites:
+  networking:
+    attempts: 3
+    backoff: 5s
+    multiplier: 2
+handlers:
+  nodeport:
+    attempts: 1
+`
+
+func TestRetriesGet(t *testing.T) {
+	var retries configsections.Retries
+	assert.Nil(t, yaml.Unmarshal([]byte(retriesYAML), &retries))
+
+	assert.Equal(t, configsections.RetryPolicy{Attempts: 3, Backoff: 5 * time.Second, Multiplier: 2}, retries.Get("networking", "ping"))
+	assert.Equal(t, configsections.RetryPolicy{Attempts: 1}, retries.Get("networking", "nodeport"))
+	assert.Equal(t, configsections.RetryPolicy{Attempts: 2, Backoff: time.Second}, retries.Get("lifecycle", "scaling"))
+
+	var unset configsections.Retries
+	assert.Equal(t, configsections.RetryPolicy{Attempts: 1}, unset.Get("networking", "ping"))
+}
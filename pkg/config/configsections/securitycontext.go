@@ -0,0 +1,70 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+import (
+	"path"
+	"strings"
+)
+
+// SecurityContext lists the containers under test accepted to run with elevated privileges, e.g. the CNF components
+// which must manage the network of the node.  The containers are matched as "namespace/pod/container", where each
+// element may be a shell pattern, e.g. "tnf/router-*/*".
+type SecurityContext struct {
+	// AllowedPrivilegedContainers are the containers accepted to run privileged.
+	AllowedPrivilegedContainers []string `yaml:"allowedPrivilegedContainers,omitempty" json:"allowedPrivilegedContainers,omitempty"`
+	// AllowedCapabilities are the containers accepted to add a capability, by capability, e.g. "NET_ADMIN".
+	AllowedCapabilities map[string][]string `yaml:"allowedCapabilities,omitempty" json:"allowedCapabilities,omitempty"`
+	// AllowedRootContainers are the containers accepted to run as root.
+	AllowedRootContainers []string `yaml:"allowedRootContainers,omitempty" json:"allowedRootContainers,omitempty"`
+}
+
+// AllowsPrivileged returns true when the container is accepted to run privileged.
+func (s *SecurityContext) AllowsPrivileged(container *ContainerIdentifier) bool {
+	return matchesContainer(s.AllowedPrivilegedContainers, container)
+}
+
+// AllowsCapability returns true when the container is accepted to add the capability.  The capabilities are compared
+// without their "CAP_" prefix.
+func (s *SecurityContext) AllowsCapability(capability string, container *ContainerIdentifier) bool {
+	for allowed, patterns := range s.AllowedCapabilities {
+		if NormalizeCapability(allowed) == NormalizeCapability(capability) && matchesContainer(patterns, container) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRoot returns true when the container is accepted to run as root.
+func (s *SecurityContext) AllowsRoot(container *ContainerIdentifier) bool {
+	return matchesContainer(s.AllowedRootContainers, container)
+}
+
+// NormalizeCapability returns the name of a capability without its "CAP_" prefix, as used in the pod specs.
+func NormalizeCapability(capability string) string {
+	return strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+}
+
+// matchesContainer returns true when one of the "namespace/pod/container" patterns matches the container.
+func matchesContainer(patterns []string, container *ContainerIdentifier) bool {
+	name := container.Namespace + "/" + container.PodName + "/" + container.ContainerName
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestSecurityContext_Allows(t *testing.T) {
+	securityContext := configsections.SecurityContext{
+		AllowedPrivilegedContainers: []string{"tnf/router-*/*"},
+		AllowedCapabilities: map[string][]string{
+			"NET_ADMIN":    {"tnf/router-*/dataplane", "tnf/test/test"},
+			"CAP_SYS_NICE": {"tnf/*/*"},
+		},
+		AllowedRootContainers: []string{"tnf/test/test"},
+	}
+	router := &configsections.ContainerIdentifier{Namespace: "tnf", PodName: "router-5d8f", ContainerName: "dataplane"}
+	test := &configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test", ContainerName: "test"}
+	other := &configsections.ContainerIdentifier{Namespace: "other", PodName: "test", ContainerName: "test"}
+
+	assert.True(t, securityContext.AllowsPrivileged(router))
+	assert.False(t, securityContext.AllowsPrivileged(test))
+
+	assert.True(t, securityContext.AllowsCapability("NET_ADMIN", router))
+	assert.True(t, securityContext.AllowsCapability("CAP_NET_ADMIN", test))
+	assert.False(t, securityContext.AllowsCapability("NET_ADMIN", other))
+	assert.False(t, securityContext.AllowsCapability("SYS_ADMIN", router))
+	assert.True(t, securityContext.AllowsCapability("SYS_NICE", test))
+
+	assert.True(t, securityContext.AllowsRoot(test))
+	assert.False(t, securityContext.AllowsRoot(router))
+}
+
+func TestNormalizeCapability(t *testing.T) {
+	assert.Equal(t, "NET_ADMIN", configsections.NormalizeCapability("CAP_NET_ADMIN"))
+	assert.Equal(t, "NET_ADMIN", configsections.NormalizeCapability("net_admin"))
+}
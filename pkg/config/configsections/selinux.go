@@ -0,0 +1,33 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+// DefaultSELinuxLabelType is the SELinux type the container runtime assigns to the processes of the unprivileged
+// containers.
+const DefaultSELinuxLabelType = "container_t"
+
+// SELinux lists the containers under test accepted to run with another SELinux type than DefaultSELinuxLabelType,
+// e.g. the privileged containers which run as spc_t.
+type SELinux struct {
+	// AllowedLabelTypes are the containers accepted to run with a SELinux type, by type, as "namespace/pod/container"
+	// where each element may be a shell pattern.
+	AllowedLabelTypes map[string][]string `yaml:"allowedLabelTypes,omitempty" json:"allowedLabelTypes,omitempty"`
+}
+
+// AllowsLabelType returns true when the container is accepted to run with the SELinux type labelType.
+func (s *SELinux) AllowsLabelType(labelType string, container *ContainerIdentifier) bool {
+	return labelType == DefaultSELinuxLabelType || matchesContainer(s.AllowedLabelTypes[labelType], container)
+}
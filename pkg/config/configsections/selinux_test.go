@@ -0,0 +1,33 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestSELinux_AllowsLabelType(t *testing.T) {
+	seLinux := configsections.SELinux{AllowedLabelTypes: map[string][]string{"spc_t": {"tnf/router-*/dataplane"}}}
+	router := &configsections.ContainerIdentifier{Namespace: "tnf", PodName: "router-5d8f", ContainerName: "dataplane"}
+	test := &configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test", ContainerName: "test"}
+	assert.True(t, seLinux.AllowsLabelType(configsections.DefaultSELinuxLabelType, test))
+	assert.True(t, seLinux.AllowsLabelType("spc_t", router))
+	assert.False(t, seLinux.AllowsLabelType("spc_t", test))
+	assert.False(t, seLinux.AllowsLabelType("unconfined_t", router))
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+import "strconv"
+
+const (
+	// ProtocolTCP is the default protocol of the container, Service and NetworkPolicy ports.
+	ProtocolTCP = "TCP"
+	// PolicyTypeIngress is the NetworkPolicy type restricting the traffic to the selected pods.
+	PolicyTypeIngress = "Ingress"
+
+	selectorOpIn           = "In"
+	selectorOpNotIn        = "NotIn"
+	selectorOpExists       = "Exists"
+	selectorOpDoesNotExist = "DoesNotExist"
+)
+
+// ServicePort is a port exposed by a Service.
+type ServicePort struct {
+	Name     string `yaml:"name,omitempty" json:"name,omitempty"`
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	Port     int    `yaml:"port" json:"port"`
+	// TargetPort is the number or the name of the port of the selected pods, Port when not set.
+	TargetPort string `yaml:"targetPort,omitempty" json:"targetPort,omitempty"`
+	NodePort   int    `yaml:"nodePort,omitempty" json:"nodePort,omitempty"`
+}
+
+// GetTargetPort returns the number or the name of the port of the selected pods.
+func (p *ServicePort) GetTargetPort() string {
+	if p.TargetPort == "" {
+		return strconv.Itoa(p.Port)
+	}
+	return p.TargetPort
+}
+
+// Service is a Service of the target namespace.
+type Service struct {
+	Namespace string `yaml:"namespace" json:"namespace"`
+	Name      string `yaml:"name" json:"name"`
+	// Type is ClusterIP, NodePort, LoadBalancer or ExternalName.
+	Type     string            `yaml:"type,omitempty" json:"type,omitempty"`
+	Selector map[string]string `yaml:"selector,omitempty" json:"selector,omitempty"`
+	Ports    []ServicePort     `yaml:"ports,omitempty" json:"ports,omitempty"`
+}
+
+// FullName returns the name of the Service as "namespace/name".
+func (s *Service) FullName() string {
+	return s.Namespace + "/" + s.Name
+}
+
+// NodePorts returns the node ports allocated to the Service, e.g. by the NodePort and LoadBalancer Services.
+func (s *Service) NodePorts() []int {
+	var nodePorts []int
+	for _, port := range s.Ports {
+		if port.NodePort != 0 {
+			nodePorts = append(nodePorts, port.NodePort)
+		}
+	}
+	return nodePorts
+}
+
+// Selects returns true when the Service exposes pod.  The Services without selector, whose endpoints are managed
+// manually, select no pod.
+func (s *Service) Selects(pod *Pod) bool {
+	if s.Namespace != pod.Namespace || len(s.Selector) == 0 {
+		return false
+	}
+	for key, value := range s.Selector {
+		if label, ok := pod.Labels[key]; !ok || label != value {
+			return false
+		}
+	}
+	return true
+}
+
+// LabelSelectorRequirement is a match expression of a LabelSelector.
+type LabelSelectorRequirement struct {
+	Key      string   `yaml:"key" json:"key"`
+	Operator string   `yaml:"operator" json:"operator"`
+	Values   []string `yaml:"values,omitempty" json:"values,omitempty"`
+}
+
+// LabelSelector selects the objects by label, all its labels and expressions must match.  The empty selector
+// selects all the objects.
+type LabelSelector struct {
+	MatchLabels      map[string]string          `yaml:"matchLabels,omitempty" json:"matchLabels,omitempty"`
+	MatchExpressions []LabelSelectorRequirement `yaml:"matchExpressions,omitempty" json:"matchExpressions,omitempty"`
+}
+
+// Matches returns true when labels match the selector.
+func (s *LabelSelector) Matches(labels map[string]string) bool {
+	for key, value := range s.MatchLabels {
+		if label, ok := labels[key]; !ok || label != value {
+			return false
+		}
+	}
+	for _, requirement := range s.MatchExpressions {
+		label, ok := labels[requirement.Key]
+		switch requirement.Operator {
+		case selectorOpIn:
+			if !ok || !contains(requirement.Values, label) {
+				return false
+			}
+		case selectorOpNotIn:
+			if ok && contains(requirement.Values, label) {
+				return false
+			}
+		case selectorOpExists:
+			if !ok {
+				return false
+			}
+		case selectorOpDoesNotExist:
+			if ok {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// NetworkPolicyPort is a port allowed by a NetworkPolicy rule.
+type NetworkPolicyPort struct {
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	// Port is the number or the name of the port, all the ports when not set.
+	Port string `yaml:"port,omitempty" json:"port,omitempty"`
+	// EndPort makes the rule allow the range of ports from Port to EndPort.
+	EndPort int `yaml:"endPort,omitempty" json:"endPort,omitempty"`
+}
+
+// NetworkPolicyRule is an ingress rule of a NetworkPolicy, only its ports are kept.
+type NetworkPolicyRule struct {
+	// Ports are the ports the rule allows, all the ports when empty.
+	Ports []NetworkPolicyPort `yaml:"ports,omitempty" json:"ports,omitempty"`
+}
+
+// NetworkPolicy is a NetworkPolicy of the target namespace.
+type NetworkPolicy struct {
+	Namespace   string        `yaml:"namespace" json:"namespace"`
+	Name        string        `yaml:"name" json:"name"`
+	PodSelector LabelSelector `yaml:"podSelector" json:"podSelector"`
+	// PolicyTypes are Ingress and/or Egress, Ingress when not set.
+	PolicyTypes []string            `yaml:"policyTypes,omitempty" json:"policyTypes,omitempty"`
+	Ingress     []NetworkPolicyRule `yaml:"ingress,omitempty" json:"ingress,omitempty"`
+}
+
+// FullName returns the name of the NetworkPolicy as "namespace/name".
+func (n *NetworkPolicy) FullName() string {
+	return n.Namespace + "/" + n.Name
+}
+
+// RestrictsIngress returns true when the NetworkPolicy restricts the traffic to pod.
+func (n *NetworkPolicy) RestrictsIngress(pod *Pod) bool {
+	if n.Namespace != pod.Namespace || !n.PodSelector.Matches(pod.Labels) {
+		return false
+	}
+	return len(n.PolicyTypes) == 0 || contains(n.PolicyTypes, PolicyTypeIngress)
+}
+
+// AllowsIngress returns true when a rule of the NetworkPolicy allows the traffic to the declared port of pod.
+func (n *NetworkPolicy) AllowsIngress(pod *Pod, port ContainerPort) bool {
+	for i := range n.Ingress {
+		rule := &n.Ingress[i]
+		if len(rule.Ports) == 0 {
+			return true
+		}
+		for j := range rule.Ports {
+			if rule.Ports[j].allows(pod, port) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allows returns true when the NetworkPolicy port matches the declared port of pod.
+func (p *NetworkPolicyPort) allows(pod *Pod, port ContainerPort) bool {
+	if defaultProtocol(p.Protocol) != defaultProtocol(port.Protocol) {
+		return false
+	}
+	if p.Port == "" {
+		return true
+	}
+	if p.EndPort != 0 {
+		start, err := strconv.Atoi(p.Port)
+		return err == nil && port.ContainerPort >= start && port.ContainerPort <= p.EndPort
+	}
+	declared, ok := pod.FindPort(p.Port, p.Protocol)
+	return ok && declared.ContainerPort == port.ContainerPort
+}
+
+// contains returns true when values contains value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
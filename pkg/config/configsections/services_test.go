@@ -0,0 +1,116 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func testPod() *configsections.Pod {
+	return &configsections.Pod{
+		Namespace: "tnf",
+		Name:      "test",
+		Labels:    map[string]string{"app": "test", "tier": "backend"},
+		Ports: []configsections.ContainerPort{
+			{Name: "http", ContainerPort: 8080},
+			{Name: "sip", ContainerPort: 5060, Protocol: "UDP"},
+		},
+	}
+}
+
+func TestPod_FindPort(t *testing.T) {
+	pod := testPod()
+	port, ok := pod.FindPort("http", "")
+	assert.True(t, ok)
+	assert.Equal(t, 8080, port.ContainerPort)
+	_, ok = pod.FindPort("8080", "TCP")
+	assert.True(t, ok)
+	_, ok = pod.FindPort("5060", "TCP")
+	assert.False(t, ok)
+	port, ok = pod.FindPort("sip", "UDP")
+	assert.True(t, ok)
+	assert.Equal(t, "5060/UDP", port.String())
+	assert.Equal(t, "8080/TCP", pod.Ports[0].String())
+}
+
+func TestService_Selects(t *testing.T) {
+	pod := testPod()
+	service := configsections.Service{Namespace: "tnf", Selector: map[string]string{"app": "test"}}
+	assert.True(t, service.Selects(pod))
+	service.Selector["tier"] = "frontend"
+	assert.False(t, service.Selects(pod))
+	// the services without selector, or in another namespace, select no pod.
+	assert.False(t, (&configsections.Service{Namespace: "tnf"}).Selects(pod))
+	assert.False(t, (&configsections.Service{Namespace: "other", Selector: map[string]string{"app": "test"}}).Selects(pod))
+	assert.Equal(t, "8080", (&configsections.ServicePort{Port: 8080}).GetTargetPort())
+}
+
+func TestLabelSelector_Matches(t *testing.T) {
+	labels := testPod().Labels
+	testCases := []struct {
+		selector configsections.LabelSelector
+		expected bool
+	}{
+		{selector: configsections.LabelSelector{}, expected: true},
+		{selector: configsections.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, expected: true},
+		{selector: configsections.LabelSelector{MatchLabels: map[string]string{"app": "other"}}, expected: false},
+		{selector: configsections.LabelSelector{MatchExpressions: []configsections.LabelSelectorRequirement{
+			{Key: "tier", Operator: "In", Values: []string{"backend", "db"}},
+			{Key: "version", Operator: "DoesNotExist"},
+		}}, expected: true},
+		{selector: configsections.LabelSelector{MatchExpressions: []configsections.LabelSelectorRequirement{
+			{Key: "tier", Operator: "NotIn", Values: []string{"backend"}},
+		}}, expected: false},
+		{selector: configsections.LabelSelector{MatchExpressions: []configsections.LabelSelectorRequirement{
+			{Key: "version", Operator: "Exists"},
+		}}, expected: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.selector.Matches(labels), tc.selector)
+	}
+}
+
+func TestNetworkPolicy_AllowsIngress(t *testing.T) {
+	pod := testPod()
+	http, _ := pod.FindPort("http", "")
+	sip, _ := pod.FindPort("sip", "UDP")
+
+	denyAll := configsections.NetworkPolicy{Namespace: "tnf"}
+	assert.True(t, denyAll.RestrictsIngress(pod))
+	assert.False(t, denyAll.AllowsIngress(pod, http))
+
+	egressOnly := configsections.NetworkPolicy{Namespace: "tnf", PolicyTypes: []string{"Egress"}}
+	assert.False(t, egressOnly.RestrictsIngress(pod))
+
+	allowAll := configsections.NetworkPolicy{Namespace: "tnf", Ingress: []configsections.NetworkPolicyRule{{}}}
+	assert.True(t, allowAll.AllowsIngress(pod, sip))
+
+	allowHTTP := configsections.NetworkPolicy{Namespace: "tnf", Ingress: []configsections.NetworkPolicyRule{
+		{Ports: []configsections.NetworkPolicyPort{{Port: "http"}}},
+	}}
+	assert.True(t, allowHTTP.AllowsIngress(pod, http))
+	assert.False(t, allowHTTP.AllowsIngress(pod, sip))
+
+	allowRange := configsections.NetworkPolicy{Namespace: "tnf", Ingress: []configsections.NetworkPolicyRule{
+		{Ports: []configsections.NetworkPolicyPort{{Protocol: "UDP", Port: "5000", EndPort: 5100}}},
+	}}
+	assert.True(t, allowRange.AllowsIngress(pod, sip))
+	assert.False(t, allowRange.AllowsIngress(pod, http))
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+// Subscription configures the health check of the OLM subscriptions of the operators under test.
+type Subscription struct {
+	// ExpectedChannels maps the package, or the subscription name, of an operator to the channel its subscription must
+	// follow, e.g. "nginx-operator: stable".
+	ExpectedChannels map[string]string `yaml:"expectedChannels,omitempty" json:"expectedChannels,omitempty"`
+}
+
+// GetExpectedChannel returns the channel expected for the subscription of the operator, looked up by package first,
+// then by subscription name.  Returns empty when no channel is configured for the operator.
+func (s *Subscription) GetExpectedChannel(op *Operator) string {
+	if channel, ok := s.ExpectedChannels[op.Package]; ok && op.Package != "" {
+		return channel
+	}
+	return s.ExpectedChannels[op.SubscriptionName]
+}
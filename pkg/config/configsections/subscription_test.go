@@ -0,0 +1,35 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestSubscription_GetExpectedChannel(t *testing.T) {
+	subscription := configsections.Subscription{ExpectedChannels: map[string]string{
+		"nginx-operator": "stable",
+		"etcd":           "alpha",
+	}}
+	assert.Equal(t, "stable", subscription.GetExpectedChannel(&configsections.Operator{Package: "nginx-operator",
+		SubscriptionName: "etcd"}))
+	assert.Equal(t, "alpha", subscription.GetExpectedChannel(&configsections.Operator{SubscriptionName: "etcd"}))
+	assert.Equal(t, "", subscription.GetExpectedChannel(&configsections.Operator{Package: "other", SubscriptionName: "other"}))
+	assert.Equal(t, "", (&configsections.Subscription{}).GetExpectedChannel(&configsections.Operator{Package: "etcd"}))
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+// TestGroup tags test cases with an owning team and groups them into a custom suite, e.g. "security-team" or
+// "netops", so that the claim summary can be broken down per group.
+type TestGroup struct {
+	// Name of the group.
+	Name string `yaml:"name" json:"name" required:"true"`
+	// Owners are the teams or people in charge of the group test cases.
+	Owners []string `yaml:"owners,omitempty" json:"owners,omitempty"`
+	// TestCases are regular expressions matching the test case names, e.g. "access-control-.*" or
+	// "lifecycle-pod-owner-type".  The name of the http://test-network-function.com/testcases/<suite>/<name> test case
+	// is "<suite>-<name>".
+	TestCases []string `yaml:"testCases" json:"testCases"`
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import "time"
+
+// Timeouts overrides the default timeout of the tests, e.g. for slow clusters.  The durations are formatted as
+// "30s" or "2m".
+type Timeouts struct {
+	// Default replaces the default timeout of all the tests.
+	Default time.Duration `yaml:"default,omitempty" json:"default,omitempty"`
+	// Suites are the timeouts of the tests run by a suite, by suite name, e.g. "lifecycle".
+	Suites map[string]time.Duration `yaml:"suites,omitempty" json:"suites,omitempty"`
+	// Handlers are the timeouts of the tests run by a handler, by handler name, e.g. "ping".  They take precedence over
+	// the suite timeouts.
+	Handlers map[string]time.Duration `yaml:"handlers,omitempty" json:"handlers,omitempty"`
+}
+
+// Get returns the timeout of a handler run by a suite: the handler timeout if set, else the suite timeout, else the
+// default timeout, else fallback.  The suite is empty outside the suites, e.g. during the autodiscovery.
+func (t *Timeouts) Get(suite, handler string, fallback time.Duration) time.Duration {
+	if timeout, ok := t.Handlers[handler]; ok && timeout > 0 {
+		return timeout
+	}
+	if timeout, ok := t.Suites[suite]; ok && timeout > 0 {
+		return timeout
+	}
+	if t.Default > 0 {
+		return t.Default
+	}
+	return fallback
+}
+
+// GetWait returns how long a suite waits for the CNF to reach a state, e.g. the deployments to be ready again once
+// scaled: the timeout set for name under handlers if any, else fallback.  The suite and default timeouts, meant for
+// single commands, do not apply.
+func (t *Timeouts) GetWait(name string, fallback time.Duration) time.Duration {
+	if timeout, ok := t.Handlers[name]; ok && timeout > 0 {
+		return timeout
+	}
+	return fallback
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"gopkg.in/yaml.v2"
+)
+
+const timeoutsYAML = `
+default: 20s
+suites:
+  lifecycle: 1m
+handlers:
+  ping: 45s
+`
+
+func TestTimeoutsGet(t *testing.T) {
+	var timeouts configsections.Timeouts
+	assert.Nil(t, yaml.Unmarshal([]byte(timeoutsYAML), &timeouts))
+
+	const fallback = 10 * time.Second
+	assert.Equal(t, 45*time.Second, timeouts.Get("lifecycle", "ping", fallback))
+	assert.Equal(t, time.Minute, timeouts.Get("lifecycle", "scaling", fallback))
+	assert.Equal(t, 20*time.Second, timeouts.Get("networking", "nodeport", fallback))
+	assert.Equal(t, 45*time.Second, timeouts.Get("", "ping", fallback))
+
+	var unset configsections.Timeouts
+	assert.Equal(t, fallback, unset.Get("lifecycle", "ping", fallback))
+}
+
+func TestTimeoutsGetWait(t *testing.T) {
+	var timeouts configsections.Timeouts
+	assert.Nil(t, yaml.Unmarshal([]byte(timeoutsYAML+"  pod-recovery: 5m\n"), &timeouts))
+
+	const fallback = 2 * time.Minute
+	assert.Equal(t, 5*time.Minute, timeouts.GetWait("pod-recovery", fallback))
+	assert.Equal(t, fallback, timeouts.GetWait("scaling-ready", fallback))
+}
@@ -0,0 +1,269 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// requiredTag marks the fields a configuration file must set, as `required:"true"`.
+	requiredTag = "required"
+	// maxSuggestionDistance is the maximum edit distance between an unknown field and the field suggested instead.
+	maxSuggestionDistance = 2
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// ValidationError is a problem of a configuration file, at the path of the offending field, e.g. nodeSSH.port or
+// targetPodLabels[0].name.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Validate checks a configuration file against the fields of TestConfiguration:  the unknown fields, e.g. misspelled
+// ones, the values of the wrong type and the missing required fields are returned, sorted by path.  An error is
+// returned when the file is not valid YAML.
+func Validate(contents []byte) ([]ValidationError, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		return nil, err
+	}
+	if document == nil {
+		document = map[interface{}]interface{}{}
+	}
+	problems := validateValue("", document, reflect.TypeOf(TestConfiguration{}))
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Path < problems[j].Path
+	})
+	return problems, nil
+}
+
+// validateValue validates a value of the document against the type of the field it is decoded into.
+func validateValue(path string, value interface{}, t reflect.Type) []ValidationError {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if value == nil {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		fields, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return []ValidationError{wrongType(path, value, t)}
+		}
+		return validateStruct(path, fields, t)
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]interface{})
+		if !ok {
+			return []ValidationError{wrongType(path, value, t)}
+		}
+		var problems []ValidationError
+		for i, item := range items {
+			problems = append(problems, validateValue(fmt.Sprintf("%s[%d]", path, i), item, t.Elem())...)
+		}
+		return problems
+	case reflect.Map:
+		entries, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return []ValidationError{wrongType(path, value, t)}
+		}
+		var problems []ValidationError
+		for key, entry := range entries {
+			problems = append(problems, validateValue(joinPath(path, fmt.Sprint(key)), entry, t.Elem())...)
+		}
+		return problems
+	case reflect.Interface:
+		return nil
+	default:
+		// the scalars are validated by decoding them, as yaml.v2 converts some of them, e.g. 30s into a duration.
+		out, err := yaml.Marshal(value)
+		if err == nil {
+			err = yaml.UnmarshalStrict(out, reflect.New(t).Interface())
+		}
+		if err != nil {
+			return []ValidationError{wrongType(path, value, t)}
+		}
+		return nil
+	}
+}
+
+// yamlField is a field of a struct, as decoded by yaml.v2.
+type yamlField struct {
+	name     string
+	t        reflect.Type
+	required bool
+}
+
+// getYAMLFields returns the fields of a struct by YAML key, the fields of its inline structs included.
+func getYAMLFields(t reflect.Type, fields map[string]yamlField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		options := strings.Split(tag, ",")
+		name := options[0]
+		if containsOption(options[1:], "inline") {
+			getYAMLFields(field.Type, fields)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = yamlField{name: name, t: field.Type, required: field.Tag.Get(requiredTag) == "true"}
+	}
+}
+
+func containsOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// validateStruct validates the fields of a mapping decoded into a struct.
+func validateStruct(path string, values map[interface{}]interface{}, t reflect.Type) []ValidationError {
+	fields := map[string]yamlField{}
+	getYAMLFields(t, fields)
+	var problems []ValidationError
+	for key, value := range values {
+		name := fmt.Sprint(key)
+		field, ok := fields[name]
+		if !ok {
+			problems = append(problems, unknownField(joinPath(path, name), name, fields))
+			continue
+		}
+		problems = append(problems, validateValue(joinPath(path, name), value, field.t)...)
+	}
+	for name, field := range fields {
+		if _, ok := values[name]; field.required && !ok {
+			problems = append(problems, ValidationError{Path: joinPath(path, name), Message: "missing required field"})
+		}
+	}
+	return problems
+}
+
+// unknownField reports an unknown field, suggesting the closest known field, if any.
+func unknownField(path, name string, fields map[string]yamlField) ValidationError {
+	suggestion, bestDistance := "", maxSuggestionDistance+1
+	for known := range fields {
+		distance := editDistance(strings.ToLower(name), strings.ToLower(known))
+		if distance < bestDistance || (distance == bestDistance && known < suggestion) {
+			suggestion, bestDistance = known, distance
+		}
+	}
+	if suggestion == "" {
+		return ValidationError{Path: path, Message: "unknown field"}
+	}
+	return ValidationError{Path: path, Message: fmt.Sprintf("unknown field, did you mean %q?", suggestion)}
+}
+
+// wrongType reports a value which cannot be decoded into the type of its field.
+func wrongType(path string, value interface{}, t reflect.Type) ValidationError {
+	return ValidationError{Path: path, Message: fmt.Sprintf("expected %s, got %s", describeType(t),
+		describeValue(value))}
+}
+
+func describeType(t reflect.Type) string {
+	if t == durationType {
+		return "a duration, e.g. 30s"
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "a mapping"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	default:
+		return t.String()
+	}
+}
+
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		return "a mapping"
+	case []interface{}:
+		return "a list"
+	case string:
+		return fmt.Sprintf("the string %q", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
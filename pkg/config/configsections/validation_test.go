@@ -0,0 +1,91 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const validConfigYAML = `
+targetNameSpaces:
+  - name: tnf
+targetPodLabels:
+  - prefix: test-network-function.com
+    name: generic
+    value: target
+testTarget:
+  containersUnderTest:
+    - namespace: tnf
+      podName: test
+      containerName: test
+      multusIpAddresses:
+        - 10.217.0.8
+timeouts:
+  default: 30s
+  suites:
+    lifecycle: 5m
+nodeSSH:
+  port: 22
+`
+
+const invalidConfigYAML = `
+targetNameSpace:
+  - name: tnf
+targetPodLabels:
+  - prefix: test-network-function.com
+    value: target
+testTarget:
+  containersUnderTest:
+    - namespace: tnf
+      podname: test
+timeouts:
+  default: soon
+nodeSSH:
+  port: twenty-two
+clusters:
+  - kubeconfig: /tmp/kubeconfig
+    bastion: jump.example.com
+`
+
+func TestValidate(t *testing.T) {
+	problems, err := configsections.Validate([]byte(validConfigYAML))
+	assert.Nil(t, err)
+	assert.Empty(t, problems)
+
+	problems, err = configsections.Validate([]byte(invalidConfigYAML))
+	assert.Nil(t, err)
+	assert.Equal(t, []configsections.ValidationError{
+		{Path: "clusters[0].bastion", Message: "expected a mapping, got the string \"jump.example.com\""},
+		{Path: "clusters[0].name", Message: "missing required field"},
+		{Path: "nodeSSH.port", Message: "expected an integer, got the string \"twenty-two\""},
+		{Path: "targetNameSpace", Message: "unknown field, did you mean \"targetNameSpaces\"?"},
+		{Path: "targetPodLabels[0].name", Message: "missing required field"},
+		{Path: "testTarget.containersUnderTest[0].podname", Message: "unknown field, did you mean \"podName\"?"},
+		{Path: "timeouts.default", Message: "expected a duration, e.g. 30s, got the string \"soon\""},
+	}, problems)
+	assert.Equal(t, "nodeSSH.port: expected an integer, got the string \"twenty-two\"", problems[2].Error())
+
+	_, err = configsections.Validate([]byte("targetNameSpaces: ["))
+	assert.NotNil(t, err)
+
+	problems, err = configsections.Validate(nil)
+	assert.Nil(t, err)
+	assert.Empty(t, problems)
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package configsections
+
+const (
+	// DefaultMaxWritableLayerGrowthMiB is the growth of the writable layer of a container accepted during a run, unless
+	// configured.
+	DefaultMaxWritableLayerGrowthMiB = 100
+
+	bytesPerMiB = 1024 * 1024
+)
+
+// WritableLayer configures the writable layer growth test of the containers under test.
+type WritableLayer struct {
+	// MaxGrowthMiB is the growth of the writable layer of a container accepted during a run, in MiB.
+	MaxGrowthMiB uint64 `yaml:"maxGrowthMiB,omitempty" json:"maxGrowthMiB,omitempty"`
+}
+
+// GetMaxGrowthBytes returns the accepted growth of the writable layer of a container, in bytes.
+func (w *WritableLayer) GetMaxGrowthBytes() uint64 {
+	if w.MaxGrowthMiB == 0 {
+		return DefaultMaxWritableLayerGrowthMiB * bytesPerMiB
+	}
+	return w.MaxGrowthMiB * bytesPerMiB
+}
@@ -19,5 +19,7 @@ Package config provides test-network-function configuration through a central pl
 is automatically included in the claim. Configuration should all be contained in a single yaml file, with each
 configuration area under its own key.
 The env var "TNF_CONFIGURATION_PATH" identifies the config file. If not set, the default of `tnf_config.yml` is used.
+It may also be an HTTP(S) URL, or a ConfigMap or Secret of the cluster, e.g. `configmap:tnf/tnf-config`, so that
+several runners share one configuration, see ReadConfigurationSource.
 */
 package config
@@ -0,0 +1,173 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/httpclient"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+)
+
+const (
+	// configurationTokenEnvVar is the environment variable holding the bearer token of the configuration URLs, if
+	// any.
+	configurationTokenEnvVar = "TNF_CONFIGURATION_TOKEN"
+	// configMapSourcePrefix and secretSourcePrefix prefix the configurations read from a ConfigMap or a Secret of the
+	// cluster, e.g. configmap:tnf/tnf-config.
+	configMapSourcePrefix = "configmap:"
+	secretSourcePrefix    = "secret:"
+	// defaultConfigurationKey is the key of the configuration in its ConfigMap or Secret, unless it holds a single key.
+	defaultConfigurationKey = defaultConfigurationFilePath
+	// configurationFetchTimeout bounds the fetch of a configuration URL.
+	configurationFetchTimeout = 30 * time.Second
+	// objectSourceParts are the namespace, the name and the optional key of a ConfigMap or Secret source.
+	objectSourceParts = 3
+	ocBinaryName      = "oc"
+)
+
+var (
+	// getClusterObject returns the JSON of an object of the cluster, a ConfigMap or a Secret.
+	getClusterObject = ocGet
+	// remoteConfigurations are the configurations read from the remote sources, by source, which are read once so that
+	// the readings of a run are consistent.
+	remoteConfigurations     = map[string][]byte{}
+	remoteConfigurationsLock sync.Mutex
+)
+
+// clusterObject is the data of a ConfigMap or a Secret, the data of a Secret being base64-encoded.
+type clusterObject struct {
+	Data map[string]string `json:"data"`
+}
+
+// IsRemoteConfigurationSource tells whether a configuration source is an HTTP(S) URL or a ConfigMap or Secret of the
+// cluster, rather than a local file.
+func IsRemoteConfigurationSource(source string) bool {
+	for _, prefix := range []string{"http://", "https://", configMapSourcePrefix, secretSourcePrefix} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadConfigurationSource reads a configuration from its source:  a local file, an HTTP(S) URL, fetched with the bearer
+// token of $TNF_CONFIGURATION_TOKEN if set, or a ConfigMap or Secret of the cluster under test, as
+// configmap:<namespace>/<name>[/<key>] or secret:<namespace>/<name>[/<key>].  The key is tnf_config.yml, or the single
+// key of the ConfigMap or Secret, unless set.  A remote configuration is only read once.
+func ReadConfigurationSource(source string) ([]byte, error) {
+	if !IsRemoteConfigurationSource(source) {
+		return os.ReadFile(source)
+	}
+	remoteConfigurationsLock.Lock()
+	defer remoteConfigurationsLock.Unlock()
+	if contents, ok := remoteConfigurations[source]; ok {
+		return contents, nil
+	}
+	var contents []byte
+	var err error
+	switch {
+	case strings.HasPrefix(source, configMapSourcePrefix):
+		contents, err = readObjectSource("configmap", strings.TrimPrefix(source, configMapSourcePrefix), false)
+	case strings.HasPrefix(source, secretSourcePrefix):
+		contents, err = readObjectSource("secret", strings.TrimPrefix(source, secretSourcePrefix), true)
+	default:
+		contents, err = fetchConfiguration(source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the configuration from %s: %w", source, err)
+	}
+	remoteConfigurations[source] = contents
+	return contents, nil
+}
+
+// fetchConfiguration fetches a configuration URL.
+func fetchConfiguration(url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	// the token is optional, the configuration URL may be public.
+	client, err := httpclient.New(configurationFetchTimeout, os.Getenv(configurationTokenEnvVar), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// readObjectSource reads the configuration of a ConfigMap or Secret source, <namespace>/<name>[/<key>].
+func readObjectSource(kind, source string, encoded bool) ([]byte, error) {
+	parts := strings.SplitN(source, "/", objectSourceParts)
+	if len(parts) < objectSourceParts-1 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("expected %s:<namespace>/<name>[/<key>]", kind)
+	}
+	out, err := getClusterObject(kind, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+	var object clusterObject
+	if err = json.Unmarshal(out, &object); err != nil {
+		return nil, err
+	}
+	key := defaultConfigurationKey
+	if len(parts) == objectSourceParts {
+		key = parts[2]
+	} else if len(object.Data) == 1 {
+		for single := range object.Data {
+			key = single
+		}
+	}
+	data, ok := object.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("the %s %s/%s has no key %s", kind, parts[0], parts[1], key)
+	}
+	if encoded {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	return []byte(data), nil
+}
+
+// ocGet returns the JSON of an object of the cluster with "oc get".
+func ocGet(kind, namespace, name string) ([]byte, error) {
+	out, err := interactive.Command(ocBinaryName, "get", kind, name, "-n", namespace, "-o", "json").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return out, nil
+}
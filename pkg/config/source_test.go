@@ -0,0 +1,92 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sourceConfigYAML = "targetNameSpaces:\n  - name: tnf\n"
+
+func TestIsRemoteConfigurationSource(t *testing.T) {
+	assert.True(t, IsRemoteConfigurationSource("https://config.example.com/tnf_config.yml"))
+	assert.True(t, IsRemoteConfigurationSource("configmap:tnf/tnf-config"))
+	assert.True(t, IsRemoteConfigurationSource("secret:tnf/tnf-config/config.yml"))
+	assert.False(t, IsRemoteConfigurationSource("/usr/tnf/config/tnf_config.yml"))
+	assert.False(t, IsRemoteConfigurationSource("tnf_config.yml"))
+}
+
+func TestReadConfigurationSourceURL(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, sourceConfigYAML)
+	}))
+	defer server.Close()
+
+	_, err := ReadConfigurationSource(server.URL + "/unauthorized.yml")
+	assert.NotNil(t, err)
+
+	t.Setenv(configurationTokenEnvVar, "secret-token")
+	contents, err := ReadConfigurationSource(server.URL + "/tnf_config.yml")
+	assert.Nil(t, err)
+	assert.Equal(t, sourceConfigYAML, string(contents))
+	// the configuration is only fetched once.
+	_, err = ReadConfigurationSource(server.URL + "/tnf_config.yml")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestReadConfigurationSourceObjects(t *testing.T) {
+	defer func() {
+		getClusterObject = ocGet
+	}()
+	getClusterObject = func(kind, namespace, name string) ([]byte, error) {
+		switch kind + " " + namespace + "/" + name {
+		case "configmap tnf/tnf-config":
+			return []byte(`{"data": {"tnf_config.yml": "targetNameSpaces:\n  - name: tnf\n", "other.yml": "{}"}}`), nil
+		case "configmap tnf/single":
+			return []byte(`{"data": {"config.yml": "targetNameSpaces:\n  - name: tnf\n"}}`), nil
+		case "secret tnf/tnf-config":
+			return []byte(fmt.Sprintf(`{"data": {"lab.yml": %q}}`,
+				base64.StdEncoding.EncodeToString([]byte(sourceConfigYAML)))), nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	for _, source := range []string{"configmap:tnf/tnf-config", "configmap:tnf/single", "secret:tnf/tnf-config/lab.yml"} {
+		contents, err := ReadConfigurationSource(source)
+		assert.Nil(t, err, source)
+		assert.Equal(t, sourceConfigYAML, string(contents), source)
+	}
+	for _, source := range []string{"configmap:tnf/missing", "configmap:tnf/tnf-config/missing.yml", "secret:tnf",
+		"configmap:/tnf-config"} {
+		_, err := ReadConfigurationSource(source)
+		assert.NotNil(t, err, source)
+	}
+}
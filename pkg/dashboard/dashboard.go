@@ -0,0 +1,188 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+)
+
+const (
+	enterAlternateScreen = "\033[?1049h\033[?25l"
+	exitAlternateScreen  = "\033[?25h\033[?1049l"
+	clearScreen          = "\033[H\033[2J"
+)
+
+// suiteCounters counts the specs of a suite per state.
+type suiteCounters struct {
+	name    string
+	passed  int
+	failed  int
+	skipped int
+}
+
+// Dashboard holds the state of the run shown on the terminal.  Its methods can be called concurrently.
+type Dashboard struct {
+	lock        sync.Mutex
+	started     time.Time
+	suites      []*suiteCounters
+	spec        string
+	specStarted time.Time
+	test        string
+	command     string
+	tail        []string
+	tailLines   int
+	// now returns the current time, time.Now by default.
+	now func() time.Time
+}
+
+// New creates a dashboard keeping the last tailLines lines of the outputs of the tests.
+func New(tailLines int) *Dashboard {
+	return &Dashboard{started: time.Now(), tailLines: tailLines, now: time.Now}
+}
+
+// getSuite returns the counters of the suite, added if needed.  The lock must be held.
+func (d *Dashboard) getSuite(name string) *suiteCounters {
+	for _, suite := range d.suites {
+		if suite.name == name {
+			return suite
+		}
+	}
+	suite := &suiteCounters{name: name}
+	d.suites = append(d.suites, suite)
+	return suite
+}
+
+// SpecStarted shows spec of suite as running.
+func (d *Dashboard) SpecStarted(suite, spec string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.getSuite(suite)
+	d.spec = suite + " / " + spec
+	d.specStarted = d.now()
+}
+
+// SpecFinished counts a spec of suite in state, e.g. "passed", "failed" or "skipped".
+func (d *Dashboard) SpecFinished(suite, state string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	counters := d.getSuite(suite)
+	switch state {
+	case claim.StatePassed:
+		counters.passed++
+	case claim.StateSkipped, claim.StatePending:
+		counters.skipped++
+	default:
+		counters.failed++
+	}
+	d.spec = ""
+}
+
+// RecordExchange shows the command sent by test as the current target, and appends the output it matched, or received
+// before timing out, to the tail.  It is meant to be set with tnf.SetExchangeHandler.
+func (d *Dashboard) RecordExchange(test string, exchange tnf.Exchange) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.test = test
+	switch {
+	case exchange.TimedOut:
+		d.appendOutput(exchange.Unmatched)
+		d.appendTail(fmt.Sprintf("<%s timed out>", exchange.Execute))
+	case exchange.Output != "":
+		d.appendOutput(exchange.Output)
+	default:
+		d.command = exchange.Execute
+	}
+}
+
+// appendOutput appends the lines of output, if any, to the tail.  The lock must be held.
+func (d *Dashboard) appendOutput(output string) {
+	if output == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(output, "\r\n"), "\n") {
+		d.appendTail(strings.TrimRight(line, "\r"))
+	}
+}
+
+// appendTail appends a line to the tail, dropping the oldest lines beyond tailLines.  The lock must be held.
+func (d *Dashboard) appendTail(line string) {
+	d.tail = append(d.tail, line)
+	if len(d.tail) > d.tailLines {
+		d.tail = d.tail[len(d.tail)-d.tailLines:]
+	}
+}
+
+// Render writes the dashboard to w, truncating the lines to width columns.
+func (d *Dashboard) Render(w io.Writer, width int) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	now := d.now()
+	var lines []string
+	lines = append(lines, fmt.Sprintf("CNF certification run, elapsed %s", now.Sub(d.started).Round(time.Second)), "")
+	lines = append(lines, fmt.Sprintf("%-30s %8s %8s %8s", "SUITE", "PASSED", "FAILED", "SKIPPED"))
+	total := suiteCounters{name: "total"}
+	for _, suite := range d.suites {
+		lines = append(lines, fmt.Sprintf("%-30s %8d %8d %8d", suite.name, suite.passed, suite.failed, suite.skipped))
+		total.passed += suite.passed
+		total.failed += suite.failed
+		total.skipped += suite.skipped
+	}
+	lines = append(lines, fmt.Sprintf("%-30s %8d %8d %8d", total.name, total.passed, total.failed, total.skipped), "")
+	if d.spec != "" {
+		lines = append(lines, fmt.Sprintf("Running: %s (%s)", d.spec, now.Sub(d.specStarted).Round(time.Second)))
+	} else {
+		lines = append(lines, "Running: -")
+	}
+	lines = append(lines, "Test:    "+d.test, "Target:  "+d.command, "", "Output:")
+	for _, line := range d.tail {
+		lines = append(lines, "  "+line)
+	}
+	for _, line := range lines {
+		if width > 0 && len(line) > width {
+			line = line[:width]
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// Run draws the dashboard on the alternate screen of the terminal w every interval, until ctx is done, and then
+// restores the screen.  width returns the current width of the terminal.
+func (d *Dashboard) Run(ctx context.Context, w io.Writer, interval time.Duration, width func() int) {
+	fmt.Fprint(w, enterAlternateScreen)
+	defer fmt.Fprint(w, exitAlternateScreen)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var frame strings.Builder
+		frame.WriteString(clearScreen)
+		d.Render(&frame, width())
+		fmt.Fprint(w, frame.String())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
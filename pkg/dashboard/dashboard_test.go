@@ -0,0 +1,88 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package dashboard_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/dashboard"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+)
+
+const testHandler = "http://test-network-function.com/tests/hostnamespaces"
+
+func TestRender(t *testing.T) {
+	d := dashboard.New(2)
+	d.SpecStarted("access-control", "access-control-host-pid")
+	d.SpecFinished("access-control", "passed")
+	d.SpecStarted("access-control", "access-control-host-ipc")
+	d.SpecFinished("access-control", "failed")
+	d.SpecStarted("lifecycle", "lifecycle-startup-ordering")
+	d.SpecFinished("lifecycle", "skipped")
+	d.SpecStarted("lifecycle", "lifecycle-pod-owner-type")
+	d.RecordExchange(testHandler, tnf.Exchange{Execute: "oc -n tnf get pods test-0"})
+	d.RecordExchange(testHandler, tnf.Exchange{Execute: "oc -n tnf get pods test-0", Output: "line 1\r\nline 2\r\nline 3\r\n"})
+	d.RecordExchange(testHandler, tnf.Exchange{Execute: "oc -n tnf get pods test-1"})
+
+	var buf bytes.Buffer
+	d.Render(&buf, 0)
+	output := buf.String()
+	assert.Contains(t, output, "access-control                        1        1        0\n")
+	assert.Contains(t, output, "lifecycle                             0        0        1\n")
+	assert.Contains(t, output, "total                                 1        1        1\n")
+	assert.Contains(t, output, "Running: lifecycle / lifecycle-pod-owner-type (")
+	assert.Contains(t, output, "Test:    "+testHandler+"\n")
+	assert.Contains(t, output, "Target:  oc -n tnf get pods test-1\n")
+	// only the last 2 lines of the outputs are kept.
+	assert.True(t, strings.HasSuffix(output, "Output:\n  line 2\n  line 3\n"), output)
+
+	d.RecordExchange(testHandler, tnf.Exchange{Execute: "oc -n tnf get pods test-1", Unmatched: "waiting\r\n", TimedOut: true})
+	d.SpecFinished("lifecycle", "passed")
+	buf.Reset()
+	d.Render(&buf, 20)
+	output = buf.String()
+	assert.Contains(t, output, "Running: -\n")
+	assert.True(t, strings.HasSuffix(output, "  waiting\n  <oc -n tnf get pod\n"), output)
+	for _, line := range strings.Split(output, "\n") {
+		assert.LessOrEqual(t, len(line), 20)
+	}
+}
+
+func TestRun(t *testing.T) {
+	d := dashboard.New(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var buf bytes.Buffer
+	d.Run(ctx, &buf, time.Second, func() int { return 80 })
+	output := buf.String()
+	assert.True(t, strings.HasPrefix(output, "\033[?1049h"))
+	assert.Contains(t, output, "CNF certification run")
+	assert.True(t, strings.HasSuffix(output, "\033[?1049l"))
+}
+
+func TestTerminalWidth(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "output")
+	assert.Nil(t, err)
+	defer f.Close()
+	assert.Equal(t, 120, dashboard.TerminalWidth(f))
+}
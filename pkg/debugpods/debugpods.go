@@ -0,0 +1,148 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package debugpods
+
+import (
+	"bytes"
+	_ "embed" // the debug daemonset manifest is embedded
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/images"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+)
+
+const (
+	// Name and Namespace of the debug daemonset.
+	Name      = "debug"
+	Namespace = "default"
+	// ServiceAccountName is the service account of the debug pods.
+	ServiceAccountName = "tnf-debug"
+	// NodeLabelName is the label scheduling the debug pods on the nodes.
+	NodeLabelName = "test-network-function.com/node"
+
+	ocBinaryName      = "oc"
+	kubectlBinaryName = "kubectl"
+)
+
+//go:embed debugpods.yaml
+var manifestTemplate string
+
+var (
+	// deployed is true once Deploy has deployed the debug daemonset, which DeleteDeployed then deletes.
+	deployed     bool
+	deployedLock sync.Mutex
+)
+
+// Manifest returns the manifest of the debug daemonset, with the image and the tolerations of conf.
+func Manifest(conf *configsections.DebugDaemonSet) (string, error) {
+	image := conf.Image
+	if image == "" {
+		for _, i := range images.GetManifest() {
+			if i.Name == images.DebugImageName {
+				image = i.Reference
+			}
+		}
+	}
+	tolerations := conf.Tolerations
+	if len(tolerations) == 0 {
+		tolerations = []configsections.Toleration{{Operator: "Exists"}}
+	}
+	tmpl, err := template.New("debug").Parse(manifestTemplate)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, struct {
+		Name, Namespace, ServiceAccount, Image string
+		Tolerations                            []configsections.Toleration
+	}{Name, Namespace, ServiceAccountName, image, tolerations})
+	return b.String(), err
+}
+
+// runOc runs an oc command, or the same kubectl command when oc is not installed, with stdin as its input unless
+// empty.  It returns the output of the command.
+func runOc(stdin string, args ...string) (string, error) {
+	binary := ocBinaryName
+	if occompat.UsesKubectl() {
+		binary = kubectlBinaryName
+	}
+	cmd := interactive.Command(binary, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w: %s", binary, strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Exists returns true when the debug daemonset is deployed.
+func Exists() (bool, error) {
+	out, err := runOc("", "get", "daemonset", Name, "-n", Namespace, "-o", "name", "--ignore-not-found")
+	return out != "", err
+}
+
+// Deploy deploys the debug daemonset, unless it already exists.  The readiness of its pods is not waited for, as they
+// only run on the nodes once labeled for debugging.
+func Deploy(conf *configsections.DebugDaemonSet) error {
+	deployedLock.Lock()
+	defer deployedLock.Unlock()
+	if deployed {
+		return nil
+	}
+	exists, err := Exists()
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Infof("reusing the existing debug daemonset %s/%s", Namespace, Name)
+		return nil
+	}
+	manifest, err := Manifest(conf)
+	if err != nil {
+		return err
+	}
+	log.Infof("deploying the debug daemonset %s/%s", Namespace, Name)
+	if _, err = runOc(manifest, "apply", "-f", "-"); err != nil {
+		return err
+	}
+	deployed = true
+	return nil
+}
+
+// DeleteDeployed deletes the debug daemonset and its service account if they were deployed by Deploy, an existing
+// debug daemonset is left in place.
+func DeleteDeployed() error {
+	deployedLock.Lock()
+	defer deployedLock.Unlock()
+	if !deployed {
+		return nil
+	}
+	log.Infof("deleting the debug daemonset %s/%s", Namespace, Name)
+	_, err := runOc("", "delete", "daemonset/"+Name, "serviceaccount/"+ServiceAccountName,
+		"rolebinding/"+ServiceAccountName+"-privileged", "-n", Namespace, "--ignore-not-found", "--wait=false")
+	if err == nil {
+		deployed = false
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package debugpods
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"gopkg.in/yaml.v2"
+)
+
+// decodeDaemonSetSpec returns the pod spec of the daemonset of a manifest, and the kinds of its resources.
+func decodeDaemonSetSpec(t *testing.T, manifest string) (podSpec map[interface{}]interface{}, kinds []string) {
+	decoder := yaml.NewDecoder(bytes.NewBufferString(manifest))
+	for {
+		var resource map[string]interface{}
+		err := decoder.Decode(&resource)
+		if errors.Is(err, io.EOF) {
+			return podSpec, kinds
+		}
+		assert.Nil(t, err)
+		kinds = append(kinds, resource["kind"].(string))
+		if resource["kind"] == "DaemonSet" {
+			spec := resource["spec"].(map[interface{}]interface{})
+			podSpec = spec["template"].(map[interface{}]interface{})["spec"].(map[interface{}]interface{})
+		}
+	}
+}
+
+func TestManifest(t *testing.T) {
+	t.Setenv("TNF_PARTNER_REPO", "registry.example.com:5000/testnetworkfunction/")
+	manifest, err := Manifest(&configsections.DebugDaemonSet{})
+	assert.Nil(t, err)
+	podSpec, kinds := decodeDaemonSetSpec(t, manifest)
+	assert.Equal(t, []string{"ServiceAccount", "RoleBinding", "DaemonSet"}, kinds)
+	assert.Equal(t, ServiceAccountName, podSpec["serviceAccountName"])
+	assert.Equal(t, map[interface{}]interface{}{"test-network-function.com/node": "target"}, podSpec["nodeSelector"])
+	assert.Equal(t, []interface{}{map[interface{}]interface{}{"operator": "Exists"}}, podSpec["tolerations"])
+	container := podSpec["containers"].([]interface{})[0].(map[interface{}]interface{})
+	assert.Equal(t, "registry.example.com:5000/testnetworkfunction/debug-partner:latest", container["image"])
+
+	manifest, err = Manifest(&configsections.DebugDaemonSet{
+		Image: "registry.example.com:5000/debug:1.0",
+		Tolerations: []configsections.Toleration{
+			{Key: "node-role.kubernetes.io/master", Effect: "NoSchedule"},
+			{Key: "dedicated", Operator: "Equal", Value: "rt", Effect: "NoExecute"},
+		},
+	})
+	assert.Nil(t, err)
+	podSpec, _ = decodeDaemonSetSpec(t, manifest)
+	assert.Equal(t, []interface{}{
+		map[interface{}]interface{}{"operator": "Exists", "key": "node-role.kubernetes.io/master", "effect": "NoSchedule"},
+		map[interface{}]interface{}{"operator": "Equal", "key": "dedicated", "value": "rt", "effect": "NoExecute"},
+	}, podSpec["tolerations"])
+	container = podSpec["containers"].([]interface{})[0].(map[interface{}]interface{})
+	assert.Equal(t, "registry.example.com:5000/debug:1.0", container["image"])
+}
@@ -0,0 +1,23 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+/*
+Package debugpods deploys the debug daemonset, whose privileged pods share the namespaces of the nodes and mount their
+root filesystem on /host, so that the tests run their node commands from them.  The daemonset schedules its pods on
+the nodes labeled for debugging by the test suites.  An existing debug daemonset, e.g. deployed with the partner pods,
+is reused and left in place.
+*/
+package debugpods
@@ -0,0 +1,265 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package deprecatedapis
+
+import (
+	_ "embed" // the table of the removed APIs is embedded
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// lastAppliedAnnotation records the manifest a resource was last applied with, including its apiVersion.
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+	// almExamplesAnnotation holds the example custom resources of the CSV of an operator.
+	almExamplesAnnotation = "alm-examples"
+	// anyResource is the wildcard of the resources and of the API groups of a policy rule.
+	anyResource = "*"
+)
+
+// The sources of the uses of the removed APIs, see Usage.
+const (
+	// SourceAPIVersion is the apiVersion a resource was last applied with.
+	SourceAPIVersion = "apiVersion"
+	// SourceRBAC is the RBAC permissions of a CSV.
+	SourceRBAC = "rbac"
+	// SourceALMExamples is the example custom resources of a CSV.
+	SourceALMExamples = "alm-examples"
+)
+
+//go:embed removals.yaml
+var removalsYAML []byte
+
+// Removal is an API removed from Kubernetes.
+type Removal struct {
+	Group    string `yaml:"group"`
+	Version  string `yaml:"version"`
+	Kind     string `yaml:"kind"`
+	Resource string `yaml:"resource"`
+	// RemovedIn is the Kubernetes release which no longer serves the API, e.g. "1.22".
+	RemovedIn string `yaml:"removedIn"`
+	// Replacement is the API to migrate to, e.g. "apiextensions.k8s.io/v1", empty when there is none.
+	Replacement string `yaml:"replacement"`
+}
+
+// APIVersion returns the apiVersion of the removed API, e.g. "policy/v1beta1", or "v1" for the core group.
+func (r *Removal) APIVersion() string {
+	if r.Group == "" {
+		return r.Version
+	}
+	return r.Group + "/" + r.Version
+}
+
+// RemovesResource returns true when the resource is removed from its group, i.e. the API has no replacement in the
+// same group, e.g. the ingresses of the extensions group.  The RBAC rules granting the resource of the group are then
+// obsolete.
+func (r *Removal) RemovesResource() bool {
+	return r.Replacement == "" || !strings.HasPrefix(r.Replacement, r.Group+"/")
+}
+
+// Table is the table of the removed APIs.
+type Table []Removal
+
+// Load returns the table of the removed APIs bundled with the tool.
+func Load() (Table, error) {
+	var table Table
+	if err := yaml.Unmarshal(removalsYAML, &table); err != nil {
+		return nil, fmt.Errorf("invalid table of the removed APIs: %w", err)
+	}
+	for i := range table {
+		if _, err := occompat.ParseVersion(table[i].RemovedIn); err != nil {
+			return nil, fmt.Errorf("invalid removal of %s %s: %w", table[i].APIVersion(), table[i].Kind, err)
+		}
+	}
+	return table, nil
+}
+
+// Upcoming returns the removals in the Kubernetes releases after cluster, all of them when cluster is unknown.
+func (t Table) Upcoming(cluster occompat.Version) Table {
+	if cluster.IsZero() {
+		return t
+	}
+	var upcoming Table
+	for i := range t {
+		// Ignore errors, the versions are validated by Load.
+		removedIn, _ := occompat.ParseVersion(t[i].RemovedIn)
+		if !cluster.AtLeast(removedIn) {
+			upcoming = append(upcoming, t[i])
+		}
+	}
+	return upcoming
+}
+
+// findKind returns the removal of the kind in the apiVersion, nil when it is not removed.
+func (t Table) findKind(apiVersion, kind string) *Removal {
+	for i := range t {
+		if t[i].APIVersion() == apiVersion && t[i].Kind == kind {
+			return &t[i]
+		}
+	}
+	return nil
+}
+
+// findResource returns the removal of the resource from the group, nil when it is not removed from the group, see
+// RemovesResource.
+func (t Table) findResource(group, resource string) *Removal {
+	for i := range t {
+		if t[i].Group == group && t[i].Resource == resource && t[i].RemovesResource() {
+			return &t[i]
+		}
+	}
+	return nil
+}
+
+// Usage is a use of a removed API by a resource under test.
+type Usage struct {
+	// Resource is the resource using the API, e.g. "crd/foos.example.com" or "csv/tnf/nginx-operator.v0.0.1".
+	Resource string `json:"resource"`
+	// Source is where the API is used: SourceAPIVersion, SourceRBAC or SourceALMExamples.
+	Source      string `json:"source"`
+	API         string `json:"api"`
+	RemovedIn   string `json:"removedIn"`
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// String returns a description of the usage, for the logs.
+func (u *Usage) String() string {
+	replacement := u.Replacement
+	if replacement == "" {
+		replacement = "none"
+	}
+	return fmt.Sprintf("%s uses %s in its %s, removed in %s (replacement: %s)", u.Resource, u.API, u.Source, u.RemovedIn,
+		replacement)
+}
+
+func newUsage(resource, source, api string, removal *Removal) Usage {
+	return Usage{Resource: resource, Source: source, API: api, RemovedIn: removal.RemovedIn,
+		Replacement: removal.Replacement}
+}
+
+// typeMeta is the apiVersion and the kind of a manifest.
+type typeMeta struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// metadata holds the annotations of a resource read with `oc get -o json`.
+type metadata struct {
+	Metadata struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+}
+
+// policyRule is a rule of the RBAC permissions of a CSV.
+type policyRule struct {
+	APIGroups []string `json:"apiGroups"`
+	Resources []string `json:"resources"`
+}
+
+// csvManifest holds the permissions and the examples of a CSV read with `oc get csv -o json`.
+type csvManifest struct {
+	metadata
+	Spec struct {
+		Install struct {
+			Spec struct {
+				ClusterPermissions []struct {
+					Rules []policyRule `json:"rules"`
+				} `json:"clusterPermissions"`
+				Permissions []struct {
+					Rules []policyRule `json:"rules"`
+				} `json:"permissions"`
+			} `json:"spec"`
+		} `json:"install"`
+	} `json:"spec"`
+}
+
+// checkLastApplied returns the use of a removed API by the manifest a resource was last applied with, if any.
+func (t Table) checkLastApplied(resource string, annotations map[string]string) []Usage {
+	lastApplied, ok := annotations[lastAppliedAnnotation]
+	if !ok {
+		return nil
+	}
+	var manifest typeMeta
+	if json.Unmarshal([]byte(lastApplied), &manifest) != nil {
+		return nil
+	}
+	if removal := t.findKind(manifest.APIVersion, manifest.Kind); removal != nil {
+		return []Usage{newUsage(resource, SourceAPIVersion, manifest.APIVersion+" "+manifest.Kind, removal)}
+	}
+	return nil
+}
+
+// CheckCRD returns the uses of the removed APIs by a CRD, from its manifest read with `oc get crd -o json`: the
+// apiVersion it was last applied with, e.g. apiextensions.k8s.io/v1beta1.
+func (t Table) CheckCRD(manifest []byte) ([]Usage, error) {
+	var crd metadata
+	if err := json.Unmarshal(manifest, &crd); err != nil {
+		return nil, err
+	}
+	return t.checkLastApplied("crd/"+crd.Metadata.Name, crd.Metadata.Annotations), nil
+}
+
+// CheckCSV returns the uses of the removed APIs by the CSV of an operator, from its manifest read with
+// `oc get csv -o json`: the apiVersion it was last applied with, the resources its RBAC permissions grant, and the
+// example custom resources of its alm-examples annotation.
+func (t Table) CheckCSV(manifest []byte) ([]Usage, error) {
+	var csv csvManifest
+	if err := json.Unmarshal(manifest, &csv); err != nil {
+		return nil, err
+	}
+	resource := "csv/" + csv.Metadata.Namespace + "/" + csv.Metadata.Name
+	usages := t.checkLastApplied(resource, csv.Metadata.Annotations)
+	var rules []policyRule
+	for _, permission := range csv.Spec.Install.Spec.ClusterPermissions {
+		rules = append(rules, permission.Rules...)
+	}
+	for _, permission := range csv.Spec.Install.Spec.Permissions {
+		rules = append(rules, permission.Rules...)
+	}
+	seen := map[string]bool{}
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, name := range rule.Resources {
+				api := group + "/" + name
+				if group == anyResource || name == anyResource || seen[api] {
+					continue
+				}
+				seen[api] = true
+				if removal := t.findResource(group, name); removal != nil {
+					usages = append(usages, newUsage(resource, SourceRBAC, api, removal))
+				}
+			}
+		}
+	}
+	if examples, ok := csv.Metadata.Annotations[almExamplesAnnotation]; ok {
+		var manifests []typeMeta
+		if err := json.Unmarshal([]byte(examples), &manifests); err != nil {
+			return usages, fmt.Errorf("invalid %s annotation of %s: %w", almExamplesAnnotation, resource, err)
+		}
+		for _, example := range manifests {
+			if removal := t.findKind(example.APIVersion, example.Kind); removal != nil {
+				usages = append(usages, newUsage(resource, SourceALMExamples, example.APIVersion+" "+example.Kind, removal))
+			}
+		}
+	}
+	return usages, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package deprecatedapis_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/deprecatedapis"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+)
+
+func loadTable(t *testing.T) deprecatedapis.Table {
+	table, err := deprecatedapis.Load()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, table)
+	return table
+}
+
+func TestRemoval(t *testing.T) {
+	removal := deprecatedapis.Removal{Group: "policy", Version: "v1beta1", Replacement: "policy/v1"}
+	assert.Equal(t, "policy/v1beta1", removal.APIVersion())
+	assert.False(t, removal.RemovesResource())
+	removal.Replacement = ""
+	assert.True(t, removal.RemovesResource())
+	removal = deprecatedapis.Removal{Group: "extensions", Version: "v1beta1", Replacement: "networking.k8s.io/v1"}
+	assert.True(t, removal.RemovesResource())
+	assert.Equal(t, "v1", (&deprecatedapis.Removal{Version: "v1"}).APIVersion())
+}
+
+func TestTable_Upcoming(t *testing.T) {
+	table := loadTable(t)
+	assert.Equal(t, table, table.Upcoming(occompat.Version{}))
+	upcoming := table.Upcoming(occompat.Version{Major: 1, Minor: 22})
+	assert.NotEmpty(t, upcoming)
+	for i := range upcoming {
+		assert.NotContains(t, []string{"1.16", "1.22"}, upcoming[i].RemovedIn)
+	}
+	assert.Less(t, len(upcoming), len(table))
+}
+
+func TestTable_CheckCRD(t *testing.T) {
+	manifest, err := os.ReadFile(filepath.Join("testdata", "crd.json"))
+	assert.Nil(t, err)
+	usages, err := loadTable(t).Upcoming(occompat.Version{Major: 1, Minor: 21}).CheckCRD(manifest)
+	assert.Nil(t, err)
+	assert.Equal(t, []deprecatedapis.Usage{{
+		Resource:    "crd/crdexamples.test-network-function.com",
+		Source:      deprecatedapis.SourceAPIVersion,
+		API:         "apiextensions.k8s.io/v1beta1 CustomResourceDefinition",
+		RemovedIn:   "1.22",
+		Replacement: "apiextensions.k8s.io/v1",
+	}}, usages)
+
+	// already removed from the cluster.
+	usages, err = loadTable(t).Upcoming(occompat.Version{Major: 1, Minor: 22}).CheckCRD(manifest)
+	assert.Nil(t, err)
+	assert.Empty(t, usages)
+
+	_, err = loadTable(t).CheckCRD([]byte("not json"))
+	assert.NotNil(t, err)
+}
+
+func TestTable_CheckCSV(t *testing.T) {
+	manifest, err := os.ReadFile(filepath.Join("testdata", "csv.json"))
+	assert.Nil(t, err)
+	usages, err := loadTable(t).Upcoming(occompat.Version{Major: 1, Minor: 21}).CheckCSV(manifest)
+	assert.Nil(t, err)
+	resource := "csv/tnf/nginx-operator.v0.0.1"
+	assert.Equal(t, []deprecatedapis.Usage{
+		{Resource: resource, Source: deprecatedapis.SourceRBAC, API: "policy/podsecuritypolicies", RemovedIn: "1.25"},
+		{Resource: resource, Source: deprecatedapis.SourceRBAC, API: "extensions/ingresses", RemovedIn: "1.22",
+			Replacement: "networking.k8s.io/v1"},
+		{Resource: resource, Source: deprecatedapis.SourceALMExamples, API: "policy/v1beta1 PodDisruptionBudget",
+			RemovedIn: "1.25", Replacement: "policy/v1"},
+	}, usages)
+	assert.Equal(t, "csv/tnf/nginx-operator.v0.0.1 uses policy/podsecuritypolicies in its rbac, removed in 1.25 "+
+		"(replacement: none)", usages[0].String())
+
+	usages, err = loadTable(t).Upcoming(occompat.Version{Major: 1, Minor: 25}).CheckCSV(manifest)
+	assert.Nil(t, err)
+	assert.Empty(t, usages)
+}
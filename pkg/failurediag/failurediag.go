@@ -0,0 +1,162 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package failurediag
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/statebundle"
+)
+
+const (
+	// IndexFileName is the name of the file listing the artifacts of a failed spec in its directory.
+	IndexFileName = "index.json"
+
+	// LogsTailLines is the number of the most recent lines of logs gathered per container.
+	LogsTailLines = 1000
+
+	dirPermissions  = 0755
+	filePermissions = 0644
+)
+
+// Diagnostic is the output of a diagnostic command on a target, or the error preventing it.
+type Diagnostic struct {
+	// Name names the diagnostic, e.g. "describe", and its artifact.
+	Name   string
+	Output []byte
+	Err    error
+}
+
+// Artifact is a file of diagnostics written for a target of a failed spec.
+type Artifact struct {
+	Target     string `json:"target"`
+	Kind       string `json:"kind"`
+	Diagnostic string `json:"diagnostic"`
+	// File is the name of the artifact in the directory of the failed spec, empty when the diagnostic failed.
+	File  string `json:"file,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Runner runs an oc command and returns its output, e.g. statebundle.RunOc.
+type Runner func(args ...string) ([]byte, error)
+
+// Hook gathers diagnostics on a target of a failed spec with run, it returns none for the kinds it does not handle.
+type Hook func(run Runner, target statebundle.Target) []Diagnostic
+
+// namespaceArgs returns the arguments selecting the namespace of target, if any.
+func namespaceArgs(target statebundle.Target) []string {
+	if target.Namespace == "" {
+		return nil
+	}
+	return []string{"-n", target.Namespace}
+}
+
+// DescribeHook gathers the description of any target.
+func DescribeHook(run Runner, target statebundle.Target) []Diagnostic {
+	out, err := run(append([]string{"describe", target.Kind, target.Object}, namespaceArgs(target)...)...)
+	return []Diagnostic{{Name: "describe", Output: out, Err: err}}
+}
+
+// LogsHook gathers the recent logs of all the containers of the pod targets, and the logs of their previous
+// instances when they restarted.
+func LogsHook(run Runner, target statebundle.Target) []Diagnostic {
+	if target.Kind != statebundle.KindPod {
+		return nil
+	}
+	args := []string{"logs", target.Object, "-n", target.Namespace, "--all-containers", "--prefix", "--timestamps",
+		"--tail", strconv.Itoa(LogsTailLines)}
+	out, err := run(args...)
+	diagnostics := []Diagnostic{{Name: "logs", Output: out, Err: err}}
+	// The previous logs are missing unless a container restarted, which is not worth reporting as an error.
+	if previous, previousErr := run(append(args, "--previous")...); previousErr == nil {
+		diagnostics = append(diagnostics, Diagnostic{Name: "previous-logs", Output: previous})
+	}
+	return diagnostics
+}
+
+// EventsHook gathers the events involving the target.
+func EventsHook(run Runner, target statebundle.Target) []Diagnostic {
+	args := []string{"get", "events", "--field-selector", "involvedObject.name=" + target.Object, "--sort-by",
+		".lastTimestamp"}
+	if target.Namespace == "" {
+		args = append(args, "--all-namespaces")
+	} else {
+		args = append(args, "-n", target.Namespace)
+	}
+	out, err := run(args...)
+	return []Diagnostic{{Name: "events", Output: out, Err: err}}
+}
+
+// DefaultHooks are the hooks of a new Collector.
+var DefaultHooks = []Hook{DescribeHook, LogsHook, EventsHook}
+
+// Collector runs hooks on the targets of the failed specs and writes their diagnostics into a directory per failed
+// spec.
+type Collector struct {
+	dir   string
+	run   Runner
+	hooks []Hook
+}
+
+// NewCollector returns a Collector running DefaultHooks with run, e.g. statebundle.RunOc, and writing the artifacts into dir, which is created
+// if needed.
+func NewCollector(dir string, run Runner) *Collector {
+	return &Collector{dir: dir, run: run, hooks: append([]Hook{}, DefaultHooks...)}
+}
+
+// AddHooks adds hooks to the ones run by the collector.
+func (c *Collector) AddHooks(hooks ...Hook) {
+	c.hooks = append(c.hooks, hooks...)
+}
+
+// Collect runs the hooks on targets and writes their diagnostics into a directory named after name, the test case or
+// spec which failed, and its start time, along with an index of the artifacts.  It returns the path of the directory.
+func (c *Collector) Collect(name string, startTime time.Time, targets []statebundle.Target) (string, error) {
+	dir := filepath.Join(c.dir, fmt.Sprintf("%s-%s", statebundle.FileName(name), startTime.UTC().Format("20060102T150405")))
+	if err := os.MkdirAll(dir, dirPermissions); err != nil {
+		return "", err
+	}
+	artifacts := []Artifact{}
+	for _, target := range targets {
+		for _, hook := range c.hooks {
+			for _, diagnostic := range hook(c.run, target) {
+				artifact := Artifact{Target: target.Name, Kind: target.Kind, Diagnostic: diagnostic.Name}
+				if diagnostic.Err != nil {
+					artifact.Error = diagnostic.Err.Error()
+				} else {
+					artifact.File = statebundle.FileName(fmt.Sprintf("%s-%s-%s.txt", target.Kind, target.Name, diagnostic.Name))
+					if err := os.WriteFile(filepath.Join(dir, artifact.File), diagnostic.Output, filePermissions); err != nil {
+						return "", err
+					}
+				}
+				artifacts = append(artifacts, artifact)
+			}
+		}
+	}
+	data, err := json.MarshalIndent(artifacts, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err = os.WriteFile(filepath.Join(dir, IndexFileName), data, filePermissions); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
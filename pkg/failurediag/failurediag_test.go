@@ -0,0 +1,86 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package failurediag_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/failurediag"
+	"github.com/test-network-function/test-network-function/pkg/statebundle"
+)
+
+func TestHooks(t *testing.T) {
+	var commands []string
+	run := func(args ...string) ([]byte, error) {
+		commands = append(commands, strings.Join(args, " "))
+		return []byte("output"), nil
+	}
+	pod := statebundle.Target{Name: "tnf/test", Kind: statebundle.KindPod, Namespace: "tnf", Object: "test"}
+	node := statebundle.Target{Name: "worker-0", Kind: statebundle.KindNode, Object: "worker-0"}
+	assert.Len(t, failurediag.DescribeHook(run, pod), 1)
+	assert.Len(t, failurediag.DescribeHook(run, node), 1)
+	assert.Len(t, failurediag.LogsHook(run, pod), 2)
+	assert.Empty(t, failurediag.LogsHook(run, node))
+	assert.Len(t, failurediag.EventsHook(run, pod), 1)
+	assert.Len(t, failurediag.EventsHook(run, node), 1)
+	assert.Equal(t, []string{
+		"describe pod test -n tnf",
+		"describe node worker-0",
+		"logs test -n tnf --all-containers --prefix --timestamps --tail 1000",
+		"logs test -n tnf --all-containers --prefix --timestamps --tail 1000 --previous",
+		"get events --field-selector involvedObject.name=test --sort-by .lastTimestamp -n tnf",
+		"get events --field-selector involvedObject.name=worker-0 --sort-by .lastTimestamp --all-namespaces",
+	}, commands)
+}
+
+func TestCollect(t *testing.T) {
+	run := func(args ...string) ([]byte, error) {
+		if args[0] == "logs" {
+			return nil, errors.New("container not found")
+		}
+		return []byte(strings.Join(args, " ")), nil
+	}
+	dir := t.TempDir()
+	collector := failurediag.NewCollector(filepath.Join(dir, "diagnostics"), run)
+	collector.AddHooks(func(run failurediag.Runner, target statebundle.Target) []failurediag.Diagnostic {
+		return []failurediag.Diagnostic{{Name: "custom", Output: []byte(target.Name)}}
+	})
+	path, err := collector.Collect("lifecycle-pod-owner-type", time.Date(2021, 11, 2, 10, 30, 0, 0, time.UTC),
+		[]statebundle.Target{{Name: "tnf/test", Kind: statebundle.KindPod, Namespace: "tnf", Object: "test"}})
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "diagnostics", "lifecycle-pod-owner-type-20211102T103000"), path)
+
+	data, err := os.ReadFile(filepath.Join(path, failurediag.IndexFileName))
+	assert.Nil(t, err)
+	var artifacts []failurediag.Artifact
+	assert.Nil(t, json.Unmarshal(data, &artifacts))
+	assert.Equal(t, []failurediag.Artifact{
+		{Target: "tnf/test", Kind: statebundle.KindPod, Diagnostic: "describe", File: "pod-tnf_test-describe.txt"},
+		{Target: "tnf/test", Kind: statebundle.KindPod, Diagnostic: "logs", Error: "container not found"},
+		{Target: "tnf/test", Kind: statebundle.KindPod, Diagnostic: "events", File: "pod-tnf_test-events.txt"},
+		{Target: "tnf/test", Kind: statebundle.KindPod, Diagnostic: "custom", File: "pod-tnf_test-custom.txt"},
+	}, artifacts)
+	describe, err := os.ReadFile(filepath.Join(path, "pod-tnf_test-describe.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "describe pod test -n tnf", string(describe))
+}
@@ -20,9 +20,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"path"
+	"path/filepath"
 
-	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
 	"github.com/test-network-function/test-network-function/pkg/jsonschema"
 	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
 	"github.com/xeipuuv/gojsonschema"
@@ -33,7 +33,7 @@ const (
 )
 
 var (
-	policySchemaPath = path.Join("schemas", "gradetool-policy-schema.json")
+	policySchemaPath = filepath.Join("schemas", "gradetool-policy-schema.json")
 )
 
 // Grade is a single grade object from policy file
@@ -74,8 +74,8 @@ func GenerateGrade(resultsPath, policyPath, outputPath string) error {
 		return err
 	}
 
-	claimObj := claim.Root{}
-	err = unmarshalFromFile(resultsPath, &claimObj)
+	// the claim is migrated to the current format version as it is read.
+	claimObj, err := claim.ReadClaimFile(resultsPath)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,113 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/test-network-function/test-network-function/internal/api"
+)
+
+// TLS configures the TLS connections of a client.
+type TLS struct {
+	// CAFile is a PEM bundle of the certificate authorities trusted in addition to the system ones.
+	CAFile string
+	// CertFile and KeyFile are the PEM client certificate and key, for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// InsecureSkipVerify disables the verification of the certificate of the server, for test servers only.
+	InsecureSkipVerify bool
+}
+
+// Client sends the requests with a bearer token, if any.
+type Client struct {
+	HTTP api.HTTPClient
+	// Token is the bearer token of the requests, if any.
+	Token string
+}
+
+// New returns a client whose requests time out after timeout, using the TLS configuration tlsConf unless nil.
+func New(timeout time.Duration, token string, tlsConf *TLS) (*Client, error) {
+	client := &http.Client{Timeout: timeout}
+	if tlsConf != nil {
+		tlsConfig, err := newTLSConfig(tlsConf)
+		if err != nil {
+			return nil, err
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		client.Transport = transport
+	}
+	return &Client{HTTP: client, Token: token}, nil
+}
+
+// GetToken reads the token named by tokenEnv with getenv, e.g. os.Getenv, none when tokenEnv is empty.  It returns an
+// error when the environment variable is not set.
+func GetToken(getenv func(key string) string, tokenEnv string) (string, error) {
+	if tokenEnv == "" {
+		return "", nil
+	}
+	token := getenv(tokenEnv)
+	if token == "" {
+		return "", fmt.Errorf("%s is not set", tokenEnv)
+	}
+	return token, nil
+}
+
+// Do sends req with the bearer token of the client, if any.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return c.HTTP.Do(req)
+}
+
+// newTLSConfig returns the TLS configuration of the requests: the additional certificate authorities, the client
+// certificate and the verification of the certificate of the server.
+func newTLSConfig(conf *TLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: conf.InsecureSkipVerify, //nolint:gosec // Explicitly configured for test servers
+	}
+	if conf.CAFile != "" {
+		pem, err := os.ReadFile(conf.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", conf.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if conf.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package httpclient_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/httpclient"
+)
+
+func TestGetToken(t *testing.T) {
+	getenv := func(key string) string {
+		return map[string]string{"TNF_TOKEN": "secret"}[key]
+	}
+	token, err := httpclient.GetToken(getenv, "TNF_TOKEN")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", token)
+	token, err = httpclient.GetToken(getenv, "")
+	assert.Nil(t, err)
+	assert.Empty(t, token)
+	_, err = httpclient.GetToken(getenv, "MISSING")
+	assert.EqualError(t, err, "MISSING is not set")
+}
+
+func TestClient(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE",
+		Bytes: server.Certificate().Raw}), 0600))
+
+	for _, token := range []string{"secret", ""} {
+		client, err := httpclient.New(time.Minute, token, &httpclient.TLS{CAFile: caFile})
+		assert.Nil(t, err)
+		req, err := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+		assert.Nil(t, err)
+		resp, err := client.Do(req)
+		assert.Nil(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, []string{"Bearer secret", ""}, authorizations)
+
+	_, err := httpclient.New(time.Minute, "", &httpclient.TLS{CertFile: "client.pem", KeyFile: "client.key"})
+	assert.NotNil(t, err)
+	_, err = httpclient.New(time.Minute, "", &httpclient.TLS{CAFile: filepath.Join(t.TempDir(), "none")})
+	assert.NotNil(t, err)
+}
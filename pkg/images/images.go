@@ -0,0 +1,104 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package images
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+)
+
+const (
+	// PartnerImageName is the name of the partner image in the manifest.
+	PartnerImageName = "cnf-test-partner"
+	// DebugImageName is the name of the debug image in the manifest.
+	DebugImageName = "debug-partner"
+	// DefaultRepository is the repository of the partner images, unless overridden with TNF_PARTNER_REPO.
+	DefaultRepository = "quay.io/testnetworkfunction"
+	// partnerRepoEnvVar overrides the repository of the partner images, e.g. with a mirror.
+	partnerRepoEnvVar = "TNF_PARTNER_REPO"
+	// ocDebugImageEnvVar sets the image of the "oc debug" pods, which is the cluster tools image otherwise.
+	ocDebugImageEnvVar = "TNF_OC_DEBUG_IMAGE_ID"
+	defaultTag         = "latest"
+	ocBinaryName       = "oc"
+)
+
+// Image is an auxiliary image the test suites may deploy.
+type Image struct {
+	// Name identifies the image in the manifest.
+	Name string `json:"name" yaml:"name"`
+	// Reference is the pull specification of the image, e.g. quay.io/testnetworkfunction/cnf-test-partner:latest.
+	Reference string `json:"reference" yaml:"reference"`
+	// Description tells what the image is used for.
+	Description string `json:"description" yaml:"description"`
+}
+
+// Inspector checks that the image of reference can be pulled, it returns an error otherwise.
+type Inspector func(reference string) error
+
+// GetManifest returns the auxiliary images the test suites may deploy, with the repository and image overrides of the
+// environment applied.
+func GetManifest() []Image {
+	repository := os.Getenv(partnerRepoEnvVar)
+	if repository == "" {
+		repository = DefaultRepository
+	}
+	repository = strings.TrimSuffix(repository, "/")
+	manifest := []Image{
+		{
+			Name:        PartnerImageName,
+			Reference:   fmt.Sprintf("%s/%s:%s", repository, PartnerImageName, defaultTag),
+			Description: "test partner pods, running the networking tests from the cluster, and the canary reference workload",
+		},
+		{
+			Name:        DebugImageName,
+			Reference:   fmt.Sprintf("%s/%s:%s", repository, DebugImageName, defaultTag),
+			Description: "debug daemonset pods, running the platform tests on the nodes",
+		},
+	}
+	if ocDebugImage := os.Getenv(ocDebugImageEnvVar); ocDebugImage != "" {
+		manifest = append(manifest, Image{
+			Name:        "oc-debug",
+			Reference:   ocDebugImage,
+			Description: `"oc debug" pods, running the commands of some tests on the nodes`,
+		})
+	}
+	return manifest
+}
+
+// Check inspects each image of the manifest, it returns an error for each image which cannot be pulled.
+func Check(manifest []Image, inspect Inspector) []error {
+	var errs []error
+	for _, image := range manifest {
+		if err := inspect(image.Reference); err != nil {
+			errs = append(errs, fmt.Errorf("image %s (%s) cannot be pulled: %w", image.Name, image.Reference, err))
+		}
+	}
+	return errs
+}
+
+// OcImageInfo is an Inspector fetching the metadata of the image from its registry with "oc image info", using the
+// registry credentials of the host running oc, the bastion if any, see interactive.SetBastion.
+func OcImageInfo(reference string) error {
+	out, err := interactive.Command(ocBinaryName, "image", "info", reference).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
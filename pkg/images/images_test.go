@@ -0,0 +1,62 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package images_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/images"
+)
+
+func TestGetManifest(t *testing.T) {
+	t.Setenv("TNF_PARTNER_REPO", "")
+	t.Setenv("TNF_OC_DEBUG_IMAGE_ID", "")
+	manifest := images.GetManifest()
+	assert.Len(t, manifest, 2)
+	assert.Equal(t, "quay.io/testnetworkfunction/cnf-test-partner:latest", manifest[0].Reference)
+	assert.Equal(t, "quay.io/testnetworkfunction/debug-partner:latest", manifest[1].Reference)
+
+	t.Setenv("TNF_PARTNER_REPO", "registry.example.com:5000/testnetworkfunction/")
+	t.Setenv("TNF_OC_DEBUG_IMAGE_ID", "registry.example.com:5000/tools@sha256:0f5c")
+	manifest = images.GetManifest()
+	assert.Len(t, manifest, 3)
+	assert.Equal(t, "registry.example.com:5000/testnetworkfunction/cnf-test-partner:latest", manifest[0].Reference)
+	assert.Equal(t, "registry.example.com:5000/testnetworkfunction/debug-partner:latest", manifest[1].Reference)
+	assert.Equal(t, "oc-debug", manifest[2].Name)
+	assert.Equal(t, "registry.example.com:5000/tools@sha256:0f5c", manifest[2].Reference)
+}
+
+func TestCheck(t *testing.T) {
+	manifest := []images.Image{
+		{Name: "pullable", Reference: "registry.example.com/pullable:latest"},
+		{Name: "missing", Reference: "registry.example.com/missing:latest"},
+	}
+	var inspected []string
+	errs := images.Check(manifest, func(reference string) error {
+		inspected = append(inspected, reference)
+		if reference == manifest[1].Reference {
+			return errors.New("manifest unknown")
+		}
+		return nil
+	})
+	assert.Equal(t, []string{manifest[0].Reference, manifest[1].Reference}, inspected)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "missing")
+	assert.Contains(t, errs[0].Error(), "manifest unknown")
+}
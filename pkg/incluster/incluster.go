@@ -0,0 +1,133 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package incluster
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ServiceAccountDir is where the token, the CA certificate and the namespace of the service account of a pod are
+	// mounted.
+	ServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	// KubeconfigEnvVar lists the kubeconfig files read by oc and kubectl.
+	KubeconfigEnvVar = "KUBECONFIG"
+
+	// serviceHostEnvVar and servicePortEnvVar are the address of the API server, set in all the pods.
+	serviceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+	servicePortEnvVar = "KUBERNETES_SERVICE_PORT"
+	tokenFileName     = "token"
+	caFileName        = "ca.crt"
+	namespaceFileName = "namespace"
+	// kubeconfigPerm are the permissions of the written kubeconfig, it references the token of the service account.
+	kubeconfigPerm = 0600
+	dirPermissions = 0755
+
+	kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- name: in-cluster
+  cluster:
+    server: %s
+    certificate-authority: %s
+users:
+- name: service-account
+  user:
+    tokenFile: %s
+contexts:
+- name: in-cluster
+  context:
+    cluster: in-cluster
+    user: service-account
+    namespace: %s
+current-context: in-cluster
+`
+)
+
+// ErrNotInCluster is returned when the current process does not run in a pod, or its service account token is not
+// mounted.
+var ErrNotInCluster = errors.New("not running in a pod with a service account token")
+
+// Config is the access to the cluster from a pod, with its service account.
+type Config struct {
+	// Server is the URL of the API server.
+	Server string
+	// TokenFile and CAFile are the paths of the token and the CA certificate of the service account.
+	TokenFile string
+	CAFile    string
+	// Namespace is the namespace of the pod, empty when not mounted.
+	Namespace string
+}
+
+// Detect returns the access to the cluster of the pod the current process runs in, from the environment of the pod and
+// the service account files of dir, see ServiceAccountDir.  It returns ErrNotInCluster outside of a pod.
+func Detect(dir string) (*Config, error) {
+	host, port := os.Getenv(serviceHostEnvVar), os.Getenv(servicePortEnvVar)
+	if host == "" || port == "" {
+		return nil, ErrNotInCluster
+	}
+	config := &Config{
+		Server:    "https://" + net.JoinHostPort(host, port),
+		TokenFile: filepath.Join(dir, tokenFileName),
+		CAFile:    filepath.Join(dir, caFileName),
+	}
+	if _, err := os.Stat(config.TokenFile); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotInCluster, err)
+	}
+	// the namespace is optional, the commands set it anyway.
+	if namespace, err := os.ReadFile(filepath.Join(dir, namespaceFileName)); err == nil {
+		config.Namespace = strings.TrimSpace(string(namespace))
+	}
+	return config, nil
+}
+
+// Kubeconfig returns the kubeconfig accessing the cluster with the service account.  The token file is referenced
+// rather than copied, so that the rotated tokens are used.
+func (c *Config) Kubeconfig() string {
+	return fmt.Sprintf(kubeconfigTemplate, c.Server, c.CAFile, c.TokenFile, c.Namespace)
+}
+
+// WriteKubeconfig writes the kubeconfig to path, see Kubeconfig.
+func (c *Config) WriteKubeconfig(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), dirPermissions); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(c.Kubeconfig()), kubeconfigPerm)
+}
+
+// HasKubeconfig returns true when oc finds a kubeconfig file, one of the files of KUBECONFIG when set, else
+// ~/.kube/config.
+func HasKubeconfig() bool {
+	paths := filepath.SplitList(os.Getenv(KubeconfigEnvVar))
+	if len(paths) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return false
+		}
+		paths = []string{filepath.Join(home, ".kube", "config")}
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package incluster_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/incluster"
+)
+
+var serviceAccountDir = filepath.Join("testdata", "serviceaccount")
+
+func TestDetect(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "172.30.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "443")
+	config, err := incluster.Detect(serviceAccountDir)
+	assert.Nil(t, err)
+	assert.Equal(t, &incluster.Config{
+		Server:    "https://172.30.0.1:443",
+		TokenFile: filepath.Join(serviceAccountDir, "token"),
+		CAFile:    filepath.Join(serviceAccountDir, "ca.crt"),
+		Namespace: "tnf",
+	}, config)
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "fd02::1")
+	config, err = incluster.Detect(serviceAccountDir)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://[fd02::1]:443", config.Server)
+
+	_, err = incluster.Detect(t.TempDir())
+	assert.True(t, errors.Is(err, incluster.ErrNotInCluster))
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	_, err = incluster.Detect(serviceAccountDir)
+	assert.True(t, errors.Is(err, incluster.ErrNotInCluster))
+}
+
+func TestConfig_WriteKubeconfig(t *testing.T) {
+	config := incluster.Config{Server: "https://172.30.0.1:443", TokenFile: "/sa/token", CAFile: "/sa/ca.crt",
+		Namespace: "tnf"}
+	path := filepath.Join(t.TempDir(), "kubeconfig", "config")
+	assert.Nil(t, config.WriteKubeconfig(path))
+	out, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, config.Kubeconfig(), string(out))
+	assert.Contains(t, string(out), "    server: https://172.30.0.1:443\n")
+	assert.Contains(t, string(out), "    certificate-authority: /sa/ca.crt\n")
+	assert.Contains(t, string(out), "    tokenFile: /sa/token\n")
+	assert.Contains(t, string(out), "    namespace: tnf\n")
+}
+
+func TestHasKubeconfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("KUBECONFIG", "")
+	assert.False(t, incluster.HasKubeconfig())
+	config := incluster.Config{Server: "https://172.30.0.1:443"}
+	assert.Nil(t, config.WriteKubeconfig(filepath.Join(dir, ".kube", "config")))
+	assert.True(t, incluster.HasKubeconfig())
+	t.Setenv("KUBECONFIG", filepath.Join(dir, "missing")+string(os.PathListSeparator)+filepath.Join(dir, "other"))
+	assert.False(t, incluster.HasKubeconfig())
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	schema "github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim"
+)
+
+const (
+	// SuiteReportFileSuffix is appended to the suite name to form the per-suite JUnit file name.
+	SuiteReportFileSuffix = "_junit.xml"
+
+	// unknownSuiteName is used for results that do not carry a test identifier.
+	unknownSuiteName = "unknown"
+
+	reportFilePermissions = 0644
+
+	statePanicked    = "panicked"
+	stateInterrupted = "interrupted"
+	stateAborted     = "aborted"
+)
+
+// Failure is the JUnit <failure> element.
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// Skipped is the JUnit <skipped> element.
+type Skipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// TestCase is the JUnit <testcase> element.
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	Classname string   `xml:"classname,attr"`
+	Status    string   `xml:"status,attr"`
+	Time      float64  `xml:"time,attr"`
+	Failure   *Failure `xml:"failure,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+// TestSuite is the JUnit <testsuite> element.
+type TestSuite struct {
+	XMLName   xml.Name   `xml:"testsuite"`
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	Errors    int        `xml:"errors,attr"`
+	Skipped   int        `xml:"skipped,attr"`
+	Time      float64    `xml:"time,attr"`
+	TestCases []TestCase `xml:"testcase"`
+}
+
+// SuiteName derives the suite name from a claim identifier URL such as
+// http://test-network-function.com/testcases/<suite>/<name>.
+func SuiteName(id *schema.Identifier) string {
+	if id == nil || id.Url == "" {
+		return unknownSuiteName
+	}
+	return path.Base(path.Dir(id.Url))
+}
+
+// newTestCase converts a single claim.Result into a JUnit TestCase.
+func newTestCase(name, suite string, result *schema.Result) TestCase {
+	testCase := TestCase{
+		Name:      name,
+		Classname: suite,
+		Status:    result.State,
+		Time:      time.Duration(result.Duration).Seconds(),
+		SystemOut: result.CapturedTestOutput,
+	}
+	switch result.State {
+	case claim.StateSkipped, claim.StatePending:
+		testCase.Skipped = &Skipped{Message: result.FailureReason}
+	case claim.StateWaived:
+		testCase.Skipped = &Skipped{Message: "waived: " + result.FailureReason}
+	case claim.StateFailed, statePanicked, stateInterrupted, stateAborted:
+		testCase.Failure = &Failure{
+			Message: result.FailureReason,
+			Type:    result.State,
+			Content: fmt.Sprintf("%s\n%s", result.FailureLocation, result.FailureLineContent),
+		}
+	}
+	return testCase
+}
+
+// BuildTestSuites groups the claim results by suite and converts them into JUnit TestSuites.  Suites and test cases are
+// sorted by name so that the output is stable across runs.
+func BuildTestSuites(results map[string][]schema.Result) []TestSuite {
+	suites := map[string]*TestSuite{}
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for i := range results[key] {
+			result := &results[key][i]
+			suiteName := SuiteName(result.TestID)
+			suite, ok := suites[suiteName]
+			if !ok {
+				suite = &TestSuite{Name: suiteName}
+				suites[suiteName] = suite
+			}
+			testCase := newTestCase(key, suiteName, result)
+			suite.Tests++
+			suite.Time += testCase.Time
+			switch {
+			case testCase.Skipped != nil:
+				suite.Skipped++
+			case result.State == statePanicked || result.State == stateAborted:
+				suite.Errors++
+			case testCase.Failure != nil:
+				suite.Failures++
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+	}
+	names := make([]string, 0, len(suites))
+	for name := range suites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	testSuites := make([]TestSuite, 0, len(names))
+	for _, name := range names {
+		testSuites = append(testSuites, *suites[name])
+	}
+	return testSuites
+}
+
+// WriteSuiteReports writes one JUnit XML file per suite into outputDir, named <suite>_junit.xml.  The list of written
+// files is returned.
+func WriteSuiteReports(results map[string][]schema.Result, outputDir string) ([]string, error) {
+	var files []string
+	suites := BuildTestSuites(results)
+	for i := range suites {
+		suite := &suites[i]
+		payload, err := xml.MarshalIndent(suite, "", "  ")
+		if err != nil {
+			return files, err
+		}
+		fileName := filepath.Join(outputDir, suite.Name+SuiteReportFileSuffix)
+		err = os.WriteFile(fileName, append([]byte(xml.Header), payload...), reportFilePermissions)
+		if err != nil {
+			return files, err
+		}
+		files = append(files, fileName)
+	}
+	return files, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package junit_test
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/junit"
+)
+
+var (
+	testNamespaceID = claim.Identifier{Url: "http://test-network-function.com/testcases/access-control/namespace", Version: "v1.0.0"}
+	testPingID      = claim.Identifier{Url: "http://test-network-function.com/testcases/networking/icmpv4-connectivity", Version: "v1.0.0"}
+	testResults     = map[string][]claim.Result{
+		"access-control-access-control-namespace": {
+			{State: "passed", Duration: 2000000000, TestID: &testNamespaceID},
+		},
+		"networking-networking-icmpv4-connectivity": {
+			{State: "failed", Duration: 1000000000, FailureReason: "ping failed", TestID: &testPingID},
+			{State: "skipped", FailureReason: "No Multus IPs detected", TestID: &testPingID},
+		},
+	}
+)
+
+func TestSuiteName(t *testing.T) {
+	assert.Equal(t, "access-control", junit.SuiteName(&testNamespaceID))
+	assert.Equal(t, "unknown", junit.SuiteName(nil))
+}
+
+func TestBuildTestSuites(t *testing.T) {
+	suites := junit.BuildTestSuites(testResults)
+	assert.Len(t, suites, 2)
+	assert.Equal(t, "access-control", suites[0].Name)
+	assert.Equal(t, 1, suites[0].Tests)
+	assert.Equal(t, 0, suites[0].Failures)
+	assert.Equal(t, float64(2), suites[0].Time)
+
+	assert.Equal(t, "networking", suites[1].Name)
+	assert.Equal(t, 2, suites[1].Tests)
+	assert.Equal(t, 1, suites[1].Failures)
+	assert.Equal(t, 1, suites[1].Skipped)
+	assert.Equal(t, "ping failed", suites[1].TestCases[0].Failure.Message)
+	assert.Equal(t, "No Multus IPs detected", suites[1].TestCases[1].Skipped.Message)
+}
+
+func TestBuildTestSuitesWaived(t *testing.T) {
+	suites := junit.BuildTestSuites(map[string][]claim.Result{
+		"networking-networking-icmpv4-connectivity": {
+			{State: "waived", FailureReason: "ping failed", TestID: &testPingID},
+		},
+	})
+	assert.Len(t, suites, 1)
+	assert.Equal(t, 0, suites[0].Failures)
+	assert.Equal(t, 1, suites[0].Skipped)
+	assert.Equal(t, "waived: ping failed", suites[0].TestCases[0].Skipped.Message)
+}
+
+func TestWriteSuiteReports(t *testing.T) {
+	dir := t.TempDir()
+	files, err := junit.WriteSuiteReports(testResults, dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "access-control"+junit.SuiteReportFileSuffix),
+		filepath.Join(dir, "networking"+junit.SuiteReportFileSuffix),
+	}, files)
+
+	contents, err := os.ReadFile(files[1])
+	assert.Nil(t, err)
+	suite := junit.TestSuite{}
+	assert.Nil(t, xml.Unmarshal(contents, &suite))
+	assert.Equal(t, "networking", suite.Name)
+	assert.Len(t, suite.TestCases, 2)
+
+	// the written file must also be readable by the existing JUnit to JSON conversion.
+	_, err = junit.ExportJUnitAsMap(files[1])
+	assert.Nil(t, err)
+}
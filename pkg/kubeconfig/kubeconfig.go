@@ -0,0 +1,136 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// EnvVar lists the kubeconfig files read by oc, kubectl and client-go.
+	EnvVar = "KUBECONFIG"
+	// tempDirPattern is the pattern of the temporary directories of the kubeconfig files, see MkdirTemp.
+	tempDirPattern = "tnf-kubeconfig-"
+	// contextFileName is the name of the kubeconfig file selecting the context, in the directory created by Use.
+	contextFileName = "tnf-kubeconfig-context"
+	// contextFilePerm are the permissions of the kubeconfig file selecting the context.
+	contextFilePerm = 0600
+)
+
+// contextFile is a kubeconfig file which only sets the current context.
+type contextFile struct {
+	APIVersion     string `yaml:"apiVersion"`
+	Kind           string `yaml:"kind"`
+	CurrentContext string `yaml:"current-context"`
+}
+
+// MkdirTemp creates a new temporary directory, only accessible to the user, for the kubeconfig files of the run.  It
+// returns the directory and the function removing it, once the commands reading its files are done.
+func MkdirTemp() (dir string, remove func(), err error) {
+	dir, err = os.MkdirTemp("", tempDirPattern)
+	if err != nil {
+		return "", nil, err
+	}
+	return dir, func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Warnf("Cannot remove the kubeconfig directory %s: %v", dir, err)
+		}
+	}, nil
+}
+
+// Use makes the oc and kubectl commands of the sessions spawned afterwards, which inherit the environment of the
+// process, access the cluster of a kubeconfig and of one of its contexts.  An empty kubeconfig keeps $KUBECONFIG, and
+// an empty context the current context of the kubeconfig.  The context is selected by a kubeconfig file written to a
+// new directory, see MkdirTemp, and listed first in $KUBECONFIG:  the files of $KUBECONFIG are merged, and the first
+// current context set wins, so the kubeconfig files of the user are left untouched.  The returned function removes the
+// directory, once the sessions are done.
+func Use(kubeconfig, context string) (remove func(), err error) {
+	paths, err := getPaths(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	// the context file of a previous call, e.g. by the tnf run command, is replaced.
+	paths = removeContextFiles(paths)
+	remove = func() {}
+	if context != "" {
+		var dir string
+		dir, remove, err = MkdirTemp()
+		if err != nil {
+			return nil, err
+		}
+		contextPath := filepath.Join(dir, contextFileName)
+		if err = writeContextFile(contextPath, context); err != nil {
+			remove()
+			return nil, err
+		}
+		paths = append([]string{contextPath}, paths...)
+	}
+	if err = os.Setenv(EnvVar, strings.Join(paths, string(filepath.ListSeparator))); err != nil {
+		remove()
+		return nil, err
+	}
+	return remove, nil
+}
+
+// getPaths returns the absolute paths of the kubeconfig files:  kubeconfig, $KUBECONFIG or the default kubeconfig file
+// of oc, in this order of precedence.
+func getPaths(kubeconfig string) ([]string, error) {
+	if kubeconfig == "" {
+		kubeconfig = os.Getenv(EnvVar)
+	}
+	if kubeconfig == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		kubeconfig = filepath.Join(home, ".kube", "config")
+	}
+	var paths []string
+	for _, path := range filepath.SplitList(kubeconfig) {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, absPath)
+	}
+	return paths, nil
+}
+
+// removeContextFiles returns paths without the context files written by Use.
+func removeContextFiles(paths []string) []string {
+	var kept []string
+	for _, path := range paths {
+		if filepath.Base(path) != contextFileName {
+			kept = append(kept, path)
+		}
+	}
+	return kept
+}
+
+// writeContextFile writes the kubeconfig file setting context as the current context.
+func writeContextFile(path, context string) error {
+	contents, err := yaml.Marshal(&contextFile{APIVersion: "v1", Kind: "Config", CurrentContext: context})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, contents, contextFilePerm)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package kubeconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/kubeconfig"
+)
+
+func TestUse(t *testing.T) {
+	t.Setenv(kubeconfig.EnvVar, "/kube/config:/kube/config.2")
+
+	// $KUBECONFIG is kept without kubeconfig nor context.
+	remove, err := kubeconfig.Use("", "")
+	assert.Nil(t, err)
+	remove()
+	assert.Equal(t, "/kube/config:/kube/config.2", os.Getenv(kubeconfig.EnvVar))
+
+	remove, err = kubeconfig.Use("/kube/edge", "")
+	assert.Nil(t, err)
+	remove()
+	assert.Equal(t, "/kube/edge", os.Getenv(kubeconfig.EnvVar))
+
+	// the context is selected by a kubeconfig file listed first, in a directory of its own.
+	remove, err = kubeconfig.Use("/kube/edge", "edge-admin")
+	assert.Nil(t, err)
+	paths := filepath.SplitList(os.Getenv(kubeconfig.EnvVar))
+	assert.Len(t, paths, 2)
+	assert.Equal(t, "/kube/edge", paths[1])
+	contextPath := paths[0]
+	assert.Equal(t, "tnf-kubeconfig-context", filepath.Base(contextPath))
+	contents, err := os.ReadFile(contextPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "apiVersion: v1\nkind: Config\ncurrent-context: edge-admin\n", string(contents))
+
+	// the context file of a previous call is replaced.
+	otherRemove, err := kubeconfig.Use("", "lab-admin")
+	assert.Nil(t, err)
+	paths = filepath.SplitList(os.Getenv(kubeconfig.EnvVar))
+	assert.Len(t, paths, 2)
+	assert.NotEqual(t, contextPath, paths[0])
+	assert.Equal(t, "/kube/edge", paths[1])
+	otherRemove()
+	_, err = os.Stat(filepath.Dir(paths[0]))
+	assert.True(t, os.IsNotExist(err))
+
+	otherRemove, err = kubeconfig.Use("", "")
+	assert.Nil(t, err)
+	otherRemove()
+	assert.Equal(t, "/kube/edge", os.Getenv(kubeconfig.EnvVar))
+
+	// the directory is removed once the sessions are done.
+	remove()
+	_, err = os.Stat(filepath.Dir(contextPath))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,167 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/test-network-function/test-network-function/internal/api"
+)
+
+const (
+	// Path is the path of the metrics on the endpoint.
+	Path = "/metrics"
+	// contentType is the content type of the Prometheus text format.
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// labelValueReplacer escapes the label values of the text format.
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// suiteKey identifies the tests of a suite in a state.
+type suiteKey struct {
+	suite string
+	state string
+}
+
+// Metrics holds the metrics of a run, it is safe for concurrent use.
+type Metrics struct {
+	lock       sync.Mutex
+	tests      map[suiteKey]int
+	durations  map[string]time.Duration
+	discovered map[string]int
+	finished   time.Time
+}
+
+// New returns empty metrics.
+func New() *Metrics {
+	return &Metrics{tests: map[suiteKey]int{}, durations: map[string]time.Duration{}, discovered: map[string]int{}}
+}
+
+// RecordTest records a completed test of suite, its state, e.g. "passed", and its duration.
+func (m *Metrics) RecordTest(suite, state string, duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.tests[suiteKey{suite: suite, state: state}]++
+	m.durations[suite] += duration
+}
+
+// SetDiscovered records the number of discovered objects of kind, e.g. "pods".
+func (m *Metrics) SetDiscovered(kind string, count int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.discovered[kind] = count
+}
+
+// Finish records the end of the run.
+func (m *Metrics) Finish(at time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.finished = at
+}
+
+// header writes the help and type lines of a metric.
+func header(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// label formats a label of a sample.
+func label(name, value string) string {
+	return name + "=\"" + labelValueReplacer.Replace(value) + "\""
+}
+
+// WriteTo writes the metrics in the Prometheus text format, sorted by labels.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var buf bytes.Buffer
+	header(&buf, "tnf_tests_total", "Number of completed tests by suite and state.", "counter")
+	keys := make([]suiteKey, 0, len(m.tests))
+	for key := range m.tests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].suite != keys[j].suite {
+			return keys[i].suite < keys[j].suite
+		}
+		return keys[i].state < keys[j].state
+	})
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "tnf_tests_total{%s,%s} %d\n", label("state", key.state), label("suite", key.suite),
+			m.tests[key])
+	}
+	header(&buf, "tnf_suite_duration_seconds", "Total duration of the completed tests by suite.", "gauge")
+	suites := make([]string, 0, len(m.durations))
+	for suite := range m.durations {
+		suites = append(suites, suite)
+	}
+	sort.Strings(suites)
+	for _, suite := range suites {
+		fmt.Fprintf(&buf, "tnf_suite_duration_seconds{%s} %g\n", label("suite", suite), m.durations[suite].Seconds())
+	}
+	header(&buf, "tnf_discovered_objects", "Number of discovered objects under test by kind.", "gauge")
+	kinds := make([]string, 0, len(m.discovered))
+	for kind := range m.discovered {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		fmt.Fprintf(&buf, "tnf_discovered_objects{%s} %d\n", label("kind", kind), m.discovered[kind])
+	}
+	if !m.finished.IsZero() {
+		header(&buf, "tnf_run_finished_timestamp_seconds", "End time of the run in seconds since the epoch.", "gauge")
+		fmt.Fprintf(&buf, "tnf_run_finished_timestamp_seconds %d\n", m.finished.Unix())
+	}
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	_, _ = m.WriteTo(w)
+}
+
+// Push replaces the metrics of job on the Pushgateway at gatewayURL.
+func (m *Metrics) Push(client api.HTTPClient, gatewayURL, job string) error {
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		return err
+	}
+	pushURL := strings.TrimSuffix(gatewayURL, "/") + "/metrics/job/" + url.PathEscape(job)
+	req, err := http.NewRequest(http.MethodPut, pushURL, &buf) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("PUT %s: %s", pushURL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package metrics_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/metrics"
+)
+
+const expectedMetrics = `# HELP tnf_tests_total Number of completed tests by suite and state.
+# TYPE tnf_tests_total counter
+tnf_tests_total{state="failed",suite="lifecycle"} 1
+tnf_tests_total{state="passed",suite="networking"} 2
+tnf_tests_total{state="skipped",suite="networking"} 1
+# HELP tnf_suite_duration_seconds Total duration of the completed tests by suite.
+# TYPE tnf_suite_duration_seconds gauge
+tnf_suite_duration_seconds{suite="lifecycle"} 60
+tnf_suite_duration_seconds{suite="networking"} 2.5
+# HELP tnf_discovered_objects Number of discovered objects under test by kind.
+# TYPE tnf_discovered_objects gauge
+tnf_discovered_objects{kind="pods"} 4
+tnf_discovered_objects{kind="with \"quotes\""} 1
+# HELP tnf_run_finished_timestamp_seconds End time of the run in seconds since the epoch.
+# TYPE tnf_run_finished_timestamp_seconds gauge
+tnf_run_finished_timestamp_seconds 1635849000
+`
+
+func newMetrics() *metrics.Metrics {
+	m := metrics.New()
+	m.RecordTest("networking", "passed", time.Second)
+	m.RecordTest("networking", "passed", time.Second)
+	m.RecordTest("networking", "skipped", time.Second/2)
+	m.RecordTest("lifecycle", "failed", time.Minute)
+	m.SetDiscovered("pods", 4)
+	m.SetDiscovered(`with "quotes"`, 1)
+	m.Finish(time.Date(2021, 11, 2, 10, 30, 0, 0, time.UTC))
+	return m
+}
+
+func TestWriteTo(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := newMetrics().WriteTo(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedMetrics, buf.String())
+}
+
+func TestServeHTTP(t *testing.T) {
+	server := httptest.NewServer(newMetrics())
+	defer server.Close()
+	resp, err := server.Client().Get(server.URL + metrics.Path)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, expectedMetrics, string(body))
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain")
+}
+
+func TestPush(t *testing.T) {
+	var path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		path = r.URL.Path
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+	assert.Nil(t, newMetrics().Push(server.Client(), server.URL+"/", "tnf"))
+	assert.Equal(t, "/metrics/job/tnf", path)
+	assert.Equal(t, expectedMetrics, body)
+	assert.NotNil(t, newMetrics().Push(server.Client(), server.URL+"/missing\x7f", "tnf"))
+}
@@ -0,0 +1,254 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/internal/api"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/httpclient"
+)
+
+const (
+	// EventRunStarted is sent once the suites start running.
+	EventRunStarted = "runStarted"
+	// EventSpecStarted is sent when a spec starts.
+	EventSpecStarted = "specStarted"
+	// EventSpecFinished is sent when a spec completes, with its state, e.g. passed, failed or skipped.
+	EventSpecFinished = "specFinished"
+	// EventRunFinished is sent once all the specs completed, with the number of specs per state.
+	EventRunFinished = "runFinished"
+
+	// queueSize is the number of events waiting to be sent, the events are dropped when the sinks lag further.
+	queueSize = 1000
+	// requestTimeout bounds each request of a webhook.
+	requestTimeout = 10 * time.Second
+	// filePermissions are the permissions of the file of the events, when created.
+	filePermissions = 0644
+)
+
+// Event is a lifecycle event of a run or of one of its specs.
+type Event struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	Suite string    `json:"suite,omitempty"`
+	Spec  string    `json:"spec,omitempty"`
+	// TestCase is the test case of the spec, e.g. "networking-icmpv4-connectivity", if any.
+	TestCase        string  `json:"testCase,omitempty"`
+	State           string  `json:"state,omitempty"`
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+	FailureMessage  string  `json:"failureMessage,omitempty"`
+	// States counts the specs per state at the end of the run.
+	States map[string]int `json:"states,omitempty"`
+}
+
+// Sink is a destination of the events.
+type Sink interface {
+	Send(event *Event) error
+	// String describes the destination, for the logs.
+	String() string
+}
+
+// Writer writes the events on a writer, one JSON event per line.
+type Writer struct {
+	Writer io.Writer
+}
+
+// Send writes event followed by a newline.
+func (w *Writer) Send(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = w.Writer.Write(append(data, '\n'))
+	return err
+}
+
+// String describes the destination.
+func (w *Writer) String() string {
+	if file, ok := w.Writer.(*os.File); ok {
+		return "NDJSON stream " + file.Name()
+	}
+	return "NDJSON stream"
+}
+
+// Close closes the writer when it is an io.Closer, e.g. a file.
+func (w *Writer) Close() error {
+	if closer, ok := w.Writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Webhook POSTs the events to an endpoint, one JSON event per request.
+type Webhook struct {
+	// Client sends the requests, e.g. an httpclient.Client adding the bearer token.
+	Client api.HTTPClient
+	URL    string
+}
+
+// Send POSTs event, and returns an error unless the response status is 2xx.
+func (h *Webhook) Send(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, h.URL, bytes.NewReader(data)) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("POST %s: %s", h.URL, resp.Status)
+	}
+	return nil
+}
+
+// String describes the destination.
+func (h *Webhook) String() string {
+	return h.URL
+}
+
+// NewSinks returns the sinks of a configuration, reading the token of the webhook with getenv.  The file of the
+// events, if any, is opened for appending and closed by Streamer.Close.
+func NewSinks(conf *configsections.ProgressEvents, getenv func(string) string) ([]Sink, error) {
+	var sinks []Sink
+	if conf.URL != "" {
+		token, err := httpclient.GetToken(getenv, conf.TokenEnv)
+		if err != nil {
+			return nil, fmt.Errorf("progress events webhook %s: %w", conf.URL, err)
+		}
+		client, err := httpclient.New(requestTimeout, token, nil)
+		if err != nil {
+			return nil, fmt.Errorf("progress events webhook %s: %w", conf.URL, err)
+		}
+		sinks = append(sinks, &Webhook{Client: client, URL: conf.URL})
+	}
+	if conf.File != "" {
+		file, err := os.OpenFile(conf.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, filePermissions)
+		if err != nil {
+			return nil, fmt.Errorf("progress events file: %w", err)
+		}
+		sinks = append(sinks, &Writer{Writer: file})
+	}
+	return sinks, nil
+}
+
+// Streamer sends the events to its sinks in the background, so that a slow sink does not slow the tests down.  The
+// events are sent in order, and the failures of a sink are logged.
+type Streamer struct {
+	sinks  []Sink
+	queue  chan *Event
+	done   chan struct{}
+	lock   sync.Mutex
+	closed bool
+	// dropped counts the events dropped because the queue was full.
+	dropped int
+}
+
+// NewStreamer returns a Streamer sending the events to sinks until it is closed.
+func NewStreamer(sinks ...Sink) *Streamer {
+	s := &Streamer{sinks: sinks, queue: make(chan *Event, queueSize), done: make(chan struct{})}
+	go s.run()
+	return s
+}
+
+// run sends the queued events until the queue is closed.
+func (s *Streamer) run() {
+	defer close(s.done)
+	failing := map[Sink]bool{}
+	for event := range s.queue {
+		for _, sink := range s.sinks {
+			err := sink.Send(event)
+			// The failures are logged when a sink starts failing, not for every event.
+			if err != nil && !failing[sink] {
+				log.Warnf("Cannot send the progress events to %s: %v", sink, err)
+			} else if err == nil && failing[sink] {
+				log.Infof("Progress events sent to %s again", sink)
+			}
+			failing[sink] = err != nil
+		}
+	}
+}
+
+// Emit queues event, it is dropped when the queue is full or the streamer closed.
+func (s *Streamer) Emit(event *Event) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.queue <- event:
+	default:
+		s.dropped++
+	}
+}
+
+// Close sends the queued events, waiting for at most timeout, stops the streamer and closes the sinks which are
+// io.Closer, e.g. the file of the events.
+func (s *Streamer) Close(timeout time.Duration) {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.queue)
+	dropped := s.dropped
+	s.lock.Unlock()
+	if dropped > 0 {
+		log.Warnf("%d progress events dropped, the sinks lagged", dropped)
+	}
+	select {
+	case <-s.done:
+	case <-time.After(timeout):
+		// the sinks may still be in use, they are left open.
+		log.Warnf("Timed out sending the progress events to %s", describe(s.sinks))
+		return
+	}
+	for _, sink := range s.sinks {
+		if closer, ok := sink.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Warnf("Cannot close the progress events %s: %v", sink, err)
+			}
+		}
+	}
+}
+
+// describe lists the sinks, for the logs.
+func describe(sinks []Sink) string {
+	names := make([]string, 0, len(sinks))
+	for _, sink := range sinks {
+		names = append(names, sink.String())
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package progress_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/progress"
+)
+
+// recordingSink records the events it is sent, and fails while failing is set.
+type recordingSink struct {
+	lock    sync.Mutex
+	events  []string
+	failing bool
+}
+
+func (r *recordingSink) Send(event *progress.Event) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.failing {
+		return errors.New("unavailable")
+	}
+	r.events = append(r.events, event.Event+" "+event.Spec)
+	return nil
+}
+
+func (r *recordingSink) String() string {
+	return "recording"
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &progress.Writer{Writer: &buf}
+	at := time.Date(2021, 11, 2, 10, 30, 0, 0, time.UTC)
+	assert.Nil(t, w.Send(&progress.Event{Event: progress.EventSpecStarted, Time: at, Suite: "networking",
+		Spec: "networking-icmpv4-connectivity"}))
+	assert.Nil(t, w.Send(&progress.Event{Event: progress.EventSpecFinished, Time: at, State: "passed",
+		DurationSeconds: 1.5}))
+	assert.Equal(t, `{"event":"specStarted","time":"2021-11-02T10:30:00Z","suite":"networking",`+
+		`"spec":"networking-icmpv4-connectivity"}`+"\n"+
+		`{"event":"specFinished","time":"2021-11-02T10:30:00Z","state":"passed","durationSeconds":1.5}`+"\n",
+		buf.String())
+}
+
+func TestWebhook(t *testing.T) {
+	var events []progress.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var event progress.Event
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&event))
+		events = append(events, event)
+		if event.Event == progress.EventRunFinished {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	sinks, err := progress.NewSinks(&configsections.ProgressEvents{URL: server.URL, TokenEnv: "TNF_TOKEN"},
+		func(string) string { return "secret" })
+	assert.Nil(t, err)
+	assert.Len(t, sinks, 1)
+	assert.Nil(t, sinks[0].Send(&progress.Event{Event: progress.EventRunStarted}))
+	assert.NotNil(t, sinks[0].Send(&progress.Event{Event: progress.EventRunFinished, States: map[string]int{"passed": 2}}))
+	assert.Equal(t, []progress.Event{{Event: progress.EventRunStarted},
+		{Event: progress.EventRunFinished, States: map[string]int{"passed": 2}}}, events)
+
+	_, err = progress.NewSinks(&configsections.ProgressEvents{URL: server.URL, TokenEnv: "TNF_TOKEN"},
+		func(string) string { return "" })
+	assert.NotNil(t, err)
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.ndjson")
+	assert.Nil(t, os.WriteFile(path, []byte("{}\n"), 0600))
+	sinks, err := progress.NewSinks(&configsections.ProgressEvents{File: path}, os.Getenv)
+	assert.Nil(t, err)
+	assert.Len(t, sinks, 1)
+	streamer := progress.NewStreamer(sinks...)
+	streamer.Emit(&progress.Event{Event: progress.EventRunStarted})
+	streamer.Close(time.Minute)
+	contents, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "{}\n"+`{"event":"runStarted","time":"0001-01-01T00:00:00Z"}`+"\n", string(contents))
+
+	_, err = progress.NewSinks(&configsections.ProgressEvents{File: filepath.Join(path, "none")}, os.Getenv)
+	assert.NotNil(t, err)
+}
+
+func TestStreamer(t *testing.T) {
+	working, broken := &recordingSink{}, &recordingSink{failing: true}
+	streamer := progress.NewStreamer(working, broken)
+	streamer.Emit(&progress.Event{Event: progress.EventSpecStarted, Spec: "a"})
+	streamer.Emit(&progress.Event{Event: progress.EventSpecFinished, Spec: "a"})
+	streamer.Close(time.Minute)
+	streamer.Emit(&progress.Event{Event: progress.EventSpecStarted, Spec: "b"})
+	streamer.Close(time.Minute)
+	assert.Equal(t, []string{"specStarted a", "specFinished a"}, working.events)
+	assert.Empty(t, broken.events)
+}
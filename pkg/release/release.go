@@ -0,0 +1,107 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/test-network-function/test-network-function/internal/api"
+)
+
+const (
+	// DefaultMetadataURL is the URL of the release metadata published with the main branch of the suite.
+	DefaultMetadataURL = "https://raw.githubusercontent.com/test-network-function/test-network-function/main/release-metadata.json"
+	// Published is the required catalog version standing for the published certification policy version.
+	Published = "published"
+)
+
+// Metadata is the release metadata published with the suite.
+type Metadata struct {
+	// PolicyVersion is the version of the published certification policy, the catalog of an official run must not be
+	// older.
+	PolicyVersion string `json:"policyVersion"`
+	// LatestRelease is the latest release of the suite, e.g. v3.2.0.
+	LatestRelease string `json:"latestRelease,omitempty"`
+	// ReleaseURL is where the latest release is published.
+	ReleaseURL string `json:"releaseURL,omitempty"`
+}
+
+// Fetch gets the release metadata from url.
+func Fetch(client api.HTTPClient, url string) (*Metadata, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil) //nolint:noctx
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &Metadata{}
+	if err = json.Unmarshal(payload, metadata); err != nil {
+		return nil, fmt.Errorf("cannot decode the release metadata of %s: %w", url, err)
+	}
+	if _, err = semver.NewVersion(metadata.PolicyVersion); err != nil {
+		return nil, fmt.Errorf("invalid policy version %q in the release metadata of %s: %w", metadata.PolicyVersion,
+			url, err)
+	}
+	return metadata, nil
+}
+
+// IsOlder returns true when version is older than other, both semantic versions, e.g. v1.0.0.
+func IsOlder(version, other string) (bool, error) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	o, err := semver.NewVersion(other)
+	if err != nil {
+		return false, fmt.Errorf("invalid version %q: %w", other, err)
+	}
+	return v.LessThan(o), nil
+}
+
+// Require returns an error when catalogVersion is older than required.  required is either a version, or Published for
+// the policy version of metadata, which must then be known.
+func Require(catalogVersion, required string, metadata *Metadata) error {
+	if required == Published {
+		if metadata == nil {
+			return errors.New("the published certification policy version is unknown")
+		}
+		required = metadata.PolicyVersion
+	}
+	older, err := IsOlder(catalogVersion, required)
+	if err != nil {
+		return err
+	}
+	if older {
+		return fmt.Errorf("the catalog version %s is older than the required %s", catalogVersion, required)
+	}
+	return nil
+}
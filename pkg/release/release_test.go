@@ -0,0 +1,70 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package release_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/release"
+)
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/release-metadata.json":
+			fmt.Fprint(w, `{"policyVersion": "v1.1.0", "latestRelease": "v3.2.0"}`)
+		case "/invalid.json":
+			fmt.Fprint(w, `{"policyVersion": "latest"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	metadata, err := release.Fetch(server.Client(), server.URL+"/release-metadata.json")
+	assert.Nil(t, err)
+	assert.Equal(t, &release.Metadata{PolicyVersion: "v1.1.0", LatestRelease: "v3.2.0"}, metadata)
+
+	_, err = release.Fetch(server.Client(), server.URL+"/invalid.json")
+	assert.NotNil(t, err)
+	_, err = release.Fetch(server.Client(), server.URL+"/unknown.json")
+	assert.NotNil(t, err)
+}
+
+func TestIsOlder(t *testing.T) {
+	older, err := release.IsOlder("v1.0.0", "v1.1.0")
+	assert.Nil(t, err)
+	assert.True(t, older)
+	older, err = release.IsOlder("v1.1.0", "1.1.0")
+	assert.Nil(t, err)
+	assert.False(t, older)
+	_, err = release.IsOlder("v1.0.0", "latest")
+	assert.NotNil(t, err)
+}
+
+func TestRequire(t *testing.T) {
+	metadata := &release.Metadata{PolicyVersion: "v1.1.0"}
+	assert.Nil(t, release.Require("v1.1.0", "v1.0.0", nil))
+	assert.NotNil(t, release.Require("v1.0.0", "v1.1.0", nil))
+	assert.Nil(t, release.Require("v1.1.0", release.Published, metadata))
+	assert.NotNil(t, release.Require("v1.0.0", release.Published, metadata))
+	// the published version must be known.
+	assert.NotNil(t, release.Require("v1.1.0", release.Published, nil))
+}
@@ -0,0 +1,203 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package requirements
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	sctpModule = "sctp"
+	// lsmodHeader is the first field of the header line of lsmod.
+	lsmodHeader = "Module"
+	kBPerMB     = 1024
+	kBPerGB     = 1024 * 1024
+)
+
+var (
+	hugepageSizeRegex    = regexp.MustCompile(`^(?i)(\d+)\s*([kmg])i?b?$`)
+	hugepagesDirRegex    = regexp.MustCompile(`hugepages-(\d+)kB`)
+	kernelVersionNumbers = regexp.MustCompile(`\d+`)
+)
+
+// Provided are the platform features provided by a node.
+type Provided struct {
+	// KernelVersion is the kernel release, as printed by "uname -r".
+	KernelVersion string
+	// KernelModules are the loaded kernel modules.
+	KernelModules []string
+	// HugepageSizesKB are the supported hugepage sizes, in kB.
+	HugepageSizesKB []int
+	// SRIOVTotalVFs is the total number of virtual functions supported by the network interfaces.
+	SRIOVTotalVFs int
+}
+
+// Row is a row of the requirements-vs-provided table.
+type Row struct {
+	Node        string `json:"node"`
+	Requirement string `json:"requirement"`
+	Required    string `json:"required"`
+	Provided    string `json:"provided"`
+	Satisfied   bool   `json:"satisfied"`
+}
+
+// Check compares the requirements to the features provided by a node, it returns one row per declared requirement.
+func Check(node string, required *configsections.PlatformRequirements, provided *Provided) []Row {
+	var rows []Row
+	if required.SCTP {
+		loaded := contains(provided.KernelModules, sctpModule)
+		rows = append(rows, Row{Node: node, Requirement: "sctp", Required: "sctp module loaded",
+			Provided: loadedString(loaded), Satisfied: loaded})
+	}
+	if required.SRIOV {
+		rows = append(rows, Row{Node: node, Requirement: "sriov", Required: "virtual functions",
+			Provided: fmt.Sprintf("%d virtual functions", provided.SRIOVTotalVFs), Satisfied: provided.SRIOVTotalVFs > 0})
+	}
+	for _, size := range required.HugepageSizes {
+		row := Row{Node: node, Requirement: "hugepages", Required: size, Provided: hugepageSizesString(provided.HugepageSizesKB)}
+		if sizeKB, err := ParseHugepageSize(size); err == nil {
+			row.Satisfied = containsInt(provided.HugepageSizesKB, sizeKB)
+		} else {
+			row.Provided = err.Error()
+		}
+		rows = append(rows, row)
+	}
+	for _, module := range required.KernelModules {
+		loaded := contains(provided.KernelModules, module)
+		rows = append(rows, Row{Node: node, Requirement: "kernel-module", Required: module,
+			Provided: loadedString(loaded), Satisfied: loaded})
+	}
+	if required.MinKernelVersion != "" {
+		rows = append(rows, Row{Node: node, Requirement: "kernel-version", Required: ">= " + required.MinKernelVersion,
+			Provided:  provided.KernelVersion,
+			Satisfied: CompareKernelVersions(provided.KernelVersion, required.MinKernelVersion) >= 0})
+	}
+	return rows
+}
+
+// CompareKernelVersions compares the numbers of two kernel releases, e.g. 4.18.0-305.19.1.el8_4.x86_64 and 4.18.0-305,
+// from left to right.  It returns -1, 0 or 1 when a is lower than, equal to or greater than b.  The trailing numbers of
+// the longer release are ignored, 4.18.0-305.19.1 is equal to 4.18.0-305.
+func CompareKernelVersions(a, b string) int {
+	aNumbers := kernelVersionNumbers.FindAllString(strings.SplitN(a, ".el", 2)[0], -1)
+	bNumbers := kernelVersionNumbers.FindAllString(strings.SplitN(b, ".el", 2)[0], -1)
+	for i := 0; i < len(aNumbers) && i < len(bNumbers); i++ {
+		aNumber, _ := strconv.Atoi(aNumbers[i])
+		bNumber, _ := strconv.Atoi(bNumbers[i])
+		if aNumber < bNumber {
+			return -1
+		}
+		if aNumber > bNumber {
+			return 1
+		}
+	}
+	return 0
+}
+
+// ParseHugepageSize parses a hugepage size such as "2M", "1Gi" or "2048kB", it returns the size in kB.
+func ParseHugepageSize(size string) (int, error) {
+	values := hugepageSizeRegex.FindStringSubmatch(strings.TrimSpace(size))
+	if values == nil {
+		return 0, fmt.Errorf("invalid hugepage size %q", size)
+	}
+	number, err := strconv.Atoi(values[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid hugepage size %q: %w", size, err)
+	}
+	switch strings.ToLower(values[2]) {
+	case "m":
+		number *= kBPerMB
+	case "g":
+		number *= kBPerGB
+	}
+	return number, nil
+}
+
+// ParseHugepageSizes parses the listing of /sys/kernel/mm/hugepages, it returns the supported hugepage sizes in kB.
+func ParseHugepageSizes(out string) []int {
+	var sizes []int
+	for _, values := range hugepagesDirRegex.FindAllStringSubmatch(out, -1) {
+		size, err := strconv.Atoi(values[1])
+		if err == nil {
+			sizes = append(sizes, size)
+		}
+	}
+	sort.Ints(sizes)
+	return sizes
+}
+
+// ParseLsmod parses the output of lsmod, it returns the names of the loaded kernel modules.
+func ParseLsmod(out string) []string {
+	var modules []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] == lsmodHeader {
+			continue
+		}
+		modules = append(modules, fields[0])
+	}
+	return modules
+}
+
+func hugepageSizesString(sizesKB []int) string {
+	if len(sizesKB) == 0 {
+		return "none"
+	}
+	sizes := make([]string, len(sizesKB))
+	for i, size := range sizesKB {
+		switch {
+		case size%kBPerGB == 0:
+			sizes[i] = fmt.Sprintf("%dG", size/kBPerGB)
+		case size%kBPerMB == 0:
+			sizes[i] = fmt.Sprintf("%dM", size/kBPerMB)
+		default:
+			sizes[i] = fmt.Sprintf("%dkB", size)
+		}
+	}
+	return strings.Join(sizes, ",")
+}
+
+func loadedString(loaded bool) string {
+	if loaded {
+		return "loaded"
+	}
+	return "not loaded"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package requirements_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/requirements"
+)
+
+const (
+	lsmodOutput = `Module                  Size  Used by
+sctp                  405504  4
+vfio_pci               61440  0
+ixgbe                 385024  0
+`
+	hugepagesOutput = `hugepages-1048576kB
+hugepages-2048kB
+`
+)
+
+func TestCompareKernelVersions(t *testing.T) {
+	assert.Equal(t, 0, requirements.CompareKernelVersions("4.18.0-305.19.1.el8_4.x86_64", "4.18.0-305"))
+	assert.Equal(t, 0, requirements.CompareKernelVersions("4.18.0-305.19.1.el8_4.x86_64", "4.18"))
+	assert.Equal(t, -1, requirements.CompareKernelVersions("4.18.0-240.el8.x86_64", "4.18.0-305"))
+	assert.Equal(t, 1, requirements.CompareKernelVersions("5.14.0-70.el9.x86_64", "4.18.0-305"))
+	assert.Equal(t, -1, requirements.CompareKernelVersions("4.9.0", "4.18"))
+}
+
+func TestParseHugepageSize(t *testing.T) {
+	for size, expected := range map[string]int{"2M": 2048, "1G": 1048576, "1Gi": 1048576, "2048kB": 2048} {
+		sizeKB, err := requirements.ParseHugepageSize(size)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, sizeKB)
+	}
+	_, err := requirements.ParseHugepageSize("huge")
+	assert.NotNil(t, err)
+}
+
+func TestParse(t *testing.T) {
+	assert.Equal(t, []string{"sctp", "vfio_pci", "ixgbe"}, requirements.ParseLsmod(lsmodOutput))
+	assert.Equal(t, []int{2048, 1048576}, requirements.ParseHugepageSizes(hugepagesOutput))
+}
+
+func TestCheck(t *testing.T) {
+	required := configsections.PlatformRequirements{
+		SCTP:             true,
+		SRIOV:            true,
+		HugepageSizes:    []string{"2M", "1G"},
+		KernelModules:    []string{"vfio_pci", "nf_conntrack_sctp"},
+		MinKernelVersion: "4.18.0-305",
+	}
+	provided := requirements.Provided{
+		KernelVersion:   "4.18.0-240.el8.x86_64",
+		KernelModules:   requirements.ParseLsmod(lsmodOutput),
+		HugepageSizesKB: []int{2048},
+	}
+	rows := requirements.Check("worker-0", &required, &provided)
+	satisfied := map[string]bool{}
+	for _, row := range rows {
+		assert.Equal(t, "worker-0", row.Node)
+		satisfied[row.Requirement+"/"+row.Required] = row.Satisfied
+	}
+	assert.Equal(t, map[string]bool{
+		"sctp/sctp module loaded":         true,
+		"sriov/virtual functions":         false,
+		"hugepages/2M":                    true,
+		"hugepages/1G":                    false,
+		"kernel-module/vfio_pci":          true,
+		"kernel-module/nf_conntrack_sctp": false,
+		"kernel-version/>= 4.18.0-305":    false,
+	}, satisfied)
+
+	assert.Empty(t, requirements.Check("worker-0", &configsections.PlatformRequirements{}, &provided))
+}
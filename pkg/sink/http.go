@@ -0,0 +1,68 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sink
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/internal/api"
+)
+
+// HTTP uploads the artifacts with PUT requests to an endpoint.
+type HTTP struct {
+	// Client sends the requests, e.g. an httpclient.Client adding the bearer token.
+	Client api.HTTPClient
+	// URL is the endpoint, the artifacts are PUT to <URL>/<name>.
+	URL string
+}
+
+// NewHTTP returns a sink uploading the artifacts to url.
+func NewHTTP(client api.HTTPClient, url string) *HTTP {
+	return &HTTP{Client: client, URL: strings.TrimSuffix(url, "/")}
+}
+
+// Write uploads the payload to <URL>/<name>.
+func (h *HTTP) Write(name string, payload []byte) error {
+	url := h.URL + "/" + name
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(payload)) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType(name))
+	return do(h.Client, req)
+}
+
+// String describes the destination.
+func (h *HTTP) String() string {
+	return h.URL
+}
+
+// do sends an upload request, and returns an error unless the response status is 2xx.
+func do(client api.HTTPClient, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s %s: %s", req.Method, req.URL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sink
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/internal/api"
+)
+
+const (
+	// DefaultS3Region is the region of the S3 sinks which do not set one.
+	DefaultS3Region = "us-east-1"
+
+	s3Service          = "s3"
+	s3Algorithm        = "AWS4-HMAC-SHA256"
+	s3DateFormat       = "20060102"
+	s3DateTimeFormat   = "20060102T150405Z"
+	s3ContentSHA256    = "X-Amz-Content-Sha256"
+	s3Date             = "X-Amz-Date"
+	s3TerminationValue = "aws4_request"
+)
+
+// S3 uploads the artifacts to a bucket of an S3-compatible storage, e.g. AWS S3, MinIO or Ceph, with path-style
+// requests signed with AWS Signature Version 4.
+type S3 struct {
+	Client api.HTTPClient
+	// URL is the endpoint of the storage, e.g. https://s3.us-east-1.amazonaws.com.
+	URL    string
+	Region string
+	Bucket string
+	// Prefix is prepended to the artifact names, e.g. "runs/42/".
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// Now returns the signing time, time.Now by default.
+	Now func() time.Time
+}
+
+// NewS3 returns a sink uploading the artifacts to the bucket of the storage at url.
+func NewS3(client api.HTTPClient, url, region, bucket, prefix, accessKeyID, secretAccessKey string) *S3 {
+	if region == "" {
+		region = DefaultS3Region
+	}
+	return &S3{Client: client, URL: strings.TrimSuffix(url, "/"), Region: region, Bucket: bucket, Prefix: prefix,
+		AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey, Now: time.Now}
+}
+
+// Write uploads the payload to the object <Prefix><name> of the bucket.
+func (s *S3) Write(name string, payload []byte) error {
+	url := s.URL + "/" + uriEncode(s.Bucket+"/"+s.Prefix+name, false)
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(payload)) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType(name))
+	s.sign(req, payload)
+	return do(s.Client, req)
+}
+
+// String describes the destination.
+func (s *S3) String() string {
+	return fmt.Sprintf("s3 bucket %s at %s", s.Bucket, s.URL)
+}
+
+// sign sets the date, the payload hash and the authorization of the request, signing all its headers.
+func (s *S3) sign(req *http.Request, payload []byte) {
+	now := s.Now().UTC()
+	payloadHash := sha256Hex(payload)
+	req.Header.Set(s3Date, now.Format(s3DateTimeFormat))
+	req.Header.Set(s3ContentSHA256, payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for key, values := range req.Header {
+		headers[strings.ToLower(key)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := strings.Join([]string{now.Format(s3DateFormat), s.Region, s3Service, s3TerminationValue}, "/")
+	stringToSign := strings.Join([]string{s3Algorithm, now.Format(s3DateTimeFormat), scope,
+		sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretAccessKey), now.Format(s3DateFormat))
+	for _, part := range []string{s.Region, s3Service, s3TerminationValue} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", s3Algorithm,
+		s.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery returns the query of the request with its parameters sorted and encoded.
+func canonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// uriEncode percent-encodes all the bytes of value but the unreserved characters, and the slashes unless encodeSlash.
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '.', b == '_', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func sha256Hex(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
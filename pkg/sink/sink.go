@@ -0,0 +1,160 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sink
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/httpclient"
+)
+
+const (
+	// uploadTimeout bounds each upload of an HTTP or S3 sink.
+	uploadTimeout = 2 * time.Minute
+	// filePermissions are the permissions of the artifacts written by a file sink.
+	filePermissions = 0644
+	// dirPermissions are the permissions of the directory of a file sink, when created.
+	dirPermissions = 0755
+)
+
+// Sink is a destination of the artifacts of a run.
+type Sink interface {
+	// Write stores the payload of the artifact name, e.g. "claim.json".
+	Write(name string, payload []byte) error
+	// String describes the destination, for the logs.
+	String() string
+}
+
+// Getenv reads the credentials of the sinks, e.g. os.Getenv.
+type Getenv func(key string) string
+
+// New creates the sink of a configuration, reading its credentials with getenv.
+func New(conf *configsections.OutputSink, getenv Getenv) (Sink, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	switch conf.Type {
+	case configsections.OutputSinkStdout:
+		return &Stdout{Writer: os.Stdout}, nil
+	case configsections.OutputSinkFile:
+		return &File{Dir: conf.Path}, nil
+	case configsections.OutputSinkHTTP:
+		token, err := httpclient.GetToken(getenv, conf.TokenEnv)
+		if err != nil {
+			return nil, fmt.Errorf("http output sink %s: %w", conf.URL, err)
+		}
+		client, err := httpclient.New(uploadTimeout, token, nil)
+		if err != nil {
+			return nil, fmt.Errorf("http output sink %s: %w", conf.URL, err)
+		}
+		return NewHTTP(client, conf.URL), nil
+	default:
+		accessKeyID, secretAccessKey := getenv(conf.AccessKeyIDEnv), getenv(conf.SecretAccessKeyEnv)
+		if accessKeyID == "" || secretAccessKey == "" {
+			return nil, fmt.Errorf("s3 output sink %s: %s and %s must be set", conf.Bucket, conf.AccessKeyIDEnv,
+				conf.SecretAccessKeyEnv)
+		}
+		// the requests are signed with the access keys instead of carrying a bearer token.
+		client, err := httpclient.New(uploadTimeout, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("s3 output sink %s: %w", conf.Bucket, err)
+		}
+		return NewS3(client, conf.URL, conf.Region, conf.Bucket, conf.Prefix, accessKeyID, secretAccessKey), nil
+	}
+}
+
+// NewAll creates the sinks of the configuration.  See New.
+func NewAll(confs []configsections.OutputSink, getenv Getenv) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(confs))
+	for i := range confs {
+		s, err := New(&confs[i], getenv)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks, nil
+}
+
+// WriteAll writes the artifact to every sink, even when some fail, and returns the errors of the failed sinks.
+func WriteAll(sinks []Sink, name string, payload []byte) []error {
+	var errs []error
+	for _, s := range sinks {
+		if err := s.Write(name, payload); err != nil {
+			errs = append(errs, fmt.Errorf("writing %s to %s: %w", name, s, err))
+		}
+	}
+	return errs
+}
+
+// Stdout writes the artifacts on a writer, the standard output by default.
+type Stdout struct {
+	Writer io.Writer
+}
+
+// Write prints the payload, followed by a newline unless it ends with one.
+func (s *Stdout) Write(_ string, payload []byte) error {
+	if _, err := s.Writer.Write(payload); err != nil {
+		return err
+	}
+	if len(payload) > 0 && payload[len(payload)-1] == '\n' {
+		return nil
+	}
+	_, err := io.WriteString(s.Writer, "\n")
+	return err
+}
+
+// String describes the destination.
+func (s *Stdout) String() string {
+	return "stdout"
+}
+
+// File writes the artifacts in a local directory, created if needed.
+type File struct {
+	Dir string
+}
+
+// Write writes the payload to the file name of the directory.
+func (f *File) Write(name string, payload []byte) error {
+	if err := os.MkdirAll(f.Dir, dirPermissions); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(f.Dir, name), payload, filePermissions)
+}
+
+// String describes the destination.
+func (f *File) String() string {
+	return "directory " + f.Dir
+}
+
+// contentType returns the content type of an artifact from its extension.
+func contentType(name string) string {
+	switch {
+	case strings.HasSuffix(name, ".json"):
+		return "application/json"
+	case strings.HasSuffix(name, ".xml"):
+		return "application/xml"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sink_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/httpclient"
+	"github.com/test-network-function/test-network-function/pkg/sink"
+)
+
+// upload is a request received by the test server.
+type upload struct {
+	path          string
+	contentType   string
+	authorization string
+	payload       string
+}
+
+func newServer(uploads *[]upload) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || strings.Contains(r.URL.Path, "forbidden") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		payload, _ := io.ReadAll(r.Body)
+		*uploads = append(*uploads, upload{path: r.URL.EscapedPath(), contentType: r.Header.Get("Content-Type"),
+			authorization: r.Header.Get("Authorization"), payload: string(payload)})
+	}))
+}
+
+func getenv(key string) string {
+	return map[string]string{"TNF_TOKEN": "secret", "AWS_ACCESS_KEY_ID": "AKID", "AWS_SECRET_ACCESS_KEY": "key"}[key]
+}
+
+func TestStdout(t *testing.T) {
+	var buf bytes.Buffer
+	s := &sink.Stdout{Writer: &buf}
+	assert.Nil(t, s.Write("claim.json", []byte(`{"claim": {}}`)))
+	assert.Nil(t, s.Write("report.xml", []byte("<testsuites/>\n")))
+	assert.Equal(t, "{\"claim\": {}}\n<testsuites/>\n", buf.String())
+}
+
+func TestFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "results")
+	s := &sink.File{Dir: dir}
+	assert.Nil(t, s.Write("claim.json", []byte("{}")))
+	payload, err := os.ReadFile(filepath.Join(dir, "claim.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", string(payload))
+}
+
+func TestHTTP(t *testing.T) {
+	var uploads []upload
+	server := newServer(&uploads)
+	defer server.Close()
+
+	s := sink.NewHTTP(&httpclient.Client{HTTP: server.Client(), Token: "secret"}, server.URL+"/results/")
+	assert.Nil(t, s.Write("claim.json", []byte("{}")))
+	assert.Equal(t, []upload{{path: "/results/claim.json", contentType: "application/json",
+		authorization: "Bearer secret", payload: "{}"}}, uploads)
+	assert.NotNil(t, s.Write("forbidden.json", []byte("{}")))
+}
+
+func TestS3(t *testing.T) {
+	var uploads []upload
+	server := newServer(&uploads)
+	defer server.Close()
+
+	s := sink.NewS3(server.Client(), server.URL, "", "tnf", "runs/42/", "AKID", "key")
+	assert.Nil(t, s.Write("report.xml", []byte("<testsuites/>")))
+	assert.Len(t, uploads, 1)
+	assert.Equal(t, "/tnf/runs/42/report.xml", uploads[0].path)
+	assert.Equal(t, "application/xml", uploads[0].contentType)
+	assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=AKID/\d{8}/us-east-1/s3/aws4_request, `+
+		`SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`, uploads[0].authorization)
+	assert.Equal(t, "<testsuites/>", uploads[0].payload)
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		conf        configsections.OutputSink
+		expectedErr bool
+		expected    string
+	}{
+		{configsections.OutputSink{Type: configsections.OutputSinkStdout}, false, "stdout"},
+		{configsections.OutputSink{Type: configsections.OutputSinkFile, Path: "/tmp/tnf"}, false, "directory /tmp/tnf"},
+		{configsections.OutputSink{Type: configsections.OutputSinkHTTP, URL: "https://results.example.com",
+			TokenEnv: "TNF_TOKEN"}, false, "https://results.example.com"},
+		{configsections.OutputSink{Type: configsections.OutputSinkHTTP, URL: "https://results.example.com",
+			TokenEnv: "UNSET_TOKEN"}, true, ""},
+		{configsections.OutputSink{Type: configsections.OutputSinkS3, URL: "https://s3.example.com", Bucket: "tnf",
+			AccessKeyIDEnv: "AWS_ACCESS_KEY_ID", SecretAccessKeyEnv: "AWS_SECRET_ACCESS_KEY"}, false,
+			"s3 bucket tnf at https://s3.example.com"},
+		{configsections.OutputSink{Type: configsections.OutputSinkS3, URL: "https://s3.example.com", Bucket: "tnf",
+			AccessKeyIDEnv: "AWS_ACCESS_KEY_ID", SecretAccessKeyEnv: "UNSET_KEY"}, true, ""},
+		{configsections.OutputSink{Type: "ftp"}, true, ""},
+	}
+	for _, tc := range testCases {
+		s, err := sink.New(&tc.conf, getenv)
+		if tc.expectedErr {
+			assert.NotNil(t, err)
+			continue
+		}
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expected, s.String())
+	}
+}
+
+func TestWriteAll(t *testing.T) {
+	var uploads []upload
+	server := newServer(&uploads)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	sinks := []sink.Sink{sink.NewHTTP(server.Client(), server.URL+"/forbidden"), &sink.Stdout{Writer: &buf}}
+	errs := sink.WriteAll(sinks, "claim.json", []byte("{}"))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "{}\n", buf.String())
+
+	_, err := sink.NewAll([]configsections.OutputSink{{Type: configsections.OutputSinkStdout}, {Type: "ftp"}}, getenv)
+	assert.NotNil(t, err)
+}
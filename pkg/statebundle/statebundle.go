@@ -0,0 +1,257 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package statebundle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+)
+
+const (
+	// KindPod is the kind of the pod targets.
+	KindPod = "pod"
+	// KindDeployment is the kind of the deployment targets.
+	KindDeployment = "deployment"
+	// KindStatefulSet is the kind of the statefulset targets.
+	KindStatefulSet = "statefulset"
+	// KindNode is the kind of the node targets.
+	KindNode = "node"
+
+	ocBinaryName      = "oc"
+	kubectlBinaryName = "kubectl"
+	dirPermissions    = 0755
+	filePermissions   = 0644
+	// containerTargetParts is the number of parts of the "namespace/pod/container" failed targets.
+	containerTargetParts = 3
+)
+
+// unsafeFileNameChars are replaced in the names of the bundle files.
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FileName returns name with the characters unsafe in file names replaced.
+func FileName(name string) string {
+	return strings.Trim(unsafeFileNameChars.ReplaceAllString(name, "_"), "_")
+}
+
+// Fingerprint identifies the environment of a run.
+type Fingerprint struct {
+	Versions *claim.Versions `json:"versions"`
+	// GoVersion and Platform are the Go version and the OS/architecture the test executable was built with.
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+	// Configuration is the test configuration, as recorded in the claim.
+	Configuration interface{} `json:"configuration,omitempty"`
+}
+
+// NewFingerprint returns the fingerprint of the environment with versions and configuration.
+func NewFingerprint(versions *claim.Versions, configuration interface{}) Fingerprint {
+	return Fingerprint{
+		Versions:      versions,
+		GoVersion:     runtime.Version(),
+		Platform:      runtime.GOOS + "/" + runtime.GOARCH,
+		Configuration: configuration,
+	}
+}
+
+// Target is an object of the cluster a spec failed on, e.g. a pod under test.
+type Target struct {
+	// Name is the target as recorded in the claim, e.g. "namespace/pod".
+	Name      string
+	Kind      string
+	Namespace string
+	Object    string
+}
+
+// GetTargets returns the pods, deployments, statefulsets and nodes of env among failedTargets, where a container target
+// stands for its pod, or all the pods under test when the spec recorded no failed target.
+func GetTargets(env *config.TestEnvironment, failedTargets []string) []Target {
+	failed := map[string]bool{}
+	for _, target := range failedTargets {
+		failed[target] = true
+		if parts := strings.Split(target, "/"); len(parts) == containerTargetParts {
+			failed[parts[0]+"/"+parts[1]] = true
+		}
+	}
+	var targets []Target
+	for i := range env.PodsUnderTest {
+		pod := &env.PodsUnderTest[i]
+		if len(failedTargets) == 0 || failed[pod.FullName()] {
+			targets = append(targets, Target{Name: pod.FullName(), Kind: KindPod, Namespace: pod.Namespace, Object: pod.Name})
+		}
+	}
+	for _, deployment := range env.DeploymentsUnderTest {
+		if name := deployment.Namespace + "/" + deployment.Name; failed[name] {
+			targets = append(targets, Target{Name: name, Kind: KindDeployment, Namespace: deployment.Namespace,
+				Object: deployment.Name})
+		}
+	}
+	for _, statefulSet := range env.StatefulSetsUnderTest {
+		if name := statefulSet.Namespace + "/" + statefulSet.Name; failed[name] {
+			targets = append(targets, Target{Name: name, Kind: KindStatefulSet, Namespace: statefulSet.Namespace,
+				Object: statefulSet.Name})
+		}
+	}
+	for _, target := range failedTargets {
+		if _, ok := env.NodesUnderTest[target]; ok {
+			targets = append(targets, Target{Name: target, Kind: KindNode, Object: target})
+		}
+	}
+	return targets
+}
+
+// Object is the JSON of a target at the time of the failure, or the error preventing its fetch.
+type Object struct {
+	Target string          `json:"target"`
+	Kind   string          `json:"kind"`
+	JSON   json.RawMessage `json:"json,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// Fetcher returns the JSON of the object of kind named name in namespace, namespace is empty for the cluster-scoped
+// kinds.
+type Fetcher func(kind, namespace, name string) (json.RawMessage, error)
+
+// RunOc runs oc, or the same kubectl command when oc is not installed, and returns its output.  The error includes the
+// standard error of the command.
+func RunOc(args ...string) ([]byte, error) {
+	binary := ocBinaryName
+	if occompat.UsesKubectl() {
+		binary = kubectlBinaryName
+	}
+	out, err := interactive.Command(binary, args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return out, fmt.Errorf("%s %s: %w", binary, strings.Join(args, " "), err)
+	}
+	return out, nil
+}
+
+// OcGet is a Fetcher getting the object with "oc get", see RunOc.
+func OcGet(kind, namespace, name string) (json.RawMessage, error) {
+	args := []string{"get", kind, name, "-o", "json"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+	out, err := RunOc(args...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Snapshot fetches the objects of targets with fetch, the fetch errors are recorded in place of the objects.
+func Snapshot(fetch Fetcher, targets []Target) []Object {
+	objects := make([]Object, 0, len(targets))
+	for _, target := range targets {
+		object := Object{Target: target.Name, Kind: target.Kind}
+		if data, err := fetch(target.Kind, target.Namespace, target.Object); err != nil {
+			object.Error = err.Error()
+		} else {
+			object.JSON = data
+		}
+		objects = append(objects, object)
+	}
+	return objects
+}
+
+// Bundle is the state of a failed spec.
+type Bundle struct {
+	Spec            string    `json:"spec"`
+	TestCase        string    `json:"testCase,omitempty"`
+	State           string    `json:"state"`
+	FailureMessage  string    `json:"failureMessage,omitempty"`
+	FailureLocation string    `json:"failureLocation,omitempty"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	// FailedTargets are the targets recorded as failed by the spec, if any.
+	FailedTargets []string `json:"failedTargets,omitempty"`
+	// Objects are the target objects at the time of the failure.
+	Objects []Object `json:"objects,omitempty"`
+	// Transcripts are the tests run by the spec, in the order they completed.
+	Transcripts []tnf.Transcript `json:"transcripts"`
+	Environment Fingerprint      `json:"environment"`
+}
+
+// Recorder collects the transcripts of the tests of the running spec and writes the bundles of the failed specs.
+type Recorder struct {
+	dir         string
+	lock        sync.Mutex
+	transcripts []tnf.Transcript
+}
+
+// NewRecorder returns a Recorder writing the bundles into dir, which is created if needed.
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{dir: dir}
+}
+
+// RecordTranscript records the transcript of a test of the running spec, it is meant to be set with
+// tnf.SetTranscriptHandler and can thus be called by the tests run in parallel.
+func (r *Recorder) RecordTranscript(transcript *tnf.Transcript) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.transcripts = append(r.transcripts, *transcript)
+}
+
+// Flush returns and forgets the transcripts recorded since the previous call, i.e. the ones of the spec which just
+// completed.
+func (r *Recorder) Flush() []tnf.Transcript {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	transcripts := r.transcripts
+	r.transcripts = nil
+	return transcripts
+}
+
+// Write writes bundle as JSON into the directory of the recorder, named after its test case, or its spec, and its
+// start time.  It returns the path of the written file.
+func (r *Recorder) Write(bundle *Bundle) (string, error) {
+	if err := os.MkdirAll(r.dir, dirPermissions); err != nil {
+		return "", err
+	}
+	name := bundle.TestCase
+	if name == "" {
+		name = bundle.Spec
+	}
+	name = fmt.Sprintf("%s-%s.json", FileName(name), bundle.StartTime.UTC().Format("20060102T150405"))
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(r.dir, name)
+	if err := os.WriteFile(path, data, filePermissions); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package statebundle_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/statebundle"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+)
+
+func TestSnapshot(t *testing.T) {
+	fetch := func(kind, namespace, name string) (json.RawMessage, error) {
+		if kind == statebundle.KindPod && namespace == "tnf" && name == "test" {
+			return json.RawMessage(`{"kind":"Pod"}`), nil
+		}
+		return nil, errors.New("not found")
+	}
+	objects := statebundle.Snapshot(fetch, []statebundle.Target{
+		{Name: "tnf/test", Kind: statebundle.KindPod, Namespace: "tnf", Object: "test"},
+		{Name: "worker-0", Kind: statebundle.KindNode, Object: "worker-0"},
+	})
+	assert.Equal(t, []statebundle.Object{
+		{Target: "tnf/test", Kind: statebundle.KindPod, JSON: json.RawMessage(`{"kind":"Pod"}`)},
+		{Target: "worker-0", Kind: statebundle.KindNode, Error: "not found"},
+	}, objects)
+}
+
+func TestGetTargets(t *testing.T) {
+	env := &config.TestEnvironment{
+		PodsUnderTest:         []configsections.Pod{{Namespace: "tnf", Name: "test-0"}, {Namespace: "tnf", Name: "test-1"}},
+		DeploymentsUnderTest:  []configsections.Deployment{{Namespace: "tnf", Name: "test"}},
+		StatefulSetsUnderTest: []configsections.StatefulSet{{Namespace: "tnf", Name: "db"}},
+		NodesUnderTest:        map[string]*config.NodeConfig{"worker-0": {Name: "worker-0"}},
+	}
+	pod0 := statebundle.Target{Name: "tnf/test-0", Kind: statebundle.KindPod, Namespace: "tnf", Object: "test-0"}
+	pod1 := statebundle.Target{Name: "tnf/test-1", Kind: statebundle.KindPod, Namespace: "tnf", Object: "test-1"}
+
+	// all the pods without failed target.
+	assert.Equal(t, []statebundle.Target{pod0, pod1}, statebundle.GetTargets(env, nil))
+	// a container target stands for its pod.
+	assert.Equal(t, []statebundle.Target{
+		pod1,
+		{Name: "tnf/test", Kind: statebundle.KindDeployment, Namespace: "tnf", Object: "test"},
+		{Name: "tnf/db", Kind: statebundle.KindStatefulSet, Namespace: "tnf", Object: "db"},
+		{Name: "worker-0", Kind: statebundle.KindNode, Object: "worker-0"},
+	}, statebundle.GetTargets(env, []string{"tnf/test-1/app", "tnf/test", "tnf/db", "worker-0", "tnf/unknown"}))
+}
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+	recorder := statebundle.NewRecorder(filepath.Join(dir, "bundles"))
+	recorder.RecordTranscript(&tnf.Transcript{Test: "http://test-network-function.com/tests/fake", Result: "FAILURE",
+		Exchanges: []tnf.Exchange{{Execute: "ls", Output: "file"}}})
+	transcripts := recorder.Flush()
+	assert.Len(t, transcripts, 1)
+	assert.Empty(t, recorder.Flush())
+
+	bundle := &statebundle.Bundle{
+		Spec:        "[It] networking networking-icmpv4-connectivity",
+		TestCase:    "networking-icmpv4-connectivity",
+		State:       "failed",
+		StartTime:   time.Date(2021, 11, 2, 10, 30, 0, 0, time.UTC),
+		Transcripts: transcripts,
+		Environment: statebundle.NewFingerprint(&claim.Versions{Tnf: "v3.0.0"}, nil),
+	}
+	path, err := recorder.Write(bundle)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "bundles", "networking-icmpv4-connectivity-20211102T103000.json"), path)
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	var written statebundle.Bundle
+	assert.Nil(t, json.Unmarshal(data, &written))
+	assert.Equal(t, bundle.Transcripts, written.Transcripts)
+	assert.Equal(t, "v3.0.0", written.Environment.Versions.Tnf)
+	assert.NotEmpty(t, written.Environment.GoVersion)
+}
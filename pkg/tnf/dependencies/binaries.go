@@ -70,4 +70,31 @@ const (
 
 	// WcBinaryName is the name of the Unix `wc` command
 	WcBinaryName = "wc"
+
+	// TcpdumpBinaryName is the name of the Unix `tcpdump` command.
+	TcpdumpBinaryName = "tcpdump"
+
+	// NcatBinaryName is the name of the Nmap `ncat` command.
+	NcatBinaryName = "ncat"
+
+	// Iperf3BinaryName is the name of the `iperf3` network throughput measurement tool.
+	Iperf3BinaryName = "iperf3"
+
+	// GetentBinaryName is the name of the Unix `getent` command.
+	GetentBinaryName = "getent"
+
+	// CrictlBinaryName is the name of the CRI-O `crictl` container runtime client.
+	CrictlBinaryName = "crictl"
+
+	// GetenforceBinaryName is the name of the SELinux `getenforce` command.
+	GetenforceBinaryName = "getenforce"
+
+	// TrBinaryName is the name of the Unix `tr` command.
+	TrBinaryName = "tr"
+
+	// ConntrackBinaryName is the name of the netfilter `conntrack` connection tracking tool.
+	ConntrackBinaryName = "conntrack"
+
+	// TimeoutBinaryName is the name of the Unix `timeout` command.
+	TimeoutBinaryName = "timeout"
 )
@@ -0,0 +1,108 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package automounttoken
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// SettingsOutputRegex matches the automountServiceAccountToken settings of the pod and of its service account,
+	// empty when not set.
+	SettingsOutputRegex = `pod=(true|false)? serviceAccount=(true|false)?\s`
+
+	// defaultServiceAccount is the service account of the pods which do not set one.
+	defaultServiceAccount = "default"
+)
+
+// AutomountToken provides a test reading whether the service account token is mounted in a pod.
+type AutomountToken struct {
+	tnf.OcCommand
+	automount bool
+	explicit  bool
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (a *AutomountToken) GetIdentifier() identifier.Identifier {
+	return identifier.AutomountTokenIdentifier
+}
+
+// ReelMatch records whether the token is mounted and sets the test result on match: FAILURE when the token is
+// mounted, SUCCESS otherwise.  The setting of the pod takes precedence over the setting of its service account, and
+// the token is mounted when neither is set.
+// Returns no step; the test is complete.
+func (a *AutomountToken) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != SettingsOutputRegex {
+		return nil
+	}
+	matched := regexp.MustCompile(pattern).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	podSetting, serviceAccountSetting := matched[1], matched[2]
+	switch {
+	case podSetting != "":
+		a.automount = podSetting == "true"
+		a.explicit = true
+	case serviceAccountSetting != "":
+		a.automount = serviceAccountSetting == "true"
+		a.explicit = true
+	default:
+		a.automount = true
+	}
+	a.SetResult(tnf.SUCCESS)
+	if a.automount {
+		a.SetResult(tnf.FAILURE)
+	}
+	return nil
+}
+
+// IsAutomounted returns true when the service account token is mounted in the pod.
+func (a *AutomountToken) IsAutomounted() bool {
+	return a.automount
+}
+
+// IsExplicit returns true when the pod or its service account sets automountServiceAccountToken, false when the
+// Kubernetes default applies.
+func (a *AutomountToken) IsExplicit() bool {
+	return a.explicit
+}
+
+// Command returns the command line printing the automountServiceAccountToken settings of the pod and of its service
+// account, the "default" service account when empty.
+func Command(podName, podNamespace, serviceAccount string) []string {
+	if serviceAccount == "" {
+		serviceAccount = defaultServiceAccount
+	}
+	return []string{"echo", fmt.Sprintf(`"pod=$(%s -n %s get pods %s -o 'jsonpath={.spec.automountServiceAccountToken}') `+
+		`serviceAccount=$(%s -n %s get serviceaccounts %s -o 'jsonpath={.automountServiceAccountToken}')"`,
+		dependencies.OcBinaryName, podNamespace, podName, dependencies.OcBinaryName, podNamespace, serviceAccount)}
+}
+
+// NewAutomountToken creates a new `AutomountToken` test which reads whether the service account token is mounted in
+// the pod.  See Command.
+func NewAutomountToken(timeout time.Duration, podName, podNamespace, serviceAccount string) *AutomountToken {
+	return &AutomountToken{
+		OcCommand: tnf.NewOcCommand(timeout, Command(podName, podNamespace, serviceAccount), SettingsOutputRegex),
+	}
+}
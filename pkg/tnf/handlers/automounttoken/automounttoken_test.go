@@ -0,0 +1,72 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package automounttoken_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/automounttoken"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testPodName         = "test-0"
+	testPodNamespace    = "tnf"
+	testServiceAccount  = "test-sa"
+)
+
+func TestCommand(t *testing.T) {
+	command := strings.Join(automounttoken.Command(testPodName, testPodNamespace, testServiceAccount), " ")
+	assert.Equal(t, `echo "pod=$(oc -n tnf get pods test-0 -o 'jsonpath={.spec.automountServiceAccountToken}') `+
+		`serviceAccount=$(oc -n tnf get serviceaccounts test-sa -o 'jsonpath={.automountServiceAccountToken}')"`, command)
+	// the echoed command line must not match.
+	assert.NotRegexp(t, automounttoken.SettingsOutputRegex, command+"\n")
+	assert.Contains(t, strings.Join(automounttoken.Command(testPodName, testPodNamespace, ""), " "),
+		"get serviceaccounts default ")
+}
+
+func TestAutomountToken_GetIdentifier(t *testing.T) {
+	test := automounttoken.NewAutomountToken(testTimeoutDuration, testPodName, testPodNamespace, testServiceAccount)
+	assert.Equal(t, identifier.AutomountTokenIdentifier, test.GetIdentifier())
+}
+
+func TestAutomountToken_ReelMatch(t *testing.T) {
+	testCases := []struct {
+		output            string
+		expectedResult    int
+		expectedAutomount bool
+		expectedExplicit  bool
+	}{
+		{"pod= serviceAccount=\n", tnf.FAILURE, true, false},
+		{"pod= serviceAccount=false\n", tnf.SUCCESS, false, true},
+		{"pod=true serviceAccount=false\n", tnf.FAILURE, true, true},
+		{"pod=false serviceAccount=true\n", tnf.SUCCESS, false, true},
+		{"pod= serviceAccount=true\n", tnf.FAILURE, true, true},
+	}
+	for _, tc := range testCases {
+		test := automounttoken.NewAutomountToken(testTimeoutDuration, testPodName, testPodNamespace, testServiceAccount)
+		assert.Regexp(t, automounttoken.SettingsOutputRegex, tc.output)
+		assert.Nil(t, test.ReelMatch(automounttoken.SettingsOutputRegex, "", tc.output))
+		assert.Equal(t, tc.expectedResult, test.Result())
+		assert.Equal(t, tc.expectedAutomount, test.IsAutomounted())
+		assert.Equal(t, tc.expectedExplicit, test.IsExplicit())
+	}
+}
@@ -43,7 +43,7 @@ func NewBootConfigEntries(timeout time.Duration) *BootConfigEntries {
 		timeout: timeout,
 		result:  tnf.ERROR,
 		args: []string{
-			"ls /host/boot/loader/entries/",
+			"chroot /host ls /boot/loader/entries/",
 		},
 	}
 }
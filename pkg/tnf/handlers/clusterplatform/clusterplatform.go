@@ -0,0 +1,112 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package clusterplatform
+
+import (
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the platform and the network type of the cluster, see Command.
+	OutputRegex = `(?s)clusterplatform:.*?\nend:`
+
+	// PlatformBareMetal and PlatformNone are the platforms of the clusters installed on bare metal, with and without
+	// the integration of OpenShift with the hosts.
+	PlatformBareMetal = "BareMetal"
+	PlatformNone      = "None"
+
+	infrastructurePrefix = "Infrastructure:"
+	networkPrefix        = "Network:"
+
+	// Each resource prints a single line, the Infrastructure has no network type and the Network no platform.
+	platformTemplate = `'jsonpath=clusterplatform:{"\n"}{range .items[*]}{.kind}:{.status.platform}{.status.networkType}` +
+		`{"\n"}{end}end:{"\n"}'`
+)
+
+// Platform is the infrastructure platform and the network type of a cluster.
+type Platform struct {
+	// Type is the infrastructure platform, e.g. AWS, BareMetal or None.
+	Type string `json:"type"`
+	// NetworkType is the network type, e.g. OpenShiftSDN or OVNKubernetes.
+	NetworkType string `json:"networkType"`
+}
+
+// IsBareMetal returns true when the cluster is installed on bare metal, rather than on a cloud or virtualization
+// platform.
+func (p *Platform) IsBareMetal() bool {
+	return p.Type == PlatformBareMetal || p.Type == PlatformNone
+}
+
+// ClusterPlatform provides a test reading the platform of the cluster.
+type ClusterPlatform struct {
+	tnf.OcCommand
+	platform Platform
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (c *ClusterPlatform) GetIdentifier() identifier.Identifier {
+	return identifier.ClusterPlatformIdentifier
+}
+
+// ReelMatch parses the platform and sets the test result to SUCCESS on match.  Returns no step; the test is complete.
+func (c *ClusterPlatform) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	c.platform = parse(match)
+	c.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetPlatform returns the platform of the cluster.
+func (c *ClusterPlatform) GetPlatform() Platform {
+	return c.platform
+}
+
+// Command returns the command line printing the platform and the network type of the cluster, from the cluster
+// Infrastructure and Network configuration resources.
+func Command() []string {
+	return []string{dependencies.OcBinaryName, "get", "infrastructure.config.openshift.io/cluster",
+		"network.config.openshift.io/cluster", "-o", platformTemplate}
+}
+
+// NewClusterPlatform creates a new `ClusterPlatform` test which reads the platform of the cluster.  See Command.
+func NewClusterPlatform(timeout time.Duration) *ClusterPlatform {
+	return &ClusterPlatform{
+		OcCommand: tnf.NewOcCommand(timeout, Command(), OutputRegex),
+	}
+}
+
+// parse reads the output of Command.
+func parse(output string) Platform {
+	var platform Platform
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, infrastructurePrefix):
+			platform.Type = strings.TrimPrefix(line, infrastructurePrefix)
+		case strings.HasPrefix(line, networkPrefix):
+			platform.NetworkType = strings.TrimPrefix(line, networkPrefix)
+		}
+	}
+	return platform
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package clusterplatform_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/clusterplatform"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+
+	platformOutput = "clusterplatform:\r\n" +
+		"Infrastructure:BareMetal\r\n" +
+		"Network:OVNKubernetes\r\n" +
+		"end:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc get infrastructure.config.openshift.io/cluster network.config.openshift.io/cluster -o "+
+		`'jsonpath=clusterplatform:{"\n"}{range .items[*]}{.kind}:{.status.platform}{.status.networkType}{"\n"}{end}`+
+		`end:{"\n"}'`,
+		strings.Join(clusterplatform.Command(), " "))
+}
+
+func TestClusterPlatform_GetIdentifier(t *testing.T) {
+	test := clusterplatform.NewClusterPlatform(testTimeoutDuration)
+	assert.Equal(t, identifier.ClusterPlatformIdentifier, test.GetIdentifier())
+}
+
+func TestClusterPlatform_ReelMatch(t *testing.T) {
+	test := clusterplatform.NewClusterPlatform(testTimeoutDuration)
+	match := regexp.MustCompile(clusterplatform.OutputRegex).FindString(platformOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(clusterplatform.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	platform := test.GetPlatform()
+	assert.Equal(t, clusterplatform.Platform{Type: "BareMetal", NetworkType: "OVNKubernetes"}, platform)
+	assert.True(t, platform.IsBareMetal())
+}
+
+func TestPlatform_IsBareMetal(t *testing.T) {
+	for platformType, bareMetal := range map[string]bool{"BareMetal": true, "None": true, "AWS": false, "VSphere": false} {
+		platform := clusterplatform.Platform{Type: platformType}
+		assert.Equal(t, bareMetal, platform.IsBareMetal(), platformType)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package conntrack
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the connections printed until the end of the observation window, see Command.
+	OutputRegex = `(?ms)^.*^conntrack-end\r?$`
+	// ErrorOutputRegex matches a missing conntrack binary, or a failure to read the connection tracking table.
+	ErrorOutputRegex = `(?m)^.*conntrack(?:-tools\)|): (?:(?:command )?not found|Operation not permitted).*$`
+
+	endMarker = "conntrack-end"
+
+	sourcePrefix      = "src="
+	destinationPrefix = "dst="
+	portPrefix        = "dport="
+)
+
+// protocols are the layer 4 protocols of the tracked connections.
+var protocols = map[string]bool{"tcp": true, "udp": true, "sctp": true, "icmp": true, "icmpv6": true}
+
+// Flow is a connection tracked on the node, in its original direction.
+type Flow struct {
+	Protocol    string
+	Source      net.IP
+	Destination net.IP
+	// Port is the destination port, 0 for the protocols without ports, e.g. icmp.
+	Port int
+}
+
+// Conntrack provides a test inventorying the connections tracked on a node.
+type Conntrack struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	flows   []Flow
+}
+
+// Args returns the command line args for the test.
+func (c *Conntrack) Args() []string {
+	return c.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (c *Conntrack) GetIdentifier() identifier.Identifier {
+	return identifier.ConntrackIdentifier
+}
+
+// Timeout returns the timeout for the test.
+func (c *Conntrack) Timeout() time.Duration {
+	return c.timeout
+}
+
+// Result returns the test result.
+func (c *Conntrack) Result() int {
+	return c.result
+}
+
+// ReelFirst returns a step which expects the connections within the test timeout.
+func (c *Conntrack) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{ErrorOutputRegex, OutputRegex},
+		Timeout: c.timeout,
+	}
+}
+
+// ReelMatch parses the connections and sets the test result to SUCCESS on match.  The result is left to ERROR when
+// conntrack fails.
+// Returns no step; the test is complete.
+func (c *Conntrack) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	c.flows = parse(match)
+	c.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (c *Conntrack) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no action is necessary on EOF.
+func (c *Conntrack) ReelEOF() {
+}
+
+// GetFlows returns the connections tracked during the observation window, including the ones tracked before.
+func (c *Conntrack) GetFlows() []Flow {
+	return c.flows
+}
+
+// Command returns the command line listing the connections tracked on the node, then following the new ones for
+// window, run from the debug pod of the node.
+func Command(window time.Duration) []string {
+	// `timeout 0` would disable the bound altogether.
+	seconds := int(window.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	return []string{"chroot", "/host", "sh", "-c", fmt.Sprintf("'%s -L; %s %d %s -E -e NEW; echo %s'",
+		dependencies.ConntrackBinaryName, dependencies.TimeoutBinaryName, seconds, dependencies.ConntrackBinaryName,
+		endMarker)}
+}
+
+// NewConntrack creates a new `Conntrack` test which inventories the connections tracked on the node during window.
+// The test timeout is added to the window.  See Command.
+func NewConntrack(timeout, window time.Duration) *Conntrack {
+	return &Conntrack{
+		result:  tnf.ERROR,
+		timeout: window + timeout,
+		args:    Command(window),
+	}
+}
+
+// parse reads the connections of the output of Command, e.g.
+// "tcp 6 431999 ESTABLISHED src=10.128.2.5 dst=203.0.113.10 sport=40000 dport=443 src=203.0.113.10 ..." or
+// "[NEW] udp 17 30 src=10.128.2.5 dst=198.51.100.53 sport=53000 dport=53 [UNREPLIED] src=198.51.100.53 ...".
+// Only the first, original, direction of each connection is kept.
+func parse(output string) []Flow {
+	var flows []Flow
+	for _, line := range strings.Split(output, "\n") {
+		var flow Flow
+		for _, field := range strings.Fields(line) {
+			switch {
+			case flow.Protocol == "":
+				if protocols[field] {
+					flow.Protocol = field
+				}
+			case strings.HasPrefix(field, sourcePrefix) && flow.Source == nil:
+				flow.Source = net.ParseIP(strings.TrimPrefix(field, sourcePrefix))
+			case strings.HasPrefix(field, destinationPrefix) && flow.Destination == nil:
+				flow.Destination = net.ParseIP(strings.TrimPrefix(field, destinationPrefix))
+			case strings.HasPrefix(field, portPrefix) && flow.Port == 0:
+				// Ignore errors in converting the port, a flow without a port is kept.
+				flow.Port, _ = strconv.Atoi(strings.TrimPrefix(field, portPrefix))
+			}
+		}
+		if flow.Protocol != "" && flow.Source != nil && flow.Destination != nil {
+			flows = append(flows, flow)
+		}
+	}
+	return flows
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package conntrack_test
+
+import (
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/conntrack"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testWindow          = time.Second * 30
+
+	conntrackOutput = `tcp      6 431999 ESTABLISHED src=10.128.2.5 dst=203.0.113.10 sport=40000 dport=443 src=203.0.113.10 dst=10.0.0.4 sport=443 dport=40000 [ASSURED] mark=0 use=1
+icmp     1 29 src=10.128.2.5 dst=198.51.100.1 type=8 code=0 id=12 src=198.51.100.1 dst=10.0.0.4 type=0 code=0 id=12 mark=0 use=1
+conntrack v1.4.4 (conntrack-tools): 2 flow entries have been shown.
+    [NEW] udp      17 30 src=10.128.2.5 dst=198.51.100.53 sport=53000 dport=53 [UNREPLIED] src=198.51.100.53 dst=10.0.0.4 sport=53 dport=53000
+    [NEW] tcp      6 120 SYN_SENT src=fd01::5 dst=2001:db8::1 sport=41000 dport=8443 [UNREPLIED] src=2001:db8::1 dst=fd01::5 sport=8443 dport=41000
+conntrack-end
+`
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "chroot /host sh -c 'conntrack -L; timeout 30 conntrack -E -e NEW; echo conntrack-end'",
+		strings.Join(conntrack.Command(testWindow), " "))
+	assert.Equal(t, "chroot /host sh -c 'conntrack -L; timeout 1 conntrack -E -e NEW; echo conntrack-end'",
+		strings.Join(conntrack.Command(time.Millisecond), " "))
+}
+
+func TestConntrack_GetIdentifier(t *testing.T) {
+	assert.Equal(t, identifier.ConntrackIdentifier, conntrack.NewConntrack(testTimeoutDuration, testWindow).GetIdentifier())
+}
+
+func TestConntrack_ReelFirst(t *testing.T) {
+	step := conntrack.NewConntrack(testTimeoutDuration, testWindow).ReelFirst()
+	assert.Equal(t, "", step.Execute)
+	assert.Equal(t, []string{conntrack.ErrorOutputRegex, conntrack.OutputRegex}, step.Expect)
+	assert.Equal(t, testWindow+testTimeoutDuration, step.Timeout)
+}
+
+func TestConntrack_ReelMatch(t *testing.T) {
+	test := conntrack.NewConntrack(testTimeoutDuration, testWindow)
+	assert.Equal(t, tnf.ERROR, test.Result())
+	match := regexp.MustCompile(conntrack.OutputRegex).FindString(conntrackOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(conntrack.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, []conntrack.Flow{
+		{Protocol: "tcp", Source: net.ParseIP("10.128.2.5"), Destination: net.ParseIP("203.0.113.10"), Port: 443},
+		{Protocol: "icmp", Source: net.ParseIP("10.128.2.5"), Destination: net.ParseIP("198.51.100.1")},
+		{Protocol: "udp", Source: net.ParseIP("10.128.2.5"), Destination: net.ParseIP("198.51.100.53"), Port: 53},
+		{Protocol: "tcp", Source: net.ParseIP("fd01::5"), Destination: net.ParseIP("2001:db8::1"), Port: 8443},
+	}, test.GetFlows())
+}
+
+func TestConntrack_ReelMatchError(t *testing.T) {
+	for _, output := range []string{
+		"sh: conntrack: command not found",
+		"conntrack v1.4.4 (conntrack-tools): Operation not permitted",
+	} {
+		assert.Regexp(t, conntrack.ErrorOutputRegex, output)
+	}
+	test := conntrack.NewConntrack(testTimeoutDuration, testWindow)
+	assert.Nil(t, test.ReelMatch(conntrack.ErrorOutputRegex, "", "sh: conntrack: command not found"))
+	assert.Equal(t, tnf.ERROR, test.Result())
+	assert.Empty(t, test.GetFlows())
+}
+
+func TestConntrack_ReelTimeout(t *testing.T) {
+	test := conntrack.NewConntrack(testTimeoutDuration, testWindow)
+	assert.Nil(t, test.ReelTimeout())
+	assert.Equal(t, tnf.ERROR, test.Result())
+}
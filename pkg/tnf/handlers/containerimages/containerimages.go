@@ -0,0 +1,190 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package containerimages
+
+import (
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the images of the containers of the pod, see Command.
+	OutputRegex = `(?s)images:.*?\nend:`
+
+	// PullAlways, PullIfNotPresent and PullNever are the imagePullPolicy values.
+	PullAlways       = "Always"
+	PullIfNotPresent = "IfNotPresent"
+	PullNever        = "Never"
+
+	// LatestTag is the tag of an image reference without tag nor digest.
+	LatestTag = "latest"
+
+	// digestAlgorithm prefixes the image IDs which are a bare digest.
+	digestAlgorithm = "sha256:"
+
+	containerPrefix = "container:"
+	statusPrefix    = "status:"
+	// nameField, imageField and pullPolicyField are the indexes of the fields of a container line, see imagesTemplate.
+	nameField       = 0
+	imageField      = 1
+	pullPolicyField = 2
+
+	// imageIDField is the index of the imageID field of a status line, see imagesTemplate.
+	imageIDField = 1
+
+	// imagesTemplate prints the name, the image and the imagePullPolicy of each container, then the name and the
+	// imageID of each container status.
+	imagesTemplate = `'jsonpath=images:{"\n"}{range .spec.containers[*]}` +
+		`container:{.name} {.image} {.imagePullPolicy}{"\n"}{end}` +
+		`{range .status.containerStatuses[*]}status:{.name} {.imageID}{"\n"}{end}end:{"\n"}'`
+)
+
+// ContainerImage is the image reference and the imagePullPolicy of a container.
+type ContainerImage struct {
+	Name       string `json:"name"`
+	Image      string `json:"image"`
+	PullPolicy string `json:"pullPolicy"`
+	// ImageID is the reference by digest of the image the container runs, empty until it started.
+	ImageID string `json:"imageID"`
+}
+
+// ContainerImages provides a test reading the images of the containers of a pod.
+type ContainerImages struct {
+	tnf.OcCommand
+	containers []ContainerImage
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (c *ContainerImages) GetIdentifier() identifier.Identifier {
+	return identifier.ContainerImagesIdentifier
+}
+
+// ReelMatch parses the images and sets the test result to SUCCESS on match.  Returns no step; the test is complete.
+func (c *ContainerImages) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	c.containers = parse(match)
+	c.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetContainers returns the images of the containers of the pod.
+func (c *ContainerImages) GetContainers() []ContainerImage {
+	return c.containers
+}
+
+// Command returns the command line printing the images of the containers of the pod.
+func Command(podName, podNamespace string) []string {
+	return []string{dependencies.OcBinaryName, "-n", podNamespace, "get", "pod", podName, "-o", imagesTemplate}
+}
+
+// NewContainerImages creates a new `ContainerImages` test which reads the images of the containers of the pod.  See
+// Command.
+func NewContainerImages(timeout time.Duration, podName, podNamespace string) *ContainerImages {
+	return &ContainerImages{
+		OcCommand: tnf.NewOcCommand(timeout, Command(podName, podNamespace), OutputRegex),
+	}
+}
+
+// HasDigest returns true when the image reference is pinned by digest, e.g. "quay.io/tnf/app@sha256:...".
+func HasDigest(image string) bool {
+	return strings.Contains(image, "@")
+}
+
+// GetTag returns the tag of the image reference, LatestTag when it has neither a tag nor a digest, empty when it has a
+// digest only.
+func GetTag(image string) string {
+	name := image
+	digest := false
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+		digest = true
+	}
+	// the registry may have a port, e.g. "registry:5000/app".
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i:], "/") {
+		return name[i+1:]
+	}
+	if digest {
+		return ""
+	}
+	return LatestTag
+}
+
+// GetDigest returns the digest of the image reference or image ID, e.g. "sha256:...", empty when it has none.
+func GetDigest(image string) string {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	if strings.HasPrefix(image, digestAlgorithm) {
+		return image
+	}
+	return ""
+}
+
+// IsLatest returns true when the image reference is not pinned by digest, and uses the latest tag or no tag.
+func IsLatest(image string) bool {
+	return !HasDigest(image) && GetTag(image) == LatestTag
+}
+
+// MatchesPullPolicy returns true when pullPolicy suits the image reference: an image pinned by digest never changes,
+// so it does not need to be pulled Always, while an image with the latest tag does, otherwise the nodes may run
+// different versions of it.
+func MatchesPullPolicy(image, pullPolicy string) bool {
+	switch {
+	case HasDigest(image):
+		return pullPolicy != PullAlways
+	case IsLatest(image):
+		return pullPolicy == PullAlways
+	default:
+		return true
+	}
+}
+
+// parse reads the output of Command.
+func parse(output string) []ContainerImage {
+	var containers []ContainerImage
+	imageIDs := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, containerPrefix):
+			fields := strings.Fields(strings.TrimPrefix(line, containerPrefix))
+			if len(fields) <= imageField {
+				continue
+			}
+			container := ContainerImage{Name: fields[nameField], Image: fields[imageField]}
+			if len(fields) > pullPolicyField {
+				container.PullPolicy = fields[pullPolicyField]
+			}
+			containers = append(containers, container)
+		case strings.HasPrefix(line, statusPrefix):
+			fields := strings.Fields(strings.TrimPrefix(line, statusPrefix))
+			if len(fields) > imageIDField {
+				imageIDs[fields[nameField]] = fields[imageIDField]
+			}
+		}
+	}
+	for i := range containers {
+		containers[i].ImageID = imageIDs[containers[i].Name]
+	}
+	return containers
+}
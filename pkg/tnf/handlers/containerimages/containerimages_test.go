@@ -0,0 +1,98 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package containerimages_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/containerimages"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testPodName         = "test-0"
+	testPodNamespace    = "tnf"
+
+	imagesOutput = "images:\r\n" +
+		"container:test quay.io/testnetworkfunction/cnf-test-partner:latest Always\r\n" +
+		"container:sidecar registry:5000/sidecar@sha256:0123456789abcdef IfNotPresent\r\n" +
+		"status:test quay.io/testnetworkfunction/cnf-test-partner@sha256:fedcba9876543210\r\n" +
+		"status:sidecar registry:5000/sidecar@sha256:0123456789abcdef\r\n" +
+		"end:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get pod test-0 -o "+
+		`'jsonpath=images:{"\n"}{range .spec.containers[*]}container:{.name} {.image} {.imagePullPolicy}{"\n"}{end}`+
+		`{range .status.containerStatuses[*]}status:{.name} {.imageID}{"\n"}{end}end:{"\n"}'`,
+		strings.Join(containerimages.Command(testPodName, testPodNamespace), " "))
+}
+
+func TestContainerImages_GetIdentifier(t *testing.T) {
+	test := containerimages.NewContainerImages(testTimeoutDuration, testPodName, testPodNamespace)
+	assert.Equal(t, identifier.ContainerImagesIdentifier, test.GetIdentifier())
+}
+
+func TestContainerImages_ReelMatch(t *testing.T) {
+	test := containerimages.NewContainerImages(testTimeoutDuration, testPodName, testPodNamespace)
+	match := regexp.MustCompile(containerimages.OutputRegex).FindString(imagesOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(containerimages.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, []containerimages.ContainerImage{
+		{Name: "test", Image: "quay.io/testnetworkfunction/cnf-test-partner:latest", PullPolicy: containerimages.PullAlways,
+			ImageID: "quay.io/testnetworkfunction/cnf-test-partner@sha256:fedcba9876543210"},
+		{Name: "sidecar", Image: "registry:5000/sidecar@sha256:0123456789abcdef", PullPolicy: containerimages.PullIfNotPresent,
+			ImageID: "registry:5000/sidecar@sha256:0123456789abcdef"},
+	}, test.GetContainers())
+}
+
+func TestGetTag(t *testing.T) {
+	assert.Equal(t, "1.2", containerimages.GetTag("quay.io/tnf/app:1.2"))
+	assert.Equal(t, containerimages.LatestTag, containerimages.GetTag("quay.io/tnf/app"))
+	assert.Equal(t, containerimages.LatestTag, containerimages.GetTag("registry:5000/app"))
+	assert.Equal(t, "1.2", containerimages.GetTag("registry:5000/app:1.2@sha256:0123456789abcdef"))
+	assert.Equal(t, "", containerimages.GetTag("registry:5000/app@sha256:0123456789abcdef"))
+}
+
+func TestGetDigest(t *testing.T) {
+	assert.Equal(t, "sha256:0123456789abcdef", containerimages.GetDigest("quay.io/tnf/app@sha256:0123456789abcdef"))
+	assert.Equal(t, "sha256:0123456789abcdef", containerimages.GetDigest("sha256:0123456789abcdef"))
+	assert.Equal(t, "", containerimages.GetDigest("quay.io/tnf/app:1.2"))
+}
+
+func TestIsLatest(t *testing.T) {
+	assert.True(t, containerimages.IsLatest("quay.io/tnf/app"))
+	assert.True(t, containerimages.IsLatest("quay.io/tnf/app:latest"))
+	assert.False(t, containerimages.IsLatest("quay.io/tnf/app:1.2"))
+	// pinned by digest.
+	assert.False(t, containerimages.IsLatest("quay.io/tnf/app:latest@sha256:0123456789abcdef"))
+}
+
+func TestMatchesPullPolicy(t *testing.T) {
+	assert.True(t, containerimages.MatchesPullPolicy("quay.io/tnf/app:latest", containerimages.PullAlways))
+	assert.False(t, containerimages.MatchesPullPolicy("quay.io/tnf/app:latest", containerimages.PullIfNotPresent))
+	assert.True(t, containerimages.MatchesPullPolicy("quay.io/tnf/app@sha256:0123456789abcdef", containerimages.PullIfNotPresent))
+	assert.False(t, containerimages.MatchesPullPolicy("quay.io/tnf/app@sha256:0123456789abcdef", containerimages.PullAlways))
+	assert.True(t, containerimages.MatchesPullPolicy("quay.io/tnf/app:1.2", containerimages.PullAlways))
+	assert.True(t, containerimages.MatchesPullPolicy("quay.io/tnf/app:1.2", containerimages.PullNever))
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package customresourcestatus
+
+import (
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the custom resources, see Command.
+	OutputRegex = `(?s)crs:.*?\nend:`
+
+	crPrefix = "cr:"
+	// fieldSeparator separates the fields of a line, see statusTemplate.
+	fieldSeparator = "|"
+	// emptyStatus is the status of a custom resource whose status is set without any field.
+	emptyStatus = "{}"
+
+	// namespaceField, nameField and statusField are the indexes of the fields of a custom resource line, see
+	// statusTemplate.  The status is the last field, it may contain the separator.
+	namespaceField = 0
+	nameField      = 1
+	statusField    = 2
+	crLen          = 3
+
+	// statusTemplate prints the namespace, the name and the status of each custom resource.
+	statusTemplate = `'jsonpath=crs:{"\n"}{range .items[*]}cr:{.metadata.namespace}|{.metadata.name}|{.status}{"\n"}{end}` +
+		`end:{"\n"}'`
+)
+
+// CustomResource is a custom resource, and whether its status is populated.
+type CustomResource struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	HasStatus bool   `json:"hasStatus"`
+}
+
+// CustomResourceStatus provides a test reading the status of the custom resources of a CRD.
+type CustomResourceStatus struct {
+	tnf.OcCommand
+	customResources []CustomResource
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (c *CustomResourceStatus) GetIdentifier() identifier.Identifier {
+	return identifier.CustomResourceStatusIdentifier
+}
+
+// ReelMatch parses the custom resources and sets the test result to SUCCESS on match.  Returns no step; the test is
+// complete.
+func (c *CustomResourceStatus) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	c.customResources = parse(match)
+	c.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetCustomResources returns the custom resources.
+func (c *CustomResourceStatus) GetCustomResources() []CustomResource {
+	return c.customResources
+}
+
+// Command returns the command line printing the status of the custom resources of the resource type, e.g.
+// "foos.example.com", in the namespace, or cluster-wide when the namespace is empty.
+func Command(resource, namespace string) []string {
+	args := []string{dependencies.OcBinaryName}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+	return append(args, "get", resource, "-o", statusTemplate)
+}
+
+// NewCustomResourceStatus creates a new `CustomResourceStatus` test which reads the status of the custom resources.
+// See Command.
+func NewCustomResourceStatus(timeout time.Duration, resource, namespace string) *CustomResourceStatus {
+	return &CustomResourceStatus{
+		OcCommand: tnf.NewOcCommand(timeout, Command(resource, namespace), OutputRegex),
+	}
+}
+
+// parse reads the output of Command.
+func parse(output string) []CustomResource {
+	var customResources []CustomResource
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, crPrefix) {
+			continue
+		}
+		fields := strings.SplitN(strings.TrimPrefix(line, crPrefix), fieldSeparator, crLen)
+		if len(fields) < crLen {
+			continue
+		}
+		status := strings.TrimSpace(fields[statusField])
+		customResources = append(customResources, CustomResource{
+			Namespace: fields[namespaceField],
+			Name:      fields[nameField],
+			HasStatus: status != "" && status != emptyStatus,
+		})
+	}
+	return customResources
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package customresourcestatus_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/customresourcestatus"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testResource        = "crdexamples.test-network-function.com"
+	testNamespace       = "tnf"
+
+	statusOutput = "crs:\r\n" +
+		`cr:tnf|ready|{"conditions":[{"status":"True","type":"Ready"}],"message":"a|b"}` + "\r\n" +
+		"cr:tnf|empty|{}\r\n" +
+		"cr:tnf|new|\r\n" +
+		"end:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get crdexamples.test-network-function.com -o "+
+		`'jsonpath=crs:{"\n"}{range .items[*]}cr:{.metadata.namespace}|{.metadata.name}|{.status}{"\n"}{end}end:{"\n"}'`,
+		strings.Join(customresourcestatus.Command(testResource, testNamespace), " "))
+	assert.Equal(t, "oc get crdexamples.test-network-function.com -o "+
+		`'jsonpath=crs:{"\n"}{range .items[*]}cr:{.metadata.namespace}|{.metadata.name}|{.status}{"\n"}{end}end:{"\n"}'`,
+		strings.Join(customresourcestatus.Command(testResource, ""), " "))
+}
+
+func TestCustomResourceStatus_GetIdentifier(t *testing.T) {
+	test := customresourcestatus.NewCustomResourceStatus(testTimeoutDuration, testResource, testNamespace)
+	assert.Equal(t, identifier.CustomResourceStatusIdentifier, test.GetIdentifier())
+}
+
+func TestCustomResourceStatus_ReelMatch(t *testing.T) {
+	test := customresourcestatus.NewCustomResourceStatus(testTimeoutDuration, testResource, testNamespace)
+	match := regexp.MustCompile(customresourcestatus.OutputRegex).FindString(statusOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(customresourcestatus.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, []customresourcestatus.CustomResource{
+		{Namespace: "tnf", Name: "ready", HasStatus: true},
+		{Namespace: "tnf", Name: "empty"},
+		{Namespace: "tnf", Name: "new"},
+	}, test.GetCustomResources())
+}
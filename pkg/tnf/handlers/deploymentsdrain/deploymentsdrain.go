@@ -22,6 +22,7 @@ import (
 
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 )
 
@@ -38,19 +39,19 @@ type DeploymentsDrain struct {
 	node    string
 }
 
-// NewDeploymentsDrain creates a new DeploymentsDrain tnf.Test.
+// NewDeploymentsDrain creates a new DeploymentsDrain tnf.Test.  The flags are adapted to the version of the oc client.
 func NewDeploymentsDrain(timeout time.Duration, nodeName string) *DeploymentsDrain {
 	drainTimeout := timeout * drainTimeoutPercentage / 100
 	drainTimeoutString := drainTimeout.String()
+	args := []string{"oc", "adm", "drain", nodeName, "--pod-selector=pod-template-hash"}
+	args = append(args, occompat.DrainDisableEviction.Current()...)
+	args = append(args, occompat.DrainDeleteEmptyDirData.Current()...)
+	args = append(args, "--ignore-daemonsets=true", "--timeout="+drainTimeoutString, "&&", "echo", "SUCCESS")
 	return &DeploymentsDrain{
 		timeout: timeout,
 		result:  tnf.ERROR,
-		args: []string{
-			"oc", "adm", "drain", nodeName, "--pod-selector=pod-template-hash", "--disable-eviction=true",
-			"--delete-emptydir-data=true", "--ignore-daemonsets=true", "--timeout=" + drainTimeoutString,
-			"&&", "echo", "SUCCESS",
-		},
-		node: nodeName,
+		args:    args,
+		node:    nodeName,
 	}
 }
 
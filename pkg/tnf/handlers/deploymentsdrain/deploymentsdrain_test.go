@@ -18,12 +18,14 @@ package deploymentsdrain_test
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	dd "github.com/test-network-function/test-network-function/pkg/tnf/handlers/deploymentsdrain"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
 )
 
 func Test_NewDeploymentsDrain(t *testing.T) {
@@ -33,6 +35,16 @@ func Test_NewDeploymentsDrain(t *testing.T) {
 	assert.Equal(t, newDd.Result(), tnf.ERROR)
 }
 
+func Test_NewDeploymentsDrainArgs(t *testing.T) {
+	defer occompat.SetVersions(occompat.Versions{})
+	args := strings.Join(dd.NewDeploymentsDrain(testTimeoutDuration, testNode).Args(), " ")
+	assert.Contains(t, args, "--disable-eviction=true --delete-emptydir-data=true")
+	// oc 4.4 predates both flags.
+	occompat.SetVersions(occompat.Versions{Client: occompat.Version{Major: 1, Minor: 17}})
+	args = strings.Join(dd.NewDeploymentsDrain(testTimeoutDuration, testNode).Args(), " ")
+	assert.Contains(t, args, "--pod-selector=pod-template-hash --delete-local-data=true --ignore-daemonsets=true")
+}
+
 func Test_ReelFirstPositive(t *testing.T) {
 	newDd := dd.NewDeploymentsDrain(testTimeoutDuration, testNode)
 	assert.NotNil(t, newDd)
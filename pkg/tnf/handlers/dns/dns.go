@@ -0,0 +1,199 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package dns
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// ResolvConfPath is the path of the resolver configuration of the containers.
+	ResolvConfPath = "/etc/resolv.conf"
+	// ClusterDNSServiceOpenShift is the name of the cluster DNS service of OpenShift, served by CoreDNS.
+	ClusterDNSServiceOpenShift = "dns-default.openshift-dns.svc"
+	// ClusterDNSServiceKubernetes is the name of the cluster DNS service of Kubernetes, served by CoreDNS.
+	ClusterDNSServiceKubernetes = "kube-dns.kube-system.svc"
+
+	// ResolvConfOutputRegex matches the content of the resolver configuration.
+	ResolvConfOutputRegex = `(?s).+`
+	// ResolvedOutputRegex matches the addresses the name resolves to, one per line.
+	ResolvedOutputRegex = `(?m)^([0-9a-fA-F.:]+)\s+\S+.*$`
+	// UnresolvedOutputRegex matches the failure to resolve the name.
+	UnresolvedOutputRegex = `(?m)^dns-lookup-failed\r?$`
+	// ErrorOutputRegex matches a missing resolver configuration, or a missing getent binary.
+	ErrorOutputRegex = `(?m)^.*(?:` + ResolvConfPath + `: No such file or directory|getent: (?:command )?not found).*$`
+
+	// directiveFields is the minimum number of fields of a resolver directive: its name and a value.
+	directiveFields = 2
+)
+
+// ResolvConf is the resolver configuration of a container.
+type ResolvConf struct {
+	Nameservers []string `json:"nameservers,omitempty"`
+	Search      []string `json:"search,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
+// Check returns the problems of the resolver configuration of a container of a pod in namespace: it must have a
+// nameserver, and search the services of namespace first as set by the ClusterFirst DNS policy.
+func (r *ResolvConf) Check(namespace string) []string {
+	var problems []string
+	if len(r.Nameservers) == 0 {
+		problems = append(problems, "no nameserver")
+	}
+	if len(r.Search) == 0 || !strings.HasPrefix(r.Search[0], namespace+".svc.") {
+		problems = append(problems, fmt.Sprintf("the services of %s are not searched first", namespace))
+	}
+	return problems
+}
+
+// ParseResolvConf parses the nameserver, search and options directives of a resolver configuration.  The last search
+// directive takes precedence, as for the resolver.
+func ParseResolvConf(content string) ResolvConf {
+	var conf ResolvConf
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < directiveFields {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			conf.Nameservers = append(conf.Nameservers, fields[1])
+		case "search", "domain":
+			conf.Search = fields[1:]
+		case "options":
+			conf.Options = append(conf.Options, fields[1:]...)
+		}
+	}
+	return conf
+}
+
+// DNS provides the DNS resolution tests: the resolver configuration of a container, or the resolution of a name.
+type DNS struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	expect  []string
+
+	resolvConf ResolvConf
+	addresses  []string
+}
+
+// Args returns the command line args for the test.
+func (d *DNS) Args() []string {
+	return d.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (d *DNS) GetIdentifier() identifier.Identifier {
+	return identifier.DNSIdentifier
+}
+
+// Timeout returns the timeout for the test.
+func (d *DNS) Timeout() time.Duration {
+	return d.timeout
+}
+
+// Result returns the test result.
+func (d *DNS) Result() int {
+	return d.result
+}
+
+// ReelFirst returns a step which expects the output of the test within the test timeout.
+func (d *DNS) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  d.expect,
+		Timeout: d.timeout,
+	}
+}
+
+// ReelMatch parses the output of the test and sets the test result on match.
+// Returns no step; the test is complete.
+func (d *DNS) ReelMatch(pattern, _, match string) *reel.Step {
+	switch pattern {
+	case ErrorOutputRegex:
+		d.result = tnf.ERROR
+	case UnresolvedOutputRegex:
+		d.result = tnf.FAILURE
+	case ResolvedOutputRegex:
+		for _, matched := range regexp.MustCompile(pattern).FindAllStringSubmatch(match, -1) {
+			d.addresses = append(d.addresses, matched[1])
+		}
+		d.result = tnf.SUCCESS
+	case ResolvConfOutputRegex:
+		d.resolvConf = ParseResolvConf(match)
+		d.result = tnf.SUCCESS
+	}
+	return nil
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (d *DNS) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no action is necessary on EOF.
+func (d *DNS) ReelEOF() {
+}
+
+// GetResolvConf returns the resolver configuration of the container.
+func (d *DNS) GetResolvConf() ResolvConf {
+	return d.resolvConf
+}
+
+// GetAddresses returns the addresses the name resolves to.
+func (d *DNS) GetAddresses() []string {
+	return d.addresses
+}
+
+// ResolvConfCommand returns the command line printing the resolver configuration.
+func ResolvConfCommand() []string {
+	return []string{dependencies.CatBinaryName, ResolvConfPath}
+}
+
+// LookupCommand returns the command line resolving name with the resolver of the container, as the CNF applications
+// would, and printing the addresses it resolves to.
+func LookupCommand(name string) []string {
+	return []string{dependencies.GetentBinaryName, "hosts", name, "||", dependencies.EchoBinaryName, "dns-lookup-failed"}
+}
+
+// NewResolvConf creates a new `DNS` test which reads the resolver configuration.  See ResolvConfCommand.
+func NewResolvConf(timeout time.Duration) *DNS {
+	return &DNS{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    ResolvConfCommand(),
+		expect:  []string{ErrorOutputRegex, ResolvConfOutputRegex},
+	}
+}
+
+// NewLookup creates a new `DNS` test which resolves name.  See LookupCommand.
+func NewLookup(timeout time.Duration, name string) *DNS {
+	return &DNS{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    LookupCommand(name),
+		expect:  []string{ErrorOutputRegex, UnresolvedOutputRegex, ResolvedOutputRegex},
+	}
+}
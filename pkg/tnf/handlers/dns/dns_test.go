@@ -0,0 +1,120 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package dns_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/dns"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+
+	resolvConf = `search tnf.svc.cluster.local svc.cluster.local cluster.local
+nameserver 172.30.0.10
+options ndots:5
+`
+)
+
+func TestParseResolvConf(t *testing.T) {
+	assert.Equal(t, dns.ResolvConf{
+		Nameservers: []string{"172.30.0.10"},
+		Search:      []string{"tnf.svc.cluster.local", "svc.cluster.local", "cluster.local"},
+		Options:     []string{"ndots:5"},
+	}, dns.ParseResolvConf(resolvConf))
+	// the last search directive wins, comments and malformed lines are ignored.
+	conf := dns.ParseResolvConf("# generated\nsearch example.com\nnameserver\ndomain tnf.svc.cluster.local\n")
+	assert.Empty(t, conf.Nameservers)
+	assert.Equal(t, []string{"tnf.svc.cluster.local"}, conf.Search)
+}
+
+func TestResolvConf_Check(t *testing.T) {
+	conf := dns.ParseResolvConf(resolvConf)
+	assert.Empty(t, conf.Check("tnf"))
+	assert.Equal(t, []string{"the services of other are not searched first"}, conf.Check("other"))
+	conf = dns.ParseResolvConf("search example.com\n")
+	assert.Equal(t, []string{"no nameserver", "the services of tnf are not searched first"}, conf.Check("tnf"))
+}
+
+func TestCommands(t *testing.T) {
+	assert.Equal(t, "cat /etc/resolv.conf", strings.Join(dns.ResolvConfCommand(), " "))
+	assert.Equal(t, "getent hosts kubernetes.default.svc || echo dns-lookup-failed",
+		strings.Join(dns.LookupCommand("kubernetes.default.svc"), " "))
+}
+
+func TestDNS_GetIdentifier(t *testing.T) {
+	assert.Equal(t, identifier.DNSIdentifier, dns.NewResolvConf(testTimeoutDuration).GetIdentifier())
+}
+
+func TestDNS_ReelFirst(t *testing.T) {
+	step := dns.NewLookup(testTimeoutDuration, "example.com").ReelFirst()
+	assert.Equal(t, "", step.Execute)
+	assert.Equal(t, []string{dns.ErrorOutputRegex, dns.UnresolvedOutputRegex, dns.ResolvedOutputRegex}, step.Expect)
+	assert.Equal(t, testTimeoutDuration, step.Timeout)
+}
+
+func TestDNS_ReelMatchResolvConf(t *testing.T) {
+	test := dns.NewResolvConf(testTimeoutDuration)
+	assert.Equal(t, tnf.ERROR, test.Result())
+	assert.Nil(t, test.ReelMatch(dns.ResolvConfOutputRegex, "", resolvConf))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, []string{"172.30.0.10"}, test.GetResolvConf().Nameservers)
+
+	test = dns.NewResolvConf(testTimeoutDuration)
+	output := "cat: /etc/resolv.conf: No such file or directory"
+	assert.True(t, regexp.MustCompile(dns.ErrorOutputRegex).MatchString(output))
+	test.ReelMatch(dns.ErrorOutputRegex, "", output)
+	assert.Equal(t, tnf.ERROR, test.Result())
+}
+
+func TestDNS_ReelMatchLookup(t *testing.T) {
+	test := dns.NewLookup(testTimeoutDuration, "dns-default.openshift-dns.svc")
+	// the command line itself does not match the addresses, nor the failure.
+	args := strings.Join(test.Args(), " ")
+	assert.False(t, regexp.MustCompile(dns.ResolvedOutputRegex).MatchString(args))
+	assert.False(t, regexp.MustCompile(dns.UnresolvedOutputRegex).MatchString(args))
+	output := "172.30.0.10     dns-default.openshift-dns.svc.cluster.local\nfd02::a  dns-default.openshift-dns.svc.cluster.local\n"
+	match := regexp.MustCompile(dns.ResolvedOutputRegex).FindString(output)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(dns.ResolvedOutputRegex, "", output))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, []string{"172.30.0.10", "fd02::a"}, test.GetAddresses())
+
+	test = dns.NewLookup(testTimeoutDuration, "unknown.example.com")
+	assert.True(t, regexp.MustCompile(dns.UnresolvedOutputRegex).MatchString("dns-lookup-failed\n"))
+	test.ReelMatch(dns.UnresolvedOutputRegex, "", "dns-lookup-failed")
+	assert.Equal(t, tnf.FAILURE, test.Result())
+
+	test = dns.NewLookup(testTimeoutDuration, "example.com")
+	output = "sh: getent: command not found\ndns-lookup-failed"
+	assert.True(t, regexp.MustCompile(dns.ErrorOutputRegex).MatchString(output))
+	test.ReelMatch(dns.ErrorOutputRegex, "", output)
+	assert.Equal(t, tnf.ERROR, test.Result())
+}
+
+func TestDNS_ReelTimeoutAndEOF(t *testing.T) {
+	test := dns.NewResolvConf(testTimeoutDuration)
+	assert.Nil(t, test.ReelTimeout())
+	// just ensures lack of panic
+	test.ReelEOF()
+}
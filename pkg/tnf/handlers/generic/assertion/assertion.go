@@ -22,6 +22,7 @@ import (
 
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic/condition"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic/condition/intcondition"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic/condition/jsoncondition"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic/condition/stringcondition"
 )
 
@@ -107,6 +108,18 @@ func (a *Assertion) unmarshalIntComparisonCondition(conditionJSONMessage *json.R
 	return nil
 }
 
+// unmarshalJSONPathCondition is a custom strategy used to json.Unmarshal an Assertion utilizing
+// jsoncondition.PathCondition.
+func (a *Assertion) unmarshalJSONPathCondition(conditionJSONMessage *json.RawMessage) error {
+	var pathCondition jsoncondition.PathCondition
+	if err := json.Unmarshal(*conditionJSONMessage, &pathCondition); err != nil {
+		return err
+	}
+	var cond condition.Condition = pathCondition
+	a.Condition = &cond
+	return nil
+}
+
 // unmarshalConditionJSON is a custom strategy used to json.Unmarshal an Assertion utilizing
 // any known condition.Condition.
 func (a *Assertion) unmarshalConditionJSON(objMap map[string]*json.RawMessage) error {
@@ -135,6 +148,10 @@ func (a *Assertion) unmarshalConditionJSON(objMap map[string]*json.RawMessage) e
 			if err := a.unmarshalIntComparisonCondition(conditionJSONMessage); err != nil {
 				return err
 			}
+		case jsoncondition.PathConditionKey:
+			if err := a.unmarshalJSONPathCondition(conditionJSONMessage); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("unrecognized condition type: \"%s\"", typ)
 		}
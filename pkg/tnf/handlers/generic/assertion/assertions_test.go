@@ -56,6 +56,16 @@ var assertionsTestCases = map[string]assertionsTestCase{
 		expectedEvaluationError:  false,
 	},
 
+	// Positive Test:  JSONPath assertions made on JSON output, "and"-ed together.
+	"json_path_composed_assertions_positive_test": {
+		match: `{"metadata": {"name": "test"}, "spec": {"containers": [{"securityContext": {"privileged": false}},` +
+			` {"securityContext": {"privileged": false}}]}}`,
+		regex:                    *regexp.MustCompile(`(?s)(\{.*\})`),
+		expectedUnmarshalError:   false,
+		expectedEvaluationResult: true,
+		expectedEvaluationError:  false,
+	},
+
 	// Negative Test:  When bad JSON is given.
 	"not_json": {
 		expectedUnmarshalError:       true,
@@ -0,0 +1,84 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package jsoncondition
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// PathConditionKey is the sentinel key identifying a JSONPath comparison.
+	PathConditionKey = "jsonPath"
+)
+
+// PathCondition is an implementation of the condition.Condition interface which parses a match as JSON, and evaluates
+// whether the values selected by the JSONPath expression Path equal Expected.  This allows structured output, such as
+// "oc get -o json" or "crictl inspect", to be asserted without brittle regular expressions.  Although PathCondition is
+// exported for serialization reasons, it is recommended to instantiate new instances of PathCondition using
+// NewPathCondition.
+type PathCondition struct {
+	// Type stores the sentinel which represents the type of Condition implemented.
+	Type string `json:"type" yaml:"type"`
+	// Path is the JSONPath expression selecting the values to compare, for example ".spec.containers[*].name".
+	Path string `json:"path" yaml:"path"`
+	// Expected is the expected value.  Strings are compared verbatim, and other values by their compact JSON form.
+	Expected string `json:"expected" yaml:"expected"`
+}
+
+// NewPathCondition creates a PathCondition.
+func NewPathCondition(path, expected string) *PathCondition {
+	return &PathCondition{Type: PathConditionKey, Path: path, Expected: expected}
+}
+
+// Evaluate parses the matchIdx group of a match as JSON, and evaluates whether every value selected by Path equals
+// Expected.  A Path selecting no value evaluates to false.
+func (p PathCondition) Evaluate(match string, regex *regexp.Regexp, matchIdx int) (bool, error) {
+	matches := regex.FindStringSubmatch(match)
+	if len(matches) <= matchIdx {
+		return false, fmt.Errorf("matches \"%s\" has no index: %d", matches, matchIdx)
+	}
+	elements, err := parsePath(p.Path)
+	if err != nil {
+		return false, err
+	}
+
+	// Numbers are decoded as json.Number so that they keep their original representation.
+	decoder := json.NewDecoder(strings.NewReader(matches[matchIdx]))
+	decoder.UseNumber()
+	var document interface{}
+	if err = decoder.Decode(&document); err != nil {
+		return false, fmt.Errorf("match \"%s\" is not valid JSON: %s", matches[matchIdx], err)
+	}
+
+	selected := selectPath(document, elements)
+	if len(selected) == 0 {
+		return false, nil
+	}
+	for _, value := range selected {
+		formatted, formatErr := formatValue(value)
+		if formatErr != nil {
+			return false, formatErr
+		}
+		if formatted != p.Expected {
+			return false, nil
+		}
+	}
+	return true, nil
+}
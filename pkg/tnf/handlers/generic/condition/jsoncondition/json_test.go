@@ -0,0 +1,142 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package jsoncondition_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic/condition/jsoncondition"
+)
+
+const podJSON = `pod: {"metadata": {"name": "test", "labels": {"app.kubernetes.io/name": "cnf"}},` +
+	` "spec": {"hostNetwork": false, "containers": [{"name": "test", "ports": [{"containerPort": 8080}]},` +
+	` {"name": "sidecar", "ports": [{"containerPort": 8080}]}]}}`
+
+func TestNewPathCondition(t *testing.T) {
+	c := jsoncondition.NewPathCondition(".metadata.name", "test")
+	assert.Equal(t, jsoncondition.PathConditionKey, c.Type)
+	assert.Equal(t, ".metadata.name", c.Path)
+	assert.Equal(t, "test", c.Expected)
+}
+
+type pathConditionTestCase struct {
+	path           string
+	expected       string
+	match          string
+	matchIdx       int
+	expectedResult bool
+	expectedError  bool
+}
+
+var pathConditionTestCases = map[string]pathConditionTestCase{
+	"Positive Case: member_equals": {
+		path:           "$.metadata.name",
+		expected:       "test",
+		match:          podJSON,
+		matchIdx:       1,
+		expectedResult: true,
+	},
+	"Positive Case: member_does_not_equal": {
+		path:           ".metadata.name",
+		expected:       "other",
+		match:          podJSON,
+		matchIdx:       1,
+		expectedResult: false,
+	},
+	"Positive Case: bracket_member": {
+		path:           ".metadata.labels['app.kubernetes.io/name']",
+		expected:       "cnf",
+		match:          podJSON,
+		matchIdx:       1,
+		expectedResult: true,
+	},
+	"Positive Case: boolean_value": {
+		path:           ".spec.hostNetwork",
+		expected:       "false",
+		match:          podJSON,
+		matchIdx:       1,
+		expectedResult: true,
+	},
+	"Positive Case: negative_index": {
+		path:           ".spec.containers[-1].name",
+		expected:       "sidecar",
+		match:          podJSON,
+		matchIdx:       1,
+		expectedResult: true,
+	},
+	"Positive Case: wildcard_all_equal": {
+		path:           ".spec.containers[*].ports[0].containerPort",
+		expected:       "8080",
+		match:          podJSON,
+		matchIdx:       1,
+		expectedResult: true,
+	},
+	"Positive Case: wildcard_not_all_equal": {
+		path:           ".spec.containers.*.name",
+		expected:       "test",
+		match:          podJSON,
+		matchIdx:       1,
+		expectedResult: false,
+	},
+	"Positive Case: nothing_selected": {
+		path:           ".spec.containers[5].name",
+		expected:       "test",
+		match:          podJSON,
+		matchIdx:       1,
+		expectedResult: false,
+	},
+	"Negative Case: invalid_path": {
+		path:          ".spec..containers",
+		expected:      "test",
+		match:         podJSON,
+		matchIdx:      1,
+		expectedError: true,
+	},
+	"Negative Case: unsupported_selector": {
+		path:          ".spec.containers[?(@.name)]",
+		expected:      "test",
+		match:         podJSON,
+		matchIdx:      1,
+		expectedError: true,
+	},
+	"Negative Case: not_json": {
+		path:          ".metadata.name",
+		expected:      "test",
+		match:         "pod: not json",
+		matchIdx:      1,
+		expectedError: true,
+	},
+	"Negative Case: index_out_of_bounds": {
+		path:          ".metadata.name",
+		expected:      "test",
+		match:         podJSON,
+		matchIdx:      100,
+		expectedError: true,
+	},
+}
+
+func TestPathCondition_Evaluate(t *testing.T) {
+	regex := regexp.MustCompile(`(?s)pod: (.*)`)
+	for testName, testCase := range pathConditionTestCases {
+		c := jsoncondition.NewPathCondition(testCase.path, testCase.expected)
+		actualResult, actualError := c.Evaluate(testCase.match, regex, testCase.matchIdx)
+		assert.Equal(t, testCase.expectedResult, actualResult, testName)
+		assert.Equal(t, testCase.expectedError, actualError != nil, testName)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package jsoncondition
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// pathRoot is the optional leading symbol of a JSONPath expression, which refers to the whole document.
+	pathRoot = "$"
+	// pathWildcard selects every member of an object, or every element of an array.
+	pathWildcard = "*"
+)
+
+// pathElement is a single selector of a parsed JSONPath expression.  A pathElement selects either the member named key
+// of an object, the element at index of an array, or every member/element when wildcard is set.
+type pathElement struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parsePath parses the supported subset of JSONPath:  dot-notation members (".metadata.name"), bracket-notation
+// members ("['app.kubernetes.io/name']"), array indexes ("[0]", "[-1]") and wildcards (".*", "[*]").  The leading "$"
+// is optional.
+func parsePath(path string) ([]pathElement, error) {
+	remaining := strings.TrimPrefix(strings.TrimSpace(path), pathRoot)
+	var elements []pathElement
+	for remaining != "" {
+		switch remaining[0] {
+		case '.':
+			remaining = remaining[1:]
+			end := strings.IndexAny(remaining, ".[")
+			if end < 0 {
+				end = len(remaining)
+			}
+			name := remaining[:end]
+			remaining = remaining[end:]
+			switch name {
+			case "":
+				return nil, fmt.Errorf("JSONPath \"%s\" has an empty member name", path)
+			case pathWildcard:
+				elements = append(elements, pathElement{wildcard: true})
+			default:
+				elements = append(elements, pathElement{key: name})
+			}
+		case '[':
+			end := strings.Index(remaining, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("JSONPath \"%s\" has an unterminated \"[\"", path)
+			}
+			element, err := parseBracketSelector(remaining[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("JSONPath \"%s\": %s", path, err)
+			}
+			elements = append(elements, element)
+			remaining = remaining[end+1:]
+		default:
+			return nil, fmt.Errorf("JSONPath \"%s\" has an unexpected character '%c'", path, remaining[0])
+		}
+	}
+	return elements, nil
+}
+
+// parseBracketSelector parses the selector found between brackets:  a wildcard, a quoted member name or an index.
+func parseBracketSelector(selector string) (pathElement, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == pathWildcard {
+		return pathElement{wildcard: true}, nil
+	}
+	if len(selector) > 1 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0] {
+		return pathElement{key: selector[1 : len(selector)-1]}, nil
+	}
+	index, err := strconv.Atoi(selector)
+	if err != nil {
+		return pathElement{}, fmt.Errorf("unsupported selector \"[%s]\"", selector)
+	}
+	return pathElement{index: index, isIndex: true}, nil
+}
+
+// selectPath returns the values of document selected by elements, in document order.
+func selectPath(document interface{}, elements []pathElement) []interface{} {
+	selected := []interface{}{document}
+	for _, element := range elements {
+		var next []interface{}
+		for _, value := range selected {
+			next = append(next, element.selectFrom(value)...)
+		}
+		selected = next
+	}
+	return selected
+}
+
+// selectFrom returns the values of value selected by the pathElement.  Object members selected by a wildcard are
+// returned sorted by member name, since JSON objects are unordered.
+func (e pathElement) selectFrom(value interface{}) []interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if e.wildcard {
+			keys := make([]string, 0, len(typed))
+			for key := range typed {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			members := make([]interface{}, 0, len(keys))
+			for _, key := range keys {
+				members = append(members, typed[key])
+			}
+			return members
+		}
+		if member, ok := typed[e.key]; ok && !e.isIndex {
+			return []interface{}{member}
+		}
+	case []interface{}:
+		if e.wildcard {
+			return typed
+		}
+		index := e.index
+		if index < 0 {
+			index += len(typed)
+		}
+		if e.isIndex && index >= 0 && index < len(typed) {
+			return []interface{}{typed[index]}
+		}
+	}
+	return nil
+}
+
+// formatValue returns the string used to compare a selected value:  strings are used verbatim, and any other value is
+// rendered as compact JSON (numbers keep their original representation).
+func formatValue(value interface{}) (string, error) {
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	formatted, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(formatted), nil
+}
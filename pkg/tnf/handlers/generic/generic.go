@@ -35,8 +35,14 @@ import (
 const (
 	// TestSchemaFileName is the filename of the generic test JSON schema.
 	TestSchemaFileName = "generic-test.schema.json"
+
+	// submatchIndexWidth is the number of indexes regexp uses to locate each submatch.
+	submatchIndexWidth = 2
 )
 
+// captureReferenceRegex matches the "${name}" references to named capture groups in the execute string of a reel.Step.
+var captureReferenceRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
 // Generic is a construct for defining an arbitrary simple test with prescriptive confines.  Essentially, the definition
 // of the state machine for a Generic reel.Handler is restricted in this facade, since most common use cases do not need
 // to perform too much heavy lifting that would otherwise require a Custom reel.Handler implementation.  Although
@@ -59,6 +65,10 @@ type Generic struct {
 	// Matches contains an in order array of matches.
 	Matches []Match `json:"matches,omitempty" yaml:"matches,omitempty"`
 
+	// Captures stores the values of the named capture groups matched so far, by group name.  A "${name}" reference in
+	// the execute string of a NextStep is substituted with the value captured for name.
+	Captures map[string]string `json:"captures,omitempty" yaml:"captures,omitempty"`
+
 	// ReelFirstStep is the first Step returned by reel.ReelFirst().
 	ReelFirstStep *reel.Step `json:"reelFirstStep,omitempty" yaml:"reelFirstStep,omitempty"`
 
@@ -150,10 +160,11 @@ func (g *Generic) ReelMatch(pattern, before, match string) *reel.Step {
 		g.TestResult = tnf.ERROR
 		return nil
 	}
+	regex := regexp.MustCompile(pattern)
+	g.recordCaptures(regex, match)
 	composedAssertions := resultContext.ComposedAssertions
 	if len(composedAssertions) > 0 {
 		for _, composedAssertion := range composedAssertions {
-			regex := regexp.MustCompile(pattern)
 			success, err := (*composedAssertion.Logic).Evaluate(composedAssertion.Assertions, match, regex)
 			if err != nil {
 				// exit immediately on a test error.
@@ -180,7 +191,43 @@ func (g *Generic) ReelMatch(pattern, before, match string) *reel.Step {
 	}
 
 	g.currentReelMatchResultContexts = resultContext.NextResultContexts
-	return resultContext.NextStep
+	return g.expandCaptures(resultContext.NextStep)
+}
+
+// recordCaptures stores the values of the named capture groups of regex which participated in match.
+func (g *Generic) recordCaptures(regex *regexp.Regexp, match string) {
+	submatchIndexes := regex.FindStringSubmatchIndex(match)
+	if submatchIndexes == nil {
+		return
+	}
+	for groupIdx, name := range regex.SubexpNames() {
+		start := submatchIndexes[groupIdx*submatchIndexWidth]
+		if name == "" || start < 0 {
+			continue
+		}
+		if g.Captures == nil {
+			g.Captures = map[string]string{}
+		}
+		g.Captures[name] = match[start:submatchIndexes[groupIdx*submatchIndexWidth+1]]
+	}
+}
+
+// expandCaptures returns a copy of step with the "${name}" references to captured values substituted in its execute
+// string.  References to names which were not captured are left untouched, so they are still available to the shell.
+// The expect patterns are never substituted, since they are used to look up the matching ResultContext.
+func (g *Generic) expandCaptures(step *reel.Step) *reel.Step {
+	if len(g.Captures) == 0 {
+		return step
+	}
+	expanded := *step
+	expanded.Execute = captureReferenceRegex.ReplaceAllStringFunc(step.Execute, func(reference string) string {
+		name := captureReferenceRegex.FindStringSubmatch(reference)[1]
+		if value, ok := g.Captures[name]; ok {
+			return value
+		}
+		return reference
+	})
+	return &expanded
 }
 
 // ReelTimeout informs of a timeout event, returning the next step to perform.
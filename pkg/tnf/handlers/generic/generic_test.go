@@ -166,6 +166,48 @@ var newGenericFromJSONFileTestCases = map[string]newGenericFromJSONFileTestCase{
 			},
 		},
 	},
+	// Positive Test:  "testdata/chained.json" captures the hostname using a named capture group in the first step, and
+	// substitutes it into the command of the next step.  References to names which were not captured (${HOME}) are left
+	// untouched.
+	"chained": {
+		expectedCreationErr: false,
+		expectedTester:      true,
+		expectedTimeout:     time.Duration(2000000000),
+		expectedHandlers:    true,
+		expectedHandlersLen: 1,
+		// This implementation returns the first command in ReelFirst().
+		expectedArgs:            nil,
+		expectedInitialResult:   tnf.ERROR,
+		expectedResultIsValid:   true,
+		expectedReelTimeoutStep: nil,
+		expectedReelFirstStep: &reel.Step{
+			Execute: "hostname\n",
+			Expect:  []string{"(?m)^(?P<hostname>[\\w.-]+)$"},
+			Timeout: time.Duration(2000000000),
+		},
+		matchTestCases: []matchTestCase{
+			// Positive Test:  The captured hostname is substituted into the next step.
+			{
+				inputPattern: "(?m)^(?P<hostname>[\\w.-]+)$",
+				inputBefore:  "",
+				inputMatch:   "worker-0.example.com",
+				expectedReelMatchNextStep: &reel.Step{
+					Execute: "getent hosts worker-0.example.com || echo \"worker-0.example.com unresolved from ${HOME}\"\n",
+					Expect:  []string{"(?m)^([0-9a-f.:]+)\\s+", "(?m)unresolved"},
+					Timeout: time.Duration(2000000000),
+				},
+				expectedFinalResult: tnf.ERROR,
+			},
+			// Positive Test:  The next step matches the resolved address.
+			{
+				inputPattern:              "(?m)^([0-9a-f.:]+)\\s+",
+				inputBefore:               "",
+				inputMatch:                "10.0.0.12       ",
+				expectedReelMatchNextStep: nil,
+				expectedFinalResult:       tnf.SUCCESS,
+			},
+		},
+	},
 	// Negative Test:  The supplied file doesn't exist, so make sure that an appropriate error is emitted.
 	"file_does_not_exist": {
 		expectedCreationErr:     true,
@@ -0,0 +1,106 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package hostnamespaces
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// HostNetwork is the setting sharing the network namespace of the node.
+	HostNetwork = "hostNetwork"
+	// HostPID is the setting sharing the process namespace of the node.
+	HostPID = "hostPID"
+	// HostIPC is the setting sharing the IPC namespace of the node.
+	HostIPC = "hostIPC"
+
+	// SettingsOutputRegex matches the host namespace settings of the pod, empty when not set.
+	SettingsOutputRegex = `hostNetwork=(true|false)?,hostPID=(true|false)?,hostIPC=(true|false)?`
+
+	// settingsTemplate prints the host namespace settings in the format of SettingsOutputRegex.
+	settingsTemplate = `'jsonpath=hostNetwork={.spec.hostNetwork},hostPID={.spec.hostPID},hostIPC={.spec.hostIPC}'`
+)
+
+// settings are the host namespace settings, in the order of the groups of SettingsOutputRegex.
+var settings = []string{HostNetwork, HostPID, HostIPC}
+
+// HostNamespaces provides a test reading the host namespace settings of a pod.
+type HostNamespaces struct {
+	tnf.OcCommand
+	shared []string
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (h *HostNamespaces) GetIdentifier() identifier.Identifier {
+	return identifier.HostNamespacesIdentifier
+}
+
+// ReelMatch records the host namespaces shared by the pod and sets the test result on match: FAILURE when the pod
+// shares any host namespace, SUCCESS otherwise.
+// Returns no step; the test is complete.
+func (h *HostNamespaces) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != SettingsOutputRegex {
+		return nil
+	}
+	matched := regexp.MustCompile(pattern).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	for i, setting := range settings {
+		if matched[i+1] == "true" {
+			h.shared = append(h.shared, setting)
+		}
+	}
+	h.SetResult(tnf.SUCCESS)
+	if len(h.shared) > 0 {
+		h.SetResult(tnf.FAILURE)
+	}
+	return nil
+}
+
+// GetShared returns the host namespace settings enabled for the pod, among HostNetwork, HostPID and HostIPC.
+func (h *HostNamespaces) GetShared() []string {
+	return h.shared
+}
+
+// Shares returns true when the pod shares the host namespace of setting, i.e. HostNetwork, HostPID or HostIPC.
+func (h *HostNamespaces) Shares(setting string) bool {
+	for _, shared := range h.shared {
+		if shared == setting {
+			return true
+		}
+	}
+	return false
+}
+
+// Command returns the command line printing the host namespace settings of the pod.
+func Command(podName, podNamespace string) []string {
+	return []string{dependencies.OcBinaryName, "-n", podNamespace, "get", "pods", podName, "-o", settingsTemplate}
+}
+
+// NewHostNamespaces creates a new `HostNamespaces` test which reads the host namespace settings of the pod.  See
+// Command.
+func NewHostNamespaces(timeout time.Duration, podName, podNamespace string) *HostNamespaces {
+	return &HostNamespaces{
+		OcCommand: tnf.NewOcCommand(timeout, Command(podName, podNamespace), SettingsOutputRegex),
+	}
+}
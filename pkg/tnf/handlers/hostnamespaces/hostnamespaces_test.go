@@ -0,0 +1,69 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package hostnamespaces_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/hostnamespaces"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testPodName         = "test-0"
+	testPodNamespace    = "tnf"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get pods test-0 -o "+
+		"'jsonpath=hostNetwork={.spec.hostNetwork},hostPID={.spec.hostPID},hostIPC={.spec.hostIPC}'",
+		strings.Join(hostnamespaces.Command(testPodName, testPodNamespace), " "))
+}
+
+func TestHostNamespaces_GetIdentifier(t *testing.T) {
+	test := hostnamespaces.NewHostNamespaces(testTimeoutDuration, testPodName, testPodNamespace)
+	assert.Equal(t, identifier.HostNamespacesIdentifier, test.GetIdentifier())
+}
+
+func TestHostNamespaces_ReelMatch(t *testing.T) {
+	testCases := []struct {
+		output         string
+		expectedResult int
+		expectedShared []string
+	}{
+		{"hostNetwork=,hostPID=,hostIPC=", tnf.SUCCESS, nil},
+		{"hostNetwork=false,hostPID=false,hostIPC=false", tnf.SUCCESS, nil},
+		{"hostNetwork=true,hostPID=,hostIPC=", tnf.FAILURE, []string{hostnamespaces.HostNetwork}},
+		{"hostNetwork=true,hostPID=true,hostIPC=true", tnf.FAILURE,
+			[]string{hostnamespaces.HostNetwork, hostnamespaces.HostPID, hostnamespaces.HostIPC}},
+		{"hostNetwork=,hostPID=false,hostIPC=true", tnf.FAILURE, []string{hostnamespaces.HostIPC}},
+	}
+	for _, tc := range testCases {
+		test := hostnamespaces.NewHostNamespaces(testTimeoutDuration, testPodName, testPodNamespace)
+		assert.Regexp(t, hostnamespaces.SettingsOutputRegex, tc.output)
+		assert.Nil(t, test.ReelMatch(hostnamespaces.SettingsOutputRegex, "", tc.output))
+		assert.Equal(t, tc.expectedResult, test.Result())
+		assert.Equal(t, tc.expectedShared, test.GetShared())
+		for _, setting := range tc.expectedShared {
+			assert.True(t, test.Shares(setting))
+		}
+	}
+}
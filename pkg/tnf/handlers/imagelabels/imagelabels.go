@@ -0,0 +1,153 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package imagelabels
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the reference of the image of the container and its labels as JSON, null without labels.
+	OutputRegex = `image=([^\s$]+) labels=(\{.*\}|null)`
+	// ErrorOutputRegex matches the errors of podman, e.g. for an image which is not in the storage of the node.
+	ErrorOutputRegex = `(?m)^Error: .*$`
+
+	// RevisionLabel is the OCI annotation holding the source control revision the image was built from.
+	RevisionLabel = "org.opencontainers.image.revision"
+	// SourceLabel is the OCI annotation holding the URL of the source code the image was built from.
+	SourceLabel = "org.opencontainers.image.source"
+	// VersionLabel is the OCI annotation holding the version of the packaged software.
+	VersionLabel = "org.opencontainers.image.version"
+)
+
+// ProvenanceLabels are the OCI annotations tracing an image back to its sources.
+var ProvenanceLabels = []string{RevisionLabel, SourceLabel, VersionLabel}
+
+// ImageLabels provides a test reading the labels of the image of a container.
+type ImageLabels struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	image   string
+	labels  map[string]string
+}
+
+// Args returns the command line args for the test.
+func (i *ImageLabels) Args() []string {
+	return i.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (i *ImageLabels) GetIdentifier() identifier.Identifier {
+	return identifier.ImageLabelsIdentifier
+}
+
+// Timeout returns the timeout for the test.
+func (i *ImageLabels) Timeout() time.Duration {
+	return i.timeout
+}
+
+// Result returns the test result.
+func (i *ImageLabels) Result() int {
+	return i.result
+}
+
+// ReelFirst returns a step which expects the image labels within the test timeout.
+func (i *ImageLabels) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{ErrorOutputRegex, OutputRegex},
+		Timeout: i.timeout,
+	}
+}
+
+// ReelMatch records the image and its labels and sets the test result to SUCCESS on match, whatever the labels.
+// Returns no step; the test is complete.
+func (i *ImageLabels) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	matched := regexp.MustCompile(OutputRegex).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(matched[2]), &labels); err != nil {
+		return nil
+	}
+	i.image = matched[1]
+	i.labels = labels
+	i.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (i *ImageLabels) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no action is necessary on EOF.
+func (i *ImageLabels) ReelEOF() {
+}
+
+// GetImage returns the reference of the image of the container, e.g.
+// "quay.io/testnetworkfunction/cnf-test-partner@sha256:...".
+func (i *ImageLabels) GetImage() string {
+	return i.image
+}
+
+// GetLabels returns the labels of the image, nil when it has none.
+func (i *ImageLabels) GetLabels() map[string]string {
+	return i.labels
+}
+
+// GetMissingProvenance returns the ProvenanceLabels the image does not set, or sets to an empty value.
+func (i *ImageLabels) GetMissingProvenance() []string {
+	var missing []string
+	for _, label := range ProvenanceLabels {
+		if i.labels[label] == "" {
+			missing = append(missing, label)
+		}
+	}
+	return missing
+}
+
+// Command returns the command line printing the reference of the image of the container of containerID, found with
+// crictl, and its labels, read with podman from the storage of the node.  It runs in the debug pod of the node.
+func Command(containerID string) []string {
+	image := fmt.Sprintf(`$(chroot /host %s inspect -o go-template --template '{{.status.imageRef}}' %s)`,
+		dependencies.CrictlBinaryName, containerID)
+	return []string{dependencies.EchoBinaryName, fmt.Sprintf(
+		`"image=%s labels=$(chroot /host %s image inspect --format '{{json .Labels}}' %s)"`,
+		image, dependencies.PodmanBinaryName, image)}
+}
+
+// NewImageLabels creates a new `ImageLabels` test which reads the labels of the image of the container of containerID.
+// See Command.
+func NewImageLabels(timeout time.Duration, containerID string) *ImageLabels {
+	return &ImageLabels{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    Command(containerID),
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package imagelabels_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/imagelabels"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testContainerID     = "cf9a1a0c5d5f"
+	testImage           = "quay.io/testnetworkfunction/cnf-test-partner@sha256:0123456789abcdef"
+)
+
+func TestCommand(t *testing.T) {
+	command := strings.Join(imagelabels.Command(testContainerID), " ")
+	image := `$(chroot /host crictl inspect -o go-template --template '{{.status.imageRef}}' cf9a1a0c5d5f)`
+	assert.Equal(t, `echo "image=`+image+` labels=$(chroot /host podman image inspect --format '{{json .Labels}}' `+image+`)"`,
+		command)
+	// the echoed command line does not match.
+	assert.NotRegexp(t, imagelabels.OutputRegex, command)
+}
+
+func TestImageLabels_GetIdentifier(t *testing.T) {
+	assert.Equal(t, identifier.ImageLabelsIdentifier, imagelabels.NewImageLabels(testTimeoutDuration, testContainerID).GetIdentifier())
+}
+
+func TestImageLabels_ReelFirst(t *testing.T) {
+	step := imagelabels.NewImageLabels(testTimeoutDuration, testContainerID).ReelFirst()
+	assert.Equal(t, "", step.Execute)
+	assert.Equal(t, []string{imagelabels.ErrorOutputRegex, imagelabels.OutputRegex}, step.Expect)
+	assert.Equal(t, testTimeoutDuration, step.Timeout)
+}
+
+func TestImageLabels_ReelMatch(t *testing.T) {
+	test := imagelabels.NewImageLabels(testTimeoutDuration, testContainerID)
+	output := "image=" + testImage + ` labels={"org.opencontainers.image.revision":"4f1c2e9",` +
+		`"org.opencontainers.image.source":"https://github.com/test-network-function/cnf-certification-test-partner",` +
+		`"vendor":"Red Hat, Inc."}` + "\r\n"
+	assert.Nil(t, test.ReelMatch(imagelabels.OutputRegex, "", output))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, testImage, test.GetImage())
+	assert.Equal(t, "4f1c2e9", test.GetLabels()[imagelabels.RevisionLabel])
+	assert.Equal(t, "Red Hat, Inc.", test.GetLabels()["vendor"])
+	assert.Equal(t, []string{imagelabels.VersionLabel}, test.GetMissingProvenance())
+
+	// an image without labels.
+	test = imagelabels.NewImageLabels(testTimeoutDuration, testContainerID)
+	assert.Nil(t, test.ReelMatch(imagelabels.OutputRegex, "", "image="+testImage+" labels=null\r\n"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Nil(t, test.GetLabels())
+	assert.Equal(t, imagelabels.ProvenanceLabels, test.GetMissingProvenance())
+}
+
+func TestImageLabels_ReelMatchError(t *testing.T) {
+	output := "Error: quay.io/testnetworkfunction/cnf-test-partner@sha256:0123456789abcdef: image not known"
+	assert.Regexp(t, imagelabels.ErrorOutputRegex, output)
+	test := imagelabels.NewImageLabels(testTimeoutDuration, testContainerID)
+	assert.Nil(t, test.ReelMatch(imagelabels.ErrorOutputRegex, "", output))
+	assert.Equal(t, tnf.ERROR, test.Result())
+	// unparsable labels.
+	assert.Nil(t, test.ReelMatch(imagelabels.OutputRegex, "", "image="+testImage+` labels={"vendor":1}`))
+	assert.Equal(t, tnf.ERROR, test.Result())
+}
+
+func TestImageLabels_ReelTimeout(t *testing.T) {
+	test := imagelabels.NewImageLabels(testTimeoutDuration, testContainerID)
+	assert.Nil(t, test.ReelTimeout())
+	assert.Equal(t, tnf.ERROR, test.Result())
+}
@@ -33,8 +33,10 @@ type IPAddr struct {
 	result  int
 	timeout time.Duration
 	args    []string
-	// The ipv4 address for a given device if the Handler matches.
-	ipv4Address string
+	// The ipv4 addresses of a given device if the Handler matches.
+	ipv4Addresses []string
+	// The global ipv6 addresses of a given device if the Handler matches.
+	ipv6Addresses []string
 }
 
 const (
@@ -42,6 +44,9 @@ const (
 	DeviceDoesNotExistRegex = `(?m)Device \"(\w+)\" does not exist.$`
 	// SuccessfulOutputRegex matches `ip addr` output for a given device, and provides grouping to extract the associated Ipv4 address.
 	SuccessfulOutputRegex = `(?m)^\s+inet ((25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?))`
+	// SuccessfulIPv6OutputRegex matches `ip addr` output for a device with a global Ipv6 address, and provides grouping
+	// to extract it.  Link-local addresses are ignored since they cannot be reached from other nodes.
+	SuccessfulIPv6OutputRegex = `(?m)^\s+inet6 ([0-9a-fA-F:]+)/\d+ scope global`
 )
 
 var (
@@ -72,22 +77,26 @@ func (i *IPAddr) Result() int {
 // ReelFirst returns a step which expects an ip summary for the given device.
 func (i *IPAddr) ReelFirst() *reel.Step {
 	return &reel.Step{
-		Expect:  []string{SuccessfulOutputRegex, DeviceDoesNotExistRegex},
+		Expect:  []string{SuccessfulOutputRegex, SuccessfulIPv6OutputRegex, DeviceDoesNotExistRegex},
 		Timeout: i.timeout,
 	}
 }
 
-// ReelMatch parses the ip addr output and set the test result on match.
+// ReelMatch parses the ip addr output and set the test result on match.  Both the Ipv4 and the global Ipv6 addresses
+// of dual-stack devices are extracted, the Ipv4 address patterns being matched first.
 // Returns no step; the test is complete.
 func (i *IPAddr) ReelMatch(pattern, _, match string) *reel.Step {
 	if pattern == DeviceDoesNotExistRegex {
 		i.result = tnf.ERROR
 		return nil
 	}
-	re := regexp.MustCompile(SuccessfulOutputRegex)
-	matched := re.FindStringSubmatch(match)
-	if matched != nil {
-		i.ipv4Address = matched[1]
+	for _, matched := range regexp.MustCompile(SuccessfulOutputRegex).FindAllStringSubmatch(match, -1) {
+		i.ipv4Addresses = append(i.ipv4Addresses, matched[1])
+	}
+	for _, matched := range regexp.MustCompile(SuccessfulIPv6OutputRegex).FindAllStringSubmatch(match, -1) {
+		i.ipv6Addresses = append(i.ipv6Addresses, matched[1])
+	}
+	if len(i.ipv4Addresses) > 0 || len(i.ipv6Addresses) > 0 {
 		i.result = tnf.SUCCESS
 	}
 	return nil
@@ -102,9 +111,33 @@ func (i *IPAddr) ReelTimeout() *reel.Step {
 func (i *IPAddr) ReelEOF() {
 }
 
-// GetIPv4Address returns the extracted IPv4 address for the given device (interface).
+// GetIPv4Address returns the first extracted IPv4 address for the given device (interface).
 func (i *IPAddr) GetIPv4Address() string {
-	return i.ipv4Address
+	if len(i.ipv4Addresses) == 0 {
+		return ""
+	}
+	return i.ipv4Addresses[0]
+}
+
+// GetIPv6Address returns the first extracted global IPv6 address for the given device (interface).
+func (i *IPAddr) GetIPv6Address() string {
+	if len(i.ipv6Addresses) == 0 {
+		return ""
+	}
+	return i.ipv6Addresses[0]
+}
+
+// GetIPAddress returns the extracted IP address for the given device, the IPv4 one for dual-stack devices.
+func (i *IPAddr) GetIPAddress() string {
+	if address := i.GetIPv4Address(); address != "" {
+		return address
+	}
+	return i.GetIPv6Address()
+}
+
+// GetIPAddresses returns all the extracted IP addresses for the given device, the IPv4 ones first.
+func (i *IPAddr) GetIPAddresses() []string {
+	return append(append([]string{}, i.ipv4Addresses...), i.ipv6Addresses...)
 }
 
 func ipAddrCmd(dev string) []string {
@@ -40,6 +40,8 @@ type TestCase struct {
 	pattern             string
 	expectedResult      int
 	expectedIpv4Address string
+	expectedIpv6Address string
+	expectedAddresses   []string
 }
 
 var testCases = map[string]TestCase{
@@ -48,12 +50,30 @@ var testCases = map[string]TestCase{
 		pattern:             ipaddr.SuccessfulOutputRegex,
 		expectedResult:      tnf.SUCCESS,
 		expectedIpv4Address: "172.17.0.7",
+		expectedAddresses:   []string{"172.17.0.7"},
+	},
+	"device_exists_dual_stack": {
+		device:              "eth0",
+		pattern:             ipaddr.SuccessfulOutputRegex,
+		expectedResult:      tnf.SUCCESS,
+		expectedIpv4Address: "10.128.2.28",
+		expectedIpv6Address: "fd01:0:0:5::1c",
+		expectedAddresses:   []string{"10.128.2.28", "fd01:0:0:5::1c"},
+	},
+	"device_exists_ipv6_only": {
+		device:              "eth0",
+		pattern:             ipaddr.SuccessfulIPv6OutputRegex,
+		expectedResult:      tnf.SUCCESS,
+		expectedIpv4Address: "",
+		expectedIpv6Address: "fd01:0:0:1::1c",
+		expectedAddresses:   []string{"fd01:0:0:1::1c"},
 	},
 	"device_does_not_exist": {
 		device:              "dne",
 		pattern:             ipaddr.DeviceDoesNotExistRegex,
 		expectedResult:      tnf.ERROR,
 		expectedIpv4Address: "",
+		expectedAddresses:   []string{},
 	},
 }
 
@@ -127,6 +147,29 @@ func TestIpAddr_GetIpv4Address(t *testing.T) {
 	}
 }
 
+func TestIpAddr_GetIpv6Address(t *testing.T) {
+	for testName, testCase := range testCases {
+		ipAddr := ipaddr.NewIPAddr(testTimeoutDuration, testCase.device)
+		step := ipAddr.ReelMatch(testCase.pattern, "", getMockOutput(t, testName))
+		assert.Nil(t, step)
+		assert.Equal(t, testCase.expectedIpv6Address, ipAddr.GetIPv6Address())
+		if testCase.expectedIpv4Address != "" {
+			assert.Equal(t, testCase.expectedIpv4Address, ipAddr.GetIPAddress())
+		} else {
+			assert.Equal(t, testCase.expectedIpv6Address, ipAddr.GetIPAddress())
+		}
+	}
+}
+
+func TestIpAddr_GetIPAddresses(t *testing.T) {
+	for testName, testCase := range testCases {
+		ipAddr := ipaddr.NewIPAddr(testTimeoutDuration, testCase.device)
+		step := ipAddr.ReelMatch(testCase.pattern, "", getMockOutput(t, testName))
+		assert.Nil(t, step)
+		assert.Equal(t, testCase.expectedAddresses, ipAddr.GetIPAddresses())
+	}
+}
+
 func TestIpAddr_ReelTimeout(t *testing.T) {
 	for _, testCase := range testCases {
 		ipAddr := ipaddr.NewIPAddr(testTimeoutDuration, testCase.device)
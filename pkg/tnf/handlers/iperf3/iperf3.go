@@ -0,0 +1,241 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package iperf3
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// DefaultPort is the port the server listens on.
+	DefaultPort = 30200
+	// DefaultDuration is how long the client sends traffic to the server.
+	DefaultDuration = 10 * time.Second
+
+	// TCP is the protocol of the default measurement, reporting the bitrate.
+	TCP = "tcp"
+	// UDP is the protocol of the measurement also reporting the jitter and the datagram loss.
+	UDP = "udp"
+
+	// StartedOutputRegex matches the PID echoed once the server is listening in the background.
+	StartedOutputRegex = `(?m)iperf3-server-pid:(\d+)$`
+	// ReceiverOutputRegex matches the receiver summary of the client, in Mbits/sec.  The jitter in ms and the
+	// lost/total datagrams are only reported for UDP.
+	ReceiverOutputRegex = `(?m)^\[\s*\d+\]\s+\S+\s+sec\s+\S+ \w*Bytes\s+([\d.]+) Mbits/sec\s+` +
+		`(?:([\d.]+) ms\s+(\d+)/(\d+) \([^)]*\)\s+)?receiver\r?$`
+	// StoppedOutputRegex matches the confirmation that the server was stopped.
+	StoppedOutputRegex = `(?m)iperf3-server-stopped$`
+	// FailureOutputRegex matches the client failing to reach the server, or the server failing to listen.
+	FailureOutputRegex = `(?m)^iperf3: error - .*$`
+	// ErrorOutputRegex matches a missing iperf3 binary.
+	ErrorOutputRegex = `(?m)^.*iperf3: (?:command )?not found.*$`
+
+	// serverStartDelay is how long the server is given to fail, e.g. when the port is in use, before it is reported
+	// as started.
+	serverStartDelay = "1"
+	// mbitsFormat reports the bitrates in Mbits/sec whatever their magnitude.
+	mbitsFormat = "m"
+)
+
+// Measurement is the throughput measured by the receiver of the traffic sent by the client.
+type Measurement struct {
+	// BitrateMbps is the bitrate in Mbits/sec.
+	BitrateMbps float64 `json:"bitrateMbps"`
+	// JitterMs is the jitter in ms, UDP only.
+	JitterMs float64 `json:"jitterMs,omitempty"`
+	// LostDatagrams is the number of datagrams lost, UDP only.
+	LostDatagrams int `json:"lostDatagrams,omitempty"`
+	// TotalDatagrams is the number of datagrams sent, UDP only.
+	TotalDatagrams int `json:"totalDatagrams,omitempty"`
+}
+
+// Iperf3 provides the three steps of a throughput measurement: start the server, run the client and stop the server.
+type Iperf3 struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	expect  []string
+
+	pid         int
+	measurement Measurement
+}
+
+// Args returns the command line args for the test.
+func (i *Iperf3) Args() []string {
+	return i.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (i *Iperf3) GetIdentifier() identifier.Identifier {
+	return identifier.Iperf3Identifier
+}
+
+// Timeout returns the timeout for the test.
+func (i *Iperf3) Timeout() time.Duration {
+	return i.timeout
+}
+
+// Result returns the test result.
+func (i *Iperf3) Result() int {
+	return i.result
+}
+
+// ReelFirst returns a step which expects the output of the current step within the test timeout.
+func (i *Iperf3) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  i.expect,
+		Timeout: i.timeout,
+	}
+}
+
+// ReelMatch parses the output of the current step and sets the test result on match.
+// Returns no step; the test is complete.
+func (i *Iperf3) ReelMatch(pattern, _, match string) *reel.Step {
+	switch pattern {
+	case ErrorOutputRegex:
+		i.result = tnf.ERROR
+	case FailureOutputRegex:
+		i.result = tnf.FAILURE
+	case StartedOutputRegex:
+		// Ignore errors in converting the PID, the regular expression only captures digits.
+		if matched := regexp.MustCompile(pattern).FindStringSubmatch(match); matched != nil {
+			i.pid, _ = strconv.Atoi(matched[1])
+			i.result = tnf.SUCCESS
+		} else {
+			i.result = tnf.FAILURE
+		}
+	case ReceiverOutputRegex:
+		i.result = i.parseMeasurement(match)
+	default:
+		i.result = tnf.SUCCESS
+	}
+	return nil
+}
+
+// parseMeasurement parses the receiver summary of the client.
+func (i *Iperf3) parseMeasurement(match string) int {
+	matched := regexp.MustCompile(ReceiverOutputRegex).FindStringSubmatch(match)
+	if matched == nil {
+		return tnf.FAILURE
+	}
+	// Ignore errors in converting the numbers, the regular expression only captures digits and dots.
+	i.measurement.BitrateMbps, _ = strconv.ParseFloat(matched[1], 64)
+	if matched[2] != "" {
+		i.measurement.JitterMs, _ = strconv.ParseFloat(matched[2], 64)
+		i.measurement.LostDatagrams, _ = strconv.Atoi(matched[3])
+		i.measurement.TotalDatagrams, _ = strconv.Atoi(matched[4])
+	}
+	return tnf.SUCCESS
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (i *Iperf3) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  iperf3 requires no intervention on eof.
+func (i *Iperf3) ReelEOF() {
+}
+
+// GetPID returns the PID of the background server, once started.
+func (i *Iperf3) GetPID() int {
+	return i.pid
+}
+
+// GetMeasurement returns the throughput measured by the client.
+func (i *Iperf3) GetMeasurement() Measurement {
+	return i.measurement
+}
+
+// logFile returns the path of the file holding the output of the server listening on port.
+func logFile(port int) string {
+	return fmt.Sprintf("/tmp/tnf-iperf3-%d.log", port)
+}
+
+// ServerCommand returns the command line starting a background server listening on port.  The PID of the server is
+// only echoed if it is still running after a short delay, its errors are printed otherwise.
+func ServerCommand(port int) []string {
+	return []string{
+		dependencies.Iperf3BinaryName, "--server", "--port", strconv.Itoa(port), ">", logFile(port), "2>&1", "&",
+		fmt.Sprintf("pid=$!; sleep %s; if kill -0 $pid 2>/dev/null; then %s iperf3-server-pid:$pid; else %s %s; fi",
+			serverStartDelay, dependencies.EchoBinaryName, dependencies.CatBinaryName, logFile(port)),
+	}
+}
+
+// ClientCommand returns the command line sending traffic with protocol to the server listening on address and port
+// for duration, and printing the summary.  IPv6 addresses may be bracketed.
+func ClientCommand(address string, port int, protocol string, duration time.Duration) []string {
+	seconds := int(duration.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	address = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+	args := []string{
+		dependencies.Iperf3BinaryName, "--client", address, "--port", strconv.Itoa(port), "--time",
+		strconv.Itoa(seconds), "--format", mbitsFormat,
+	}
+	if protocol == UDP {
+		args = append(args, "--udp")
+	}
+	return append(args, "2>&1")
+}
+
+// StopCommand returns the command line stopping the server with pid.
+func StopCommand(pid int) []string {
+	return []string{
+		fmt.Sprintf("kill %d 2>/dev/null;", pid), dependencies.EchoBinaryName, "iperf3-server-stopped",
+	}
+}
+
+// NewServer creates a new `Iperf3` test which starts a background server.  See ServerCommand.
+func NewServer(timeout time.Duration, port int) *Iperf3 {
+	return &Iperf3{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    ServerCommand(port),
+		expect:  []string{ErrorOutputRegex, FailureOutputRegex, StartedOutputRegex},
+	}
+}
+
+// NewClient creates a new `Iperf3` test which measures the throughput to a server.  The client is given timeout on top
+// of duration to connect and report.  See ClientCommand.
+func NewClient(timeout time.Duration, address string, port int, protocol string, duration time.Duration) *Iperf3 {
+	return &Iperf3{
+		result:  tnf.ERROR,
+		timeout: timeout + duration,
+		args:    ClientCommand(address, port, protocol, duration),
+		expect:  []string{ReceiverOutputRegex, ErrorOutputRegex, FailureOutputRegex},
+	}
+}
+
+// NewStop creates a new `Iperf3` test which stops the background server with pid.
+func NewStop(timeout time.Duration, pid int) *Iperf3 {
+	return &Iperf3{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    StopCommand(pid),
+		expect:  []string{StoppedOutputRegex},
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package iperf3_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/iperf3"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+
+	tcpOutput = `Connecting to host 10.0.0.1, port 30200
+[  5] local 10.0.0.2 port 41234 connected to 10.0.0.1 port 30200
+[ ID] Interval           Transfer     Bitrate         Retr  Cwnd
+[  5]   0.00-1.00   sec   112 MBytes   941 Mbits/sec    0    421 KBytes
+- - - - - - - - - - - - - - - - - - - - - - - - -
+[ ID] Interval           Transfer     Bitrate         Retr
+[  5]   0.00-10.00  sec  1.09 GBytes   937 Mbits/sec    0             sender
+[  5]   0.00-10.04  sec  1.09 GBytes   933 Mbits/sec                  receiver
+
+iperf Done.
+`
+	udpOutput = `Connecting to host fd00::1, port 30200
+[ ID] Interval           Transfer     Bitrate         Jitter    Lost/Total Datagrams
+[  5]   0.00-10.00  sec  1.25 MBytes  1.05 Mbits/sec  0.000 ms  0/906 (0%)  sender
+[  5]   0.00-10.04  sec  1.25 MBytes  1.04 Mbits/sec  0.012 ms  3/906 (0.33%)  receiver
+
+iperf Done.
+`
+)
+
+func TestServerCommand(t *testing.T) {
+	assert.Equal(t, "iperf3 --server --port 30200 > /tmp/tnf-iperf3-30200.log 2>&1 & "+
+		"pid=$!; sleep 1; if kill -0 $pid 2>/dev/null; then echo iperf3-server-pid:$pid; else cat /tmp/tnf-iperf3-30200.log; fi",
+		strings.Join(iperf3.ServerCommand(iperf3.DefaultPort), " "))
+}
+
+func TestClientCommand(t *testing.T) {
+	assert.Equal(t, "iperf3 --client 10.0.0.1 --port 30200 --time 10 --format m 2>&1",
+		strings.Join(iperf3.ClientCommand("10.0.0.1", iperf3.DefaultPort, iperf3.TCP, iperf3.DefaultDuration), " "))
+	// IPv6 addresses are unbracketed, and sub-second durations do not disable the bound.
+	assert.Equal(t, "iperf3 --client fd00::1 --port 30200 --time 1 --format m --udp 2>&1",
+		strings.Join(iperf3.ClientCommand("[fd00::1]", iperf3.DefaultPort, iperf3.UDP, time.Millisecond), " "))
+}
+
+func TestIperf3_GetIdentifier(t *testing.T) {
+	assert.Equal(t, identifier.Iperf3Identifier, iperf3.NewStop(testTimeoutDuration, 1).GetIdentifier())
+}
+
+func TestIperf3_ReelFirst(t *testing.T) {
+	step := iperf3.NewClient(testTimeoutDuration, "10.0.0.1", iperf3.DefaultPort, iperf3.TCP, iperf3.DefaultDuration).ReelFirst()
+	assert.Equal(t, "", step.Execute)
+	assert.Equal(t, []string{iperf3.ReceiverOutputRegex, iperf3.ErrorOutputRegex, iperf3.FailureOutputRegex}, step.Expect)
+	// the client is given the timeout on top of the duration of the measurement.
+	assert.Equal(t, testTimeoutDuration+iperf3.DefaultDuration, step.Timeout)
+}
+
+func TestIperf3_ReelMatchServer(t *testing.T) {
+	server := iperf3.NewServer(testTimeoutDuration, iperf3.DefaultPort)
+	assert.Equal(t, tnf.ERROR, server.Result())
+	assert.Nil(t, server.ReelMatch(iperf3.StartedOutputRegex, "", "iperf3-server-pid:4242"))
+	assert.Equal(t, tnf.SUCCESS, server.Result())
+	assert.Equal(t, 4242, server.GetPID())
+
+	server = iperf3.NewServer(testTimeoutDuration, iperf3.DefaultPort)
+	output := "iperf3: error - unable to start listener for connections: Address already in use"
+	assert.True(t, regexp.MustCompile(iperf3.FailureOutputRegex).MatchString(output))
+	server.ReelMatch(iperf3.FailureOutputRegex, "", output)
+	assert.Equal(t, tnf.FAILURE, server.Result())
+
+	server = iperf3.NewServer(testTimeoutDuration, iperf3.DefaultPort)
+	output = "sh: iperf3: command not found"
+	assert.True(t, regexp.MustCompile(iperf3.ErrorOutputRegex).MatchString(output))
+	server.ReelMatch(iperf3.ErrorOutputRegex, "", output)
+	assert.Equal(t, tnf.ERROR, server.Result())
+}
+
+func TestIperf3_ReelMatchClient(t *testing.T) {
+	client := iperf3.NewClient(testTimeoutDuration, "10.0.0.1", iperf3.DefaultPort, iperf3.TCP, iperf3.DefaultDuration)
+	// the command line itself does not match the summary.
+	assert.False(t, regexp.MustCompile(iperf3.ReceiverOutputRegex).MatchString(strings.Join(client.Args(), " ")))
+	match := regexp.MustCompile(iperf3.ReceiverOutputRegex).FindString(tcpOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, client.ReelMatch(iperf3.ReceiverOutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, client.Result())
+	assert.Equal(t, iperf3.Measurement{BitrateMbps: 933}, client.GetMeasurement())
+
+	client = iperf3.NewClient(testTimeoutDuration, "fd00::1", iperf3.DefaultPort, iperf3.UDP, iperf3.DefaultDuration)
+	match = regexp.MustCompile(iperf3.ReceiverOutputRegex).FindString(udpOutput)
+	assert.NotEmpty(t, match)
+	client.ReelMatch(iperf3.ReceiverOutputRegex, "", match)
+	assert.Equal(t, tnf.SUCCESS, client.Result())
+	assert.Equal(t, iperf3.Measurement{BitrateMbps: 1.04, JitterMs: 0.012, LostDatagrams: 3, TotalDatagrams: 906},
+		client.GetMeasurement())
+
+	client = iperf3.NewClient(testTimeoutDuration, "10.0.0.1", iperf3.DefaultPort, iperf3.TCP, iperf3.DefaultDuration)
+	output := "iperf3: error - unable to connect to server: Connection refused"
+	assert.True(t, regexp.MustCompile(iperf3.FailureOutputRegex).MatchString(output))
+	client.ReelMatch(iperf3.FailureOutputRegex, "", output)
+	assert.Equal(t, tnf.FAILURE, client.Result())
+}
+
+func TestIperf3_ReelMatchStop(t *testing.T) {
+	stop := iperf3.NewStop(testTimeoutDuration, 4242)
+	assert.Equal(t, "kill 4242 2>/dev/null; echo iperf3-server-stopped", strings.Join(stop.Args(), " "))
+	assert.Nil(t, stop.ReelMatch(iperf3.StoppedOutputRegex, "", "iperf3-server-stopped"))
+	assert.Equal(t, tnf.SUCCESS, stop.Result())
+}
+
+func TestIperf3_ReelTimeoutAndEOF(t *testing.T) {
+	stop := iperf3.NewStop(testTimeoutDuration, 4242)
+	assert.Nil(t, stop.ReelTimeout())
+	// just ensures lack of panic
+	stop.ReelEOF()
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package operatorgroup
+
+import (
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the operator groups of the namespace, see Command.
+	OutputRegex = `(?s)operatorgroups:.*?\nend:`
+
+	operatorGroupPrefix = "operatorgroup:"
+	// fieldSeparator separates the fields of a line, which may be empty, see operatorGroupsTemplate.
+	fieldSeparator = "|"
+
+	// nameField, targetNamespacesField and namespacesField are the indexes of the fields of an operator group line,
+	// see operatorGroupsTemplate.
+	nameField             = 0
+	targetNamespacesField = 1
+	namespacesField       = 2
+	operatorGroupLen      = 3
+
+	operatorGroupsTemplate = `'jsonpath=operatorgroups:{"\n"}{range .items[*]}operatorgroup:{.metadata.name}|` +
+		`{.spec.targetNamespaces[*]}|{.status.namespaces[*]}{"\n"}{end}end:{"\n"}'`
+)
+
+// OperatorGroup is the target namespaces of an operator group.
+type OperatorGroup struct {
+	Name string `json:"name"`
+	// TargetNamespaces are the namespaces of the spec, empty for all the namespaces.
+	TargetNamespaces []string `json:"targetNamespaces,omitempty"`
+	// Namespaces are the namespaces OLM resolved, from the status.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// OperatorGroups provides a test reading the operator groups of a namespace.
+type OperatorGroups struct {
+	tnf.OcCommand
+	operatorGroups []OperatorGroup
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (o *OperatorGroups) GetIdentifier() identifier.Identifier {
+	return identifier.OperatorGroupsIdentifier
+}
+
+// ReelMatch parses the operator groups and sets the test result to SUCCESS on match.  Returns no step; the test is
+// complete.
+func (o *OperatorGroups) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	o.operatorGroups = parse(match)
+	o.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetOperatorGroups returns the operator groups of the namespace.
+func (o *OperatorGroups) GetOperatorGroups() []OperatorGroup {
+	return o.operatorGroups
+}
+
+// Command returns the command line printing the operator groups of the namespace.
+func Command(namespace string) []string {
+	return []string{dependencies.OcBinaryName, "-n", namespace, "get", "operatorgroup", "-o", operatorGroupsTemplate}
+}
+
+// NewOperatorGroups creates a new `OperatorGroups` test which reads the operator groups of the namespace.  See
+// Command.
+func NewOperatorGroups(timeout time.Duration, namespace string) *OperatorGroups {
+	return &OperatorGroups{
+		OcCommand: tnf.NewOcCommand(timeout, Command(namespace), OutputRegex),
+	}
+}
+
+// parse reads the output of Command.
+func parse(output string) []OperatorGroup {
+	var operatorGroups []OperatorGroup
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, operatorGroupPrefix) {
+			continue
+		}
+		fields := strings.Split(strings.TrimPrefix(line, operatorGroupPrefix), fieldSeparator)
+		if len(fields) < operatorGroupLen {
+			continue
+		}
+		operatorGroups = append(operatorGroups, OperatorGroup{
+			Name:             fields[nameField],
+			TargetNamespaces: strings.Fields(fields[targetNamespacesField]),
+			Namespaces:       strings.Fields(fields[namespacesField]),
+		})
+	}
+	return operatorGroups
+}
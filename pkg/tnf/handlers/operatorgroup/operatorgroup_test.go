@@ -0,0 +1,71 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package operatorgroup_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/operatorgroup"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testNamespace       = "tnf"
+
+	operatorGroupsOutput = "operatorgroups:\r\n" +
+		"operatorgroup:tnf-own|tnf|tnf\r\n" +
+		"operatorgroup:global-operators||\r\n" +
+		"operatorgroup:tnf-multi|tnf cnf|cnf tnf\r\n" +
+		"end:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get operatorgroup -o "+
+		`'jsonpath=operatorgroups:{"\n"}{range .items[*]}operatorgroup:{.metadata.name}|{.spec.targetNamespaces[*]}|`+
+		`{.status.namespaces[*]}{"\n"}{end}end:{"\n"}'`,
+		strings.Join(operatorgroup.Command(testNamespace), " "))
+}
+
+func TestOperatorGroups_GetIdentifier(t *testing.T) {
+	test := operatorgroup.NewOperatorGroups(testTimeoutDuration, testNamespace)
+	assert.Equal(t, identifier.OperatorGroupsIdentifier, test.GetIdentifier())
+}
+
+func TestOperatorGroups_ReelMatch(t *testing.T) {
+	test := operatorgroup.NewOperatorGroups(testTimeoutDuration, testNamespace)
+	match := regexp.MustCompile(operatorgroup.OutputRegex).FindString(operatorGroupsOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(operatorgroup.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, []operatorgroup.OperatorGroup{
+		{Name: "tnf-own", TargetNamespaces: []string{"tnf"}, Namespaces: []string{"tnf"}},
+		{Name: "global-operators", TargetNamespaces: []string{}, Namespaces: []string{}},
+		{Name: "tnf-multi", TargetNamespaces: []string{"tnf", "cnf"}, Namespaces: []string{"cnf", "tnf"}},
+	}, test.GetOperatorGroups())
+}
+
+func TestOperatorGroups_ReelMatchNone(t *testing.T) {
+	test := operatorgroup.NewOperatorGroups(testTimeoutDuration, testNamespace)
+	assert.Nil(t, test.ReelMatch(operatorgroup.OutputRegex, "", "operatorgroups:\r\nend:\r\n"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Empty(t, test.GetOperatorGroups())
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package ownerreferences
+
+import (
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the owners of the resource, see Command.
+	OutputRegex = `(?s)owners:.*?\nend:`
+
+	ownerPrefix = "owner:"
+	// kindField, nameField and controllerField are the indexes of the fields of an owner line, see ownersTemplate.
+	kindField       = 0
+	nameField       = 1
+	controllerField = 2
+
+	// ownersTemplate prints the kind, the name and the controller flag, empty when not set, of each ownerReference.
+	ownersTemplate = `'jsonpath=owners:{"\n"}{range .metadata.ownerReferences[*]}` +
+		`owner:{.kind} {.name} {.controller}{"\n"}{end}end:{"\n"}'`
+)
+
+// Owner is an ownerReference of a resource.
+type Owner struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Controller is true for the owner managing the resource.
+	Controller bool `json:"controller"`
+}
+
+// OwnerReferences provides a test reading the owners of a resource.
+type OwnerReferences struct {
+	tnf.OcCommand
+	owners []Owner
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (o *OwnerReferences) GetIdentifier() identifier.Identifier {
+	return identifier.OwnerReferencesIdentifier
+}
+
+// ReelMatch parses the owners and sets the test result to SUCCESS on match, whether the resource has owners or not.
+// Returns no step; the test is complete.
+func (o *OwnerReferences) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	o.owners = parse(match)
+	o.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetOwners returns the owners of the resource, empty when it has none.
+func (o *OwnerReferences) GetOwners() []Owner {
+	return o.owners
+}
+
+// GetController returns the owner managing the resource, or its first owner when none is flagged as the controller,
+// nil when the resource has no owner.
+func (o *OwnerReferences) GetController() *Owner {
+	for i := range o.owners {
+		if o.owners[i].Controller {
+			return &o.owners[i]
+		}
+	}
+	if len(o.owners) > 0 {
+		return &o.owners[0]
+	}
+	return nil
+}
+
+// Command returns the command line printing the owners of the resource of resourceType, e.g. pod or replicaset.
+func Command(resourceType, name, namespace string) []string {
+	return []string{dependencies.OcBinaryName, "-n", namespace, "get", resourceType, name, "-o", ownersTemplate}
+}
+
+// NewOwnerReferences creates a new `OwnerReferences` test which reads the owners of the resource.  See Command.
+func NewOwnerReferences(timeout time.Duration, resourceType, name, namespace string) *OwnerReferences {
+	return &OwnerReferences{
+		OcCommand: tnf.NewOcCommand(timeout, Command(resourceType, name, namespace), OutputRegex),
+	}
+}
+
+// parse reads the output of Command.
+func parse(output string) []Owner {
+	var owners []Owner
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, ownerPrefix) {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, ownerPrefix))
+		if len(fields) <= nameField {
+			continue
+		}
+		owners = append(owners, Owner{
+			Kind:       fields[kindField],
+			Name:       fields[nameField],
+			Controller: len(fields) > controllerField && fields[controllerField] == "true",
+		})
+	}
+	return owners
+}
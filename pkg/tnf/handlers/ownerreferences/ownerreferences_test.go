@@ -0,0 +1,73 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package ownerreferences_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ownerreferences"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testResourceType    = "pod"
+	testPodName         = "test-65d8c9c8b-8h4zb"
+	testNamespace       = "tnf"
+
+	ownersOutput = "owners:\r\n" +
+		"owner:Workload test \r\n" +
+		"owner:ReplicaSet test-65d8c9c8b true\r\n" +
+		"end:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get pod test-65d8c9c8b-8h4zb -o "+
+		`'jsonpath=owners:{"\n"}{range .metadata.ownerReferences[*]}owner:{.kind} {.name} {.controller}{"\n"}{end}end:{"\n"}'`,
+		strings.Join(ownerreferences.Command(testResourceType, testPodName, testNamespace), " "))
+}
+
+func TestOwnerReferences_GetIdentifier(t *testing.T) {
+	test := ownerreferences.NewOwnerReferences(testTimeoutDuration, testResourceType, testPodName, testNamespace)
+	assert.Equal(t, identifier.OwnerReferencesIdentifier, test.GetIdentifier())
+}
+
+func TestOwnerReferences_ReelMatch(t *testing.T) {
+	test := ownerreferences.NewOwnerReferences(testTimeoutDuration, testResourceType, testPodName, testNamespace)
+	match := regexp.MustCompile(ownerreferences.OutputRegex).FindString(ownersOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(ownerreferences.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, []ownerreferences.Owner{
+		{Kind: "Workload", Name: "test"},
+		{Kind: "ReplicaSet", Name: "test-65d8c9c8b", Controller: true},
+	}, test.GetOwners())
+	assert.Equal(t, &ownerreferences.Owner{Kind: "ReplicaSet", Name: "test-65d8c9c8b", Controller: true},
+		test.GetController())
+}
+
+func TestOwnerReferences_ReelMatchNoOwner(t *testing.T) {
+	test := ownerreferences.NewOwnerReferences(testTimeoutDuration, testResourceType, testPodName, testNamespace)
+	assert.Nil(t, test.ReelMatch(ownerreferences.OutputRegex, "", "owners:\nend:"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Empty(t, test.GetOwners())
+	assert.Nil(t, test.GetController())
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
 	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+	"github.com/test-network-function/test-network-function/pkg/utils"
 )
 
 // Ping provides a ping test implemented using command line tool `ping`.
@@ -122,12 +123,17 @@ func (p *Ping) GetStats() (transmitted, received, errors int) {
 }
 
 // Command returns command line args for pinging `host` with `count` requests, or indefinitely if `count` is not
-// positive.
+// positive.  IPv6 addresses may be bracketed, and are pinged with `-6` so that older `ping` builds do not need `ping6`.
 func Command(host string, count int) []string {
+	args := []string{dependencies.PingBinaryName}
+	if utils.IsIPv6(host) {
+		args = append(args, "-6")
+		host = utils.StripIPAddress(host)
+	}
 	if count > 0 {
-		return []string{dependencies.PingBinaryName, "-c", strconv.Itoa(count), host}
+		args = append(args, "-c", strconv.Itoa(count))
 	}
-	return []string{dependencies.PingBinaryName, host}
+	return append(args, host)
 }
 
 // NewPing creates a new `Ping` test which pings `hosts` with `count` requests, or indefinitely if `count` is not
@@ -198,3 +198,22 @@ func TestPingCmd(t *testing.T) {
 	cmd = ping.Command("192.168.1.1", 1)
 	assert.Equal(t, []string{"ping", "-c", "1", "192.168.1.1"}, cmd)
 }
+
+func TestPingCmdIPv6(t *testing.T) {
+	cmd := ping.Command("fd00:10:244::5", 4)
+	assert.Equal(t, []string{"ping", "-6", "-c", "4", "fd00:10:244::5"}, cmd)
+	// bracketed addresses, as found in URLs or host:port pairs, are unbracketed for ping.
+	cmd = ping.Command("[fd00:10:244::5]", 0)
+	assert.Equal(t, []string{"ping", "-6", "fd00:10:244::5"}, cmd)
+}
+
+func TestPing_ReelMatchIPv6(t *testing.T) {
+	request := ping.NewPing(testTimeoutDuration, "fd00:10:244::5", 4)
+	step := request.ReelMatch("", "", getMockOutput(t, "ipv6_address_no_packet_loss"))
+	assert.Nil(t, step)
+	sent, received, errors := request.GetStats()
+	assert.Equal(t, 4, sent)
+	assert.Equal(t, 4, received)
+	assert.Zero(t, errors)
+	assert.Equal(t, tnf.SUCCESS, request.Result())
+}
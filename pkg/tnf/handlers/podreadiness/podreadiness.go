@@ -0,0 +1,98 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package podreadiness
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the list of pods, one "name,uid,ready" line per pod, see Command.
+	OutputRegex = `(?s)pods:\r?\n(.*?)end:`
+
+	// podFields is the number of fields of a pod line.
+	podFields = 3
+	// readyStatus is the status of the Ready condition of a ready pod.
+	readyStatus = "True"
+
+	// podsTemplate prints the pods between the "pods:" and "end:" markers.
+	podsTemplate = `'jsonpath=pods:{"\n"}{range .items[*]}{.metadata.name},{.metadata.uid},` +
+		`{.status.conditions[?(@.type=="Ready")].status}{"\n"}{end}end:{"\n"}'`
+)
+
+// Pod is the readiness of a pod.
+type Pod struct {
+	Name  string
+	UID   string
+	Ready bool
+}
+
+// PodReadiness provides a test listing the pods of a namespace with their readiness.
+type PodReadiness struct {
+	tnf.OcCommand
+	pods []Pod
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (p *PodReadiness) GetIdentifier() identifier.Identifier {
+	return identifier.PodReadinessIdentifier
+}
+
+// ReelMatch parses the list of pods and sets the test result to SUCCESS on match, whatever their readiness.
+// Returns no step; the test is complete.
+func (p *PodReadiness) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	matched := regexp.MustCompile(OutputRegex).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	p.pods = nil
+	for _, line := range strings.Split(matched[1], "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		if len(fields) != podFields {
+			continue
+		}
+		p.pods = append(p.pods, Pod{Name: fields[0], UID: fields[1], Ready: fields[2] == readyStatus})
+	}
+	p.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetPods returns the pods of the namespace.
+func (p *PodReadiness) GetPods() []Pod {
+	return p.pods
+}
+
+// Command returns the command line listing the pods of the namespace with their UID and readiness.
+func Command(namespace string) []string {
+	return []string{dependencies.OcBinaryName, "-n", namespace, "get", "pods", "-o", podsTemplate}
+}
+
+// NewPodReadiness creates a new `PodReadiness` test which lists the pods of the namespace.  See Command.
+func NewPodReadiness(timeout time.Duration, namespace string) *PodReadiness {
+	return &PodReadiness{
+		OcCommand: tnf.NewOcCommand(timeout, Command(namespace), OutputRegex),
+	}
+}
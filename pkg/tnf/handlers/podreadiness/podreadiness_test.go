@@ -0,0 +1,61 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package podreadiness_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/podreadiness"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testNamespace       = "tnf"
+)
+
+func TestCommand(t *testing.T) {
+	command := strings.Join(podreadiness.Command(testNamespace), " ")
+	assert.Equal(t, `oc -n tnf get pods -o 'jsonpath=pods:{"\n"}{range .items[*]}{.metadata.name},{.metadata.uid},`+
+		`{.status.conditions[?(@.type=="Ready")].status}{"\n"}{end}end:{"\n"}'`, command)
+	// the echoed command line does not match.
+	assert.NotRegexp(t, podreadiness.OutputRegex, command)
+}
+
+func TestPodReadiness_GetIdentifier(t *testing.T) {
+	assert.Equal(t, identifier.PodReadinessIdentifier, podreadiness.NewPodReadiness(testTimeoutDuration, testNamespace).GetIdentifier())
+}
+
+func TestPodReadiness_ReelMatch(t *testing.T) {
+	test := podreadiness.NewPodReadiness(testTimeoutDuration, testNamespace)
+	output := "pods:\r\ntest-0,9f1c,True\r\ntest-1,2b7e,False\r\ntest-2,77aa,\r\nend:\r\n"
+	assert.Nil(t, test.ReelMatch(podreadiness.OutputRegex, "", output))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, []podreadiness.Pod{
+		{Name: "test-0", UID: "9f1c", Ready: true},
+		{Name: "test-1", UID: "2b7e", Ready: false},
+		{Name: "test-2", UID: "77aa", Ready: false},
+	}, test.GetPods())
+
+	test = podreadiness.NewPodReadiness(testTimeoutDuration, testNamespace)
+	assert.Nil(t, test.ReelMatch(podreadiness.OutputRegex, "", "pods:\nend:\n"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Empty(t, test.GetPods())
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package podspreading
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the spreading rules of the pod template of the workload, see Command.
+	OutputRegex = `(?s)spreading:.*?\nend:`
+
+	antiAffinityPrefix   = "antiaffinity:"
+	topologySpreadPrefix = "topologyspread:"
+
+	// spreadingTemplate prints the podAntiAffinity and the topologySpreadConstraints of the pod template as JSON, empty
+	// when not defined.
+	spreadingTemplate = `'jsonpath=spreading:{"\n"}antiaffinity:{.spec.template.spec.affinity.podAntiAffinity}{"\n"}` +
+		`topologyspread:{.spec.template.spec.topologySpreadConstraints}{"\n"}end:{"\n"}'`
+)
+
+// podAntiAffinity holds the terms of a podAntiAffinity.
+type podAntiAffinity struct {
+	Required  []interface{} `json:"requiredDuringSchedulingIgnoredDuringExecution"`
+	Preferred []interface{} `json:"preferredDuringSchedulingIgnoredDuringExecution"`
+}
+
+// PodSpreading provides a test reading the spreading rules of the pods of a workload.
+type PodSpreading struct {
+	tnf.OcCommand
+	antiAffinityTerms         int
+	topologySpreadConstraints int
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (p *PodSpreading) GetIdentifier() identifier.Identifier {
+	return identifier.PodSpreadingIdentifier
+}
+
+// ReelMatch parses the spreading rules and sets the test result to SUCCESS on match, whether rules are defined or not.
+// Returns no step; the test is complete.
+func (p *PodSpreading) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	antiAffinityTerms, topologySpreadConstraints, err := parse(match)
+	if err != nil {
+		return nil
+	}
+	p.antiAffinityTerms = antiAffinityTerms
+	p.topologySpreadConstraints = topologySpreadConstraints
+	p.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetAntiAffinityTerms returns the number of required and preferred podAntiAffinity terms of the pod template.
+func (p *PodSpreading) GetAntiAffinityTerms() int {
+	return p.antiAffinityTerms
+}
+
+// GetTopologySpreadConstraints returns the number of topologySpreadConstraints of the pod template.
+func (p *PodSpreading) GetTopologySpreadConstraints() int {
+	return p.topologySpreadConstraints
+}
+
+// Command returns the command line printing the spreading rules of the pod template of the workload of resourceType,
+// e.g. deployment or statefulset.
+func Command(resourceType, name, namespace string) []string {
+	return []string{dependencies.OcBinaryName, "-n", namespace, "get", resourceType, name, "-o", spreadingTemplate}
+}
+
+// NewPodSpreading creates a new `PodSpreading` test which reads the spreading rules of the pods of the workload.  See
+// Command.
+func NewPodSpreading(timeout time.Duration, resourceType, name, namespace string) *PodSpreading {
+	return &PodSpreading{
+		OcCommand: tnf.NewOcCommand(timeout, Command(resourceType, name, namespace), OutputRegex),
+	}
+}
+
+// parse reads the output of Command.
+func parse(output string) (antiAffinityTerms, topologySpreadConstraints int, err error) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, antiAffinityPrefix) && line != antiAffinityPrefix:
+			var affinity podAntiAffinity
+			if err = json.Unmarshal([]byte(strings.TrimPrefix(line, antiAffinityPrefix)), &affinity); err != nil {
+				return 0, 0, err
+			}
+			antiAffinityTerms = len(affinity.Required) + len(affinity.Preferred)
+		case strings.HasPrefix(line, topologySpreadPrefix) && line != topologySpreadPrefix:
+			var constraints []interface{}
+			if err = json.Unmarshal([]byte(strings.TrimPrefix(line, topologySpreadPrefix)), &constraints); err != nil {
+				return 0, 0, err
+			}
+			topologySpreadConstraints = len(constraints)
+		}
+	}
+	return antiAffinityTerms, topologySpreadConstraints, nil
+}
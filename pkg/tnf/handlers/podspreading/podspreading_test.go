@@ -0,0 +1,72 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package podspreading_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/podspreading"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testResourceType    = "statefulset"
+	testName            = "test"
+	testNamespace       = "tnf"
+
+	spreadingOutput = "spreading:\r\n" +
+		`antiaffinity:{"preferredDuringSchedulingIgnoredDuringExecution":[{"podAffinityTerm":{"labelSelector":` +
+		`{"matchLabels":{"app":"test"}},"topologyKey":"kubernetes.io/hostname"},"weight":100}]}` + "\r\n" +
+		`topologyspread:[{"maxSkew":1,"topologyKey":"topology.kubernetes.io/zone","whenUnsatisfiable":"DoNotSchedule"},` +
+		`{"maxSkew":1,"topologyKey":"kubernetes.io/hostname","whenUnsatisfiable":"ScheduleAnyway"}]` + "\r\n" +
+		"end:\r\n"
+	noSpreadingOutput = "spreading:\r\nantiaffinity:\r\ntopologyspread:\r\nend:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get statefulset test -o "+
+		`'jsonpath=spreading:{"\n"}antiaffinity:{.spec.template.spec.affinity.podAntiAffinity}{"\n"}`+
+		`topologyspread:{.spec.template.spec.topologySpreadConstraints}{"\n"}end:{"\n"}'`,
+		strings.Join(podspreading.Command(testResourceType, testName, testNamespace), " "))
+}
+
+func TestPodSpreading_GetIdentifier(t *testing.T) {
+	test := podspreading.NewPodSpreading(testTimeoutDuration, testResourceType, testName, testNamespace)
+	assert.Equal(t, identifier.PodSpreadingIdentifier, test.GetIdentifier())
+}
+
+func TestPodSpreading_ReelMatch(t *testing.T) {
+	test := podspreading.NewPodSpreading(testTimeoutDuration, testResourceType, testName, testNamespace)
+	match := regexp.MustCompile(podspreading.OutputRegex).FindString(spreadingOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(podspreading.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, 1, test.GetAntiAffinityTerms())
+	assert.Equal(t, 2, test.GetTopologySpreadConstraints())
+
+	test = podspreading.NewPodSpreading(testTimeoutDuration, testResourceType, testName, testNamespace)
+	match = regexp.MustCompile(podspreading.OutputRegex).FindString(noSpreadingOutput)
+	assert.Nil(t, test.ReelMatch(podspreading.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, 0, test.GetAntiAffinityTerms())
+	assert.Equal(t, 0, test.GetTopologySpreadConstraints())
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package probes
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the probes of the containers of the workload, see Command.
+	OutputRegex = `(?s)probes:.*?\nend:`
+
+	containerPrefix = "container:"
+	livenessPrefix  = "liveness:"
+	readinessPrefix = "readiness:"
+
+	// probesTemplate prints three lines per container of the pod template: its name, then its liveness and readiness
+	// probes as JSON, empty when not defined.
+	probesTemplate = `'jsonpath=probes:{"\n"}{range .spec.template.spec.containers[*]}container:{.name}{"\n"}` +
+		`liveness:{.livenessProbe}{"\n"}readiness:{.readinessProbe}{"\n"}{end}end:{"\n"}'`
+)
+
+// ContainerProbes are the probes of a container, nil when not defined.
+type ContainerProbes struct {
+	Name           string                 `json:"name"`
+	LivenessProbe  map[string]interface{} `json:"livenessProbe,omitempty"`
+	ReadinessProbe map[string]interface{} `json:"readinessProbe,omitempty"`
+}
+
+// Probes provides a test reading the probes of the containers of a workload.
+type Probes struct {
+	tnf.OcCommand
+	containers []ContainerProbes
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (p *Probes) GetIdentifier() identifier.Identifier {
+	return identifier.ProbesIdentifier
+}
+
+// ReelMatch parses the probes of the containers and sets the test result to SUCCESS on match, whether the probes are
+// defined or not.
+// Returns no step; the test is complete.
+func (p *Probes) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	containers, err := parse(match)
+	if err != nil {
+		return nil
+	}
+	p.containers = containers
+	p.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetContainers returns the probes of the containers of the workload.
+func (p *Probes) GetContainers() []ContainerProbes {
+	return p.containers
+}
+
+// Command returns the command line printing the probes of the containers of the workload of resourceType, e.g.
+// deployment or statefulset.
+func Command(resourceType, name, namespace string) []string {
+	return []string{dependencies.OcBinaryName, "-n", namespace, "get", resourceType, name, "-o", probesTemplate}
+}
+
+// NewProbes creates a new `Probes` test which reads the probes of the containers of the workload.  See Command.
+func NewProbes(timeout time.Duration, resourceType, name, namespace string) *Probes {
+	return &Probes{
+		OcCommand: tnf.NewOcCommand(timeout, Command(resourceType, name, namespace), OutputRegex),
+	}
+}
+
+// parse reads the output of Command.
+func parse(output string) ([]ContainerProbes, error) {
+	var containers []ContainerProbes
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		var probe *map[string]interface{}
+		switch {
+		case strings.HasPrefix(line, containerPrefix):
+			containers = append(containers, ContainerProbes{Name: strings.TrimPrefix(line, containerPrefix)})
+			continue
+		case len(containers) == 0:
+			continue
+		case strings.HasPrefix(line, livenessPrefix):
+			probe, line = &containers[len(containers)-1].LivenessProbe, strings.TrimPrefix(line, livenessPrefix)
+		case strings.HasPrefix(line, readinessPrefix):
+			probe, line = &containers[len(containers)-1].ReadinessProbe, strings.TrimPrefix(line, readinessPrefix)
+		default:
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(line), probe); err != nil {
+			return nil, err
+		}
+	}
+	return containers, nil
+}
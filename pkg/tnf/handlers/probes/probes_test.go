@@ -0,0 +1,77 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package probes_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/probes"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testResourceType    = "deployment"
+	testName            = "test"
+	testNamespace       = "tnf"
+
+	probesOutput = "probes:\r\n" +
+		"container:test\r\n" +
+		`liveness:{"httpGet":{"path":"/healthz","port":8080},"periodSeconds":10}` + "\r\n" +
+		`readiness:{"exec":{"command":["cat","/tmp/ready"]}}` + "\r\n" +
+		"container:sidecar\r\n" +
+		"liveness:\r\n" +
+		"readiness:\r\n" +
+		"end:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get deployment test -o "+
+		`'jsonpath=probes:{"\n"}{range .spec.template.spec.containers[*]}container:{.name}{"\n"}`+
+		`liveness:{.livenessProbe}{"\n"}readiness:{.readinessProbe}{"\n"}{end}end:{"\n"}'`,
+		strings.Join(probes.Command(testResourceType, testName, testNamespace), " "))
+}
+
+func TestProbes_GetIdentifier(t *testing.T) {
+	test := probes.NewProbes(testTimeoutDuration, testResourceType, testName, testNamespace)
+	assert.Equal(t, identifier.ProbesIdentifier, test.GetIdentifier())
+}
+
+func TestProbes_ReelMatch(t *testing.T) {
+	test := probes.NewProbes(testTimeoutDuration, testResourceType, testName, testNamespace)
+	match := regexp.MustCompile(probes.OutputRegex).FindString(probesOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(probes.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, []probes.ContainerProbes{
+		{
+			Name: "test",
+			LivenessProbe: map[string]interface{}{
+				"httpGet":       map[string]interface{}{"path": "/healthz", "port": float64(8080)},
+				"periodSeconds": float64(10),
+			},
+			ReadinessProbe: map[string]interface{}{
+				"exec": map[string]interface{}{"command": []interface{}{"cat", "/tmp/ready"}},
+			},
+		},
+		{Name: "sidecar"},
+	}, test.GetContainers())
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package processcount
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the process counts and the pids limit, "max" when unlimited and empty when the container has
+	// no pids cgroup.
+	OutputRegex = `processes=(\d+) zombies=(\d+) pids_max=(max|\d*)\s`
+
+	// unlimited is the pids.max value of a cgroup without pids limit.
+	unlimited = "max"
+
+	// Command counts the process directories of /proc and the processes in the zombie state, then prints the pids limit
+	// of the cgroup of the container, with cgroup v2 or v1.
+	Command = `echo "processes=$(ls -d /proc/[0-9]* | wc -l) ` +
+		`zombies=$(grep -s '^State:' /proc/[0-9]*/status | grep -c 'Z (zombie)') ` +
+		`pids_max=$(cat /sys/fs/cgroup/pids.max /sys/fs/cgroup/pids/pids.max 2>/dev/null | head -n 1)"`
+)
+
+// ProcessCount provides a test counting the processes of a container.
+type ProcessCount struct {
+	result    int
+	timeout   time.Duration
+	args      []string
+	processes int
+	zombies   int
+	pidsLimit int
+}
+
+// Args returns the command line args for the test.
+func (p *ProcessCount) Args() []string {
+	return p.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (p *ProcessCount) GetIdentifier() identifier.Identifier {
+	return identifier.ProcessCountIdentifier
+}
+
+// Timeout returns the timeout for the test.
+func (p *ProcessCount) Timeout() time.Duration {
+	return p.timeout
+}
+
+// Result returns the test result.
+func (p *ProcessCount) Result() int {
+	return p.result
+}
+
+// ReelFirst returns a step which expects the counts within the test timeout.
+func (p *ProcessCount) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{OutputRegex},
+		Timeout: p.timeout,
+	}
+}
+
+// ReelMatch parses the counts and the pids limit and sets the test result to SUCCESS on match.
+// Returns no step; the test is complete.
+func (p *ProcessCount) ReelMatch(_, _, match string) *reel.Step {
+	matched := regexp.MustCompile(OutputRegex).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	var err error
+	if p.processes, err = strconv.Atoi(matched[1]); err != nil {
+		return nil
+	}
+	if p.zombies, err = strconv.Atoi(matched[2]); err != nil {
+		return nil
+	}
+	p.pidsLimit = 0
+	if matched[3] != unlimited && matched[3] != "" {
+		if p.pidsLimit, err = strconv.Atoi(matched[3]); err != nil {
+			return nil
+		}
+	}
+	p.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (p *ProcessCount) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no action is necessary on EOF.
+func (p *ProcessCount) ReelEOF() {
+}
+
+// GetProcesses returns the number of processes of the container, including the zombies and the counting shell.
+func (p *ProcessCount) GetProcesses() int {
+	return p.processes
+}
+
+// GetZombies returns the number of zombie processes of the container.
+func (p *ProcessCount) GetZombies() int {
+	return p.zombies
+}
+
+// GetPidsLimit returns the pids limit of the cgroup of the container, e.g. the pids_limit of CRI-O, 0 when unlimited.
+func (p *ProcessCount) GetPidsLimit() int {
+	return p.pidsLimit
+}
+
+// NewProcessCount creates a new `ProcessCount` test which counts the processes of the container its session runs in.
+func NewProcessCount(timeout time.Duration) *ProcessCount {
+	return &ProcessCount{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    []string{Command},
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package processcount_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/processcount"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const testTimeoutDuration = time.Second * 5
+
+func TestProcessCount_Args(t *testing.T) {
+	test := processcount.NewProcessCount(testTimeoutDuration)
+	assert.Equal(t, []string{processcount.Command}, test.Args())
+	// the echoed command line does not match.
+	assert.NotRegexp(t, processcount.OutputRegex, processcount.Command)
+}
+
+func TestProcessCount_GetIdentifier(t *testing.T) {
+	test := processcount.NewProcessCount(testTimeoutDuration)
+	assert.Equal(t, identifier.ProcessCountIdentifier, test.GetIdentifier())
+}
+
+func TestProcessCount_ReelFirst(t *testing.T) {
+	step := processcount.NewProcessCount(testTimeoutDuration).ReelFirst()
+	assert.Equal(t, "", step.Execute)
+	assert.Equal(t, []string{processcount.OutputRegex}, step.Expect)
+	assert.Equal(t, testTimeoutDuration, step.Timeout)
+}
+
+func TestProcessCount_ReelMatch(t *testing.T) {
+	test := processcount.NewProcessCount(testTimeoutDuration)
+	assert.Nil(t, test.ReelMatch(processcount.OutputRegex, "", "processes=42 zombies=3 pids_max=1024\r\n"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, 42, test.GetProcesses())
+	assert.Equal(t, 3, test.GetZombies())
+	assert.Equal(t, 1024, test.GetPidsLimit())
+
+	test = processcount.NewProcessCount(testTimeoutDuration)
+	assert.Nil(t, test.ReelMatch(processcount.OutputRegex, "", "processes=5 zombies=0 pids_max=max\n"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, 0, test.GetPidsLimit())
+
+	// no pids cgroup.
+	test = processcount.NewProcessCount(testTimeoutDuration)
+	assert.Nil(t, test.ReelMatch(processcount.OutputRegex, "", "processes=5 zombies=0 pids_max=\n"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, 0, test.GetPidsLimit())
+}
+
+func TestProcessCount_ReelTimeout(t *testing.T) {
+	test := processcount.NewProcessCount(testTimeoutDuration)
+	assert.Nil(t, test.ReelTimeout())
+	assert.Equal(t, tnf.ERROR, test.Result())
+}
@@ -42,7 +42,7 @@ func NewReadBootConfig(timeout time.Duration) *ReadBootConfig {
 		timeout: timeout,
 		result:  tnf.ERROR,
 		args: []string{
-			"cat /host/boot/loader/entries/$(ls /host/boot/loader/entries/ | sort | tail -n 1)",
+			"chroot /host sh -c 'cat /boot/loader/entries/$(ls /boot/loader/entries/ | sort | tail -n 1)'",
 		},
 	}
 }
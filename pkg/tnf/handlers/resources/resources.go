@@ -0,0 +1,146 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package resources
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the QoS class of the pod and the resources of its containers, one per line, see Command.
+	OutputRegex = `(?s)pod:.*?\nend:`
+
+	// GuaranteedQOSClass is the QoS class of the pods whose containers all set their requests equal to their limits.
+	GuaranteedQOSClass = "Guaranteed"
+
+	podPrefix       = "pod:"
+	containerPrefix = "container:"
+	// containerFields is the number of fields of a container line.
+	containerFields = 5
+
+	// resourcesTemplate prints the QoS class of the pod, then one line per container as
+	// "container:name,requests.cpu,requests.memory,limits.cpu,limits.memory".
+	resourcesTemplate = `'jsonpath=pod:{.status.qosClass}{"\n"}{range .spec.containers[*]}container:{.name},` +
+		`{.resources.requests.cpu},{.resources.requests.memory},{.resources.limits.cpu},{.resources.limits.memory}` +
+		`{"\n"}{end}end:{"\n"}'`
+)
+
+// ContainerResources are the CPU and memory requests and limits of a container, empty when not set.
+type ContainerResources struct {
+	Name           string
+	RequestsCPU    string
+	RequestsMemory string
+	LimitsCPU      string
+	LimitsMemory   string
+}
+
+// GetMissing returns the requests and limits the container does not set, e.g. "limits.memory".
+func (c *ContainerResources) GetMissing() []string {
+	var missing []string
+	for _, resource := range []struct{ name, value string }{
+		{"requests.cpu", c.RequestsCPU},
+		{"requests.memory", c.RequestsMemory},
+		{"limits.cpu", c.LimitsCPU},
+		{"limits.memory", c.LimitsMemory},
+	} {
+		if resource.value == "" {
+			missing = append(missing, resource.name)
+		}
+	}
+	return missing
+}
+
+// Resources provides a test reading the resources of the containers of a pod.
+type Resources struct {
+	tnf.OcCommand
+	qosClass   string
+	containers []ContainerResources
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (r *Resources) GetIdentifier() identifier.Identifier {
+	return identifier.ResourcesIdentifier
+}
+
+// ReelMatch parses the QoS class of the pod and the resources of its containers and sets the test result to SUCCESS
+// on match, whatever the resources of the containers.
+// Returns no step; the test is complete.
+func (r *Resources) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	qosClass, containers, err := parse(match)
+	if err != nil {
+		return nil
+	}
+	r.qosClass = qosClass
+	r.containers = containers
+	r.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetQOSClass returns the QoS class of the pod, i.e. Guaranteed, Burstable or BestEffort.
+func (r *Resources) GetQOSClass() string {
+	return r.qosClass
+}
+
+// GetContainers returns the resources of the containers of the pod.
+func (r *Resources) GetContainers() []ContainerResources {
+	return r.containers
+}
+
+// Command returns the command line printing the QoS class of the pod and the resources of its containers.
+func Command(podName, podNamespace string) []string {
+	return []string{dependencies.OcBinaryName, "-n", podNamespace, "get", "pods", podName, "-o", resourcesTemplate}
+}
+
+// NewResources creates a new `Resources` test which reads the resources of the containers of the pod.  See Command.
+func NewResources(timeout time.Duration, podName, podNamespace string) *Resources {
+	return &Resources{
+		OcCommand: tnf.NewOcCommand(timeout, Command(podName, podNamespace), OutputRegex),
+	}
+}
+
+// parse reads the output of Command.
+func parse(output string) (qosClass string, containers []ContainerResources, err error) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, podPrefix):
+			qosClass = strings.TrimPrefix(line, podPrefix)
+		case strings.HasPrefix(line, containerPrefix):
+			fields := strings.Split(strings.TrimPrefix(line, containerPrefix), ",")
+			if len(fields) != containerFields {
+				return "", nil, strconv.ErrSyntax
+			}
+			containers = append(containers, ContainerResources{
+				Name:           fields[0],
+				RequestsCPU:    fields[1],
+				RequestsMemory: fields[2],
+				LimitsCPU:      fields[3],
+				LimitsMemory:   fields[4],
+			})
+		}
+	}
+	return qosClass, containers, nil
+}
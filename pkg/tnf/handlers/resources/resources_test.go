@@ -0,0 +1,69 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package resources_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/resources"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testPodName         = "test-0"
+	testPodNamespace    = "tnf"
+
+	resourcesOutput = "pod:Burstable\r\n" +
+		"container:test,250m,64Mi,500m,128Mi\r\n" +
+		"container:sidecar,100m,,,\r\n" +
+		"end:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get pods test-0 -o "+
+		`'jsonpath=pod:{.status.qosClass}{"\n"}{range .spec.containers[*]}container:{.name},`+
+		`{.resources.requests.cpu},{.resources.requests.memory},{.resources.limits.cpu},{.resources.limits.memory}`+
+		`{"\n"}{end}end:{"\n"}'`,
+		strings.Join(resources.Command(testPodName, testPodNamespace), " "))
+}
+
+func TestResources_GetIdentifier(t *testing.T) {
+	test := resources.NewResources(testTimeoutDuration, testPodName, testPodNamespace)
+	assert.Equal(t, identifier.ResourcesIdentifier, test.GetIdentifier())
+}
+
+func TestResources_ReelMatch(t *testing.T) {
+	test := resources.NewResources(testTimeoutDuration, testPodName, testPodNamespace)
+	match := regexp.MustCompile(resources.OutputRegex).FindString(resourcesOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(resources.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, "Burstable", test.GetQOSClass())
+
+	containers := test.GetContainers()
+	assert.Len(t, containers, 2)
+	assert.Equal(t, resources.ContainerResources{Name: "test", RequestsCPU: "250m", RequestsMemory: "64Mi",
+		LimitsCPU: "500m", LimitsMemory: "128Mi"}, containers[0])
+	assert.Empty(t, containers[0].GetMissing())
+	assert.Equal(t, "sidecar", containers[1].Name)
+	assert.Equal(t, []string{"requests.memory", "limits.cpu", "limits.memory"}, containers[1].GetMissing())
+}
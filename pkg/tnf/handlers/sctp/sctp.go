@@ -0,0 +1,197 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sctp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// DefaultPort is the port the server listens on.
+	DefaultPort = 30100
+	// Probe is the message sent by the client and echoed by the server.
+	Probe = "tnf-sctp-probe"
+
+	// StartedOutputRegex matches the PID echoed once the server is listening in the background.
+	StartedOutputRegex = `(?m)sctp-server-pid:(\d+)$`
+	// EchoOutputRegex matches the probe echoed back to the client.
+	EchoOutputRegex = `(?m)^` + Probe + `\r?$`
+	// StoppedOutputRegex matches the confirmation that the server was stopped.
+	StoppedOutputRegex = `(?m)sctp-server-stopped$`
+	// FailureOutputRegex matches the client failing to reach the server, or getting no answer.
+	FailureOutputRegex = `(?m)Ncat: .*(?:Connection refused|Connection reset|TIMEOUT|timed out|Idle timeout expired|No route to host|unreachable).*$`
+	// ErrorOutputRegex matches a missing ncat binary, or a kernel or a tool lacking the SCTP support.
+	ErrorOutputRegex = `(?m)(.*ncat: (?:command )?not found|Ncat: .*(?:Protocol not supported|not supported|Permission denied|Operation not permitted).*|.*unrecognized option.*sctp.*)$`
+
+	// serverStartDelay is how long the server is given to fail, e.g. without the sctp kernel module, before it is
+	// reported as started.
+	serverStartDelay = "1"
+	// clientIdleSeconds is how long the client waits for the echo once the probe is sent.
+	clientIdleSeconds = 2
+)
+
+// Sctp provides the three steps of an SCTP connectivity test: start the server, run the client and stop the server.
+type Sctp struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	expect  []string
+
+	pid int
+}
+
+// Args returns the command line args for the test.
+func (s *Sctp) Args() []string {
+	return s.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (s *Sctp) GetIdentifier() identifier.Identifier {
+	return identifier.SctpIdentifier
+}
+
+// Timeout returns the timeout for the test.
+func (s *Sctp) Timeout() time.Duration {
+	return s.timeout
+}
+
+// Result returns the test result.
+func (s *Sctp) Result() int {
+	return s.result
+}
+
+// ReelFirst returns a step which expects the output of the current step within the test timeout.
+func (s *Sctp) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  s.expect,
+		Timeout: s.timeout,
+	}
+}
+
+// ReelMatch parses the output of the current step and sets the test result on match.
+// Returns no step; the test is complete.
+func (s *Sctp) ReelMatch(pattern, _, match string) *reel.Step {
+	switch pattern {
+	case ErrorOutputRegex:
+		s.result = tnf.ERROR
+	case FailureOutputRegex:
+		s.result = tnf.FAILURE
+	case StartedOutputRegex:
+		// Ignore errors in converting the PID, the regular expression only captures digits.
+		if matched := regexp.MustCompile(pattern).FindStringSubmatch(match); matched != nil {
+			s.pid, _ = strconv.Atoi(matched[1])
+			s.result = tnf.SUCCESS
+		} else {
+			s.result = tnf.FAILURE
+		}
+	default:
+		s.result = tnf.SUCCESS
+	}
+	return nil
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (s *Sctp) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  ncat requires no intervention on eof.
+func (s *Sctp) ReelEOF() {
+}
+
+// GetPID returns the PID of the background server, once started.
+func (s *Sctp) GetPID() int {
+	return s.pid
+}
+
+// logFile returns the path of the file holding the output of the server listening on port.
+func logFile(port int) string {
+	return fmt.Sprintf("/tmp/tnf-sctp-%d.log", port)
+}
+
+// ServerCommand returns the command line starting a background server echoing the messages received on port.  The
+// PID of the server is only echoed if it is still running after a short delay, its errors are printed otherwise.
+func ServerCommand(port int) []string {
+	return []string{
+		dependencies.NcatBinaryName, "--sctp", "--listen", "--keep-open", strconv.Itoa(port), "--exec", "/bin/cat",
+		">", logFile(port), "2>&1", "&",
+		fmt.Sprintf("pid=$!; sleep %s; if kill -0 $pid 2>/dev/null; then %s sctp-server-pid:$pid; else %s %s; fi",
+			serverStartDelay, dependencies.EchoBinaryName, dependencies.CatBinaryName, logFile(port)),
+	}
+}
+
+// ClientCommand returns the command line sending the probe to the server listening on address and port, and printing
+// the answer.  IPv6 addresses may be bracketed.
+func ClientCommand(address string, port int, connectTimeout time.Duration) []string {
+	seconds := int(connectTimeout.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	address = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+	// the input is kept open until the echo is received, ncat exits once the server is idle.
+	return []string{
+		fmt.Sprintf("(%s %s; sleep %d)", dependencies.EchoBinaryName, Probe, clientIdleSeconds+1), "|",
+		dependencies.NcatBinaryName, "--sctp", "--wait", strconv.Itoa(seconds), "--idle-timeout",
+		strconv.Itoa(clientIdleSeconds), address, strconv.Itoa(port), "2>&1",
+	}
+}
+
+// StopCommand returns the command line stopping the server with pid.
+func StopCommand(pid int) []string {
+	return []string{
+		fmt.Sprintf("kill %d 2>/dev/null;", pid), dependencies.EchoBinaryName, "sctp-server-stopped",
+	}
+}
+
+// NewServer creates a new `Sctp` test which starts a background server.  See ServerCommand.
+func NewServer(timeout time.Duration, port int) *Sctp {
+	return &Sctp{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    ServerCommand(port),
+		expect:  []string{ErrorOutputRegex, StartedOutputRegex},
+	}
+}
+
+// NewClient creates a new `Sctp` test which sends the probe to a server and expects it back.  See ClientCommand.
+func NewClient(timeout time.Duration, address string, port int) *Sctp {
+	return &Sctp{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    ClientCommand(address, port, timeout),
+		expect:  []string{EchoOutputRegex, ErrorOutputRegex, FailureOutputRegex},
+	}
+}
+
+// NewStop creates a new `Sctp` test which stops the background server with pid.
+func NewStop(timeout time.Duration, pid int) *Sctp {
+	return &Sctp{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    StopCommand(pid),
+		expect:  []string{StoppedOutputRegex},
+	}
+}
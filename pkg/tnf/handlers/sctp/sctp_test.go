@@ -0,0 +1,104 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sctp_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/sctp"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const testTimeoutDuration = time.Second * 5
+
+func TestServerCommand(t *testing.T) {
+	assert.Equal(t, "ncat --sctp --listen --keep-open 30100 --exec /bin/cat > /tmp/tnf-sctp-30100.log 2>&1 & "+
+		"pid=$!; sleep 1; if kill -0 $pid 2>/dev/null; then echo sctp-server-pid:$pid; else cat /tmp/tnf-sctp-30100.log; fi",
+		strings.Join(sctp.ServerCommand(sctp.DefaultPort), " "))
+}
+
+func TestClientCommand(t *testing.T) {
+	assert.Equal(t, "(echo tnf-sctp-probe; sleep 3) | ncat --sctp --wait 5 --idle-timeout 2 10.0.0.1 30100 2>&1",
+		strings.Join(sctp.ClientCommand("10.0.0.1", sctp.DefaultPort, testTimeoutDuration), " "))
+	// IPv6 addresses are unbracketed, and sub-second timeouts do not disable the bound.
+	args := sctp.ClientCommand("[fd00::1]", 3868, time.Millisecond)
+	assert.Contains(t, args, "fd00::1")
+	assert.Equal(t, "1", args[5])
+}
+
+func TestSctp_GetIdentifier(t *testing.T) {
+	assert.Equal(t, identifier.SctpIdentifier, sctp.NewStop(testTimeoutDuration, 1).GetIdentifier())
+}
+
+func TestSctp_ReelFirst(t *testing.T) {
+	step := sctp.NewClient(testTimeoutDuration, "10.0.0.1", sctp.DefaultPort).ReelFirst()
+	assert.Equal(t, "", step.Execute)
+	assert.Equal(t, []string{sctp.EchoOutputRegex, sctp.ErrorOutputRegex, sctp.FailureOutputRegex}, step.Expect)
+	assert.Equal(t, testTimeoutDuration, step.Timeout)
+}
+
+func TestSctp_ReelMatchServer(t *testing.T) {
+	server := sctp.NewServer(testTimeoutDuration, sctp.DefaultPort)
+	assert.Equal(t, tnf.ERROR, server.Result())
+	assert.Nil(t, server.ReelMatch(sctp.StartedOutputRegex, "", "sctp-server-pid:4242"))
+	assert.Equal(t, tnf.SUCCESS, server.Result())
+	assert.Equal(t, 4242, server.GetPID())
+
+	server = sctp.NewServer(testTimeoutDuration, sctp.DefaultPort)
+	output := "Ncat: Protocol not supported. QUITTING."
+	assert.True(t, regexp.MustCompile(sctp.ErrorOutputRegex).MatchString(output))
+	server.ReelMatch(sctp.ErrorOutputRegex, "", output)
+	assert.Equal(t, tnf.ERROR, server.Result())
+	assert.True(t, regexp.MustCompile(sctp.ErrorOutputRegex).MatchString("sh: ncat: command not found"))
+}
+
+func TestSctp_ReelMatchClient(t *testing.T) {
+	client := sctp.NewClient(testTimeoutDuration, "10.0.0.1", sctp.DefaultPort)
+	output := "tnf-sctp-probe\nNcat: Idle timeout expired (2000 ms).\n"
+	// the echo comes first in the expectations, the idle timeout following it is not a failure.
+	assert.True(t, regexp.MustCompile(sctp.EchoOutputRegex).MatchString(output))
+	assert.Nil(t, client.ReelMatch(sctp.EchoOutputRegex, "", output))
+	assert.Equal(t, tnf.SUCCESS, client.Result())
+
+	// the command line itself does not match the echo.
+	assert.False(t, regexp.MustCompile(sctp.EchoOutputRegex).MatchString(strings.Join(client.Args(), " ")))
+
+	client = sctp.NewClient(testTimeoutDuration, "10.0.0.1", sctp.DefaultPort)
+	output = "Ncat: Connection refused."
+	assert.True(t, regexp.MustCompile(sctp.FailureOutputRegex).MatchString(output))
+	client.ReelMatch(sctp.FailureOutputRegex, "", output)
+	assert.Equal(t, tnf.FAILURE, client.Result())
+}
+
+func TestSctp_ReelMatchStop(t *testing.T) {
+	stop := sctp.NewStop(testTimeoutDuration, 4242)
+	assert.Equal(t, "kill 4242 2>/dev/null; echo sctp-server-stopped", strings.Join(stop.Args(), " "))
+	assert.Nil(t, stop.ReelMatch(sctp.StoppedOutputRegex, "", "sctp-server-stopped"))
+	assert.Equal(t, tnf.SUCCESS, stop.Result())
+}
+
+func TestSctp_ReelTimeoutAndEOF(t *testing.T) {
+	stop := sctp.NewStop(testTimeoutDuration, 4242)
+	assert.Nil(t, stop.ReelTimeout())
+	// just ensures lack of panic
+	stop.ReelEOF()
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package securitycontext
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the security contexts of the pod and of its containers, one per line, see Command.
+	OutputRegex = `(?s)pod:.*?\nend:`
+
+	podPrefix       = "pod:"
+	containerPrefix = "container:"
+	// containerFields is the number of fields of a container line, the added capabilities being the last one.
+	containerFields = 5
+
+	// securityContextTemplate prints the security context of the pod, then one line per container as
+	// "container:name,privileged,runAsNonRoot,runAsUser,capabilities", the capabilities being a JSON list.
+	securityContextTemplate = `'jsonpath=pod:{.spec.securityContext.runAsNonRoot},{.spec.securityContext.runAsUser}{"\n"}` +
+		`{range .spec.containers[*]}container:{.name},{.securityContext.privileged},{.securityContext.runAsNonRoot},` +
+		`{.securityContext.runAsUser},{.securityContext.capabilities.add}{"\n"}{end}end:{"\n"}'`
+)
+
+// ContainerSecurityContext is the effective security context of a container, the runAsNonRoot and runAsUser settings of
+// the pod applying unless overridden by the container.
+type ContainerSecurityContext struct {
+	Name       string
+	Privileged bool
+	// RunAsNonRoot is nil when set neither for the container nor for the pod.
+	RunAsNonRoot *bool
+	// RunAsUser is nil when set neither for the container nor for the pod.
+	RunAsUser *int64
+	// AddedCapabilities are the capabilities added to the default set of the container runtime.
+	AddedCapabilities []string
+}
+
+// IsNonRoot returns true when the container cannot run as root: runAsNonRoot is set, or runAsUser is not 0.
+func (c *ContainerSecurityContext) IsNonRoot() bool {
+	return (c.RunAsNonRoot != nil && *c.RunAsNonRoot) || (c.RunAsUser != nil && *c.RunAsUser != 0)
+}
+
+// SecurityContext provides a test reading the security context of the containers of a pod.
+type SecurityContext struct {
+	tnf.OcCommand
+	containers []ContainerSecurityContext
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (s *SecurityContext) GetIdentifier() identifier.Identifier {
+	return identifier.SecurityContextIdentifier
+}
+
+// ReelMatch parses the security contexts of the containers and sets the test result to SUCCESS on match, whatever
+// the settings of the containers.
+// Returns no step; the test is complete.
+func (s *SecurityContext) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	containers, err := parse(match)
+	if err != nil {
+		return nil
+	}
+	s.containers = containers
+	s.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetContainers returns the security contexts of the containers of the pod.
+func (s *SecurityContext) GetContainers() []ContainerSecurityContext {
+	return s.containers
+}
+
+// Command returns the command line printing the security contexts of the pod and of its containers.
+func Command(podName, podNamespace string) []string {
+	return []string{dependencies.OcBinaryName, "-n", podNamespace, "get", "pods", podName, "-o", securityContextTemplate}
+}
+
+// NewSecurityContext creates a new `SecurityContext` test which reads the security contexts of the containers of the
+// pod.  See Command.
+func NewSecurityContext(timeout time.Duration, podName, podNamespace string) *SecurityContext {
+	return &SecurityContext{
+		OcCommand: tnf.NewOcCommand(timeout, Command(podName, podNamespace), OutputRegex),
+	}
+}
+
+// parse reads the output of Command.
+func parse(output string) ([]ContainerSecurityContext, error) {
+	var podNonRoot *bool
+	var podUser *int64
+	var containers []ContainerSecurityContext
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, podPrefix):
+			fields := strings.SplitN(strings.TrimPrefix(line, podPrefix), ",", 2)
+			if len(fields) != 2 {
+				continue
+			}
+			var err error
+			if podNonRoot, err = parseBool(fields[0]); err != nil {
+				return nil, err
+			}
+			if podUser, err = parseInt(fields[1]); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, containerPrefix):
+			container, err := parseContainer(strings.TrimPrefix(line, containerPrefix))
+			if err != nil {
+				return nil, err
+			}
+			containers = append(containers, container)
+		}
+	}
+	for i := range containers {
+		if containers[i].RunAsNonRoot == nil {
+			containers[i].RunAsNonRoot = podNonRoot
+		}
+		if containers[i].RunAsUser == nil {
+			containers[i].RunAsUser = podUser
+		}
+	}
+	return containers, nil
+}
+
+// parseContainer reads a container line, without its prefix.
+func parseContainer(line string) (container ContainerSecurityContext, err error) {
+	fields := strings.SplitN(line, ",", containerFields)
+	if len(fields) != containerFields {
+		return container, strconv.ErrSyntax
+	}
+	container.Name = fields[0]
+	privileged, err := parseBool(fields[1])
+	if err != nil {
+		return container, err
+	}
+	container.Privileged = privileged != nil && *privileged
+	if container.RunAsNonRoot, err = parseBool(fields[2]); err != nil {
+		return container, err
+	}
+	if container.RunAsUser, err = parseInt(fields[3]); err != nil {
+		return container, err
+	}
+	if fields[4] != "" {
+		err = json.Unmarshal([]byte(fields[4]), &container.AddedCapabilities)
+	}
+	return container, err
+}
+
+// parseBool parses an optional boolean, nil when not set.
+func parseBool(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	return &b, err
+}
+
+// parseInt parses an optional integer, nil when not set.
+func parseInt(value string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	return &i, err
+}
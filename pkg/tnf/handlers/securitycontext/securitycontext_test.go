@@ -0,0 +1,90 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package securitycontext_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/securitycontext"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testPodName         = "test-0"
+	testPodNamespace    = "tnf"
+
+	securityContextOutput = "pod:,1000\r\n" +
+		"container:test,,,,\r\n" +
+		"container:dataplane,true,,0,[\"NET_ADMIN\",\"SYS_ADMIN\"]\r\n" +
+		"container:sidecar,false,true,,\r\n" +
+		"end:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get pods test-0 -o "+
+		`'jsonpath=pod:{.spec.securityContext.runAsNonRoot},{.spec.securityContext.runAsUser}{"\n"}`+
+		`{range .spec.containers[*]}container:{.name},{.securityContext.privileged},{.securityContext.runAsNonRoot},`+
+		`{.securityContext.runAsUser},{.securityContext.capabilities.add}{"\n"}{end}end:{"\n"}'`,
+		strings.Join(securitycontext.Command(testPodName, testPodNamespace), " "))
+}
+
+func TestSecurityContext_GetIdentifier(t *testing.T) {
+	test := securitycontext.NewSecurityContext(testTimeoutDuration, testPodName, testPodNamespace)
+	assert.Equal(t, identifier.SecurityContextIdentifier, test.GetIdentifier())
+}
+
+func TestSecurityContext_ReelMatch(t *testing.T) {
+	test := securitycontext.NewSecurityContext(testTimeoutDuration, testPodName, testPodNamespace)
+	match := regexp.MustCompile(securitycontext.OutputRegex).FindString(securityContextOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(securitycontext.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+
+	containers := test.GetContainers()
+	assert.Len(t, containers, 3)
+	// the runAsUser of the pod applies to the containers which do not set it.
+	assert.Equal(t, "test", containers[0].Name)
+	assert.False(t, containers[0].Privileged)
+	assert.Nil(t, containers[0].RunAsNonRoot)
+	assert.Equal(t, int64(1000), *containers[0].RunAsUser)
+	assert.Empty(t, containers[0].AddedCapabilities)
+	assert.True(t, containers[0].IsNonRoot())
+
+	assert.Equal(t, "dataplane", containers[1].Name)
+	assert.True(t, containers[1].Privileged)
+	assert.Equal(t, int64(0), *containers[1].RunAsUser)
+	assert.Equal(t, []string{"NET_ADMIN", "SYS_ADMIN"}, containers[1].AddedCapabilities)
+	assert.False(t, containers[1].IsNonRoot())
+
+	assert.Equal(t, "sidecar", containers[2].Name)
+	assert.False(t, containers[2].Privileged)
+	assert.True(t, *containers[2].RunAsNonRoot)
+	assert.True(t, containers[2].IsNonRoot())
+}
+
+func TestSecurityContext_ReelMatchUnset(t *testing.T) {
+	test := securitycontext.NewSecurityContext(testTimeoutDuration, testPodName, testPodNamespace)
+	assert.Nil(t, test.ReelMatch(securitycontext.OutputRegex, "", "pod:,\ncontainer:test,,,,\nend:"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Len(t, test.GetContainers(), 1)
+	assert.False(t, test.GetContainers()[0].IsNonRoot())
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package selinux
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the SELinux mode of the node and the label of the process, empty when it cannot be read.
+	OutputRegex = `mode=(Enforcing|Permissive|Disabled) label=(\S*)\s`
+	// ErrorOutputRegex matches a node without SELinux tools.
+	ErrorOutputRegex = `(?m)^.*(?:getenforce: (?:command )?not found|failed to run command 'getenforce').*$`
+
+	// Enforcing is the SELinux mode denying the accesses not allowed by the policy.
+	Enforcing = "Enforcing"
+
+	// labelFields is the number of fields of a SELinux label, user:role:type:level, the level possibly holding colons.
+	labelFields = 4
+	// labelTypeField is the index of the type in a SELinux label, e.g. container_t.
+	labelTypeField = 2
+)
+
+// SELinux provides a test reading the SELinux mode of a node and the SELinux label of a container.
+type SELinux struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	mode    string
+	label   string
+}
+
+// Args returns the command line args for the test.
+func (s *SELinux) Args() []string {
+	return s.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (s *SELinux) GetIdentifier() identifier.Identifier {
+	return identifier.SELinuxIdentifier
+}
+
+// Timeout returns the timeout for the test.
+func (s *SELinux) Timeout() time.Duration {
+	return s.timeout
+}
+
+// Result returns the test result.
+func (s *SELinux) Result() int {
+	return s.result
+}
+
+// ReelFirst returns a step which expects the SELinux mode and label within the test timeout.
+func (s *SELinux) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{ErrorOutputRegex, OutputRegex},
+		Timeout: s.timeout,
+	}
+}
+
+// ReelMatch records the SELinux mode and label and sets the test result to SUCCESS on match, whatever the mode; the
+// label is empty when the process of the container could not be found.
+// Returns no step; the test is complete.
+func (s *SELinux) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	matched := regexp.MustCompile(OutputRegex).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	s.mode = matched[1]
+	s.label = matched[2]
+	s.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (s *SELinux) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no action is necessary on EOF.
+func (s *SELinux) ReelEOF() {
+}
+
+// GetMode returns the SELinux mode of the node, Enforcing, Permissive or Disabled.
+func (s *SELinux) GetMode() string {
+	return s.mode
+}
+
+// GetLabel returns the SELinux label of the main process of the container, e.g.
+// "system_u:system_r:container_t:s0:c12,c34", empty when it could not be read.
+func (s *SELinux) GetLabel() string {
+	return s.label
+}
+
+// GetLabelType returns the type of the SELinux label of the container, e.g. "container_t", empty when the label could
+// not be read.
+func (s *SELinux) GetLabelType() string {
+	return LabelType(s.label)
+}
+
+// LabelType returns the type of a SELinux label, e.g. "container_t" for "system_u:system_r:container_t:s0:c12,c34".
+func LabelType(label string) string {
+	fields := strings.SplitN(label, ":", labelFields)
+	if len(fields) != labelFields {
+		return ""
+	}
+	return fields[labelTypeField]
+}
+
+// Command returns the command line printing the SELinux mode of the node and the label of the main process of the
+// container of containerID, found with crictl.  It runs in the debug pod of the node, which shares its process
+// namespace.
+func Command(containerID string) []string {
+	pid := fmt.Sprintf(`$(chroot /host %s inspect -o go-template --template '{{.info.pid}}' %s)`, dependencies.CrictlBinaryName, containerID)
+	return []string{dependencies.EchoBinaryName, fmt.Sprintf(`"mode=$(chroot /host %s) label=$(%s -d '\0' < /proc/%s/attr/current)"`,
+		dependencies.GetenforceBinaryName, dependencies.TrBinaryName, pid)}
+}
+
+// NewSELinux creates a new `SELinux` test which reads the SELinux mode of the node and the label of the container of
+// containerID.  See Command.
+func NewSELinux(timeout time.Duration, containerID string) *SELinux {
+	return &SELinux{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    Command(containerID),
+	}
+}
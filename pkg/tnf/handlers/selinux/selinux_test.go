@@ -0,0 +1,90 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package selinux_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/selinux"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testContainerID     = "cf9a1a0c5d5f"
+)
+
+func TestCommand(t *testing.T) {
+	command := strings.Join(selinux.Command(testContainerID), " ")
+	assert.Equal(t, `echo "mode=$(chroot /host getenforce) label=$(tr -d '\0' < /proc/$(chroot /host crictl inspect `+
+		`-o go-template --template '{{.info.pid}}' cf9a1a0c5d5f)/attr/current)"`, command)
+	// the echoed command line does not match.
+	assert.NotRegexp(t, selinux.OutputRegex, command)
+}
+
+func TestSELinux_GetIdentifier(t *testing.T) {
+	assert.Equal(t, identifier.SELinuxIdentifier, selinux.NewSELinux(testTimeoutDuration, testContainerID).GetIdentifier())
+}
+
+func TestSELinux_ReelFirst(t *testing.T) {
+	step := selinux.NewSELinux(testTimeoutDuration, testContainerID).ReelFirst()
+	assert.Equal(t, "", step.Execute)
+	assert.Equal(t, []string{selinux.ErrorOutputRegex, selinux.OutputRegex}, step.Expect)
+	assert.Equal(t, testTimeoutDuration, step.Timeout)
+}
+
+func TestSELinux_ReelMatch(t *testing.T) {
+	test := selinux.NewSELinux(testTimeoutDuration, testContainerID)
+	assert.Nil(t, test.ReelMatch(selinux.OutputRegex, "", "mode=Enforcing label=system_u:system_r:container_t:s0:c12,c34\r\n"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, selinux.Enforcing, test.GetMode())
+	assert.Equal(t, "system_u:system_r:container_t:s0:c12,c34", test.GetLabel())
+	assert.Equal(t, "container_t", test.GetLabelType())
+
+	// the process of the container is gone.
+	test = selinux.NewSELinux(testTimeoutDuration, testContainerID)
+	assert.Nil(t, test.ReelMatch(selinux.OutputRegex, "", "mode=Permissive label=\n"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, "Permissive", test.GetMode())
+	assert.Equal(t, "", test.GetLabelType())
+}
+
+func TestSELinux_ReelMatchError(t *testing.T) {
+	for _, output := range []string{
+		"sh: getenforce: command not found",
+		"chroot: failed to run command 'getenforce': No such file or directory",
+	} {
+		assert.Regexp(t, selinux.ErrorOutputRegex, output)
+	}
+	test := selinux.NewSELinux(testTimeoutDuration, testContainerID)
+	assert.Nil(t, test.ReelMatch(selinux.ErrorOutputRegex, "", "sh: getenforce: command not found"))
+	assert.Equal(t, tnf.ERROR, test.Result())
+}
+
+func TestSELinux_ReelTimeout(t *testing.T) {
+	test := selinux.NewSELinux(testTimeoutDuration, testContainerID)
+	assert.Nil(t, test.ReelTimeout())
+	assert.Equal(t, tnf.ERROR, test.Result())
+}
+
+func TestLabelType(t *testing.T) {
+	assert.Equal(t, "spc_t", selinux.LabelType("system_u:system_r:spc_t:s0"))
+	assert.Equal(t, "", selinux.LabelType("unconfined"))
+}
@@ -51,6 +51,7 @@ func createTest() (*tnf.Tester, []reel.Handler, *gojsonschema.Result, error) {
 	values["POD_NAMESPACE"] = testPodNameSpace
 	values["POD_NAME"] = testPodName
 	values["GO_TEMPLATE_PATH"] = "."
+	values["SKIPPED_CONTAINERS"] = ""
 	return generic.NewGenericFromMap(shutdownFilename, pathToTestSchemaFile, values)
 }
 
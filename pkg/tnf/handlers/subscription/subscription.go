@@ -0,0 +1,163 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package subscription
+
+import (
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the subscription and the install plans, see Command.
+	OutputRegex = `(?s)olm:.*?\nend:`
+
+	// StateAtLatestKnown is the state of a subscription whose installed CSV is the latest of its channel.
+	StateAtLatestKnown = "AtLatestKnown"
+	// PhaseComplete is the phase of an install plan which has been applied.
+	PhaseComplete = "Complete"
+
+	subscriptionPrefix = "subscription:"
+	installPlanPrefix  = "installplan:"
+	// fieldSeparator separates the fields of a line, which may be empty, see the templates.
+	fieldSeparator = "|"
+	// approvedTrue is the approved field of an approved install plan.
+	approvedTrue = "true"
+
+	// channelField, approvalField, stateField, installedCSVField, currentCSVField and installPlanField are the indexes
+	// of the fields of the subscription line, see subscriptionTemplate.
+	channelField      = 0
+	approvalField     = 1
+	stateField        = 2
+	installedCSVField = 3
+	currentCSVField   = 4
+	installPlanField  = 5
+	subscriptionLen   = 6
+
+	// nameField, approvedField and phaseField are the indexes of the fields of an install plan line, see
+	// installPlansTemplate.
+	nameField      = 0
+	approvedField  = 1
+	phaseField     = 2
+	installPlanLen = 3
+
+	subscriptionTemplate = `'jsonpath=olm:{"\n"}subscription:{.spec.channel}|{.spec.installPlanApproval}|` +
+		`{.status.state}|{.status.installedCSV}|{.status.currentCSV}|{.status.installPlanRef.name}{"\n"}'`
+	installPlansTemplate = `'jsonpath={range .items[*]}installplan:{.metadata.name}|{.spec.approved}|` +
+		`{.status.phase}{"\n"}{end}end:{"\n"}'`
+)
+
+// InstallPlan is the approval and the phase of an install plan.
+type InstallPlan struct {
+	Name     string `json:"name"`
+	Approved bool   `json:"approved"`
+	Phase    string `json:"phase"`
+}
+
+// Status is the state of a subscription, and the install plan it references.
+type Status struct {
+	Channel             string `json:"channel"`
+	InstallPlanApproval string `json:"installPlanApproval"`
+	State               string `json:"state"`
+	InstalledCSV        string `json:"installedCSV"`
+	CurrentCSV          string `json:"currentCSV"`
+	// InstallPlan is the install plan referenced by the subscription, nil when it has none or it is gone.
+	InstallPlan *InstallPlan `json:"installPlan,omitempty"`
+}
+
+// Subscription provides a test reading the status of an OLM subscription.
+type Subscription struct {
+	tnf.OcCommand
+	status *Status
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (s *Subscription) GetIdentifier() identifier.Identifier {
+	return identifier.SubscriptionIdentifier
+}
+
+// ReelMatch parses the subscription and sets the test result to SUCCESS on match.  Returns no step; the test is
+// complete.
+func (s *Subscription) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	s.status = parse(match)
+	if s.status != nil {
+		s.SetResult(tnf.SUCCESS)
+	}
+	return nil
+}
+
+// GetStatus returns the status of the subscription, nil when it could not be read.
+func (s *Subscription) GetStatus() *Status {
+	return s.status
+}
+
+// Command returns the command line printing the status of the subscription, then the install plans of its namespace.
+func Command(name, namespace string) []string {
+	return []string{dependencies.OcBinaryName, "-n", namespace, "get", "subscription", name, "-o", subscriptionTemplate,
+		"&&", dependencies.OcBinaryName, "-n", namespace, "get", "installplan", "-o", installPlansTemplate}
+}
+
+// NewSubscription creates a new `Subscription` test which reads the status of the subscription.  See Command.
+func NewSubscription(timeout time.Duration, name, namespace string) *Subscription {
+	return &Subscription{
+		OcCommand: tnf.NewOcCommand(timeout, Command(name, namespace), OutputRegex),
+	}
+}
+
+// parse reads the output of Command, returns nil when it has no subscription line.
+func parse(output string) *Status {
+	var status *Status
+	installPlans := map[string]*InstallPlan{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, subscriptionPrefix):
+			fields := strings.Split(strings.TrimPrefix(line, subscriptionPrefix), fieldSeparator)
+			if len(fields) < subscriptionLen {
+				continue
+			}
+			status = &Status{
+				Channel:             fields[channelField],
+				InstallPlanApproval: fields[approvalField],
+				State:               fields[stateField],
+				InstalledCSV:        fields[installedCSVField],
+				CurrentCSV:          fields[currentCSVField],
+				InstallPlan:         &InstallPlan{Name: fields[installPlanField]},
+			}
+		case strings.HasPrefix(line, installPlanPrefix):
+			fields := strings.Split(strings.TrimPrefix(line, installPlanPrefix), fieldSeparator)
+			if len(fields) < installPlanLen {
+				continue
+			}
+			installPlans[fields[nameField]] = &InstallPlan{
+				Name:     fields[nameField],
+				Approved: fields[approvedField] == approvedTrue,
+				Phase:    fields[phaseField],
+			}
+		}
+	}
+	if status != nil {
+		status.InstallPlan = installPlans[status.InstallPlan.Name]
+	}
+	return status
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package subscription_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/subscription"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testName            = "nginx-operator"
+	testNamespace       = "tnf"
+
+	subscriptionOutput = "olm:\r\n" +
+		"subscription:stable|Manual|AtLatestKnown|nginx-operator.v0.0.2|nginx-operator.v0.0.2|install-b4x2k\r\n" +
+		"installplan:install-7qzvd|true|Complete\r\n" +
+		"installplan:install-b4x2k|true|Complete\r\n" +
+		"end:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get subscription nginx-operator -o "+
+		`'jsonpath=olm:{"\n"}subscription:{.spec.channel}|{.spec.installPlanApproval}|{.status.state}|`+
+		`{.status.installedCSV}|{.status.currentCSV}|{.status.installPlanRef.name}{"\n"}' && `+
+		`oc -n tnf get installplan -o 'jsonpath={range .items[*]}installplan:{.metadata.name}|{.spec.approved}|`+
+		`{.status.phase}{"\n"}{end}end:{"\n"}'`,
+		strings.Join(subscription.Command(testName, testNamespace), " "))
+}
+
+func TestSubscription_GetIdentifier(t *testing.T) {
+	test := subscription.NewSubscription(testTimeoutDuration, testName, testNamespace)
+	assert.Equal(t, identifier.SubscriptionIdentifier, test.GetIdentifier())
+}
+
+func TestSubscription_ReelMatch(t *testing.T) {
+	test := subscription.NewSubscription(testTimeoutDuration, testName, testNamespace)
+	match := regexp.MustCompile(subscription.OutputRegex).FindString(subscriptionOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(subscription.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, &subscription.Status{
+		Channel:             "stable",
+		InstallPlanApproval: "Manual",
+		State:               subscription.StateAtLatestKnown,
+		InstalledCSV:        "nginx-operator.v0.0.2",
+		CurrentCSV:          "nginx-operator.v0.0.2",
+		InstallPlan:         &subscription.InstallPlan{Name: "install-b4x2k", Approved: true, Phase: subscription.PhaseComplete},
+	}, test.GetStatus())
+}
+
+func TestSubscription_ReelMatchPending(t *testing.T) {
+	// an upgrade waiting for its install plan to be approved, empty fields included.
+	output := "olm:\r\n" +
+		"subscription:stable|Manual|UpgradePending|nginx-operator.v0.0.2||install-z9w8q\r\n" +
+		"installplan:install-z9w8q|false|RequiresApproval\r\n" +
+		"end:\r\n"
+	test := subscription.NewSubscription(testTimeoutDuration, testName, testNamespace)
+	assert.Nil(t, test.ReelMatch(subscription.OutputRegex, "", output))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	status := test.GetStatus()
+	assert.Equal(t, "UpgradePending", status.State)
+	assert.Equal(t, "", status.CurrentCSV)
+	assert.Equal(t, &subscription.InstallPlan{Name: "install-z9w8q", Approved: false, Phase: "RequiresApproval"},
+		status.InstallPlan)
+}
+
+func TestSubscription_ReelMatchNoInstallPlan(t *testing.T) {
+	output := "olm:\r\nsubscription:stable|Automatic||||\r\nend:\r\n"
+	test := subscription.NewSubscription(testTimeoutDuration, testName, testNamespace)
+	assert.Nil(t, test.ReelMatch(subscription.OutputRegex, "", output))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Nil(t, test.GetStatus().InstallPlan)
+}
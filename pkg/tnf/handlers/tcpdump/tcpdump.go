@@ -0,0 +1,219 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tcpdump
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// StartedOutputRegex matches the PID echoed once the capture is running in the background.
+	StartedOutputRegex = `(?m)tcpdump-pid:(\d+)$`
+	// StatsOutputRegex matches the statistics printed by tcpdump when the capture stops.
+	StatsOutputRegex = `(?m)(\d+) packets? captured\s+(\d+) packets? received by filter\s+(\d+) packets? dropped by kernel`
+	// CountOutputRegex matches the number of packets matching a filter in a pcap file.
+	CountOutputRegex = `(?m)tcpdump-count:\s*(\d+)$`
+	// ErrorOutputRegex matches tcpdump failures such as an unknown interface, an invalid filter or a missing binary.
+	ErrorOutputRegex = `(?m)(tcpdump: .*(?:No such device|syntax error|Permission denied|Operation not permitted|doesn't exist).*|tcpdump: (?:command )?not found)$`
+
+	// DefaultMaxPackets bounds the number of packets captured when no explicit limit is given.
+	DefaultMaxPackets = 10000
+)
+
+// Tcpdump provides the three steps of a bounded packet capture: start, stop and count.
+type Tcpdump struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	expect  []string
+
+	pid              int
+	captured         int
+	receivedByFilter int
+	droppedByKernel  int
+	count            int
+}
+
+// Args returns the command line args for the test.
+func (t *Tcpdump) Args() []string {
+	return t.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (t *Tcpdump) GetIdentifier() identifier.Identifier {
+	return identifier.TcpdumpIdentifier
+}
+
+// Timeout returns the timeout for the test.
+func (t *Tcpdump) Timeout() time.Duration {
+	return t.timeout
+}
+
+// Result returns the test result.
+func (t *Tcpdump) Result() int {
+	return t.result
+}
+
+// ReelFirst returns a step which expects the output of the current capture step within the test timeout.
+func (t *Tcpdump) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  t.expect,
+		Timeout: t.timeout,
+	}
+}
+
+// ReelMatch parses the output of the capture step and sets the test result on match.
+// Returns no step; the test is complete.
+func (t *Tcpdump) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern == ErrorOutputRegex {
+		t.result = tnf.ERROR
+		return nil
+	}
+	matched := regexp.MustCompile(pattern).FindStringSubmatch(match)
+	if matched == nil {
+		t.result = tnf.FAILURE
+		return nil
+	}
+	// Ignore errors in converting matches to decimal integers, the regular expressions only capture digits.
+	switch pattern {
+	case StartedOutputRegex:
+		t.pid, _ = strconv.Atoi(matched[1])
+	case StatsOutputRegex:
+		t.captured, _ = strconv.Atoi(matched[1])
+		t.receivedByFilter, _ = strconv.Atoi(matched[2])
+		t.droppedByKernel, _ = strconv.Atoi(matched[3])
+	case CountOutputRegex:
+		t.count, _ = strconv.Atoi(matched[1])
+	}
+	t.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  the capture itself is bounded by the `timeout` command.
+func (t *Tcpdump) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  tcpdump requires no intervention on eof.
+func (t *Tcpdump) ReelEOF() {
+}
+
+// GetPID returns the PID of the background capture, once started.
+func (t *Tcpdump) GetPID() int {
+	return t.pid
+}
+
+// GetStats returns the captured, received by filter and dropped by kernel packet counts reported when the capture
+// stopped.
+func (t *Tcpdump) GetStats() (captured, receivedByFilter, droppedByKernel int) {
+	return t.captured, t.receivedByFilter, t.droppedByKernel
+}
+
+// GetCount returns the number of packets of the pcap file matching the filter.
+func (t *Tcpdump) GetCount() int {
+	return t.count
+}
+
+// logFile returns the path of the file holding the tcpdump output of a capture.
+func logFile(pcapFile string) string {
+	return pcapFile + ".log"
+}
+
+// StartCommand returns the command line starting a background capture of at most `maxPackets` packets on `iface`
+// for at most `maxDuration`, written to `pcapFile`.  `filter` is an optional pcap filter expression.
+func StartCommand(iface, pcapFile, filter string, maxPackets int, maxDuration time.Duration) []string {
+	if maxPackets <= 0 {
+		maxPackets = DefaultMaxPackets
+	}
+	// `timeout 0` would disable the bound altogether.
+	seconds := int(maxDuration.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	return []string{
+		"timeout", "-s", "INT", strconv.Itoa(seconds),
+		dependencies.TcpdumpBinaryName, "-nn", "-U", "-i", iface, "-c", strconv.Itoa(maxPackets), "-w", pcapFile,
+		quoteFilter(filter), ">", logFile(pcapFile), "2>&1", "&",
+		dependencies.EchoBinaryName, "tcpdump-pid:$!",
+	}
+}
+
+// StopCommand returns the command line interrupting the capture with `pid`, waiting for it to exit and printing the
+// capture statistics.
+func StopCommand(pid int, pcapFile string) []string {
+	return []string{
+		fmt.Sprintf("kill -INT %d 2>/dev/null; while kill -0 %d 2>/dev/null; do sleep 0.1; done;", pid, pid),
+		dependencies.CatBinaryName, logFile(pcapFile),
+	}
+}
+
+// CountCommand returns the command line counting the packets of `pcapFile` matching `filter`.
+func CountCommand(pcapFile, filter string) []string {
+	return []string{
+		dependencies.EchoBinaryName,
+		fmt.Sprintf(`"tcpdump-count:$(%s -nn -r %s %s 2>/dev/null | %s -l)"`,
+			dependencies.TcpdumpBinaryName, pcapFile, quoteFilter(filter), dependencies.WcBinaryName),
+	}
+}
+
+// quoteFilter protects a pcap filter expression from the shell.  A single quote cannot appear within single quotes, so
+// each one closes the quoted string, is escaped and reopens it.
+func quoteFilter(filter string) string {
+	if filter == "" {
+		return ""
+	}
+	return "'" + strings.ReplaceAll(filter, "'", `'\''`) + "'"
+}
+
+// NewStart creates a new `Tcpdump` test which starts a background capture.  See StartCommand.
+func NewStart(timeout time.Duration, iface, pcapFile, filter string, maxPackets int, maxDuration time.Duration) *Tcpdump {
+	return &Tcpdump{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    StartCommand(iface, pcapFile, filter, maxPackets, maxDuration),
+		expect:  []string{StartedOutputRegex},
+	}
+}
+
+// NewStop creates a new `Tcpdump` test which stops the background capture with `pid` and parses its statistics.
+func NewStop(timeout time.Duration, pid int, pcapFile string) *Tcpdump {
+	return &Tcpdump{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    StopCommand(pid, pcapFile),
+		expect:  []string{ErrorOutputRegex, StatsOutputRegex},
+	}
+}
+
+// NewCount creates a new `Tcpdump` test which counts the packets of `pcapFile` matching `filter`.
+func NewCount(timeout time.Duration, pcapFile, filter string) *Tcpdump {
+	return &Tcpdump{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    CountCommand(pcapFile, filter),
+		expect:  []string{CountOutputRegex},
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tcpdump_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/tcpdump"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+	testPcapFile        = "/tmp/tnf.pcap"
+	testStatsOutput     = `tcpdump: listening on eth0, link-type EN10MB (Ethernet), capture size 262144 bytes
+12 packets captured
+14 packets received by filter
+1 packet dropped by kernel`
+)
+
+func TestStartCommand(t *testing.T) {
+	args := tcpdump.StartCommand("eth0", testPcapFile, "icmp", 0, 30*time.Second)
+	assert.Equal(t, "timeout -s INT 30 tcpdump -nn -U -i eth0 -c 10000 -w /tmp/tnf.pcap 'icmp' > /tmp/tnf.pcap.log 2>&1 & echo tcpdump-pid:$!",
+		strings.Join(args, " "))
+	// sub-second durations must not disable the bound.
+	args = tcpdump.StartCommand("eth0", testPcapFile, "", 5, time.Millisecond)
+	assert.Equal(t, "1", args[3])
+	assert.Contains(t, args, "5")
+}
+
+func TestTcpdump_GetIdentifier(t *testing.T) {
+	assert.Equal(t, identifier.TcpdumpIdentifier, tcpdump.NewCount(testTimeoutDuration, testPcapFile, "").GetIdentifier())
+}
+
+func TestTcpdump_ReelFirst(t *testing.T) {
+	step := tcpdump.NewStop(testTimeoutDuration, 42, testPcapFile).ReelFirst()
+	assert.Equal(t, "", step.Execute)
+	assert.Equal(t, []string{tcpdump.ErrorOutputRegex, tcpdump.StatsOutputRegex}, step.Expect)
+	assert.Equal(t, testTimeoutDuration, step.Timeout)
+}
+
+func TestTcpdump_ReelMatchStart(t *testing.T) {
+	start := tcpdump.NewStart(testTimeoutDuration, "eth0", testPcapFile, "", 0, time.Minute)
+	assert.Equal(t, tnf.ERROR, start.Result())
+	assert.Nil(t, start.ReelMatch(tcpdump.StartedOutputRegex, "", "tcpdump-pid:4242"))
+	assert.Equal(t, tnf.SUCCESS, start.Result())
+	assert.Equal(t, 4242, start.GetPID())
+}
+
+func TestTcpdump_ReelMatchStop(t *testing.T) {
+	stop := tcpdump.NewStop(testTimeoutDuration, 4242, testPcapFile)
+	assert.Equal(t, "kill -INT 4242 2>/dev/null; while kill -0 4242 2>/dev/null; do sleep 0.1; done; cat /tmp/tnf.pcap.log",
+		strings.Join(stop.Args(), " "))
+	assert.Nil(t, stop.ReelMatch(tcpdump.StatsOutputRegex, "", testStatsOutput))
+	assert.Equal(t, tnf.SUCCESS, stop.Result())
+	captured, received, dropped := stop.GetStats()
+	assert.Equal(t, 12, captured)
+	assert.Equal(t, 14, received)
+	assert.Equal(t, 1, dropped)
+
+	stop = tcpdump.NewStop(testTimeoutDuration, 4242, testPcapFile)
+	stop.ReelMatch(tcpdump.ErrorOutputRegex, "", "tcpdump: eth9: No such device exists")
+	assert.Equal(t, tnf.ERROR, stop.Result())
+}
+
+func TestTcpdump_ReelMatchCount(t *testing.T) {
+	count := tcpdump.NewCount(testTimeoutDuration, testPcapFile, "icmp and host 10.0.0.1")
+	assert.Equal(t, `echo "tcpdump-count:$(tcpdump -nn -r /tmp/tnf.pcap 'icmp and host 10.0.0.1' 2>/dev/null | wc -l)"`,
+		strings.Join(count.Args(), " "))
+	assert.Nil(t, count.ReelMatch(tcpdump.CountOutputRegex, "", "tcpdump-count:7"))
+	assert.Equal(t, tnf.SUCCESS, count.Result())
+	assert.Equal(t, 7, count.GetCount())
+
+	// the single quotes of the filter must not end the quoted string.
+	count = tcpdump.NewCount(testTimeoutDuration, testPcapFile, "icmp' ; reboot ; '")
+	assert.Equal(t, `echo "tcpdump-count:$(tcpdump -nn -r /tmp/tnf.pcap 'icmp'\'' ; reboot ; '\''' 2>/dev/null | wc -l)"`,
+		strings.Join(count.Args(), " "))
+}
+
+func TestTcpdump_ReelTimeoutAndEOF(t *testing.T) {
+	count := tcpdump.NewCount(testTimeoutDuration, testPcapFile, "")
+	assert.Nil(t, count.ReelTimeout())
+	// just ensures lack of panic
+	count.ReelEOF()
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package termination
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// OutputRegex matches the termination settings of the pod, see Command.
+	OutputRegex = `(?s)termination:.*?\nend:`
+
+	// PodKind is the resource type of the pods, whose spec is not a pod template.
+	PodKind = "pod"
+
+	gracePeriodPrefix   = "grace:"
+	managedFieldsPrefix = "managed:"
+	containerPrefix     = "container:"
+	preStopPrefix       = "prestop:"
+
+	// managedGracePeriodField is the terminationGracePeriodSeconds field in the managed fields of a resource, which
+	// only lists the fields set by its managers, not the defaulted ones.
+	managedGracePeriodField = `"f:terminationGracePeriodSeconds"`
+
+	// terminationTemplate prints the terminationGracePeriodSeconds of the pod spec at the given path, the managed fields
+	// of the resource, then the name and the preStop hook as JSON of each container, empty when not defined.
+	terminationTemplate = `'jsonpath=termination:{"\n"}grace:{%[1]s.terminationGracePeriodSeconds}{"\n"}` +
+		`managed:{.metadata.managedFields[*].fieldsV1}{"\n"}` +
+		`{range %[1]s.containers[*]}container:{.name}{"\n"}prestop:{.lifecycle.preStop}{"\n"}{end}end:{"\n"}'`
+)
+
+// Termination provides a test reading the termination settings of a pod, or of the pod template of a workload.
+type Termination struct {
+	tnf.OcCommand
+	gracePeriod    *int
+	gracePeriodSet bool
+	containers     []string
+	noPreStop      []string
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (t *Termination) GetIdentifier() identifier.Identifier {
+	return identifier.TerminationIdentifier
+}
+
+// ReelMatch parses the termination settings and sets the test result to SUCCESS on match, whether preStop hooks are
+// defined or not.  Returns no step; the test is complete.
+func (t *Termination) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != OutputRegex {
+		return nil
+	}
+	gracePeriod, gracePeriodSet, containers, noPreStop, err := parse(match)
+	if err != nil {
+		return nil
+	}
+	t.gracePeriod = gracePeriod
+	t.gracePeriodSet = gracePeriodSet
+	t.containers = containers
+	t.noPreStop = noPreStop
+	t.SetResult(tnf.SUCCESS)
+	return nil
+}
+
+// GetGracePeriod returns the terminationGracePeriodSeconds of the pod, nil when its spec does not set it.  The API
+// server defaults it to 30 seconds, see IsGracePeriodSet.
+func (t *Termination) GetGracePeriod() *int {
+	return t.gracePeriod
+}
+
+// IsGracePeriodSet returns true when the terminationGracePeriodSeconds is set by the managers of the resource, e.g. in
+// its manifest, rather than defaulted by the API server, which tells an explicit grace period of 30 seconds from the
+// default.  It is only told from the spec when the resource has no managed fields.
+func (t *Termination) IsGracePeriodSet() bool {
+	return t.gracePeriodSet
+}
+
+// GetContainers returns the names of the containers of the pod.
+func (t *Termination) GetContainers() []string {
+	return t.containers
+}
+
+// GetContainersWithoutPreStop returns the names of the containers of the pod which do not define a preStop hook.
+func (t *Termination) GetContainersWithoutPreStop() []string {
+	return t.noPreStop
+}
+
+// Command returns the command line printing the termination settings of the resource of type resourceType, a pod or a
+// workload such as a deployment, whose pod template is read.
+func Command(resourceType, name, namespace string) []string {
+	specPath := ".spec.template.spec"
+	if resourceType == PodKind {
+		specPath = ".spec"
+	}
+	return []string{dependencies.OcBinaryName, "-n", namespace, "get", resourceType, name, "-o",
+		fmt.Sprintf(terminationTemplate, specPath)}
+}
+
+// NewTermination creates a new `Termination` test which reads the termination settings of a pod or of a workload.  See
+// Command.
+func NewTermination(timeout time.Duration, resourceType, name, namespace string) *Termination {
+	return &Termination{
+		OcCommand: tnf.NewOcCommand(timeout, Command(resourceType, name, namespace), OutputRegex),
+	}
+}
+
+// parse reads the output of Command.
+func parse(output string) (gracePeriod *int, gracePeriodSet bool, containers, noPreStop []string, err error) {
+	container := ""
+	managedFields := ""
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == gracePeriodPrefix:
+			// jsonpath prints nothing for a field which is not set.
+		case strings.HasPrefix(line, gracePeriodPrefix):
+			seconds, atoiErr := strconv.Atoi(strings.TrimPrefix(line, gracePeriodPrefix))
+			if atoiErr != nil {
+				return nil, false, nil, nil, atoiErr
+			}
+			gracePeriod = &seconds
+		case strings.HasPrefix(line, managedFieldsPrefix):
+			managedFields = strings.TrimPrefix(line, managedFieldsPrefix)
+		case strings.HasPrefix(line, containerPrefix):
+			container = strings.TrimPrefix(line, containerPrefix)
+			containers = append(containers, container)
+		case strings.HasPrefix(line, preStopPrefix) && container != "":
+			if line == preStopPrefix {
+				noPreStop = append(noPreStop, container)
+			}
+			container = ""
+		}
+	}
+	gracePeriodSet = gracePeriod != nil && (managedFields == "" || strings.Contains(managedFields, managedGracePeriodField))
+	return gracePeriod, gracePeriodSet, containers, noPreStop, nil
+}
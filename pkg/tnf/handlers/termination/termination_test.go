@@ -0,0 +1,91 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package termination_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/termination"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testPodName         = "test-0"
+	testPodNamespace    = "tnf"
+
+	terminationOutput = "termination:\r\n" +
+		"grace:60\r\n" +
+		`managed:{"f:metadata":{"f:labels":{}},"f:spec":{"f:terminationGracePeriodSeconds":{}}}` + "\r\n" +
+		"container:test\r\n" +
+		`prestop:{"exec":{"command":["/bin/sh","-c","killall -0 tail"]}}` + "\r\n" +
+		"container:sidecar\r\n" +
+		"prestop:\r\n" +
+		"end:\r\n"
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "oc -n tnf get pod test-0 -o "+
+		`'jsonpath=termination:{"\n"}grace:{.spec.terminationGracePeriodSeconds}{"\n"}`+
+		`managed:{.metadata.managedFields[*].fieldsV1}{"\n"}`+
+		`{range .spec.containers[*]}container:{.name}{"\n"}prestop:{.lifecycle.preStop}{"\n"}{end}end:{"\n"}'`,
+		strings.Join(termination.Command(termination.PodKind, testPodName, testPodNamespace), " "))
+	assert.Equal(t, "oc -n tnf get deployment test -o "+
+		`'jsonpath=termination:{"\n"}grace:{.spec.template.spec.terminationGracePeriodSeconds}{"\n"}`+
+		`managed:{.metadata.managedFields[*].fieldsV1}{"\n"}`+
+		`{range .spec.template.spec.containers[*]}container:{.name}{"\n"}prestop:{.lifecycle.preStop}{"\n"}{end}end:{"\n"}'`,
+		strings.Join(termination.Command("deployment", "test", testPodNamespace), " "))
+}
+
+func TestTermination_GetIdentifier(t *testing.T) {
+	test := termination.NewTermination(testTimeoutDuration, termination.PodKind, testPodName, testPodNamespace)
+	assert.Equal(t, identifier.TerminationIdentifier, test.GetIdentifier())
+}
+
+func TestTermination_ReelMatch(t *testing.T) {
+	test := termination.NewTermination(testTimeoutDuration, termination.PodKind, testPodName, testPodNamespace)
+	match := regexp.MustCompile(termination.OutputRegex).FindString(terminationOutput)
+	assert.NotEmpty(t, match)
+	assert.Nil(t, test.ReelMatch(termination.OutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	if assert.NotNil(t, test.GetGracePeriod()) {
+		assert.Equal(t, 60, *test.GetGracePeriod())
+	}
+	assert.True(t, test.IsGracePeriodSet())
+	assert.Equal(t, []string{"test", "sidecar"}, test.GetContainers())
+	assert.Equal(t, []string{"sidecar"}, test.GetContainersWithoutPreStop())
+
+	// a grace period which is not set is told from an explicit one.
+	test = termination.NewTermination(testTimeoutDuration, termination.PodKind, testPodName, testPodNamespace)
+	assert.Nil(t, test.ReelMatch(termination.OutputRegex, "", "termination:\r\ngrace:\r\nmanaged:\r\nend:\r\n"))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Nil(t, test.GetGracePeriod())
+	assert.False(t, test.IsGracePeriodSet())
+
+	// a grace period defaulted by the API server is not in the managed fields.
+	test = termination.NewTermination(testTimeoutDuration, "deployment", "test", testPodNamespace)
+	assert.Nil(t, test.ReelMatch(termination.OutputRegex, "", "termination:\r\ngrace:30\r\n"+
+		`managed:{"f:spec":{"f:template":{"f:spec":{"f:containers":{}}}}}`+"\r\nend:\r\n"))
+	if assert.NotNil(t, test.GetGracePeriod()) {
+		assert.Equal(t, 30, *test.GetGracePeriod())
+	}
+	assert.False(t, test.IsGracePeriodSet())
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package writablelayer
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// UsedBytesOutputRegex matches the bytes used by the writable layer in the JSON output of `crictl stats`.
+	UsedBytesOutputRegex = `"usedBytes":\s*\{\s*"value":\s*"(\d+)"`
+	// ErrorOutputRegex matches a missing crictl binary, or an error of crictl, e.g. an unknown container.
+	ErrorOutputRegex = `(?m)^.*(?:crictl: (?:command )?not found|level=fatal|FATA\[\d+\]).*$`
+)
+
+// WritableLayer provides a test measuring the disk usage of the writable layer of a container.
+type WritableLayer struct {
+	result    int
+	timeout   time.Duration
+	args      []string
+	usedBytes uint64
+}
+
+// Args returns the command line args for the test.
+func (w *WritableLayer) Args() []string {
+	return w.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (w *WritableLayer) GetIdentifier() identifier.Identifier {
+	return identifier.WritableLayerIdentifier
+}
+
+// Timeout returns the timeout for the test.
+func (w *WritableLayer) Timeout() time.Duration {
+	return w.timeout
+}
+
+// Result returns the test result.
+func (w *WritableLayer) Result() int {
+	return w.result
+}
+
+// ReelFirst returns a step which expects the container stats within the test timeout.
+func (w *WritableLayer) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{ErrorOutputRegex, UsedBytesOutputRegex},
+		Timeout: w.timeout,
+	}
+}
+
+// ReelMatch parses the bytes used by the writable layer and sets the test result on match.  The result is left to
+// ERROR when crictl fails, or when it returns no stats, e.g. for a container which is gone.
+// Returns no step; the test is complete.
+func (w *WritableLayer) ReelMatch(pattern, _, match string) *reel.Step {
+	if pattern != UsedBytesOutputRegex {
+		return nil
+	}
+	matched := regexp.MustCompile(pattern).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	usedBytes, err := strconv.ParseUint(matched[1], 10, 64)
+	if err != nil {
+		return nil
+	}
+	w.usedBytes = usedBytes
+	w.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (w *WritableLayer) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no action is necessary on EOF.
+func (w *WritableLayer) ReelEOF() {
+}
+
+// GetUsedBytes returns the bytes used by the writable layer of the container.
+func (w *WritableLayer) GetUsedBytes() uint64 {
+	return w.usedBytes
+}
+
+// Command returns the command line printing the stats of the container of containerID, run from the debug pod of its
+// node.
+func Command(containerID string) []string {
+	return []string{"chroot", "/host", dependencies.CrictlBinaryName, "stats", "-o", "json", containerID}
+}
+
+// NewWritableLayer creates a new `WritableLayer` test which measures the disk usage of the writable layer of the
+// container of containerID.  See Command.
+func NewWritableLayer(timeout time.Duration, containerID string) *WritableLayer {
+	return &WritableLayer{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    Command(containerID),
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package writablelayer_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/writablelayer"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 5
+	testContainerID     = "cf9a1a0c5d5f"
+
+	statsOutput = `{
+  "stats": [
+    {
+      "attributes": {
+        "id": "cf9a1a0c5d5f",
+        "metadata": {
+          "name": "test"
+        }
+      },
+      "cpu": {
+        "usageCoreNanoSeconds": {
+          "value": "5320000000"
+        }
+      },
+      "memory": {
+        "workingSetBytes": {
+          "value": "20480000"
+        }
+      },
+      "writableLayer": {
+        "usedBytes": {
+          "value": "1048576"
+        },
+        "inodesUsed": {
+          "value": "12"
+        }
+      }
+    }
+  ]
+}
+`
+)
+
+func TestCommand(t *testing.T) {
+	assert.Equal(t, "chroot /host crictl stats -o json "+testContainerID,
+		strings.Join(writablelayer.Command(testContainerID), " "))
+}
+
+func TestWritableLayer_GetIdentifier(t *testing.T) {
+	assert.Equal(t, identifier.WritableLayerIdentifier, writablelayer.NewWritableLayer(testTimeoutDuration, testContainerID).GetIdentifier())
+}
+
+func TestWritableLayer_ReelFirst(t *testing.T) {
+	step := writablelayer.NewWritableLayer(testTimeoutDuration, testContainerID).ReelFirst()
+	assert.Equal(t, "", step.Execute)
+	assert.Equal(t, []string{writablelayer.ErrorOutputRegex, writablelayer.UsedBytesOutputRegex}, step.Expect)
+	assert.Equal(t, testTimeoutDuration, step.Timeout)
+}
+
+func TestWritableLayer_ReelMatch(t *testing.T) {
+	test := writablelayer.NewWritableLayer(testTimeoutDuration, testContainerID)
+	assert.Equal(t, tnf.ERROR, test.Result())
+	match := regexp.MustCompile(writablelayer.UsedBytesOutputRegex).FindString(statsOutput)
+	assert.Nil(t, test.ReelMatch(writablelayer.UsedBytesOutputRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, test.Result())
+	assert.Equal(t, uint64(1048576), test.GetUsedBytes())
+}
+
+func TestWritableLayer_ReelMatchError(t *testing.T) {
+	for _, output := range []string{
+		"sh: crictl: command not found",
+		`time="2021-11-02T10:30:00Z" level=fatal msg="container \"cf9a\" not found"`,
+		"FATA[0000] connect: connect endpoint 'unix:///var/run/crio/crio.sock', make sure you are running as root",
+	} {
+		assert.Regexp(t, writablelayer.ErrorOutputRegex, output)
+	}
+	test := writablelayer.NewWritableLayer(testTimeoutDuration, testContainerID)
+	assert.Nil(t, test.ReelMatch(writablelayer.ErrorOutputRegex, "", "sh: crictl: command not found"))
+	assert.Equal(t, tnf.ERROR, test.Result())
+	// no stats for a container which is gone.
+	assert.Nil(t, test.ReelMatch("", "", `{"stats": []}`))
+	assert.Equal(t, tnf.ERROR, test.Result())
+}
+
+func TestWritableLayer_ReelTimeout(t *testing.T) {
+	test := writablelayer.NewWritableLayer(testTimeoutDuration, testContainerID)
+	assert.Nil(t, test.ReelTimeout())
+	assert.Equal(t, tnf.ERROR, test.Result())
+}
@@ -62,6 +62,29 @@ const (
 	clusterVersionIdentifierURL           = "http://test-network-function.com/tests/clusterVersion"
 	crdStatusExistenceIdentifierURL       = "http://test-network-function.com/tests/crdStatusExistence"
 	daemonSetIdentifierURL                = "http://test-network-function.com/tests/daemonset"
+	tcpdumpIdentifierURL                  = "http://test-network-function.com/tests/tcpdump"
+	sctpIdentifierURL                     = "http://test-network-function.com/tests/sctp"
+	iperf3IdentifierURL                   = "http://test-network-function.com/tests/iperf3"
+	dnsIdentifierURL                      = "http://test-network-function.com/tests/dns"
+	writableLayerIdentifierURL            = "http://test-network-function.com/tests/writablelayer"
+	hostNamespacesIdentifierURL           = "http://test-network-function.com/tests/hostnamespaces"
+	securityContextIdentifierURL          = "http://test-network-function.com/tests/securitycontext"
+	processCountIdentifierURL             = "http://test-network-function.com/tests/processcount"
+	seLinuxIdentifierURL                  = "http://test-network-function.com/tests/selinux"
+	podReadinessIdentifierURL             = "http://test-network-function.com/tests/podreadiness"
+	automountTokenIdentifierURL           = "http://test-network-function.com/tests/automounttoken"
+	resourcesIdentifierURL                = "http://test-network-function.com/tests/resources"
+	probesIdentifierURL                   = "http://test-network-function.com/tests/probes"
+	conntrackIdentifierURL                = "http://test-network-function.com/tests/conntrack"
+	podSpreadingIdentifierURL             = "http://test-network-function.com/tests/podspreading"
+	terminationIdentifierURL              = "http://test-network-function.com/tests/termination"
+	imageLabelsIdentifierURL              = "http://test-network-function.com/tests/imagelabels"
+	ownerReferencesIdentifierURL          = "http://test-network-function.com/tests/ownerreferences"
+	containerImagesIdentifierURL          = "http://test-network-function.com/tests/containerimages"
+	subscriptionIdentifierURL             = "http://test-network-function.com/tests/subscription"
+	operatorGroupsIdentifierURL           = "http://test-network-function.com/tests/operatorgroups"
+	customResourceStatusIdentifierURL     = "http://test-network-function.com/tests/customresourcestatus"
+	clusterPlatformIdentifierURL          = "http://test-network-function.com/tests/clusterplatform"
 	versionOne                            = "v1.0.0"
 )
 
@@ -648,6 +671,304 @@ var Catalog = map[string]TestCatalogEntry{
 			dependencies.OcBinaryName,
 		},
 	},
+	tcpdumpIdentifierURL: {
+		Identifier:  TcpdumpIdentifier,
+		Description: "A generic test used to run a bounded packet capture on a pod or node interface and count the captured packets matching a filter.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.TcpdumpBinaryName,
+			dependencies.WcBinaryName,
+		},
+	},
+	sctpIdentifierURL: {
+		Identifier:  SctpIdentifier,
+		Description: "A generic test used to check the SCTP connectivity between two pods, with an ncat server echoing the probe sent by an ncat client.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.NcatBinaryName,
+			dependencies.EchoBinaryName,
+			dependencies.CatBinaryName,
+		},
+	},
+	iperf3IdentifierURL: {
+		Identifier:  Iperf3Identifier,
+		Description: "A generic test used to measure the throughput between two pods, with an iperf3 client sending TCP or UDP traffic to an iperf3 server.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.Iperf3BinaryName,
+			dependencies.EchoBinaryName,
+			dependencies.CatBinaryName,
+		},
+	},
+	dnsIdentifierURL: {
+		Identifier:  DNSIdentifier,
+		Description: "A generic test used to check the resolver configuration of a container, or resolve a name from inside it with getent.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.CatBinaryName,
+			dependencies.GetentBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
+	writableLayerIdentifierURL: {
+		Identifier:  WritableLayerIdentifier,
+		Description: "A generic test used to measure the disk usage of the writable layer of a container with crictl, from the debug pod of its node.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.CrictlBinaryName,
+		},
+	},
+	hostNamespacesIdentifierURL: {
+		Identifier:  HostNamespacesIdentifier,
+		Description: "A generic test used to check whether a pod shares the network, process or IPC namespace of its node.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	securityContextIdentifierURL: {
+		Identifier: SecurityContextIdentifier,
+		Description: "A generic test used to read the security context of the containers of a pod: whether they run " +
+			"privileged or as root, and the capabilities they add.",
+		Type: Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	processCountIdentifierURL: {
+		Identifier:  ProcessCountIdentifier,
+		Description: "A generic test used to count the processes and the zombie processes of a container, and read its pids limit.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.EchoBinaryName,
+			dependencies.LsBinaryName,
+			dependencies.WcBinaryName,
+			dependencies.GrepBinaryName,
+			dependencies.CatBinaryName,
+			dependencies.HeadBinaryName,
+		},
+	},
+	seLinuxIdentifierURL: {
+		Identifier:  SELinuxIdentifier,
+		Description: "A generic test used to read the SELinux mode of a node and the SELinux label of a container running on it.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.EchoBinaryName,
+			dependencies.CrictlBinaryName,
+			dependencies.GetenforceBinaryName,
+			dependencies.TrBinaryName,
+		},
+	},
+	podReadinessIdentifierURL: {
+		Identifier:  PodReadinessIdentifier,
+		Description: "A generic test used to list the pods of a namespace with their UID and readiness.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	automountTokenIdentifierURL: {
+		Identifier:  AutomountTokenIdentifier,
+		Description: "A generic test used to check whether the service account token is mounted in a pod.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.EchoBinaryName,
+			dependencies.OcBinaryName,
+		},
+	},
+	resourcesIdentifierURL: {
+		Identifier: ResourcesIdentifier,
+		Description: "A generic test used to read the CPU and memory requests and limits of the containers of a pod, " +
+			"and the QoS class of the pod.",
+		Type: Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	probesIdentifierURL: {
+		Identifier:  ProbesIdentifier,
+		Description: "A generic test used to read the liveness and readiness probes of the containers of a workload.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	conntrackIdentifierURL: {
+		Identifier:  ConntrackIdentifier,
+		Description: "A generic test used to inventory the connections tracked on a node during an observation window with conntrack, from the debug pod of the node.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.ConntrackBinaryName,
+			dependencies.TimeoutBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
+	podSpreadingIdentifierURL: {
+		Identifier:  PodSpreadingIdentifier,
+		Description: "A generic test used to read the podAntiAffinity and topologySpreadConstraints of the pods of a workload.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	terminationIdentifierURL: {
+		Identifier:  TerminationIdentifier,
+		Description: "A generic test used to read the terminationGracePeriodSeconds of a pod, or of the pod template of a workload, whether it is set or defaulted, and the preStop hooks of its containers.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	imageLabelsIdentifierURL: {
+		Identifier:  ImageLabelsIdentifier,
+		Description: "A generic test used to read the labels of the image of a container, from the debug pod of its node.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.EchoBinaryName,
+			dependencies.CrictlBinaryName,
+			dependencies.PodmanBinaryName,
+		},
+	},
+	ownerReferencesIdentifierURL: {
+		Identifier:  OwnerReferencesIdentifier,
+		Description: "A generic test used to read the ownerReferences of a resource, e.g. the ReplicaSet of a pod.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	containerImagesIdentifierURL: {
+		Identifier:  ContainerImagesIdentifier,
+		Description: "A generic test used to read the image reference and the imagePullPolicy of each container of a pod.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	subscriptionIdentifierURL: {
+		Identifier:  SubscriptionIdentifier,
+		Description: "A generic test used to read the state, the channel and the install plan of an OLM subscription.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	operatorGroupsIdentifierURL: {
+		Identifier:  OperatorGroupsIdentifier,
+		Description: "A generic test used to read the target namespaces of the OLM operator groups of a namespace.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	customResourceStatusIdentifierURL: {
+		Identifier:  CustomResourceStatusIdentifier,
+		Description: "A generic test used to read whether the custom resources of a CRD have a populated status.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	clusterPlatformIdentifierURL: {
+		Identifier:  ClusterPlatformIdentifier,
+		Description: "A generic test used to read the infrastructure platform and the network type of the cluster.",
+		Type:        Informative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
 }
 
 // CommandIdentifier is  the Identifier used to represent the generic command test case.
@@ -907,3 +1228,141 @@ var DaemonSetIdentifier = Identifier{
 	URL:             daemonSetIdentifierURL,
 	SemanticVersion: versionOne,
 }
+
+// TcpdumpIdentifier is the Identifier used to represent the bounded packet capture test.
+var TcpdumpIdentifier = Identifier{
+	URL:             tcpdumpIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// SctpIdentifier is the Identifier used to represent the SCTP connectivity test.
+var SctpIdentifier = Identifier{
+	URL:             sctpIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// Iperf3Identifier is the Identifier used to represent the iperf3 throughput measurement.
+var Iperf3Identifier = Identifier{
+	URL:             iperf3IdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// DNSIdentifier is the Identifier used to represent the DNS resolution test.
+var DNSIdentifier = Identifier{
+	URL:             dnsIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// WritableLayerIdentifier is the Identifier used to represent the writable layer disk usage measurement.
+var WritableLayerIdentifier = Identifier{
+	URL:             writableLayerIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// HostNamespacesIdentifier is the Identifier used to represent the host namespaces test.
+var HostNamespacesIdentifier = Identifier{
+	URL:             hostNamespacesIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// SecurityContextIdentifier is the Identifier used to represent the security context test.
+var SecurityContextIdentifier = Identifier{
+	URL:             securityContextIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ProcessCountIdentifier is the Identifier used to represent the process count test.
+var ProcessCountIdentifier = Identifier{
+	URL:             processCountIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// SELinuxIdentifier is the Identifier used to represent the SELinux test.
+var SELinuxIdentifier = Identifier{
+	URL:             seLinuxIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// PodReadinessIdentifier is the Identifier used to represent the pod readiness test.
+var PodReadinessIdentifier = Identifier{
+	URL:             podReadinessIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// AutomountTokenIdentifier is the Identifier used to represent the automounted service account token test.
+var AutomountTokenIdentifier = Identifier{
+	URL:             automountTokenIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ResourcesIdentifier is the Identifier used to represent the container resources test.
+var ResourcesIdentifier = Identifier{
+	URL:             resourcesIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ProbesIdentifier is the Identifier used to represent the container probes test.
+var ProbesIdentifier = Identifier{
+	URL:             probesIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ConntrackIdentifier is the Identifier used to represent the node connection tracking inventory.
+var ConntrackIdentifier = Identifier{
+	URL:             conntrackIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// PodSpreadingIdentifier is the Identifier used to represent the pod spreading rules test.
+var PodSpreadingIdentifier = Identifier{
+	URL:             podSpreadingIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// TerminationIdentifier is the Identifier used to represent the pod termination settings test.
+var TerminationIdentifier = Identifier{
+	URL:             terminationIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ImageLabelsIdentifier is the Identifier used to represent the container image labels test.
+var ImageLabelsIdentifier = Identifier{
+	URL:             imageLabelsIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// OwnerReferencesIdentifier is the Identifier used to represent the resource owners test.
+var OwnerReferencesIdentifier = Identifier{
+	URL:             ownerReferencesIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ContainerImagesIdentifier is the Identifier used to represent the container images test.
+var ContainerImagesIdentifier = Identifier{
+	URL:             containerImagesIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// SubscriptionIdentifier is the Identifier used to represent the OLM subscription test.
+var SubscriptionIdentifier = Identifier{
+	URL:             subscriptionIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// OperatorGroupsIdentifier is the Identifier used to represent the OLM operator groups test.
+var OperatorGroupsIdentifier = Identifier{
+	URL:             operatorGroupsIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// CustomResourceStatusIdentifier is the Identifier used to represent the custom resource status test.
+var CustomResourceStatusIdentifier = Identifier{
+	URL:             customResourceStatusIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ClusterPlatformIdentifier is the Identifier used to represent the cluster platform test.
+var ClusterPlatformIdentifier = Identifier{
+	URL:             clusterPlatformIdentifierURL,
+	SemanticVersion: versionOne,
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tnf
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// ErrorCategory is the category of an infrastructure error, i.e. an error preventing a test from executing as opposed
+// to a test failure.
+type ErrorCategory string
+
+const (
+	// ExpecterTimeout is the category of the tests which timed out waiting for the output of their command.
+	ExpecterTimeout ErrorCategory = "ExpecterTimeout"
+	// PermissionDenied is the category of the tests whose command was not allowed, e.g. by the file permissions or RBAC.
+	PermissionDenied ErrorCategory = "PermissionDenied"
+	// TargetGone is the category of the tests whose pod, container or node disappeared, or whose session was closed.
+	TargetGone ErrorCategory = "TargetGone"
+	// ToolMissing is the category of the tests whose command is not installed on the target.
+	ToolMissing ErrorCategory = "ToolMissing"
+)
+
+// errorCategoryRegexes match the outputs and errors of each category, they are tried in order so that e.g. a pod not
+// found is not mistaken for a missing command.
+var errorCategoryRegexes = []struct {
+	category ErrorCategory
+	regex    *regexp.Regexp
+}{
+	{TargetGone, regexp.MustCompile(`(?i)\(NotFound\)|no such container|container not found|container .* is not running|` +
+		`process not running|unable to upgrade connection`)},
+	{PermissionDenied, regexp.MustCompile(`(?i)permission denied|operation not permitted|\(Forbidden\)|\(Unauthorized\)`)},
+	{ToolMissing, regexp.MustCompile(`(?i)command not found|executable file not found|: not found`)},
+}
+
+// InfraError is an error preventing a test from executing, recorded in the claim so that automation can tell the tests
+// which could not execute from the tests which failed.
+type InfraError struct {
+	// Category is the category of the error.
+	Category ErrorCategory `json:"category"`
+	// Test is the URL of the identifier of the handler which could not execute.
+	Test string `json:"test"`
+	// Message is the output or the error which was categorized.
+	Message string `json:"message,omitempty"`
+}
+
+// Error returns the description of the infrastructure error.
+func (e *InfraError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s: %s", e.Category, e.Test)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Category, e.Test, e.Message)
+}
+
+// ClassifyOutput returns the category of the infrastructure error reported by output, the output of a command or an
+// error message, and false when output reports no known infrastructure error.
+func ClassifyOutput(output string) (ErrorCategory, bool) {
+	for _, c := range errorCategoryRegexes {
+		if c.regex.MatchString(output) {
+			return c.category, true
+		}
+	}
+	return "", false
+}
+
+// infraErrorHandler is called with the infrastructure errors of the tests, see SetInfraErrorHandler.
+var (
+	infraErrorHandler     func(*InfraError)
+	infraErrorHandlerLock sync.RWMutex
+)
+
+// SetInfraErrorHandler sets the function called with the infrastructure error of each test which could not execute,
+// e.g. to record it in the claim.  The handler can be called concurrently by the tests run in parallel.
+func SetInfraErrorHandler(handler func(*InfraError)) {
+	infraErrorHandlerLock.Lock()
+	defer infraErrorHandlerLock.Unlock()
+	infraErrorHandler = handler
+}
+
+// reportInfraError calls the handler set by SetInfraErrorHandler, if any.
+func reportInfraError(infraErr *InfraError) {
+	infraErrorHandlerLock.RLock()
+	defer infraErrorHandlerLock.RUnlock()
+	if infraErrorHandler != nil {
+		infraErrorHandler(infraErr)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package interactive
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// sshCommandSeparator ends the options of ssh before the command run on the remote host.
+	sshCommandSeparator = "--"
+	// jumphostSeparator separates the hosts of a chain of jumps.
+	jumphostSeparator = ","
+	// defaultRemoteKubeconfig is the kubeconfig of oc and kubectl on the bastion when the cluster sets none.
+	defaultRemoteKubeconfig = `${KUBECONFIG:-$HOME/.kube/config}`
+	// remoteContextFile is the kubeconfig file selecting the context of the cluster on the bastion, listed first in
+	// $KUBECONFIG like the one of kubeconfig.Use, named after the user and the context.
+	remoteContextFile = `"${TMPDIR:-/tmp}/tnf-kubeconfig-context-$(id -u)-%s"`
+	// remoteContextCommand writes the remote context file:  the context is its argument.
+	remoteContextCommand = `umask 077; printf 'apiVersion: v1\nkind: Config\ncurrent-context: %%s\n' %s > %s; `
+)
+
+// shellSafeArg matches the arguments passed as is to the remote shell, the others are single-quoted.
+var shellSafeArg = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// unsafeContextChars matches the characters of a context which cannot be part of the name of the remote context file.
+var unsafeContextChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+var (
+	// bastion is the jump host the sessions are spawned through, none when nil.
+	bastion     *Bastion
+	bastionLock sync.Mutex
+)
+
+// Bastion is a jump host the sessions are spawned through, with the kubeconfig and the context of the cluster on it.
+type Bastion struct {
+	SSHTarget
+	// Kubeconfig is the kubeconfig of the cluster on the bastion, the one of oc and kubectl on the bastion when empty.
+	Kubeconfig string
+	// Context is the kubeconfig context of the cluster, the current context of the kubeconfig when empty.
+	Context string
+}
+
+// SetBastion makes the sessions spawned afterwards, and the commands of Command, go through a bastion host, for the
+// clusters only reachable from a jump box: the SSH sessions jump through it, as with "ssh -J", and the other sessions,
+// e.g. oc, kubectl or the shells running them, are run on it over SSH with the kubeconfig and the context of the
+// bastion.  nil spawns the sessions locally again.
+func SetBastion(target *Bastion) {
+	bastionLock.Lock()
+	defer bastionLock.Unlock()
+	bastion = target
+}
+
+// Command returns the command running name with args, e.g. oc, locally or through the bastion, if any, like the
+// spawned sessions.
+func Command(name string, args ...string) *exec.Cmd {
+	name, args = throughBastion(name, args)
+	return exec.Command(name, args...)
+}
+
+// getBastion returns the bastion the sessions are spawned through, nil when none.
+func getBastion() *Bastion {
+	bastionLock.Lock()
+	defer bastionLock.Unlock()
+	return bastion
+}
+
+// throughBastion returns the command and the arguments spawning a session through the bastion, if any, unchanged
+// otherwise.
+func throughBastion(command string, args []string) (string, []string) {
+	target := getBastion()
+	if target == nil {
+		return command, args
+	}
+	if command == sshCommand {
+		return command, jumpThrough(target.jumps(), args)
+	}
+	remoteCommand := make([]string, 0, len(args)+1)
+	for _, arg := range append([]string{command}, args...) {
+		remoteCommand = append(remoteCommand, shellQuote(arg))
+	}
+	return sshCommand, append(target.args(), sshCommandSeparator,
+		target.kubeconfigPrefix()+strings.Join(remoteCommand, " "))
+}
+
+// kubeconfigPrefix returns the shell commands selecting the kubeconfig and the context of the cluster on the bastion,
+// run before the remote command, empty when the cluster sets neither.
+func (b *Bastion) kubeconfigPrefix() string {
+	kubeconfig := defaultRemoteKubeconfig
+	if b.Kubeconfig != "" {
+		kubeconfig = shellQuote(b.Kubeconfig)
+	}
+	if b.Context == "" {
+		if b.Kubeconfig == "" {
+			return ""
+		}
+		return "export KUBECONFIG=" + kubeconfig + "; "
+	}
+	contextFile := fmt.Sprintf(remoteContextFile, unsafeContextChars.ReplaceAllString(b.Context, "_"))
+	return fmt.Sprintf(remoteContextCommand, shellQuote(b.Context), contextFile) +
+		"export KUBECONFIG=" + contextFile + ":" + kubeconfig + "; "
+}
+
+// jumpThrough prepends the jump through the bastion to the jumps of the ssh arguments.
+func jumpThrough(bastionJumps string, args []string) []string {
+	jumpArgs := make([]string, len(args))
+	copy(jumpArgs, args)
+	for i := 0; i < len(jumpArgs)-1; i++ {
+		if jumpArgs[i] == sshJumpArg {
+			jumpArgs[i+1] = bastionJumps + jumphostSeparator + jumpArgs[i+1]
+			return jumpArgs
+		}
+	}
+	return append([]string{sshJumpArg, bastionJumps}, jumpArgs...)
+}
+
+// shellQuote quotes an argument of a command run by the remote shell of an SSH session.
+func shellQuote(arg string) string {
+	if shellSafeArg.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
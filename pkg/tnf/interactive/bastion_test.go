@@ -0,0 +1,128 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package interactive_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	mock_interactive "github.com/test-network-function/test-network-function/pkg/tnf/interactive/mocks"
+)
+
+var errBastionStart = errors.New("the command does not start")
+
+func TestSetBastion(t *testing.T) {
+	testCases := map[string]struct {
+		bastion         *interactive.Bastion
+		command         string
+		args            []string
+		expectedCommand string
+		expectedArgs    []string
+	}{
+		"no_bastion": {
+			command:         "oc",
+			args:            []string{"rsh", "-n", "tnf", "-c", "test", "test-0"},
+			expectedCommand: "oc",
+			expectedArgs:    []string{"rsh", "-n", "tnf", "-c", "test", "test-0"},
+		},
+		"oc": {
+			bastion:         &interactive.Bastion{SSHTarget: interactive.SSHTarget{User: "admin", Host: "bastion.example.com"}},
+			command:         "oc",
+			args:            []string{"rsh", "-n", "tnf", "-c", "test", "test-0"},
+			expectedCommand: "ssh",
+			expectedArgs: []string{"-o", "BatchMode=yes", "admin@bastion.example.com", "--",
+				"oc rsh -n tnf -c test test-0"},
+		},
+		"quoted_args": {
+			bastion: &interactive.Bastion{SSHTarget: interactive.SSHTarget{Host: "bastion.example.com", Port: 2222,
+				KeyFile: "/keys/id_rsa"}},
+			command:         "kubectl",
+			args:            []string{"exec", "-it", "it's", "--", "sh"},
+			expectedCommand: "ssh",
+			expectedArgs: []string{"-o", "BatchMode=yes", "-p", "2222", "-i", "/keys/id_rsa", "bastion.example.com", "--",
+				`kubectl exec -it 'it'\''s' -- sh`},
+		},
+		"kubeconfig": {
+			bastion: &interactive.Bastion{SSHTarget: interactive.SSHTarget{Host: "bastion.example.com"},
+				Kubeconfig: "/kube/lab"},
+			command:         "oc",
+			args:            []string{"get", "pods"},
+			expectedCommand: "ssh",
+			expectedArgs: []string{"-o", "BatchMode=yes", "bastion.example.com", "--",
+				"export KUBECONFIG=/kube/lab; oc get pods"},
+		},
+		"context": {
+			bastion: &interactive.Bastion{SSHTarget: interactive.SSHTarget{Host: "bastion.example.com"},
+				Context: "edge/admin"},
+			command:         "sh",
+			args:            []string{"-i"},
+			expectedCommand: "ssh",
+			expectedArgs: []string{"-o", "BatchMode=yes", "bastion.example.com", "--",
+				`umask 077; printf 'apiVersion: v1\nkind: Config\ncurrent-context: %s\n' edge/admin > ` +
+					`"${TMPDIR:-/tmp}/tnf-kubeconfig-context-$(id -u)-edge_admin"; ` +
+					`export KUBECONFIG="${TMPDIR:-/tmp}/tnf-kubeconfig-context-$(id -u)-edge_admin":` +
+					`${KUBECONFIG:-$HOME/.kube/config}; sh -i`},
+		},
+		"ssh": {
+			bastion: &interactive.Bastion{SSHTarget: interactive.SSHTarget{User: "admin", Host: "bastion.example.com",
+				Port: 2222}, Kubeconfig: "/kube/lab", Context: "lab-admin"},
+			command:         "ssh",
+			args:            []string{"-o", "BatchMode=yes", "core@10.0.0.10", "sudo -n sh"},
+			expectedCommand: "ssh",
+			expectedArgs: []string{"-J", "admin@bastion.example.com:2222", "-o", "BatchMode=yes", "core@10.0.0.10",
+				"sudo -n sh"},
+		},
+		"ssh_jumphost": {
+			bastion: &interactive.Bastion{SSHTarget: interactive.SSHTarget{User: "admin", Host: "bastion.example.com",
+				Jumphost: "gateway.example.com"}},
+			command:         "ssh",
+			args:            []string{"-o", "BatchMode=yes", "-J", "lab-jump", "core@10.0.0.10", "sudo -n sh"},
+			expectedCommand: "ssh",
+			expectedArgs: []string{"-o", "BatchMode=yes", "-J", "gateway.example.com,admin@bastion.example.com,lab-jump",
+				"core@10.0.0.10", "sudo -n sh"},
+		},
+	}
+	defer interactive.SetBastion(nil)
+	for name, testCase := range testCases {
+		ctrl := gomock.NewController(t)
+		mockSpawnFunc := mock_interactive.NewMockSpawnFunc(ctrl)
+		var sFunc interactive.SpawnFunc = mockSpawnFunc
+		interactive.SetSpawnFunc(&sFunc)
+		mockSpawnFunc.EXPECT().Command(testCase.expectedCommand, testCase.expectedArgs).Return(&sFunc)
+		mockSpawnFunc.EXPECT().StdinPipe().Return(defaultStdin, nil)
+		mockSpawnFunc.EXPECT().StdoutPipe().Return(defaultStdout, nil)
+		mockSpawnFunc.EXPECT().StderrPipe().Return(defaultStderr, nil)
+		mockSpawnFunc.EXPECT().Start().Return(errBastionStart)
+
+		interactive.SetBastion(testCase.bastion)
+		_, err := interactive.NewGoExpectSpawner().Spawn(testCase.command, testCase.args, testTimeoutDuration)
+		assert.Equal(t, errBastionStart, err, name)
+		ctrl.Finish()
+	}
+}
+
+func TestCommand(t *testing.T) {
+	defer interactive.SetBastion(nil)
+	assert.Equal(t, []string{"oc", "get", "pods"}, interactive.Command("oc", "get", "pods").Args)
+	interactive.SetBastion(&interactive.Bastion{SSHTarget: interactive.SSHTarget{Host: "bastion.example.com"},
+		Kubeconfig: "/kube/lab"})
+	assert.Equal(t, []string{"ssh", "-o", "BatchMode=yes", "bastion.example.com", "--",
+		"export KUBECONFIG=/kube/lab; oc get pods"}, interactive.Command("oc", "get", "pods").Args)
+}
@@ -21,6 +21,7 @@ import (
 
 	expect "github.com/google/goexpect"
 	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
 )
 
 const (
@@ -28,6 +29,12 @@ const (
 	ocContainerArg = "-c"
 	ocRsh          = "rsh"
 	ocNamespaceArg = "-n"
+
+	// kubectl has no rsh, the shell of the container is run by exec.
+	kubectlCommand = "kubectl"
+	kubectlExec    = "exec"
+	kubectlStdin   = "-it"
+	kubectlShell   = "sh"
 )
 
 // Oc provides an OpenShift Client designed to wrap the "oc" CLI.
@@ -57,8 +64,14 @@ type Oc struct {
 
 // SpawnOc creates an OpenShift Client subprocess, spawning the appropriate underlying PTY.
 func SpawnOc(spawner *Spawner, pod, container, namespace string, timeout time.Duration, opts ...Option) (*Oc, <-chan error, error) {
+	command := ocCommand
 	ocArgs := []string{ocRsh, ocNamespaceArg, namespace, ocContainerArg, container, pod}
-	context, err := (*spawner).Spawn(ocCommand, ocArgs, timeout, opts...)
+	if occompat.UsesKubectl() {
+		command = kubectlCommand
+		ocArgs = []string{kubectlExec, kubectlStdin, ocNamespaceArg, namespace, ocContainerArg, container, pod, "--",
+			kubectlShell}
+	}
+	context, err := (*spawner).Spawn(command, ocArgs, timeout, opts...)
 	if err != nil {
 		return nil, context.GetErrorChannel(), err
 	}
@@ -0,0 +1,106 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package interactive
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ContextPool is a fixed size pool of interactive sessions, letting workers run tests concurrently, each one on its
+// own session.  Sessions are not safe for concurrent use, a session is owned by a single worker between Get and Put.
+type ContextPool struct {
+	contexts chan *Context
+	all      []*Context
+}
+
+// NewContextPool spawns size sessions using spawn.  In case of error, the sessions already spawned are closed.  With a
+// nil spawn, no session is spawned and the pool only bounds the parallelism of ForEach, whose calls get a nil context.
+func NewContextPool(size int, spawn func() (*Context, error)) (*ContextPool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("invalid session pool size %d", size)
+	}
+	pool := &ContextPool{contexts: make(chan *Context, size)}
+	for i := 0; i < size; i++ {
+		var context *Context
+		if spawn != nil {
+			var err error
+			context, err = spawn()
+			if err != nil {
+				pool.Close()
+				return nil, fmt.Errorf("cannot spawn session %d of the pool: %w", i+1, err)
+			}
+		}
+		pool.all = append(pool.all, context)
+		pool.contexts <- context
+	}
+	return pool, nil
+}
+
+// Size returns the number of sessions of the pool.
+func (p *ContextPool) Size() int {
+	return len(p.all)
+}
+
+// Get takes a session out of the pool, waiting for one to be available.
+func (p *ContextPool) Get() *Context {
+	return <-p.contexts
+}
+
+// Put returns a session taken with Get to the pool.
+func (p *ContextPool) Put(context *Context) {
+	p.contexts <- context
+}
+
+// ForEach calls fn for each index in [0, n), running up to Size() calls concurrently, each one with a session of the
+// pool.  It waits for all the calls to complete, and returns their errors indexed like the calls.  A panicking call
+// is reported as an error.
+func (p *ContextPool) ForEach(n int, fn func(i int, context *Context) error) []error {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		context := p.Get()
+		wg.Add(1)
+		go func(i int, context *Context) {
+			defer wg.Done()
+			defer p.Put(context)
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = fmt.Errorf("panic: %v", r)
+				}
+			}()
+			errs[i] = fn(i, context)
+		}(i, context)
+	}
+	wg.Wait()
+	return errs
+}
+
+// Close closes the expecters of all the sessions of the pool.  The pool must not be used afterwards.
+func (p *ContextPool) Close() {
+	for _, context := range p.all {
+		if context == nil || context.GetExpecter() == nil {
+			continue
+		}
+		if err := (*context.GetExpecter()).Close(); err != nil {
+			log.Errorf("error closing a pooled session: %v", err)
+		}
+	}
+	p.all = nil
+}
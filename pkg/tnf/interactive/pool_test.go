@@ -0,0 +1,128 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package interactive_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	expect "github.com/google/goexpect"
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	mock_interactive "github.com/test-network-function/test-network-function/pkg/tnf/interactive/mocks"
+)
+
+func spawnEmptyContext() (*interactive.Context, error) {
+	return &interactive.Context{}, nil
+}
+
+func TestNewContextPool(t *testing.T) {
+	pool, err := interactive.NewContextPool(3, spawnEmptyContext)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, pool.Size())
+
+	_, err = interactive.NewContextPool(0, spawnEmptyContext)
+	assert.NotNil(t, err)
+}
+
+func TestNewContextPoolSpawnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the session spawned before the failure is closed.
+	mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+	mockExpecter.EXPECT().Close().Return(nil)
+	var expecter expect.Expecter = mockExpecter
+	spawned := 0
+	_, err := interactive.NewContextPool(2, func() (*interactive.Context, error) {
+		spawned++
+		if spawned == 2 {
+			return nil, errors.New("spawn error")
+		}
+		return interactive.NewContext(&expecter, nil), nil
+	})
+	assert.NotNil(t, err)
+}
+
+func TestContextPoolForEach(t *testing.T) {
+	const size = 2
+	pool, err := interactive.NewContextPool(size, spawnEmptyContext)
+	assert.Nil(t, err)
+	defer pool.Close()
+
+	var lock sync.Mutex
+	running, maxRunning := 0, 0
+	used := map[*interactive.Context]bool{}
+	errs := pool.ForEach(5, func(i int, context *interactive.Context) error {
+		lock.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		used[context] = true
+		lock.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		lock.Lock()
+		running--
+		lock.Unlock()
+		switch i {
+		case 1:
+			return errors.New("item 1 failed")
+		case 3:
+			panic("item 3 panicked")
+		}
+		return nil
+	})
+
+	assert.Len(t, errs, 5)
+	assert.Nil(t, errs[0])
+	assert.EqualError(t, errs[1], "item 1 failed")
+	assert.EqualError(t, errs[3], "panic: item 3 panicked")
+	assert.Nil(t, errs[4])
+	assert.Equal(t, size, maxRunning)
+	assert.Len(t, used, size)
+}
+
+func TestContextPoolWithoutSpawn(t *testing.T) {
+	const size = 2
+	pool, err := interactive.NewContextPool(size, nil)
+	assert.Nil(t, err)
+	defer pool.Close()
+
+	var lock sync.Mutex
+	running, maxRunning := 0, 0
+	errs := pool.ForEach(4, func(i int, context *interactive.Context) error {
+		assert.Nil(t, context)
+		lock.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		lock.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return nil
+	})
+
+	assert.Equal(t, []error{nil, nil, nil, nil}, errs)
+	assert.Equal(t, size, maxRunning)
+}
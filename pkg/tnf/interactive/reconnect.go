@@ -0,0 +1,84 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package interactive
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+// NewReconnectingContext spawns a session with spawn, and lazily re-spawns it with spawn once it ended, e.g. when the
+// expecter hit an EOF mid-run:  GetExpecter then returns the expecter of a new session, so that the tests using the
+// context after a dropped session do not all fail in turn.  The tests which were running in the dropped session still
+// fail, as their expecter is not replaced.
+func NewReconnectingContext(spawn func() (*Context, error)) (*Context, error) {
+	context, err := spawn()
+	if err != nil {
+		return nil, err
+	}
+	context.respawn = spawn
+	context.watch()
+	return context, nil
+}
+
+// watch monitors the error channel of the session, closing ended once the session ended.  The error reported by the
+// session is forwarded through a new error channel which is closed afterwards, so that every reader, e.g. the reels of
+// the tests and the health checks of the session pool, observes the end of the session.
+func (c *Context) watch() {
+	errorChannel := c.errorChannel
+	forwarded := make(chan error, 1)
+	ended := make(chan struct{})
+	c.errorChannel = forwarded
+	c.ended = ended
+	if errorChannel == nil {
+		return
+	}
+	go func() {
+		err := <-errorChannel
+		log.Debugf("session ended: %v", err)
+		forwarded <- err
+		close(forwarded)
+		close(ended)
+	}()
+}
+
+// hasEnded returns true once the session of a reconnecting context ended.  The caller holds the lock.
+func (c *Context) hasEnded() bool {
+	select {
+	case <-c.ended:
+		return true
+	default:
+		return false
+	}
+}
+
+// reconnect replaces the ended session with a new one.  The ended session is kept when the new one cannot be spawned,
+// so that its users get the error of the ended session.  The caller holds the lock.
+func (c *Context) reconnect() {
+	log.Warn("re-spawning an ended session")
+	context, err := c.respawn()
+	if err != nil {
+		log.Errorf("cannot re-spawn an ended session: %v", err)
+		return
+	}
+	if closeErr := (*c.expecter).Close(); closeErr != nil {
+		log.Debugf("error closing an ended session: %v", closeErr)
+	}
+	context.watch()
+	c.expecter = context.expecter
+	c.errorChannel = context.errorChannel
+	c.ended = context.ended
+}
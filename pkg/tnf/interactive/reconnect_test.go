@@ -0,0 +1,98 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package interactive_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	expect "github.com/google/goexpect"
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	mock_interactive "github.com/test-network-function/test-network-function/pkg/tnf/interactive/mocks"
+)
+
+const reconnectTimeout = time.Second
+
+func TestReconnectingContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var errorChannels []chan error
+	var expecters []*expect.Expecter
+	spawn := func() (*interactive.Context, error) {
+		mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+		mockExpecter.EXPECT().Close().Return(nil).MaxTimes(1)
+		var expecter expect.Expecter = mockExpecter
+		errorChannel := make(chan error, 1)
+		errorChannels = append(errorChannels, errorChannel)
+		expecters = append(expecters, &expecter)
+		return interactive.NewContext(&expecter, errorChannel), nil
+	}
+	context, err := interactive.NewReconnectingContext(spawn)
+	assert.Nil(t, err)
+	assert.Equal(t, expecters[0], context.GetExpecter())
+
+	// the session is re-spawned once it ended, and the error of the ended session is reported once.
+	errorChannel := context.GetErrorChannel()
+	errorChannels[0] <- io.EOF
+	assert.Equal(t, io.EOF, <-errorChannel)
+	assert.Eventually(t, func() bool {
+		return context.GetExpecter() != expecters[0]
+	}, reconnectTimeout, time.Millisecond)
+	assert.Len(t, expecters, 2)
+	assert.Equal(t, expecters[1], context.GetExpecter())
+	assert.NotEqual(t, errorChannel, context.GetErrorChannel())
+
+	// the live session is not re-spawned.
+	assert.Equal(t, expecters[1], context.GetExpecter())
+	assert.Len(t, expecters, 2)
+}
+
+func TestReconnectingContextSpawnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errSpawn := errors.New("cannot spawn")
+	_, err := interactive.NewReconnectingContext(func() (*interactive.Context, error) {
+		return nil, errSpawn
+	})
+	assert.Equal(t, errSpawn, err)
+
+	// the ended session is kept when it cannot be re-spawned.
+	mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+	var expecter expect.Expecter = mockExpecter
+	errorChannel := make(chan error, 1)
+	spawned := 0
+	context, err := interactive.NewReconnectingContext(func() (*interactive.Context, error) {
+		spawned++
+		if spawned > 1 {
+			return nil, errSpawn
+		}
+		return interactive.NewContext(&expecter, errorChannel), nil
+	})
+	assert.Nil(t, err)
+	errorChannel <- io.EOF
+	<-context.GetErrorChannel()
+	assert.Eventually(t, func() bool {
+		return context.GetExpecter() == &expecter && spawned > 1
+	}, reconnectTimeout, time.Millisecond)
+	assert.False(t, interactive.IsHealthy(context, reconnectTimeout))
+}
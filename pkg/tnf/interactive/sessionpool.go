@@ -0,0 +1,203 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package interactive
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// keepAliveProbe is the command probing a session.  The shell expands the arithmetic expression, so the probe output
+	// matches keepAliveRegex while the echo of the command itself does not.
+	keepAliveProbe = "echo TNF_KEEPALIVE_$((40+2))\n"
+
+	// DefaultMaxIdleSessions is the number of sessions kept open between the commands.
+	DefaultMaxIdleSessions = 2
+	// DefaultSessionKeepAlivePeriod is the period of the keep-alive probes of the idle sessions.
+	DefaultSessionKeepAlivePeriod = 30 * time.Second
+	// DefaultSessionProbeTimeout is how long a session has to answer a keep-alive probe.
+	DefaultSessionProbeTimeout = 5 * time.Second
+)
+
+var keepAliveRegex = regexp.MustCompile(`TNF_KEEPALIVE_42\r?\n`)
+
+// SessionPool is a managed pool of reusable sessions, saving the cost of spawning a session per command.  Sessions are
+// health checked when taken out of the pool, and the idle ones are periodically probed to keep them alive.  Dead
+// sessions, e.g. after an EOF, are closed and transparently re-spawned.
+type SessionPool struct {
+	spawn           func() (*Context, error)
+	maxIdle         int
+	keepAlivePeriod time.Duration
+	probeTimeout    time.Duration
+
+	lock          sync.Mutex
+	idle          []*Context
+	closed        bool
+	stop          chan struct{}
+	keepAliveOnce sync.Once
+}
+
+// NewSessionPool creates a pool of sessions created by spawn, keeping up to maxIdle idle sessions which are probed
+// every keepAlivePeriod.  A session not answering a probe within probeTimeout is considered dead.  No session is
+// spawned until the first Get.
+func NewSessionPool(spawn func() (*Context, error), maxIdle int, keepAlivePeriod, probeTimeout time.Duration) *SessionPool {
+	return &SessionPool{
+		spawn:           spawn,
+		maxIdle:         maxIdle,
+		keepAlivePeriod: keepAlivePeriod,
+		probeTimeout:    probeTimeout,
+		stop:            make(chan struct{}),
+	}
+}
+
+// Get returns a healthy idle session, or spawns a new one.  The session must be returned with Put or Discard.
+func (p *SessionPool) Get() (*Context, error) {
+	p.keepAliveOnce.Do(func() {
+		if p.keepAlivePeriod > 0 {
+			go p.keepAlive()
+		}
+	})
+	for context := p.takeIdle(); context != nil; context = p.takeIdle() {
+		if IsHealthy(context, p.probeTimeout) {
+			return context, nil
+		}
+		log.Debug("closing a dead pooled session")
+		closeSession(context)
+	}
+	return p.spawn()
+}
+
+// Put returns a session to the pool.  The session is closed when it reported an error, or when the pool is full or
+// closed.
+func (p *SessionPool) Put(context *Context) {
+	if context == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed || len(p.idle) >= p.maxIdle || hasFailed(context) {
+		closeSession(context)
+		return
+	}
+	p.idle = append(p.idle, context)
+}
+
+// Discard closes a session taken out of the pool instead of returning it, e.g. when a command timed out and its
+// output may still be pending.
+func (p *SessionPool) Discard(context *Context) {
+	if context != nil {
+		closeSession(context)
+	}
+}
+
+// Close stops the keep-alive probes and closes the idle sessions.  Sessions returned afterwards are closed.
+func (p *SessionPool) Close() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	close(p.stop)
+	for _, context := range p.idle {
+		closeSession(context)
+	}
+	p.idle = nil
+}
+
+// takeIdle takes the most recently used idle session out of the pool, nil if there is none.
+func (p *SessionPool) takeIdle() *Context {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.idle) == 0 {
+		return nil
+	}
+	context := p.idle[len(p.idle)-1]
+	p.idle = p.idle[:len(p.idle)-1]
+	return context
+}
+
+func (p *SessionPool) keepAlive() {
+	ticker := time.NewTicker(p.keepAlivePeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.probeIdle()
+		}
+	}
+}
+
+// probeIdle probes the idle sessions, replacing the dead ones with new sessions.
+func (p *SessionPool) probeIdle() {
+	p.lock.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.lock.Unlock()
+	for _, context := range idle {
+		if !IsHealthy(context, p.probeTimeout) {
+			log.Debug("re-spawning a dead pooled session")
+			closeSession(context)
+			var err error
+			context, err = p.spawn()
+			if err != nil {
+				log.Warnf("cannot re-spawn a pooled session: %v", err)
+				continue
+			}
+		}
+		p.Put(context)
+	}
+}
+
+// IsHealthy returns false when a session reported an error, e.g. io.EOF once its process exited, or when it does not
+// answer a probe command within timeout.  An ended reconnecting session is not re-spawned.
+func IsHealthy(context *Context, timeout time.Duration) bool {
+	if context.currentExpecter() == nil || hasFailed(context) {
+		return false
+	}
+	expecter := *context.currentExpecter()
+	if err := expecter.Send(keepAliveProbe); err != nil {
+		return false
+	}
+	_, _, err := expecter.Expect(keepAliveRegex, timeout)
+	return err == nil
+}
+
+// hasFailed returns true when the session reported an error, or closed its error channel.
+func hasFailed(context *Context) bool {
+	select {
+	case <-context.GetErrorChannel():
+		return true
+	default:
+		return false
+	}
+}
+
+func closeSession(context *Context) {
+	if context.currentExpecter() == nil {
+		return
+	}
+	if err := (*context.currentExpecter()).Close(); err != nil {
+		log.Debugf("error closing a session: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package interactive_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	expect "github.com/google/goexpect"
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	mock_interactive "github.com/test-network-function/test-network-function/pkg/tnf/interactive/mocks"
+)
+
+const probeTimeout = time.Second
+
+// newMockContext returns a session answering up to healthyProbes probes, then failing to answer.
+func newMockContext(ctrl *gomock.Controller, healthyProbes int, errCh <-chan error) *interactive.Context {
+	mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+	mockExpecter.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
+	first := mockExpecter.EXPECT().Expect(gomock.Any(), probeTimeout).Return("TNF_KEEPALIVE_42\n", nil, nil).MaxTimes(healthyProbes)
+	mockExpecter.EXPECT().Expect(gomock.Any(), probeTimeout).Return("", nil, errors.New("timeout")).After(first).AnyTimes()
+	mockExpecter.EXPECT().Close().Return(nil).MaxTimes(1)
+	var expecter expect.Expecter = mockExpecter
+	return interactive.NewContext(&expecter, errCh)
+}
+
+func TestSessionPoolReuse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spawned := 0
+	pool := interactive.NewSessionPool(func() (*interactive.Context, error) {
+		spawned++
+		return newMockContext(ctrl, 1, nil), nil
+	}, 1, 0, probeTimeout)
+	defer pool.Close()
+
+	first, err := pool.Get()
+	assert.Nil(t, err)
+	pool.Put(first)
+	second, err := pool.Get()
+	assert.Nil(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, spawned)
+	pool.Put(second)
+}
+
+func TestSessionPoolReplacesDeadSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the first session does not answer the probe anymore, the second one reported an EOF.
+	errCh := make(chan error, 1)
+	sessions := []*interactive.Context{newMockContext(ctrl, 0, nil), newMockContext(ctrl, 0, errCh), newMockContext(ctrl, 0, nil)}
+	spawned := 0
+	pool := interactive.NewSessionPool(func() (*interactive.Context, error) {
+		spawned++
+		return sessions[spawned-1], nil
+	}, 2, 0, probeTimeout)
+	defer pool.Close()
+
+	first, _ := pool.Get()
+	pool.Put(first)
+	context, err := pool.Get()
+	assert.Nil(t, err)
+	assert.Same(t, sessions[1], context)
+
+	errCh <- io.EOF
+	pool.Put(context)
+	context, err = pool.Get()
+	assert.Nil(t, err)
+	assert.Same(t, sessions[2], context)
+	assert.Equal(t, 3, spawned)
+	pool.Discard(context)
+}
+
+func TestSessionPoolMaxIdle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool := interactive.NewSessionPool(func() (*interactive.Context, error) {
+		return newMockContext(ctrl, 0, nil), nil
+	}, 1, 0, probeTimeout)
+
+	// the second session returned to a full pool is closed, the first one when the pool is closed.
+	first, _ := pool.Get()
+	second, _ := pool.Get()
+	pool.Put(first)
+	pool.Put(second)
+	pool.Close()
+	// sessions returned to a closed pool are closed.
+	third, _ := pool.Get()
+	pool.Put(third)
+}
+
+func TestSessionPoolSpawnError(t *testing.T) {
+	pool := interactive.NewSessionPool(func() (*interactive.Context, error) {
+		return nil, errors.New("spawn error")
+	}, 1, 0, probeTimeout)
+	defer pool.Close()
+
+	_, err := pool.Get()
+	assert.NotNil(t, err)
+}
+
+func TestSessionPoolKeepAlive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	respawned := make(chan struct{})
+	spawned := 0
+	pool := interactive.NewSessionPool(func() (*interactive.Context, error) {
+		spawned++
+		if spawned == 2 {
+			close(respawned)
+			return newMockContext(ctrl, 1, nil), nil
+		}
+		return newMockContext(ctrl, 0, nil), nil
+	}, 1, 10*time.Millisecond, probeTimeout)
+	defer pool.Close()
+
+	context, err := pool.Get()
+	assert.Nil(t, err)
+	pool.Put(context)
+	select {
+	case <-respawned:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "the dead idle session was not re-spawned")
+	}
+}
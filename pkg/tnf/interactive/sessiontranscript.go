@@ -0,0 +1,140 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package interactive
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	sessionTranscriptDirPermissions  = 0755
+	sessionTranscriptFilePermissions = 0644
+	// sessionTranscriptTimeFormat is the format of the timestamps of the transcripts, in UTC.
+	sessionTranscriptTimeFormat = "2006-01-02T15:04:05.000000Z"
+	sentDirection               = "sent to"
+	receivedDirection           = "received from"
+)
+
+// unsafeFileNameChars are replaced in the names of the transcript files.
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+var (
+	// sessionTranscript is the destination of the traffic of the sessions, none when nil.
+	sessionTranscript     io.Writer
+	sessionTranscriptLock sync.Mutex
+)
+
+// SetSessionTranscript sets the destination of the transcript of the interactive sessions: the commands sent to them
+// and their raw output, as they are written and read, with timestamps.  It can be switched while the sessions run, e.g.
+// to write one transcript per test, and nil stops the recording.
+func SetSessionTranscript(w io.Writer) {
+	sessionTranscriptLock.Lock()
+	defer sessionTranscriptLock.Unlock()
+	sessionTranscript = w
+}
+
+// recordSessionTraffic writes a chunk of the traffic of a session to the transcript, if any, under a header line with
+// its timestamp, its direction and the command line of the session.
+func recordSessionTraffic(session, direction string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	sessionTranscriptLock.Lock()
+	defer sessionTranscriptLock.Unlock()
+	if sessionTranscript == nil {
+		return
+	}
+	chunk := string(data)
+	if !strings.HasSuffix(chunk, "\n") {
+		chunk += "\n"
+	}
+	_, _ = fmt.Fprintf(sessionTranscript, "=== %s %s %s\n%s", time.Now().UTC().Format(sessionTranscriptTimeFormat),
+		direction, session, chunk)
+}
+
+// recordingWriter records the commands written to the standard input of a session.
+type recordingWriter struct {
+	io.WriteCloser
+	session string
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	recordSessionTraffic(w.session, sentDirection, p)
+	return w.WriteCloser.Write(p)
+}
+
+// recordingReader records the raw output read from a session.
+type recordingReader struct {
+	io.Reader
+	session string
+}
+
+func (r *recordingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	recordSessionTraffic(r.session, receivedDirection, p[:n])
+	return n, err
+}
+
+// SessionTranscriptRecorder writes the transcript of the sessions into one file per test, in a directory.
+type SessionTranscriptRecorder struct {
+	dir  string
+	file *os.File
+}
+
+// NewSessionTranscriptRecorder returns a SessionTranscriptRecorder writing the transcripts into dir, which is created
+// if needed.
+func NewSessionTranscriptRecorder(dir string) *SessionTranscriptRecorder {
+	return &SessionTranscriptRecorder{dir: dir}
+}
+
+// Start stops the current transcript, if any, and records the sessions into a new file named after a test and its
+// start time.  It returns the path of the file.
+func (r *SessionTranscriptRecorder) Start(name string, startTime time.Time) (string, error) {
+	if err := r.Stop(); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(r.dir, sessionTranscriptDirPermissions); err != nil {
+		return "", err
+	}
+	name = fmt.Sprintf("%s-%s.log", strings.Trim(unsafeFileNameChars.ReplaceAllString(name, "_"), "_"),
+		startTime.UTC().Format("20060102T150405.000000"))
+	path := filepath.Join(r.dir, name)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, sessionTranscriptFilePermissions)
+	if err != nil {
+		return "", err
+	}
+	r.file = file
+	SetSessionTranscript(file)
+	return path, nil
+}
+
+// Stop stops recording the sessions and closes the current transcript, if any.
+func (r *SessionTranscriptRecorder) Stop() error {
+	if r.file == nil {
+		return nil
+	}
+	SetSessionTranscript(nil)
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
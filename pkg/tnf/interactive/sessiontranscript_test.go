@@ -0,0 +1,76 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package interactive
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// nopWriteCloser is the standard input of a fake session.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestSessionTranscriptRecorder(t *testing.T) {
+	var stdin bytes.Buffer
+	writer := &recordingWriter{WriteCloser: nopWriteCloser{&stdin}, session: "oc rsh -n tnf test-0"}
+	reader := &recordingReader{Reader: strings.NewReader("0\n"), session: "oc rsh -n tnf test-0"}
+
+	// nothing is recorded without a transcript
+	_, err := writer.Write([]byte("uname -r\n"))
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	recorder := NewSessionTranscriptRecorder(filepath.Join(dir, "transcripts"))
+	startTime := time.Date(2021, time.October, 15, 10, 0, 0, 0, time.UTC)
+	path, err := recorder.Start("platform-alteration/tainted node kernel", startTime)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "transcripts", "platform-alteration_tainted_node_kernel-20211015T100000.000000.log"),
+		path)
+	_, err = writer.Write([]byte("cat /proc/sys/kernel/tainted\n"))
+	assert.Nil(t, err)
+	output, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "0\n", string(output))
+	assert.Nil(t, recorder.Stop())
+	assert.Nil(t, recorder.Stop())
+
+	// nothing is recorded once stopped
+	_, err = writer.Write([]byte("exit\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, "uname -r\ncat /proc/sys/kernel/tainted\nexit\n", stdin.String())
+
+	contents, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^=== \d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{6}Z sent to oc rsh -n tnf test-0
+cat /proc/sys/kernel/tainted
+=== \S+ received from oc rsh -n tnf test-0
+0
+$`), string(contents))
+}
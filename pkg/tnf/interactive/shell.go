@@ -17,8 +17,10 @@
 package interactive
 
 import (
-	"log"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 )
 
@@ -26,23 +28,54 @@ const (
 	shellEnvironmentVariableKey = "SHELL"
 	defaultTimeoutSeconds       = 10
 	defaultTimeout              = defaultTimeoutSeconds * time.Second
+	// defaultShell is the POSIX shell used when $SHELL is unset or is not a POSIX shell.
+	defaultShell = "/bin/sh"
 )
 
+// ErrUnhealthySession is returned when a newly spawned session does not answer a health check.
+var ErrUnhealthySession = errors.New("the session does not answer the health check")
+
+// nonPOSIXShells are the shells unable to run the POSIX commands sent by the handlers, e.g. `cmd ; echo exit=$?`.
+var nonPOSIXShells = map[string]bool{
+	"csh":  true,
+	"fish": true,
+	"nu":   true,
+	"tcsh": true,
+}
+
 // SpawnShell creates an interactive shell subprocess based on the value of $SHELL, spawning the appropriate underlying
-// PTY.
+// PTY.  The shell of a bastion is defaultShell, the local $SHELL may not exist there, see SetBastion.
 func SpawnShell(spawner *Spawner, timeout time.Duration, opts ...Option) (*Context, error) {
-	shellEnv := os.Getenv(shellEnvironmentVariableKey)
+	shellEnv := GetShell()
+	if getBastion() != nil {
+		shellEnv = defaultShell
+	}
 	var args []string
 	return (*spawner).Spawn(shellEnv, args, timeout, opts...)
 }
 
-//
-//
-// GetContext spawns a new shell session and returns its context
-func GetContext(verbose bool) *Context {
-	context, err := SpawnShell(CreateGoExpectSpawner(), defaultTimeout, Verbose(verbose), SendTimeout(defaultTimeout))
-	if err != nil || context == nil || context.GetExpecter() == nil {
-		log.Panicf("can't get a proper context for test execution")
+// GetShell returns the value of $SHELL, or defaultShell when $SHELL is unset or is not a POSIX shell, e.g. fish on a
+// macOS laptop.
+func GetShell() string {
+	shellEnv := os.Getenv(shellEnvironmentVariableKey)
+	if shellEnv == "" || nonPOSIXShells[filepath.Base(shellEnv)] {
+		return defaultShell
 	}
-	return context
+	return shellEnv
+}
+
+// GetContext spawns a new shell session and returns its context, or an error when the shell cannot be spawned or does
+// not answer a health check.  The session is lazily re-spawned once it ended, see NewReconnectingContext.
+func GetContext(verbose bool) (*Context, error) {
+	return NewReconnectingContext(func() (*Context, error) {
+		context, err := SpawnShell(CreateGoExpectSpawner(), defaultTimeout, Verbose(verbose), SendTimeout(defaultTimeout))
+		if err != nil {
+			return nil, fmt.Errorf("cannot spawn a shell: %w", err)
+		}
+		if !IsHealthy(context, defaultTimeout) {
+			closeSession(context)
+			return nil, ErrUnhealthySession
+		}
+		return context, nil
+	})
 }
@@ -64,3 +64,18 @@ func TestSpawnShell(t *testing.T) {
 		assert.Equal(t, testCase.expectedSpawnErr, err)
 	}
 }
+
+func TestGetShell(t *testing.T) {
+	testCases := map[string]string{
+		"":                     "/bin/sh",
+		"/bin/bash":            "/bin/bash",
+		"/bin/zsh":             "/bin/zsh",
+		"/usr/local/bin/fish":  "/bin/sh",
+		"/opt/homebrew/bin/nu": "/bin/sh",
+		"/bin/tcsh":            "/bin/sh",
+	}
+	for shellEnv, expected := range testCases {
+		t.Setenv("SHELL", shellEnv)
+		assert.Equal(t, expected, interactive.GetShell())
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	expect "github.com/google/goexpect"
@@ -137,17 +138,37 @@ type Spawner interface {
 // something as simple as a shell, to as complex as an interactive OpenShift client or SSH session.  Context follows the
 // Container design pattern, and is a simple data transfer object.
 type Context struct {
+	lock         sync.Mutex
 	expecter     *expect.Expecter
 	errorChannel <-chan error
+	// respawn re-spawns the session once it ended, see NewReconnectingContext.  nil for the other contexts.
+	respawn func() (*Context, error)
+	// ended is closed once the session of a reconnecting context ended.
+	ended <-chan struct{}
 }
 
-// GetExpecter returns the expect.Expecter Context.
+// GetExpecter returns the expect.Expecter Context.  The session of a reconnecting context is re-spawned first when it
+// ended, see NewReconnectingContext.
 func (c *Context) GetExpecter() *expect.Expecter {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.respawn != nil && c.hasEnded() {
+		c.reconnect()
+	}
+	return c.expecter
+}
+
+// currentExpecter returns the expect.Expecter of the current session, without re-spawning it.
+func (c *Context) currentExpecter() *expect.Expecter {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	return c.expecter
 }
 
 // GetErrorChannel returns the error channel.
 func (c *Context) GetErrorChannel() <-chan error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	return c.errorChannel
 }
 
@@ -325,6 +346,8 @@ func (g *GoExpectSpawner) Spawn(command string, args []string, timeout time.Dura
 		opt(g)
 	}
 
+	// the sessions are spawned through the bastion, if any, see SetBastion.
+	command, args = throughBastion(command, args)
 	spawnFunc = (*spawnFunc).Command(command, args...)
 	stdinPipe, stdoutPipe, stderrPipe, err := g.unpackPipes(spawnFunc)
 	if err != nil {
@@ -336,6 +359,9 @@ func (g *GoExpectSpawner) Spawn(command string, args []string, timeout time.Dura
 
 	logCmdMirrorPipe(cmdLine, stderrPipe, "STDERR", false)
 	stdoutPipe = logCmdMirrorPipe(cmdLine, stdoutPipe, "STDOUT", true)
+	// the traffic of the session is recorded into the session transcript, see SetSessionTranscript.
+	stdinPipe = &recordingWriter{WriteCloser: stdinPipe, session: cmdLine}
+	stdoutPipe = &recordingReader{Reader: stdoutPipe, session: cmdLine}
 
 	err = g.startCommand(spawnFunc, command, args)
 	if err != nil {
@@ -18,14 +18,75 @@ package interactive
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 )
 
 const (
 	sshCommand   = "ssh"
 	sshSeparator = "@"
+
+	sshPortArg     = "-p"
+	sshIdentityArg = "-i"
+	sshJumpArg     = "-J"
+	sshOptionArg   = "-o"
+	// sshBatchMode fails the session instead of prompting for a password or a passphrase.
+	sshBatchMode = "BatchMode=yes"
+	// sshRootShell runs the commands sent to a node session as root, sudo not prompting for a password.
+	sshRootShell = "sudo -n sh"
+	// hostChrootShim defines a chroot shell function dropping the "chroot /host" prefix of the commands written for the
+	// debug pods, which mount the node root filesystem on /host, since it is / in a node session.  The node commands
+	// hence reach the files of the node through "chroot /host", never with a /host path.
+	hostChrootShim = `chroot() { if [ "$1" = /host ]; then shift; "$@"; else command chroot "$@"; fi; }`
 )
 
+// SSHTarget is a host reached over SSH, possibly through a jumphost.
+type SSHTarget struct {
+	User string
+	Host string
+	// Port is the SSH port of the host, the default of the ssh client when 0.
+	Port int
+	// KeyFile is the private key authenticating the user, the default keys of the ssh client when empty.
+	KeyFile string
+	// Jumphost is the bastion the host is reached through, as [user@]host[:port], none when empty.
+	Jumphost string
+}
+
+// args returns the arguments of the ssh command connecting to the target.
+func (t *SSHTarget) args() []string {
+	args := []string{sshOptionArg, sshBatchMode}
+	if t.Port != 0 {
+		args = append(args, sshPortArg, strconv.Itoa(t.Port))
+	}
+	if t.KeyFile != "" {
+		args = append(args, sshIdentityArg, t.KeyFile)
+	}
+	if t.Jumphost != "" {
+		args = append(args, sshJumpArg, t.Jumphost)
+	}
+	return append(args, t.address())
+}
+
+// address returns the target as [user@]host.
+func (t *SSHTarget) address() string {
+	if t.User == "" {
+		return t.Host
+	}
+	return getSSHString(t.User, t.Host)
+}
+
+// jumps returns the chain of the jumps reaching the target with "ssh -J", as [user@]host[:port] separated by commas.
+func (t *SSHTarget) jumps() string {
+	jump := t.address()
+	if t.Port != 0 {
+		jump += ":" + strconv.Itoa(t.Port)
+	}
+	if t.Jumphost != "" {
+		jump = t.Jumphost + jumphostSeparator + jump
+	}
+	return jump
+}
+
 // SpawnSSH spawns an SSH session to a generic linux host using ssh provided by openssh-clients.  Takes care of
 // establishing the pseudo-terminal (PTY) through expect.SpawnGeneric().
 // TODO: This method currently relies upon passwordless SSH setup beforehand.  Handle all types of auth.
@@ -34,6 +95,22 @@ func SpawnSSH(spawner *Spawner, user, host string, timeout time.Duration, opts .
 	return (*spawner).Spawn(sshCommand, []string{sshArgs}, timeout, opts...)
 }
 
+// SpawnNodeSSH spawns an SSH session to a node, running the commands as root, as an alternative to its debug pod when
+// "oc debug" or the privileged pods are forbidden.  The session is wrapped in an Oc, named after the node, so that the
+// node-level handlers run the same way over SSH.
+func SpawnNodeSSH(spawner *Spawner, node string, target *SSHTarget, timeout time.Duration, opts ...Option) (*Oc, <-chan error, error) {
+	context, err := (*spawner).Spawn(sshCommand, append(target.args(), sshRootShell), timeout, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = (*context.GetExpecter()).Send(hostChrootShim + "\n"); err != nil {
+		return nil, nil, fmt.Errorf("cannot set up the session to node %s: %w", node, err)
+	}
+	errorChannel := context.GetErrorChannel()
+	return &Oc{pod: node, timeout: timeout, opts: opts, expecter: context.GetExpecter(), errorChannel: errorChannel,
+		doneChannel: make(chan bool)}, errorChannel, nil
+}
+
 func getSSHString(user, host string) string {
 	return fmt.Sprintf("%s%s%s", user, sshSeparator, host)
 }
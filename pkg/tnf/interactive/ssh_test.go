@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	expect "github.com/google/goexpect"
 	"github.com/stretchr/testify/assert"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	mock_interactive "github.com/test-network-function/test-network-function/pkg/tnf/interactive/mocks"
@@ -66,3 +67,47 @@ func TestSpawnSsh(t *testing.T) {
 		assert.Equal(t, testCase.expectedSpawnErr, err)
 	}
 }
+
+func TestSpawnNodeSSH(t *testing.T) {
+	testCases := map[string]struct {
+		target       interactive.SSHTarget
+		expectedArgs []string
+	}{
+		"default": {
+			target:       interactive.SSHTarget{User: "core", Host: "10.0.0.10"},
+			expectedArgs: []string{"-o", "BatchMode=yes", "core@10.0.0.10", "sudo -n sh"},
+		},
+		"jumphost": {
+			target: interactive.SSHTarget{User: "core", Host: "worker-0", Port: 2222, KeyFile: "/keys/id_rsa",
+				Jumphost: "admin@bastion.example.com"},
+			expectedArgs: []string{"-o", "BatchMode=yes", "-p", "2222", "-i", "/keys/id_rsa", "-J", "admin@bastion.example.com",
+				"core@worker-0", "sudo -n sh"},
+		},
+	}
+	for name, testCase := range testCases {
+		ctrl := gomock.NewController(t)
+		mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+		mockExpecter.EXPECT().Send(gomock.Any()).Return(nil)
+		var expecter expect.Expecter = mockExpecter
+		mockSpawner := mock_interactive.NewMockSpawner(ctrl)
+		mockSpawner.EXPECT().Spawn("ssh", testCase.expectedArgs, gomock.Any(), gomock.Any()).
+			Return(interactive.NewContext(&expecter, nil), nil)
+
+		var spawner interactive.Spawner = mockSpawner
+		target := testCase.target
+		oc, _, err := interactive.SpawnNodeSSH(&spawner, "worker-0", &target, ocTestTimeoutDuration)
+		assert.Nil(t, err, name)
+		assert.Equal(t, "worker-0", oc.GetPodName(), name)
+		assert.Equal(t, &expecter, oc.GetExpecter(), name)
+		ctrl.Finish()
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSpawner := mock_interactive.NewMockSpawner(ctrl)
+	mockSpawner.EXPECT().Spawn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errSpawnSSH)
+	var spawner interactive.Spawner = mockSpawner
+	_, _, err := interactive.SpawnNodeSSH(&spawner, "worker-0", &interactive.SSHTarget{User: "core", Host: "worker-0"},
+		ocTestTimeoutDuration)
+	assert.Equal(t, errSpawnSSH, err)
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package occompat
+
+// Variant are the arguments of a command line supported from a client version.
+type Variant struct {
+	Since Version
+	Args  []string
+}
+
+// Adaptation is a part of a command line which changed across the client versions, e.g. a renamed flag.
+type Adaptation struct {
+	// Name identifies the adaptation, e.g. in the tests.
+	Name string
+	// Variants are sorted by increasing version.
+	Variants []Variant
+}
+
+// Args returns the arguments of the latest variant supported by client, those of the latest variant when client is
+// unknown, and none when no variant is supported, e.g. for a flag added after the client release.
+func (a *Adaptation) Args(client Version) []string {
+	if client.IsZero() {
+		return a.Variants[len(a.Variants)-1].Args
+	}
+	var args []string
+	for _, variant := range a.Variants {
+		if client.AtLeast(variant.Since) {
+			args = variant.Args
+		}
+	}
+	return args
+}
+
+// Current returns the arguments supported by the detected client.
+func (a *Adaptation) Current() []string {
+	return a.Args(GetVersions().Client)
+}
+
+var (
+	// DrainDeleteEmptyDirData lets `oc adm drain` delete the pods with emptyDir volumes, the flag was renamed in 1.20.
+	DrainDeleteEmptyDirData = Adaptation{
+		Name: "drain-delete-emptydir-data",
+		Variants: []Variant{
+			{Args: []string{"--delete-local-data=true"}},
+			{Since: Version{Major: 1, Minor: 20}, Args: []string{"--delete-emptydir-data=true"}},
+		},
+	}
+	// DrainDisableEviction makes `oc adm drain` delete the pods instead of evicting them, the flag was added in 1.18.
+	DrainDisableEviction = Adaptation{
+		Name: "drain-disable-eviction",
+		Variants: []Variant{
+			{Since: Version{Major: 1, Minor: 18}, Args: []string{"--disable-eviction=true"}},
+		},
+	}
+
+	// Adaptations are all the adaptations of the command lines, new ones are added here when a flag used by the suites
+	// changes across the client releases.
+	Adaptations = []*Adaptation{&DrainDeleteEmptyDirData, &DrainDisableEviction}
+)
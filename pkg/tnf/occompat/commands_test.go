@@ -0,0 +1,76 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package occompat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+)
+
+// clientVersions are the client versions the adaptations are checked against, from oc 3.11 to oc 4.14.
+var clientVersions = []occompat.Version{
+	{Major: 1, Minor: 11}, {Major: 1, Minor: 17}, {Major: 1, Minor: 18}, {Major: 1, Minor: 19}, {Major: 1, Minor: 20},
+	{Major: 1, Minor: 21}, {Major: 1, Minor: 23}, {Major: 1, Minor: 27},
+}
+
+// testAdaptation checks the arguments of an adaptation for each of clientVersions, and for an unknown client.
+func testAdaptation(t *testing.T, adaptation *occompat.Adaptation, expected func(client occompat.Version) []string) {
+	for _, client := range clientVersions {
+		assert.Equal(t, expected(client), adaptation.Args(client), "%s with client %s", adaptation.Name, client)
+	}
+	latest := adaptation.Variants[len(adaptation.Variants)-1].Args
+	assert.Equal(t, latest, adaptation.Args(occompat.Version{}), "%s with an unknown client", adaptation.Name)
+}
+
+func TestDrainDeleteEmptyDirData(t *testing.T) {
+	testAdaptation(t, &occompat.DrainDeleteEmptyDirData, func(client occompat.Version) []string {
+		if client.Minor < 20 {
+			return []string{"--delete-local-data=true"}
+		}
+		return []string{"--delete-emptydir-data=true"}
+	})
+}
+
+func TestDrainDisableEviction(t *testing.T) {
+	testAdaptation(t, &occompat.DrainDisableEviction, func(client occompat.Version) []string {
+		if client.Minor < 18 {
+			return nil
+		}
+		return []string{"--disable-eviction=true"}
+	})
+}
+
+func TestAdaptations(t *testing.T) {
+	names := map[string]bool{}
+	for _, adaptation := range occompat.Adaptations {
+		assert.False(t, names[adaptation.Name], "duplicate adaptation %s", adaptation.Name)
+		names[adaptation.Name] = true
+		assert.NotEmpty(t, adaptation.Variants, adaptation.Name)
+		for i := 1; i < len(adaptation.Variants); i++ {
+			assert.True(t, adaptation.Variants[i].Since.AtLeast(adaptation.Variants[i-1].Since),
+				"variants of %s are not sorted", adaptation.Name)
+		}
+	}
+}
+
+func TestAdaptation_Current(t *testing.T) {
+	defer occompat.SetVersions(occompat.Versions{})
+	assert.Equal(t, []string{"--delete-emptydir-data=true"}, occompat.DrainDeleteEmptyDirData.Current())
+	occompat.SetVersions(occompat.Versions{Client: occompat.Version{Major: 1, Minor: 19}})
+	assert.Equal(t, []string{"--delete-local-data=true"}, occompat.DrainDeleteEmptyDirData.Current())
+}
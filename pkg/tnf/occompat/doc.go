@@ -0,0 +1,23 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package occompat adapts the `oc adm drain` flags which changed across the oc releases to the version of the oc
+// client; the other flags used by the test suites are supported by all the client releases.  The versions of the client
+// and of the cluster are detected once with `oc version -o json`, whose output is stable across the releases, then each
+// Adaptation resolves the arguments supported by the client.  The latest arguments are used when the versions could not
+// be detected.  The flavor of the cluster, OpenShift or upstream Kubernetes, is detected from the API groups it serves,
+// and the oc command lines are run by kubectl on the hosts without oc.
+package occompat
@@ -0,0 +1,95 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package occompat
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// Flavor is the distribution of the cluster, OpenShift or upstream Kubernetes.
+type Flavor string
+
+const (
+	// FlavorOpenShift is an OpenShift cluster, the flavor assumed until detected.
+	FlavorOpenShift Flavor = "openshift"
+	// FlavorKubernetes is an upstream Kubernetes cluster, without the OpenShift resources such as the MachineConfigs.
+	FlavorKubernetes Flavor = "kubernetes"
+
+	// APIVersionsCommand prints the API group versions served by the cluster, one per line.
+	APIVersionsCommand = "oc api-versions"
+	// KubectlShim defines an oc shell function running kubectl, `oc adm <command>` running `kubectl <command>`, e.g.
+	// drain or uncordon, so that the oc command lines run on the hosts with kubectl only, see UsesKubectl.
+	KubectlShim = `oc() { if [ "$1" = adm ]; then shift; fi; kubectl "$@"; }`
+
+	// openShiftAPIGroup is only served by the OpenShift clusters.
+	openShiftAPIGroup = "config.openshift.io/"
+	ocBinaryName      = "oc"
+	kubectlBinaryName = "kubectl"
+)
+
+var flavor Flavor
+
+// ParseFlavor returns the flavor of a cluster from the output of APIVersionsCommand.
+func ParseFlavor(apiVersions string) Flavor {
+	for _, apiVersion := range strings.Fields(apiVersions) {
+		if strings.HasPrefix(apiVersion, openShiftAPIGroup) {
+			return FlavorOpenShift
+		}
+	}
+	return FlavorKubernetes
+}
+
+// ParseFlavorName returns the flavor named name, case-insensitively, and false for an unknown flavor.
+func ParseFlavorName(name string) (Flavor, bool) {
+	switch f := Flavor(strings.ToLower(strings.TrimSpace(name))); f {
+	case FlavorOpenShift, FlavorKubernetes:
+		return f, true
+	}
+	return "", false
+}
+
+// SetFlavor sets the detected flavor of the cluster.
+func SetFlavor(f Flavor) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	flavor = f
+}
+
+// GetFlavor returns the detected flavor of the cluster, FlavorOpenShift until detected.
+func GetFlavor() Flavor {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	if flavor == "" {
+		return FlavorOpenShift
+	}
+	return flavor
+}
+
+// IsOpenShift returns true unless the cluster was detected as an upstream Kubernetes cluster.
+func IsOpenShift() bool {
+	return GetFlavor() == FlavorOpenShift
+}
+
+// UsesKubectl returns true when oc is not installed and kubectl is, in which case kubectl runs the oc command lines,
+// see KubectlShim.
+func UsesKubectl() bool {
+	if _, err := exec.LookPath(ocBinaryName); err == nil {
+		return false
+	}
+	_, err := exec.LookPath(kubectlBinaryName)
+	return err == nil
+}
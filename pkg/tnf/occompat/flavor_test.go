@@ -0,0 +1,71 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package occompat_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+)
+
+// executablePerm are the permissions of the fake oc and kubectl binaries.
+const executablePerm = 0o755
+
+func TestParseFlavor(t *testing.T) {
+	for file, expected := range map[string]occompat.Flavor{
+		"api-versions-openshift.txt":  occompat.FlavorOpenShift,
+		"api-versions-kubernetes.txt": occompat.FlavorKubernetes,
+	} {
+		out, err := os.ReadFile(path.Join(testdataPath, file))
+		assert.Nil(t, err)
+		assert.Equal(t, expected, occompat.ParseFlavor(string(out)), file)
+	}
+	assert.Equal(t, occompat.FlavorKubernetes, occompat.ParseFlavor(""))
+}
+
+func TestParseFlavorName(t *testing.T) {
+	f, ok := occompat.ParseFlavorName(" Kubernetes\n")
+	assert.True(t, ok)
+	assert.Equal(t, occompat.FlavorKubernetes, f)
+	f, ok = occompat.ParseFlavorName("openshift")
+	assert.True(t, ok)
+	assert.Equal(t, occompat.FlavorOpenShift, f)
+	_, ok = occompat.ParseFlavorName("k3s")
+	assert.False(t, ok)
+}
+
+func TestSetFlavor(t *testing.T) {
+	defer occompat.SetFlavor("")
+	assert.Equal(t, occompat.FlavorOpenShift, occompat.GetFlavor())
+	assert.True(t, occompat.IsOpenShift())
+	occompat.SetFlavor(occompat.FlavorKubernetes)
+	assert.Equal(t, occompat.FlavorKubernetes, occompat.GetFlavor())
+	assert.False(t, occompat.IsOpenShift())
+}
+
+func TestUsesKubectl(t *testing.T) {
+	dir := t.TempDir()
+	fakeBinary := []byte("#!/bin/sh\n")
+	t.Setenv("PATH", dir)
+	assert.False(t, occompat.UsesKubectl())
+	assert.Nil(t, os.WriteFile(path.Join(dir, "kubectl"), fakeBinary, executablePerm)) //nolint:gosec // the fake binaries must be executable
+	assert.True(t, occompat.UsesKubectl())
+	assert.Nil(t, os.WriteFile(path.Join(dir, "oc"), fakeBinary, executablePerm)) //nolint:gosec // the fake binaries must be executable
+	assert.False(t, occompat.UsesKubectl())
+}
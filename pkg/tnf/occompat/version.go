@@ -0,0 +1,205 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package occompat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// VersionCommand prints the versions of the oc client and of the cluster as JSON.
+	VersionCommand = "oc version -o json"
+
+	// supportedSkew is the number of minor versions the client may be older or newer than the cluster.
+	supportedSkew = 1
+	// kubernetesMajor is the major version of Kubernetes.
+	kubernetesMajor = 1
+	// openShift3Major and openShift4Major are the major versions of the OpenShift releases, whose oc embeds the
+	// kubectl of Kubernetes 1.<minor> and 1.<minor+13> respectively.
+	openShift3Major     = 3
+	openShift4Major     = 4
+	openShift4MinorSkew = 13
+)
+
+var (
+	versionRegex = regexp.MustCompile(`^v?(\d+)\.(\d+)`)
+
+	mutex    sync.RWMutex
+	detected Versions
+)
+
+// Version is the major and minor version of Kubernetes, the zero Version when unknown.
+type Version struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+}
+
+// IsZero returns true when the version is unknown.
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// AtLeast returns true when the version is other or a later one.
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	return v.Minor >= other.Minor
+}
+
+// String returns the version as "major.minor", "unknown" for the zero Version.
+func (v Version) String() string {
+	if v.IsZero() {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// kubernetes returns the Kubernetes version of an OpenShift version, e.g. 1.21 for 4.8.
+func (v Version) kubernetes() Version {
+	switch v.Major {
+	case openShift3Major:
+		return Version{Major: kubernetesMajor, Minor: v.Minor}
+	case openShift4Major:
+		return Version{Major: kubernetesMajor, Minor: v.Minor + openShift4MinorSkew}
+	}
+	return v
+}
+
+// ParseVersion parses a Kubernetes or OpenShift version, e.g. "v1.21.1+9807387" or "4.8.0-202108130208.p0", into the
+// Kubernetes version it stands for.
+func ParseVersion(version string) (Version, error) {
+	matched := versionRegex.FindStringSubmatch(strings.TrimSpace(version))
+	if matched == nil {
+		return Version{}, fmt.Errorf("invalid version %q", version)
+	}
+	// Ignore errors in converting matches to decimal integers, the regular expression only captures digits.
+	major, _ := strconv.Atoi(matched[1])
+	minor, _ := strconv.Atoi(matched[2])
+	return Version{Major: major, Minor: minor}.kubernetes(), nil
+}
+
+// IsSupported returns true when the cluster version is within minVersion and maxVersion, both included, e.g. "1.21" or
+// "4.8" for OpenShift 4.8, see ParseVersion.  An empty bound is not checked, and an unknown cluster version is
+// supported.  It returns an error for an invalid bound.
+func IsSupported(cluster Version, minVersion, maxVersion string) (bool, error) {
+	supported := true
+	if minVersion != "" {
+		minimum, err := ParseVersion(minVersion)
+		if err != nil {
+			return false, fmt.Errorf("minimum version: %w", err)
+		}
+		supported = cluster.AtLeast(minimum)
+	}
+	if maxVersion != "" {
+		maximum, err := ParseVersion(maxVersion)
+		if err != nil {
+			return false, fmt.Errorf("maximum version: %w", err)
+		}
+		supported = supported && maximum.AtLeast(cluster)
+	}
+	return supported || cluster.IsZero(), nil
+}
+
+// versionInfo holds a version of the output of VersionCommand.
+type versionInfo struct {
+	Major      string `json:"major"`
+	Minor      string `json:"minor"`
+	GitVersion string `json:"gitVersion"`
+}
+
+// parse returns the version, from the major and minor versions when set, e.g. "1" and "21+", else from the git
+// version, e.g. "4.8.0-202108130208.p0" for some oc releases.
+func (i *versionInfo) parse() (Version, error) {
+	if i.Major != "" && i.Minor != "" {
+		return ParseVersion(i.Major + "." + strings.TrimSuffix(i.Minor, "+"))
+	}
+	return ParseVersion(i.GitVersion)
+}
+
+// Versions are the versions of the oc client and of the cluster.
+type Versions struct {
+	Client Version `json:"client"`
+	Server Version `json:"server"`
+}
+
+// Skew returns the number of minor versions between the client and the cluster.
+func (v Versions) Skew() int {
+	skew := v.Client.Minor - v.Server.Minor
+	if skew < 0 {
+		return -skew
+	}
+	return skew
+}
+
+// IsSkewSupported returns true when the client supports the version of the cluster, or when either is unknown.
+func (v Versions) IsSkewSupported() bool {
+	if v.Client.IsZero() || v.Server.IsZero() {
+		return true
+	}
+	return v.Client.Major == v.Server.Major && v.Skew() <= supportedSkew
+}
+
+// ParseVersions parses the output of VersionCommand.  The server version is unknown when the cluster cannot be
+// reached.
+func ParseVersions(output string) (Versions, error) {
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		return Versions{}, errors.New("no version found")
+	}
+	var info struct {
+		ClientVersion *versionInfo `json:"clientVersion"`
+		ServerVersion *versionInfo `json:"serverVersion"`
+	}
+	if err := json.Unmarshal([]byte(output[start:end+1]), &info); err != nil {
+		return Versions{}, err
+	}
+	if info.ClientVersion == nil {
+		return Versions{}, errors.New("no client version found")
+	}
+	var versions Versions
+	var err error
+	if versions.Client, err = info.ClientVersion.parse(); err != nil {
+		return Versions{}, fmt.Errorf("client version: %w", err)
+	}
+	if info.ServerVersion != nil {
+		if versions.Server, err = info.ServerVersion.parse(); err != nil {
+			return Versions{}, fmt.Errorf("server version: %w", err)
+		}
+	}
+	return versions, nil
+}
+
+// SetVersions sets the detected versions of the oc client and of the cluster.
+func SetVersions(versions Versions) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	detected = versions
+}
+
+// GetVersions returns the detected versions of the oc client and of the cluster, unknown until detected.
+func GetVersions() Versions {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return detected
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package occompat_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+)
+
+const (
+	testdataPath = "testdata"
+)
+
+func TestParseVersion(t *testing.T) {
+	for version, expected := range map[string]occompat.Version{
+		"v1.21.1+9807387":                 {Major: 1, Minor: 21},
+		"1.23":                            {Major: 1, Minor: 23},
+		"4.8.0-202108130208.p0":           {Major: 1, Minor: 21},
+		"v3.11.0+0cbc58b":                 {Major: 1, Minor: 11},
+		" v1.27.3\n":                      {Major: 1, Minor: 27},
+		"4.14.0-202310201027.p0.g0c63f9d": {Major: 1, Minor: 27},
+	} {
+		parsed, err := occompat.ParseVersion(version)
+		assert.Nil(t, err, version)
+		assert.Equal(t, expected, parsed, version)
+	}
+	for _, version := range []string{"", "unknown", "v1"} {
+		_, err := occompat.ParseVersion(version)
+		assert.NotNil(t, err, version)
+	}
+}
+
+func TestVersion_AtLeast(t *testing.T) {
+	v := occompat.Version{Major: 1, Minor: 20}
+	assert.True(t, v.AtLeast(occompat.Version{Major: 1, Minor: 20}))
+	assert.True(t, v.AtLeast(occompat.Version{Major: 1, Minor: 18}))
+	assert.False(t, v.AtLeast(occompat.Version{Major: 1, Minor: 21}))
+	assert.True(t, v.AtLeast(occompat.Version{}))
+	assert.Equal(t, "1.20", v.String())
+	assert.Equal(t, "unknown", occompat.Version{}.String())
+}
+
+func TestIsSupported(t *testing.T) {
+	cluster := occompat.Version{Major: 1, Minor: 21}
+	for bounds, expected := range map[[2]string]bool{
+		{"", ""}:         true,
+		{"1.21", ""}:     true,
+		{"4.8", "4.8"}:   true,
+		{"1.22", ""}:     false,
+		{"4.9", ""}:      false,
+		{"", "1.20"}:     false,
+		{"1.19", "1.23"}: true,
+	} {
+		supported, err := occompat.IsSupported(cluster, bounds[0], bounds[1])
+		assert.Nil(t, err, bounds)
+		assert.Equal(t, expected, supported, bounds)
+	}
+	supported, err := occompat.IsSupported(occompat.Version{}, "1.22", "")
+	assert.Nil(t, err)
+	assert.True(t, supported)
+	_, err = occompat.IsSupported(cluster, "v1", "")
+	assert.NotNil(t, err)
+	_, err = occompat.IsSupported(cluster, "", "latest")
+	assert.NotNil(t, err)
+}
+
+func TestParseVersions(t *testing.T) {
+	for file, expected := range map[string]occompat.Versions{
+		// oc 4.6 only sets the git version of the client, an OpenShift version.
+		"oc-4.6.json": {Client: occompat.Version{Major: 1, Minor: 19}, Server: occompat.Version{Major: 1, Minor: 19}},
+		// oc 4.10 sets the Kubernetes major and minor versions, its git version is not meaningful.
+		"oc-4.10.json": {Client: occompat.Version{Major: 1, Minor: 23}, Server: occompat.Version{Major: 1, Minor: 23}},
+		"oc-3.11.json": {Client: occompat.Version{Major: 1, Minor: 11}, Server: occompat.Version{Major: 1, Minor: 11}},
+		// the cluster cannot be reached.
+		"kubectl-1.27-no-server.txt": {Client: occompat.Version{Major: 1, Minor: 27}},
+	} {
+		contents, err := os.ReadFile(path.Join(testdataPath, file))
+		assert.Nil(t, err)
+		versions, err := occompat.ParseVersions(string(contents))
+		assert.Nil(t, err, file)
+		assert.Equal(t, expected, versions, file)
+	}
+	for _, output := range []string{
+		"error: unknown flag: --output",
+		`{"serverVersion": {"major": "1", "minor": "21"}}`,
+		`{"clientVersion": {"gitVersion": "unknown"}}`,
+		`{"clientVersion": {"major": "1", "minor": "21"}, "serverVersion": {"gitVersion": "unknown"}}`,
+		`{"clientVersion": `,
+	} {
+		_, err := occompat.ParseVersions(output)
+		assert.NotNil(t, err, output)
+	}
+}
+
+func TestVersions_IsSkewSupported(t *testing.T) {
+	for _, tc := range []struct {
+		versions  occompat.Versions
+		supported bool
+	}{
+		{versions: occompat.Versions{Client: occompat.Version{Major: 1, Minor: 21}, Server: occompat.Version{Major: 1, Minor: 21}}, supported: true},
+		{versions: occompat.Versions{Client: occompat.Version{Major: 1, Minor: 22}, Server: occompat.Version{Major: 1, Minor: 21}}, supported: true},
+		{versions: occompat.Versions{Client: occompat.Version{Major: 1, Minor: 20}, Server: occompat.Version{Major: 1, Minor: 21}}, supported: true},
+		{versions: occompat.Versions{Client: occompat.Version{Major: 1, Minor: 19}, Server: occompat.Version{Major: 1, Minor: 21}}},
+		{versions: occompat.Versions{Client: occompat.Version{Major: 1, Minor: 27}, Server: occompat.Version{Major: 1, Minor: 21}}},
+		{versions: occompat.Versions{Client: occompat.Version{Major: 1, Minor: 27}}, supported: true},
+		{versions: occompat.Versions{}, supported: true},
+	} {
+		assert.Equal(t, tc.supported, tc.versions.IsSkewSupported(), "%+v", tc.versions)
+	}
+	assert.Equal(t, 6, occompat.Versions{Client: occompat.Version{Major: 1, Minor: 27},
+		Server: occompat.Version{Major: 1, Minor: 21}}.Skew())
+}
+
+func TestSetVersions(t *testing.T) {
+	defer occompat.SetVersions(occompat.Versions{})
+	assert.Equal(t, occompat.Versions{}, occompat.GetVersions())
+	versions := occompat.Versions{Client: occompat.Version{Major: 1, Minor: 19}, Server: occompat.Version{Major: 1, Minor: 19}}
+	occompat.SetVersions(versions)
+	assert.Equal(t, versions, occompat.GetVersions())
+}
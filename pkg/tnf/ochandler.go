@@ -0,0 +1,83 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tnf
+
+import (
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+// OcErrorOutputRegex matches the errors of oc, e.g. for a resource which is gone.
+const OcErrorOutputRegex = `(?m)^(?:Error from server|error:).*$`
+
+// OcCommand implements the steps shared by the handlers running a single oc command and parsing its output: the
+// handler embeds it, implements GetIdentifier and ReelMatch, and sets the result of the test with SetResult on a match
+// of the output regex.  The test errors when oc fails, on timeout or on EOF.
+type OcCommand struct {
+	result      int
+	timeout     time.Duration
+	args        []string
+	outputRegex string
+}
+
+// NewOcCommand creates an OcCommand running args and expecting outputRegex or an oc error within timeout.
+func NewOcCommand(timeout time.Duration, args []string, outputRegex string) OcCommand {
+	return OcCommand{
+		result:      ERROR,
+		timeout:     timeout,
+		args:        args,
+		outputRegex: outputRegex,
+	}
+}
+
+// Args returns the command line args for the test.
+func (o *OcCommand) Args() []string {
+	return o.args
+}
+
+// Timeout returns the timeout for the test.
+func (o *OcCommand) Timeout() time.Duration {
+	return o.timeout
+}
+
+// Result returns the test result.
+func (o *OcCommand) Result() int {
+	return o.result
+}
+
+// SetResult sets the test result, see Result.
+func (o *OcCommand) SetResult(result int) {
+	o.result = result
+}
+
+// ReelFirst returns a step which expects the output regex or an oc error within the test timeout.
+func (o *OcCommand) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{OcErrorOutputRegex, o.outputRegex},
+		Timeout: o.timeout,
+	}
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (o *OcCommand) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no action is necessary on EOF.
+func (o *OcCommand) ReelEOF() {
+}
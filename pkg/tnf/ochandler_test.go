@@ -0,0 +1,56 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tnf_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+)
+
+const (
+	testOcTimeout     = time.Second * 5
+	testOcOutputRegex = `(?s)pod:.*?\nend:`
+)
+
+var testOcArgs = []string{"oc", "-n", "tnf", "get", "pod", "test"}
+
+func TestOcCommand(t *testing.T) {
+	command := tnf.NewOcCommand(testOcTimeout, testOcArgs, testOcOutputRegex)
+	assert.Equal(t, testOcArgs, command.Args())
+	assert.Equal(t, testOcTimeout, command.Timeout())
+	assert.Equal(t, tnf.ERROR, command.Result())
+
+	step := command.ReelFirst()
+	assert.Equal(t, "", step.Execute)
+	assert.Equal(t, []string{tnf.OcErrorOutputRegex, testOcOutputRegex}, step.Expect)
+	assert.Equal(t, testOcTimeout, step.Timeout)
+
+	assert.Nil(t, command.ReelTimeout())
+	assert.Equal(t, tnf.ERROR, command.Result())
+
+	command.SetResult(tnf.SUCCESS)
+	assert.Equal(t, tnf.SUCCESS, command.Result())
+}
+
+func TestOcErrorOutputRegex(t *testing.T) {
+	assert.Regexp(t, tnf.OcErrorOutputRegex, `Error from server (NotFound): pods "test" not found`)
+	assert.Regexp(t, tnf.OcErrorOutputRegex, "some output\nerror: the server doesn't have a resource type \"crs\"")
+	assert.NotRegexp(t, tnf.OcErrorOutputRegex, "pod:\nno error: here\nend:")
+}
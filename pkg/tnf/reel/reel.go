@@ -17,6 +17,8 @@
 package reel
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -34,9 +36,23 @@ const (
 	EndOfTestSentinel = `END_OF_TEST_SENTINEL`
 	// ExitKeyword keyword delimiting the command exit status
 	ExitKeyword = "exit="
+
+	// processNotRunningMessage is the error reported by goexpect once the session ended.
+	processNotRunningMessage = "Process not running"
 )
 
 var (
+	// ErrAborted is wrapped by the errors of the steps interrupted by the cancellation of the reel context.
+	ErrAborted = errors.New("aborted")
+
+	// ErrBufferFull is wrapped by the errors of the streaming steps whose output exceeds Step.MaxBufferSize.
+	ErrBufferFull = errors.New("output exceeds the maximum buffer size")
+
+	// streamChunkRegex matches whatever output is available, so that streaming steps read the output chunk by chunk.
+	streamChunkRegex = regexp.MustCompile(`(?s).+`)
+
+	// sentinelRegex matches the emulated terminal prompt which completes the output of a command.
+	sentinelRegex = regexp.MustCompile(fmt.Sprintf("%s %s[0-9]+\n", EndOfTestSentinel, ExitKeyword))
 
 	// matchSentinel This regular expression is matching stricly the sentinel and exit code.
 	// This match regular expression matches commands that return no output
@@ -52,7 +68,7 @@ var (
 // Step is an instruction for a single REEL pass.
 // To process a step, first send the `Execute` string to the target subprocess (if supplied).  Block until the
 // subprocess output to stdout matches one of the regular expressions in `Expect` (if any supplied). A positive integer
-// `Timeout` prevents blocking forever.
+// `Timeout` prevents blocking forever.  A positive `MaxBufferSize` streams the output instead, see MaxBufferSize.
 type Step struct {
 	// Execute is a Unix command to execute using the underlying subprocess.
 	Execute string `json:"execute,omitempty" yaml:"execute,omitempty"`
@@ -62,6 +78,12 @@ type Step struct {
 
 	// Timeout is the timeout for the Step.  A positive Timeout prevents blocking forever.
 	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// MaxBufferSize is the maximum number of bytes of output accumulated by a streaming Step.  A positive MaxBufferSize
+	// makes the Step read its output chunk by chunk until the command completes, then match Expect against the whole
+	// output, so that expectations span as many lines and reads as needed, e.g. to match large JSON documents.  Without
+	// terminal prompt emulation, Expect is matched each time a chunk is received.  Timeout then applies between chunks.
+	MaxBufferSize int `json:"maxBufferSize,omitempty" yaml:"maxBufferSize,omitempty"`
 }
 
 // A utility method to return the important aspects of the Step container as a tuple.
@@ -74,6 +96,11 @@ func (s *Step) hasExpectations() bool {
 	return len(s.Expect) > 0
 }
 
+// Whether or not the Step streams its output, see Step.MaxBufferSize.
+func (s *Step) isStreaming() bool {
+	return s.MaxBufferSize > 0
+}
+
 // A Handler implements desired programmatic control.
 type Handler interface {
 	// ReelFirst returns the first step to perform.
@@ -92,6 +119,13 @@ type Handler interface {
 	ReelEOF()
 }
 
+// UnmatchedOutputHandler is optionally implemented by a Handler to be informed of the output received by a step and not
+// matched by any of its expectations, before the step timed out or the session ended.  ReelUnmatchedOutput is called
+// prior to ReelTimeout or ReelEOF.
+type UnmatchedOutputHandler interface {
+	ReelUnmatchedOutput(output string)
+}
+
 // StepFunc provides a wrapper around a generic Handler.
 type StepFunc func(Handler) *Step
 
@@ -103,6 +137,8 @@ type Reel struct {
 	// A pointer to the underlying subprocess
 	expecter *expect.Expecter
 	Err      error
+	// ctx aborts the steps when it is done, closing the expecter to tear down the session.
+	ctx context.Context
 	// disableTerminalPromptEmulation determines whether terminal prompt emulation should be disabled.
 	disableTerminalPromptEmulation bool
 }
@@ -117,6 +153,16 @@ func DisableTerminalPromptEmulation() Option {
 	}
 }
 
+// WithContext sets the context of the reel.Reel.  Once ctx is done, e.g. on SIGINT or when a global deadline expires,
+// the running step is aborted and the expecter is closed, as the session is left in an unknown state.
+func WithContext(ctx context.Context) Option {
+	return func(r *Reel) Option {
+		prev := r.ctx
+		r.ctx = ctx
+		return WithContext(prev)
+	}
+}
+
 // Each Step can have zero or more expectations (Step.Expect).  This method follows the Adapter design pattern;  a raw
 // array of strings is turned into a corresponding array of expect.Batcher.  This method side-effects the input
 // expectations array, following the Builder design pattern.  Finally, the first match is stored in the firstMatch
@@ -179,6 +225,135 @@ func isTimeout(err error) bool {
 	return ok
 }
 
+// Determines if an error reports the end of the session;  goexpect has no dedicated error type for it.
+func isEOF(err error) bool {
+	return strings.Contains(err.Error(), processNotRunningMessage)
+}
+
+// reportUnmatchedOutput informs handler of the output accumulated by a step which did not match, if any.
+func reportUnmatchedOutput(output string, handler Handler) {
+	unmatchedOutputHandler, ok := handler.(UnmatchedOutputHandler)
+	if !ok || output == "" {
+		return
+	}
+	unmatchedOutputHandler.ReelUnmatchedOutput(output)
+}
+
+// lastOutput returns the output of the last batch result, if any.
+func lastOutput(results []expect.BatchRes) string {
+	if len(results) == 0 {
+		return ""
+	}
+	return results[len(results)-1].Output
+}
+
+// abortedError returns an error wrapping ErrAborted once the reel context is done, nil otherwise.
+func (r *Reel) abortedError() error {
+	if err := r.ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrAborted, err)
+	}
+	return nil
+}
+
+// expectBatch runs the batcher, closing the expecter to interrupt it when the reel context is done first.
+func (r *Reel) expectBatch(batcher []expect.Batcher, timeout time.Duration) ([]expect.BatchRes, error) {
+	var results []expect.BatchRes
+	err := r.interruptible(func() (err error) {
+		results, err = (*r.expecter).ExpectBatch(batcher, timeout)
+		return err
+	})
+	if errors.Is(err, ErrAborted) {
+		return nil, err
+	}
+	return results, err
+}
+
+// expect waits for the output to match re, closing the expecter to interrupt it when the reel context is done first.
+func (r *Reel) expect(re *regexp.Regexp, timeout time.Duration) (string, error) {
+	var output string
+	err := r.interruptible(func() (err error) {
+		output, _, err = (*r.expecter).Expect(re, timeout)
+		return err
+	})
+	return output, err
+}
+
+// interruptible runs wait, closing the expecter to interrupt it when the reel context is done first.  The error of
+// wait is replaced by one wrapping ErrAborted once the reel context is done.
+func (r *Reel) interruptible(wait func() error) error {
+	if r.ctx.Done() == nil {
+		// the context can never be canceled.
+		return wait()
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-r.ctx.Done():
+			log.Warnf("closing the session of an aborted test: %v", r.ctx.Err())
+			if err := (*r.expecter).Close(); err != nil {
+				log.Debugf("error closing the session of an aborted test: %v", err)
+			}
+		case <-done:
+		}
+	}()
+	err := wait()
+	if abortedErr := r.abortedError(); abortedErr != nil {
+		return abortedErr
+	}
+	return err
+}
+
+// streamStep performs a streaming step, see Step.MaxBufferSize, returning the next step fed by handler.
+func (r *Reel) streamStep(step *Step, handler Handler) (*Step, error) {
+	if step.Execute != "" {
+		if err := (*r.expecter).Send(r.wrapTestCommand(step.Execute)); err != nil {
+			return nil, err
+		}
+	}
+	if !step.hasExpectations() {
+		return nil, nil
+	}
+	var buffer strings.Builder
+	for {
+		chunk, err := r.expect(streamChunkRegex, step.Timeout)
+		if errors.Is(err, ErrAborted) {
+			return nil, err
+		}
+		buffer.WriteString(chunk)
+		if err == nil && buffer.Len() > step.MaxBufferSize {
+			err = fmt.Errorf("%w of %d bytes", ErrBufferFull, step.MaxBufferSize)
+		}
+		if err != nil {
+			reportUnmatchedOutput(buffer.String(), handler)
+			if isTimeout(err) {
+				return handler.ReelTimeout(), nil
+			}
+			if isEOF(err) {
+				handler.ReelEOF()
+			}
+			return nil, err
+		}
+		completed := r.disableTerminalPromptEmulation || sentinelRegex.MatchString(buffer.String())
+		if !completed {
+			continue
+		}
+		output, outputStatus := r.stripEmulatedPromptFromOutput(buffer.String())
+		if outputStatus != 0 {
+			r.Err = fmt.Errorf("error executing command %d: ", outputStatus)
+		}
+		for _, expectation := range step.Expect {
+			if loc := regexp.MustCompile(expectation).FindStringIndex(output); loc != nil {
+				return handler.ReelMatch(expectation, output[:loc[0]], output[loc[0]:loc[1]]), nil
+			}
+		}
+		if !r.disableTerminalPromptEmulation {
+			// the command completed without matching any expectation.
+			return handler.ReelMatch("", output, ""), nil
+		}
+	}
+}
+
 // Step performs `step`, then, in response to events, consequent steps fed by `handler`.
 // Return on first error, or when there is no next step to perform.
 func (r *Reel) Step(step *Step, handler Handler) error {
@@ -186,22 +361,39 @@ func (r *Reel) Step(step *Step, handler Handler) error {
 		if r.Err != nil {
 			return r.Err
 		}
+		if err := r.abortedError(); err != nil {
+			return err
+		}
+		if step.isStreaming() {
+			var err error
+			if step, err = r.streamStep(step, handler); err != nil {
+				return err
+			}
+			continue
+		}
 		exec, exp, timeout := step.unpack()
 		var batcher []expect.Batcher
 		batcher = r.generateBatcher(exec)
 		// firstMatchRe is the first regular expression (expectation) that has matched results
 		var firstMatchRe string
 		batcher = r.batchExpectations(exp, batcher, &firstMatchRe)
-		results, err := (*r.expecter).ExpectBatch(batcher, timeout)
+		results, err := r.expectBatch(batcher, timeout)
+		if errors.Is(err, ErrAborted) {
+			return err
+		}
 
 		if !step.hasExpectations() {
 			return nil
 		}
 
 		if err != nil {
+			reportUnmatchedOutput(lastOutput(results), handler)
 			if isTimeout(err) {
 				step = handler.ReelTimeout()
 			} else {
+				if isEOF(err) {
+					handler.ReelEOF()
+				}
 				return err
 			}
 		} else {
@@ -245,10 +437,13 @@ func (r *Reel) createExecutableCommand(command string) string {
 // NewReel create a new `Reel` instance for interacting with a target subprocess.  The command line for the target is
 // specified by the args parameter.
 func NewReel(expecter *expect.Expecter, args []string, errorChannel <-chan error, opts ...Option) (*Reel, error) {
-	r := &Reel{}
+	r := &Reel{ctx: context.Background()}
 	for _, o := range opts {
 		o(r)
 	}
+	if err := r.abortedError(); err != nil {
+		return nil, err
+	}
 	if len(args) > 0 {
 		command := r.createExecutableCommand(strings.Join(args, " "))
 		err := (*expecter).Send(command)
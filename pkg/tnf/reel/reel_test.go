@@ -17,6 +17,7 @@
 package reel_test
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -37,6 +38,7 @@ import (
 var (
 	defaultCommand = []string{"ls"}
 	errReel        = errors.New("some reel error")
+	errEOF         = errors.New("expect: Process not running")
 	errSendCommand = errors.New("send command error")
 )
 
@@ -114,6 +116,7 @@ type reelStepTestCase struct {
 	expectBatchResResult               []expect.BatchRes
 	expectBatchErrResult               error
 	isTimeout                          bool
+	isEOF                              bool
 }
 
 var reelStepTestCases = map[string]reelStepTestCase{
@@ -163,6 +166,17 @@ var reelStepTestCases = map[string]reelStepTestCase{
 		expectBatchErrResult:               errReel,
 		isTimeout:                          false,
 	},
+	"eof_error": {
+		stepInput:                          &reel.Step{Expect: []string{"expect something"}},
+		command:                            defaultCommand,
+		stepReturnErr:                      errEOF,
+		reelErr:                            nil,
+		expectBatchExpectedInvocationCount: 1,
+		expectBatchResResult:               []expect.BatchRes{},
+		expectBatchErrResult:               errEOF,
+		isTimeout:                          false,
+		isEOF:                              true,
+	},
 	"successful_reel": {
 		stepInput:                          &reel.Step{Expect: []string{`.+`}},
 		command:                            defaultCommand,
@@ -250,6 +264,9 @@ func TestReel_Step(t *testing.T) {
 		if testCase.isTimeout {
 			handler.EXPECT().ReelTimeout().Times(1)
 		}
+		if testCase.isEOF {
+			handler.EXPECT().ReelEOF().Times(1)
+		}
 
 		// successful ExpectBatch
 		if len(testCase.expectBatchResResult) > 0 {
@@ -260,3 +277,144 @@ func TestReel_Step(t *testing.T) {
 		assert.Equal(t, testCase.stepReturnErr, err)
 	}
 }
+
+func TestReel_StepAborted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the pending ExpectBatch is interrupted by closing the expecter once the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	closed := make(chan struct{})
+	mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+	mockExpecter.EXPECT().Send(gomock.Any()).Return(nil)
+	mockExpecter.EXPECT().ExpectBatch(gomock.Any(), gomock.Any()).DoAndReturn(func([]expect.Batcher, time.Duration) ([]expect.BatchRes, error) {
+		cancel()
+		<-closed
+		return nil, errors.New("expecter closed")
+	})
+	mockExpecter.EXPECT().Close().DoAndReturn(func() error {
+		close(closed)
+		return nil
+	})
+	var expecter expect.Expecter = mockExpecter
+	r, err := reel.NewReel(&expecter, []string{"sleep", "3600"}, nil, reel.WithContext(ctx))
+	assert.Nil(t, err)
+
+	handler := mock_reel.NewMockHandler(ctrl)
+	err = r.Step(&reel.Step{Expect: []string{"never"}, Timeout: time.Hour}, handler)
+	assert.True(t, errors.Is(err, reel.ErrAborted))
+
+	// no command is sent once the context is done.
+	_, err = reel.NewReel(&expecter, []string{"ls"}, nil, reel.WithContext(ctx))
+	assert.True(t, errors.Is(err, reel.ErrAborted))
+}
+
+type expectResult struct {
+	output string
+	err    error
+}
+
+type reelStreamingStepTestCase struct {
+	step          *reel.Step
+	expectResults []expectResult
+	matchPattern  string
+	matchBefore   string
+	matchText     string
+	isMatch       bool
+	isTimeout     bool
+	stepReturnErr error
+	isBufferFull  bool
+}
+
+var reelStreamingStepTestCases = map[string]reelStreamingStepTestCase{
+	"match_across_chunks": {
+		step: &reel.Step{Execute: "oc get pods -o json", Expect: []string{`(?s)\{.*\}`}, MaxBufferSize: 1024},
+		expectResults: []expectResult{
+			{output: "{\n  \"items\": ["},
+			{output: "\n    1,\n    2"},
+			{output: "\n  ]\n}\nEND_OF_TEST_SENTINEL exit=0\n"},
+		},
+		isMatch:      true,
+		matchPattern: `(?s)\{.*\}`,
+		matchBefore:  "",
+		matchText:    "{\n  \"items\": [\n    1,\n    2\n  ]\n}",
+	},
+	"second_expectation": {
+		step: &reel.Step{Execute: "ls", Expect: []string{`never`, `file\d`}, MaxBufferSize: 1024},
+		expectResults: []expectResult{
+			{output: "dir\nfile1"},
+			{output: "\nEND_OF_TEST_SENTINEL exit=0\n"},
+		},
+		isMatch:      true,
+		matchPattern: `file\d`,
+		matchBefore:  "dir\n",
+		matchText:    "file1",
+	},
+	"no_expectation_matched": {
+		step: &reel.Step{Execute: "ls", Expect: []string{`never`}, MaxBufferSize: 1024},
+		expectResults: []expectResult{
+			{output: "file1\nEND_OF_TEST_SENTINEL exit=0\n"},
+		},
+		isMatch:      true,
+		matchPattern: "",
+		matchBefore:  "file1",
+		matchText:    "",
+	},
+	"buffer_full": {
+		step: &reel.Step{Execute: "ls", Expect: []string{`never`}, MaxBufferSize: 8},
+		expectResults: []expectResult{
+			{output: "file1\n"},
+			{output: "file2\n"},
+		},
+		isBufferFull: true,
+	},
+	"timeout": {
+		step: &reel.Step{Execute: "ls", Expect: []string{`never`}, MaxBufferSize: 1024},
+		expectResults: []expectResult{
+			{output: "file1\n"},
+			{err: expect.TimeoutError(time.Second)},
+		},
+		isTimeout: true,
+	},
+	"non_timeout_error": {
+		step: &reel.Step{Execute: "ls", Expect: []string{`never`}, MaxBufferSize: 1024},
+		expectResults: []expectResult{
+			{err: errReel},
+		},
+		stepReturnErr: errReel,
+	},
+}
+
+func TestReel_StreamingStep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	for name, testCase := range reelStreamingStepTestCases {
+		mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+		mockExpecter.EXPECT().Send(reel.WrapTestCommand(testCase.step.Execute)).Return(nil)
+		var calls []*gomock.Call
+		for _, result := range testCase.expectResults {
+			calls = append(calls, mockExpecter.EXPECT().Expect(gomock.Any(), testCase.step.Timeout).Return(result.output, nil, result.err))
+		}
+		gomock.InOrder(calls...)
+
+		var expecter expect.Expecter = mockExpecter
+		r, err := reel.NewReel(&expecter, nil, nil)
+		assert.Nil(t, err)
+
+		handler := mock_reel.NewMockHandler(ctrl)
+		if testCase.isMatch {
+			handler.EXPECT().ReelMatch(testCase.matchPattern, testCase.matchBefore, testCase.matchText)
+		}
+		if testCase.isTimeout {
+			handler.EXPECT().ReelTimeout()
+		}
+
+		err = r.Step(testCase.step, handler)
+		if testCase.isBufferFull {
+			assert.True(t, errors.Is(err, reel.ErrBufferFull), name)
+		} else {
+			assert.Equal(t, testCase.stepReturnErr, err, name)
+		}
+	}
+}
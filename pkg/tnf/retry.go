@@ -0,0 +1,137 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tnf
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+// resultNames are the names of the test results, as recorded in the claim.
+var resultNames = map[int]string{
+	SUCCESS: "SUCCESS",
+	FAILURE: "FAILURE",
+	ERROR:   "ERROR",
+	ABORTED: "ABORTED",
+}
+
+// extraInfoLock protects TestsExtraInfo, written by the tests run in parallel.
+var extraInfoLock sync.Mutex
+
+// RetryPolicy retries the tests which fail or error, e.g. because of transient network issues.
+type RetryPolicy struct {
+	// Attempts is the maximum number of runs of a test, the test is run once if it is 0 or 1.
+	Attempts int
+	// Backoff is the delay before the second attempt.
+	Backoff time.Duration
+	// Multiplier multiplies the delay before each subsequent attempt, the delay is constant if it is 0 or 1.
+	Multiplier float64
+}
+
+// Attempt is a run of a test.
+type Attempt struct {
+	Result   int
+	Err      error
+	Duration time.Duration
+}
+
+// String returns the description of the attempt recorded in the claim.
+func (a *Attempt) String() string {
+	if a.Err != nil {
+		return fmt.Sprintf("%s in %s: %s", resultNames[a.Result], a.Duration.Round(time.Millisecond), a.Err)
+	}
+	return fmt.Sprintf("%s in %s", resultNames[a.Result], a.Duration.Round(time.Millisecond))
+}
+
+// WriteTestExtraInfo adds messages about the test identified by id to the claim.
+func WriteTestExtraInfo(id string, messages ...string) {
+	extraInfoLock.Lock()
+	defer extraInfoLock.Unlock()
+	TestsExtraInfo = append(TestsExtraInfo, map[string][]string{id: messages})
+}
+
+// RunWithRetry runs the test created by newTest until it succeeds or the attempts of the policy are exhausted.  A new
+// test, and thus a new handler, is created for each attempt since tests and handlers can only run once.  The waits
+// between attempts are interrupted by the cancellation of the context of the tests, and aborted tests are
+// not retried.  It returns the result and error of the last attempt.  When the test was retried, all the attempts
+// are recorded in the claim with WriteTestExtraInfo.
+func RunWithRetry(policy RetryPolicy, newTest func() (*Test, error)) (int, error) {
+	attempts, err := runAttempts(policy, newTest)
+	if len(attempts) == 0 {
+		return ERROR, err
+	}
+	last := attempts[len(attempts)-1]
+	return last.Result, last.Err
+}
+
+// runAttempts runs the attempts of RunWithRetry.  It returns an error only when no test could be created.
+func runAttempts(policy RetryPolicy, newTest func() (*Test, error)) ([]Attempt, error) {
+	var attempts []Attempt
+	var id string
+	backoff := policy.Backoff
+	for {
+		test, err := newTest()
+		if err != nil {
+			if len(attempts) == 0 {
+				return nil, err
+			}
+			attempts = append(attempts, Attempt{Result: ERROR, Err: err})
+			break
+		}
+		id = test.tester.GetIdentifier().URL
+		start := time.Now()
+		result, err := test.Run()
+		attempts = append(attempts, Attempt{Result: result, Err: err, Duration: time.Since(start)})
+		if result == SUCCESS || result == ABORTED || len(attempts) >= policy.Attempts {
+			break
+		}
+		log.Warnf("%s attempt %d/%d: %s, retrying in %s", id, len(attempts), policy.Attempts,
+			attempts[len(attempts)-1].String(), backoff)
+		if !sleep(test.ctx, backoff) {
+			attempts = append(attempts, Attempt{Result: ABORTED, Err: reel.ErrAborted})
+			break
+		}
+		if policy.Multiplier > 1 {
+			backoff = time.Duration(float64(backoff) * policy.Multiplier)
+		}
+	}
+	if len(attempts) > 1 {
+		messages := make([]string, len(attempts))
+		for i := range attempts {
+			messages[i] = fmt.Sprintf("attempt %d/%d: %s", i+1, policy.Attempts, attempts[i].String())
+		}
+		WriteTestExtraInfo(id, messages...)
+	}
+	return attempts, nil
+}
+
+// sleep waits for d, it returns false if ctx was canceled in the meantime.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tnf_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	expect "github.com/google/goexpect"
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	mock_interactive "github.com/test-network-function/test-network-function/pkg/tnf/interactive/mocks"
+	mock_tnf "github.com/test-network-function/test-network-function/pkg/tnf/mocks"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+	mock_reel "github.com/test-network-function/test-network-function/pkg/tnf/reel/mocks"
+)
+
+const retryTestURL = "http://test-network-function.com/tests/retry"
+
+// newTestFunc returns a function creating tests with the given results, one per call.
+func newTestFunc(t *testing.T, ctrl *gomock.Controller, results []int) func() (*tnf.Test, error) {
+	calls := 0
+	return func() (*tnf.Test, error) {
+		mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+		mockExpecter.EXPECT().Send(gomock.Any()).AnyTimes()
+		mockTester := mock_tnf.NewMockTester(ctrl)
+		mockTester.EXPECT().Args().Return(defaultTestCommand)
+		mockTester.EXPECT().Result().Return(results[calls])
+		mockTester.EXPECT().GetIdentifier().Return(identifier.Identifier{URL: retryTestURL}).AnyTimes()
+		mockHandler := mock_reel.NewMockHandler(ctrl)
+		mockHandler.EXPECT().ReelFirst().Return(nil)
+		calls++
+
+		var expecter expect.Expecter = mockExpecter
+		var errorChannel <-chan error
+		test, err := tnf.NewTest(context.Background(), &expecter, mockTester, []reel.Handler{mockHandler}, errorChannel, reel.DisableTerminalPromptEmulation())
+		assert.Nil(t, err)
+		return test, err
+	}
+}
+
+func TestRunWithRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		policy         tnf.RetryPolicy
+		results        []int
+		expectedResult int
+		recorded       bool
+	}{
+		// the test is run once without a policy
+		{policy: tnf.RetryPolicy{}, results: []int{tnf.FAILURE}, expectedResult: tnf.FAILURE},
+		// the test is not retried once it succeeded
+		{policy: tnf.RetryPolicy{Attempts: 3}, results: []int{tnf.SUCCESS}, expectedResult: tnf.SUCCESS},
+		// the test is retried until it succeeds
+		{policy: tnf.RetryPolicy{Attempts: 3, Backoff: time.Millisecond, Multiplier: 2},
+			results: []int{tnf.FAILURE, tnf.ERROR, tnf.SUCCESS}, expectedResult: tnf.SUCCESS, recorded: true},
+		// the result of the last attempt is returned
+		{policy: tnf.RetryPolicy{Attempts: 2, Backoff: time.Millisecond},
+			results: []int{tnf.ERROR, tnf.FAILURE}, expectedResult: tnf.FAILURE, recorded: true},
+	}
+	for _, testCase := range testCases {
+		tnf.TestsExtraInfo = nil
+		result, err := tnf.RunWithRetry(testCase.policy, newTestFunc(t, ctrl, testCase.results))
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedResult, result)
+		if !testCase.recorded {
+			assert.Empty(t, tnf.TestsExtraInfo)
+			continue
+		}
+		assert.Len(t, tnf.TestsExtraInfo, 1)
+		assert.Len(t, tnf.TestsExtraInfo[0][retryTestURL], len(testCase.results))
+		assert.Contains(t, tnf.TestsExtraInfo[0][retryTestURL][0], "attempt 1/")
+	}
+}
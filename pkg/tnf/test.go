@@ -17,12 +17,15 @@
 package tnf
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	expect "github.com/google/goexpect"
-	"github.com/onsi/gomega"
 	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 )
@@ -34,6 +37,8 @@ const (
 	SUCCESS
 	// FAILURE represents a failed test.
 	FAILURE
+	// ABORTED represents a test interrupted by the cancellation of its context.
+	ABORTED
 )
 
 // TestsExtraInfo a collection of messages per test that is added to the claim file
@@ -45,6 +50,12 @@ var ExitCodeMap = map[int]int{
 	SUCCESS: 0,
 	FAILURE: 1,
 	ERROR:   2,
+	ABORTED: 130,
+}
+
+// IsAborted returns true when err was returned by a test interrupted by the cancellation of its context.
+func IsAborted(err error) bool {
+	return errors.Is(err, reel.ErrAborted)
 }
 
 // Tester provides the interface for a Test.
@@ -67,16 +78,86 @@ type Test struct {
 	runner *reel.Reel
 	tester Tester
 	chain  []reel.Handler
+	ctx    context.Context
+	// exchanges are the commands sent and outputs matched by the steps, and timedOut is true once a step timed out,
+	// they are used to categorize the infrastructure errors and are reported in the transcript of the test.
+	exchanges []Exchange
+	timedOut  bool
+	startTime time.Time
+	infraErr  *InfraError
 }
 
 // Run performs a test, returning the result and any encountered errors.
 func (t *Test) Run() (int, error) {
+	t.startTime = time.Now()
+	t.reportExchange()
 	err := t.runner.Run(t)
+	if IsAborted(err) {
+		log.Errorf("%s %s", t.tester.GetIdentifier().URL, err)
+		t.reportTranscript(ABORTED, err)
+		return ABORTED, err
+	}
 	// if the runner fails, print the error
 	if t.runner.Err != nil {
 		log.Errorf("%s", t.runner.Err)
 	}
-	return t.tester.Result(), err
+	if unmatched := t.unmatchedOutput(); err != nil && unmatched != "" {
+		err = fmt.Errorf("%w, unmatched output: %q", err, unmatched)
+	}
+	result := t.tester.Result()
+	if result == ERROR || err != nil {
+		t.categorize(err)
+	}
+	t.reportTranscript(result, err)
+	return result, err
+}
+
+// categorize sets the infrastructure error of a test which could not execute, from the output of its last step, matched
+// or not, its timeout or the error of its runner, and reports it to the handler set by SetInfraErrorHandler.  The test is left
+// uncategorized when none of them reports a known infrastructure error.
+func (t *Test) categorize(err error) {
+	var message string
+	if len(t.exchanges) > 0 {
+		message = t.exchanges[len(t.exchanges)-1].received()
+	}
+	category, ok := ClassifyOutput(message)
+	if !ok && t.timedOut {
+		category, ok = ExpecterTimeout, true
+	}
+	if !ok && err != nil {
+		message = err.Error()
+		category, ok = ClassifyOutput(message)
+	}
+	if !ok {
+		return
+	}
+	t.infraErr = &InfraError{Category: category, Test: t.tester.GetIdentifier().URL, Message: strings.TrimSpace(message)}
+	reportInfraError(t.infraErr)
+}
+
+// InfraError returns the infrastructure error which prevented the test from executing, or nil when the test executed
+// or its error could not be categorized.
+func (t *Test) InfraError() *InfraError {
+	return t.infraErr
+}
+
+// RunAndCheck performs a test, invoking the cb, if any, on failure.  It returns an error unless the test succeeded.
+func (t *Test) RunAndCheck(cb func()) error {
+	testResult, err := t.Run()
+	if testResult == FAILURE && cb != nil {
+		cb()
+	}
+	if err != nil {
+		return err
+	}
+	if testResult != SUCCESS {
+		if unmatched := t.unmatchedOutput(); t.timedOut && unmatched != "" {
+			return fmt.Errorf("%s failed with result %d, timed out with the unmatched output: %q", t.tester.GetIdentifier().URL,
+				testResult, unmatched)
+		}
+		return fmt.Errorf("%s failed with result %d", t.tester.GetIdentifier().URL, testResult)
+	}
+	return nil
 }
 
 func (t *Test) dispatch(fp reel.StepFunc) *reel.Step {
@@ -94,45 +175,52 @@ func (t *Test) ReelFirst() *reel.Step {
 	fp := func(handler reel.Handler) *reel.Step {
 		return handler.ReelFirst()
 	}
-	return t.dispatch(fp)
+	step := t.dispatch(fp)
+	t.recordStep(step)
+	return step
 }
 
 // ReelMatch calls the current Handler's ReelMatch function.
 func (t *Test) ReelMatch(pattern, before, match string) *reel.Step {
+	t.lastExchange().Output = before + match
+	t.reportExchange()
 	fp := func(handler reel.Handler) *reel.Step {
 		return handler.ReelMatch(pattern, before, match)
 	}
-	return t.dispatch(fp)
+	step := t.dispatch(fp)
+	t.recordStep(step)
+	return step
 }
 
 // ReelTimeout calls the current Handler's ReelTimeout function.
 func (t *Test) ReelTimeout() *reel.Step {
+	t.timedOut = true
+	t.lastExchange().TimedOut = true
+	t.reportExchange()
 	fp := func(handler reel.Handler) *reel.Step {
 		return handler.ReelTimeout()
 	}
-	return t.dispatch(fp)
+	step := t.dispatch(fp)
+	t.recordStep(step)
+	return step
 }
 
-// ReelEOF calls the current Handler's ReelEOF function.
-func (t *Test) ReelEOF() {
+// ReelUnmatchedOutput records the output received by the running step and not matched before it timed out or the
+// session ended, and forwards it to the Handlers implementing reel.UnmatchedOutputHandler.
+func (t *Test) ReelUnmatchedOutput(output string) {
+	t.lastExchange().Unmatched = output
 	for _, handler := range t.chain {
-		handler.ReelEOF()
+		if unmatchedOutputHandler, ok := handler.(reel.UnmatchedOutputHandler); ok {
+			unmatchedOutputHandler.ReelUnmatchedOutput(output)
+		}
 	}
 }
 
-// RunAndValidate runs the test and checks the result
-func (t *Test) RunAndValidate() {
-	t.RunAndValidateWithFailureCallback(nil)
-}
-
-// RunAndValidateWithFailureCallback runs the test, checks the result/error and invokes the cb on failure
-func (t *Test) RunAndValidateWithFailureCallback(cb func()) {
-	testResult, err := t.Run()
-	if testResult == FAILURE && cb != nil {
-		cb()
+// ReelEOF calls the current Handler's ReelEOF function.
+func (t *Test) ReelEOF() {
+	for _, handler := range t.chain {
+		handler.ReelEOF()
 	}
-	gomega.Expect(testResult).To(gomega.Equal(SUCCESS))
-	gomega.Expect(err).To(gomega.BeNil())
 }
 
 // RunWithCallbacks runs the test, invokes the cb on failure/error/success
@@ -148,19 +236,25 @@ func (t *Test) RunWithCallbacks(successCb, failureCb func(), errorCb func(error)
 		if failureCb != nil {
 			failureCb()
 		}
-	case ERROR:
+	case ERROR, ABORTED:
 		if errorCb != nil {
 			errorCb(err)
 		}
 	}
 }
 
-// NewTest creates a new Test given a chain of Handlers.
-func NewTest(expecter *expect.Expecter, tester Tester, chain []reel.Handler, errorChannel <-chan error, opts ...reel.Option) (*Test, error) {
+// NewTest creates a new Test given a chain of Handlers.  Once ctx is done, e.g. on SIGINT or when the deadline of the
+// run expires, the test is aborted and its session is closed.
+func NewTest(ctx context.Context, expecter *expect.Expecter, tester Tester, chain []reel.Handler, errorChannel <-chan error,
+	opts ...reel.Option) (*Test, error) {
 	args := tester.Args()
-	runner, err := reel.NewReel(expecter, args, errorChannel, opts...)
+	runner, err := reel.NewReel(expecter, args, errorChannel, append([]reel.Option{reel.WithContext(ctx)}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
-	return &Test{runner: runner, tester: tester, chain: chain}, nil
+	test := &Test{runner: runner, tester: tester, chain: chain, ctx: ctx}
+	if len(args) > 0 {
+		test.exchanges = []Exchange{{Execute: strings.Join(args, " ")}}
+	}
+	return test, nil
 }
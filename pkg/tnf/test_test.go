@@ -17,6 +17,7 @@
 package tnf_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -27,6 +28,7 @@ import (
 	expect "github.com/google/goexpect"
 	"github.com/stretchr/testify/assert"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
 	mock_interactive "github.com/test-network-function/test-network-function/pkg/tnf/interactive/mocks"
 	mock_tnf "github.com/test-network-function/test-network-function/pkg/tnf/mocks"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
@@ -84,7 +86,7 @@ func TestNewTest(t *testing.T) {
 
 		var expecter expect.Expecter = mockExpecter
 		var errorChannel <-chan error
-		testTest, err := tnf.NewTest(&expecter, mockTester, []reel.Handler{mockHandler}, errorChannel, reel.DisableTerminalPromptEmulation())
+		testTest, err := tnf.NewTest(context.Background(), &expecter, mockTester, []reel.Handler{mockHandler}, errorChannel, reel.DisableTerminalPromptEmulation())
 		assert.Equal(t, testCase.newTestErr, err)
 		assert.Equal(t, testCase.newTestIsNil, testTest == nil)
 	}
@@ -183,7 +185,7 @@ func TestTest_Run(t *testing.T) {
 
 		var expecter expect.Expecter = mockExpecter
 		var errorChannel <-chan error
-		test, err := tnf.NewTest(&expecter, mockTester, []reel.Handler{mockHandler}, errorChannel, reel.DisableTerminalPromptEmulation())
+		test, err := tnf.NewTest(context.Background(), &expecter, mockTester, []reel.Handler{mockHandler}, errorChannel, reel.DisableTerminalPromptEmulation())
 		assert.Nil(t, err)
 		assert.NotNil(t, test)
 		result, err := test.Run()
@@ -194,6 +196,59 @@ func TestTest_Run(t *testing.T) {
 	}
 }
 
+func TestTest_RunAndCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	for _, testerResult := range []int{tnf.SUCCESS, tnf.FAILURE, tnf.ERROR} {
+		mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+		mockExpecter.EXPECT().Send(gomock.Any()).AnyTimes()
+		mockTester := mock_tnf.NewMockTester(ctrl)
+		mockTester.EXPECT().Args().Return(defaultTestCommand)
+		mockTester.EXPECT().Result().Return(testerResult)
+		mockTester.EXPECT().GetIdentifier().Return(identifier.Identifier{URL: "http://test-network-function.com/tests/fake"}).AnyTimes()
+		mockHandler := mock_reel.NewMockHandler(ctrl)
+		mockHandler.EXPECT().ReelFirst().Return(nil)
+
+		var expecter expect.Expecter = mockExpecter
+		var errorChannel <-chan error
+		test, err := tnf.NewTest(context.Background(), &expecter, mockTester, []reel.Handler{mockHandler}, errorChannel, reel.DisableTerminalPromptEmulation())
+		assert.Nil(t, err)
+		callbackInvoked := false
+		err = test.RunAndCheck(func() { callbackInvoked = true })
+		assert.Equal(t, testerResult == tnf.SUCCESS, err == nil)
+		assert.Equal(t, testerResult == tnf.FAILURE, callbackInvoked)
+	}
+}
+
+func TestTest_RunAborted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+	mockExpecter.EXPECT().Send(gomock.Any()).AnyTimes()
+	mockTester := mock_tnf.NewMockTester(ctrl)
+	mockTester.EXPECT().Args().Return(defaultTestCommand)
+	mockTester.EXPECT().GetIdentifier().Return(identifier.Identifier{URL: "http://test-network-function.com/tests/fake"}).AnyTimes()
+	mockHandler := mock_reel.NewMockHandler(ctrl)
+	mockHandler.EXPECT().ReelFirst().Return(&reel.Step{Expect: []string{"never"}, Timeout: testTimeoutDuration})
+
+	// the context is canceled after the command was sent, the first step is aborted.
+	ctx, cancel := context.WithCancel(context.Background())
+	var expecter expect.Expecter = mockExpecter
+	var errorChannel <-chan error
+	test, err := tnf.NewTest(ctx, &expecter, mockTester, []reel.Handler{mockHandler}, errorChannel,
+		reel.DisableTerminalPromptEmulation())
+	assert.Nil(t, err)
+	cancel()
+	errorCbInvoked := false
+	test.RunWithCallbacks(nil, nil, func(err error) {
+		errorCbInvoked = true
+		assert.True(t, tnf.IsAborted(err))
+	})
+	assert.True(t, errorCbInvoked)
+}
+
 func TestTest_ReelTimeout(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -209,7 +264,7 @@ func TestTest_ReelTimeout(t *testing.T) {
 	var expecter expect.Expecter = mockExpecter
 	var errorChannel <-chan error
 
-	test, err := tnf.NewTest(&expecter, mockTester, []reel.Handler{mockHandler}, errorChannel)
+	test, err := tnf.NewTest(context.Background(), &expecter, mockTester, []reel.Handler{mockHandler}, errorChannel)
 
 	assert.Nil(t, err)
 	step := test.ReelTimeout()
@@ -231,9 +286,196 @@ func TestTest_ReelEof(t *testing.T) {
 	var expecter expect.Expecter = mockExpecter
 	var errorChannel <-chan error
 
-	test, err := tnf.NewTest(&expecter, mockTester, []reel.Handler{mockHandler}, errorChannel)
+	test, err := tnf.NewTest(context.Background(), &expecter, mockTester, []reel.Handler{mockHandler}, errorChannel)
 
 	assert.Nil(t, err)
 	// just ensure there are no panics
 	test.ReelEOF()
 }
+
+type infraErrorTestCase struct {
+	output           string
+	batchErr         error
+	runErr           bool
+	testerResult     int
+	expectedCategory tnf.ErrorCategory
+}
+
+var infraErrorTestCases = map[string]infraErrorTestCase{
+	"tool_missing": {
+		output:           "sh: iperf3: command not found\n",
+		testerResult:     tnf.ERROR,
+		expectedCategory: tnf.ToolMissing,
+	},
+	"permission_denied": {
+		output:           "cat: /proc/1/environ: Permission denied\n",
+		testerResult:     tnf.ERROR,
+		expectedCategory: tnf.PermissionDenied,
+	},
+	"target_gone": {
+		output:           "Error from server (NotFound): pods \"test\" not found\n",
+		testerResult:     tnf.ERROR,
+		expectedCategory: tnf.TargetGone,
+	},
+	"expecter_timeout": {
+		batchErr:         expect.TimeoutError(testTimeoutDuration),
+		testerResult:     tnf.ERROR,
+		expectedCategory: tnf.ExpecterTimeout,
+	},
+	"session_closed": {
+		batchErr:         errors.New("expect: Process not running"),
+		runErr:           true,
+		testerResult:     tnf.ERROR,
+		expectedCategory: tnf.TargetGone,
+	},
+	"uncategorized_error": {
+		output:       "unexpected output\n",
+		testerResult: tnf.ERROR,
+	},
+	"failure": {
+		output:       "sh: iperf3: command not found\n",
+		testerResult: tnf.FAILURE,
+	},
+}
+
+func TestTest_InfraError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var reported []*tnf.InfraError
+	tnf.SetInfraErrorHandler(func(infraErr *tnf.InfraError) {
+		reported = append(reported, infraErr)
+	})
+	defer tnf.SetInfraErrorHandler(nil)
+
+	for name, testCase := range infraErrorTestCases {
+		reported = nil
+		mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+		mockExpecter.EXPECT().Send(gomock.Any()).AnyTimes()
+		output := testCase.output + fakeSentinelOutput()
+		mockExpecter.EXPECT().ExpectBatch(gomock.Any(), gomock.Any()).Return(
+			[]expect.BatchRes{{Idx: 0, Output: output, Match: []string{output}}}, testCase.batchErr)
+		mockTester := mock_tnf.NewMockTester(ctrl)
+		mockTester.EXPECT().Args().Return(defaultTestCommand)
+		mockTester.EXPECT().Result().Return(testCase.testerResult)
+		mockTester.EXPECT().GetIdentifier().Return(identifier.Identifier{URL: "http://test-network-function.com/tests/fake"}).AnyTimes()
+		mockHandler := mock_reel.NewMockHandler(ctrl)
+		mockHandler.EXPECT().ReelFirst().Return(&reel.Step{Expect: []string{".*"}, Timeout: testTimeoutDuration})
+		mockHandler.EXPECT().ReelMatch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		mockHandler.EXPECT().ReelTimeout().Return(nil).AnyTimes()
+		mockHandler.EXPECT().ReelEOF().AnyTimes()
+
+		var expecter expect.Expecter = mockExpecter
+		var errorChannel <-chan error
+		test, err := tnf.NewTest(context.Background(), &expecter, mockTester, []reel.Handler{mockHandler}, errorChannel, reel.DisableTerminalPromptEmulation())
+		assert.Nil(t, err)
+		result, err := test.Run()
+		assert.Equal(t, testCase.testerResult, result, name)
+		assert.Equal(t, testCase.runErr, err != nil, name)
+		if testCase.expectedCategory == "" {
+			assert.Nil(t, test.InfraError(), name)
+			assert.Empty(t, reported, name)
+			continue
+		}
+		if assert.NotNil(t, test.InfraError(), name) {
+			assert.Equal(t, testCase.expectedCategory, test.InfraError().Category, name)
+			assert.Equal(t, "http://test-network-function.com/tests/fake", test.InfraError().Test, name)
+		}
+		assert.Equal(t, []*tnf.InfraError{test.InfraError()}, reported, name)
+	}
+}
+
+func TestTest_Transcript(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var transcripts []*tnf.Transcript
+	tnf.SetTranscriptHandler(func(transcript *tnf.Transcript) {
+		transcripts = append(transcripts, transcript)
+	})
+	defer tnf.SetTranscriptHandler(nil)
+	var exchanges []tnf.Exchange
+	tnf.SetExchangeHandler(func(test string, exchange tnf.Exchange) {
+		assert.Equal(t, "http://test-network-function.com/tests/fake", test)
+		exchanges = append(exchanges, exchange)
+	})
+	defer tnf.SetExchangeHandler(nil)
+
+	mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+	mockExpecter.EXPECT().Send(gomock.Any()).AnyTimes()
+	output := "file" + fakeSentinelOutput()
+	gomock.InOrder(
+		mockExpecter.EXPECT().ExpectBatch(gomock.Any(), gomock.Any()).Return(
+			[]expect.BatchRes{{Idx: 0, Output: output, Match: []string{output}}}, nil),
+		mockExpecter.EXPECT().ExpectBatch(gomock.Any(), gomock.Any()).Return(
+			[]expect.BatchRes{{Idx: 0, Output: "partial"}}, expect.TimeoutError(testTimeoutDuration)),
+	)
+	mockTester := mock_tnf.NewMockTester(ctrl)
+	mockTester.EXPECT().Args().Return(defaultTestCommand)
+	mockTester.EXPECT().Result().Return(tnf.FAILURE)
+	mockTester.EXPECT().GetIdentifier().Return(identifier.Identifier{URL: "http://test-network-function.com/tests/fake"}).AnyTimes()
+	mockHandler := mock_reel.NewMockHandler(ctrl)
+	mockHandler.EXPECT().ReelFirst().Return(&reel.Step{Expect: []string{".*"}, Timeout: testTimeoutDuration})
+	mockHandler.EXPECT().ReelMatch(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&reel.Step{Execute: "cat file", Expect: []string{".*"}, Timeout: testTimeoutDuration})
+	mockHandler.EXPECT().ReelTimeout().Return(nil)
+
+	var expecter expect.Expecter = mockExpecter
+	var errorChannel <-chan error
+	test, err := tnf.NewTest(context.Background(), &expecter, mockTester, []reel.Handler{mockHandler}, errorChannel, reel.DisableTerminalPromptEmulation())
+	assert.Nil(t, err)
+	result, err := test.Run()
+	assert.Nil(t, err)
+	assert.Equal(t, tnf.FAILURE, result)
+	if assert.Len(t, transcripts, 1) {
+		assert.Equal(t, "http://test-network-function.com/tests/fake", transcripts[0].Test)
+		assert.Equal(t, "FAILURE", transcripts[0].Result)
+		assert.Equal(t, []tnf.Exchange{
+			{Execute: "ls", Output: output},
+			{Execute: "cat file", Unmatched: "partial", TimedOut: true},
+		}, transcripts[0].Exchanges)
+	}
+	// the exchanges are reported as the commands are sent, and again with their output.
+	assert.Equal(t, []tnf.Exchange{
+		{Execute: "ls"},
+		{Execute: "ls", Output: output},
+		{Execute: "cat file"},
+		{Execute: "cat file", Unmatched: "partial", TimedOut: true},
+	}, exchanges)
+}
+
+func TestTest_UnmatchedOutput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	for name, batchErr := range map[string]error{
+		"timeout": expect.TimeoutError(testTimeoutDuration),
+		"eof":     errors.New("expect: Process not running"),
+	} {
+		mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+		mockExpecter.EXPECT().Send(gomock.Any()).AnyTimes()
+		mockExpecter.EXPECT().ExpectBatch(gomock.Any(), gomock.Any()).Return(
+			[]expect.BatchRes{{Idx: 0, Output: "Waiting for the lock"}}, batchErr)
+		mockTester := mock_tnf.NewMockTester(ctrl)
+		mockTester.EXPECT().Args().Return(defaultTestCommand)
+		mockTester.EXPECT().Result().Return(tnf.ERROR)
+		mockTester.EXPECT().GetIdentifier().Return(identifier.Identifier{URL: "http://test-network-function.com/tests/fake"}).AnyTimes()
+		mockHandler := mock_reel.NewMockHandler(ctrl)
+		mockHandler.EXPECT().ReelFirst().Return(&reel.Step{Expect: []string{"never"}, Timeout: testTimeoutDuration})
+		mockHandler.EXPECT().ReelTimeout().Return(nil).AnyTimes()
+		mockHandler.EXPECT().ReelEOF().AnyTimes()
+
+		var expecter expect.Expecter = mockExpecter
+		var errorChannel <-chan error
+		test, err := tnf.NewTest(context.Background(), &expecter, mockTester, []reel.Handler{mockHandler}, errorChannel, reel.DisableTerminalPromptEmulation())
+		assert.Nil(t, err)
+		// the failure message carries the output received before the timeout or the end of the session.
+		err = test.RunAndCheck(nil)
+		if assert.NotNil(t, err, name) {
+			assert.Contains(t, err.Error(), `"Waiting for the lock"`, name)
+		}
+		if assert.NotNil(t, test.InfraError(), name) {
+			assert.Contains(t, test.InfraError().Message, "Waiting for the lock", name)
+		}
+	}
+}
@@ -347,31 +347,33 @@ func IsInFocus(focus []string, desc string) bool {
 }
 
 // GetConfiguredPodTests loads the `configuredTestFile` and extracts
-// the names of test groups from it.
+// the names of test groups from it, followed by the registered packs of pod and container test cases.
 func GetConfiguredPodTests() (cnfTests []string) {
 	configuredTests, err := LoadConfiguredTestFile(ConfiguredTestFile)
 	if err != nil {
-		log.Errorf("failed to load %s, continuing with no tests", ConfiguredTestFile)
-		return []string{}
+		log.Errorf("failed to load %s, continuing with the test case packs only", ConfiguredTestFile)
+		return GetRegisteredPacks(Cnf)
 	}
 	for _, configuredTest := range configuredTests.CnfTest {
 		cnfTests = append(cnfTests, configuredTest.Name)
 	}
+	cnfTests = append(cnfTests, GetRegisteredPacks(Cnf)...)
 	log.WithField("cnfTests", cnfTests).Infof("got all tests from %s.", ConfiguredTestFile)
 	return cnfTests
 }
 
 // GetConfiguredOperatorTests loads the `configuredTestFile` and extracts
-// the names of test groups from it.
+// the names of test groups from it, followed by the registered packs of operator test cases.
 func GetConfiguredOperatorTests() (operatorTests []string) {
 	configuredTests, err := LoadConfiguredTestFile(ConfiguredTestFile)
 	if err != nil {
-		log.Errorf("failed to load %s, continuing with no tests", ConfiguredTestFile)
-		return []string{}
+		log.Errorf("failed to load %s, continuing with the test case packs only", ConfiguredTestFile)
+		return GetRegisteredPacks(Operator)
 	}
 	for _, configuredTest := range configuredTests.OperatorTest {
 		operatorTests = append(operatorTests, configuredTest.Name)
 	}
+	operatorTests = append(operatorTests, GetRegisteredPacks(Operator)...)
 	log.WithField("operatorTests", operatorTests).Infof("got all tests from %s.", ConfiguredTestFile)
 	return operatorTests
 }
@@ -0,0 +1,35 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package testcases
+
+// TestClassification classifies test cases by their impact on the cluster under test.
+type TestClassification string
+
+const (
+	// Safe tests only read the state of the cluster and the CNF.
+	Safe TestClassification = "safe"
+	// Intrusive tests temporarily alter the CNF, e.g. by scaling its deployments or deleting its pods.
+	Intrusive TestClassification = "intrusive"
+	// Destructive tests disrupt the cluster itself, e.g. by draining or rebooting nodes.
+	Destructive TestClassification = "destructive"
+)
+
+// IsIntrusive returns true for the classifications that must be explicitly enabled to run, i.e. Intrusive and
+// Destructive.  An empty classification is considered Safe.
+func (c TestClassification) IsIntrusive() bool {
+	return c == Intrusive || c == Destructive
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package testcases_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+)
+
+func TestTestClassificationIsIntrusive(t *testing.T) {
+	assert.False(t, testcases.Safe.IsIntrusive())
+	assert.False(t, testcases.TestClassification("").IsIntrusive())
+	assert.True(t, testcases.Intrusive.IsIntrusive())
+	assert.True(t, testcases.Destructive.IsIntrusive())
+}
@@ -53,7 +53,7 @@ var PrivilegedPodJSON = string(`{
       "name": "HOST_IPC_CHECK",
       "skiptest": true,
       "loop": 0,
-      "command": "oc get pod  %s  -n %s -o json  | jq -r '.spec.hostipc'",
+      "command": "oc get pod  %s  -n %s -o json  | jq -r '.spec.hostIPC'",
       "action": "allow",
       "expectedstatus": [
         "NULL_FALSE"
@@ -63,7 +63,7 @@ var PrivilegedPodJSON = string(`{
       "name": "HOST_PID_CHECK",
       "skiptest": true,
        "loop": 0,
-      "command": "oc get pod  %s  -n %s -o json  | jq -r '.spec.hostpid'",
+      "command": "oc get pod  %s  -n %s -o json  | jq -r '.spec.hostPID'",
       "action": "allow",
       "expectedstatus": [
         "NULL_FALSE"
@@ -0,0 +1,54 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package testcases
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// SpecFocus selects the spec of a test case of a suite.
+type SpecFocus struct {
+	Suite string
+	// Leaf is a regular expression matching the end of the spec text, e.g. the quoted Ginkgo identifier of the test
+	// case.
+	Leaf string
+}
+
+// SuiteFocusString returns the Ginkgo focus regular expression matching the name of suite alone, so that the suite
+// registers its specs (see IsInFocus) without focusing on all of them.
+func SuiteFocusString(suite string) string {
+	return "^" + regexp.QuoteMeta(suite) + "$"
+}
+
+// SpecsFocusStrings returns the Ginkgo focus regular expressions selecting only specs: the SuiteFocusString of each of
+// their suites, then one expression per spec matching its spec text.  Ginkgo prefixes the spec text with the suite
+// description, hence the expressions are not anchored at the start of the text.
+func SpecsFocusStrings(specs []SpecFocus) []string {
+	suites := map[string]bool{}
+	var tests []string
+	for _, spec := range specs {
+		suites[spec.Suite] = true
+		tests = append(tests, fmt.Sprintf("(^| )%s (.* )?%s$", regexp.QuoteMeta(spec.Suite), spec.Leaf))
+	}
+	focus := make([]string, 0, len(suites)+len(tests))
+	for suite := range suites {
+		focus = append(focus, SuiteFocusString(suite))
+	}
+	sort.Strings(focus)
+	return append(focus, tests...)
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package testcases
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/jsonschema"
+	"gopkg.in/yaml.v2"
+)
+
+// PackTarget is the type of the resources the test cases of a pack run against.
+type PackTarget string
+
+const (
+	// PackTargetPod runs the command once per pod under test, with the name and the namespace of the pod.
+	PackTargetPod PackTarget = "pod"
+	// PackTargetContainer runs the command once per container of the pods under test, with the name and the namespace
+	// of the pod and the index of the container.
+	PackTargetContainer PackTarget = "container"
+	// PackTargetOperator runs the command once per operator under test, with the name and the namespace of its CSV.
+	PackTargetOperator PackTarget = "operator"
+)
+
+// Pack is a set of user-defined test cases, read at runtime from a YAML or JSON file.
+type Pack struct {
+	// Name is the name of the pack, under which its test cases are registered alongside the built-in templates.
+	Name string `yaml:"name" json:"name"`
+	// Description describes the test cases of the pack.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// TestCase is the list of the test cases of the pack.
+	TestCase []PackTestCase `yaml:"testcase" json:"testcase"`
+}
+
+// PackTestCase is a test case of a pack: a command run against each target, whose output is checked against the
+// expected status as for the built-in test cases.
+type PackTestCase struct {
+	BaseTestCase `yaml:",inline"`
+	// Description describes what the test case checks.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Target is the type of the resources the command runs against.
+	Target PackTarget `yaml:"target" json:"target"`
+}
+
+// packExtensions are the extensions of the pack files, the other files of a pack directory being ignored.
+var packExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// registeredPacks are the names of the registered packs, by spec type.
+var registeredPacks = map[TestSpecType][]string{}
+
+// LoadPacks reads the packs of the YAML and JSON files of dir, in the order of their file names, each validated against
+// the JSON schema of schemaPath.
+func LoadPacks(dir, schemaPath string) ([]Pack, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var packs []Pack
+	for _, entry := range entries {
+		if entry.IsDir() || !packExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			continue
+		}
+		pack, loadErr := LoadPack(filepath.Join(dir, entry.Name()), schemaPath)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		packs = append(packs, *pack)
+	}
+	return packs, nil
+}
+
+// LoadPack reads a pack from a YAML or JSON file, validated against the JSON schema of schemaPath.
+func LoadPack(path, schemaPath string) (*Pack, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// YAML being a superset of JSON, both are converted to JSON to be validated against the schema.
+	var document interface{}
+	if err = yaml.Unmarshal(contents, &document); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	jsonContents, err := json.Marshal(toJSONValue(document))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	result, err := jsonschema.ValidateJSONAgainstSchema(jsonContents, schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if !result.Valid() {
+		var errs []string
+		for _, resultErr := range result.Errors() {
+			errs = append(errs, resultErr.String())
+		}
+		return nil, fmt.Errorf("%s: invalid test case pack: %s", path, strings.Join(errs, "; "))
+	}
+	pack := &Pack{}
+	if err = json.Unmarshal(jsonContents, pack); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return pack, nil
+}
+
+// toJSONValue converts the maps decoded from YAML, keyed by interface{}, to maps keyed by string which can be encoded
+// as JSON.
+func toJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = toJSONValue(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = toJSONValue(item)
+		}
+	}
+	return value
+}
+
+// RegisterPack registers the test cases of the pack alongside the built-in templates: the pod and container test cases
+// run with the CNF templates, the operator test cases with the operator templates.  The names of the packs and of
+// their test cases must not clash with the built-in ones.
+func RegisterPack(pack *Pack) error {
+	if _, ok := PodTestTemplateDataMap[pack.Name]; ok {
+		return fmt.Errorf("the test case pack %s is already registered", pack.Name)
+	}
+	if _, ok := OperatorTestTemplateDataMap[pack.Name]; ok {
+		return fmt.Errorf("the test case pack %s is already registered", pack.Name)
+	}
+	knownNames, err := getTestCaseNames()
+	if err != nil {
+		return err
+	}
+	specs := map[TestSpecType]*BaseTestCaseConfigSpec{}
+	for i := range pack.TestCase {
+		testCase := pack.TestCase[i].BaseTestCase
+		if knownNames[testCase.Name] {
+			return fmt.Errorf("the test case %s of the pack %s is already registered", testCase.Name, pack.Name)
+		}
+		knownNames[testCase.Name] = true
+		testCase.SkipTest = false
+		testCase.Loop = 0
+		specType := Cnf
+		switch pack.TestCase[i].Target {
+		case PackTargetContainer:
+			testCase.Loop = 1
+		case PackTargetOperator:
+			specType = Operator
+		}
+		if testCase.ResultType == "" {
+			testCase.ResultType = StringType
+		}
+		if specs[specType] == nil {
+			specs[specType] = &BaseTestCaseConfigSpec{}
+		}
+		specs[specType].TestCase = append(specs[specType].TestCase, testCase)
+	}
+	for specType, spec := range specs {
+		data, marshalErr := json.Marshal(spec)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		if specType == Operator {
+			OperatorTestTemplateDataMap[pack.Name] = string(data)
+		} else {
+			PodTestTemplateDataMap[pack.Name] = string(data)
+		}
+		registeredPacks[specType] = append(registeredPacks[specType], pack.Name)
+	}
+	return nil
+}
+
+// GetRegisteredPacks returns the names of the registered packs holding test cases of the spec type, sorted.
+func GetRegisteredPacks(testSpecType TestSpecType) []string {
+	names := append([]string{}, registeredPacks[testSpecType]...)
+	sort.Strings(names)
+	return names
+}
+
+// getTestCaseNames returns the names of the test cases of the registered templates.
+func getTestCaseNames() (map[string]bool, error) {
+	names := map[string]bool{}
+	for _, templates := range []map[string]string{PodTestTemplateDataMap, OperatorTestTemplateDataMap} {
+		for templateName, data := range templates {
+			var spec BaseTestCaseConfigSpec
+			if err := json.Unmarshal([]byte(data), &spec); err != nil {
+				return nil, fmt.Errorf("template %s: %w", templateName, err)
+			}
+			for i := range spec.TestCase {
+				names[spec.TestCase[i].Name] = true
+			}
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package testcases_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+)
+
+var packSchemaPath = filepath.Join("..", "..", "..", "schemas", "testcase-pack.schema.json")
+
+func TestLoadPacks(t *testing.T) {
+	packs, err := testcases.LoadPacks(filepath.Join("testdata", "packs"), packSchemaPath)
+	assert.Nil(t, err)
+	assert.Len(t, packs, 2)
+	assert.Equal(t, "MY_NETWORKING", packs[0].Name)
+	assert.Len(t, packs[0].TestCase, 2)
+	assert.Equal(t, "MY_DNS_POLICY_CHECK", packs[0].TestCase[0].Name)
+	assert.Equal(t, testcases.PackTargetPod, packs[0].TestCase[0].Target)
+	assert.Equal(t, testcases.RegEx, packs[0].TestCase[0].ExpectedType)
+	assert.Equal(t, []string{"^ClusterFirst$"}, packs[0].TestCase[0].ExpectedStatus)
+	assert.Equal(t, testcases.PackTargetContainer, packs[0].TestCase[1].Target)
+	assert.Equal(t, "MY_OPERATORS", packs[1].Name)
+	assert.Equal(t, testcases.PackTargetOperator, packs[1].TestCase[0].Target)
+
+	_, err = testcases.LoadPacks(filepath.Join("testdata", "invalidpacks"), packSchemaPath)
+	assert.NotNil(t, err)
+	_, err = testcases.LoadPacks(invalidFilePath, packSchemaPath)
+	assert.NotNil(t, err)
+}
+
+func TestRegisterPack(t *testing.T) {
+	packs, err := testcases.LoadPacks(filepath.Join("testdata", "packs"), packSchemaPath)
+	assert.Nil(t, err)
+	for i := range packs {
+		assert.Nil(t, testcases.RegisterPack(&packs[i]))
+	}
+	assert.Equal(t, []string{"MY_NETWORKING"}, testcases.GetRegisteredPacks(testcases.Cnf))
+	assert.Equal(t, []string{"MY_OPERATORS"}, testcases.GetRegisteredPacks(testcases.Operator))
+
+	spec, err := testcases.LoadCnfTestCaseSpecs("MY_NETWORKING")
+	assert.Nil(t, err)
+	assert.Len(t, spec.TestCase, 2)
+	assert.False(t, spec.TestCase[0].SkipTest)
+	assert.Equal(t, 0, spec.TestCase[0].Loop)
+	assert.Equal(t, testcases.StringType, spec.TestCase[0].ResultType)
+	// the container test cases loop over the containers of the pods.
+	assert.Equal(t, 1, spec.TestCase[1].Loop)
+	spec, err = testcases.LoadOperatorTestCaseSpecs("MY_OPERATORS")
+	assert.Nil(t, err)
+	assert.Equal(t, "MY_CSV_PHASE_CHECK", spec.TestCase[0].Name)
+
+	// neither the packs nor their test cases may clash with the registered ones.
+	assert.NotNil(t, testcases.RegisterPack(&packs[0]))
+	assert.NotNil(t, testcases.RegisterPack(&testcases.Pack{Name: testcases.PrivilegedPod}))
+	clash := testcases.Pack{Name: "MY_CLASH", TestCase: []testcases.PackTestCase{
+		{BaseTestCase: testcases.BaseTestCase{Name: "HOST_NETWORK_CHECK"}, Target: testcases.PackTargetPod},
+	}}
+	assert.NotNil(t, testcases.RegisterPack(&clash))
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package testcases
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Tag labels test cases across the suites, e.g. the security or the telco test cases, to select them.
+type Tag string
+
+const (
+	// TagSecurity labels the test cases checking the security of the CNF.
+	TagSecurity Tag = "security"
+	// TagTelco labels the test cases checking the requirements specific to the telco workloads, e.g. SCTP or
+	// hugepages.
+	TagTelco Tag = "telco"
+	// TagCertification labels the test cases checking the certification of the images, operators and charts.
+	TagCertification Tag = "certification"
+	// TagLoad labels the test cases generating load on the cluster, e.g. the throughput measurement.
+	TagLoad Tag = "load"
+	// TagOpenShift labels the test cases only running on the OpenShift clusters.
+	TagOpenShift Tag = "openshift"
+)
+
+// TaggedTestCase is a test case of a suite with its tags.
+type TaggedTestCase struct {
+	Suite string
+	// ID is the Ginkgo identifier of the test case, e.g. "networking-icmpv4-connectivity".
+	ID   string
+	Tags []Tag
+}
+
+// HasTag returns true when the test case has one of tags.
+func (t *TaggedTestCase) HasTag(tags []Tag) bool {
+	for _, tag := range t.Tags {
+		for _, other := range tags {
+			if tag == other {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SelectByTags returns the test cases having one of the include tags, or all of them when include is empty, and none
+// of the exclude tags.
+func SelectByTags(testCases []TaggedTestCase, include, exclude []Tag) []TaggedTestCase {
+	var selected []TaggedTestCase
+	for i := range testCases {
+		if (len(include) == 0 || testCases[i].HasTag(include)) && !testCases[i].HasTag(exclude) {
+			selected = append(selected, testCases[i])
+		}
+	}
+	return selected
+}
+
+// ParseTags returns the tags of names, an error for the tags not in known.
+func ParseTags(names []string, known map[Tag]bool) ([]Tag, error) {
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		if !known[Tag(name)] {
+			return nil, fmt.Errorf("unknown tag %q", name)
+		}
+		tags = append(tags, Tag(name))
+	}
+	return tags, nil
+}
+
+// FocusStrings returns the Ginkgo focus regular expressions selecting only testCases, see SpecsFocusStrings.  The spec
+// text of a test case may extend its identifier with a suffix.
+func FocusStrings(testCases []TaggedTestCase) []string {
+	specs := make([]SpecFocus, 0, len(testCases))
+	for i := range testCases {
+		specs = append(specs, SpecFocus{Suite: testCases[i].Suite, Leaf: regexp.QuoteMeta(testCases[i].ID) + "(-[^ ]+)?"})
+	}
+	return SpecsFocusStrings(specs)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package testcases_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+)
+
+var taggedTestCases = []testcases.TaggedTestCase{
+	{Suite: "networking", ID: "networking-icmpv4-connectivity", Tags: []testcases.Tag{"networking"}},
+	{Suite: "networking", ID: "networking-sctp-connectivity", Tags: []testcases.Tag{"networking", testcases.TagTelco}},
+	{Suite: "access-control", ID: "access-control-host-network", Tags: []testcases.Tag{"access-control",
+		testcases.TagSecurity}},
+	{Suite: "lifecycle", ID: "lifecycle-scaling", Tags: []testcases.Tag{"lifecycle", "intrusive"}},
+}
+
+func TestSelectByTags(t *testing.T) {
+	ids := func(testCases []testcases.TaggedTestCase) []string {
+		var result []string
+		for i := range testCases {
+			result = append(result, testCases[i].ID)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"networking-sctp-connectivity", "access-control-host-network"},
+		ids(testcases.SelectByTags(taggedTestCases, []testcases.Tag{testcases.TagTelco, testcases.TagSecurity}, nil)))
+	assert.Equal(t, []string{"networking-icmpv4-connectivity", "access-control-host-network"},
+		ids(testcases.SelectByTags(taggedTestCases, nil, []testcases.Tag{testcases.TagTelco, "intrusive"})))
+	assert.Equal(t, []string{"networking-icmpv4-connectivity"}, ids(testcases.SelectByTags(taggedTestCases,
+		[]testcases.Tag{"networking"}, []testcases.Tag{testcases.TagTelco})))
+}
+
+func TestParseTags(t *testing.T) {
+	known := map[testcases.Tag]bool{testcases.TagTelco: true, "networking": true}
+	tags, err := testcases.ParseTags([]string{"telco", "networking"}, known)
+	assert.Nil(t, err)
+	assert.Equal(t, []testcases.Tag{testcases.TagTelco, "networking"}, tags)
+	_, err = testcases.ParseTags([]string{"telecom"}, known)
+	assert.NotNil(t, err)
+}
+
+func TestFocusStrings(t *testing.T) {
+	focus := testcases.FocusStrings(taggedTestCases[:2])
+	assert.Equal(t, "^networking$", focus[0])
+	assert.True(t, testcases.IsInFocus(focus, "networking"))
+	assert.False(t, testcases.IsInFocus(focus, "lifecycle"))
+	re := regexp.MustCompile(strings.Join(focus, "|"))
+	assert.True(t, re.MatchString("networking Both Pods are on the Default network networking-icmpv4-connectivity"))
+	assert.True(t, re.MatchString("networking networking-sctp-connectivity-tnf-pod"))
+	assert.False(t, re.MatchString("networking networking-icmpv6-connectivity"))
+	assert.False(t, re.MatchString("lifecycle lifecycle-scaling"))
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tnf
+
+import (
+	"sync"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+// Exchange is a step of a test: the command sent to the session, if any, and the output it matched.  Unmatched is the
+// output received and not matched before the step timed out or the session ended.
+type Exchange struct {
+	Execute   string `json:"execute,omitempty"`
+	Output    string `json:"output,omitempty"`
+	Unmatched string `json:"unmatched,omitempty"`
+	TimedOut  bool   `json:"timedOut,omitempty"`
+}
+
+// received returns the output of the exchange, matched or not.
+func (e *Exchange) received() string {
+	if e.Output != "" {
+		return e.Output
+	}
+	return e.Unmatched
+}
+
+// Transcript records the run of a test, so that a failure can be investigated without access to the cluster.
+type Transcript struct {
+	// Test is the URL of the identifier of the handler.
+	Test      string        `json:"test"`
+	StartTime time.Time     `json:"startTime"`
+	Duration  time.Duration `json:"duration"`
+	Exchanges []Exchange    `json:"exchanges"`
+	Result    string        `json:"result"`
+	Error     string        `json:"error,omitempty"`
+}
+
+var (
+	transcriptHandler     func(*Transcript)
+	transcriptHandlerLock sync.RWMutex
+	exchangeHandler       func(test string, exchange Exchange)
+	exchangeHandlerLock   sync.RWMutex
+)
+
+// SetTranscriptHandler sets the function called with the transcript of each test once run, e.g. to persist the
+// transcripts of the failed specs.  The handler can be called concurrently by the tests run in parallel.
+func SetTranscriptHandler(handler func(*Transcript)) {
+	transcriptHandlerLock.Lock()
+	defer transcriptHandlerLock.Unlock()
+	transcriptHandler = handler
+}
+
+// reportTranscript calls the handler set by SetTranscriptHandler with the transcript of t, if any handler is set.
+func (t *Test) reportTranscript(result int, err error) {
+	transcriptHandlerLock.RLock()
+	defer transcriptHandlerLock.RUnlock()
+	if transcriptHandler == nil {
+		return
+	}
+	transcript := &Transcript{
+		Test:      t.tester.GetIdentifier().URL,
+		StartTime: t.startTime,
+		Duration:  time.Since(t.startTime),
+		Exchanges: t.exchanges,
+		Result:    resultNames[result],
+	}
+	if err != nil {
+		transcript.Error = err.Error()
+	}
+	transcriptHandler(transcript)
+}
+
+// SetExchangeHandler sets the function called, while the tests run, with each command they send and again once its
+// output is matched or it times out, e.g. to follow a run live.  The handler can be called concurrently by the tests run
+// in parallel.
+func SetExchangeHandler(handler func(test string, exchange Exchange)) {
+	exchangeHandlerLock.Lock()
+	defer exchangeHandlerLock.Unlock()
+	exchangeHandler = handler
+}
+
+// reportExchange calls the handler set by SetExchangeHandler with the last exchange of t, if any.
+func (t *Test) reportExchange() {
+	exchangeHandlerLock.RLock()
+	defer exchangeHandlerLock.RUnlock()
+	if exchangeHandler == nil || len(t.exchanges) == 0 {
+		return
+	}
+	exchangeHandler(t.tester.GetIdentifier().URL, t.exchanges[len(t.exchanges)-1])
+}
+
+// recordStep records the command sent by step, if any.
+func (t *Test) recordStep(step *reel.Step) {
+	if step != nil && step.Execute != "" {
+		t.exchanges = append(t.exchanges, Exchange{Execute: step.Execute})
+		t.reportExchange()
+	}
+}
+
+// unmatchedOutput returns the output of the last step which was not matched before it timed out or the session ended,
+// if any.
+func (t *Test) unmatchedOutput() string {
+	if len(t.exchanges) == 0 {
+		return ""
+	}
+	return t.exchanges[len(t.exchanges)-1].Unmatched
+}
+
+// lastExchange returns the exchange the output or timeout of the running step belongs to.
+func (t *Test) lastExchange() *Exchange {
+	if len(t.exchanges) == 0 || t.exchanges[len(t.exchanges)-1].Output != "" || t.exchanges[len(t.exchanges)-1].TimedOut {
+		t.exchanges = append(t.exchanges, Exchange{})
+	}
+	return &t.exchanges[len(t.exchanges)-1]
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/internal/api"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/httpclient"
+)
+
+const (
+	// requestTimeout bounds each attempt of an upload.
+	requestTimeout = 2 * time.Minute
+	// maxErrorBodySize is the maximum size of the response body reported in the errors.
+	maxErrorBodySize = 512
+)
+
+// Getenv reads the token of the collector, e.g. os.Getenv.
+type Getenv func(key string) string
+
+// Client uploads claims to a collector.
+type Client struct {
+	// HTTP sends the requests, e.g. an httpclient.Client adding the bearer token.
+	HTTP api.HTTPClient
+	// URL is the endpoint the claims are POSTed to.
+	URL    string
+	Policy configsections.RetryPolicy
+	// Sleep waits between the attempts, time.Sleep by default.
+	Sleep func(time.Duration)
+}
+
+// New returns the client of a configuration, reading its token with getenv and its TLS files.
+func New(conf *configsections.ClaimUpload, getenv Getenv) (*Client, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	token, err := httpclient.GetToken(getenv, conf.TokenEnv)
+	if err != nil {
+		return nil, fmt.Errorf("claim upload %s: %w", conf.URL, err)
+	}
+	client, err := httpclient.New(requestTimeout, token, &httpclient.TLS{CAFile: conf.CAFile, CertFile: conf.CertFile,
+		KeyFile: conf.KeyFile, InsecureSkipVerify: conf.InsecureSkipVerify})
+	if err != nil {
+		return nil, fmt.Errorf("claim upload %s: %w", conf.URL, err)
+	}
+	return &Client{
+		HTTP:   client,
+		URL:    conf.URL,
+		Policy: conf.GetRetryPolicy(),
+		Sleep:  time.Sleep,
+	}, nil
+}
+
+// errPermanent marks the failures not worth retrying, e.g. a rejected token.
+var errPermanent = errors.New("permanent failure")
+
+// Upload POSTs the claim payload to the collector, retrying the network errors, the server errors and the throttled
+// requests according to the retry policy of the client.  It returns the error of the last attempt.
+func (c *Client) Upload(payload []byte) error {
+	backoff := c.Policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err := c.post(payload)
+		if err == nil || errors.Is(err, errPermanent) || attempt >= c.Policy.Attempts {
+			return err
+		}
+		log.Warnf("Claim upload attempt %d/%d to %s: %v, retrying in %s", attempt, c.Policy.Attempts, c.URL, err,
+			backoff)
+		c.Sleep(backoff)
+		if c.Policy.Multiplier > 1 {
+			backoff = time.Duration(float64(backoff) * c.Policy.Multiplier)
+		}
+	}
+}
+
+// post sends one attempt of an upload, and returns an error unless the response status is 2xx.  The errors are
+// permanent for the 4xx statuses other than 429 Too Many Requests.
+func (c *Client) post(payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewReader(payload)) //nolint:noctx
+	if err != nil {
+		return fmt.Errorf("%w: %v", errPermanent, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("POST %s: %s: %s", c.URL, resp.Status, bytes.TrimSpace(body))
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError &&
+		resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %v", errPermanent, err)
+	}
+	return err
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package upload_test
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/httpclient"
+	"github.com/test-network-function/test-network-function/pkg/upload"
+)
+
+func getenv(key string) string {
+	return map[string]string{"TNF_TOKEN": "secret"}[key]
+}
+
+func TestUpload(t *testing.T) {
+	statuses := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusCreated}
+	var payloads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		payload, _ := io.ReadAll(r.Body)
+		payloads = append(payloads, string(payload))
+		w.WriteHeader(statuses[len(payloads)-1])
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	client := &upload.Client{HTTP: &httpclient.Client{HTTP: server.Client(), Token: "secret"}, URL: server.URL + "/claims",
+		Policy: configsections.RetryPolicy{Attempts: 3, Backoff: time.Second, Multiplier: 2},
+		Sleep:  func(d time.Duration) { waits = append(waits, d) }}
+	assert.Nil(t, client.Upload([]byte(`{"claim": {}}`)))
+	assert.Equal(t, []string{`{"claim": {}}`, `{"claim": {}}`, `{"claim": {}}`}, payloads)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+
+	// The attempts are exhausted.
+	payloads, waits = nil, nil
+	client.Policy.Attempts = 2
+	assert.NotNil(t, client.Upload([]byte("{}")))
+	assert.Len(t, payloads, 2)
+}
+
+func TestUploadPermanentFailure(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := &upload.Client{HTTP: server.Client(), URL: server.URL, Policy: configsections.RetryPolicy{Attempts: 3},
+		Sleep: func(time.Duration) {}}
+	err := client.Upload([]byte("{}"))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "401 Unauthorized: invalid token")
+	assert.Equal(t, 1, requests)
+}
+
+func TestNew(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE",
+		Bytes: server.Certificate().Raw}), 0600))
+
+	client, err := upload.New(&configsections.ClaimUpload{URL: server.URL, TokenEnv: "TNF_TOKEN", CAFile: caFile},
+		getenv)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", client.HTTP.(*httpclient.Client).Token)
+	assert.Equal(t, 3, client.Policy.Attempts)
+	assert.Nil(t, client.Upload([]byte("{}")))
+
+	_, err = upload.New(&configsections.ClaimUpload{URL: server.URL, TokenEnv: "MISSING"}, getenv)
+	assert.NotNil(t, err)
+	_, err = upload.New(&configsections.ClaimUpload{URL: server.URL, CertFile: "client.pem"}, getenv)
+	assert.NotNil(t, err)
+	_, err = upload.New(&configsections.ClaimUpload{URL: server.URL, CAFile: filepath.Join(t.TempDir(), "none")},
+		getenv)
+	assert.NotNil(t, err)
+}
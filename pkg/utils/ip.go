@@ -0,0 +1,98 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package utils
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	// IPv4Family is the address family of IPv4 addresses.
+	IPv4Family = "IPv4"
+	// IPv6Family is the address family of IPv6 addresses.
+	IPv6Family = "IPv6"
+)
+
+// StripIPAddress removes the brackets and the zone, if any, around an IP address literal, e.g. "[fe80::1%eth0]"
+// becomes "fe80::1".
+func StripIPAddress(address string) string {
+	address = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+	if i := strings.Index(address, "%"); i >= 0 {
+		address = address[:i]
+	}
+	return address
+}
+
+// IPFamily returns IPv4Family or IPv6Family for an IP address literal, bracketed or not, and an empty string when
+// address is not an IP address (e.g. a hostname).
+func IPFamily(address string) string {
+	ip := net.ParseIP(StripIPAddress(address))
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return IPv4Family
+	default:
+		return IPv6Family
+	}
+}
+
+// IsIPv6 returns true when address is an IPv6 address literal.
+func IsIPv6(address string) bool {
+	return IPFamily(address) == IPv6Family
+}
+
+// JoinHostPort combines a host and a port for use in commands and URLs, bracketing IPv6 addresses, e.g.
+// "[fd00::1]:8080".
+func JoinHostPort(host string, port int) string {
+	return net.JoinHostPort(StripIPAddress(host), strconv.Itoa(port))
+}
+
+// IPFamilies returns the sorted, deduplicated address families of a list of IP addresses.  Entries that are not IP
+// addresses are ignored.
+func IPFamilies(addresses []string) []string {
+	var hasIPv4, hasIPv6 bool
+	for _, address := range addresses {
+		switch IPFamily(address) {
+		case IPv4Family:
+			hasIPv4 = true
+		case IPv6Family:
+			hasIPv6 = true
+		}
+	}
+	var families []string
+	if hasIPv4 {
+		families = append(families, IPv4Family)
+	}
+	if hasIPv6 {
+		families = append(families, IPv6Family)
+	}
+	return families
+}
+
+// FilterIPFamily returns the addresses of a list of IP addresses which belong to an address family, in order.
+func FilterIPFamily(addresses []string, family string) []string {
+	var filtered []string
+	for _, address := range addresses {
+		if IPFamily(address) == family {
+			filtered = append(filtered, address)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/utils"
+)
+
+func TestStripIPAddress(t *testing.T) {
+	assert.Equal(t, "10.0.0.1", utils.StripIPAddress("10.0.0.1"))
+	assert.Equal(t, "fd00::1", utils.StripIPAddress("[fd00::1]"))
+	assert.Equal(t, "fe80::1", utils.StripIPAddress("[fe80::1%eth0]"))
+}
+
+func TestIPFamily(t *testing.T) {
+	assert.Equal(t, utils.IPv4Family, utils.IPFamily("10.0.0.1"))
+	assert.Equal(t, utils.IPv6Family, utils.IPFamily("fd00::1"))
+	assert.Equal(t, utils.IPv6Family, utils.IPFamily("[fd00::1]"))
+	// IPv4-mapped IPv6 addresses are IPv4 addresses.
+	assert.Equal(t, utils.IPv4Family, utils.IPFamily("::ffff:10.0.0.1"))
+	assert.Equal(t, "", utils.IPFamily("www.redhat.com"))
+	assert.True(t, utils.IsIPv6("[2001:db8::5]"))
+	assert.False(t, utils.IsIPv6("192.168.1.1"))
+}
+
+func TestJoinHostPort(t *testing.T) {
+	assert.Equal(t, "10.0.0.1:8080", utils.JoinHostPort("10.0.0.1", 8080))
+	assert.Equal(t, "[fd00::1]:8080", utils.JoinHostPort("fd00::1", 8080))
+	// already bracketed addresses must not be bracketed twice.
+	assert.Equal(t, "[fd00::1]:8080", utils.JoinHostPort("[fd00::1]", 8080))
+	assert.Equal(t, "example.com:443", utils.JoinHostPort("example.com", 443))
+}
+
+func TestIPFamilies(t *testing.T) {
+	assert.Nil(t, utils.IPFamilies(nil))
+	assert.Equal(t, []string{utils.IPv6Family}, utils.IPFamilies([]string{"fd00::1", "fd00::2"}))
+	assert.Equal(t, []string{utils.IPv4Family, utils.IPv6Family}, utils.IPFamilies([]string{"fd00::1", "10.0.0.1", "bogus"}))
+}
+
+func TestFilterIPFamily(t *testing.T) {
+	addresses := []string{"10.0.0.1", "fd00::1", "bogus", "10.0.0.2"}
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, utils.FilterIPFamily(addresses, utils.IPv4Family))
+	assert.Equal(t, []string{"fd00::1"}, utils.FilterIPFamily(addresses, utils.IPv6Family))
+	assert.Nil(t, utils.FilterIPFamily([]string{"10.0.0.1"}, utils.IPv6Family))
+}
@@ -1,27 +1,28 @@
 package utils
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
 )
 
 var (
 	// pathRelativeToRoot is used to calculate relative filepaths to the tnf folder.
-	pathRelativeToRoot = path.Join("..")
+	pathRelativeToRoot = filepath.Join("..")
 	// commandHandlerFilePath is the file location of the command handler.
-	commandHandlerFilePath = path.Join(pathRelativeToRoot, "pkg", "tnf", "handlers", "command", "command.json")
+	commandHandlerFilePath = filepath.Join(pathRelativeToRoot, "pkg", "tnf", "handlers", "command", "command.json")
 	// handlerJSONSchemaFilePath is the file location of the json handlers generic schema.
-	handlerJSONSchemaFilePath = path.Join(pathRelativeToRoot, "schemas", "generic-test.schema.json")
+	handlerJSONSchemaFilePath = filepath.Join(pathRelativeToRoot, "schemas", "generic-test.schema.json")
 )
 
 // ArgListToMap takes a list of strings of the form "key=value" and translate it into a map
@@ -62,8 +63,9 @@ func CheckFileExists(filePath, name string) {
 }
 
 // ExecuteCommand uses the generic command handler to execute an arbitrary interactive command, returning
-// its output wihout any other check.
-func ExecuteCommand(command string, timeout time.Duration, context *interactive.Context, failureCallbackFun func()) string {
+// its output wihout any other check.  The failureCallbackFun, if any, is invoked when the command fails.  The command
+// is aborted once ctx is done.
+func ExecuteCommand(ctx context.Context, command string, timeout time.Duration, context *interactive.Context, failureCallbackFun func()) (string, error) {
 	log.Debugf("Executing command: %s", command)
 
 	values := make(map[string]interface{})
@@ -72,24 +74,38 @@ func ExecuteCommand(command string, timeout time.Duration, context *interactive.
 	values["TIMEOUT"] = timeout.Nanoseconds()
 
 	tester, handler, result, err := generic.NewGenericFromMap(commandHandlerFilePath, handlerJSONSchemaFilePath, values)
+	if err != nil {
+		return "", err
+	}
+	if result == nil || !result.Valid() {
+		return "", fmt.Errorf("invalid command handler for command %q", command)
+	}
 
-	gomega.Expect(err).To(gomega.BeNil())
-	gomega.Expect(result).ToNot(gomega.BeNil())
-	gomega.Expect(result.Valid()).To(gomega.BeTrue())
-	gomega.Expect(handler).ToNot(gomega.BeNil())
-	gomega.Expect(tester).ToNot(gomega.BeNil())
-
-	test, err := tnf.NewTest(context.GetExpecter(), *tester, handler, context.GetErrorChannel())
-	gomega.Expect(err).To(gomega.BeNil())
-	gomega.Expect(tester).ToNot(gomega.BeNil())
+	test, err := tnf.NewTest(ctx, context.GetExpecter(), *tester, handler, context.GetErrorChannel())
+	if err != nil {
+		return "", err
+	}
 
-	test.RunAndValidateWithFailureCallback(failureCallbackFun)
+	if err := test.RunAndCheck(failureCallbackFun); err != nil {
+		return "", fmt.Errorf("command %q: %w", command, err)
+	}
 
-	genericTest := (*tester).(*generic.Generic)
-	gomega.Expect(genericTest).ToNot(gomega.BeNil())
+	matches := (*tester).(*generic.Generic).GetMatches()
+	if len(matches) != 1 {
+		return "", fmt.Errorf("command %q returned %d matches instead of 1", command, len(matches))
+	}
+	return matches[0].Match, nil
+}
 
-	matches := genericTest.Matches
-	gomega.Expect(len(matches)).To(gomega.Equal(1))
-	match := genericTest.GetMatches()[0]
-	return match.Match
+// SpawnShell spawns a shell session like interactive.SpawnShell, in which the oc command lines are run by kubectl when
+// oc is not installed, see occompat.UsesKubectl.
+func SpawnShell(timeout time.Duration, opts ...interactive.Option) (*interactive.Context, error) {
+	shell, err := interactive.SpawnShell(interactive.CreateGoExpectSpawner(), timeout, opts...)
+	if err != nil || !occompat.UsesKubectl() {
+		return shell, err
+	}
+	if _, err := ExecuteCommand(context.Background(), occompat.KubectlShim, timeout, shell, nil); err != nil {
+		return nil, fmt.Errorf("cannot run oc with kubectl: %w", err)
+	}
+	return shell, nil
 }
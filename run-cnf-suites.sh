@@ -4,11 +4,47 @@ set -x
 export OUTPUT_LOC="$PWD/test-network-function"
 
 usage() {
-	echo "$0 [-o OUTPUT_LOC] [-f SUITE...] -s [SUITE...]"
+	echo "$0 [-o OUTPUT_LOC] [-f SUITE...] -s [SUITE...] [-r CLAIM_FILE] [-w WAIVERS_FILE] [-k PACKS_DIR] [-g TAG...] [-x TAG...] [-i] [-l] [-p] [-b] [--kubeconfig KUBECONFIG] [--kube-context CONTEXT] [--cluster CLUSTER] [--discovery-snapshot SNAPSHOT_FILE] [--save-discovery-snapshot] [--validate-config] [--set PATH=VALUE...]"
 	echo "Call the script and list the test suites to run"
 	echo "  e.g."
 	echo "    $0 [ARGS] -f access-control lifecycle"
 	echo "  will run the access-control and lifecycle suites"
+	echo "    $0 [ARGS] -r claim.json"
+	echo "  will only run the tests that failed in claim.json"
+	echo "    $0 [ARGS] -g telco security -x intrusive"
+	echo "  will run the tests tagged telco or security, except the intrusive ones"
+	echo "    $0 [ARGS] -w waivers.yml"
+	echo "  will report the failures matching an active waiver of waivers.yml as waived"
+	echo "    $0 [ARGS] -k packs -f access-control operator"
+	echo "  will also run the user-defined test case packs of the packs directory"
+	echo "    $0 [ARGS] -i -f lifecycle"
+	echo "  will also run the intrusive and destructive tests, which are skipped otherwise"
+	echo "    $0 [ARGS] -l -f networking"
+	echo "  will also run the load-generating tests, e.g. the throughput measurement, which are skipped otherwise"
+	echo "    $0 [ARGS] -p -f networking"
+	echo "  will first check the auxiliary images of the suites can be pulled"
+	echo "    $0 [ARGS] -b -f networking"
+	echo "  will write the state bundle of each failed test into the state-bundles directory of OUTPUT_LOC"
+	echo "    $0 [ARGS] -t -f networking"
+	echo "  will record the session transcript of each test into the session-transcripts directory of OUTPUT_LOC"
+	echo "    $0 [ARGS] -d -f networking"
+	echo "  will gather diagnostics on the targets of each failed test into the failure-diagnostics directory of OUTPUT_LOC"
+	echo "    $0 [ARGS] -a -b -d -f networking"
+	echo "  will also package the artifacts of the run into a single tnf-artifacts-<time>.tar.gz file of OUTPUT_LOC"
+	echo "    $0 [ARGS] -u -f networking"
+	echo "  will upload the claim to the collector of the claimUpload section of the configuration"
+	echo "    $0 [ARGS] --kubeconfig ~/.kube/lab --kube-context lab-admin -f networking"
+	echo "  will test the cluster of the lab-admin context of the ~/.kube/lab kubeconfig file"
+	echo "    $0 [ARGS] --cluster edge -f networking"
+	echo "  will test the edge cluster of the clusters section of the configuration"
+	echo "    $0 [ARGS] --save-discovery-snapshot -f networking"
+	echo "  will write the result of the autodiscovery into the discovery-snapshot.json file of OUTPUT_LOC"
+	echo "    $0 [ARGS] --discovery-snapshot discovery-snapshot.json -f networking"
+	echo "  will test the resources of discovery-snapshot.json instead of discovering them"
+	echo "    $0 [ARGS] --set timeouts.default=1m --set 'targetNameSpaces=[{name: cnf}]' -f networking"
+	echo "  will override the fields of the configuration, which TNF_CONFIG_ environment variables override too"
+	echo "    $0 --validate-config"
+	echo "  will only validate the configuration file, listing its unknown fields, wrong types and missing fields"
 	echo ""
 	echo "Allowed suites are listed in the README."
 }
@@ -18,8 +54,34 @@ usage_error() {
 	exit 1
 }
 
+# abspath prints the absolute path of an existing file, without relying on GNU realpath which older macOS lack.
+abspath() {
+	echo "$(cd "$(dirname "$1")" && pwd)/$(basename "$1")"
+}
+
 FOCUS=""
 SKIP=""
+RERUN_FAILED=""
+INCLUDE_TAGS=""
+EXCLUDE_TAGS=""
+WAIVERS=""
+TESTCASE_PACKS=""
+ALLOW_INTRUSIVE=""
+ALLOW_LOAD=""
+IMAGES_PREFLIGHT=""
+STATE_BUNDLES=""
+SESSION_TRANSCRIPTS=""
+FAILURE_DIAGNOSTICS=""
+ARCHIVE=""
+UPLOAD=""
+KUBECONFIG_FILE=""
+KUBE_CONTEXT=""
+CLUSTER=""
+DISCOVERY_SNAPSHOT=""
+SAVE_DISCOVERY_SNAPSHOT=""
+VALIDATE_CONFIG=""
+# the overrides are kept in an array as their values may hold spaces.
+SETTINGS=()
 # Parge args beginning with "-"
 while [[ $1 == -* ]]; do
 	case "$1" in
@@ -40,19 +102,142 @@ while [[ $1 == -* ]]; do
           FOCUS="$2|$FOCUS"
           shift
         done;;
+		-g|--include-tags)
+        while (( "$#" >= 2 )) && ! [[ $2 = --* ]]  && ! [[ $2 = -* ]] ; do
+          INCLUDE_TAGS="$2,$INCLUDE_TAGS"
+          shift
+        done;;
+		-x|--exclude-tags)
+        while (( "$#" >= 2 )) && ! [[ $2 = --* ]]  && ! [[ $2 = -* ]] ; do
+          EXCLUDE_TAGS="$2,$EXCLUDE_TAGS"
+          shift
+        done;;
+		-r|--rerun-failed) if (($# > 1)); then
+				  RERUN_FAILED=$(abspath "$2"); shift
+			  else
+				  echo "-r requires an argument" 1>&2
+				  exit 1
+			  fi ;;
+		-i|--allow-intrusive) ALLOW_INTRUSIVE="true";;
+		-l|--allow-load) ALLOW_LOAD="true";;
+		-p|--images-preflight) IMAGES_PREFLIGHT="true";;
+		-b|--state-bundles) STATE_BUNDLES="true";;
+		-t|--session-transcripts) SESSION_TRANSCRIPTS="true";;
+		-d|--failure-diagnostics) FAILURE_DIAGNOSTICS="true";;
+		-a|--archive) ARCHIVE="true";;
+		-u|--upload) UPLOAD="true";;
+		-k|--testcase-packs) if (($# > 1)); then
+				  TESTCASE_PACKS=$(abspath "$2"); shift
+			  else
+				  echo "-k requires an argument" 1>&2
+				  exit 1
+			  fi ;;
+		--kubeconfig) if (($# > 1)); then
+				  KUBECONFIG_FILE=$(abspath "$2"); shift
+			  else
+				  echo "--kubeconfig requires an argument" 1>&2
+				  exit 1
+			  fi ;;
+		--kube-context) if (($# > 1)); then
+				  KUBE_CONTEXT=$2; shift
+			  else
+				  echo "--kube-context requires an argument" 1>&2
+				  exit 1
+			  fi ;;
+		--cluster) if (($# > 1)); then
+				  CLUSTER=$2; shift
+			  else
+				  echo "--cluster requires an argument" 1>&2
+				  exit 1
+			  fi ;;
+		--discovery-snapshot) if (($# > 1)); then
+				  DISCOVERY_SNAPSHOT=$(abspath "$2"); shift
+			  else
+				  echo "--discovery-snapshot requires an argument" 1>&2
+				  exit 1
+			  fi ;;
+		--save-discovery-snapshot) SAVE_DISCOVERY_SNAPSHOT="true";;
+		--validate-config) VALIDATE_CONFIG="true";;
+		--set) if (($# > 1)); then
+				  SETTINGS+=(-set "$2"); shift
+			  else
+				  echo "--set requires an argument" 1>&2
+				  exit 1
+			  fi ;;
+		-w|--waivers) if (($# > 1)); then
+				  WAIVERS=$(abspath "$2"); shift
+			  else
+				  echo "-w requires an argument" 1>&2
+				  exit 1
+			  fi ;;
     -*) echo "invalid option: $1" 1>&2; usage_error;;
 	esac
   shift
 done
 # specify Junit report file name.
 GINKGO_ARGS="-junit $OUTPUT_LOC -claimloc $OUTPUT_LOC --ginkgo.junit-report $OUTPUT_LOC/cnf-certification-tests_junit.xml -ginkgo.v -test.v"
+if [ -n "$WAIVERS" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -waivers $WAIVERS"
+fi
+if [ -n "$TESTCASE_PACKS" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -testcase-packs $TESTCASE_PACKS"
+fi
+if [ -n "$ALLOW_INTRUSIVE" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -allow-intrusive"
+fi
+if [ -n "$ALLOW_LOAD" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -allow-load"
+fi
+if [ -n "$IMAGES_PREFLIGHT" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -images-preflight"
+fi
+if [ -n "$STATE_BUNDLES" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -state-bundles"
+fi
+if [ -n "$SESSION_TRANSCRIPTS" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -session-transcripts"
+fi
+if [ -n "$FAILURE_DIAGNOSTICS" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -failure-diagnostics"
+fi
+if [ -n "$ARCHIVE" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -archive"
+fi
+if [ -n "$UPLOAD" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -upload"
+fi
+if [ -n "$KUBECONFIG_FILE" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -kubeconfig $KUBECONFIG_FILE"
+fi
+if [ -n "$KUBE_CONTEXT" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -kube-context $KUBE_CONTEXT"
+fi
+if [ -n "$CLUSTER" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -cluster $CLUSTER"
+fi
+if [ -n "$DISCOVERY_SNAPSHOT" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -discovery-snapshot $DISCOVERY_SNAPSHOT"
+fi
+if [ -n "$SAVE_DISCOVERY_SNAPSHOT" ]; then
+	GINKGO_ARGS="$GINKGO_ARGS -save-discovery-snapshot"
+fi
 
+# The configuration file is validated without running the tests, no focus is needed.
+if [ -n "$VALIDATE_CONFIG" ]; then
+	cd ./test-network-function && ./test-network-function.test -validate-config "${SETTINGS[@]}" ${GINKGO_ARGS}
+	exit $?
+fi
 
-# If no focus is set then display usage and quit with a non-zero exit code.
-[ -z "$FOCUS" ] && echo "no focus found" && usage_error
+# If no focus is set then display usage and quit with a non-zero exit code, unless failed tests are re-run or the
+# tests are selected by tags.
+[ -z "$FOCUS" ] && [ -z "$RERUN_FAILED" ] && [ -z "$INCLUDE_TAGS" ] && [ -z "$EXCLUDE_TAGS" ] && echo "no focus found" && usage_error
+[ -n "$FOCUS" ] && [ -n "$RERUN_FAILED" ] && echo "-f and -r cannot be combined" && usage_error
+[ -n "$INCLUDE_TAGS$EXCLUDE_TAGS" ] && [ -n "$FOCUS$RERUN_FAILED" ] && echo "-g and -x cannot be combined with -f or -r" && usage_error
 
 FOCUS=${FOCUS%?}  # strip the trailing "|" from the concatenation
 SKIP=${SKIP%?} # strip the trailing "|" from the concatenation
+INCLUDE_TAGS=${INCLUDE_TAGS%,} # strip the trailing "," from the concatenation
+EXCLUDE_TAGS=${EXCLUDE_TAGS%,}
 
 # Run cnf-feature-deploy test container if not running inside a container
 # cgroup file doesn't exist on MacOS. Consider that as not running in container as well
@@ -76,4 +261,14 @@ SKIP_STRING=""
 if [ -n "$SKIP" ]; then
 	SKIP_STRING=-ginkgo.skip="$SKIP"
 fi
-cd ./test-network-function && ./test-network-function.test -ginkgo.focus="$FOCUS" $SKIP_STRING ${GINKGO_ARGS}
+if [ -n "$RERUN_FAILED" ]; then
+	echo "Re-running the failed tests of '$RERUN_FAILED'"
+	cd ./test-network-function && ./test-network-function.test -rerun-failed "$RERUN_FAILED" $SKIP_STRING "${SETTINGS[@]}" ${GINKGO_ARGS}
+	exit $?
+fi
+if [ -n "$INCLUDE_TAGS$EXCLUDE_TAGS" ]; then
+	echo "Running the tests tagged '$INCLUDE_TAGS' except '$EXCLUDE_TAGS'"
+	cd ./test-network-function && ./test-network-function.test -include-tags="$INCLUDE_TAGS" -exclude-tags="$EXCLUDE_TAGS" $SKIP_STRING "${SETTINGS[@]}" ${GINKGO_ARGS}
+	exit $?
+fi
+cd ./test-network-function && ./test-network-function.test -ginkgo.focus="$FOCUS" $SKIP_STRING "${SETTINGS[@]}" ${GINKGO_ARGS}
@@ -111,6 +111,8 @@ ${TNF_CONTAINER_CLIENT} run --rm $DNS_ARG \
 	-e TNF_PARTNER_REPO=$TNF_PARTNER_REPO \
 	-e TNF_DEPLOYMENT_TIMEOUT=$TNF_DEPLOYMENT_TIMEOUT \
 	-e TNF_OC_DEBUG_IMAGE_ID=$TNF_OC_DEBUG_IMAGE_ID \
+	-e TNF_LABEL_DOMAIN=$TNF_LABEL_DOMAIN \
+	-e TNF_CLUSTER_FLAVOR=$TNF_CLUSTER_FLAVOR \
 	-e REDHAT_RHEL_REGISTRY=$REDHAT_RHEL_REGISTRY \
 	-e LOG_LEVEL=$LOG_LEVEL \
 	-e PATH=/usr/bin:/usr/local/oc/bin \
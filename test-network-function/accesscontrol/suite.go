@@ -19,16 +19,22 @@ package accesscontrol
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
 	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/automounttoken"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/clusterrolebinding"
 	containerpkg "github.com/test-network-function/test-network-function/pkg/tnf/handlers/container"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/hostnamespaces"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/rolebinding"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/serviceaccount"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/common"
@@ -41,7 +47,7 @@ var _ = ginkgo.Describe(common.AccessControlTestKey, func() {
 	if testcases.IsInFocus(conf.FocusStrings, common.AccessControlTestKey) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
-			env.LoadAndRefresh()
+			gomega.Expect(env.LoadAndRefresh()).To(gomega.Succeed())
 			gomega.Expect(len(env.PodsUnderTest)).ToNot(gomega.Equal(0))
 			gomega.Expect(len(env.ContainersUnderTest)).ToNot(gomega.Equal(0))
 		})
@@ -52,6 +58,14 @@ var _ = ginkgo.Describe(common.AccessControlTestKey, func() {
 
 		testRoles(env)
 
+		testNodeExposure(env)
+
+		testHostNamespaces(env)
+
+		testAutomountServiceAccountToken(env)
+
+		testRBAC(env)
+
 		defer ginkgo.GinkgoRecover()
 
 		// Run the tests that interact with the pods
@@ -75,61 +89,74 @@ var _ = ginkgo.Describe(common.AccessControlTestKey, func() {
 	}
 })
 
-//nolint:gocritic,funlen // ignore hugeParam error. Pointers to loop iterator vars are bad and `testCmd` is likely to be such.
+// hostNamespaceChecks are the host namespaces checked by the host resource test cases, by name, which the pods may be
+// exempted from like in testHostNamespace.
+var hostNamespaceChecks = map[string]string{
+	"HOST_NETWORK_CHECK": hostnamespaces.HostNetwork,
+	"HOST_PID_CHECK":     hostnamespaces.HostPID,
+	"HOST_IPC_CHECK":     hostnamespaces.HostIPC,
+}
+
+//nolint:gocritic // ignore hugeParam error. Pointers to loop iterator vars are bad and `testCmd` is likely to be such.
 func runTestOnPods(env *config.TestEnvironment, testCmd testcases.BaseTestCase, testType string) {
 	testID := identifiers.XformToGinkgoItIdentifierExtended(identifiers.TestHostResourceIdentifier, testCmd.Name)
 	ginkgo.It(testID, func() {
-		context := common.GetContext()
+		var pods []configsections.Pod
 		for _, podUnderTest := range env.PodsUnderTest {
-			podName := podUnderTest.Name
-			podNamespace := podUnderTest.Namespace
-			ginkgo.By(fmt.Sprintf("Reading namespace of podnamespace= %s podname= %s", podNamespace, podName))
-			if testCmd.ExpectedType == testcases.Function {
-				for _, val := range testCmd.ExpectedStatus {
-					testCmd.ExpectedStatusFn(podName, testcases.StatusFunctionType(val))
-				}
-			}
-			testType := testType
-			testCmd := testCmd
-			var args []interface{}
-			if testType == testcases.PrivilegedRoles {
-				args = []interface{}{podUnderTest.Namespace, podUnderTest.Namespace, podUnderTest.ServiceAccount}
-			} else {
-				args = []interface{}{podUnderTest.Name, podUnderTest.Namespace}
-			}
-			var count int
-			if testCmd.Loop > 0 {
-				count = podUnderTest.ContainerCount
-			} else {
-				count = testCmd.Loop
-			}
-
-			if count > 0 {
-				count := 0
-				for count < podUnderTest.ContainerCount {
-					argsCount := append(args, count)
-					cmdArgs := strings.Split(fmt.Sprintf(testCmd.Command, argsCount...), " ")
-					cnfInTest := containerpkg.NewPod(cmdArgs, podUnderTest.Name, podUnderTest.Namespace, testCmd.ExpectedStatus, testCmd.ResultType, testCmd.Action, common.DefaultTimeout)
-					gomega.Expect(cnfInTest).ToNot(gomega.BeNil())
-					test, err := tnf.NewTest(context.GetExpecter(), cnfInTest, []reel.Handler{cnfInTest}, context.GetErrorChannel())
-					gomega.Expect(err).To(gomega.BeNil())
-					gomega.Expect(test).ToNot(gomega.BeNil())
-					test.RunAndValidate()
-					count++
-				}
-			} else {
-				cmdArgs := strings.Split(fmt.Sprintf(testCmd.Command, args...), " ")
-				podTest := containerpkg.NewPod(cmdArgs, podUnderTest.Name, podUnderTest.Namespace, testCmd.ExpectedStatus, testCmd.ResultType, testCmd.Action, common.DefaultTimeout)
-				gomega.Expect(podTest).ToNot(gomega.BeNil())
-				test, err := tnf.NewTest(context.GetExpecter(), podTest, []reel.Handler{podTest}, context.GetErrorChannel())
-				gomega.Expect(err).To(gomega.BeNil())
-				gomega.Expect(test).ToNot(gomega.BeNil())
-				test.RunAndValidate()
+			if setting, ok := hostNamespaceChecks[testCmd.Name]; ok && podUnderTest.IsHostNamespaceExempted(setting) {
+				log.Infof("Pod %s sets %s, exempted by its annotation", podUnderTest.FullName(), setting)
+				continue
 			}
+			pods = append(pods, podUnderTest)
 		}
+		common.RunInParallel(len(pods), func(i int, context *interactive.Context) error {
+			return runTestOnPod(env, testID, context, testCmd, testType, &pods[i])
+		})
 	})
 }
 
+// runTestOnPod runs the commands of testCmd on podUnderTest, once per container when the test case loops, with the
+// session of context, except on the containers opted out of testID.  It returns an error unless they all succeed.
+func runTestOnPod(env *config.TestEnvironment, testID string, context *interactive.Context, testCmd testcases.BaseTestCase, //nolint:gocritic // copied, its expected status is set per pod
+	testType string, podUnderTest *configsections.Pod) error {
+	log.Debugf("Reading namespace of podnamespace= %s podname= %s", podUnderTest.Namespace, podUnderTest.Name)
+	if testCmd.ExpectedType == testcases.Function {
+		testCmd.ExpectedStatus = append([]string{}, testCmd.ExpectedStatus...)
+		for _, val := range testCmd.ExpectedStatus {
+			testCmd.ExpectedStatusFn(podUnderTest.Name, testcases.StatusFunctionType(val))
+		}
+	}
+	var args []interface{}
+	if testType == testcases.PrivilegedRoles {
+		args = []interface{}{podUnderTest.Namespace, podUnderTest.Namespace, podUnderTest.ServiceAccount}
+	} else {
+		args = []interface{}{podUnderTest.Name, podUnderTest.Namespace}
+	}
+	var commands [][]string
+	if testCmd.Loop > 0 && podUnderTest.ContainerCount > 0 {
+		for count := 0; count < podUnderTest.ContainerCount; count++ {
+			if env.SkipsContainerTest(testID, &configsections.ContainerIdentifier{Namespace: podUnderTest.Namespace,
+				PodName: podUnderTest.Name, ContainerName: podUnderTest.GetContainerName(count)}) {
+				continue
+			}
+			commands = append(commands, strings.Split(fmt.Sprintf(testCmd.Command, append(args, count)...), " "))
+		}
+	} else {
+		commands = append(commands, strings.Split(fmt.Sprintf(testCmd.Command, args...), " "))
+	}
+	for _, cmdArgs := range commands {
+		podTest := containerpkg.NewPod(cmdArgs, podUnderTest.Name, podUnderTest.Namespace, testCmd.ExpectedStatus, testCmd.ResultType, testCmd.Action, common.GetTimeout(common.AccessControlTestKey, "container"))
+		test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), podTest, []reel.Handler{podTest}, context.GetErrorChannel())
+		if err != nil {
+			return err
+		}
+		if err = test.RunAndCheck(nil); err != nil {
+			return fmt.Errorf("pod %s: %w", podUnderTest.FullName(), err)
+		}
+	}
+	return nil
+}
+
 func testNamespace(env *config.TestEnvironment) {
 	ginkgo.When("test deployment namespace", func() {
 		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestNamespaceBestPracticesIdentifier)
@@ -155,18 +182,24 @@ func testServiceAccount(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodServiceAccountBestPracticesIdentifier)
 	ginkgo.It(testID, func() {
 		ginkgo.By("Should have a valid ServiceAccount name")
-		for _, podUnderTest := range env.PodsUnderTest {
-			podName := podUnderTest.Name
-			podNamespace := podUnderTest.Namespace
-			context := common.GetContext()
-			ginkgo.By(fmt.Sprintf("Testing pod service account %s %s", podNamespace, podName))
-			tester := serviceaccount.NewServiceAccount(common.DefaultTimeout, podName, podNamespace)
-			test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
-			gomega.Expect(err).To(gomega.BeNil())
-			test.RunAndValidate()
-			serviceAccountName := tester.GetServiceAccountName()
-			gomega.Expect(serviceAccountName).ToNot(gomega.BeEmpty())
-		}
+		pods := env.PodsUnderTest
+		common.RunInParallel(len(pods), func(i int, context *interactive.Context) error {
+			podName := pods[i].Name
+			podNamespace := pods[i].Namespace
+			log.Infof("Testing pod service account %s %s", podNamespace, podName)
+			tester := serviceaccount.NewServiceAccount(common.GetTimeout(common.AccessControlTestKey, "serviceaccount"), podName, podNamespace)
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+			if err != nil {
+				return err
+			}
+			if err := test.RunAndCheck(nil); err != nil {
+				return fmt.Errorf("pod %s/%s: %w", podNamespace, podName, err)
+			}
+			if tester.GetServiceAccountName() == "" {
+				return fmt.Errorf("pod %s/%s has no service account", podNamespace, podName)
+			}
+			return nil
+		})
 	})
 }
 
@@ -175,20 +208,22 @@ func testRoleBindings(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodRoleBindingsBestPracticesIdentifier)
 	ginkgo.It(testID, func() {
 		ginkgo.By("Should not have RoleBinding in other namespaces")
-		for _, podUnderTest := range env.PodsUnderTest {
-			podName := podUnderTest.Name
-			podNamespace := podUnderTest.Namespace
-			serviceAccountName := podUnderTest.ServiceAccount
-			context := common.GetContext()
-			ginkgo.By(fmt.Sprintf("Testing role  bidning  %s %s", podNamespace, podName))
-			if serviceAccountName == "" {
-				ginkgo.Skip("Can not test when serviceAccountName is empty. Please check previous tests for failures")
-			}
-			rbTester := rolebinding.NewRoleBinding(common.DefaultTimeout, serviceAccountName, podNamespace)
-			test, err := tnf.NewTest(context.GetExpecter(), rbTester, []reel.Handler{rbTester}, context.GetErrorChannel())
-			gomega.Expect(err).To(gomega.BeNil())
-			test.RunAndValidateWithFailureCallback(func() { log.Info("RoleBindings: ", rbTester.GetRoleBindings()) })
-		}
+		pods := env.PodsUnderTest
+		skipOnMissingServiceAccount(pods)
+		common.RunInParallel(len(pods), func(i int, context *interactive.Context) error {
+			podName := pods[i].Name
+			podNamespace := pods[i].Namespace
+			log.Infof("Testing role  bidning  %s %s", podNamespace, podName)
+			rbTester := rolebinding.NewRoleBinding(common.GetTimeout(common.AccessControlTestKey, "rolebinding"), pods[i].ServiceAccount, podNamespace)
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), rbTester, []reel.Handler{rbTester}, context.GetErrorChannel())
+			if err != nil {
+				return err
+			}
+			if err := test.RunAndCheck(func() { log.Info("RoleBindings: ", rbTester.GetRoleBindings()) }); err != nil {
+				return fmt.Errorf("pod %s/%s: %w", podNamespace, podName, err)
+			}
+			return nil
+		})
 	})
 }
 
@@ -197,19 +232,214 @@ func testClusterRoleBindings(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodClusterRoleBindingsBestPracticesIdentifier)
 	ginkgo.It(testID, func() {
 		ginkgo.By("Should not have ClusterRoleBindings")
-		for _, podUnderTest := range env.PodsUnderTest {
-			podName := podUnderTest.Name
-			podNamespace := podUnderTest.Namespace
-			serviceAccountName := podUnderTest.ServiceAccount
-			context := common.GetContext()
-			ginkgo.By(fmt.Sprintf("Testing cluster role  bidning  %s %s", podNamespace, podName))
-			if serviceAccountName == "" {
-				ginkgo.Skip("Can not test when serviceAccountName is empty. Please check previous tests for failures")
-			}
-			crbTester := clusterrolebinding.NewClusterRoleBinding(common.DefaultTimeout, serviceAccountName, podNamespace)
-			test, err := tnf.NewTest(context.GetExpecter(), crbTester, []reel.Handler{crbTester}, context.GetErrorChannel())
-			gomega.Expect(err).To(gomega.BeNil())
-			test.RunAndValidateWithFailureCallback(func() { log.Info("ClusterRoleBindings: ", crbTester.GetClusterRoleBindings()) })
+		pods := env.PodsUnderTest
+		skipOnMissingServiceAccount(pods)
+		common.RunInParallel(len(pods), func(i int, context *interactive.Context) error {
+			podName := pods[i].Name
+			podNamespace := pods[i].Namespace
+			log.Infof("Testing cluster role  bidning  %s %s", podNamespace, podName)
+			crbTester := clusterrolebinding.NewClusterRoleBinding(common.GetTimeout(common.AccessControlTestKey, "clusterrolebinding"), pods[i].ServiceAccount, podNamespace)
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), crbTester, []reel.Handler{crbTester}, context.GetErrorChannel())
+			if err != nil {
+				return err
+			}
+			if err := test.RunAndCheck(func() { log.Info("ClusterRoleBindings: ", crbTester.GetClusterRoleBindings()) }); err != nil {
+				return fmt.Errorf("pod %s/%s: %w", podNamespace, podName, err)
+			}
+			return nil
+		})
+	})
+}
+
+func testNodeExposure(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestNodeExposureIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should not use hostPorts nor NodePort Services")
+		var badPods []string
+		for i := range env.PodsUnderTest {
+			pod := &env.PodsUnderTest[i]
+			if exposures := getNodeExposures(pod, env.Config.Services, &env.Config.NodeExposure); len(exposures) > 0 {
+				log.Errorf("Pod %s is exposed on the node ports: %s", pod.FullName(), strings.Join(exposures, ", "))
+				badPods = append(badPods, pod.FullName())
+			}
 		}
+		results.RecordFailedTargets(badPods...)
+		gomega.Expect(badPods).To(gomega.BeEmpty())
 	})
 }
+
+// getNodeExposures returns the descriptions of the hostPorts of pod and of the Services selecting pod which allocate
+// node ports, except the ones allowed by allowlist.
+func getNodeExposures(pod *configsections.Pod, services []configsections.Service, allowlist *configsections.NodeExposure) []string {
+	var exposures []string
+	for _, port := range pod.Ports {
+		if port.HostPort != 0 && !allowlist.AllowsHostPort(port) {
+			exposures = append(exposures, fmt.Sprintf("hostPort %d for port %s", port.HostPort, port))
+		}
+	}
+	for i := range services {
+		service := &services[i]
+		if !service.Selects(pod) || allowlist.AllowsNodePortService(service) {
+			continue
+		}
+		for _, nodePort := range service.NodePorts() {
+			exposures = append(exposures, fmt.Sprintf("nodePort %d of %s Service %s", nodePort, service.Type, service.FullName()))
+		}
+	}
+	return exposures
+}
+
+func testHostNamespaces(env *config.TestEnvironment) {
+	testHostNamespace(env, identifiers.TestHostNetworkIdentifier, hostnamespaces.HostNetwork)
+	testHostNamespace(env, identifiers.TestHostPIDIdentifier, hostnamespaces.HostPID)
+	testHostNamespace(env, identifiers.TestHostIPCIdentifier, hostnamespaces.HostIPC)
+}
+
+// testHostNamespace checks that the pods under test do not share the host namespace of setting, i.e.
+// hostnamespaces.HostNetwork, HostPID or HostIPC, unless exempted by their host_namespace_exemptions annotation.
+func testHostNamespace(env *config.TestEnvironment, id claim.Identifier, setting string) {
+	testID := identifiers.XformToGinkgoItIdentifier(id)
+	ginkgo.It(testID, func() {
+		ginkgo.By(fmt.Sprintf("Should not set %s unless exempted", setting))
+		pods := env.PodsUnderTest
+		var mutex sync.Mutex
+		var badPods []string
+		defer func() {
+			results.RecordFailedTargets(badPods...)
+		}()
+		common.RunInParallel(len(pods), func(i int, context *interactive.Context) error {
+			pod := &pods[i]
+			tester := hostnamespaces.NewHostNamespaces(common.GetTimeout(common.AccessControlTestKey, "hostnamespaces"), pod.Name, pod.Namespace)
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+			if err != nil {
+				return err
+			}
+			// FAILURE only means the pod shares a host namespace, which may be another one or exempted.
+			result, err := test.Run()
+			if err != nil || result == tnf.ERROR {
+				return fmt.Errorf("pod %s: unable to read the host namespace settings: %v", pod.FullName(), err)
+			}
+			if !tester.Shares(setting) {
+				return nil
+			}
+			if pod.IsHostNamespaceExempted(setting) {
+				log.Infof("Pod %s sets %s, exempted by its annotation", pod.FullName(), setting)
+				return nil
+			}
+			mutex.Lock()
+			badPods = append(badPods, pod.FullName())
+			mutex.Unlock()
+			return fmt.Errorf("pod %s sets %s", pod.FullName(), setting)
+		})
+	})
+}
+
+// testAutomountServiceAccountToken checks that the service account token is not mounted in the pods under test, unless
+// they declare a Kubernetes API access with their api_access annotation.
+func testAutomountServiceAccountToken(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestAutomountServiceAccountTokenIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should not mount the service account token unless accessing the Kubernetes API")
+		pods := env.PodsUnderTest
+		var mutex sync.Mutex
+		var badPods []string
+		defer func() {
+			results.RecordFailedTargets(badPods...)
+		}()
+		common.RunInParallel(len(pods), func(i int, context *interactive.Context) error {
+			pod := &pods[i]
+			if pod.APIAccess {
+				log.Infof("Pod %s declares a Kubernetes API access, its token may be mounted", pod.FullName())
+				return nil
+			}
+			tester := automounttoken.NewAutomountToken(common.GetTimeout(common.AccessControlTestKey, "automounttoken"),
+				pod.Name, pod.Namespace, pod.ServiceAccount)
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+			if err != nil {
+				return err
+			}
+			result, err := test.Run()
+			if err != nil || result == tnf.ERROR {
+				return fmt.Errorf("pod %s: unable to read the automountServiceAccountToken settings: %v", pod.FullName(), err)
+			}
+			if result == tnf.SUCCESS {
+				return nil
+			}
+			mutex.Lock()
+			badPods = append(badPods, pod.FullName())
+			mutex.Unlock()
+			if tester.IsExplicit() {
+				return fmt.Errorf("pod %s mounts its service account token without declaring a Kubernetes API access", pod.FullName())
+			}
+			return fmt.Errorf("pod %s mounts its service account token by default without declaring a Kubernetes API access",
+				pod.FullName())
+		})
+	})
+}
+
+// rbacViolation returns why a binding of the service account of a pod, granting role, is over-privileged, empty when
+// it is not.  role is nil when the granted role is not found.
+type rbacViolation func(pod *configsections.Pod, binding *configsections.RoleBinding, role *configsections.Role) string
+
+// testRBAC checks the roles granted to the service accounts of the pods under test, as discovered with the bindings
+// referencing them.
+func testRBAC(env *config.TestEnvironment) {
+	testRBACBindings(env, identifiers.TestClusterAdminBindingIdentifier, "Should not be bound to cluster-admin",
+		func(_ *configsections.Pod, binding *configsections.RoleBinding, _ *configsections.Role) string {
+			if binding.RoleRef.Kind == configsections.KindClusterRole && binding.RoleRef.Name == configsections.ClusterAdminRole {
+				return "grants cluster-admin"
+			}
+			return ""
+		})
+	testRBACBindings(env, identifiers.TestRBACWildcardVerbsIdentifier, "Should not be granted wildcard verbs",
+		func(_ *configsections.Pod, _ *configsections.RoleBinding, role *configsections.Role) string {
+			if role != nil && role.HasWildcardVerb() {
+				return fmt.Sprintf("grants %s %s allowing every verb", role.Kind, role.Name)
+			}
+			return ""
+		})
+	testRBACBindings(env, identifiers.TestRBACCrossNamespaceIdentifier, "Should not be granted roles in other namespaces",
+		func(pod *configsections.Pod, binding *configsections.RoleBinding, _ *configsections.Role) string {
+			if binding.Kind == configsections.KindRoleBinding && binding.Namespace != pod.Namespace {
+				return fmt.Sprintf("grants %s %s in namespace %s", binding.RoleRef.Kind, binding.RoleRef.Name, binding.Namespace)
+			}
+			return ""
+		})
+}
+
+// testRBACBindings fails the pods under test whose service account is granted a role by a binding for which
+// violation returns a reason.
+func testRBACBindings(env *config.TestEnvironment, id claim.Identifier, by string, violation rbacViolation) {
+	testID := identifiers.XformToGinkgoItIdentifier(id)
+	ginkgo.It(testID, func() {
+		ginkgo.By(by)
+		target := &env.Config.TestTarget
+		var badPods []string
+		for i := range env.PodsUnderTest {
+			pod := &env.PodsUnderTest[i]
+			bindings := target.GetServiceAccountBindings(pod)
+			var reasons []string
+			for j := range bindings {
+				binding := &bindings[j]
+				if reason := violation(pod, binding, binding.FindRole(target.Roles)); reason != "" {
+					reasons = append(reasons, binding.String()+" "+reason)
+				}
+			}
+			if len(reasons) > 0 {
+				log.Errorf("Pod %s is over-privileged: %s", pod.FullName(), strings.Join(reasons, ", "))
+				badPods = append(badPods, pod.FullName())
+			}
+		}
+		results.RecordFailedTargets(badPods...)
+		gomega.Expect(badPods).To(gomega.BeEmpty())
+	})
+}
+
+// skipOnMissingServiceAccount skips the spec when a pod has no service account.
+func skipOnMissingServiceAccount(pods []configsections.Pod) {
+	for i := range pods {
+		if pods[i].ServiceAccount == "" {
+			ginkgo.Skip("Can not test when serviceAccountName is empty. Please check previous tests for failures")
+		}
+	}
+}
@@ -18,11 +18,18 @@ package certification
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/internal/api"
 	configpkg "github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/containerimages"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
@@ -38,18 +45,58 @@ const (
 
 var certAPIClient api.CertAPIClient
 
+// ImageCertification is the certification status of an image of the containers under test.
+type ImageCertification struct {
+	api.ImageReference
+	// Containers are the containers under test running the image, as namespace/pod/container.
+	Containers []string `json:"containers"`
+	Certified  bool     `json:"certified"`
+}
+
+// imageCertifications holds the certification status of the images read by the image certification test.
+var imageCertifications []ImageCertification
+
+// OperatorCertification is the certification status of an operator under test in the certified operators index.
+type OperatorCertification struct {
+	Operator string `json:"operator"`
+	Package  string `json:"package"`
+	Version  string `json:"version"`
+	// Channels are the channels of the package publishing the version, empty when it is not certified.
+	Channels []string `json:"channels"`
+}
+
+// operatorCertifications holds the certification status of the operators read by the operator bundle certification
+// test.
+var operatorCertifications []OperatorCertification
+
+// GetOperatorCertifications returns the certification status of the operators under test, empty unless the operator
+// bundle certification test ran.
+func GetOperatorCertifications() []OperatorCertification {
+	return operatorCertifications
+}
+
+// GetImageCertifications returns the certification status of the images of the containers under test, empty unless
+// the image certification test ran.
+func GetImageCertifications() []ImageCertification {
+	return imageCertifications
+}
+
 var _ = ginkgo.Describe(common.AffiliatedCertTestKey, func() {
 	conf, _ := ginkgo.GinkgoConfiguration()
 	if testcases.IsInFocus(conf.FocusStrings, common.AffiliatedCertTestKey) {
 		env := configpkg.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
-			env.LoadAndRefresh()
+			gomega.Expect(env.LoadAndRefresh()).To(gomega.Succeed())
 		})
 
 		ginkgo.ReportAfterEach(results.RecordResult)
 
 		testContainerCertificationStatus()
 		testOperatorCertificationStatus()
+		testContainerImageCertification(env)
+		testOperatorBundleCertification(env)
+		testHelmChartProvenance(env)
+		testHelmValuesOverrides(env)
 	}
 })
 
@@ -96,3 +143,174 @@ func testOperatorCertificationStatus() {
 		}
 	})
 }
+
+// testContainerImageCertification looks up the image of each container under test in the container catalog, or in its
+// offline dump, and fails the containers running images which are not certified.
+func testContainerImageCertification(env *configpkg.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestContainerImageCertifiedIdentifier)
+	ginkgo.It(testID, func() {
+		if len(env.PodsUnderTest) == 0 {
+			ginkgo.Skip("No pod under test found.")
+		}
+		checker, err := getImageCertificationChecker(&env.Config.ImageCertification)
+		gomega.Expect(err).To(gomega.BeNil())
+		ginkgo.By("Getting the certification status of the images of the containers under test")
+		byImage := map[api.ImageReference]*ImageCertification{}
+		var badContainers []string
+		for i := range env.PodsUnderTest {
+			pod := &env.PodsUnderTest[i]
+			for _, container := range getContainerImages(pod.Name, pod.Namespace) {
+				if env.SkipsContainerTest(testID, &configsections.ContainerIdentifier{Namespace: pod.Namespace,
+					PodName: pod.Name, ContainerName: container.Name}) {
+					continue
+				}
+				name := pod.FullName() + "/" + container.Name
+				image := api.ParseImageReference(container.Image)
+				if image.Digest == "" {
+					image.Digest = containerimages.GetDigest(container.ImageID)
+				}
+				if image.Digest == "" {
+					log.Errorf("The digest of the image %s of the container %s is unknown", container.Image, name)
+					badContainers = append(badContainers, name)
+					continue
+				}
+				if certification, ok := byImage[image]; ok {
+					certification.Containers = append(certification.Containers, name)
+					continue
+				}
+				certified, checkErr := checker.IsImageCertified(image)
+				if checkErr != nil {
+					log.Errorf("Cannot get the certification status of the image %s of the container %s: %v", image, name,
+						checkErr)
+					badContainers = append(badContainers, name)
+					continue
+				}
+				byImage[image] = &ImageCertification{ImageReference: image, Containers: []string{name}, Certified: certified}
+			}
+		}
+		imageCertifications = nil
+		for _, certification := range byImage {
+			if !certification.Certified {
+				log.Errorf("The image %s of the containers %v is not certified", certification.ImageReference,
+					certification.Containers)
+				badContainers = append(badContainers, certification.Containers...)
+			}
+			imageCertifications = append(imageCertifications, *certification)
+		}
+		sort.Slice(imageCertifications, func(i, j int) bool {
+			return imageCertifications[i].String() < imageCertifications[j].String()
+		})
+		results.RecordFailedTargets(badContainers...)
+		gomega.Expect(badContainers).To(gomega.BeEmpty())
+	})
+}
+
+// getImageCertificationChecker returns the offline dump of the container catalog when configured, the client of the
+// catalog API otherwise.
+func getImageCertificationChecker(imageCertification *configsections.ImageCertification) (api.ImageCertificationChecker, error) {
+	if imageCertification.OfflineCatalog == "" {
+		return api.NewHTTPClient(), nil
+	}
+	return api.LoadOfflineCatalog(imageCertification.OfflineCatalog)
+}
+
+// getContainerImages reads the image references and the image IDs of the containers of the pod.
+func getContainerImages(podName, podNamespace string) []containerimages.ContainerImage {
+	context := common.GetContext()
+	tester := containerimages.NewContainerImages(common.GetTimeout(common.AffiliatedCertTestKey, "containerimages"), podName,
+		podNamespace)
+	test, err := tnf.NewTest(configpkg.GetTestEnvironment().Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	common.RunAndValidateTest(test)
+	return tester.GetContainers()
+}
+
+// testOperatorBundleCertification checks that the package and version of each operator under test are published in the
+// certified operators index, through a cache of the catalog responses.
+func testOperatorBundleCertification(env *configpkg.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestOperatorBundleCertifiedIdentifier)
+	ginkgo.It(testID, func() {
+		if len(env.OperatorsUnderTest) == 0 {
+			ginkgo.Skip("No Operator found.")
+		}
+		ginkgo.By("Should publish the operators under test in the certified operators index")
+		operatorCertification := &env.Config.OperatorCertification
+		getter := api.NewCachedBundleGetter(api.NewHTTPClient(), operatorCertification.GetCacheFile(),
+			operatorCertification.GetCacheTTL())
+		operatorCertifications = nil
+		var badOperators []string
+		for _, operator := range env.OperatorsUnderTest {
+			name := operator.Namespace + "/" + operator.Name
+			if operator.Package == "" || operator.Version == "" {
+				log.Errorf("The package or the version of the operator %s is unknown, it was not installed by OLM", name)
+				badOperators = append(badOperators, name)
+				continue
+			}
+			bundles, err := getter.GetCertifiedOperatorBundles(operator.Package, operator.Version)
+			if err != nil {
+				log.Errorf("Cannot get the certified bundles of the operator %s: %v", name, err)
+				badOperators = append(badOperators, name)
+				continue
+			}
+			certification := OperatorCertification{Operator: name, Package: operator.Package, Version: operator.Version}
+			for _, bundle := range bundles {
+				certification.Channels = append(certification.Channels, bundle.Channel)
+			}
+			if len(certification.Channels) == 0 {
+				log.Errorf("The version %s of the package %s of the operator %s is not certified", operator.Version,
+					operator.Package, name)
+				badOperators = append(badOperators, name)
+			}
+			operatorCertifications = append(operatorCertifications, certification)
+		}
+		results.RecordFailedTargets(badOperators...)
+		gomega.Expect(badOperators).To(gomega.BeEmpty())
+	})
+}
+
+// testHelmChartProvenance checks that the chart of each Helm release under test declares where it comes from.
+func testHelmChartProvenance(env *configpkg.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestHelmChartProvenanceIdentifier)
+	ginkgo.It(testID, func() {
+		if len(env.Config.HelmReleases) == 0 {
+			ginkgo.Skip("No Helm release found.")
+		}
+		ginkgo.By("Should trace the charts of the Helm releases back to their sources")
+		var badReleases []string
+		for i := range env.Config.HelmReleases {
+			release := &env.Config.HelmReleases[i]
+			if issues := release.GetProvenanceIssues(); len(issues) > 0 {
+				log.Errorf("The Helm release %s cannot be traced back to its sources: %s", release.FullName(),
+					strings.Join(issues, ", "))
+				badReleases = append(badReleases, release.FullName())
+			}
+		}
+		results.RecordFailedTargets(badReleases...)
+		gomega.Expect(badReleases).To(gomega.BeEmpty())
+	})
+}
+
+// testHelmValuesOverrides checks that no Helm release under test overrides the images of its chart, unless allowed.
+func testHelmValuesOverrides(env *configpkg.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestHelmValuesOverridesIdentifier)
+	ginkgo.It(testID, func() {
+		if len(env.Config.HelmReleases) == 0 {
+			ginkgo.Skip("No Helm release found.")
+		}
+		ginkgo.By("Should not override the images of the charts of the Helm releases")
+		allowed := env.Config.Helm.AllowedImageOverrides
+		var badReleases []string
+		for i := range env.Config.HelmReleases {
+			release := &env.Config.HelmReleases[i]
+			log.Infof("The Helm release %s, revision %d of the chart %s %s, overrides the values %v", release.FullName(),
+				release.Revision, release.Chart, release.ChartVersion, release.OverriddenKeys)
+			if keys := release.GetOverriddenImageKeys(allowed); len(keys) > 0 {
+				log.Errorf("The Helm release %s overrides the images of its chart with the values %s", release.FullName(),
+					strings.Join(keys, ", "))
+				badReleases = append(badReleases, release.FullName())
+			}
+		}
+		results.RecordFailedTargets(badReleases...)
+		gomega.Expect(badReleases).To(gomega.BeEmpty())
+	})
+}
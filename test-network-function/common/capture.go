@@ -0,0 +1,102 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	configpkg "github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/tcpdump"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	artifactsDirPermissions = 0755
+	remoteCaptureDir        = "/tmp"
+)
+
+// PacketCapture is a bounded tcpdump capture running in the background of a pod or node debug pod session.
+type PacketCapture struct {
+	oc       *interactive.Oc
+	name     string
+	pcapFile string
+	pid      int
+	// Captured, ReceivedByFilter and DroppedByKernel are the statistics reported by tcpdump once stopped.
+	Captured         int
+	ReceivedByFilter int
+	DroppedByKernel  int
+	// ArtifactPath is the local path of the pcap file once stopped.
+	ArtifactPath string
+}
+
+// runTcpdump runs one step of a capture in the oc session and validates it.
+func runTcpdump(oc *interactive.Oc, tester *tcpdump.Tcpdump) {
+	test, err := tnf.NewTest(configpkg.GetTestEnvironment().Context(), oc.GetExpecter(), tester, []reel.Handler{tester}, oc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(test).ToNot(gomega.BeNil())
+	RunAndValidateTest(test)
+}
+
+// StartPacketCapture starts capturing at most `maxPackets` packets matching `filter` on `iface` in the session `oc`.
+// The capture stops by itself after `maxDuration`, so that a failing spec can never leave it running.  `name` is used
+// to name the resulting pcap artifact.  tcpdump must be available in the container or the node debug pod.
+func StartPacketCapture(oc *interactive.Oc, name, iface, filter string, maxPackets int, maxDuration time.Duration) *PacketCapture {
+	pc := &PacketCapture{
+		oc:       oc,
+		name:     name,
+		pcapFile: fmt.Sprintf("%s/tnf-%s-%d.pcap", remoteCaptureDir, name, time.Now().UnixNano()),
+	}
+	tester := tcpdump.NewStart(GetTimeout(CommonTestKey, "tcpdump"), iface, pc.pcapFile, filter, maxPackets, maxDuration)
+	runTcpdump(oc, tester)
+	pc.pid = tester.GetPID()
+	log.Debugf("packet capture %s started on %s/%s with pid %d", name, oc.GetPodName(), iface, pc.pid)
+	return pc
+}
+
+// Stop stops the capture, records its statistics and copies the pcap file into the artifacts directory.
+func (pc *PacketCapture) Stop() {
+	tester := tcpdump.NewStop(GetTimeout(CommonTestKey, "tcpdump"), pc.pid, pc.pcapFile)
+	runTcpdump(pc.oc, tester)
+	pc.Captured, pc.ReceivedByFilter, pc.DroppedByKernel = tester.GetStats()
+	log.Debugf("packet capture %s stopped: %d captured, %d received by filter, %d dropped by kernel",
+		pc.name, pc.Captured, pc.ReceivedByFilter, pc.DroppedByKernel)
+
+	artifactsDir := GetArtifactsDir()
+	err := os.MkdirAll(artifactsDir, artifactsDirPermissions)
+	gomega.Expect(err).To(gomega.BeNil())
+	pc.ArtifactPath = filepath.Join(artifactsDir, filepath.Base(pc.pcapFile))
+	command := fmt.Sprintf("oc cp -n %s -c %s %s:%s %s", pc.oc.GetPodNamespace(), pc.oc.GetPodContainerName(),
+		pc.oc.GetPodName(), pc.pcapFile, pc.ArtifactPath)
+	ExecuteCommand(command, DefaultTimeout, GetContext(), func() {
+		log.Errorf("failed to copy pcap file %s from pod %s", pc.pcapFile, pc.oc.GetPodName())
+	})
+	log.Infof("packet capture %s stored as %s", pc.name, pc.ArtifactPath)
+}
+
+// CountPackets returns the number of captured packets matching `filter`.  It must be called after Stop.
+func (pc *PacketCapture) CountPackets(filter string) int {
+	tester := tcpdump.NewCount(GetTimeout(CommonTestKey, "tcpdump"), pc.pcapFile, filter)
+	runTcpdump(pc.oc, tester)
+	return tester.GetCount()
+}
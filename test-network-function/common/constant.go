@@ -20,6 +20,8 @@ package common
 const (
 	ConfiguredTestFile        = "testconfigure.yml"
 	defaultTimeoutSeconds     = 10
+	defaultArtifactsDir       = "artifacts"
+	defaultParallelism        = 1
 	AccessControlTestKey      = "access-control"
 	DiagnosticTestKey         = "diagnostic"
 	LifecycleTestKey          = "lifecycle"
@@ -28,5 +30,8 @@ const (
 	ObservabilityTestKey      = "observability"
 	OperatorTestKey           = "operator"
 	PlatformAlterationTestKey = "platform-alteration"
+	SecurityContextTestKey    = "security-context"
 	CommonTestKey             = "common"
+	AllowIntrusiveFlagKey     = "allow-intrusive"
+	AllowLoadFlagKey          = "allow-load"
 )
@@ -20,51 +20,159 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"time"
 
+	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
+	configpkg "github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 )
 
 var (
 	// PathRelativeToRoot is used to calculate relative filepaths for the `test-network-function` executable entrypoint.
-	PathRelativeToRoot = path.Join("..")
+	PathRelativeToRoot = filepath.Join("..")
 
 	// RelativeSchemaPath is the relative path to the generic-test.schema.json JSON schema.
-	RelativeSchemaPath = path.Join(PathRelativeToRoot, schemaPath)
+	RelativeSchemaPath = filepath.Join(PathRelativeToRoot, schemaPath)
 
 	// schemaPath is the path to the generic-test.schema.json JSON schema relative to the project root.
-	schemaPath = path.Join("schemas", "generic-test.schema.json")
+	schemaPath = filepath.Join("schemas", "generic-test.schema.json")
+
+	// RelativePackSchemaPath is the relative path to the testcase-pack.schema.json JSON schema of the test case packs.
+	RelativePackSchemaPath = filepath.Join(PathRelativeToRoot, "schemas", "testcase-pack.schema.json")
 )
 
 // DefaultTimeout for creating new interactive sessions (oc, ssh, tty)
 var DefaultTimeout = time.Duration(defaultTimeoutSeconds) * time.Second
 
+// GetTimeout returns the timeout of the tests run with a handler by a suite, DefaultTimeout unless overridden in the
+// timeouts section of the configuration file.  The handler is named after its package, e.g. "ping".
+func GetTimeout(suite, handler string) time.Duration {
+	return configpkg.GetTestEnvironment().Config.Timeouts.Get(suite, handler, DefaultTimeout)
+}
+
+// GetRetryPolicy returns the retry policy of the tests run with a handler by a suite, as set in the retries section of
+// the configuration file.  The tests are run once by default.
+func GetRetryPolicy(suite, handler string) tnf.RetryPolicy {
+	return tnf.RetryPolicy(configpkg.GetTestEnvironment().Config.Retries.Get(suite, handler))
+}
+
 // LogLevelTraceEnabled is saved to filter some debug trace logs (e.g. expecters Sent/Match)
 var LogLevelTraceEnabled = false
 
-// GetContext spawns a new shell session and returns its context
+// sessions are the shell sessions reused across the specs instead of spawning a shell per GetContext call.  The
+// sessions are re-spawned once they ended, so that a session dropped mid-spec does not fail the remaining tests of the
+// spec.
+var sessions = interactive.NewSessionPool(spawnReconnectingShellContext, interactive.DefaultMaxIdleSessions,
+	interactive.DefaultSessionKeepAlivePeriod, interactive.DefaultSessionProbeTimeout)
+
+// spawnReconnectingShellContext spawns a shell session which is lazily re-spawned once it ended.
+func spawnReconnectingShellContext() (*interactive.Context, error) {
+	return interactive.NewReconnectingContext(SpawnShellContext)
+}
+
+// GetContext returns the context of a healthy shell session, reused from a previous spec or newly spawned.  It must be
+// called from a running spec: the session goes back to the pool once the spec completes, or is closed if it failed.
 func GetContext() *interactive.Context {
-	context, err := interactive.SpawnShell(interactive.CreateGoExpectSpawner(), DefaultTimeout, interactive.Verbose(LogLevelTraceEnabled), interactive.SendTimeout(DefaultTimeout))
+	context, err := sessions.Get()
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(context).ToNot(gomega.BeNil())
 	gomega.Expect(context.GetExpecter()).ToNot(gomega.BeNil())
+	ginkgo.DeferCleanup(releaseContext, context)
 	return context
 }
 
+// releaseContext returns a session to the pool, unless the spec using it failed and may have left output pending.
+func releaseContext(context *interactive.Context) {
+	if ginkgo.CurrentSpecReport().Failed() {
+		sessions.Discard(context)
+		return
+	}
+	sessions.Put(context)
+}
+
+// CloseSessions closes the pooled shell sessions, once all the specs ran.
+func CloseSessions() {
+	sessions.Close()
+}
+
 // IsMinikube returns true when the env var is set, OCP only test would be skipped based on this flag
 func IsMinikube() bool {
 	b, _ := strconv.ParseBool(os.Getenv("TNF_MINIKUBE_ONLY"))
 	return b
 }
 
-// Intrusive is for running tests that can impact the CNF or test environment in an intrusive way
+// AllowIntrusive is set by the -allow-intrusive flag of the test binary to run the intrusive and destructive tests.
+var AllowIntrusive = false
+
+// Intrusive is for running tests that can impact the CNF or test environment in an intrusive way.  Such tests are
+// skipped unless the -allow-intrusive flag is passed, or TNF_NON_INTRUSIVE_ONLY is explicitly set to false.
 func Intrusive() bool {
-	b, _ := strconv.ParseBool(os.Getenv("TNF_NON_INTRUSIVE_ONLY"))
-	return !b
+	if AllowIntrusive {
+		return true
+	}
+	b, err := strconv.ParseBool(os.Getenv("TNF_NON_INTRUSIVE_ONLY"))
+	return err == nil && !b
+}
+
+// SkipUnlessAllowed skips the current spec when its classification is intrusive and intrusive tests are not enabled.
+func SkipUnlessAllowed(classification testcases.TestClassification) {
+	if classification.IsIntrusive() && !Intrusive() {
+		ginkgo.Skip(fmt.Sprintf("%s test, run with -%s to enable it", classification, AllowIntrusiveFlagKey))
+	}
+}
+
+// SkipUnlessSupportedVersion skips the current spec when the cluster version is outside the minimum and maximum
+// versions it supports, see occompat.IsSupported.  The spec runs when the cluster version is unknown.
+func SkipUnlessSupportedVersion(minVersion, maxVersion string) {
+	cluster := occompat.GetVersions().Server
+	supported, err := occompat.IsSupported(cluster, minVersion, maxVersion)
+	gomega.Expect(err).To(gomega.BeNil())
+	if !supported {
+		ginkgo.Skip(fmt.Sprintf("unsupported cluster version %s, the test supports the versions from %q to %q", cluster,
+			minVersion, maxVersion))
+	}
+}
+
+// SkipUnlessOpenShift skips the current spec when the cluster is not an OpenShift cluster, see occompat.IsOpenShift.
+func SkipUnlessOpenShift() {
+	if !occompat.IsOpenShift() {
+		ginkgo.Skip(fmt.Sprintf("the test requires an OpenShift cluster, the cluster flavor is %s", occompat.GetFlavor()))
+	}
+}
+
+// RunsOnNode returns true when the node test case testID runs on a node, according to the node roles of the
+// configuration.  byDefault is returned when none of the roles of the node is configured.
+func RunsOnNode(env *configpkg.TestEnvironment, node *configpkg.NodeConfig, testID string, byDefault bool) bool {
+	return configsections.RunsOnNode(env.Config.NodeRoles, &node.Node, testID, byDefault)
+}
+
+// AllowLoad is set by the -allow-load flag of the test binary to run the load-generating tests.
+var AllowLoad = false
+
+// LoadGenerating is for running tests that generate traffic or load which may disturb the CNF, e.g. throughput
+// measurements.  Such tests are skipped unless the -allow-load flag is passed, or TNF_ALLOW_LOAD is set to true.
+func LoadGenerating() bool {
+	if AllowLoad {
+		return true
+	}
+	b, _ := strconv.ParseBool(os.Getenv("TNF_ALLOW_LOAD"))
+	return b
+}
+
+// SkipUnlessLoadAllowed skips the current spec when load-generating tests are not enabled.
+func SkipUnlessLoadAllowed() {
+	if !LoadGenerating() {
+		ginkgo.Skip(fmt.Sprintf("load-generating test, run with -%s to enable it", AllowLoadFlagKey))
+	}
 }
 
 // GetOcDebugImageID is for running oc debug commands in a disconnected environment with a specific oc debug pod image mirrored
@@ -72,9 +180,33 @@ func GetOcDebugImageID() string {
 	return os.Getenv("TNF_OC_DEBUG_IMAGE_ID")
 }
 
-// logLevel retrieves the LOG_LEVEL environment variable
+// GetArtifactsDir returns the directory where test artifacts such as packet captures are stored, from the
+// TNF_ARTIFACTS_DIR environment variable.  It defaults to "artifacts" in the current directory.
+func GetArtifactsDir() string {
+	artifactsDir := os.Getenv("TNF_ARTIFACTS_DIR")
+	if artifactsDir == "" {
+		artifactsDir = defaultArtifactsDir
+	}
+	return artifactsDir
+}
+
+// GetParallelism returns the number of sessions used to run the per-pod checks concurrently, from the
+// TNF_PARALLELISM environment variable.  It defaults to 1, i.e. the checks run sequentially.
+func GetParallelism() int {
+	parallelism, err := strconv.Atoi(os.Getenv("TNF_PARALLELISM"))
+	if err != nil || parallelism < 1 {
+		return defaultParallelism
+	}
+	return parallelism
+}
+
+// logLevel retrieves the logLevel field of the configuration, which the LOG_LEVEL environment variable overrides, see
+// configsections.GetEnvOverrides
 func logLevel() string {
 	logLevel := os.Getenv("LOG_LEVEL")
+	if testConfig, err := configpkg.ReadConfigurationFile(); err == nil && testConfig.LogLevel != "" {
+		logLevel = testConfig.LogLevel
+	}
 	if logLevel == "" {
 		log.Info("LOG_LEVEL environment is not set, defaulting to DEBUG")
 		logLevel = "debug" //nolint:goconst
@@ -83,12 +215,13 @@ func logLevel() string {
 	return logLevel
 }
 
-// SetLogLevel sets the log level for logrus based on the "LOG_LEVEL" environment variable
+// SetLogLevel sets the log level for logrus based on the logLevel field of the configuration and its overrides, e.g. the
+// "LOG_LEVEL" environment variable
 func SetLogLevel() {
 	var aLogLevel, err = log.ParseLevel(logLevel())
 
 	if err != nil {
-		log.Error("LOG_LEVEL environment set with an invalid value, defaulting to DEBUG \n Valid values are:  trace, debug, info, warn, error, fatal, panic")
+		log.Error("logLevel configuration field or LOG_LEVEL environment set with an invalid value, defaulting to DEBUG \n Valid values are:  trace, debug, info, warn, error, fatal, panic")
 		aLogLevel = log.DebugLevel
 	}
 
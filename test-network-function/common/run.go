@@ -0,0 +1,122 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package common
+
+import (
+	"time"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	configpkg "github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/utils"
+)
+
+// RunAndValidateTest runs the test and checks the result
+func RunAndValidateTest(test *tnf.Test) {
+	RunAndValidateTestWithFailureCallback(test, nil)
+}
+
+// RunAndValidateTestWithFailureCallback runs the test, checks the result/error and invokes the cb on failure.  The
+// failure reports the output received when the test timed out, see tnf.Test.RunAndCheck.
+func RunAndValidateTestWithFailureCallback(test *tnf.Test, cb func()) {
+	err := test.RunAndCheck(cb)
+	abortOnCancellation(err)
+	gomega.Expect(err).To(gomega.BeNil())
+}
+
+// RunWithRetry runs the test created by newTest with the retry policy of the handler run by the suite, see
+// GetRetryPolicy.  newTest is called for each attempt, and all the attempts are recorded in the claim when the test
+// was retried.  It returns the result and error of the last attempt, it can be called by RunInParallel workers.
+func RunWithRetry(suite, handler string, newTest func() (*tnf.Test, error)) (int, error) {
+	return tnf.RunWithRetry(GetRetryPolicy(suite, handler), newTest)
+}
+
+// SpawnShellContext spawns a new shell session, it can be used to create a pool of sessions for RunInParallel.
+func SpawnShellContext() (*interactive.Context, error) {
+	return utils.SpawnShell(DefaultTimeout, interactive.Verbose(LogLevelTraceEnabled), interactive.SendTimeout(DefaultTimeout))
+}
+
+// SpawnOcContextFunc returns a function spawning new sessions to the same container as oc, it can be used to create
+// a pool of sessions for RunInParallelWithSpawner.
+func SpawnOcContextFunc(oc *interactive.Oc) func() (*interactive.Context, error) {
+	return func() (*interactive.Context, error) {
+		var spawner interactive.Spawner = interactive.NewGoExpectSpawner()
+		session, _, err := interactive.SpawnOc(&spawner, oc.GetPodName(), oc.GetPodContainerName(), oc.GetPodNamespace(),
+			oc.GetTimeout(), oc.GetOptions()...)
+		if err != nil {
+			return nil, err
+		}
+		return interactive.NewContext(session.GetExpecter(), session.GetErrorChannel()), nil
+	}
+}
+
+// RunInParallel calls fn for each of n items, e.g. the pods under test, on a pool of up to GetParallelism() shell
+// sessions.  The spec fails with the errors of all the failed items once they all completed.  Items running on
+// sessions of their own, e.g. to their container or debug pod, use ForEachInParallel instead: no session is spawned,
+// the pool only bounds the parallelism.
+func RunInParallel(n int, fn func(i int, context *interactive.Context) error) {
+	RunInParallelWithSpawner(n, SpawnShellContext, fn)
+}
+
+// ForEachInParallel calls fn for each of n items like RunInParallel, without spawning any session.
+func ForEachInParallel(n int, fn func(i int) error) {
+	RunInParallelWithSpawner(n, nil, func(i int, _ *interactive.Context) error {
+		return fn(i)
+	})
+}
+
+// RunInParallelWithSpawner is RunInParallel with the sessions of the pool created by spawn, e.g. sessions to a given
+// container.  A nil spawn does not spawn any session, see ForEachInParallel.
+func RunInParallelWithSpawner(n int, spawn func() (*interactive.Context, error), fn func(i int, context *interactive.Context) error) {
+	size := GetParallelism()
+	if size > n {
+		size = n
+	}
+	if size == 0 {
+		return
+	}
+	pool, err := interactive.NewContextPool(size, spawn)
+	gomega.Expect(err).To(gomega.BeNil())
+	defer pool.Close()
+	var failures []string
+	for _, err := range pool.ForEach(n, fn) {
+		abortOnCancellation(err)
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	gomega.Expect(failures).To(gomega.BeEmpty())
+}
+
+// abortOnCancellation aborts the suite when err was returned by a test interrupted by the cancellation of the run
+// context, e.g. on SIGINT: the current spec is reported as aborted and the remaining specs do not run.
+func abortOnCancellation(err error) {
+	if tnf.IsAborted(err) {
+		ginkgo.AbortSuite(err.Error(), 1)
+	}
+}
+
+// ExecuteCommand executes an arbitrary interactive command with utils.ExecuteCommand, and checks it succeeded.  It
+// returns the command output.
+func ExecuteCommand(command string, timeout time.Duration, context *interactive.Context, failureCallbackFun func()) string {
+	out, err := utils.ExecuteCommand(configpkg.GetTestEnvironment().Context(), command, timeout, context, failureCallbackFun)
+	abortOnCancellation(err)
+	gomega.Expect(err).To(gomega.BeNil())
+	return out
+}
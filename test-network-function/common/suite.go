@@ -21,27 +21,47 @@ import (
 	log "github.com/sirupsen/logrus"
 	configpkg "github.com/test-network-function/test-network-function/pkg/config"
 	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
+	"github.com/test-network-function/test-network-function/pkg/debugpods"
 )
 
 var env *configpkg.TestEnvironment
 
+// runStartHooks are called at the start of the run, see OnRunStart.
+var runStartHooks []func()
+
+// OnRunStart registers hook to be called at the start of the run, before any spec, e.g. to take the baseline of a
+// measurement compared at the end of the run.  It must be called while the specs are defined.
+func OnRunStart(hook func()) {
+	runStartHooks = append(runStartHooks, hook)
+}
+
 var _ = ginkgo.BeforeSuite(func() {
 	for name := range autodiscover.GetNodesList() {
 		autodiscover.DeleteDebugLabel(name)
 	}
+	for _, hook := range runStartHooks {
+		hook()
+	}
 })
 
 var _ = ginkgo.AfterSuite(func() {
 	// clean up added label to nodes
 	log.Info("clean up added labels to nodes")
 	env = configpkg.GetTestEnvironment()
-	env.LoadAndRefresh()
+	if err := env.LoadAndRefresh(); err != nil {
+		log.Warnf("cannot refresh the test environment: %s", err)
+	}
 	for name, node := range env.NodesUnderTest {
 		if !(node.HasDebugPod()) {
 			continue
 		}
-		node.Oc.Close()
-		node.Oc = nil
+		if node.Oc != nil {
+			node.Oc.Close()
+			node.Oc = nil
+		}
 		autodiscover.DeleteDebugLabel(name)
 	}
+	if err := debugpods.DeleteDeployed(); err != nil {
+		log.Warnf("cannot delete the debug daemonset: %s", err)
+	}
 })
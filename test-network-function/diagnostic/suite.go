@@ -2,7 +2,7 @@ package diagnostic
 
 import (
 	"encoding/json"
-	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -12,10 +12,13 @@ import (
 
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/clusterplatform"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/clusterversion"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodedebug"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
@@ -24,6 +27,9 @@ import (
 const (
 	// defaultTimeoutSeconds contains the default timeout in secons.
 	defaultTimeoutSeconds = 20
+
+	// minikubePlatform is the platform of the minikube clusters, which have no infrastructure resource.
+	minikubePlatform = "minikube"
 )
 
 var (
@@ -37,31 +43,33 @@ var (
 
 	versionsOcp clusterversion.ClusterVersion
 
+	clusterInfo = ClusterInfo{}
+
 	nodesHwInfo = NodesHwInfo{}
 
 	// csiDriver stores the csi driver JSON output of `oc get csidriver -o json`
 	csiDriver = make(map[string]interface{})
 
 	// nodesTestPath is the file location of the nodes.json test case relative to the project root.
-	nodesTestPath = path.Join("pkg", "tnf", "handlers", "node", "nodes.json")
+	nodesTestPath = filepath.Join("pkg", "tnf", "handlers", "node", "nodes.json")
 
 	// csiDriverTestPath is the file location of the csidriver.json test case relative to the project root.
-	csiDriverTestPath = path.Join("pkg", "tnf", "handlers", "csidriver", "csidriver.json")
+	csiDriverTestPath = filepath.Join("pkg", "tnf", "handlers", "csidriver", "csidriver.json")
 
 	// relativeCsiDriverTestPath is the relative path to the csidriver.json test case.
-	relativeCsiDriverTestPath = path.Join(pathRelativeToRoot, csiDriverTestPath)
+	relativeCsiDriverTestPath = filepath.Join(pathRelativeToRoot, csiDriverTestPath)
 
 	// pathRelativeToRoot is used to calculate relative filepaths for the `test-network-function` executable entrypoint.
-	pathRelativeToRoot = path.Join("..")
+	pathRelativeToRoot = filepath.Join("..")
 
 	// relativeNodesTestPath is the relative path to the nodes.json test case.
-	relativeNodesTestPath = path.Join(pathRelativeToRoot, nodesTestPath)
+	relativeNodesTestPath = filepath.Join(pathRelativeToRoot, nodesTestPath)
 
 	// relativeSchemaPath is the relative path to the generic-test.schema.json JSON schema.
-	relativeSchemaPath = path.Join(pathRelativeToRoot, schemaPath)
+	relativeSchemaPath = filepath.Join(pathRelativeToRoot, schemaPath)
 
 	// schemaPath is the path to the generic-test.schema.json JSON schema relative to the project root.
-	schemaPath = path.Join("schemas", "generic-test.schema.json")
+	schemaPath = filepath.Join("schemas", "generic-test.schema.json")
 
 	// retrieve the singleton instance of test environment
 	env *config.TestEnvironment = config.GetTestEnvironment()
@@ -71,7 +79,7 @@ var _ = ginkgo.Describe(common.DiagnosticTestKey, func() {
 	conf, _ := ginkgo.GinkgoConfiguration()
 	if testcases.IsInFocus(conf.FocusStrings, common.DiagnosticTestKey) {
 		ginkgo.BeforeEach(func() {
-			env.LoadAndRefresh()
+			gomega.Expect(env.LoadAndRefresh()).To(gomega.Succeed())
 			gomega.Expect(len(env.PodsUnderTest)).ToNot(gomega.Equal(0))
 			gomega.Expect(len(env.ContainersUnderTest)).ToNot(gomega.Equal(0))
 		})
@@ -81,6 +89,11 @@ var _ = ginkgo.Describe(common.DiagnosticTestKey, func() {
 			testOcpVersion()
 		})
 
+		testID = identifiers.XformToGinkgoItIdentifier(identifiers.TestClusterInfoIdentifier)
+		ginkgo.It(testID, func() {
+			testClusterInfo()
+		})
+
 		testID = identifiers.XformToGinkgoItIdentifier(identifiers.TestExtractNodeInformationIdentifier)
 		ginkgo.It(testID, func() {
 			context := common.GetContext()
@@ -92,11 +105,11 @@ var _ = ginkgo.Describe(common.DiagnosticTestKey, func() {
 			gomega.Expect(handlers).ToNot(gomega.BeNil())
 			gomega.Expect(tester).ToNot(gomega.BeNil())
 
-			test, err := tnf.NewTest(context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
 			gomega.Expect(err).To(gomega.BeNil())
 			gomega.Expect(test).ToNot(gomega.BeNil())
 
-			test.RunAndValidate()
+			common.RunAndValidateTest(test)
 
 			genericTest := (*tester).(*generic.Generic)
 			gomega.Expect(genericTest).ToNot(gomega.BeNil())
@@ -127,6 +140,20 @@ type CniPlugin struct {
 	version string
 }
 
+// ClusterInfo holds the versions, the platform and the network type of the cluster
+type ClusterInfo struct {
+	OcpVersion string `json:"ocpVersion"`
+	K8sVersion string `json:"k8sVersion"`
+	// Flavor is openshift, or kubernetes for an upstream Kubernetes cluster.
+	Flavor string `json:"flavor"`
+	// Platform is the infrastructure platform, e.g. AWS, BareMetal or None, minikube for a minikube cluster and empty
+	// for the other upstream Kubernetes clusters.
+	Platform  string `json:"platform"`
+	BareMetal bool   `json:"bareMetal"`
+	// NetworkType is the CNI plugin, e.g. OVNKubernetes, the names of the CNI plugins of the nodes when unknown.
+	NetworkType string `json:"networkType"`
+}
+
 // NodeHwInfo node HW info
 type NodeHwInfo struct {
 	NodeName string
@@ -157,6 +184,11 @@ func GetVersionsOcp() clusterversion.ClusterVersion {
 	return versionsOcp
 }
 
+// GetClusterInfo returns the versions, the platform and the network type of the cluster
+func GetClusterInfo() ClusterInfo {
+	return clusterInfo
+}
+
 // GetNodesHwInfo returns an object with HW info of one master and one worker
 func GetNodesHwInfo() NodesHwInfo {
 	return nodesHwInfo
@@ -191,9 +223,9 @@ func listNodeCniPlugins(nodeName string) []CniPlugin {
 	nodes := config.GetTestEnvironment().NodesUnderTest
 	context := nodes[nodeName].Oc
 	tester := nodedebug.NewNodeDebug(defaultTestTimeout, nodeName, command, true, true)
-	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	gomega.Expect(len(tester.Processed)%2 == 0).To(gomega.BeTrue())
 	for i := 0; i < len(tester.Processed); i += 2 {
 		result = append(result, CniPlugin{
@@ -207,12 +239,43 @@ func listNodeCniPlugins(nodeName string) []CniPlugin {
 func testOcpVersion() {
 	context := common.GetContext()
 	tester := clusterversion.NewClusterVersion(defaultTestTimeout)
-	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	versionsOcp = tester.GetVersions()
 }
 
+func testClusterInfo() {
+	if versionsOcp.K8s == "" {
+		testOcpVersion()
+	}
+	clusterInfo = ClusterInfo{OcpVersion: versionsOcp.Ocp, K8sVersion: versionsOcp.K8s,
+		Flavor: string(occompat.GetFlavor())}
+	if common.IsMinikube() {
+		clusterInfo.Platform = minikubePlatform
+	} else if occompat.IsOpenShift() {
+		context := common.GetContext()
+		tester := clusterplatform.NewClusterPlatform(defaultTestTimeout)
+		test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+		gomega.Expect(err).To(gomega.BeNil())
+		common.RunAndValidateTest(test)
+		platform := tester.GetPlatform()
+		clusterInfo.Platform = platform.Type
+		clusterInfo.BareMetal = platform.IsBareMetal()
+		clusterInfo.NetworkType = platform.NetworkType
+	}
+	if clusterInfo.NetworkType == "" {
+		names := make([]string, len(cniPlugins))
+		for i := range cniPlugins {
+			names[i] = cniPlugins[i].Name
+		}
+		clusterInfo.NetworkType = strings.Join(names, ",")
+	}
+	log.Infof("Cluster version %s (Kubernetes %s), flavor %s, platform %s, bare metal %t, network type %s",
+		clusterInfo.OcpVersion, clusterInfo.K8sVersion, clusterInfo.Flavor, clusterInfo.Platform, clusterInfo.BareMetal,
+		clusterInfo.NetworkType)
+}
+
 func testCniPlugins() {
 	if common.IsMinikube() {
 		ginkgo.Skip("can't use 'oc debug' in minikube")
@@ -255,9 +318,9 @@ func getNodeLscpu(nodeName string) map[string]string {
 	nodes := env.NodesUnderTest
 	context := nodes[nodeName].Oc
 	tester := nodedebug.NewNodeDebug(defaultTestTimeout, nodeName, command, true, true)
-	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	for _, line := range tester.Processed {
 		fields := strings.SplitN(line, ":", numSplitSubstrings)
 		result[fields[0]] = strings.TrimSpace(fields[1])
@@ -273,9 +336,9 @@ func getNodeIfconfig(nodeName string) map[string][]string {
 	nodes := env.NodesUnderTest
 	context := nodes[nodeName].Oc
 	tester := nodedebug.NewNodeDebug(defaultTestTimeout, nodeName, command, true, true)
-	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	deviceName := ""
 	for _, line := range tester.Processed {
 		if line == "" {
@@ -297,9 +360,9 @@ func getNodeLsblk(nodeName string) interface{} {
 	nodes := env.NodesUnderTest
 	context := nodes[nodeName].Oc
 	tester := nodedebug.NewNodeDebug(defaultTestTimeout, nodeName, command, false, false)
-	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	result := map[string]interface{}{}
 	err = json.Unmarshal([]byte(tester.Raw), &result)
 	gomega.Expect(err).To(gomega.BeNil())
@@ -312,9 +375,9 @@ func getNodeLspci(nodeName string) []string {
 	nodes := env.NodesUnderTest
 	context := nodes[nodeName].Oc
 	tester := nodedebug.NewNodeDebug(defaultTestTimeout, nodeName, command, true, true)
-	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	return tester.Processed
 }
 
@@ -331,10 +394,10 @@ func listClusterCSIInfo() {
 	gomega.Expect(handlers).ToNot(gomega.BeNil())
 	gomega.Expect(len(handlers)).To(gomega.Equal(1))
 	gomega.Expect(tester).ToNot(gomega.BeNil())
-	test, err := tnf.NewTest(context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
+	test, err := tnf.NewTest(env.Context(), context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(test).ToNot(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	genericTest := (*tester).(*generic.Generic)
 	gomega.Expect(genericTest).ToNot(gomega.BeNil())
 	matches := genericTest.Matches
@@ -18,6 +18,7 @@ package generic
 
 import (
 	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
 	"github.com/test-network-function/test-network-function/pkg/config"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
@@ -33,7 +34,7 @@ var _ = ginkgo.Describe(testsKey, func() {
 	if testcases.IsInFocus(conf.FocusStrings, testsKey) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
-			env.LoadAndRefresh()
+			gomega.Expect(env.LoadAndRefresh()).To(gomega.Succeed())
 		})
 		ginkgo.ReportAfterEach(results.RecordResult)
 	}
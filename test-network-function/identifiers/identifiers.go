@@ -19,9 +19,13 @@ package identifiers
 
 import (
 	"fmt"
+	"path"
+	"sort"
 	"strings"
 
 	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/remediation"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 )
 
@@ -33,6 +37,10 @@ const (
 	versionOne               = "v1.0.0"
 )
 
+// CatalogVersion is the version of the certification policy implemented by the test catalog, to be bumped along with
+// the published release metadata whenever test cases are added or their pass criteria change.
+const CatalogVersion = "v1.0.0"
+
 // TestCaseDescription describes a JUnit test case.
 type TestCaseDescription struct {
 	// Identifier is the unique test identifier.
@@ -49,6 +57,89 @@ type TestCaseDescription struct {
 
 	// BestPracticeReference is a helpful best practice references of the test case.
 	BestPracticeReference string `json:"BestPracticeReference" yaml:"BestPracticeReference"`
+
+	// Classification is the impact of the test case on the cluster under test, Safe when empty.
+	Classification testcases.TestClassification `json:"classification,omitempty" yaml:"classification,omitempty"`
+
+	// RemediationTheme groups the failures of the test case in the remediation summary, the theme of its suite when
+	// empty.
+	RemediationTheme remediation.Theme `json:"remediationTheme,omitempty" yaml:"remediationTheme,omitempty"`
+
+	// MinVersion and MaxVersion are the oldest and the latest cluster versions the test case supports, e.g. "1.21" or
+	// "4.8" for OpenShift 4.8, unbounded when empty.  The test case is skipped on the other clusters.
+	MinVersion string `json:"minVersion,omitempty" yaml:"minVersion,omitempty"`
+	MaxVersion string `json:"maxVersion,omitempty" yaml:"maxVersion,omitempty"`
+
+	// RequiresOpenShift is true for the test cases relying on the OpenShift resources, e.g. the MachineConfigs or the
+	// OLM ClusterServiceVersions.  They are skipped on the upstream Kubernetes clusters.
+	RequiresOpenShift bool `json:"requiresOpenShift,omitempty" yaml:"requiresOpenShift,omitempty"`
+
+	// ResourceTypes are the types of the resources the test case checks, the types of its suite when empty.
+	ResourceTypes []ResourceType `json:"resourceTypes,omitempty" yaml:"resourceTypes,omitempty"`
+
+	// Tags label the test case in addition to the name of its suite, the tags of its suite and its classification
+	// when not safe, e.g. telco.
+	Tags []testcases.Tag `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// suiteRemediationThemes are the default remediation themes of the test cases of each suite.
+var suiteRemediationThemes = map[string]remediation.Theme{
+	common.AccessControlTestKey:      remediation.SecurityContext,
+	common.AffiliatedCertTestKey:     remediation.Images,
+	common.LifecycleTestKey:          remediation.Lifecycle,
+	common.NetworkingTestKey:         remediation.Networking,
+	common.ObservabilityTestKey:      remediation.Observability,
+	common.OperatorTestKey:           remediation.Operators,
+	common.PlatformAlterationTestKey: remediation.Platform,
+	common.SecurityContextTestKey:    remediation.SecurityContext,
+}
+
+// ResourceType is a type of the resources checked by test cases, to select the test cases applicable to a CNF.
+type ResourceType string
+
+const (
+	// ResourcePod is the type of the test cases checking the pods under test.
+	ResourcePod ResourceType = "pod"
+	// ResourceContainer is the type of the test cases checking the containers under test or their images.
+	ResourceContainer ResourceType = "container"
+	// ResourceDeployment is the type of the test cases checking the deployments under test.
+	ResourceDeployment ResourceType = "deployment"
+	// ResourceStatefulSet is the type of the test cases checking the statefulsets under test.
+	ResourceStatefulSet ResourceType = "statefulset"
+	// ResourceService is the type of the test cases checking the services of the CNF.
+	ResourceService ResourceType = "service"
+	// ResourceNamespace is the type of the test cases checking the namespaces under test.
+	ResourceNamespace ResourceType = "namespace"
+	// ResourceOperator is the type of the test cases checking the operators under test.
+	ResourceOperator ResourceType = "operator"
+	// ResourceCRD is the type of the test cases checking the CRDs under test or their custom resources.
+	ResourceCRD ResourceType = "crd"
+	// ResourceHelmRelease is the type of the test cases checking the Helm releases under test.
+	ResourceHelmRelease ResourceType = "helm-release"
+	// ResourceNode is the type of the test cases checking the nodes of the cluster.
+	ResourceNode ResourceType = "node"
+	// ResourceCluster is the type of the test cases reporting information on the cluster.
+	ResourceCluster ResourceType = "cluster"
+)
+
+// suiteResourceTypes are the default resource types of the test cases of each suite.
+var suiteResourceTypes = map[string][]ResourceType{
+	common.AccessControlTestKey:      {ResourcePod},
+	common.AffiliatedCertTestKey:     {ResourceContainer},
+	common.DiagnosticTestKey:         {ResourceCluster},
+	common.LifecycleTestKey:          {ResourcePod},
+	common.NetworkingTestKey:         {ResourcePod},
+	common.ObservabilityTestKey:      {ResourceContainer},
+	common.OperatorTestKey:           {ResourceOperator},
+	common.PlatformAlterationTestKey: {ResourceContainer},
+	common.SecurityContextTestKey:    {ResourceContainer},
+}
+
+// suiteTags are the tags of the test cases of each suite, in addition to the name of the suite.
+var suiteTags = map[string][]testcases.Tag{
+	common.AccessControlTestKey:   {testcases.TagSecurity},
+	common.AffiliatedCertTestKey:  {testcases.TagCertification},
+	common.SecurityContextTestKey: {testcases.TagSecurity},
 }
 
 func formTestURL(suite, name string) string {
@@ -69,6 +160,28 @@ var (
 		Url:     formTestURL(common.AffiliatedCertTestKey, "container-is-certified"),
 		Version: versionOne,
 	}
+	// TestContainerImageCertifiedIdentifier reports whether the images of the containers under test are certified.
+	TestContainerImageCertifiedIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AffiliatedCertTestKey, "container-image-certified"),
+		Version: versionOne,
+	}
+	// TestOperatorBundleCertifiedIdentifier ensures the operators under test are published in the certified operators
+	// index.
+	TestOperatorBundleCertifiedIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AffiliatedCertTestKey, "operator-bundle-certified"),
+		Version: versionOne,
+	}
+	// TestHelmChartProvenanceIdentifier ensures the charts of the Helm releases under test can be traced back to their
+	// sources.
+	TestHelmChartProvenanceIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AffiliatedCertTestKey, "helm-chart-provenance"),
+		Version: versionOne,
+	}
+	// TestHelmValuesOverridesIdentifier ensures the Helm releases under test do not override the images of their charts.
+	TestHelmValuesOverridesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AffiliatedCertTestKey, "helm-values-overrides"),
+		Version: versionOne,
+	}
 	// TestExtractNodeInformationIdentifier is a test which extracts Node information.
 	TestExtractNodeInformationIdentifier = claim.Identifier{
 		Url:     formTestURL(common.DiagnosticTestKey, "extract-node-information"),
@@ -94,6 +207,41 @@ var (
 		Url:     formTestURL(common.NetworkingTestKey, "icmpv4-connectivity"),
 		Version: versionOne,
 	}
+	// TestICMPv6ConnectivityIdentifier tests icmpv6 connectivity.
+	TestICMPv6ConnectivityIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "icmpv6-connectivity"),
+		Version: versionOne,
+	}
+	// TestSCTPConnectivityIdentifier tests the SCTP connectivity of the CNFs requiring SCTP.
+	TestSCTPConnectivityIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "sctp-connectivity"),
+		Version: versionOne,
+	}
+	// TestThroughputIdentifier measures the throughput between the partner pod and the CNF containers.
+	TestThroughputIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "throughput"),
+		Version: versionOne,
+	}
+	// TestDNSResolutionIdentifier tests the DNS resolution from inside the pods under test.
+	TestDNSResolutionIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "dns-resolution"),
+		Version: versionOne,
+	}
+	// TestServiceExposureIdentifier ensures the pods under test are only exposed on the ports they declare.
+	TestServiceExposureIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "service-exposure"),
+		Version: versionOne,
+	}
+	// TestNetworkPolicyPortsIdentifier ensures the NetworkPolicies do not block the ports exposed by the Services.
+	TestNetworkPolicyPortsIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "network-policy-ports"),
+		Version: versionOne,
+	}
+	// TestEgressDestinationsIdentifier ensures the pods under test only reach the allowed external destinations.
+	TestEgressDestinationsIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "egress-destinations"),
+		Version: versionOne,
+	}
 	// TestNamespaceBestPracticesIdentifier ensures the namespace has followed best namespace practices.
 	TestNamespaceBestPracticesIdentifier = claim.Identifier{
 		Url:     formTestURL(common.AccessControlTestKey, "namespace"),
@@ -124,6 +272,16 @@ var (
 		Url:     formTestURL(common.OperatorTestKey, "install-source"),
 		Version: versionOne,
 	}
+	// TestOperatorSubscriptionHealthIdentifier tests that the OLM subscription of an Operator is healthy.
+	TestOperatorSubscriptionHealthIdentifier = claim.Identifier{
+		Url:     formTestURL(common.OperatorTestKey, "subscription-health"),
+		Version: versionOne,
+	}
+	// TestOperatorInstallModeIdentifier tests that an Operator supports the install mode it is deployed with.
+	TestOperatorInstallModeIdentifier = claim.Identifier{
+		Url:     formTestURL(common.OperatorTestKey, "install-mode"),
+		Version: versionOne,
+	}
 	// TestPodNodeSelectorAndAffinityBestPractices is the test ensuring nodeSelector and nodeAffinity are not used by a
 	// Pod.
 	TestPodNodeSelectorAndAffinityBestPractices = claim.Identifier{
@@ -152,6 +310,62 @@ var (
 		Url:     formTestURL(common.LifecycleTestKey, "pod-recreation"),
 		Version: versionOne,
 	}
+	// TestStartupOrderingIdentifier ensures the CNF converges whatever the startup order of its pods.
+	TestStartupOrderingIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "startup-ordering"),
+		Version: versionOne,
+	}
+	// TestContainerResourcesIdentifier ensures the containers under test declare their CPU and memory requests and
+	// limits, and the latency-sensitive pods get the Guaranteed QoS class.
+	TestContainerResourcesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "container-resources"),
+		Version: versionOne,
+	}
+	// TestLivenessProbeIdentifier ensures the containers of the workloads under test define a liveness probe.
+	TestLivenessProbeIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "liveness-probe"),
+		Version: versionOne,
+	}
+	// TestReadinessProbeIdentifier ensures the containers of the workloads under test define a readiness probe.
+	TestReadinessProbeIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "readiness-probe"),
+		Version: versionOne,
+	}
+	// TestPodDisruptionBudgetIdentifier ensures the workloads under test are covered by a consistent PodDisruptionBudget.
+	TestPodDisruptionBudgetIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "pod-disruption-budget"),
+		Version: versionOne,
+	}
+	// TestPodSpreadingIdentifier ensures the pods of the workloads with several replicas are spread across the nodes.
+	TestPodSpreadingIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "pod-spreading"),
+		Version: versionOne,
+	}
+	// TestGracefulTerminationIdentifier ensures the pods under test set their grace period and handle their termination.
+	TestGracefulTerminationIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "graceful-termination"),
+		Version: versionOne,
+	}
+	// TestTerminationTimeIdentifier ensures a deleted pod under test shuts down within its grace period.
+	TestTerminationTimeIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "termination-time"),
+		Version: versionOne,
+	}
+	// TestPodDeleteRecoveryIdentifier ensures the deployments under test replace a deleted pod in time.
+	TestPodDeleteRecoveryIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "pod-delete-recovery"),
+		Version: versionOne,
+	}
+	// TestUnmanagedPodsIdentifier ensures the pods under test are managed by a controller rescheduling them.
+	TestUnmanagedPodsIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "unmanaged-pods"),
+		Version: versionOne,
+	}
+	// TestImagePolicyIdentifier ensures the containers under test pin their images and pull them accordingly.
+	TestImagePolicyIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "image-policy"),
+		Version: versionOne,
+	}
 	// TestPodRoleBindingsBestPracticesIdentifier represents rb best practices.
 	TestPodRoleBindingsBestPracticesIdentifier = claim.Identifier{
 		Url:     formTestURL(common.AccessControlTestKey, "pod-role-bindings"),
@@ -162,6 +376,66 @@ var (
 		Url:     formTestURL(common.AccessControlTestKey, "pod-service-account"),
 		Version: versionOne,
 	}
+	// TestNodeExposureIdentifier ensures the pods under test are not exposed on the node ports, unless allowed.
+	TestNodeExposureIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "node-exposure"),
+		Version: versionOne,
+	}
+	// TestHostNetworkIdentifier ensures the pods under test do not share the network namespace of their node.
+	TestHostNetworkIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "host-network"),
+		Version: versionOne,
+	}
+	// TestHostPIDIdentifier ensures the pods under test do not share the process namespace of their node.
+	TestHostPIDIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "host-pid"),
+		Version: versionOne,
+	}
+	// TestHostIPCIdentifier ensures the pods under test do not share the IPC namespace of their node.
+	TestHostIPCIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "host-ipc"),
+		Version: versionOne,
+	}
+	// TestClusterAdminBindingIdentifier ensures the service accounts of the pods under test are not bound to the
+	// cluster-admin ClusterRole.
+	TestClusterAdminBindingIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "cluster-admin-binding"),
+		Version: versionOne,
+	}
+	// TestRBACWildcardVerbsIdentifier ensures the roles granted to the service accounts of the pods under test do not
+	// allow every verb.
+	TestRBACWildcardVerbsIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "rbac-wildcard-verbs"),
+		Version: versionOne,
+	}
+	// TestRBACCrossNamespaceIdentifier ensures the service accounts of the pods under test are not granted roles in
+	// other namespaces.
+	TestRBACCrossNamespaceIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "rbac-cross-namespace-grants"),
+		Version: versionOne,
+	}
+	// TestAutomountServiceAccountTokenIdentifier ensures the service account token is only mounted in the pods under
+	// test declaring a Kubernetes API access.
+	TestAutomountServiceAccountTokenIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "automount-service-account-token"),
+		Version: versionOne,
+	}
+	// TestPrivilegedContainersIdentifier ensures the containers under test do not run privileged, unless allowed.
+	TestPrivilegedContainersIdentifier = claim.Identifier{
+		Url:     formTestURL(common.SecurityContextTestKey, "privileged-containers"),
+		Version: versionOne,
+	}
+	// TestAddedCapabilitiesIdentifier ensures the containers under test do not add restricted capabilities, unless
+	// allowed.
+	TestAddedCapabilitiesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.SecurityContextTestKey, "added-capabilities"),
+		Version: versionOne,
+	}
+	// TestRunAsNonRootIdentifier ensures the containers under test cannot run as root, unless allowed.
+	TestRunAsNonRootIdentifier = claim.Identifier{
+		Url:     formTestURL(common.SecurityContextTestKey, "run-as-non-root"),
+		Version: versionOne,
+	}
 	// TestServicesDoNotUseNodeportsIdentifier ensures Services don't utilize NodePorts.
 	TestServicesDoNotUseNodeportsIdentifier = claim.Identifier{
 		Url:     formTestURL(common.NetworkingTestKey, "service-type"),
@@ -172,6 +446,32 @@ var (
 		Url:     formTestURL(common.PlatformAlterationTestKey, "base-image"),
 		Version: versionOne,
 	}
+	// TestWritableLayerGrowthIdentifier ensures the writable layer of the containers does not grow during the run.
+	TestWritableLayerGrowthIdentifier = claim.Identifier{
+		Url:     formTestURL(common.PlatformAlterationTestKey, "writable-layer-growth"),
+		Version: versionOne,
+	}
+	// TestPidsLimitIdentifier ensures the processes of the containers under test stay below their pids limit.
+	TestPidsLimitIdentifier = claim.Identifier{
+		Url:     formTestURL(common.PlatformAlterationTestKey, "pids-limit"),
+		Version: versionOne,
+	}
+	// TestDeprecatedAPIsIdentifier ensures the CRDs and the operators under test do not use the APIs removed in the
+	// upcoming Kubernetes releases.
+	TestDeprecatedAPIsIdentifier = claim.Identifier{
+		Url:     formTestURL(common.PlatformAlterationTestKey, "deprecated-apis"),
+		Version: versionOne,
+	}
+	// TestSELinuxIdentifier ensures the containers under test run confined by SELinux, on enforcing nodes.
+	TestSELinuxIdentifier = claim.Identifier{
+		Url:     formTestURL(common.PlatformAlterationTestKey, "selinux"),
+		Version: versionOne,
+	}
+	// TestImageProvenanceIdentifier reports the images under test which do not trace back to their sources.
+	TestImageProvenanceIdentifier = claim.Identifier{
+		Url:     formTestURL(common.PlatformAlterationTestKey, "image-provenance"),
+		Version: versionOne,
+	}
 	// TestUnalteredStartupBootParamsIdentifier ensures startup boot params are not altered.
 	TestUnalteredStartupBootParamsIdentifier = claim.Identifier{
 		Url:     formTestURL(common.PlatformAlterationTestKey, "boot-params"),
@@ -187,6 +487,17 @@ var (
 		Url:     formTestURL(common.ObservabilityTestKey, "crd-status"),
 		Version: versionOne,
 	}
+	// TestCrdSubresourcesIdentifier ensures the CRDs define the status subresource, and the scale subresource when
+	// scalable.
+	TestCrdSubresourcesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.ObservabilityTestKey, "crd-subresources"),
+		Version: versionOne,
+	}
+	// TestCrStatusIdentifier ensures the custom resources of the CRDs report their status.
+	TestCrStatusIdentifier = claim.Identifier{
+		Url:     formTestURL(common.ObservabilityTestKey, "cr-status"),
+		Version: versionOne,
+	}
 	// TestShudtownIdentifier ensures pre-stop lifecycle is defined
 	TestShudtownIdentifier = claim.Identifier{
 		Url:     formTestURL(common.LifecycleTestKey, "container-shutdown"),
@@ -217,12 +528,154 @@ var (
 		Url:     formTestURL(common.DiagnosticTestKey, "clusterversion"),
 		Version: versionOne,
 	}
+	// TestClusterInfoIdentifier retrieves the versions, the platform and the network type of the cluster.
+	TestClusterInfoIdentifier = claim.Identifier{
+		Url:     formTestURL(common.DiagnosticTestKey, "cluster-info"),
+		Version: versionOne,
+	}
+	// TestTimezoneIdentifier ensures the nodes use UTC and the containers under test do not override their timezone.
+	TestTimezoneIdentifier = claim.Identifier{
+		Url:     formTestURL(common.PlatformAlterationTestKey, "timezone"),
+		Version: versionOne,
+	}
+	// TestPlatformRequirementsIdentifier ensures the nodes provide the platform features required by the CNF.
+	TestPlatformRequirementsIdentifier = claim.Identifier{
+		Url:     formTestURL(common.PlatformAlterationTestKey, "platform-requirements"),
+		Version: versionOne,
+	}
 )
 
 func formDescription(identifier claim.Identifier, description string) string {
 	return fmt.Sprintf("%s %s", identifier.Url, description)
 }
 
+// GetClassification returns the classification of a test case, Safe when it is not set in the catalog.
+func GetClassification(identifier claim.Identifier) testcases.TestClassification {
+	if classification := Catalog[identifier].Classification; classification != "" {
+		return classification
+	}
+	return testcases.Safe
+}
+
+// GetSupportedVersions returns the minimum and maximum cluster versions a test case supports, empty when unbounded.
+func GetSupportedVersions(identifier claim.Identifier) (minVersion, maxVersion string) {
+	return Catalog[identifier].MinVersion, Catalog[identifier].MaxVersion
+}
+
+// RequiresOpenShift returns true when a test case only runs on the OpenShift clusters.
+func RequiresOpenShift(identifier claim.Identifier) bool {
+	return Catalog[identifier].RequiresOpenShift
+}
+
+// FormatSupportedVersions returns the cluster versions a test case supports, e.g. ">= 4.8" or ">= 1.21, <= 1.24",
+// empty when unbounded.
+func FormatSupportedVersions(identifier claim.Identifier) string {
+	minVersion, maxVersion := GetSupportedVersions(identifier)
+	var bounds []string
+	if minVersion != "" {
+		bounds = append(bounds, ">= "+minVersion)
+	}
+	if maxVersion != "" {
+		bounds = append(bounds, "<= "+maxVersion)
+	}
+	return strings.Join(bounds, ", ")
+}
+
+// GetRemediationTheme returns the remediation theme of a test case, the theme of its suite when it is not set in the
+// catalog.
+func GetRemediationTheme(identifier *claim.Identifier) remediation.Theme {
+	if identifier == nil {
+		return remediation.Other
+	}
+	if theme := Catalog[*identifier].RemediationTheme; theme != "" {
+		return theme
+	}
+	if theme, ok := suiteRemediationThemes[path.Base(path.Dir(identifier.Url))]; ok {
+		return theme
+	}
+	return remediation.Other
+}
+
+// GetSuite returns the suite of a test case, e.g. "networking".
+func GetSuite(identifier claim.Identifier) string {
+	return path.Base(path.Dir(identifier.Url))
+}
+
+// GetResourceTypes returns the types of the resources a test case checks, the types of its suite when they are not
+// set in the catalog.
+func GetResourceTypes(identifier claim.Identifier) []ResourceType {
+	if types := Catalog[identifier].ResourceTypes; len(types) > 0 {
+		return types
+	}
+	return suiteResourceTypes[GetSuite(identifier)]
+}
+
+// FormatResourceTypes returns the resource types of a test case separated by commas, e.g. "pod, container".
+func FormatResourceTypes(identifier claim.Identifier) string {
+	types := GetResourceTypes(identifier)
+	names := make([]string, 0, len(types))
+	for _, resourceType := range types {
+		names = append(names, string(resourceType))
+	}
+	return strings.Join(names, ", ")
+}
+
+// GetTags returns the tags of a test case: the name of its suite, the tags of its suite, its classification unless
+// safe, "openshift" when it requires OpenShift and the tags set in the catalog.
+func GetTags(identifier claim.Identifier) []testcases.Tag {
+	suite := GetSuite(identifier)
+	tags := append([]testcases.Tag{testcases.Tag(suite)}, suiteTags[suite]...)
+	if classification := GetClassification(identifier); classification != testcases.Safe {
+		tags = append(tags, testcases.Tag(classification))
+	}
+	if RequiresOpenShift(identifier) {
+		tags = append(tags, testcases.TagOpenShift)
+	}
+	for _, tag := range Catalog[identifier].Tags {
+		if !hasTag(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// hasTag returns true when tags holds tag.
+func hasTag(tags []testcases.Tag, tag testcases.Tag) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTaggedTestCases returns the test cases of the catalog with their tags, sorted by identifier.
+func GetTaggedTestCases() []testcases.TaggedTestCase {
+	taggedTestCases := make([]testcases.TaggedTestCase, 0, len(Catalog))
+	for identifier := range Catalog {
+		taggedTestCases = append(taggedTestCases, testcases.TaggedTestCase{Suite: GetSuite(identifier),
+			ID: ginkgoItID(identifier), Tags: GetTags(identifier)})
+	}
+	sort.Slice(taggedTestCases, func(i, j int) bool { return taggedTestCases[i].ID < taggedTestCases[j].ID })
+	return taggedTestCases
+}
+
+// GetKnownTags returns the tags of the test cases of the catalog.
+func GetKnownTags() map[testcases.Tag]bool {
+	known := map[testcases.Tag]bool{}
+	for identifier := range Catalog {
+		for _, tag := range GetTags(identifier) {
+			known[tag] = true
+		}
+	}
+	return known
+}
+
+// ginkgoItID returns the Ginkgo identifier of a test case, e.g. "networking-icmpv4-connectivity".
+func ginkgoItID(identifier claim.Identifier) string {
+	return strings.ReplaceAll(strings.TrimPrefix(identifier.Url, url+"/"), "/", "-")
+}
+
 // XformToGinkgoItIdentifier transform the claim.Identifier into a test Id that can be used to skip
 // specific tests
 func XformToGinkgoItIdentifier(identifier claim.Identifier) string {
@@ -232,7 +685,7 @@ func XformToGinkgoItIdentifier(identifier claim.Identifier) string {
 // XformToGinkgoItIdentifierExtended transform the claim.Identifier into a test Id that can be used to skip
 // specific tests
 func XformToGinkgoItIdentifierExtended(identifier claim.Identifier, extra string) string {
-	itID := strings.ReplaceAll(strings.TrimPrefix(identifier.Url, url+"/"), "/", "-")
+	itID := ginkgoItID(identifier)
 	var key string
 	if extra != "" {
 		key = itID + "-" + extra
@@ -247,8 +700,9 @@ func XformToGinkgoItIdentifierExtended(identifier claim.Identifier, extra string
 var Catalog = map[claim.Identifier]TestCaseDescription{
 
 	TestHostResourceIdentifier: {
-		Identifier: TestHostResourceIdentifier,
-		Type:       normativeResult,
+		Identifier:    TestHostResourceIdentifier,
+		ResourceTypes: []ResourceType{ResourcePod, ResourceContainer},
+		Type:          normativeResult,
 		Remediation: `Ensure that each Pod in the CNF abides by the suggested best practices listed in the test description.  In some rare
 cases, not all best practices can be followed.  For example, some CNFs may be required to run as root.  Such exceptions
 should be handled on a case-by-case basis, and should provide a proper justification as to why the best practice(s)
@@ -278,17 +732,35 @@ cannot be followed.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.3.7",
 	},
 
+	TestContainerImageCertifiedIdentifier: {
+		Identifier:       TestContainerImageCertifiedIdentifier,
+		RemediationTheme: remediation.Images,
+		Type:             normativeResult,
+		Remediation: `Certify the CNF images with the Red Hat Container Certification Program (CCP), and reference them
+by digest.`,
+		Description: formDescription(TestContainerImageCertifiedIdentifier,
+			`looks up the image of each CNF container, by registry, repository and digest, in the Red Hat container
+catalog, or in a dump of the catalog set in the imageCertification section of the TNF configuration.  It fails the
+containers whose image is not certified, or cannot be looked up.  The certification status of the images is recorded
+in the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.3.7",
+	},
+
 	TestExtractNodeInformationIdentifier: {
-		Identifier: TestExtractNodeInformationIdentifier,
-		Type:       informativeResult,
+		Identifier:    TestExtractNodeInformationIdentifier,
+		ResourceTypes: []ResourceType{ResourceNode},
+		Type:          informativeResult,
 		Description: formDescription(TestExtractNodeInformationIdentifier,
 			`extracts informational information about the cluster.`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.3.6",
 	},
 
 	TestHugepagesNotManuallyManipulated: {
-		Identifier: TestHugepagesNotManuallyManipulated,
-		Type:       normativeResult,
+		Identifier:        TestHugepagesNotManuallyManipulated,
+		Tags:              []testcases.Tag{testcases.TagTelco},
+		ResourceTypes:     []ResourceType{ResourceNode},
+		RequiresOpenShift: true,
+		Type:              normativeResult,
 		Remediation: `HugePage settings should be configured either directly through the MachineConfigOperator or indirectly using the
 PerformanceAddonOperator.  This ensures that OpenShift is aware of the special MachineConfig requirements, and can
 provision your CNF on a Node that is part of the corresponding MachineConfigSet.  Avoid making changes directly to an
@@ -314,14 +786,126 @@ how to exclude a particular container from ICMPv4 connectivity tests, consult:
 			`checks that each CNF Container is able to communicate via ICMPv4 on the Default OpenShift network.  This
 test case requires the Deployment of the
 [CNF Certification Test Partner](https://github.com/test-network-function/cnf-certification-test-partner/blob/main/test-partner/partner.yaml).
-The test ensures that all CNF containers respond to ICMPv4 requests from the Partner Pod, and vice-versa.
+The test ensures that all CNF containers respond to ICMPv4 requests from the Partner Pod, and vice-versa.  On each
+Multus secondary network shared by several CNF pods, the first pod attached pings the other ones.
+`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestICMPv6ConnectivityIdentifier: {
+		Identifier: TestICMPv6ConnectivityIdentifier,
+		Tags:       []testcases.Tag{testcases.TagTelco},
+		Type:       normativeResult,
+		Remediation: `Ensure that the CNF is able to communicate via the Default OpenShift network over IPv6.  In other cases,
+if the Container base image does not provide the "ip" or "ping" binaries, this test may not be applicable.  For
+instructions on how to exclude a particular container from ICMPv6 connectivity tests, consult:
+[README.md](https://github.com/test-network-function/test-network-function#issue-161-some-containers-under-test-do-not-contain-ping-or-ip-binary-utilities).`,
+		Description: formDescription(TestICMPv6ConnectivityIdentifier,
+			`checks that each CNF Container with an IPv6 address is able to communicate via ICMPv6 on the Default
+OpenShift network, and on the Multus networks.  This test case requires the Deployment of the
+[CNF Certification Test Partner](https://github.com/test-network-function/cnf-certification-test-partner/blob/main/test-partner/partner.yaml).
+The test ensures that all CNF containers respond to ICMPv6 requests from the Partner Pod, and vice-versa, and that
+the CNF pods attached to the same Multus secondary network reach each other over it.  Dual-stack
+CNFs are tested with both this test and the ICMPv4 one, the test is skipped for IPv4-only CNFs.
 `),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
 
-	TestNamespaceBestPracticesIdentifier: {
-		Identifier: TestNamespaceBestPracticesIdentifier,
+	TestSCTPConnectivityIdentifier: {
+		Identifier: TestSCTPConnectivityIdentifier,
+		Tags:       []testcases.Tag{testcases.TagTelco},
+		Type:       normativeResult,
+		Remediation: `Ensure that the sctp kernel module is loaded on the nodes hosting the CNF, and that the network
+policies of the CNF namespace allow the SCTP traffic.  The containers under test need the "ncat" binary; containers
+lacking it can be excluded from the connectivity tests, see:
+[README.md](https://github.com/test-network-function/test-network-function#issue-161-some-containers-under-test-do-not-contain-ping-or-ip-binary-utilities).`,
+		Description: formDescription(TestSCTPConnectivityIdentifier,
+			`checks that each CNF Container is able to establish an SCTP association with the Partner Pod on the
+Default OpenShift network, and the Partner Pod with each CNF Container, over each address family of the CNF.  The test
+only runs for the CNFs declaring they require SCTP in the platformRequirements section of the configuration, and
+requires "ncat" in the Partner Pod and in the CNF Containers.  The Partner Pod runs an ncat server echoing the probe
+sent by each CNF Container, then each CNF Container runs an ncat server echoing the probe sent by the Partner Pod.
+`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestThroughputIdentifier: {
+		Identifier: TestThroughputIdentifier,
+		Tags:       []testcases.Tag{testcases.TagLoad},
+		Type:       informativeResult,
+		Remediation: `Check the network policies and the bandwidth limits of the CNF namespace allow the traffic.  The
+Partner Pod and the containers under test need the "iperf3" binary; containers lacking it can be excluded from the
+connectivity tests, see:
+[README.md](https://github.com/test-network-function/test-network-function#issue-161-some-containers-under-test-do-not-contain-ping-or-ip-binary-utilities).`,
+		Description: formDescription(TestThroughputIdentifier,
+			`measures the throughput from each CNF Container to the Partner Pod on the Default OpenShift network,
+over each address family of the CNF: an iperf3 client in the CNF Container sends TCP then UDP traffic to an iperf3
+server in the Partner Pod.  The bitrate, and the jitter and datagram loss of UDP, are recorded under the throughput key
+of the claim rawResults.  The test generates load, and is skipped unless enabled with -allow-load.
+`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestDNSResolutionIdentifier: {
+		Identifier: TestDNSResolutionIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the pods use the ClusterFirst DNS policy, or a dnsConfig searching the services of
+their namespace first, and that the network policies of the CNF namespace allow the DNS traffic to CoreDNS.  Check the
+FQDNs listed in the dns section of the configuration exist.  The containers under test need the "getent" binary;
+containers lacking it can be excluded from the connectivity tests, see:
+[README.md](https://github.com/test-network-function/test-network-function#issue-161-some-containers-under-test-do-not-contain-ping-or-ip-binary-utilities).`,
+		Description: formDescription(TestDNSResolutionIdentifier,
+			`checks the DNS resolution from inside each CNF Pod: its /etc/resolv.conf must have a nameserver and
+search the services of the pod namespace first, and the pod must resolve the cluster DNS service, i.e. reach CoreDNS,
+and the FQDNs listed in the dns section of the configuration.  The resolution is tested with "getent hosts" from the
+first CNF Container of each Pod.
+`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestServiceExposureIdentifier: {
+		Identifier:    TestServiceExposureIdentifier,
+		ResourceTypes: []ResourceType{ResourceService},
+		Type:          normativeResult,
+		Remediation: `Declare the ports the containers listen on in their ports section, and ensure that the targetPort of
+each Service selecting the CNF Pods refers to one of them, by number or by name, with the same protocol.`,
+		Description: formDescription(TestServiceExposureIdentifier,
+			`ensures that each CNF Pod is only exposed through the ports it declares: every port of the Services
+selecting the Pod must target a port declared by one of its containers.
+`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestNetworkPolicyPortsIdentifier: {
+		Identifier:    TestNetworkPolicyPortsIdentifier,
+		ResourceTypes: []ResourceType{ResourceService},
+		Type:          normativeResult,
+		Remediation: `Ensure that the NetworkPolicies selecting the CNF Pods have an ingress rule allowing each port
+exposed by their Services, or remove the Service ports which are not meant to be reached.`,
+		Description: formDescription(TestNetworkPolicyPortsIdentifier,
+			`ensures that the NetworkPolicies of the CNF namespace do not silently block the declared connectivity:
+each port of a CNF Pod exposed by a Service must be allowed by an ingress rule of the NetworkPolicies restricting the
+ingress traffic of the Pod, if any.  Only the ports of the rules are checked, not the peers they allow.
+`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestEgressDestinationsIdentifier: {
+		Identifier: TestEgressDestinationsIdentifier,
 		Type:       normativeResult,
+		Remediation: `Declare the external destinations the CNF is expected to reach in the egress allowList of the
+configuration, or stop the CNF Pods from reaching the unexpected destinations.`,
+		Description: formDescription(TestEgressDestinationsIdentifier,
+			`inventories the external destinations the CNF Pods connect to during an observation window, with
+conntrack from the debug pods of their nodes, and ensures each of them is in the egress allowList of the configuration.
+The destinations in the cluster and service networks, and in the configured internal networks, are not external.  The
+inventory is recorded in the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestNamespaceBestPracticesIdentifier: {
+		Identifier:    TestNamespaceBestPracticesIdentifier,
+		ResourceTypes: []ResourceType{ResourceNamespace},
+		Type:          normativeResult,
 		Remediation: `Ensure that your CNF utilizes a CNF-specific namespace.  Additionally, the CNF-specific namespace
 should not start with "openshift-", except in rare cases.`,
 		Description: formDescription(TestNamespaceBestPracticesIdentifier,
@@ -346,8 +930,10 @@ acceptable for a CNF.`),
 	},
 
 	TestNonTaintedNodeKernelsIdentifier: {
-		Identifier: TestNonTaintedNodeKernelsIdentifier,
-		Type:       normativeResult,
+		Identifier:    TestNonTaintedNodeKernelsIdentifier,
+		Tags:          []testcases.Tag{testcases.TagSecurity},
+		ResourceTypes: []ResourceType{ResourceNode},
+		Type:          normativeResult,
 		Remediation: `Test failure indicates that the underlying Node's' kernel is tainted.  Ensure that you have not altered underlying
 Node(s) kernels in order to run the CNF.`,
 		Description: formDescription(TestNonTaintedNodeKernelsIdentifier,
@@ -358,9 +944,10 @@ the same hacks.'`),
 	},
 
 	TestOperatorInstallStatusIdentifier: {
-		Identifier:  TestOperatorInstallStatusIdentifier,
-		Type:        normativeResult,
-		Remediation: `Ensure that your Operator abides by the Operator Best Practices mentioned in the description.`,
+		Identifier:        TestOperatorInstallStatusIdentifier,
+		RequiresOpenShift: true,
+		Type:              normativeResult,
+		Remediation:       `Ensure that your Operator abides by the Operator Best Practices mentioned in the description.`,
 		Description: formDescription(TestOperatorInstallStatusIdentifier,
 			`Ensures that CNF Operators abide by best practices.  The following is tested:
 1. The Operator CSV reports "Installed" status.
@@ -370,23 +957,100 @@ with no resourceNames under its rules.`),
 	},
 
 	TestOperatorIsCertifiedIdentifier: {
-		Identifier:  TestOperatorIsCertifiedIdentifier,
-		Type:        normativeResult,
-		Remediation: `Ensure that your Operator has passed Red Hat's Operator Certification Program (OCP).`,
+		Identifier:        TestOperatorIsCertifiedIdentifier,
+		ResourceTypes:     []ResourceType{ResourceOperator},
+		RequiresOpenShift: true,
+		RemediationTheme:  remediation.Operators,
+		Type:              normativeResult,
+		Remediation:       `Ensure that your Operator has passed Red Hat's Operator Certification Program (OCP).`,
 		Description: formDescription(TestOperatorIsCertifiedIdentifier,
 			`tests whether CNF Operators have passed the Red Hat Operator Certification Program (OCP).`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2.12 and Section 6.3.3",
 	},
 
+	TestOperatorBundleCertifiedIdentifier: {
+		Identifier:        TestOperatorBundleCertifiedIdentifier,
+		ResourceTypes:     []ResourceType{ResourceOperator},
+		RequiresOpenShift: true,
+		RemediationTheme:  remediation.Operators,
+		Type:              normativeResult,
+		Remediation: `Install a version of the Operator which has passed the Red Hat Operator Certification Program (OCP),
+from the certified-operators catalog.`,
+		Description: formDescription(TestOperatorBundleCertifiedIdentifier,
+			`tests that the package and version of the CSV of each CNF Operator are published in at least one channel of
+the certified operators index of the Red Hat catalog.  The bundles read from the catalog are cached for 24 hours by
+default, see the operatorCertification section of the TNF configuration.  The certified channels are recorded in the
+claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2.12 and Section 6.3.3",
+	},
+
+	TestHelmChartProvenanceIdentifier: {
+		Identifier:    TestHelmChartProvenanceIdentifier,
+		ResourceTypes: []ResourceType{ResourceHelmRelease},
+		Type:          normativeResult,
+		Remediation: `Declare the sources or the home of the CNF Helm charts, and the appVersion of the application they
+deploy, in their Chart.yaml.`,
+		Description: formDescription(TestHelmChartProvenanceIdentifier,
+			`tests that the chart of the latest revision of each Helm release in the target namespace, discovered from
+the secrets and configmaps Helm stores its releases in, declares its sources or its home, and its appVersion, so that
+the deployed CNF can be traced back to its sources.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestHelmValuesOverridesIdentifier: {
+		Identifier:       TestHelmValuesOverridesIdentifier,
+		ResourceTypes:    []ResourceType{ResourceHelmRelease},
+		RemediationTheme: remediation.Images,
+		Type:             normativeResult,
+		Remediation: `Release a new version of the CNF Helm chart referencing the images to deploy, rather than overriding
+them with --set or --values, or allow the overrides in the helm section of the TNF configuration.`,
+		Description: formDescription(TestHelmValuesOverridesIdentifier,
+			`tests that the latest revision of each Helm release in the target namespace does not override the values of
+its chart referencing an image, i.e. whose key ends with image, images, registry, repository, tag or digest, as the
+deployed CNF then differs from the released chart.  The allowedImageOverrides of the helm section of the TNF
+configuration lists the keys which may be overridden.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
 	TestOperatorIsInstalledViaOLMIdentifier: {
-		Identifier:  TestOperatorIsInstalledViaOLMIdentifier,
-		Type:        normativeResult,
-		Remediation: `Ensure that your Operator is installed via OLM.`,
+		Identifier:        TestOperatorIsInstalledViaOLMIdentifier,
+		RequiresOpenShift: true,
+		Type:              normativeResult,
+		Remediation:       `Ensure that your Operator is installed via OLM.`,
 		Description: formDescription(TestOperatorIsInstalledViaOLMIdentifier,
 			`tests whether a CNF Operator is installed via OLM.`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2.12 and Section 6.3.3",
 	},
 
+	TestOperatorSubscriptionHealthIdentifier: {
+		Identifier:        TestOperatorSubscriptionHealthIdentifier,
+		RequiresOpenShift: true,
+		RemediationTheme:  remediation.Operators,
+		Type:              normativeResult,
+		Remediation: `Approve the pending install plans of the Operator subscription, fix the failed ones, and subscribe to the
+expected channel.`,
+		Description: formDescription(TestOperatorSubscriptionHealthIdentifier,
+			`tests that the OLM Subscription of each CNF Operator, named by its subscription_name annotation, is in the
+AtLatestKnown state, that the InstallPlan it references is approved and Complete, and that it follows the channel
+expected for the Operator in the subscription section of the TNF configuration, if any.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2.12 and Section 6.3.3",
+	},
+
+	TestOperatorInstallModeIdentifier: {
+		Identifier:        TestOperatorInstallModeIdentifier,
+		RequiresOpenShift: true,
+		RemediationTheme:  remediation.Operators,
+		Type:              normativeResult,
+		Remediation: `Deploy the Operator with a single OperatorGroup in its namespace targeting namespaces it supports, and
+declare the install modes it supports in the installModes of its CSV.`,
+		Description: formDescription(TestOperatorInstallModeIdentifier,
+			`tests that each CNF Operator supports, according to the installModes of its CSV, the install mode of the
+single OperatorGroup of its namespace, e.g. AllNamespaces when it has no targetNamespaces, and the install mode declared
+in the operatorInstallMode section of the TNF configuration, if any.  The OperatorGroup of each Operator is recorded in
+the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2.12 and Section 6.3.3",
+	},
+
 	TestPodNodeSelectorAndAffinityBestPractices: {
 		Identifier: TestPodNodeSelectorAndAffinityBestPractices,
 		Type:       informativeResult,
@@ -401,9 +1065,10 @@ instantiation on any underlying Node.`),
 	},
 
 	TestPodHighAvailabilityBestPractices: {
-		Identifier:  TestPodHighAvailabilityBestPractices,
-		Type:        informativeResult,
-		Remediation: `In high availability cases, Pod podAntiAffinity rule should be specified for pod scheduling and pod replica value is set to more than 1 .`,
+		Identifier:    TestPodHighAvailabilityBestPractices,
+		ResourceTypes: []ResourceType{ResourceDeployment, ResourceStatefulSet},
+		Type:          informativeResult,
+		Remediation:   `In high availability cases, Pod podAntiAffinity rule should be specified for pod scheduling and pod replica value is set to more than 1 .`,
 		Description: formDescription(TestPodHighAvailabilityBestPractices,
 			`ensures that CNF Pods specify podAntiAffinity rules and replica value is set to more than 1.`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
@@ -446,18 +1111,226 @@ ClusterRoleBindings, if possible.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2.3 and 6.2.7",
 	},
 
+	TestNodeExposureIdentifier: {
+		Identifier:    TestNodeExposureIdentifier,
+		ResourceTypes: []ResourceType{ResourcePod, ResourceService},
+		Type:          normativeResult,
+		Remediation: `Expose the CNF Pods through ClusterIP Services, Ingresses or Routes instead of hostPorts and NodePort
+Services, or add the accepted exposures to the nodeExposure section of the TNF configuration.`,
+		Description: formDescription(TestNodeExposureIdentifier,
+			`tests that the containers of each CNF Pod declare no hostPort, and that no Service selecting a CNF Pod
+allocates node ports, e.g. a NodePort or LoadBalancer Service, except for the hostPorts and Services allowed in the
+nodeExposure section of the TNF configuration.  Binding node ports bypasses the NetworkPolicies and limits the
+scheduling of the Pods.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.3.1",
+	},
+
+	TestHostNetworkIdentifier: {
+		Identifier: TestHostNetworkIdentifier,
+		Type:       normativeResult,
+		Remediation: `Remove hostNetwork from the spec of the CNF Pods, or exempt the Pods which require it with the
+test-network-function.com/host_namespace_exemptions annotation, e.g. ["hostNetwork"].`,
+		Description: formDescription(TestHostNetworkIdentifier,
+			`tests that no CNF Pod sets hostNetwork, i.e. shares the network namespace of its node, unless exempted by
+the test-network-function.com/host_namespace_exemptions annotation.  A Pod on the host network sees all the traffic
+of the node and bypasses the NetworkPolicies.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestHostPIDIdentifier: {
+		Identifier: TestHostPIDIdentifier,
+		Type:       normativeResult,
+		Remediation: `Remove hostPID from the spec of the CNF Pods, or exempt the Pods which require it with the
+test-network-function.com/host_namespace_exemptions annotation, e.g. ["hostPID"].`,
+		Description: formDescription(TestHostPIDIdentifier,
+			`tests that no CNF Pod sets hostPID, i.e. shares the process namespace of its node, unless exempted by the
+test-network-function.com/host_namespace_exemptions annotation.  A Pod in the host process namespace can inspect and
+signal all the processes of the node.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestHostIPCIdentifier: {
+		Identifier: TestHostIPCIdentifier,
+		Type:       normativeResult,
+		Remediation: `Remove hostIPC from the spec of the CNF Pods, or exempt the Pods which require it with the
+test-network-function.com/host_namespace_exemptions annotation, e.g. ["hostIPC"].`,
+		Description: formDescription(TestHostIPCIdentifier,
+			`tests that no CNF Pod sets hostIPC, i.e. shares the IPC namespace of its node, unless exempted by the
+test-network-function.com/host_namespace_exemptions annotation.  A Pod in the host IPC namespace can access the shared
+memory of all the processes of the node.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestClusterAdminBindingIdentifier: {
+		Identifier: TestClusterAdminBindingIdentifier,
+		Type:       normativeResult,
+		Remediation: `Bind the ServiceAccounts of the CNF Pods to a Role or ClusterRole granting only the permissions they
+need instead of cluster-admin.`,
+		Description: formDescription(TestClusterAdminBindingIdentifier,
+			`tests that no RoleBinding nor ClusterRoleBinding grants the cluster-admin ClusterRole to the ServiceAccount of
+a CNF Pod.  The bindings and roles are discovered for the ServiceAccounts of the Pods under test.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestRBACWildcardVerbsIdentifier: {
+		Identifier: TestRBACWildcardVerbsIdentifier,
+		Type:       normativeResult,
+		Remediation: `List the verbs the CNF needs, e.g. get, list and watch, in the rules of the Roles and ClusterRoles
+granted to the ServiceAccounts of the CNF Pods instead of "*".`,
+		Description: formDescription(TestRBACWildcardVerbsIdentifier,
+			`tests that no Role nor ClusterRole granted to the ServiceAccount of a CNF Pod has a rule allowing every verb,
+i.e. "*".`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestRBACCrossNamespaceIdentifier: {
+		Identifier: TestRBACCrossNamespaceIdentifier,
+		Type:       normativeResult,
+		Remediation: `Remove the RoleBindings granting roles to the ServiceAccounts of the CNF Pods in other namespaces
+than their own.`,
+		Description: formDescription(TestRBACCrossNamespaceIdentifier,
+			`tests that no RoleBinding of another namespace than the one of a CNF Pod grants a Role or ClusterRole to its
+ServiceAccount, and reports the granted role.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.3.3 and 6.3.5",
+	},
+
+	TestAutomountServiceAccountTokenIdentifier: {
+		Identifier: TestAutomountServiceAccountTokenIdentifier,
+		Type:       normativeResult,
+		Remediation: `Set automountServiceAccountToken to false in the spec of the CNF Pods or in their ServiceAccount, or
+declare the Pods which access the Kubernetes API with the test-network-function.com/api_access annotation, e.g. true.`,
+		Description: formDescription(TestAutomountServiceAccountTokenIdentifier,
+			`tests that the service account token is not mounted in the CNF Pods, either explicitly or by default,
+unless they declare a Kubernetes API access with the test-network-function.com/api_access annotation.  A mounted token
+lets whoever compromises the Pod act on the cluster with the permissions of its ServiceAccount.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestPrivilegedContainersIdentifier: {
+		Identifier: TestPrivilegedContainersIdentifier,
+		Type:       normativeResult,
+		Remediation: `Remove privileged from the securityContext of the CNF containers, adding the capabilities they need
+instead, or add the containers which require it to allowedPrivilegedContainers in the securityContext section of the TNF
+configuration.`,
+		Description: formDescription(TestPrivilegedContainersIdentifier,
+			`tests that no CNF container sets privileged in its securityContext, except for the containers allowed in
+the securityContext section of the TNF configuration.  A privileged container has all the capabilities and the devices
+of its node.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestAddedCapabilitiesIdentifier: {
+		Identifier: TestAddedCapabilitiesIdentifier,
+		Type:       normativeResult,
+		Remediation: `Remove the restricted capabilities from the securityContext of the CNF containers, or add the
+containers which require them to allowedCapabilities in the securityContext section of the TNF configuration, per
+capability.`,
+		Description: formDescription(TestAddedCapabilitiesIdentifier,
+			`tests that no CNF container adds ALL, BPF, IPC_LOCK, NET_ADMIN, NET_RAW, SYS_ADMIN, SYS_MODULE or
+SYS_PTRACE to its capabilities, except for the containers allowed for the capability in the securityContext section of
+the TNF configuration.  These capabilities grant control over the node or the other workloads.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestRunAsNonRootIdentifier: {
+		Identifier: TestRunAsNonRootIdentifier,
+		Type:       normativeResult,
+		Remediation: `Set runAsNonRoot, or a non-zero runAsUser, in the securityContext of the CNF Pods or containers, or
+add the containers which require root to allowedRootContainers in the securityContext section of the TNF
+configuration.`,
+		Description: formDescription(TestRunAsNonRootIdentifier,
+			`tests that each CNF container sets runAsNonRoot or a non-zero runAsUser, in its securityContext or in the
+one of its Pod, except for the containers allowed in the securityContext section of the TNF configuration.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
 	TestServicesDoNotUseNodeportsIdentifier: {
-		Identifier:  TestServicesDoNotUseNodeportsIdentifier,
-		Type:        normativeResult,
-		Remediation: `Ensure Services are not configured to use NodePort(s).`,
+		Identifier:    TestServicesDoNotUseNodeportsIdentifier,
+		ResourceTypes: []ResourceType{ResourceService},
+		Type:          normativeResult,
+		Remediation:   `Ensure Services are not configured to use NodePort(s).`,
 		Description: formDescription(TestServicesDoNotUseNodeportsIdentifier,
 			`tests that each CNF Service does not utilize NodePort(s).`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.3.1",
 	},
 
-	TestUnalteredBaseImageIdentifier: {
-		Identifier: TestUnalteredBaseImageIdentifier,
+	TestWritableLayerGrowthIdentifier: {
+		Identifier: TestWritableLayerGrowthIdentifier,
 		Type:       normativeResult,
+		Remediation: `Ensure that the containers log to stdout and stderr rather than to files, and write their temporary
+and persistent data to volumes, e.g. emptyDir volumes, rather than to their writable layer.  The accepted growth can be
+set with the maxGrowthMiB field of the writableLayer section of the TNF configuration.`,
+		Description: formDescription(TestWritableLayerGrowthIdentifier,
+			`tests that the writable layer of each container under test, measured with crictl from the debug pod of
+its node at the start of the run and when the test runs, does not grow by more than the accepted growth, 100MiB by
+default.  The growth is measured up to this test, the tests running afterwards are not covered.  A growing writable layer reveals a container logging to its local disk or leaking temporary files, which
+eventually exhausts the disk of the node.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestPidsLimitIdentifier: {
+		Identifier: TestPidsLimitIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the CNF processes reap their children and do not fork without bound, e.g. by running an
+init process such as tini as the entrypoint of the containers which spawn processes.  The accepted share of the pids
+limit can be set with the maxUsagePercent field of the pidsLimit section of the TNF configuration.`,
+		Description: formDescription(TestPidsLimitIdentifier,
+			`counts the processes and the zombie processes of each CNF container, from a shell in the container, and
+tests that they use at most 80% by default of the pids limit of the container, i.e. the pids_limit of CRI-O, and of the
+podPidsLimit of the kubelet of its node.  The process counts are reported in the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestDeprecatedAPIsIdentifier: {
+		Identifier:       TestDeprecatedAPIsIdentifier,
+		ResourceTypes:    []ResourceType{ResourceCRD, ResourceOperator},
+		RemediationTheme: remediation.Operators,
+		Type:             normativeResult,
+		Remediation: `Migrate the CRDs, the operator RBAC permissions and the example custom resources to the replacement
+APIs before upgrading the cluster, e.g. apiextensions.k8s.io/v1 for the CustomResourceDefinitions.`,
+		Description: formDescription(TestDeprecatedAPIsIdentifier,
+			`tests that the CRDs and the operators under test do not use the Kubernetes APIs removed in the releases after
+the version of the cluster, e.g. the v1beta1 CustomResourceDefinitions or the PodSecurityPolicies, according to a table
+of the removals bundled with the tool.  The apiVersion the CRDs and the CSVs were last applied with, the resources the
+RBAC permissions of the CSVs grant, and the alm-examples of the CSVs are checked.  The uses of the removed APIs are
+recorded in the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestSELinuxIdentifier: {
+		Identifier: TestSELinuxIdentifier,
+		Tags:       []testcases.Tag{testcases.TagSecurity},
+		Type:       normativeResult,
+		Remediation: `Ensure that SELinux is enforcing on the worker nodes, and that the CNF containers do not run
+privileged nor set a custom seLinuxOptions type.  The containers which require another type can be added to
+allowedLabelTypes in the selinux section of the TNF configuration, per type.`,
+		Description: formDescription(TestSELinuxIdentifier,
+			`tests, from the debug pod of their node, that the main process of each CNF container runs with the
+container_t SELinux type, except for the containers allowed in the selinux section of the TNF configuration, and that
+the nodes hosting the CNF containers run SELinux in enforcing mode.  The modes and labels are reported per node in
+the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestImageProvenanceIdentifier: {
+		Identifier:       TestImageProvenanceIdentifier,
+		Tags:             []testcases.Tag{testcases.TagSecurity},
+		RemediationTheme: remediation.Images,
+		Type:             informativeResult,
+		Remediation: `Set the org.opencontainers.image.revision, org.opencontainers.image.source and
+org.opencontainers.image.version labels when building the CNF images, e.g. with the LABEL instruction of the
+Dockerfile or the --label option of buildah, to the commit, repository URL and version they are built from.`,
+		Description: formDescription(TestImageProvenanceIdentifier,
+			`reads, from the debug pod of their node, the labels of the images of the CNF containers, and reports the
+images which do not set the org.opencontainers.image.revision, org.opencontainers.image.source and
+org.opencontainers.image.version OCI labels tracing them back to their sources.  The labels are recorded per image in
+the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestUnalteredBaseImageIdentifier: {
+		Identifier:       TestUnalteredBaseImageIdentifier,
+		RemediationTheme: remediation.Images,
+		Type:             normativeResult,
 		Remediation: `Ensure that Container applications do not modify the Container Base Image.  In particular, ensure that the following
 directories are not modified:
 1) /var/lib/rpm
@@ -488,8 +1361,11 @@ that there are no changes to the following directories:
 	},
 
 	TestUnalteredStartupBootParamsIdentifier: {
-		Identifier: TestUnalteredStartupBootParamsIdentifier,
-		Type:       normativeResult,
+		Identifier:        TestUnalteredStartupBootParamsIdentifier,
+		Tags:              []testcases.Tag{testcases.TagTelco},
+		ResourceTypes:     []ResourceType{ResourceNode},
+		RequiresOpenShift: true,
+		Type:              normativeResult,
 		Remediation: `Ensure that boot parameters are set directly through the MachineConfigOperator, or indirectly through the PerformanceAddonOperator.  Boot parameters should not be changed directly through the Node, as OpenShift should manage
 the changes for you.`,
 		Description: formDescription(TestUnalteredStartupBootParamsIdentifier,
@@ -497,25 +1373,28 @@ the changes for you.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2.13 and 6.2.14",
 	},
 	TestListCniPluginsIdentifier: {
-		Identifier:  TestListCniPluginsIdentifier,
-		Type:        normativeResult,
-		Remediation: "",
+		Identifier:    TestListCniPluginsIdentifier,
+		ResourceTypes: []ResourceType{ResourceNode},
+		Type:          normativeResult,
+		Remediation:   "",
 		Description: formDescription(TestListCniPluginsIdentifier,
 			`lists CNI plugins`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2.4 and 6.3.7",
 	},
 	TestNodesHwInfoIdentifier: {
-		Identifier:  TestNodesHwInfoIdentifier,
-		Type:        normativeResult,
-		Remediation: "",
+		Identifier:    TestNodesHwInfoIdentifier,
+		ResourceTypes: []ResourceType{ResourceNode},
+		Type:          normativeResult,
+		Remediation:   "",
 		Description: formDescription(TestNodesHwInfoIdentifier,
 			`list nodes HW info`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
 
 	TestShudtownIdentifier: {
-		Identifier: TestShudtownIdentifier,
-		Type:       normativeResult,
+		Identifier:    TestShudtownIdentifier,
+		ResourceTypes: []ResourceType{ResourceContainer},
+		Type:          normativeResult,
 		Description: formDescription(TestShudtownIdentifier,
 			`Ensure that the containers lifecycle pre-stop management feature is configured.`),
 		Remediation: `
@@ -534,8 +1413,10 @@ the changes for you.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
 	TestPodRecreationIdentifier: {
-		Identifier: TestPodRecreationIdentifier,
-		Type:       normativeResult,
+		Identifier:     TestPodRecreationIdentifier,
+		ResourceTypes:  []ResourceType{ResourceDeployment, ResourceStatefulSet, ResourceNode},
+		Type:           normativeResult,
+		Classification: testcases.Destructive,
 		Description: formDescription(TestPodRecreationIdentifier,
 			`tests that a CNF is configured to support High Availability.  
 			First, this test cordons and drains a Node that hosts the CNF Pod.  
@@ -545,9 +1426,149 @@ the changes for you.`,
 			Additionally, ensure that there are available Nodes in the OpenShift cluster that can be utilized in the event that a host Node fails.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
-	TestSysctlConfigsIdentifier: {
-		Identifier: TestSysctlConfigsIdentifier,
+	TestStartupOrderingIdentifier: {
+		Identifier:     TestStartupOrderingIdentifier,
+		Type:           normativeResult,
+		Classification: testcases.Intrusive,
+		Remediation: `Ensure that each CNF Pod waits for and retries the services it depends on, e.g. with readiness
+probes and retries instead of init ordering, so that the CNF recovers from any restart order without manual steps.`,
+		Description: formDescription(TestStartupOrderingIdentifier,
+			`deletes all the CNF Pods at once, so that they restart in an arbitrary order, and tests that the CNF
+converges to healthy within the convergence timeout of the TNF configuration, 5 minutes by default: the deleted Pods
+are replaced, and all the Pods and Deployments of their namespaces are ready.  The convergence time is recorded in the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestContainerResourcesIdentifier: {
+		Identifier:    TestContainerResourcesIdentifier,
+		ResourceTypes: []ResourceType{ResourceContainer},
+		Type:          normativeResult,
+		Remediation: `Set the CPU and memory requests and limits of each container of the CNF Pods.  For the latency-sensitive
+Pods, set the requests equal to the limits for both CPU and memory in all their containers, so that they get the
+Guaranteed QoS class.`,
+		Description: formDescription(TestContainerResourcesIdentifier,
+			`tests that each container of the CNF Pods sets its CPU and memory requests and limits, so that the
+scheduler can place the Pods and the nodes are not overcommitted.  The Pods declared latency-sensitive with the
+test-network-function.com/latency_sensitive annotation, e.g. true, must have the Guaranteed QoS class.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestLivenessProbeIdentifier: {
+		Identifier:       TestLivenessProbeIdentifier,
+		ResourceTypes:    []ResourceType{ResourceContainer},
+		RemediationTheme: remediation.Probes,
+		Type:             normativeResult,
+		Remediation:      `Define a livenessProbe in each container of the CNF Deployments and StatefulSets.`,
+		Description: formDescription(TestLivenessProbeIdentifier,
+			`tests that each container of the CNF Deployments and StatefulSets defines a liveness probe, so that
+Kubernetes restarts the containers which stop working.  The probes are recorded in the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestReadinessProbeIdentifier: {
+		Identifier:       TestReadinessProbeIdentifier,
+		ResourceTypes:    []ResourceType{ResourceContainer},
+		RemediationTheme: remediation.Probes,
+		Type:             normativeResult,
+		Remediation:      `Define a readinessProbe in each container of the CNF Deployments and StatefulSets.`,
+		Description: formDescription(TestReadinessProbeIdentifier,
+			`tests that each container of the CNF Deployments and StatefulSets defines a readiness probe, so that
+no traffic is sent to the Pods until they are ready to serve it.  The probes are recorded in the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestPodDisruptionBudgetIdentifier: {
+		Identifier:    TestPodDisruptionBudgetIdentifier,
+		ResourceTypes: []ResourceType{ResourceDeployment, ResourceStatefulSet},
+		Type:          normativeResult,
+		Remediation: `Create a PodDisruptionBudget selecting the Pods of each CNF Deployment and StatefulSet, whose
+minAvailable or maxUnavailable keeps at least one replica available while allowing at least one to be evicted.`,
+		Description: formDescription(TestPodDisruptionBudgetIdentifier,
+			`tests that the Pods of each CNF Deployment and StatefulSet are selected by a single PodDisruptionBudget,
+and that its minAvailable or maxUnavailable is consistent with the replicas of the workload: the budget must keep at
+least one replica available during the voluntary disruptions, e.g. the node drains of an upgrade, without blocking
+them.  The percentages are rounded up, as Kubernetes does.  The workloads with a single replica, which no budget can
+both keep available and allow to be evicted, are not checked.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestPodSpreadingIdentifier: {
+		Identifier:    TestPodSpreadingIdentifier,
+		ResourceTypes: []ResourceType{ResourceDeployment, ResourceStatefulSet},
+		Type:          normativeResult,
+		Remediation: `Define a podAntiAffinity, e.g. on the kubernetes.io/hostname topology key, or
+topologySpreadConstraints in the pod template of each CNF Deployment and StatefulSet with more than one replica.`,
+		Description: formDescription(TestPodSpreadingIdentifier,
+			`tests that each CNF Deployment and StatefulSet with more than one replica defines a podAntiAffinity or
+topologySpreadConstraints in its pod template, so that its replicas are not all scheduled on the same node, which
+would defeat their high availability.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestGracefulTerminationIdentifier: {
+		Identifier:    TestGracefulTerminationIdentifier,
+		ResourceTypes: []ResourceType{ResourcePod, ResourceContainer},
+		Type:          normativeResult,
+		Remediation: `Set the terminationGracePeriodSeconds of each CNF Pod to the time its containers need to shut down,
+and define a preStop hook in each container, or handle SIGTERM in the containers and declare it with the
+test-network-function.com/sigterm_handler annotation.`,
+		Description: formDescription(TestGracefulTerminationIdentifier,
+			`tests that each CNF Pod sets its terminationGracePeriodSeconds explicitly, even to the default of 30 seconds,
+in the pod template of its Deployment or StatefulSet, or in its own spec otherwise, as told by the managedFields of the
+resource, and that each of its containers defines a preStop hook, unless the Pod declares that its containers handle
+SIGTERM with the test-network-function.com/sigterm_handler annotation, e.g. true.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestTerminationTimeIdentifier: {
+		Identifier:     TestTerminationTimeIdentifier,
+		Type:           normativeResult,
+		Classification: testcases.Intrusive,
+		Remediation: `Ensure that the containers of the CNF Pods stop on SIGTERM, or in their preStop hook, before the
+terminationGracePeriodSeconds of the Pod elapses, e.g. by closing their connections and exiting once drained.`,
+		Description: formDescription(TestTerminationTimeIdentifier,
+			`deletes one CNF Pod owned by a Deployment or a StatefulSet, and tests that it shuts down before its
+terminationGracePeriodSeconds elapses, i.e. that it is not killed.  The measured shutdown time is recorded in the
+claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestPodDeleteRecoveryIdentifier: {
+		Identifier:     TestPodDeleteRecoveryIdentifier,
+		ResourceTypes:  []ResourceType{ResourceDeployment, ResourceStatefulSet},
+		Type:           normativeResult,
+		Classification: testcases.Intrusive,
+		Remediation: `Ensure that the CNF Pods start and become ready quickly and without manual steps, e.g. with
+readiness probes reflecting their actual readiness, and that the nodes have the capacity to schedule the replacement
+Pods.`,
+		Description: formDescription(TestPodDeleteRecoveryIdentifier,
+			`deletes one Pod of each CNF Deployment, one Deployment at a time, and tests that the Deployment replaces it
+and is ready again within the pod-recovery timeout of the TNF configuration, 2 minutes by default.  The recovery
+times are recorded in the claim.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestUnmanagedPodsIdentifier: {
+		Identifier: TestUnmanagedPodsIdentifier,
 		Type:       normativeResult,
+		Remediation: `Deploy each CNF Pod with a Deployment, a StatefulSet, a DaemonSet or a Job rather than as a bare
+Pod, so that it is recreated on another node after a node failure.`,
+		Description: formDescription(TestUnmanagedPodsIdentifier,
+			`tests that the chain of ownerReferences of each CNF Pod, e.g. from the Pod to its ReplicaSet then to the
+Deployment of the ReplicaSet, reaches a Deployment, a StatefulSet, a DaemonSet or a Job.  Bare Pods, and Pods owned by
+other resources only, are not rescheduled after a node failure.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestImagePolicyIdentifier: {
+		Identifier:       TestImagePolicyIdentifier,
+		ResourceTypes:    []ResourceType{ResourceContainer},
+		Type:             normativeResult,
+		RemediationTheme: remediation.Images,
+		Remediation: `Reference the CNF images by a version tag, or better by digest, rather than by the latest tag, and
+set the imagePullPolicy of the containers to Always for a mutable tag and to IfNotPresent for a digest.`,
+		Description: formDescription(TestImagePolicyIdentifier,
+			`tests the image reference of each CNF container: it must not use the latest tag, or no tag, and should be
+pinned by digest, while the imagePullPolicy must be Always for the latest tag and must not be Always for a digest.  The
+action on each policy, fail, warn or ignore, can be changed in the imagePolicy section of the TNF configuration.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestSysctlConfigsIdentifier: {
+		Identifier:        TestSysctlConfigsIdentifier,
+		Tags:              []testcases.Tag{testcases.TagTelco},
+		ResourceTypes:     []ResourceType{ResourceNode},
+		RequiresOpenShift: true,
+		Type:              normativeResult,
 		Description: formDescription(TestPodRecreationIdentifier,
 			`tests that no one has changed the node's sysctl configs after the node
 			was created, the tests works by checking if the sysctl configs are consistent with the
@@ -556,18 +1577,23 @@ the changes for you.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
 	TestScalingIdentifier: {
-		Identifier: TestScalingIdentifier,
-		Type:       normativeResult,
+		Identifier:     TestScalingIdentifier,
+		ResourceTypes:  []ResourceType{ResourceDeployment, ResourceStatefulSet},
+		Type:           normativeResult,
+		Classification: testcases.Intrusive,
 		Description: formDescription(TestScalingIdentifier,
 			`tests that CNF deployments support scale in/out operations. 
 			First, The test starts getting the current replicaCount (N) of the deployment/s with the Pod Under Test. Then, it executes the 
-			scale-in oc command for (N-1) replicas. Lastly, it executes the scale-out oc command, restoring the original replicaCount of the deployment/s.`),
+			scale-in oc command for (N-1) replicas. Lastly, it executes the scale-out oc command, restoring the original replicaCount of the deployment/s.
+			After each step, the deployments must be ready again within the scaling-ready timeout of the TNF configuration,
+			1 minute by default.  The time they took is recorded in the claim.`),
 		Remediation:           `Make sure CNF deployments/replica sets can scale in/out successfully.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
 	TestIsRedHatReleaseIdentifier: {
-		Identifier: TestIsRedHatReleaseIdentifier,
-		Type:       normativeResult,
+		Identifier:       TestIsRedHatReleaseIdentifier,
+		RemediationTheme: remediation.Images,
+		Type:             normativeResult,
 		Description: formDescription(TestIsRedHatReleaseIdentifier,
 			`verifies if the container base image is redhat.`),
 		Remediation:           `build a new docker image that's based on UBI (redhat universal base image).`,
@@ -587,14 +1613,47 @@ the changes for you.`,
 			`Extracts OCP versions from the cluster.`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.3.6",
 	},
-	TestCrdsStatusSubresourceIdentifier: {
-		Identifier: TestCrdsStatusSubresourceIdentifier,
+	TestClusterInfoIdentifier: {
+		Identifier: TestClusterInfoIdentifier,
 		Type:       informativeResult,
+		Description: formDescription(TestClusterInfoIdentifier,
+			`extracts the OpenShift and Kubernetes versions, the infrastructure platform, e.g. AWS or BareMetal, and the
+network type, i.e. the CNI plugin, of the cluster.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.3.6",
+	},
+	TestCrdsStatusSubresourceIdentifier: {
+		Identifier:    TestCrdsStatusSubresourceIdentifier,
+		ResourceTypes: []ResourceType{ResourceCRD},
+		Type:          informativeResult,
 		Description: formDescription(TestCrdsStatusSubresourceIdentifier,
 			`checks that all CRDs have a status subresource specification.`),
 		Remediation:           `make sure that all the CRDs have a meaningful status specification.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
+	TestCrdSubresourcesIdentifier: {
+		Identifier:       TestCrdSubresourcesIdentifier,
+		ResourceTypes:    []ResourceType{ResourceCRD},
+		RemediationTheme: remediation.Observability,
+		Type:             normativeResult,
+		Description: formDescription(TestCrdSubresourcesIdentifier,
+			`checks that the served versions of each CRD under test define the status subresource, so that the status
+of the custom resources is updated apart from their spec, and the scale subresource when a targetCrdFilters entry
+matching the CRD declares its custom resources scalable.`),
+		Remediation: `Enable the status subresource of the CRDs, and the scale subresource of the CRDs whose custom
+resources are scalable, in all their served versions.`,
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestCrStatusIdentifier: {
+		Identifier:       TestCrStatusIdentifier,
+		ResourceTypes:    []ResourceType{ResourceCRD},
+		RemediationTheme: remediation.Observability,
+		Type:             normativeResult,
+		Description: formDescription(TestCrStatusIdentifier,
+			`checks that at least one custom resource of each CRD under test, in the target namespace for the namespaced
+CRDs, has a populated status.`),
+		Remediation:           `Make sure that the operators update the status of the custom resources they reconcile.`,
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
 	TestLoggingIdentifier: {
 		Identifier: TestLoggingIdentifier,
 		Type:       informativeResult,
@@ -603,4 +1662,30 @@ the changes for you.`,
 		Remediation:           `make sure containers are not redirecting stdout/stderr`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 11.1",
 	},
+	TestTimezoneIdentifier: {
+		Identifier:    TestTimezoneIdentifier,
+		ResourceTypes: []ResourceType{ResourceNode},
+		Type:          normativeResult,
+		Description: formDescription(TestTimezoneIdentifier,
+			`verifies that the nodes hosting the CNF report the UTC timezone, and that the containers under test do not
+override it with the TZ environment variable.  Mixed timezones make the correlation of the logs of the nodes and
+containers error prone when troubleshooting.`),
+		Remediation: `Keep the default UTC timezone of the nodes, and remove the TZ environment variable from the pod specs and
+container images.  Convert the timestamps to a local time in the log viewers instead.`,
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestPlatformRequirementsIdentifier: {
+		Identifier:    TestPlatformRequirementsIdentifier,
+		Tags:          []testcases.Tag{testcases.TagTelco},
+		ResourceTypes: []ResourceType{ResourceNode},
+		Type:          normativeResult,
+		Description: formDescription(TestPlatformRequirementsIdentifier,
+			`verifies that the nodes provide the platform features declared as required by the CNF in the
+platformRequirements section of the configuration: SCTP, SR-IOV, hugepage sizes, kernel modules and minimum kernel
+version.  The requirements-vs-provided table is recorded under the platformRequirements key of the claim rawResults.
+The test is skipped when no requirement is declared.`),
+		Remediation: `Deploy the CNF on nodes providing the required features, e.g. load the kernel modules and configure the
+hugepages with a MachineConfig, or relax the requirements of the CNF.`,
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
 }
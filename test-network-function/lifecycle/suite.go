@@ -17,13 +17,17 @@
 package lifecycle
 
 import (
+	"errors"
 	"fmt"
-	"path"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/test-network-function/test-network-function/pkg/config"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/containerimages"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/scaling"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
@@ -34,12 +38,20 @@ import (
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	dp "github.com/test-network-function/test-network-function/pkg/tnf/handlers/deployments"
 	dd "github.com/test-network-function/test-network-function/pkg/tnf/handlers/deploymentsdrain"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/graceperiod"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodeselector"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ownerreferences"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/owners"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/podreadiness"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/podspreading"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/probes"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/resources"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/termination"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
 )
@@ -49,36 +61,156 @@ const (
 	drainTimeoutMinutes           = 5
 	scalingTimeout                = 60 * time.Second
 	scalingPollingPeriod          = 1 * time.Second
+	// scalingReadyTimeout is how long the deployments under test have to be ready again once scaled, unless set for
+	// scalingReadyTimeoutKey in the timeouts section of the configuration.
+	scalingReadyTimeout    = time.Minute
+	scalingReadyTimeoutKey = "scaling-ready"
+	// podRecoveryTimeout is how long a deployment under test has to replace a deleted pod and be ready again, unless
+	// set for podRecoveryTimeoutKey in the timeouts section of the configuration.
+	podRecoveryTimeout    = 2 * time.Minute
+	podRecoveryTimeoutKey = "pod-recovery"
+	// convergenceTimeout is how long the CNF has to become healthy again once all its pods were deleted, unless set
+	// for convergenceTimeoutKey in the timeouts section of the configuration.
+	convergenceTimeout    = 5 * time.Minute
+	convergenceTimeoutKey = "convergence"
+	// convergencePollingPeriod is the period of the checks of the pods and deployments once all the pods were deleted.
+	convergencePollingPeriod = 5 * time.Second
+	// deploymentKind and statefulSetKind are the resource types of the workloads under test.
+	deploymentKind  = "deployment"
+	statefulSetKind = "statefulset"
+	// maxOwnerChainLength bounds the walk of the ownerReferences of a pod, e.g. pod, ReplicaSet then Deployment.
+	maxOwnerChainLength = 5
 )
 
 var (
 	// nodeUncordonTestPath is the file location of the uncordon.json test case relative to the project root.
-	nodeUncordonTestPath = path.Join("pkg", "tnf", "handlers", "nodeuncordon", "uncordon.json")
+	nodeUncordonTestPath = filepath.Join("pkg", "tnf", "handlers", "nodeuncordon", "uncordon.json")
 
 	// shutdownTestPath is the file location of shutdown.json test case relative to the project root.
-	shutdownTestPath = path.Join("pkg", "tnf", "handlers", "shutdown", "shutdown.json")
+	shutdownTestPath = filepath.Join("pkg", "tnf", "handlers", "shutdown", "shutdown.json")
 
 	// shutdownTestDirectoryPath is the directory of the shutdown test
-	shutdownTestDirectoryPath = path.Join("pkg", "tnf", "handlers", "shutdown")
+	shutdownTestDirectoryPath = filepath.Join("pkg", "tnf", "handlers", "shutdown")
 
 	// relativeNodesTestPath is the relative path to the nodes.json test case.
-	relativeNodesTestPath = path.Join(common.PathRelativeToRoot, nodeUncordonTestPath)
+	relativeNodesTestPath = filepath.Join(common.PathRelativeToRoot, nodeUncordonTestPath)
 
 	// relativeShutdownTestPath is the relative path to the shutdown.json test case.
-	relativeShutdownTestPath = path.Join(common.PathRelativeToRoot, shutdownTestPath)
+	relativeShutdownTestPath = filepath.Join(common.PathRelativeToRoot, shutdownTestPath)
 
 	// relativeShutdownTestDirectoryPath is the directory of the shutdown directory
-	relativeShutdownTestDirectoryPath = path.Join(common.PathRelativeToRoot, shutdownTestDirectoryPath)
+	relativeShutdownTestDirectoryPath = filepath.Join(common.PathRelativeToRoot, shutdownTestDirectoryPath)
 
 	// podAntiAffinityTestPath is the file location of the podantiaffinity.json test case relative to the project root.
-	podAntiAffinityTestPath = path.Join("pkg", "tnf", "handlers", "podantiaffinity", "podantiaffinity.json")
+	podAntiAffinityTestPath = filepath.Join("pkg", "tnf", "handlers", "podantiaffinity", "podantiaffinity.json")
 
 	// relativePodTestPath is the relative path to the podantiaffinity.json test case.
-	relativePodTestPath = path.Join(common.PathRelativeToRoot, podAntiAffinityTestPath)
+	relativePodTestPath = filepath.Join(common.PathRelativeToRoot, podAntiAffinityTestPath)
 )
 
 var drainTimeout = time.Duration(drainTimeoutMinutes) * time.Minute
 
+// managedOwnerKinds are the kinds of the controllers which recreate their pods after a node failure.
+var managedOwnerKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+	"Job":         true,
+}
+
+// StartupOrdering is the convergence of the CNF after the simultaneous deletion of all the pods under test.
+type StartupOrdering struct {
+	DeletedPods []string `json:"deletedPods"`
+	Converged   bool     `json:"converged"`
+	// ConvergenceSeconds is the time from the deletion to the readiness of all the pods and deployments, or the time
+	// waited when the CNF did not converge.
+	ConvergenceSeconds float64 `json:"convergenceSeconds"`
+}
+
+// startupOrdering holds the convergence measured by the startup ordering test, nil unless it ran.
+var startupOrdering *StartupOrdering
+
+// WorkloadProbes are the probes of the containers of a deployment or a statefulset under test.
+type WorkloadProbes struct {
+	Kind       string                   `json:"kind"`
+	Namespace  string                   `json:"namespace"`
+	Name       string                   `json:"name"`
+	Containers []probes.ContainerProbes `json:"containers"`
+	// podLabels are the labels of the pod template of the workload, to find its pods under test.
+	podLabels map[string]string
+}
+
+// workloadProbes holds the probes read by the probe tests, nil unless they ran.
+var workloadProbes []WorkloadProbes
+
+// TerminationTime is the shutdown of a pod under test deleted by the termination time test.
+type TerminationTime struct {
+	Pod                string `json:"pod"`
+	GracePeriodSeconds int    `json:"gracePeriodSeconds"`
+	// ShutdownSeconds is the time from the deletion request to the removal of the pod.
+	ShutdownSeconds float64 `json:"shutdownSeconds"`
+	// Killed is true when the pod did not shut down before the end of its grace period.
+	Killed bool `json:"killed"`
+}
+
+// ScalingTiming is the time a deployment under test took to be ready again once scaled in by one replica, then out
+// to its original replicas.
+type ScalingTiming struct {
+	Deployment string `json:"deployment"`
+	Replicas   int    `json:"replicas"`
+	// ScaleInSeconds and ScaleOutSeconds are the times from the scaling command to the readiness of the deployments of
+	// the namespace, 0 when the step did not complete.
+	ScaleInSeconds  float64 `json:"scaleInSeconds"`
+	ScaleOutSeconds float64 `json:"scaleOutSeconds"`
+}
+
+// scalingTimings holds the timings measured by the scaling test.
+var scalingTimings []ScalingTiming
+
+// PodDeleteRecovery is the recovery of a deployment under test once one of its pods was deleted.
+type PodDeleteRecovery struct {
+	Deployment string `json:"deployment"`
+	DeletedPod string `json:"deletedPod"`
+	Recovered  bool   `json:"recovered"`
+	// RecoverySeconds is the time from the deletion to the readiness of the deployment without the deleted pod, or
+	// the time waited when the deployment did not recover.
+	RecoverySeconds float64 `json:"recoverySeconds"`
+}
+
+// podDeleteRecoveries holds the recoveries measured by the pod delete recovery test.
+var podDeleteRecoveries []PodDeleteRecovery
+
+// terminationTime holds the shutdown measured by the termination time test, nil unless it ran.
+var terminationTime *TerminationTime
+
+// GetStartupOrdering returns the convergence measured by the startup ordering test, nil unless the test ran.
+func GetStartupOrdering() *StartupOrdering {
+	return startupOrdering
+}
+
+// GetTerminationTime returns the shutdown measured by the termination time test, nil unless the test ran.
+func GetTerminationTime() *TerminationTime {
+	return terminationTime
+}
+
+// GetScalingTimings returns the time each deployment under test took to be ready again once scaled in then out, empty
+// unless the scaling test ran.
+func GetScalingTimings() []ScalingTiming {
+	return scalingTimings
+}
+
+// GetPodDeleteRecoveries returns the recovery of each deployment under test once one of its pods was deleted, empty
+// unless the pod delete recovery test ran.
+func GetPodDeleteRecoveries() []PodDeleteRecovery {
+	return podDeleteRecoveries
+}
+
+// GetProbes returns the probes of the containers of the deployments and statefulsets under test, nil unless the probe
+// tests ran.
+func GetProbes() []WorkloadProbes {
+	return workloadProbes
+}
+
 //
 // All actual test code belongs below here.  Utilities belong above.
 //
@@ -87,7 +219,7 @@ var _ = ginkgo.Describe(common.LifecycleTestKey, func() {
 	if testcases.IsInFocus(conf.FocusStrings, common.LifecycleTestKey) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
-			env.LoadAndRefresh()
+			gomega.Expect(env.LoadAndRefresh()).To(gomega.Succeed())
 			gomega.Expect(len(env.PodsUnderTest)).ToNot(gomega.Equal(0))
 			gomega.Expect(len(env.ContainersUnderTest)).ToNot(gomega.Equal(0))
 
@@ -103,17 +235,37 @@ var _ = ginkgo.Describe(common.LifecycleTestKey, func() {
 
 		testPodAntiAffinity(env)
 
-		if common.Intrusive() {
-			testPodsRecreation(env)
+		testPodsRecreation(env)
 
-			testScaling(env)
-		}
+		testScaling(env)
+
+		testStartupOrdering(env)
 
 		testOwner(env)
+
+		testResources(env)
+
+		testLivenessProbes(env)
+
+		testReadinessProbes(env)
+
+		testPodDisruptionBudgets(env)
+
+		testPodSpreading(env)
+
+		testGracefulTermination(env)
+
+		testTerminationTime(env)
+
+		testPodDeleteRecovery(env)
+
+		testUnmanagedPods(env)
+
+		testImagePolicy(env)
 	}
 })
 
-func waitForAllDeploymentsReady(namespace string, timeout, pollingPeriod time.Duration) { //nolint:unparam // it is fine to use always the same value for timeout
+func waitForAllDeploymentsReady(namespace string, timeout, pollingPeriod time.Duration) {
 	gomega.Eventually(func() []string {
 		_, notReadyDeployments := getDeployments(namespace)
 		log.Debugf("Waiting for deployments to get ready, remaining: %d deployments", len(notReadyDeployments))
@@ -136,7 +288,7 @@ func restoreDeployments(env *config.TestEnvironment) {
 			log.Warn("Deployment ", deployment.Name, " replicaCount (", deployment.Replicas, ") needs to be restored.")
 
 			// Try to scale to the original deployment's replicaCount.
-			runScalingTest(deployment)
+			runScalingTest(deployment, env.Config.Timeouts.GetWait(scalingReadyTimeoutKey, scalingReadyTimeout))
 
 			env.SetNeedsRefresh()
 		}
@@ -156,20 +308,25 @@ func closeOcSessionsByDeployment(containers map[configsections.ContainerIdentifi
 	}
 }
 
-// runScalingTest Runs a Scaling handler TC and waits for all the deployments to be ready.
-func runScalingTest(deployment configsections.Deployment) {
-	handler := scaling.NewScaling(common.DefaultTimeout, deployment.Namespace, deployment.Name, deployment.Replicas)
-	test, err := tnf.NewTest(common.GetContext().GetExpecter(), handler, []reel.Handler{handler}, common.GetContext().GetErrorChannel())
+// runScalingTest Runs a Scaling handler TC and waits for all the deployments to be ready within readyTimeout, it
+// returns the time from the scaling to their readiness.
+func runScalingTest(deployment configsections.Deployment, readyTimeout time.Duration) time.Duration {
+	handler := scaling.NewScaling(common.GetTimeout(common.LifecycleTestKey, "scaling"), deployment.Namespace, deployment.Name, deployment.Replicas)
+	context := common.GetContext()
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), handler, []reel.Handler{handler}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	start := time.Now()
+	common.RunAndValidateTest(test)
 
 	// Wait until the deployment is ready
-	waitForAllDeploymentsReady(deployment.Namespace, scalingTimeout, scalingPollingPeriod)
+	waitForAllDeploymentsReady(deployment.Namespace, readyTimeout, scalingPollingPeriod)
+	return time.Since(start)
 }
 
 func testScaling(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestScalingIdentifier)
 	ginkgo.It(testID, func() {
+		common.SkipUnlessAllowed(identifiers.GetClassification(identifiers.TestScalingIdentifier))
 		ginkgo.By("Testing deployment scaling")
 		defer restoreDeployments(env)
 		defer env.SetNeedsRefresh()
@@ -177,20 +334,31 @@ func testScaling(env *config.TestEnvironment) {
 		if len(env.DeploymentsUnderTest) == 0 {
 			ginkgo.Skip("No test deployments found.")
 		}
+		scalingTimings = nil
+		readyTimeout := env.Config.Timeouts.GetWait(scalingReadyTimeoutKey, scalingReadyTimeout)
 		for _, deployment := range env.DeploymentsUnderTest {
 			ginkgo.By(fmt.Sprintf("Scaling Deployment=%s, Replicas=%d (ns=%s)",
 				deployment.Name, deployment.Replicas, deployment.Namespace))
 
 			closeOcSessionsByDeployment(env.ContainersUnderTest, deployment)
 			replicaCount := deployment.Replicas
+			// the timing is recorded beforehand, so that the completed steps are kept when the deployment is not ready
+			// in time.
+			scalingTimings = append(scalingTimings, ScalingTiming{
+				Deployment: deployment.Namespace + "/" + deployment.Name,
+				Replicas:   replicaCount,
+			})
+			timing := &scalingTimings[len(scalingTimings)-1]
 
 			// ScaleIn, removing one pod from the replicaCount
 			deployment.Replicas = replicaCount - 1
-			runScalingTest(deployment)
+			timing.ScaleInSeconds = runScalingTest(deployment, readyTimeout).Seconds()
 
 			// Scaleout, restoring the original replicaCount number
 			deployment.Replicas = replicaCount
-			runScalingTest(deployment)
+			timing.ScaleOutSeconds = runScalingTest(deployment, readyTimeout).Seconds()
+			log.Infof("Deployment %s was ready in %.1fs once scaled in, %.1fs once scaled out", timing.Deployment,
+				timing.ScaleInSeconds, timing.ScaleOutSeconds)
 		}
 	})
 }
@@ -204,10 +372,10 @@ func testNodeSelector(env *config.TestEnvironment) {
 			podName := podUnderTest.Name
 			podNamespace := podUnderTest.Namespace
 			ginkgo.By(fmt.Sprintf("Testing pod nodeSelector %s/%s", podNamespace, podName))
-			tester := nodeselector.NewNodeSelector(common.DefaultTimeout, podName, podNamespace)
-			test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+			tester := nodeselector.NewNodeSelector(common.GetTimeout(common.LifecycleTestKey, "nodeselector"), podName, podNamespace)
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 			gomega.Expect(err).To(gomega.BeNil())
-			test.RunAndValidateWithFailureCallback(func() {
+			common.RunAndValidateTestWithFailureCallback(test, func() {
 				msg := fmt.Sprintf("The pod specifies nodeSelector/nodeAffinity field, you might want to change it, %s %s", podNamespace, podName)
 				log.Warn(msg)
 				_, err := ginkgo.GinkgoWriter.Write([]byte(msg))
@@ -228,10 +396,10 @@ func testGracePeriod(env *config.TestEnvironment) {
 			podName := podUnderTest.Name
 			podNamespace := podUnderTest.Namespace
 			ginkgo.By(fmt.Sprintf("Testing pod terminationGracePeriod %s %s", podNamespace, podName))
-			tester := graceperiod.NewGracePeriod(common.DefaultTimeout, podName, podNamespace)
-			test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+			tester := graceperiod.NewGracePeriod(common.GetTimeout(common.LifecycleTestKey, "graceperiod"), podName, podNamespace)
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 			gomega.Expect(err).To(gomega.BeNil())
-			test.RunAndValidate()
+			common.RunAndValidateTest(test)
 			gracePeriod := tester.GetGracePeriod()
 			if gracePeriod == defaultTerminationGracePeriod {
 				msg := fmt.Sprintf("%s %s has terminationGracePeriod set to %d, you might want to change it", podNamespace, podName, defaultTerminationGracePeriod)
@@ -249,21 +417,40 @@ func testShutdown(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestShudtownIdentifier)
 	ginkgo.It(testID, func() {
 		ginkgo.By("Testing PUTs are configured with pre-stop lifecycle")
-		for _, podUnderTest := range env.PodsUnderTest {
-			podName := podUnderTest.Name
-			podNamespace := podUnderTest.Namespace
-			ginkgo.By(fmt.Sprintf("should have pre-stop configured %s/%s", podNamespace, podName))
-			shutdownTest(podNamespace, podName)
+		for i := range env.PodsUnderTest {
+			pod := &env.PodsUnderTest[i]
+			skipped, tested := getSkippedContainers(env, testID, pod)
+			if !tested {
+				log.Infof("The containers of pod %s are opted out of %s", pod.FullName(), testID)
+				continue
+			}
+			ginkgo.By(fmt.Sprintf("should have pre-stop configured %s", pod.FullName()))
+			shutdownTest(pod.Namespace, pod.Name, skipped)
 		}
 	})
 }
 
-func shutdownTest(podNamespace, podName string) {
+// getSkippedContainers returns the sorted names of the containers under test of pod opted out of the test case
+// testID, and whether some of its containers are still tested.
+func getSkippedContainers(env *config.TestEnvironment, testID string, pod *configsections.Pod) (skipped []string, tested bool) {
+	for _, cut := range env.ContainersUnderTest {
+		if cut.ContainerIdentifier.Namespace == pod.Namespace && cut.ContainerIdentifier.PodName == pod.Name &&
+			env.SkipsContainerTest(testID, &cut.ContainerIdentifier) {
+			skipped = append(skipped, cut.ContainerIdentifier.ContainerName)
+		}
+	}
+	sort.Strings(skipped)
+	return skipped, pod.ContainerCount == 0 || len(skipped) < pod.ContainerCount
+}
+
+// shutdownTest tests the containers of a pod define a pre-stop hook, except the skipped containers.
+func shutdownTest(podNamespace, podName string, skipped []string) {
 	context := common.GetContext()
 	values := make(map[string]interface{})
 	values["POD_NAMESPACE"] = podNamespace
 	values["POD_NAME"] = podName
 	values["GO_TEMPLATE_PATH"] = relativeShutdownTestDirectoryPath
+	values["SKIPPED_CONTAINERS"] = strings.Join(skipped, "|")
 	tester, handlers, result, err := generic.NewGenericFromMap(relativeShutdownTestPath, common.RelativeSchemaPath, values)
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(result).ToNot(gomega.BeNil())
@@ -271,11 +458,11 @@ func shutdownTest(podNamespace, podName string) {
 	gomega.Expect(handlers).ToNot(gomega.BeNil())
 	gomega.Expect(handlers).ToNot(gomega.BeNil())
 	gomega.Expect(tester).ToNot(gomega.BeNil())
-	test, err := tnf.NewTest(context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(test).ToNot(gomega.BeNil())
 
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 }
 
 func testPodsRecreation(env *config.TestEnvironment) {
@@ -284,6 +471,7 @@ func testPodsRecreation(env *config.TestEnvironment) {
 
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodRecreationIdentifier)
 	ginkgo.It(testID, func() {
+		common.SkipUnlessAllowed(identifiers.GetClassification(identifiers.TestPodRecreationIdentifier))
 		ginkgo.By("Testing node draining effect of deployment")
 		ginkgo.By(fmt.Sprintf("test deployment in namespace %s", env.NameSpaceUnderTest))
 		deployments, notReadyDeployments = getDeployments(env.NameSpaceUnderTest)
@@ -327,11 +515,15 @@ func testPodsRecreation(env *config.TestEnvironment) {
 
 // getDeployments returns map of deployments and names of not-ready deployments
 func getDeployments(namespace string) (deployments dp.DeploymentMap, notReadyDeployments []string) {
-	context := common.GetContext()
-	tester := dp.NewDeployments(common.DefaultTimeout, namespace)
-	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	return getDeploymentsWithContext(common.GetContext(), namespace)
+}
+
+// getDeploymentsWithContext is getDeployments running oc in context, e.g. to poll the deployments with one session.
+func getDeploymentsWithContext(context *interactive.Context, namespace string) (deployments dp.DeploymentMap, notReadyDeployments []string) {
+	tester := dp.NewDeployments(common.GetTimeout(common.LifecycleTestKey, "deployments"), namespace)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 
 	deployments = tester.GetDeployments()
 
@@ -350,7 +542,7 @@ func getDeployments(namespace string) (deployments dp.DeploymentMap, notReadyDep
 func drainNode(node string) {
 	context := common.GetContext()
 	tester := dd.NewDeploymentsDrain(drainTimeout, node)
-	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 	result, err := test.Run()
 	if err != nil || result == tnf.ERROR {
@@ -370,11 +562,11 @@ func uncordonNode(node string) {
 	gomega.Expect(len(handlers)).To(gomega.Equal(1))
 	gomega.Expect(tester).ToNot(gomega.BeNil())
 
-	test, err := tnf.NewTest(context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(test).ToNot(gomega.BeNil())
 
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 }
 
 // Pod antiaffinity test for all deployments
@@ -409,11 +601,11 @@ func podAntiAffinity(deployment, podNamespace string, replica int) {
 	gomega.Expect(handlers).ToNot(gomega.BeNil())
 	gomega.Expect(len(handlers)).To(gomega.Equal(1))
 	gomega.Expect(tester).ToNot(gomega.BeNil())
-	test, err := tnf.NewTest(context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(test).ToNot(gomega.BeNil())
 
-	test.RunAndValidateWithFailureCallback(func() {
+	common.RunAndValidateTestWithFailureCallback(test, func() {
 		if replica > 1 {
 			msg := fmt.Sprintf("The deployment replica count is %d, but a podAntiAffinity rule is not defined, "+
 				"you might want to change it in deployment %s in namespace %s", replica, deployment, podNamespace)
@@ -444,10 +636,702 @@ func testOwner(env *config.TestEnvironment) {
 			podName := podUnderTest.Name
 			podNamespace := podUnderTest.Namespace
 			ginkgo.By(fmt.Sprintf("Should be ReplicaSet %s %s", podNamespace, podName))
-			tester := owners.NewOwners(common.DefaultTimeout, podNamespace, podName)
-			test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+			tester := owners.NewOwners(common.GetTimeout(common.LifecycleTestKey, "owners"), podNamespace, podName)
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 			gomega.Expect(err).To(gomega.BeNil())
-			test.RunAndValidate()
+			common.RunAndValidateTest(test)
+		}
+	})
+}
+
+// testStartupOrdering deletes all the pods under test at once, so that they restart in an arbitrary order, and checks
+// that the CNF converges to healthy without any manual ordering.
+func testStartupOrdering(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestStartupOrderingIdentifier)
+	ginkgo.It(testID, func() {
+		common.SkipUnlessAllowed(identifiers.GetClassification(identifiers.TestStartupOrderingIdentifier))
+		ginkgo.By("Should converge to healthy whatever the startup order of the pods")
+		context := common.GetContext()
+		podsByNamespace := map[string][]string{}
+		for i := range env.PodsUnderTest {
+			pod := &env.PodsUnderTest[i]
+			podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod.Name)
+		}
+		// the pods to be replaced, by UID since the pods of the StatefulSets keep their name, and the pod count to reach.
+		deletedUIDs := map[string]bool{}
+		podCounts := map[string]int{}
+		var deletedPods []string
+		for namespace, names := range podsByNamespace {
+			pods := getPodReadiness(context, namespace)
+			podCounts[namespace] = len(pods)
+			targets := map[string]bool{}
+			for _, name := range names {
+				targets[name] = true
+			}
+			for _, pod := range pods {
+				if !pod.Ready {
+					ginkgo.Skip(fmt.Sprintf("Can not test when pod %s/%s is not ready", namespace, pod.Name))
+				}
+				if targets[pod.Name] {
+					deletedUIDs[pod.UID] = true
+					deletedPods = append(deletedPods, namespace+"/"+pod.Name)
+				}
+			}
+			if _, notReadyDeployments := getDeploymentsWithContext(context, namespace); len(notReadyDeployments) != 0 {
+				ginkgo.Skip("Can not test when deployments are not ready")
+			}
+		}
+		sort.Strings(deletedPods)
+		defer env.SetNeedsRefresh()
+		// the sessions to the containers under test are lost with their pods.
+		env.ResetOc()
+		start := time.Now()
+		for namespace, names := range podsByNamespace {
+			command := fmt.Sprintf("oc delete pods -n %s --wait=false %s", namespace, strings.Join(names, " "))
+			common.ExecuteCommand(command, common.GetTimeout(common.LifecycleTestKey, "deletepods"), context, nil)
+		}
+		timeout := env.Config.Timeouts.GetWait(convergenceTimeoutKey, convergenceTimeout)
+		var pending []string
+		for {
+			pending = getPendingConvergence(context, podsByNamespace, deletedUIDs, podCounts)
+			if len(pending) == 0 || time.Since(start) > timeout {
+				break
+			}
+			log.Debugf("Waiting for the CNF to converge: %s", strings.Join(pending, ", "))
+			time.Sleep(convergencePollingPeriod)
+		}
+		startupOrdering = &StartupOrdering{
+			DeletedPods:        deletedPods,
+			Converged:          len(pending) == 0,
+			ConvergenceSeconds: time.Since(start).Seconds(),
+		}
+		log.Infof("Deleted %d pods, converged: %t after %.1fs", len(deletedPods), startupOrdering.Converged,
+			startupOrdering.ConvergenceSeconds)
+		gomega.Expect(pending).To(gomega.BeEmpty())
+	})
+}
+
+// getPendingConvergence returns why the namespaces of the deleted pods did not converge yet: deleted pods still
+// present, missing or not ready pods, and not ready deployments.
+func getPendingConvergence(context *interactive.Context, podsByNamespace map[string][]string, deletedUIDs map[string]bool,
+	podCounts map[string]int) []string {
+	var pending []string
+	for namespace := range podsByNamespace {
+		pods := getPodReadiness(context, namespace)
+		if len(pods) < podCounts[namespace] {
+			pending = append(pending, fmt.Sprintf("%d of %d pods in namespace %s", len(pods), podCounts[namespace], namespace))
+		}
+		for _, pod := range pods {
+			if deletedUIDs[pod.UID] {
+				pending = append(pending, fmt.Sprintf("pod %s/%s not deleted yet", namespace, pod.Name))
+			} else if !pod.Ready {
+				pending = append(pending, fmt.Sprintf("pod %s/%s not ready", namespace, pod.Name))
+			}
+		}
+		_, notReadyDeployments := getDeploymentsWithContext(context, namespace)
+		for _, name := range notReadyDeployments {
+			pending = append(pending, fmt.Sprintf("deployment %s/%s not ready", namespace, name))
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
+
+// getPodReadiness returns the pods of the namespace with their readiness.
+func getPodReadiness(context *interactive.Context, namespace string) []podreadiness.Pod {
+	tester := podreadiness.NewPodReadiness(common.GetTimeout(common.LifecycleTestKey, "podreadiness"), namespace)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	common.RunAndValidateTest(test)
+	return tester.GetPods()
+}
+
+// testResources checks that each container under test declares its CPU and memory requests and limits, and that the
+// latency-sensitive pods get the Guaranteed QoS class, i.e. that their requests are equal to their limits.
+func testResources(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestContainerResourcesIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should declare the CPU and memory requests and limits of the containers")
+		pods := env.PodsUnderTest
+		var mutex sync.Mutex
+		var badTargets []string
+		defer func() {
+			results.RecordFailedTargets(badTargets...)
+		}()
+		common.RunInParallel(len(pods), func(i int, context *interactive.Context) error {
+			pod := &pods[i]
+			tester := resources.NewResources(common.GetTimeout(common.LifecycleTestKey, "resources"), pod.Name, pod.Namespace)
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+			if err != nil {
+				return err
+			}
+			if err := test.RunAndCheck(nil); err != nil {
+				return fmt.Errorf("pod %s: %w", pod.FullName(), err)
+			}
+			violations, targets := getResourcesViolations(env, testID, pod, tester)
+			if len(violations) == 0 {
+				return nil
+			}
+			mutex.Lock()
+			badTargets = append(badTargets, targets...)
+			mutex.Unlock()
+			return fmt.Errorf("%s", strings.Join(violations, "; "))
+		})
+	})
+}
+
+// getResourcesViolations returns the descriptions of the missing resources of the containers of pod, except the
+// containers opted out of the test case testID, and of its QoS class when it is latency-sensitive, along with the
+// failed targets.
+func getResourcesViolations(env *config.TestEnvironment, testID string, pod *configsections.Pod,
+	tester *resources.Resources) (violations, targets []string) {
+	containers := tester.GetContainers()
+	for i := range containers {
+		if env.SkipsContainerTest(testID, &configsections.ContainerIdentifier{Namespace: pod.Namespace, PodName: pod.Name,
+			ContainerName: containers[i].Name}) {
+			continue
+		}
+		if missing := containers[i].GetMissing(); len(missing) > 0 {
+			name := pod.FullName() + "/" + containers[i].Name
+			log.Errorf("Container %s does not set %s", name, strings.Join(missing, ", "))
+			violations = append(violations, fmt.Sprintf("container %s does not set %s", name, strings.Join(missing, ", ")))
+			targets = append(targets, name)
+		}
+	}
+	if pod.LatencySensitive && tester.GetQOSClass() != resources.GuaranteedQOSClass {
+		log.Errorf("Latency-sensitive pod %s has the %s QoS class", pod.FullName(), tester.GetQOSClass())
+		violations = append(violations, fmt.Sprintf("latency-sensitive pod %s has the %s QoS class, not %s",
+			pod.FullName(), tester.GetQOSClass(), resources.GuaranteedQOSClass))
+		targets = append(targets, pod.FullName())
+	}
+	return violations, targets
+}
+
+func testLivenessProbes(env *config.TestEnvironment) {
+	testProbes(env, identifiers.TestLivenessProbeIdentifier, "liveness", func(container *probes.ContainerProbes) bool {
+		return container.LivenessProbe != nil
+	})
+}
+
+func testReadinessProbes(env *config.TestEnvironment) {
+	testProbes(env, identifiers.TestReadinessProbeIdentifier, "readiness", func(container *probes.ContainerProbes) bool {
+		return container.ReadinessProbe != nil
+	})
+}
+
+// testProbes checks that each container of the deployments and statefulsets under test defines the probe of kind,
+// e.g. liveness, as told by defined.
+func testProbes(env *config.TestEnvironment, id claim.Identifier, kind string, defined func(*probes.ContainerProbes) bool) {
+	testID := identifiers.XformToGinkgoItIdentifier(id)
+	ginkgo.It(testID, func() {
+		if len(env.DeploymentsUnderTest) == 0 && len(env.StatefulSetsUnderTest) == 0 {
+			ginkgo.Skip("No deployment or statefulset under test found.")
+		}
+		ginkgo.By(fmt.Sprintf("Should define the %s probe of the containers", kind))
+		workloadProbes = getWorkloadProbes(env)
+		var badContainers []string
+		for i := range workloadProbes {
+			workload := &workloadProbes[i]
+			for j := range workload.Containers {
+				if !defined(&workload.Containers[j]) && !skipsWorkloadContainer(env, testID, workload, workload.Containers[j].Name) {
+					name := workload.Namespace + "/" + workload.Name + "/" + workload.Containers[j].Name
+					log.Errorf("Container %s of %s does not define a %s probe", name, workload.Kind, kind)
+					badContainers = append(badContainers, name)
+				}
+			}
+		}
+		results.RecordFailedTargets(badContainers...)
+		gomega.Expect(badContainers).To(gomega.BeEmpty())
+	})
+}
+
+// getWorkloadProbes reads the probes of the containers of the deployments and statefulsets under test.
+func getWorkloadProbes(env *config.TestEnvironment) []WorkloadProbes {
+	context := common.GetContext()
+	var workloads []WorkloadProbes
+	read := func(kind, name, namespace string, podLabels map[string]string) {
+		tester := probes.NewProbes(common.GetTimeout(common.LifecycleTestKey, "probes"), kind, name, namespace)
+		test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+		gomega.Expect(err).To(gomega.BeNil())
+		common.RunAndValidateTest(test)
+		workloads = append(workloads, WorkloadProbes{Kind: kind, Namespace: namespace, Name: name,
+			Containers: tester.GetContainers(), podLabels: podLabels})
+	}
+	for _, deployment := range env.DeploymentsUnderTest {
+		read(deploymentKind, deployment.Name, deployment.Namespace, deployment.PodLabels)
+	}
+	for _, statefulSet := range env.StatefulSetsUnderTest {
+		read(statefulSetKind, statefulSet.Name, statefulSet.Namespace, statefulSet.PodLabels)
+	}
+	return workloads
+}
+
+// skipsWorkloadContainer returns true when the container is opted out of the test case testID in one of the pods
+// under test owned by the workload.
+func skipsWorkloadContainer(env *config.TestEnvironment, testID string, workload *WorkloadProbes, containerName string) bool {
+	for i := range env.PodsUnderTest {
+		pod := &env.PodsUnderTest[i]
+		if isOwnedBy(pod, workload.Namespace, workload.podLabels) && env.SkipsContainerTest(testID,
+			&configsections.ContainerIdentifier{Namespace: pod.Namespace, PodName: pod.Name, ContainerName: containerName}) {
+			return true
+		}
+	}
+	return false
+}
+
+func testPodDisruptionBudgets(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodDisruptionBudgetIdentifier)
+	ginkgo.It(testID, func() {
+		if len(env.DeploymentsUnderTest) == 0 && len(env.StatefulSetsUnderTest) == 0 {
+			ginkgo.Skip("No deployment or statefulset under test found.")
+		}
+		ginkgo.By("Should be covered by a PodDisruptionBudget consistent with their replicas")
+		var badWorkloads []string
+		checked := false
+		check := func(kind, name, namespace string, replicas int, podLabels map[string]string) {
+			err := checkPodDisruptionBudget(env.Config.PodDisruptionBudgets, namespace, replicas, podLabels)
+			if errors.Is(err, configsections.ErrSingleReplica) {
+				log.Infof("The %s %s/%s has %d replica, its PodDisruptionBudget is not checked", kind, namespace, name, replicas)
+				return
+			}
+			checked = true
+			if err != nil {
+				log.Errorf("The %s %s/%s %v", kind, namespace, name, err)
+				badWorkloads = append(badWorkloads, namespace+"/"+name)
+			}
+		}
+		for _, deployment := range env.DeploymentsUnderTest {
+			check(deploymentKind, deployment.Name, deployment.Namespace, deployment.Replicas, deployment.PodLabels)
+		}
+		for _, statefulSet := range env.StatefulSetsUnderTest {
+			check(statefulSetKind, statefulSet.Name, statefulSet.Namespace, statefulSet.Replicas, statefulSet.PodLabels)
+		}
+		if !checked {
+			ginkgo.Skip("No deployment or statefulset under test has several replicas.")
 		}
+		results.RecordFailedTargets(badWorkloads...)
+		gomega.Expect(badWorkloads).To(gomega.BeEmpty())
 	})
 }
+
+// checkPodDisruptionBudget returns an error unless a single PodDisruptionBudget of budgets selects the pods of a
+// workload of namespace, with podLabels, and it is consistent with the replicas of the workload.  A workload with a
+// single replica is not checked, configsections.ErrSingleReplica is returned.
+func checkPodDisruptionBudget(budgets []configsections.PodDisruptionBudget, namespace string, replicas int,
+	podLabels map[string]string) error {
+	if replicas < configsections.MinCheckedReplicas {
+		return configsections.ErrSingleReplica
+	}
+	var names []string
+	var selecting *configsections.PodDisruptionBudget
+	for i := range budgets {
+		if budgets[i].Selects(namespace, podLabels) {
+			selecting = &budgets[i]
+			names = append(names, selecting.FullName())
+		}
+	}
+	switch len(names) {
+	case 0:
+		return errors.New("is not covered by a PodDisruptionBudget")
+	case 1:
+		if err := selecting.CheckReplicas(replicas); err != nil {
+			return fmt.Errorf("is covered by the PodDisruptionBudget %s which %w", selecting.FullName(), err)
+		}
+		return nil
+	default:
+		// the eviction API refuses to evict the pods selected by several budgets.
+		return fmt.Errorf("is covered by several PodDisruptionBudgets, %s, its pods cannot be evicted",
+			strings.Join(names, ", "))
+	}
+}
+
+func testPodSpreading(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodSpreadingIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should spread the pods of the workloads with several replicas across the nodes")
+		var badWorkloads []string
+		checked := false
+		check := func(kind, name, namespace string, replicas int) {
+			if replicas <= 1 {
+				return
+			}
+			checked = true
+			if !isPodSpreadingDefined(kind, name, namespace) {
+				log.Errorf("The %s %s/%s has %d replicas but defines neither a podAntiAffinity nor "+
+					"topologySpreadConstraints, its pods may all run on the same node", kind, namespace, name, replicas)
+				badWorkloads = append(badWorkloads, namespace+"/"+name)
+			}
+		}
+		for _, deployment := range env.DeploymentsUnderTest {
+			check(deploymentKind, deployment.Name, deployment.Namespace, deployment.Replicas)
+		}
+		for _, statefulSet := range env.StatefulSetsUnderTest {
+			check(statefulSetKind, statefulSet.Name, statefulSet.Namespace, statefulSet.Replicas)
+		}
+		if !checked {
+			ginkgo.Skip("No deployment or statefulset under test with more than one replica found.")
+		}
+		results.RecordFailedTargets(badWorkloads...)
+		gomega.Expect(badWorkloads).To(gomega.BeEmpty())
+	})
+}
+
+// isPodSpreadingDefined returns true when the pod template of the workload of kind defines a podAntiAffinity term or
+// a topologySpreadConstraint.
+func isPodSpreadingDefined(kind, name, namespace string) bool {
+	context := common.GetContext()
+	tester := podspreading.NewPodSpreading(common.GetTimeout(common.LifecycleTestKey, "podspreading"), kind, name, namespace)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	common.RunAndValidateTest(test)
+	return tester.GetAntiAffinityTerms() > 0 || tester.GetTopologySpreadConstraints() > 0
+}
+
+// testGracefulTermination checks that each pod under test sets its terminationGracePeriodSeconds, even to the default
+// value, in the pod template of its deployment or statefulset under test, or in its own spec otherwise, and that its
+// containers define a preStop hook, unless the pod declares that they handle SIGTERM.
+func testGracefulTermination(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestGracefulTerminationIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should set the grace period and handle the termination of the pods")
+		var badPods []string
+		for i := range env.PodsUnderTest {
+			pod := &env.PodsUnderTest[i]
+			tester := getTermination(termination.PodKind, pod.Name, pod.Namespace)
+			owner := tester
+			if kind, name := getOwnerWorkload(env, pod); kind != "" {
+				owner = getTermination(kind, name, pod.Namespace)
+			}
+			bad := false
+			if !owner.IsGracePeriodSet() {
+				log.Errorf("The pod %s does not set its terminationGracePeriodSeconds, it is defaulted", pod.FullName())
+				bad = true
+			}
+			if noPreStop := getTestedContainers(env, testID, pod, tester.GetContainersWithoutPreStop()); len(noPreStop) > 0 &&
+				!pod.SIGTERMHandler {
+				log.Errorf("The containers %s of the pod %s define no preStop hook, and the pod does not declare that "+
+					"they handle SIGTERM", strings.Join(noPreStop, ", "), pod.FullName())
+				bad = true
+			}
+			if bad {
+				badPods = append(badPods, pod.FullName())
+			}
+		}
+		results.RecordFailedTargets(badPods...)
+		gomega.Expect(badPods).To(gomega.BeEmpty())
+	})
+}
+
+// getTestedContainers returns the containers of pod among names which are not opted out of the test case testID.
+func getTestedContainers(env *config.TestEnvironment, testID string, pod *configsections.Pod, names []string) []string {
+	var tested []string
+	for _, name := range names {
+		if !env.SkipsContainerTest(testID, &configsections.ContainerIdentifier{Namespace: pod.Namespace, PodName: pod.Name,
+			ContainerName: name}) {
+			tested = append(tested, name)
+		}
+	}
+	return tested
+}
+
+// testTerminationTime deletes one pod under test owned by a deployment or a statefulset, and checks that it shuts down
+// before the end of its grace period, i.e. that it is not killed.
+func testTerminationTime(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestTerminationTimeIdentifier)
+	ginkgo.It(testID, func() {
+		common.SkipUnlessAllowed(identifiers.GetClassification(identifiers.TestTerminationTimeIdentifier))
+		pod := getReplicatedPod(env)
+		if pod == nil {
+			ginkgo.Skip("No pod under test owned by a deployment or a statefulset found.")
+		}
+		ginkgo.By(fmt.Sprintf("Should shut down the pod %s within its grace period", pod.FullName()))
+		if _, notReadyDeployments := getDeployments(pod.Namespace); len(notReadyDeployments) != 0 {
+			ginkgo.Skip("Can not test when deployments are not ready")
+		}
+		gracePeriod := defaultTerminationGracePeriod
+		if seconds := getTermination(termination.PodKind, pod.Name, pod.Namespace).GetGracePeriod(); seconds != nil {
+			gracePeriod = *seconds
+		}
+		context := common.GetContext()
+		defer env.SetNeedsRefresh()
+		// the sessions to the containers of the pod are lost with it.
+		env.ResetOc()
+		timeout := common.GetTimeout(common.LifecycleTestKey, "deletepod") + time.Duration(gracePeriod)*time.Second
+		start := time.Now()
+		common.ExecuteCommand(fmt.Sprintf("oc delete pod -n %s %s --wait=true", pod.Namespace, pod.Name), timeout,
+			context, nil)
+		shutdown := time.Since(start)
+		terminationTime = &TerminationTime{
+			Pod:                pod.FullName(),
+			GracePeriodSeconds: gracePeriod,
+			ShutdownSeconds:    shutdown.Seconds(),
+			Killed:             shutdown >= time.Duration(gracePeriod)*time.Second,
+		}
+		log.Infof("The pod %s shut down in %.1fs, its grace period is %ds", pod.FullName(), shutdown.Seconds(), gracePeriod)
+		// wait for the replacement of the pod, otherwise it might be unreachable during the next discovery.
+		waitForAllDeploymentsReady(pod.Namespace, env.Config.Timeouts.GetWait(podRecoveryTimeoutKey, podRecoveryTimeout),
+			scalingPollingPeriod)
+		if terminationTime.Killed {
+			results.RecordFailedTargets(pod.FullName())
+		}
+		gomega.Expect(terminationTime.Killed).To(gomega.BeFalse())
+	})
+}
+
+// getReplicatedPod returns the first pod under test whose labels match the pod template of a deployment or a
+// statefulset under test, and which is thus replaced once deleted, nil when there is none.
+func getReplicatedPod(env *config.TestEnvironment) *configsections.Pod {
+	for i := range env.PodsUnderTest {
+		if kind, _ := getOwnerWorkload(env, &env.PodsUnderTest[i]); kind != "" {
+			return &env.PodsUnderTest[i]
+		}
+	}
+	return nil
+}
+
+// isOwnedBy returns true when pod is in namespace and has the podLabels of the pod template of a workload.
+func isOwnedBy(pod *configsections.Pod, namespace string, podLabels map[string]string) bool {
+	if pod.Namespace != namespace || len(podLabels) == 0 {
+		return false
+	}
+	selector := configsections.LabelSelector{MatchLabels: podLabels}
+	return selector.Matches(pod.Labels)
+}
+
+// getOwnerWorkload returns the kind and the name of the deployment or statefulset under test owning pod, empty when
+// there is none.
+func getOwnerWorkload(env *config.TestEnvironment, pod *configsections.Pod) (kind, name string) {
+	for _, deployment := range env.DeploymentsUnderTest {
+		if isOwnedBy(pod, deployment.Namespace, deployment.PodLabels) {
+			return deploymentKind, deployment.Name
+		}
+	}
+	for _, statefulSet := range env.StatefulSetsUnderTest {
+		if isOwnedBy(pod, statefulSet.Namespace, statefulSet.PodLabels) {
+			return statefulSetKind, statefulSet.Name
+		}
+	}
+	return "", ""
+}
+
+// getTermination reads the termination settings of the pod, or of the pod template of the workload, of resourceType.
+func getTermination(resourceType, name, namespace string) *termination.Termination {
+	context := common.GetContext()
+	tester := termination.NewTermination(common.GetTimeout(common.LifecycleTestKey, "termination"), resourceType, name, namespace)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	common.RunAndValidateTest(test)
+	return tester
+}
+
+// testPodDeleteRecovery deletes one pod of each deployment under test, one deployment at a time, and checks that the
+// deployment replaces it and is ready again within the configured timeout.
+func testPodDeleteRecovery(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodDeleteRecoveryIdentifier)
+	ginkgo.It(testID, func() {
+		common.SkipUnlessAllowed(identifiers.GetClassification(identifiers.TestPodDeleteRecoveryIdentifier))
+		if len(env.DeploymentsUnderTest) == 0 {
+			ginkgo.Skip("No test deployments found.")
+		}
+		ginkgo.By("Should replace a deleted pod of each deployment in time")
+		context := common.GetContext()
+		for _, deployment := range env.DeploymentsUnderTest {
+			if _, notReadyDeployments := getDeploymentsWithContext(context, deployment.Namespace); len(notReadyDeployments) != 0 {
+				ginkgo.Skip("Can not test when deployments are not ready")
+			}
+		}
+		podDeleteRecoveries = nil
+		timeout := env.Config.Timeouts.GetWait(podRecoveryTimeoutKey, podRecoveryTimeout)
+		var badDeployments []string
+		defer env.SetNeedsRefresh()
+		// the sessions to the containers of the deleted pods are lost with them.
+		env.ResetOc()
+		for _, deployment := range env.DeploymentsUnderTest {
+			name := deployment.Namespace + "/" + deployment.Name
+			pod := getDeploymentPod(context, env, deployment)
+			if pod == nil {
+				log.Warnf("No pod under test of the deployment %s found, it is not tested", name)
+				continue
+			}
+			recovery := deletePodAndWait(context, deployment, pod, timeout)
+			log.Infof("Deleted the pod %s of the deployment %s, recovered: %t after %.1fs", recovery.DeletedPod, name,
+				recovery.Recovered, recovery.RecoverySeconds)
+			if !recovery.Recovered {
+				badDeployments = append(badDeployments, name)
+			}
+			podDeleteRecoveries = append(podDeleteRecoveries, recovery)
+		}
+		results.RecordFailedTargets(badDeployments...)
+		gomega.Expect(badDeployments).To(gomega.BeEmpty())
+	})
+}
+
+// getDeploymentPod returns the first pod under test of the deployment, with its UID, nil when there is none.
+func getDeploymentPod(context *interactive.Context, env *config.TestEnvironment, deployment configsections.Deployment) *podreadiness.Pod {
+	pods := getPodReadiness(context, deployment.Namespace)
+	for i := range env.PodsUnderTest {
+		podUnderTest := &env.PodsUnderTest[i]
+		if !isOwnedBy(podUnderTest, deployment.Namespace, deployment.PodLabels) {
+			continue
+		}
+		for j := range pods {
+			if pods[j].Name == podUnderTest.Name {
+				return &pods[j]
+			}
+		}
+	}
+	return nil
+}
+
+// deletePodAndWait deletes pod of deployment and waits until it is gone and the deployment is ready again, at most
+// timeout.
+func deletePodAndWait(context *interactive.Context, deployment configsections.Deployment, pod *podreadiness.Pod,
+	timeout time.Duration) PodDeleteRecovery {
+	start := time.Now()
+	command := fmt.Sprintf("oc delete pod -n %s --wait=false %s", deployment.Namespace, pod.Name)
+	common.ExecuteCommand(command, common.GetTimeout(common.LifecycleTestKey, "deletepods"), context, nil)
+	recovered := false
+	for !recovered && time.Since(start) <= timeout {
+		time.Sleep(convergencePollingPeriod)
+		recovered = isRecovered(context, deployment, pod.UID)
+	}
+	return PodDeleteRecovery{
+		Deployment:      deployment.Namespace + "/" + deployment.Name,
+		DeletedPod:      deployment.Namespace + "/" + pod.Name,
+		Recovered:       recovered,
+		RecoverySeconds: time.Since(start).Seconds(),
+	}
+}
+
+// isRecovered returns true when the pod of deletedUID is gone and the deployment is ready.
+func isRecovered(context *interactive.Context, deployment configsections.Deployment, deletedUID string) bool {
+	for _, pod := range getPodReadiness(context, deployment.Namespace) {
+		if pod.UID == deletedUID {
+			return false
+		}
+	}
+	deployments, notReadyDeployments := getDeploymentsWithContext(context, deployment.Namespace)
+	if _, ok := deployments[deployment.Name]; !ok {
+		return false
+	}
+	for _, name := range notReadyDeployments {
+		if name == deployment.Name {
+			return false
+		}
+	}
+	return true
+}
+
+// testUnmanagedPods checks that the chain of ownerReferences of each pod under test reaches a controller recreating
+// it after a node failure, see managedOwnerKinds.
+func testUnmanagedPods(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestUnmanagedPodsIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should manage the pods with a Deployment, a StatefulSet, a DaemonSet or a Job")
+		var badPods []string
+		for i := range env.PodsUnderTest {
+			pod := &env.PodsUnderTest[i]
+			chain, managed := getOwnerChain(pod)
+			if managed {
+				continue
+			}
+			if len(chain) == 0 {
+				log.Errorf("The pod %s has no owner, it is not recreated after a node failure", pod.FullName())
+			} else {
+				log.Errorf("The pod %s is owned by %s, none of which recreates it after a node failure", pod.FullName(),
+					strings.Join(chain, " -> "))
+			}
+			badPods = append(badPods, pod.FullName())
+		}
+		results.RecordFailedTargets(badPods...)
+		gomega.Expect(badPods).To(gomega.BeEmpty())
+	})
+}
+
+// getOwnerChain follows the controller owners of pod, e.g. its ReplicaSet then the Deployment of the ReplicaSet, and
+// returns them as kind/name, up to the first one of managedOwnerKinds.  managed is true when such an owner was found.
+func getOwnerChain(pod *configsections.Pod) (chain []string, managed bool) {
+	context := common.GetContext()
+	resourceType, name := "pod", pod.Name
+	for len(chain) < maxOwnerChainLength {
+		tester := ownerreferences.NewOwnerReferences(common.GetTimeout(common.LifecycleTestKey, "ownerreferences"),
+			resourceType, name, pod.Namespace)
+		test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+		gomega.Expect(err).To(gomega.BeNil())
+		common.RunAndValidateTest(test)
+		owner := tester.GetController()
+		if owner == nil {
+			return chain, false
+		}
+		chain = append(chain, owner.Kind+"/"+owner.Name)
+		if managedOwnerKinds[owner.Kind] {
+			return chain, true
+		}
+		resourceType, name = strings.ToLower(owner.Kind), owner.Name
+	}
+	return chain, false
+}
+
+// testImagePolicy checks the image reference and the imagePullPolicy of each container under test against the image
+// policies, each failing, warning or ignored as configured.
+func testImagePolicy(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestImagePolicyIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should pin the images of the containers and pull them accordingly")
+		imagePolicy := &env.Config.ImagePolicy
+		var badContainers []string
+		for i := range env.PodsUnderTest {
+			pod := &env.PodsUnderTest[i]
+			for _, container := range getContainerImages(pod.Name, pod.Namespace) {
+				if env.SkipsContainerTest(testID, &configsections.ContainerIdentifier{Namespace: pod.Namespace,
+					PodName: pod.Name, ContainerName: container.Name}) {
+					continue
+				}
+				name := pod.FullName() + "/" + container.Name
+				bad := applyImagePolicy(imagePolicy.GetLatestTagAction(), containerimages.IsLatest(container.Image),
+					fmt.Sprintf("The container %s uses the latest tag of the image %s", name, container.Image))
+				bad = applyImagePolicy(imagePolicy.GetMissingDigestAction(), !containerimages.HasDigest(container.Image),
+					fmt.Sprintf("The container %s does not pin the image %s by digest", name, container.Image)) || bad
+				bad = applyImagePolicy(imagePolicy.GetPullPolicyAction(),
+					!containerimages.MatchesPullPolicy(container.Image, container.PullPolicy),
+					fmt.Sprintf("The container %s pulls the image %s with the imagePullPolicy %s", name, container.Image,
+						container.PullPolicy)) || bad
+				if bad {
+					badContainers = append(badContainers, name)
+				}
+			}
+		}
+		results.RecordFailedTargets(badContainers...)
+		gomega.Expect(badContainers).To(gomega.BeEmpty())
+	})
+}
+
+// applyImagePolicy logs msg when the policy is broken, as a warning when its action is ImagePolicyWarn, and returns true
+// when its action is ImagePolicyFail.
+func applyImagePolicy(action configsections.ImagePolicyAction, broken bool, msg string) bool {
+	if !broken {
+		return false
+	}
+	switch action {
+	case configsections.ImagePolicyFail:
+		log.Error(msg)
+		return true
+	case configsections.ImagePolicyWarn:
+		log.Warn(msg)
+		if _, err := ginkgo.GinkgoWriter.Write([]byte(msg + "\n")); err != nil {
+			log.Errorf("Ginkgo writer could not write because: %s", err)
+		}
+	}
+	return false
+}
+
+// getContainerImages reads the image references and the imagePullPolicy of the containers of the pod.
+func getContainerImages(podName, podNamespace string) []containerimages.ContainerImage {
+	context := common.GetContext()
+	tester := containerimages.NewContainerImages(common.GetTimeout(common.LifecycleTestKey, "containerimages"), podName,
+		podNamespace)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	common.RunAndValidateTest(test)
+	return tester.GetContainers()
+}
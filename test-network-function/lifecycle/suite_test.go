@@ -0,0 +1,65 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func Test_getSkippedContainers(t *testing.T) {
+	app := configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test-0", ContainerName: "app"}
+	sidecar := configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test-0", ContainerName: "istio-proxy"}
+	pod := configsections.Pod{Namespace: "tnf", Name: "test-0", ContainerCount: 2}
+	env := &config.TestEnvironment{ContainersUnderTest: map[configsections.ContainerIdentifier]*config.Container{
+		app:     {ContainerIdentifier: app},
+		sidecar: {ContainerIdentifier: sidecar},
+	}}
+	env.Config.ContainerTestSkips = configsections.ContainerTestSkips{"lifecycle-container-shutdown": {"tnf/*/istio-proxy"}}
+
+	// the opted out sidecar is not tested, the other container is.
+	skipped, tested := getSkippedContainers(env, "lifecycle-container-shutdown", &pod)
+	assert.Equal(t, []string{"istio-proxy"}, skipped)
+	assert.True(t, tested)
+
+	skipped, tested = getSkippedContainers(env, "lifecycle-liveness-probe", &pod)
+	assert.Empty(t, skipped)
+	assert.True(t, tested)
+
+	// the pod is not tested when all its containers are opted out.
+	env.Config.ContainerTestSkips["lifecycle-container-shutdown"] = []string{"tnf/test-0/*"}
+	skipped, tested = getSkippedContainers(env, "lifecycle-container-shutdown", &pod)
+	assert.Equal(t, []string{"app", "istio-proxy"}, skipped)
+	assert.False(t, tested)
+}
+
+func Test_checkPodDisruptionBudget(t *testing.T) {
+	labels := map[string]string{"app": "test"}
+	budgets := []configsections.PodDisruptionBudget{{Namespace: "tnf", Name: "test",
+		Selector: &configsections.LabelSelector{MatchLabels: labels}, MinAvailable: "1"}}
+
+	// a single replica is not checked, covered or not.
+	assert.ErrorIs(t, checkPodDisruptionBudget(budgets, "tnf", 1, labels), configsections.ErrSingleReplica)
+	assert.ErrorIs(t, checkPodDisruptionBudget(nil, "tnf", 1, labels), configsections.ErrSingleReplica)
+
+	assert.Nil(t, checkPodDisruptionBudget(budgets, "tnf", 2, labels))
+	assert.NotNil(t, checkPodDisruptionBudget(nil, "tnf", 2, labels))
+	assert.NotNil(t, checkPodDisruptionBudget(append(budgets, budgets[0]), "tnf", 2, labels))
+}
@@ -17,9 +17,16 @@
 package networking
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 
 	"github.com/test-network-function/test-network-function/test-network-function/common"
@@ -28,18 +35,74 @@ import (
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/conntrack"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/dns"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/iperf3"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodeport"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ping"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/sctp"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+	"github.com/test-network-function/test-network-function/pkg/utils"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
 )
 
 const (
 	defaultNumPings = 5
+
+	// clusterNetworksCommand prints the cluster and service networks of an OpenShift cluster.
+	clusterNetworksCommand = `oc get network.config.openshift.io cluster -o ` +
+		`jsonpath='{.status.clusterNetwork[*].cidr} {.status.serviceNetwork[*]}'`
+)
+
+var (
+	// ipFamilies are the address families of the connectivity tests, dual-stack containers are tested with both.
+	ipFamilies = []string{utils.IPv4Family, utils.IPv6Family}
+	// icmpIdentifiers are the connectivity test identifiers per address family.
+	icmpIdentifiers = map[string]claim.Identifier{
+		utils.IPv4Family: identifiers.TestICMPv4ConnectivityIdentifier,
+		utils.IPv6Family: identifiers.TestICMPv6ConnectivityIdentifier,
+	}
+	// throughputProtocols are the protocols of the throughput measurements, one after the other.
+	throughputProtocols = []string{iperf3.TCP, iperf3.UDP}
 )
 
+// Throughput is a throughput measurement from a container under test to the partner pod.
+type Throughput struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Address   string `json:"address"`
+	Protocol  string `json:"protocol"`
+	iperf3.Measurement
+}
+
+// throughput holds the measurements of the throughput test.
+var throughput []Throughput
+
+// GetThroughput returns the measurements of the throughput test, empty unless the test ran.
+func GetThroughput() []Throughput {
+	return throughput
+}
+
+// EgressDestination is an external destination reached by a pod under test during the observation window.
+type EgressDestination struct {
+	Pod         string `json:"pod"`
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Port        int    `json:"port,omitempty"`
+	Allowed     bool   `json:"allowed"`
+}
+
+// egress holds the inventory of the egress destinations test.
+var egress []EgressDestination
+
+// GetEgress returns the inventory of the egress destinations test, empty unless the test ran.
+func GetEgress() []EgressDestination {
+	return egress
+}
+
 //
 // All actual test code belongs below here.  Utilities belong above.
 //
@@ -50,7 +113,7 @@ var _ = ginkgo.Describe(common.NetworkingTestKey, func() {
 	if testcases.IsInFocus(conf.FocusStrings, common.NetworkingTestKey) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
-			env.LoadAndRefresh()
+			gomega.Expect(env.LoadAndRefresh()).To(gomega.Succeed())
 			gomega.Expect(len(env.PodsUnderTest)).ToNot(gomega.Equal(0))
 			gomega.Expect(len(env.ContainersUnderTest)).ToNot(gomega.Equal(0))
 		})
@@ -59,92 +122,702 @@ var _ = ginkgo.Describe(common.NetworkingTestKey, func() {
 
 		ginkgo.Context("Both Pods are on the Default network", func() {
 			// for each container under test, ensure bidirectional ICMP traffic between the container and the orchestrator.
-			testDefaultNetworkConnectivity(env, defaultNumPings)
+			for _, family := range ipFamilies {
+				testDefaultNetworkConnectivity(env, family, defaultNumPings)
+			}
 		})
 
 		ginkgo.Context("Both Pods are connected via a Multus Overlay Network", func() {
-			// Unidirectional test;  for each container under test, attempt to ping the target Multus IP addresses.
-			testMultusNetworkConnectivity(env, defaultNumPings)
+			// Unidirectional test;  on each secondary network, the first pod under test attached pings the other ones.
+			for _, family := range ipFamilies {
+				testMultusNetworkConnectivity(env, family, defaultNumPings)
+			}
+		})
+		ginkgo.Context("Both Pods are on the Default network and the CNF requires SCTP", func() {
+			testSCTPConnectivity(env)
+		})
+		ginkgo.Context("Each Pod resolves the cluster DNS service and the configured FQDNs", func() {
+			testDNSResolution(env)
+		})
+		ginkgo.Context("Both Pods are on the Default network and load-generating tests are allowed", func() {
+			testThroughput(env)
 		})
 		ginkgo.Context("Should not have type of nodePort", func() {
 			testNodePort(env)
 		})
+		ginkgo.Context("Pods are only exposed through the declared Services and ports", func() {
+			testServiceExposure(env)
+			testNetworkPolicyPorts(env)
+		})
+		ginkgo.Context("Pods only reach the allowed external destinations", func() {
+			testEgressDestinations(env)
+		})
 	}
 })
 
-func testDefaultNetworkConnectivity(env *config.TestEnvironment, count int) {
+func testDefaultNetworkConnectivity(env *config.TestEnvironment, family string, count int) {
 	ginkgo.When("Testing network connectivity", func() {
-		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestICMPv4ConnectivityIdentifier)
+		testID := identifiers.XformToGinkgoItIdentifier(icmpIdentifiers[family])
 		ginkgo.It(testID, func() {
 			if env.TestOrchestrator == nil {
 				ginkgo.Skip("Orchestrator is not deployed, skip this test")
 			}
-			found := false
-			for _, cut := range env.ContainersUnderTest {
-				if _, ok := env.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; ok {
-					continue
-				}
-				found = true
-				context := cut.Oc
-				testOrchestrator := env.TestOrchestrator
-				ginkgo.By(fmt.Sprintf("a Ping is issued from %s(%s) to %s(%s) %s", testOrchestrator.Oc.GetPodName(),
+			testOrchestrator := env.TestOrchestrator
+			orchestratorIPAddress := testOrchestrator.GetDefaultNetworkIPAddress(family)
+			if orchestratorIPAddress == "" {
+				ginkgo.Skip(fmt.Sprintf("Orchestrator has no %s address, skip this test", family))
+			}
+			cuts := getConnectivityContainers(env, testID, family)
+			if len(cuts) == 0 {
+				ginkgo.Skip(fmt.Sprintf("No container with an %s address found suitable for connectivity test", family))
+			}
+			// the pings from the orchestrator run on a pool of orchestrator sessions, the pings from each container under
+			// test on its own session.
+			common.RunInParallelWithSpawner(len(cuts), common.SpawnOcContextFunc(testOrchestrator.Oc), func(i int, context *interactive.Context) error {
+				cut := cuts[i]
+				cutIPAddress := cut.GetDefaultNetworkIPAddress(family)
+				log.Infof("a Ping is issued from %s(%s) to %s(%s) %s", testOrchestrator.Oc.GetPodName(),
 					testOrchestrator.Oc.GetPodContainerName(), cut.Oc.GetPodName(), cut.Oc.GetPodContainerName(),
-					cut.DefaultNetworkIPAddress))
-				testPing(testOrchestrator.Oc, cut.DefaultNetworkIPAddress, count)
-				ginkgo.By(fmt.Sprintf("a Ping is issued from %s(%s) to %s(%s) %s", cut.Oc.GetPodName(),
+					cutIPAddress)
+				if err := runPing(context, testOrchestrator.Oc.GetPodName(), cutIPAddress, count); err != nil {
+					return err
+				}
+				log.Infof("a Ping is issued from %s(%s) to %s(%s) %s", cut.Oc.GetPodName(),
 					cut.Oc.GetPodContainerName(), testOrchestrator.Oc.GetPodName(), testOrchestrator.Oc.GetPodContainerName(),
-					testOrchestrator.DefaultNetworkIPAddress))
-				testPing(context, testOrchestrator.DefaultNetworkIPAddress, count)
+					orchestratorIPAddress)
+				return runPing(interactive.NewContext(cut.Oc.GetExpecter(), cut.Oc.GetErrorChannel()), cut.Oc.GetPodName(),
+					orchestratorIPAddress, count)
+			})
+		})
+	})
+}
+
+func testMultusNetworkConnectivity(env *config.TestEnvironment, family string, count int) {
+	ginkgo.When("Testing network connectivity", func() {
+		testID := identifiers.XformToGinkgoItIdentifier(icmpIdentifiers[family])
+		ginkgo.It(testID, func() {
+			cuts := getConnectivityContainers(env, testID, "")
+			if len(cuts) == 0 {
+				ginkgo.Skip("No container found suitable for Multus connectivity test")
 			}
-			if !found {
-				ginkgo.Skip("No container found suitable for connectivity test")
+			if pings := getMultusPings(cuts, family); len(pings) > 0 {
+				networkTypes := getNetworkTypes(env)
+				common.ForEachInParallel(len(pings), func(i int) error {
+					source := pings[i].source
+					context := interactive.NewContext(source.Oc.GetExpecter(), source.Oc.GetErrorChannel())
+					for _, target := range pings[i].targets {
+						log.Infof("a Ping is issued from %s(%s) to %s on network %s(%s)", source.Oc.GetPodName(),
+							source.Oc.GetPodContainerName(), target.address, target.network, networkTypes[target.network])
+						if err := runPing(context, source.Oc.GetPodName(), target.address, count); err != nil {
+							return fmt.Errorf("network %s: %w", target.network, err)
+						}
+					}
+					return nil
+				})
+				return
 			}
+			// without network attachments, e.g. when the Multus IPs are set with the multusips annotation, the Multus
+			// IPs are pinged from the orchestrator.
+			if env.TestOrchestrator == nil {
+				ginkgo.Skip("Orchestrator is not deployed, skip this test")
+			}
+			var targets []string
+			for _, cut := range cuts {
+				targets = append(targets, utils.FilterIPFamily(cut.ContainerConfiguration.MultusIPAddresses, family)...)
+			}
+			if len(targets) == 0 {
+				ginkgo.Skip(fmt.Sprintf("No Multus %s IPs detected", family))
+			}
+			testOrchestrator := env.TestOrchestrator
+			common.RunInParallelWithSpawner(len(targets), common.SpawnOcContextFunc(testOrchestrator.Oc), func(i int, context *interactive.Context) error {
+				log.Infof("a Ping is issued from %s(%s) to %s", testOrchestrator.Oc.GetPodName(),
+					testOrchestrator.Oc.GetPodContainerName(), targets[i])
+				return runPing(context, testOrchestrator.Oc.GetPodName(), targets[i], count)
+			})
 		})
 	})
 }
 
-func testMultusNetworkConnectivity(env *config.TestEnvironment, count int) {
-	ginkgo.When("Testing network connectivity", func() {
-		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestICMPv4ConnectivityIdentifier)
+// multusTarget is an address of a pod on a secondary (Multus) network.
+type multusTarget struct {
+	network string
+	address string
+}
+
+// multusPings are the pings issued by a container under test to the other pods on its secondary networks.
+type multusPings struct {
+	source  *config.Container
+	targets []multusTarget
+}
+
+// getMultusPings returns the pings testing the secondary networks of family shared by several pods: on each network,
+// the first pod attached pings the others.  The containers of a pod share its network interfaces, so only the first
+// container of each pod is used.
+func getMultusPings(cuts []*config.Container, family string) []multusPings {
+	sort.Slice(cuts, func(i, j int) bool {
+		return fmt.Sprint(cuts[i].ContainerIdentifier) < fmt.Sprint(cuts[j].ContainerIdentifier)
+	})
+	pods := map[string]bool{}
+	var networks []string
+	members := map[string][]*config.Container{}
+	addresses := map[*config.Container]map[string]string{}
+	for _, cut := range cuts {
+		pod := cut.ContainerIdentifier.Namespace + "/" + cut.ContainerIdentifier.PodName
+		if pods[pod] {
+			continue
+		}
+		pods[pod] = true
+		for _, attachment := range cut.ContainerConfiguration.NetworkAttachments {
+			familyAddresses := utils.FilterIPFamily(attachment.IPAddresses, family)
+			if len(familyAddresses) == 0 {
+				continue
+			}
+			if _, ok := members[attachment.Network]; !ok {
+				networks = append(networks, attachment.Network)
+			}
+			members[attachment.Network] = append(members[attachment.Network], cut)
+			if addresses[cut] == nil {
+				addresses[cut] = map[string]string{}
+			}
+			addresses[cut][attachment.Network] = familyAddresses[0]
+		}
+	}
+	var pings []multusPings
+	sources := map[*config.Container]int{}
+	for _, network := range networks {
+		if len(members[network]) < 2 {
+			log.Infof("only one pod under test is attached to network %s, skipping it", network)
+			continue
+		}
+		source := members[network][0]
+		i, ok := sources[source]
+		if !ok {
+			i = len(pings)
+			sources[source] = i
+			pings = append(pings, multusPings{source: source})
+		}
+		for _, target := range members[network][1:] {
+			pings[i].targets = append(pings[i].targets, multusTarget{network: network, address: addresses[target][network]})
+		}
+	}
+	return pings
+}
+
+// getNetworkTypes returns the CNI plugin types of the discovered NetworkAttachmentDefinitions, by "namespace/name".
+func getNetworkTypes(env *config.TestEnvironment) map[string]string {
+	types := map[string]string{}
+	for i := range env.Config.NetworkAttachmentDefinitions {
+		definition := &env.Config.NetworkAttachmentDefinitions[i]
+		types[definition.FullName()] = definition.Type
+	}
+	return types
+}
+
+// getConnectivityContainers returns the containers under test that are not excluded from the connectivity tests, nor
+// opted out of the test case testID, and which have a default network address of family unless it is empty.
+func getConnectivityContainers(env *config.TestEnvironment, testID, family string) []*config.Container {
+	var cuts []*config.Container
+	for _, cut := range env.ContainersUnderTest {
+		if _, ok := env.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; ok {
+			continue
+		}
+		if env.SkipsContainerTest(testID, &cut.ContainerIdentifier) {
+			continue
+		}
+		if family != "" && cut.GetDefaultNetworkIPAddress(family) == "" {
+			continue
+		}
+		cuts = append(cuts, cut)
+	}
+	return cuts
+}
+
+// strictPing is a ping test failing when a packet is lost or an error is reported, unlike ping.Ping which succeeds
+// when a response is received, so that such an attempt is retried.
+type strictPing struct {
+	*ping.Ping
+}
+
+// Result returns FAILURE instead of SUCCESS when some packets were lost or errors were reported.
+func (p strictPing) Result() int {
+	result := p.Ping.Result()
+	if transmitted, received, errors := p.GetStats(); result == tnf.SUCCESS && (received != transmitted || errors != 0) {
+		return tnf.FAILURE
+	}
+	return result
+}
+
+// runPing tests that a container can ping a target IP address without losing packets, using a session to the
+// initiating pod.
+func runPing(context *interactive.Context, initiatingPodName, targetPodIPAddress string, count int) error {
+	log.Infof("Sending ICMP traffic(%s to %s)", initiatingPodName, targetPodIPAddress)
+	var pingTester *ping.Ping
+	result, err := common.RunWithRetry(common.NetworkingTestKey, "ping", func() (*tnf.Test, error) {
+		pingTester = ping.NewPing(common.GetTimeout(common.NetworkingTestKey, "ping"), targetPodIPAddress, count)
+		return tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), strictPing{pingTester}, []reel.Handler{pingTester},
+			context.GetErrorChannel())
+	})
+	if err != nil {
+		return fmt.Errorf("ping from %s to %s: %w", initiatingPodName, targetPodIPAddress, err)
+	}
+	if result != tnf.SUCCESS {
+		transmitted, received, errors := pingTester.GetStats()
+		return fmt.Errorf("ping from %s to %s failed with result %d: %d packets transmitted, %d received, %d errors",
+			initiatingPodName, targetPodIPAddress, result, transmitted, received, errors)
+	}
+	return nil
+}
+
+func testSCTPConnectivity(env *config.TestEnvironment) {
+	ginkgo.When("Testing SCTP connectivity", func() {
+		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestSCTPConnectivityIdentifier)
 		ginkgo.It(testID, func() {
+			if !env.Config.PlatformRequirements.SCTP {
+				ginkgo.Skip("The CNF does not require SCTP in the platformRequirements section of the configuration")
+			}
 			if env.TestOrchestrator == nil {
 				ginkgo.Skip("Orchestrator is not deployed, skip this test")
 			}
-			found := false
-			for _, cut := range env.ContainersUnderTest {
-				if _, ok := env.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; ok {
+			testOrchestrator := env.TestOrchestrator
+			context := interactive.NewContext(testOrchestrator.Oc.GetExpecter(), testOrchestrator.Oc.GetErrorChannel())
+			pid, err := startSCTPServer(context)
+			if errors.Is(err, errNoSCTPServer) {
+				ginkgo.Skip("The partner pod " + err.Error())
+			}
+			gomega.Expect(err).To(gomega.BeNil())
+			defer stopSCTPServer(context, pid)
+			// the address families each container under test shares with the partner pod.
+			families := map[*config.Container][]string{}
+			var cuts []*config.Container
+			for _, family := range ipFamilies {
+				if testOrchestrator.GetDefaultNetworkIPAddress(family) == "" {
 					continue
 				}
-				found = true
-				if len(cut.ContainerConfiguration.MultusIPAddresses) == 0 {
-					ginkgo.Skip("No Multus IPs detected")
+				for _, cut := range getConnectivityContainers(env, testID, family) {
+					if _, ok := families[cut]; !ok {
+						cuts = append(cuts, cut)
+					}
+					families[cut] = append(families[cut], family)
 				}
+			}
+			if len(cuts) == 0 {
+				ginkgo.Skip("No container found suitable for SCTP connectivity test")
+			}
+			// the probes of the partner pod run on a pool of partner sessions, the ones of each container under test
+			// on its own session.
+			common.RunInParallelWithSpawner(len(cuts), common.SpawnOcContextFunc(testOrchestrator.Oc), func(i int, partnerContext *interactive.Context) error {
+				return testSCTPAssociations(testOrchestrator, cuts[i], families[cuts[i]], partnerContext)
+			})
+		})
+	})
+}
 
-				for _, multusIPAddress := range cut.ContainerConfiguration.MultusIPAddresses {
-					testOrchestrator := env.TestOrchestrator
-					ginkgo.By(fmt.Sprintf("a Ping is issued from %s(%s) to %s(%s) %s", testOrchestrator.Oc.GetPodName(),
-						testOrchestrator.Oc.GetPodContainerName(), cut.Oc.GetPodName(), cut.Oc.GetPodContainerName(),
-						cut.DefaultNetworkIPAddress))
-					testPing(testOrchestrator.Oc, multusIPAddress, count)
+// testSCTPAssociations tests the SCTP associations between cut and the partner pod over families, in both directions:
+// from cut to the server of the partner pod, then from the partner pod, with partnerContext, to a server started in
+// cut.
+func testSCTPAssociations(partner, cut *config.Container, families []string, partnerContext *interactive.Context) error {
+	cutContext := interactive.NewContext(cut.Oc.GetExpecter(), cut.Oc.GetErrorChannel())
+	for _, family := range families {
+		address := partner.GetDefaultNetworkIPAddress(family)
+		log.Infof("an SCTP probe is sent from %s(%s) to %s(%s) %s", cut.Oc.GetPodName(), cut.Oc.GetPodContainerName(),
+			partner.Oc.GetPodName(), partner.Oc.GetPodContainerName(), address)
+		if err := runSCTPClient(cutContext, cut.Oc.GetPodName(), address); err != nil {
+			return err
+		}
+	}
+	pid, err := startSCTPServer(cutContext)
+	if err != nil {
+		return fmt.Errorf("SCTP to %s(%s): the container %w", cut.Oc.GetPodName(), cut.Oc.GetPodContainerName(), err)
+	}
+	defer stopSCTPServer(cutContext, pid)
+	for _, family := range families {
+		address := cut.GetDefaultNetworkIPAddress(family)
+		log.Infof("an SCTP probe is sent from %s(%s) to %s(%s) %s", partner.Oc.GetPodName(),
+			partner.Oc.GetPodContainerName(), cut.Oc.GetPodName(), cut.Oc.GetPodContainerName(), address)
+		if err := runSCTPClient(partnerContext, partner.Oc.GetPodName(), address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errNoSCTPServer is returned by startSCTPServer when the server cannot run.
+var errNoSCTPServer = errors.New("cannot run an SCTP server, check it has ncat and the nodes the sctp module")
+
+// startSCTPServer starts the SCTP echo server in the background of a session, returning its PID.  It returns
+// errNoSCTPServer when the server cannot run, e.g. without ncat.
+func startSCTPServer(context *interactive.Context) (int, error) {
+	server := sctp.NewServer(common.GetTimeout(common.NetworkingTestKey, "sctp"), sctp.DefaultPort)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), server, []reel.Handler{server}, context.GetErrorChannel())
+	if err != nil {
+		return 0, err
+	}
+	result, err := test.Run()
+	switch {
+	case err != nil:
+		return 0, err
+	case result == tnf.ERROR:
+		return 0, errNoSCTPServer
+	case result != tnf.SUCCESS:
+		return 0, fmt.Errorf("the SCTP server did not start, result %d", result)
+	}
+	return server.GetPID(), nil
+}
+
+// stopSCTPServer stops the SCTP echo server with pid.
+func stopSCTPServer(context *interactive.Context, pid int) {
+	stop := sctp.NewStop(common.GetTimeout(common.NetworkingTestKey, "sctp"), pid)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), stop, []reel.Handler{stop}, context.GetErrorChannel())
+	if err == nil {
+		_, err = test.Run()
+	}
+	if err != nil {
+		log.Warnf("cannot stop the SCTP server %d: %s", pid, err)
+	}
+}
+
+// runSCTPClient tests that a container can establish an SCTP association with the server listening on address,
+// using a session to the initiating pod.
+func runSCTPClient(context *interactive.Context, initiatingPodName, address string) error {
+	result, err := common.RunWithRetry(common.NetworkingTestKey, "sctp", func() (*tnf.Test, error) {
+		client := sctp.NewClient(common.GetTimeout(common.NetworkingTestKey, "sctp"), address, sctp.DefaultPort)
+		return tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), client, []reel.Handler{client}, context.GetErrorChannel())
+	})
+	if err != nil {
+		return fmt.Errorf("SCTP from %s to %s: %w", initiatingPodName, address, err)
+	}
+	switch result {
+	case tnf.SUCCESS:
+		return nil
+	case tnf.ERROR:
+		return fmt.Errorf("SCTP from %s to %s: the container cannot run an SCTP client, check it has ncat",
+			initiatingPodName, address)
+	default:
+		return fmt.Errorf("SCTP from %s to %s: no echo received from port %d", initiatingPodName, address,
+			sctp.DefaultPort)
+	}
+}
+
+func testThroughput(env *config.TestEnvironment) {
+	ginkgo.When("Measuring the throughput", func() {
+		// a rerun of the spec, e.g. a flaky attempt, replaces the measurements instead of adding to them.
+		ginkgo.BeforeEach(func() {
+			throughput = nil
+		})
+		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestThroughputIdentifier)
+		ginkgo.It(testID, func() {
+			common.SkipUnlessLoadAllowed()
+			if env.TestOrchestrator == nil {
+				ginkgo.Skip("Orchestrator is not deployed, skip this test")
+			}
+			testOrchestrator := env.TestOrchestrator
+			context := interactive.NewContext(testOrchestrator.Oc.GetExpecter(), testOrchestrator.Oc.GetErrorChannel())
+			pid := startIperf3Server(context)
+			defer stopIperf3Server(context, pid)
+			// the server serves one client at a time, the measurements run one after the other.
+			var failedContainers []string
+			measured := false
+			for _, family := range ipFamilies {
+				address := testOrchestrator.GetDefaultNetworkIPAddress(family)
+				if address == "" {
+					continue
 				}
-				if !found {
-					ginkgo.Skip("No container found suitable for Multus connectivity test")
+				for _, cut := range getConnectivityContainers(env, testID, family) {
+					measured = true
+					cutContext := interactive.NewContext(cut.Oc.GetExpecter(), cut.Oc.GetErrorChannel())
+					for _, protocol := range throughputProtocols {
+						measurement, err := runIperf3Client(cutContext, cut.Oc.GetPodName(), address, protocol)
+						if err != nil {
+							log.Error(err)
+							failedContainers = append(failedContainers, cut.Oc.GetPodNamespace()+"/"+
+								cut.Oc.GetPodName()+"/"+cut.Oc.GetPodContainerName())
+							break
+						}
+						log.Infof("%s throughput from %s(%s) to %s(%s) %s: %+v", protocol, cut.Oc.GetPodName(),
+							cut.Oc.GetPodContainerName(), testOrchestrator.Oc.GetPodName(),
+							testOrchestrator.Oc.GetPodContainerName(), address, measurement)
+						throughput = append(throughput, Throughput{
+							Pod:         cut.Oc.GetPodName(),
+							Container:   cut.Oc.GetPodContainerName(),
+							Address:     address,
+							Protocol:    protocol,
+							Measurement: measurement,
+						})
+					}
 				}
 			}
+			if !measured {
+				ginkgo.Skip("No container found suitable for throughput test")
+			}
+			results.RecordFailedTargets(failedContainers...)
+			gomega.Expect(failedContainers).To(gomega.BeEmpty())
 		})
 	})
 }
 
-// Test that a container can ping a target IP address.
-func testPing(initiatingPodOc *interactive.Oc, targetPodIPAddress string, count int) {
-	log.Infof("Sending ICMP traffic(%s to %s)", initiatingPodOc.GetPodName(), targetPodIPAddress)
-	pingTester := ping.NewPing(common.DefaultTimeout, targetPodIPAddress, count)
-	test, err := tnf.NewTest(initiatingPodOc.GetExpecter(), pingTester, []reel.Handler{pingTester}, initiatingPodOc.GetErrorChannel())
+// startIperf3Server starts the iperf3 server in the background of a session, returning its PID.  The test is skipped
+// when the server cannot run, e.g. without iperf3.
+func startIperf3Server(context *interactive.Context) int {
+	server := iperf3.NewServer(common.GetTimeout(common.NetworkingTestKey, "iperf3"), iperf3.DefaultPort)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), server, []reel.Handler{server}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	result, err := test.Run()
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
-	transmitted, received, errors := pingTester.GetStats()
-	gomega.Expect(received).To(gomega.Equal(transmitted))
-	gomega.Expect(errors).To(gomega.BeZero())
+	if result == tnf.ERROR {
+		ginkgo.Skip("The partner pod cannot run an iperf3 server, check it has iperf3")
+	}
+	gomega.Expect(result).To(gomega.Equal(tnf.SUCCESS))
+	return server.GetPID()
+}
+
+// stopIperf3Server stops the iperf3 server with pid.
+func stopIperf3Server(context *interactive.Context, pid int) {
+	stop := iperf3.NewStop(common.GetTimeout(common.NetworkingTestKey, "iperf3"), pid)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), stop, []reel.Handler{stop}, context.GetErrorChannel())
+	if err == nil {
+		_, err = test.Run()
+	}
+	if err != nil {
+		log.Warnf("cannot stop the iperf3 server %d: %s", pid, err)
+	}
+}
+
+// runIperf3Client measures the throughput with protocol from a container to the server listening on address, using a
+// session to the initiating pod.
+func runIperf3Client(context *interactive.Context, initiatingPodName, address, protocol string) (iperf3.Measurement, error) {
+	var client *iperf3.Iperf3
+	result, err := common.RunWithRetry(common.NetworkingTestKey, "iperf3", func() (*tnf.Test, error) {
+		client = iperf3.NewClient(common.GetTimeout(common.NetworkingTestKey, "iperf3"), address, iperf3.DefaultPort,
+			protocol, iperf3.DefaultDuration)
+		return tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), client, []reel.Handler{client}, context.GetErrorChannel())
+	})
+	if err != nil {
+		return iperf3.Measurement{}, fmt.Errorf("%s throughput from %s to %s: %w", protocol, initiatingPodName, address, err)
+	}
+	switch result {
+	case tnf.SUCCESS:
+		return client.GetMeasurement(), nil
+	case tnf.ERROR:
+		return iperf3.Measurement{}, fmt.Errorf("%s throughput from %s to %s: the container cannot run an iperf3 "+
+			"client, check it has iperf3", protocol, initiatingPodName, address)
+	default:
+		return iperf3.Measurement{}, fmt.Errorf("%s throughput from %s to %s: no measurement from port %d", protocol,
+			initiatingPodName, address, iperf3.DefaultPort)
+	}
+}
+
+func testDNSResolution(env *config.TestEnvironment) {
+	ginkgo.When("Testing DNS resolution", func() {
+		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestDNSResolutionIdentifier)
+		ginkgo.It(testID, func() {
+			cuts := getDNSContainers(env, testID)
+			if len(cuts) == 0 {
+				ginkgo.Skip("No container found suitable for DNS resolution test")
+			}
+			names := append([]string{getClusterDNSService()}, env.Config.DNS.FQDNs...)
+			var mutex sync.Mutex
+			var failedPods []string
+			defer func() {
+				results.RecordFailedTargets(failedPods...)
+			}()
+			common.ForEachInParallel(len(cuts), func(i int) error {
+				cut := cuts[i]
+				cutContext := interactive.NewContext(cut.Oc.GetExpecter(), cut.Oc.GetErrorChannel())
+				err := checkDNSResolution(cutContext, cut, names)
+				if err != nil {
+					mutex.Lock()
+					failedPods = append(failedPods, cut.Oc.GetPodNamespace()+"/"+cut.Oc.GetPodName())
+					mutex.Unlock()
+				}
+				return err
+			})
+		})
+	})
+}
+
+// getClusterDNSService returns the name of the cluster DNS service, served by CoreDNS.
+func getClusterDNSService() string {
+	if common.IsMinikube() {
+		return dns.ClusterDNSServiceKubernetes
+	}
+	return dns.ClusterDNSServiceOpenShift
+}
+
+// getDNSContainers returns a container per pod under test, except the containers opted out of the test case testID,
+// the resolver configuration being the same for all the containers of a pod.
+func getDNSContainers(env *config.TestEnvironment, testID string) []*config.Container {
+	var cuts []*config.Container
+	pods := map[string]bool{}
+	for _, cut := range getConnectivityContainers(env, testID, "") {
+		pod := cut.Oc.GetPodNamespace() + "/" + cut.Oc.GetPodName()
+		if !pods[pod] {
+			pods[pod] = true
+			cuts = append(cuts, cut)
+		}
+	}
+	return cuts
+}
+
+// checkDNSResolution checks the resolver configuration of a container, and that it resolves names, using a session to
+// the container.  The cluster DNS service, names[0], is expected to be one of the nameservers, unless e.g. a node-local
+// DNS cache is used.
+func checkDNSResolution(context *interactive.Context, cut *config.Container, names []string) error {
+	pod := cut.Oc.GetPodNamespace() + "/" + cut.Oc.GetPodName()
+	timeout := common.GetTimeout(common.NetworkingTestKey, "dns")
+	resolvConf := dns.NewResolvConf(timeout)
+	if err := runDNSTest(context, resolvConf); err != nil {
+		return fmt.Errorf("DNS of %s: cannot read %s: %w", pod, dns.ResolvConfPath, err)
+	}
+	conf := resolvConf.GetResolvConf()
+	problems := conf.Check(cut.Oc.GetPodNamespace())
+	for i, name := range names {
+		lookup := dns.NewLookup(timeout, name)
+		if err := runDNSTest(context, lookup); err != nil {
+			if tnf.IsAborted(err) {
+				return err
+			}
+			problems = append(problems, fmt.Sprintf("cannot resolve %s: %s", name, err))
+			continue
+		}
+		log.Infof("%s(%s) resolves %s to %v", pod, cut.Oc.GetPodContainerName(), name, lookup.GetAddresses())
+		if i == 0 && !containsAny(conf.Nameservers, lookup.GetAddresses()) {
+			log.Warnf("%s does not use the cluster DNS service %s %v as nameserver, but %v", pod, name,
+				lookup.GetAddresses(), conf.Nameservers)
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("DNS of %s: %s", pod, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// runDNSTest runs a DNS test, returning an error unless it succeeded.
+func runDNSTest(context *interactive.Context, handler *dns.DNS) error {
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), handler, []reel.Handler{handler}, context.GetErrorChannel())
+	if err != nil {
+		return err
+	}
+	result, err := test.Run()
+	if err != nil {
+		return err
+	}
+	switch result {
+	case tnf.SUCCESS:
+		return nil
+	case tnf.FAILURE:
+		return errors.New("no address found")
+	default:
+		return fmt.Errorf("the container cannot run the test, check it has getent and %s", dns.ResolvConfPath)
+	}
+}
+
+// containsAny returns true when values contains one of candidates.
+func containsAny(values, candidates []string) bool {
+	for _, candidate := range candidates {
+		for _, value := range values {
+			if value == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// exposedPort is a declared port of a pod exposed by a Service.
+type exposedPort struct {
+	service *configsections.Service
+	port    configsections.ContainerPort
+}
+
+// getExposedPorts returns the declared ports of pod exposed by the Services, and the Service ports targeting no
+// declared port of pod.
+func getExposedPorts(pod *configsections.Pod, services []configsections.Service) (exposed []exposedPort, undeclared []string) {
+	for i := range services {
+		service := &services[i]
+		if !service.Selects(pod) {
+			continue
+		}
+		for j := range service.Ports {
+			servicePort := &service.Ports[j]
+			if port, ok := pod.FindPort(servicePort.GetTargetPort(), servicePort.Protocol); ok {
+				exposed = append(exposed, exposedPort{service: service, port: port})
+			} else {
+				undeclared = append(undeclared, fmt.Sprintf("%s port %d targets %s", service.FullName(), servicePort.Port,
+					servicePort.GetTargetPort()))
+			}
+		}
+	}
+	return exposed, undeclared
+}
+
+func testServiceExposure(env *config.TestEnvironment) {
+	ginkgo.When("Testing the Services exposing the pods", func() {
+		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestServiceExposureIdentifier)
+		ginkgo.It(testID, func() {
+			var badPods []string
+			for i := range env.PodsUnderTest {
+				pod := &env.PodsUnderTest[i]
+				if _, undeclared := getExposedPorts(pod, env.Config.Services); len(undeclared) > 0 {
+					log.Errorf("Pod %s is exposed on ports it does not declare: %s", pod.FullName(),
+						strings.Join(undeclared, ", "))
+					badPods = append(badPods, pod.FullName())
+				}
+			}
+			results.RecordFailedTargets(badPods...)
+			gomega.Expect(badPods).To(gomega.BeEmpty())
+		})
+	})
+}
+
+func testNetworkPolicyPorts(env *config.TestEnvironment) {
+	ginkgo.When("Testing the NetworkPolicies do not block the exposed ports", func() {
+		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestNetworkPolicyPortsIdentifier)
+		ginkgo.It(testID, func() {
+			if len(env.Config.NetworkPolicies) == 0 {
+				ginkgo.Skip(fmt.Sprintf("No NetworkPolicy found in namespace %s", env.NameSpaceUnderTest))
+			}
+			var badPods []string
+			for i := range env.PodsUnderTest {
+				pod := &env.PodsUnderTest[i]
+				var policies []*configsections.NetworkPolicy
+				for j := range env.Config.NetworkPolicies {
+					if policy := &env.Config.NetworkPolicies[j]; policy.RestrictsIngress(pod) {
+						policies = append(policies, policy)
+					}
+				}
+				if len(policies) == 0 {
+					continue
+				}
+				exposed, _ := getExposedPorts(pod, env.Config.Services)
+				if blocked := getBlockedPorts(pod, exposed, policies); len(blocked) > 0 {
+					log.Errorf("Pod %s is exposed on ports blocked by its NetworkPolicies: %s", pod.FullName(),
+						strings.Join(blocked, ", "))
+					badPods = append(badPods, pod.FullName())
+				}
+			}
+			results.RecordFailedTargets(badPods...)
+			gomega.Expect(badPods).To(gomega.BeEmpty())
+		})
+	})
+}
+
+// getBlockedPorts returns the exposed ports of pod that no ingress rule of the NetworkPolicies restricting its ingress
+// traffic allows.
+func getBlockedPorts(pod *configsections.Pod, exposed []exposedPort, policies []*configsections.NetworkPolicy) []string {
+	var blocked []string
+	for _, e := range exposed {
+		allowed := false
+		for _, policy := range policies {
+			if policy.AllowsIngress(pod, e.port) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			blocked = append(blocked, fmt.Sprintf("%s exposed by %s", e.port, e.service.FullName()))
+		}
+	}
+	return blocked
 }
 
 func testNodePort(env *config.TestEnvironment) {
@@ -152,9 +825,168 @@ func testNodePort(env *config.TestEnvironment) {
 	ginkgo.It(testID, func() {
 		context := common.GetContext()
 		ginkgo.By(fmt.Sprintf("Testing services in namespace %s", env.NameSpaceUnderTest))
-		tester := nodeport.NewNodePort(common.DefaultTimeout, env.NameSpaceUnderTest)
-		test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+		tester := nodeport.NewNodePort(common.GetTimeout(common.NetworkingTestKey, "nodeport"), env.NameSpaceUnderTest)
+		test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 		gomega.Expect(err).To(gomega.BeNil())
-		test.RunAndValidate()
+		common.RunAndValidateTest(test)
+	})
+}
+
+// egressNode is a node running pods under test, with their pod by address.
+type egressNode struct {
+	node *config.NodeConfig
+	pods map[string]string
+}
+
+// getEgressNodes returns the nodes running the pods under test which have a debug pod.
+func getEgressNodes(env *config.TestEnvironment) []egressNode {
+	byName := map[string]*egressNode{}
+	var nodes []egressNode
+	for id, cut := range env.ContainersUnderTest {
+		nodeName := cut.ContainerConfiguration.NodeName
+		entry, ok := byName[nodeName]
+		if !ok {
+			node, found := env.NodesUnderTest[nodeName]
+			if !found || !node.HasDebugPod() {
+				log.Warnf("Node %s has no debug pod, the connections of the pods it runs are not observed", nodeName)
+				byName[nodeName] = nil
+				continue
+			}
+			entry = &egressNode{node: node, pods: map[string]string{}}
+			byName[nodeName] = entry
+		}
+		if entry == nil {
+			continue
+		}
+		var addresses []string
+		addresses = append(addresses, cut.ContainerConfiguration.PodIPAddresses...)
+		addresses = append(addresses, cut.DefaultNetworkIPAddresses...)
+		addresses = append(addresses, cut.ContainerConfiguration.MultusIPAddresses...)
+		for _, address := range addresses {
+			if ip := net.ParseIP(address); ip != nil {
+				entry.pods[ip.String()] = id.Namespace + "/" + id.PodName
+			}
+		}
+	}
+	for _, entry := range byName {
+		if entry != nil {
+			nodes = append(nodes, *entry)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].node.Name < nodes[j].node.Name
+	})
+	return nodes
+}
+
+// getClusterNetworks returns the cluster and service networks, which are not external.  None are returned, with a
+// warning, when they cannot be read, e.g. on minikube.
+func getClusterNetworks() []string {
+	if common.IsMinikube() {
+		return nil
+	}
+	out, err := utils.ExecuteCommand(config.GetTestEnvironment().Context(), clusterNetworksCommand,
+		common.GetTimeout(common.NetworkingTestKey, "clusternetworks"), common.GetContext(), nil)
+	if err != nil {
+		log.Warnf("Cannot read the cluster networks, only the configured internal networks are not external: %v", err)
+		return nil
+	}
+	return strings.Fields(out)
+}
+
+// observeConnections returns the connections tracked on node during window.
+func observeConnections(node *config.NodeConfig, window time.Duration) ([]conntrack.Flow, error) {
+	tester := conntrack.NewConntrack(common.GetTimeout(common.NetworkingTestKey, "conntrack"), window)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), node.Oc.GetExpecter(), tester, []reel.Handler{tester}, node.Oc.GetErrorChannel())
+	if err != nil {
+		return nil, err
+	}
+	if err = test.RunAndCheck(nil); err != nil {
+		return nil, fmt.Errorf("cannot observe the connections on node %s, check it has conntrack: %w", node.Name, err)
+	}
+	return tester.GetFlows(), nil
+}
+
+// getEgressDestinations returns the external destinations of the flows sourced from the pods of node, once each.
+func getEgressDestinations(node *egressNode, flows []conntrack.Flow, egressConfig *configsections.Egress,
+	clusterNetworks []string) []EgressDestination {
+	var destinations []EgressDestination
+	seen := map[EgressDestination]bool{}
+	for _, flow := range flows {
+		pod, ok := node.pods[flow.Source.String()]
+		if !ok || !egressConfig.IsExternal(flow.Destination, clusterNetworks) {
+			continue
+		}
+		destination := EgressDestination{
+			Pod:         pod,
+			Protocol:    flow.Protocol,
+			Destination: flow.Destination.String(),
+			Port:        flow.Port,
+			Allowed:     egressConfig.Allows(flow.Protocol, flow.Destination, flow.Port),
+		}
+		if !seen[destination] {
+			seen[destination] = true
+			destinations = append(destinations, destination)
+		}
+	}
+	return destinations
+}
+
+func testEgressDestinations(env *config.TestEnvironment) {
+	ginkgo.When("Inventorying the external destinations of the pods", func() {
+		// a rerun of the spec, e.g. a flaky attempt, replaces the inventory instead of adding to it.
+		ginkgo.BeforeEach(func() {
+			egress = nil
+		})
+		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestEgressDestinationsIdentifier)
+		ginkgo.It(testID, func() {
+			nodes := getEgressNodes(env)
+			if len(nodes) == 0 {
+				ginkgo.Skip("No node running the pods under test has a debug pod")
+			}
+			clusterNetworks := getClusterNetworks()
+			window := env.Config.Egress.GetWindow()
+			ginkgo.By(fmt.Sprintf("Observing the connections on %d nodes for %s", len(nodes), window))
+			var mutex sync.Mutex
+			var failedPods []string
+			defer func() {
+				sort.Slice(egress, func(i, j int) bool {
+					a, b := &egress[i], &egress[j]
+					if a.Pod != b.Pod {
+						return a.Pod < b.Pod
+					}
+					if a.Destination != b.Destination {
+						return a.Destination < b.Destination
+					}
+					if a.Port != b.Port {
+						return a.Port < b.Port
+					}
+					return a.Protocol < b.Protocol
+				})
+				results.RecordFailedTargets(failedPods...)
+			}()
+			common.ForEachInParallel(len(nodes), func(i int) error {
+				flows, err := observeConnections(nodes[i].node, window)
+				if err != nil {
+					return err
+				}
+				destinations := getEgressDestinations(&nodes[i], flows, &env.Config.Egress, clusterNetworks)
+				mutex.Lock()
+				defer mutex.Unlock()
+				egress = append(egress, destinations...)
+				for _, destination := range destinations {
+					if destination.Allowed {
+						continue
+					}
+					log.Errorf("Pod %s reached %s %s port %d, which is not in the egress allow list", destination.Pod,
+						destination.Protocol, destination.Destination, destination.Port)
+					if !containsAny(failedPods, []string{destination.Pod}) {
+						failedPods = append(failedPods, destination.Pod)
+					}
+				}
+				return nil
+			})
+			gomega.Expect(failedPods).To(gomega.BeEmpty())
+		})
 	})
 }
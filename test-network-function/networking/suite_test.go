@@ -0,0 +1,57 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package networking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ping"
+)
+
+func Test_getConnectivityContainers(t *testing.T) {
+	app := configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test-0", ContainerName: "app"}
+	sidecar := configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test-0", ContainerName: "istio-proxy"}
+	env := &config.TestEnvironment{ContainersUnderTest: map[configsections.ContainerIdentifier]*config.Container{
+		app:     {ContainerIdentifier: app, DefaultNetworkIPAddresses: []string{"10.0.0.10"}},
+		sidecar: {ContainerIdentifier: sidecar, DefaultNetworkIPAddresses: []string{"10.0.0.10"}},
+	}}
+	env.Config.ContainerTestSkips = configsections.ContainerTestSkips{"networking-icmpv4-*": {"tnf/*/istio-proxy"}}
+
+	// the opted out sidecar is not tested.
+	cuts := getConnectivityContainers(env, "networking-icmpv4-connectivity", "")
+	assert.Len(t, cuts, 1)
+	assert.Equal(t, app, cuts[0].ContainerIdentifier)
+	assert.Len(t, getConnectivityContainers(env, "networking-dns-resolution", ""), 2)
+}
+
+func Test_strictPing(t *testing.T) {
+	testCases := map[string]int{
+		"5 packets transmitted, 5 received, 0% packet loss, time 4006ms":   tnf.SUCCESS,
+		"5 packets transmitted, 4 received, 20% packet loss, time 4006ms":  tnf.FAILURE,
+		"5 packets transmitted, 0 received, 100% packet loss, time 4006ms": tnf.FAILURE,
+	}
+	for output, expected := range testCases {
+		pingTester := ping.NewPing(time.Second, "10.0.0.10", 5)
+		pingTester.ReelMatch("", "", output)
+		assert.Equal(t, expected, strictPing{pingTester}.Result(), output)
+	}
+}
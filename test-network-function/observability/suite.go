@@ -18,15 +18,19 @@ package observability
 
 import (
 	"fmt"
-	"path"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/customresourcestatus"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
@@ -38,14 +42,14 @@ import (
 //
 var (
 	// loggingTestPath is the file location of the logging.json test case relative to the project root.
-	loggingTestPath = path.Join("pkg", "tnf", "handlers", "logging", "logging.json")
+	loggingTestPath = filepath.Join("pkg", "tnf", "handlers", "logging", "logging.json")
 	// relativeLoggingTestPath is the relative path to the logging.json test case.
-	relativeLoggingTestPath = path.Join(common.PathRelativeToRoot, loggingTestPath)
+	relativeLoggingTestPath = filepath.Join(common.PathRelativeToRoot, loggingTestPath)
 
 	// crdTestPath is the file location of the CRD status existence test case relative to the project root.
-	crdTestPath = path.Join("pkg", "tnf", "handlers", "crdstatusexistence", "crdstatusexistence.json")
+	crdTestPath = filepath.Join("pkg", "tnf", "handlers", "crdstatusexistence", "crdstatusexistence.json")
 	// relativeCrdTestPath is the relatieve path to the crdstatusexistence.json test case.
-	relativeCrdTestPath = path.Join(common.PathRelativeToRoot, crdTestPath)
+	relativeCrdTestPath = filepath.Join(common.PathRelativeToRoot, crdTestPath)
 	// testCrdsTimeout is the timeout in seconds for the CRDs TC.
 	testCrdsTimeout = 10 * time.Second
 	// retrieve the singleton instance of test environment
@@ -56,13 +60,15 @@ var _ = ginkgo.Describe(common.ObservabilityTestKey, func() {
 
 	if testcases.IsInFocus(conf.FocusStrings, common.ObservabilityTestKey) {
 		ginkgo.BeforeEach(func() {
-			env.LoadAndRefresh()
+			gomega.Expect(env.LoadAndRefresh()).To(gomega.Succeed())
 			gomega.Expect(len(env.PodsUnderTest)).ToNot(gomega.Equal(0))
 			gomega.Expect(len(env.ContainersUnderTest)).ToNot(gomega.Equal(0))
 		})
 		ginkgo.ReportAfterEach(results.RecordResult)
 		testLogging()
 		testCrds()
+		testCrdSubresources()
+		testCrStatus()
 	}
 })
 
@@ -70,6 +76,9 @@ func testLogging() {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestLoggingIdentifier)
 	ginkgo.It(testID, func() {
 		for _, cut := range env.ContainersUnderTest {
+			if env.SkipsContainerTest(testID, &cut.ContainerIdentifier) {
+				continue
+			}
 			ginkgo.By(fmt.Sprintf("Test container: %+v. should emit at least one line of log to stderr/stdout", cut.ContainerIdentifier))
 			loggingTest(cut.ContainerIdentifier)
 		}
@@ -89,11 +98,11 @@ func loggingTest(c configsections.ContainerIdentifier) {
 	gomega.Expect(handlers).ToNot(gomega.BeNil())
 	gomega.Expect(handlers).ToNot(gomega.BeNil())
 	gomega.Expect(tester).ToNot(gomega.BeNil())
-	test, err := tnf.NewTest(context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
+	test, err := tnf.NewTest(env.Context(), context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(test).ToNot(gomega.BeNil())
 
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 }
 
 func testCrds() {
@@ -101,7 +110,8 @@ func testCrds() {
 	ginkgo.It(testID, func() {
 		ginkgo.By("CRDs should have a status subresource")
 		context := common.GetContext()
-		for _, crdName := range env.CrdNames {
+		for i := range env.Crds {
+			crdName := env.Crds[i].Name
 			ginkgo.By("Testing CRD " + crdName)
 
 			values := make(map[string]interface{})
@@ -115,10 +125,83 @@ func testCrds() {
 			gomega.Expect(handlers).ToNot(gomega.BeNil())
 			gomega.Expect(tester).ToNot(gomega.BeNil())
 
-			test, err := tnf.NewTest(context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
 			gomega.Expect(test).ToNot(gomega.BeNil())
 			gomega.Expect(err).To(gomega.BeNil())
-			test.RunAndValidate()
+			common.RunAndValidateTest(test)
 		}
 	})
 }
+
+// testCrdSubresources checks that the served versions of each CRD under test define a status subresource, and a scale
+// subresource when the configuration declares its custom resources scalable.
+func testCrdSubresources() {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestCrdSubresourcesIdentifier)
+	ginkgo.It(testID, func() {
+		if len(env.Crds) == 0 {
+			ginkgo.Skip("No CRD found.")
+		}
+		ginkgo.By("CRDs should have a status subresource, and a scale subresource when scalable")
+		var badCrds []string
+		for i := range env.Crds {
+			crd := &env.Crds[i]
+			scalable := configsections.IsScalableCrd(env.Config.CrdFilters, crd)
+			var issues []string
+			for _, version := range crd.Versions {
+				if !crd.HasStatus(version) {
+					issues = append(issues, fmt.Sprintf("version %s has no status subresource", version))
+				}
+				if scalable && !crd.HasScale(version) {
+					issues = append(issues, fmt.Sprintf("version %s has no scale subresource", version))
+				}
+			}
+			if len(issues) > 0 {
+				log.Errorf("The CRD %s is missing subresources: %s", crd.Name, strings.Join(issues, ", "))
+				badCrds = append(badCrds, crd.Name)
+			}
+		}
+		results.RecordFailedTargets(badCrds...)
+		gomega.Expect(badCrds).To(gomega.BeEmpty())
+	})
+}
+
+// testCrStatus checks that at least one custom resource of each CRD under test has a populated status, in the
+// namespace under test for the namespaced CRDs.
+func testCrStatus() {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestCrStatusIdentifier)
+	ginkgo.It(testID, func() {
+		if len(env.Crds) == 0 {
+			ginkgo.Skip("No CRD found.")
+		}
+		ginkgo.By("Custom resources should report their status")
+		var badCrds []string
+		for i := range env.Crds {
+			crd := &env.Crds[i]
+			namespace := env.NameSpaceUnderTest
+			if crd.Scope == configsections.CrdScopeCluster {
+				namespace = ""
+			}
+			context := common.GetContext()
+			tester := customresourcestatus.NewCustomResourceStatus(
+				common.GetTimeout(common.ObservabilityTestKey, "customresourcestatus"), crd.Resource(), namespace)
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+			gomega.Expect(err).To(gomega.BeNil())
+			common.RunAndValidateTest(test)
+			customResources := tester.GetCustomResources()
+			withStatus := 0
+			for _, customResource := range customResources {
+				if customResource.HasStatus {
+					withStatus++
+				}
+			}
+			log.Infof("%d of the %d custom resources of the CRD %s have a status", withStatus, len(customResources), crd.Name)
+			if withStatus == 0 {
+				log.Errorf("No custom resource of the CRD %s has a populated status", crd.Name)
+				badCrds = append(badCrds, crd.Name)
+			}
+		}
+		results.RecordFailedTargets(badCrds...)
+		gomega.Expect(badCrds).To(gomega.BeEmpty())
+	})
+}
+
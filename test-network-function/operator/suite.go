@@ -18,10 +18,14 @@ package operator
 
 import (
 	"fmt"
-	"path"
+	"path/filepath"
 	"strings"
 
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/operatorgroup"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/subscription"
 
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
@@ -45,27 +49,45 @@ const (
 var (
 
 	// checkSubscriptionTestPath is the file location of the uncordon.json test case relative to the project root.
-	checkSubscriptionTestPath = path.Join("pkg", "tnf", "handlers", "checksubscription", "check-subscription.json")
+	checkSubscriptionTestPath = filepath.Join("pkg", "tnf", "handlers", "checksubscription", "check-subscription.json")
 
 	// pathRelativeToRoot is used to calculate relative filepaths for the `test-network-function` executable entrypoint.
-	pathRelativeToRoot = path.Join("..")
+	pathRelativeToRoot = filepath.Join("..")
 
 	// relativeNodesTestPath is the relative path to the nodes.json test case.
-	relativeNodesTestPath = path.Join(pathRelativeToRoot, checkSubscriptionTestPath)
+	relativeNodesTestPath = filepath.Join(pathRelativeToRoot, checkSubscriptionTestPath)
 
 	// relativeSchemaPath is the relative path to the generic-test.schema.json JSON schema.
-	relativeSchemaPath = path.Join(pathRelativeToRoot, schemaPath)
+	relativeSchemaPath = filepath.Join(pathRelativeToRoot, schemaPath)
 
 	// schemaPath is the path to the generic-test.schema.json JSON schema relative to the project root.
-	schemaPath = path.Join("schemas", "generic-test.schema.json")
+	schemaPath = filepath.Join("schemas", "generic-test.schema.json")
 )
 
+// OperatorGroupCoverage is the operator group of an operator under test, and the install mode it results in.
+type OperatorGroupCoverage struct {
+	Operator              string   `json:"operator"`
+	OperatorGroup         string   `json:"operatorGroup,omitempty"`
+	TargetNamespaces      []string `json:"targetNamespaces,omitempty"`
+	InstallMode           string   `json:"installMode,omitempty"`
+	SupportedInstallModes []string `json:"supportedInstallModes"`
+}
+
+// operatorGroupCoverages holds the operator groups read by the install mode test.
+var operatorGroupCoverages []OperatorGroupCoverage
+
+// GetOperatorGroupCoverages returns the operator groups of the operators under test, empty unless the install mode
+// test ran.
+func GetOperatorGroupCoverages() []OperatorGroupCoverage {
+	return operatorGroupCoverages
+}
+
 var _ = ginkgo.Describe(testSpecName, func() {
 	conf, _ := ginkgo.GinkgoConfiguration()
 	if testcases.IsInFocus(conf.FocusStrings, testSpecName) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
-			env.LoadAndRefresh()
+			gomega.Expect(env.LoadAndRefresh()).To(gomega.Succeed())
 			if len(env.OperatorsUnderTest) == 0 {
 				ginkgo.Skip("No Operator found.")
 			}
@@ -76,6 +98,8 @@ var _ = ginkgo.Describe(testSpecName, func() {
 			itRunsTestsOnOperator(env)
 		})
 		testOperatorsAreInstalledViaOLM(env)
+		testOperatorSubscriptionsHealth(env)
+		testOperatorInstallModes(env)
 	}
 })
 
@@ -103,11 +127,125 @@ func testOperatorIsInstalledViaOLM(subscriptionName, subscriptionNamespace strin
 	gomega.Expect(len(handlers)).To(gomega.Equal(1))
 	gomega.Expect(tester).ToNot(gomega.BeNil())
 	context := common.GetContext()
-	test, err := tnf.NewTest(context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(test).ToNot(gomega.BeNil())
 
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
+}
+
+// testOperatorSubscriptionsHealth ensures the OLM subscription of each operator under test is healthy.
+func testOperatorSubscriptionsHealth(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestOperatorSubscriptionHealthIdentifier)
+	ginkgo.It(testID, func() {
+		var badOperators []string
+		for i := range env.OperatorsUnderTest {
+			op := &env.OperatorsUnderTest[i]
+			ginkgo.By(fmt.Sprintf("%s in namespace %s Should have a healthy subscription", op.Name, op.Namespace))
+			if issues := getSubscriptionIssues(op, env.Config.Subscription.GetExpectedChannel(op)); len(issues) > 0 {
+				log.Errorf("The subscription of the operator %s/%s is not healthy: %s", op.Namespace, op.Name,
+					strings.Join(issues, ", "))
+				badOperators = append(badOperators, op.Namespace+"/"+op.Name)
+			}
+		}
+		results.RecordFailedTargets(badOperators...)
+		gomega.Expect(badOperators).To(gomega.BeEmpty())
+	})
+}
+
+// getSubscriptionIssues reads the subscription of the operator and returns why it is not healthy: it must be at the
+// latest known CSV of its channel, follow expectedChannel when not empty, and reference an approved and complete install
+// plan.
+func getSubscriptionIssues(op *configsections.Operator, expectedChannel string) []string {
+	if op.SubscriptionName == "" {
+		return []string{"no subscription name"}
+	}
+	context := common.GetContext()
+	tester := subscription.NewSubscription(common.GetTimeout(common.OperatorTestKey, "subscription"), op.SubscriptionName,
+		op.Namespace)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	// a missing subscription is an issue of the operator, the other operators are still checked.
+	result, err := test.Run()
+	if err != nil {
+		return []string{fmt.Sprintf("cannot read the subscription %s: %v", op.SubscriptionName, err)}
+	}
+	status := tester.GetStatus()
+	if result != tnf.SUCCESS || status == nil {
+		return []string{fmt.Sprintf("subscription %s not found", op.SubscriptionName)}
+	}
+	log.Infof("The subscription %s of the operator %s/%s is %+v", op.SubscriptionName, op.Namespace, op.Name, *status)
+
+	var issues []string
+	if status.State != subscription.StateAtLatestKnown {
+		issues = append(issues, fmt.Sprintf("state %q instead of %q", status.State, subscription.StateAtLatestKnown))
+	}
+	if expectedChannel != "" && status.Channel != expectedChannel {
+		issues = append(issues, fmt.Sprintf("channel %q instead of %q", status.Channel, expectedChannel))
+	}
+	switch {
+	case status.InstallPlan == nil:
+		issues = append(issues, "no install plan")
+	case !status.InstallPlan.Approved:
+		issues = append(issues, fmt.Sprintf("install plan %s not approved", status.InstallPlan.Name))
+	case status.InstallPlan.Phase != subscription.PhaseComplete:
+		issues = append(issues, fmt.Sprintf("install plan %s in phase %q instead of %q", status.InstallPlan.Name,
+			status.InstallPlan.Phase, subscription.PhaseComplete))
+	}
+	return issues
+}
+
+// testOperatorInstallModes ensures each operator under test supports the install mode of its operator group, and the
+// install mode declared in the configuration.
+func testOperatorInstallModes(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestOperatorInstallModeIdentifier)
+	ginkgo.It(testID, func() {
+		target := env.Config.OperatorInstallMode.Target
+		operatorGroupCoverages = nil
+		var badOperators []string
+		for i := range env.OperatorsUnderTest {
+			op := &env.OperatorsUnderTest[i]
+			name := op.Namespace + "/" + op.Name
+			if len(op.InstallModes) == 0 {
+				log.Warnf("The install modes of the operator %s are unknown, it was not discovered from its CSV", name)
+				continue
+			}
+			coverage := OperatorGroupCoverage{Operator: name, SupportedInstallModes: op.InstallModes}
+			var issues []string
+			operatorGroups := getOperatorGroups(op.Namespace)
+			if len(operatorGroups) == 1 {
+				coverage.OperatorGroup = operatorGroups[0].Name
+				coverage.TargetNamespaces = operatorGroups[0].TargetNamespaces
+				coverage.InstallMode = configsections.GetInstallMode(op.Namespace, coverage.TargetNamespaces)
+				if !op.SupportsInstallMode(coverage.InstallMode) {
+					issues = append(issues, fmt.Sprintf("operator group %s is %s", coverage.OperatorGroup, coverage.InstallMode))
+				}
+			} else {
+				issues = append(issues, fmt.Sprintf("%d operator groups in its namespace instead of 1", len(operatorGroups)))
+			}
+			if target != "" && !op.SupportsInstallMode(target) {
+				issues = append(issues, fmt.Sprintf("the target is %s", target))
+			}
+			operatorGroupCoverages = append(operatorGroupCoverages, coverage)
+			if len(issues) > 0 {
+				log.Errorf("The operator %s supports the install modes %v only, but %s", name, op.InstallModes,
+					strings.Join(issues, ", "))
+				badOperators = append(badOperators, name)
+			}
+		}
+		results.RecordFailedTargets(badOperators...)
+		gomega.Expect(badOperators).To(gomega.BeEmpty())
+	})
+}
+
+// getOperatorGroups returns the operator groups of the namespace.
+func getOperatorGroups(namespace string) []operatorgroup.OperatorGroup {
+	context := common.GetContext()
+	tester := operatorgroup.NewOperatorGroups(common.GetTimeout(common.OperatorTestKey, "operatorgroup"), namespace)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	common.RunAndValidateTest(test)
+	return tester.GetOperatorGroups()
 }
 
 func itRunsTestsOnOperator(env *config.TestEnvironment) {
@@ -141,13 +279,13 @@ func runTestsOnOperator(env *config.TestEnvironment, testCase testcases.BaseTest
 			name := op.Name
 			args := []interface{}{name, op.Namespace}
 			cmdArgs := strings.Split(fmt.Sprintf(testCase.Command, args...), " ")
-			opInTest := operator.NewOperator(cmdArgs, name, op.Namespace, testCase.ExpectedStatus, testCase.ResultType, testCase.Action, common.DefaultTimeout)
+			opInTest := operator.NewOperator(cmdArgs, name, op.Namespace, testCase.ExpectedStatus, testCase.ResultType, testCase.Action, common.GetTimeout(common.OperatorTestKey, "operator"))
 			gomega.Expect(opInTest).ToNot(gomega.BeNil())
 			context := common.GetContext()
-			test, err := tnf.NewTest(context.GetExpecter(), opInTest, []reel.Handler{opInTest}, context.GetErrorChannel())
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), opInTest, []reel.Handler{opInTest}, context.GetErrorChannel())
 			gomega.Expect(err).To(gomega.BeNil())
 			gomega.Expect(test).ToNot(gomega.BeNil())
-			test.RunAndValidate()
+			common.RunAndValidateTest(test)
 		}
 	})
 }
@@ -28,6 +28,9 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/deprecatedapis"
+	"github.com/test-network-function/test-network-function/pkg/requirements"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 
 	"github.com/test-network-function/test-network-function/test-network-function/common"
@@ -40,13 +43,18 @@ import (
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/cnffsdiff"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/containerid"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/currentkernelcmdlineargs"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/imagelabels"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/mckernelarguments"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodemcname"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodetainted"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/podnodename"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/processcount"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/readbootconfig"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/selinux"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/sysctlallconfigsargs"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/writablelayer"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	utils "github.com/test-network-function/test-network-function/pkg/utils"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
@@ -60,8 +68,107 @@ const (
 	DefaultHugepagesz        = "default_hugepagesz"
 	KernArgsKeyValueSplitLen = 2
 	commandTimeout           = 30 * time.Second
+	utcTimezone              = "UTC"
+	// nodeTimezoneCommand prints the timezone abbreviation of a node, from its debug pod.
+	nodeTimezoneCommand = "chroot /host date +%Z"
+	// containerTimezoneCommand prints the TZ environment variable of a container, set by its pod spec or image.
+	containerTimezoneCommand = `echo "TZ=${TZ}"`
+	// the commands gathering the features provided by a node, from its debug pod.
+	kernelVersionCommand = "uname -r"
+	lsmodCommand         = "chroot /host lsmod"
+	hugepagesCommand     = "chroot /host ls /sys/kernel/mm/hugepages"
+	sriovTotalVFsCommand = "chroot /host sh -c 'cat /sys/class/net/*/device/sriov_totalvfs 2>/dev/null' | " +
+		"awk '{s+=$1} END {print s+0}'"
+	// podPidsLimitCommand prints the pids limit of the pods set in the kubelet configuration of a node, from its debug
+	// pod.
+	podPidsLimitCommand = `chroot /host grep -hs podPidsLimit /etc/kubernetes/kubelet.conf || echo "podPidsLimit unset"`
 )
 
+// podPidsLimitRegex matches the pids limit of the pods in the kubelet configuration, in JSON or YAML.
+var podPidsLimitRegex = regexp.MustCompile(`podPidsLimit"?\s*:\s*(-?\d+)`)
+
+// ProcessCount is the number of processes of a container under test, against its pids limit.
+type ProcessCount struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Processes int    `json:"processes"`
+	Zombies   int    `json:"zombies"`
+	// PidsLimit is the pids limit of the container, e.g. the pids_limit of CRI-O, 0 when unlimited.
+	PidsLimit int `json:"pidsLimit"`
+	// PodPidsLimit is the pids limit of the pod set by the kubelet of its node, 0 when unlimited or unknown.
+	PodPidsLimit int `json:"podPidsLimit"`
+}
+
+// SELinuxNode is the SELinux mode of a node hosting containers under test, and the SELinux labels of these containers.
+type SELinuxNode struct {
+	Node       string             `json:"node"`
+	Mode       string             `json:"mode"`
+	Containers []SELinuxContainer `json:"containers"`
+}
+
+// SELinuxContainer is the SELinux label of the main process of a container under test.
+type SELinuxContainer struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Label     string `json:"label"`
+}
+
+// ImageProvenance is the provenance of an image of the containers under test, as recorded in its OCI labels.
+type ImageProvenance struct {
+	Image      string   `json:"image"`
+	Containers []string `json:"containers"`
+	Revision   string   `json:"revision,omitempty"`
+	Source     string   `json:"source,omitempty"`
+	Version    string   `json:"version,omitempty"`
+	// Missing are the provenance labels the image does not set.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// imageProvenance holds the provenance of the images read by the image provenance test.
+var imageProvenance []ImageProvenance
+
+// GetImageProvenance returns the provenance of each image of the containers under test, empty unless the image
+// provenance test ran.
+func GetImageProvenance() []ImageProvenance {
+	return imageProvenance
+}
+
+// deprecatedAPIUsages holds the uses of the removed APIs found by the deprecated APIs test.
+var deprecatedAPIUsages []deprecatedapis.Usage
+
+// GetDeprecatedAPIUsages returns the uses of the APIs removed in the upcoming Kubernetes releases by the CRDs and the
+// operators under test, empty unless the deprecated APIs test found some.
+func GetDeprecatedAPIUsages() []deprecatedapis.Usage {
+	return deprecatedAPIUsages
+}
+
+// seLinuxNodes holds the SELinux modes and labels read by the SELinux test.
+var seLinuxNodes []SELinuxNode
+
+// GetSELinuxNodes returns the SELinux mode and container labels of each node hosting containers under test, empty
+// unless the SELinux test ran.
+func GetSELinuxNodes() []SELinuxNode {
+	return seLinuxNodes
+}
+
+// processCounts holds the process counts of the pids limit test.
+var processCounts []ProcessCount
+
+// GetProcessCounts returns the process counts of the containers measured by the pids limit test, empty unless the
+// test ran.
+func GetProcessCounts() []ProcessCount {
+	return processCounts
+}
+
+// platformRequirements is the requirements-vs-provided table of the platform requirements test.
+var platformRequirements []requirements.Row
+
+// GetPlatformRequirements returns the requirements-vs-provided table of the platform requirements test, one row per
+// node and requirement, empty unless the test ran.
+func GetPlatformRequirements() []requirements.Row {
+	return platformRequirements
+}
+
 type hugePagesConfig struct {
 	hugepagesSize  int // size in kb
 	hugepagesCount int
@@ -103,6 +210,9 @@ type machineConfig struct {
 	} `json:"spec"`
 }
 
+// writableLayerBaseline is the disk usage of the writable layer of the containers under test at the start of the run.
+var writableLayerBaseline = map[configsections.ContainerIdentifier]uint64{}
+
 // systemdHugePagesUnit maps a systemd unit in a machineconfig json object.
 type systemdHugePagesUnit struct {
 	Contents string `json:"contents"`
@@ -140,7 +250,7 @@ var _ = ginkgo.Describe(common.PlatformAlterationTestKey, func() {
 	if testcases.IsInFocus(conf.FocusStrings, common.PlatformAlterationTestKey) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
-			env.LoadAndRefresh()
+			gomega.Expect(env.LoadAndRefresh()).To(gomega.Succeed())
 			gomega.Expect(len(env.PodsUnderTest)).ToNot(gomega.Equal(0))
 			gomega.Expect(len(env.ContainersUnderTest)).ToNot(gomega.Equal(0))
 		})
@@ -152,8 +262,19 @@ var _ = ginkgo.Describe(common.PlatformAlterationTestKey, func() {
 			testHugepages(env)
 			testBootParams(env)
 			testSysctlConfigs(env)
+			testPlatformRequirements(env)
+			testSELinux(env)
+			testImageProvenance(env)
 		}
 		testIsRedHatRelease(env)
+		testTimezone(env)
+		testPidsLimit(env)
+		testDeprecatedAPIs(env)
+		// the growth is measured up to this test, defined last so that it covers at least the other tests of the suite.
+		if !common.IsMinikube() {
+			common.OnRunStart(func() { recordWritableLayerBaseline(env) })
+			testWritableLayerGrowth(env)
+		}
 	}
 })
 
@@ -163,6 +284,9 @@ func testIsRedHatRelease(env *config.TestEnvironment) {
 	ginkgo.It(testID, func() {
 		ginkgo.By("should report a proper Red Hat version")
 		for _, cut := range env.ContainersUnderTest {
+			if env.SkipsContainerTest(testID, &cut.ContainerIdentifier) {
+				continue
+			}
 			testContainerIsRedHatRelease(cut)
 		}
 	})
@@ -174,10 +298,10 @@ func testContainerIsRedHatRelease(cut *config.Container) {
 	containerName := cut.Oc.GetPodContainerName()
 	context := cut.Oc
 	ginkgo.By(fmt.Sprintf("%s(%s) is checked for Red Hat version", podName, containerName))
-	versionTester := redhat.NewRelease(common.DefaultTimeout)
-	test, err := tnf.NewTest(context.GetExpecter(), versionTester, []reel.Handler{versionTester}, context.GetErrorChannel())
+	versionTester := redhat.NewRelease(common.GetTimeout(common.PlatformAlterationTestKey, "redhat"))
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), versionTester, []reel.Handler{versionTester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 }
 
 // testContainersFsDiff test that all CUT didn't install new packages are starting
@@ -188,6 +312,9 @@ func testContainersFsDiff(env *config.TestEnvironment) {
 			var badContainers []string
 			var errContainers []string
 			for _, cut := range env.ContainersUnderTest {
+				if env.SkipsContainerTest(testID, &cut.ContainerIdentifier) {
+					continue
+				}
 				podName := cut.Oc.GetPodName()
 				containerName := cut.Oc.GetPodContainerName()
 				containerOC := cut.Oc
@@ -217,21 +344,21 @@ func testContainersFsDiff(env *config.TestEnvironment) {
 // newContainerFsDiffTest  test that the CUT didn't install new packages after starting, and report through Ginkgo.
 func newContainerFsDiffTest(nodeName string, nodeOc, targetContainerOC *interactive.Oc) *tnf.Test {
 	targetContainerOC.GetExpecter()
-	containerIDTester := containerid.NewContainerID(common.DefaultTimeout)
-	test, err := tnf.NewTest(targetContainerOC.GetExpecter(), containerIDTester, []reel.Handler{containerIDTester}, targetContainerOC.GetErrorChannel())
+	containerIDTester := containerid.NewContainerID(common.GetTimeout(common.PlatformAlterationTestKey, "containerid"))
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), targetContainerOC.GetExpecter(), containerIDTester, []reel.Handler{containerIDTester}, targetContainerOC.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	containerID := containerIDTester.GetID()
-	fsDiffTester := cnffsdiff.NewFsDiff(common.DefaultTimeout, containerID, nodeName)
-	test, err = tnf.NewTest(nodeOc.GetExpecter(), fsDiffTester, []reel.Handler{fsDiffTester}, nodeOc.GetErrorChannel())
+	fsDiffTester := cnffsdiff.NewFsDiff(common.GetTimeout(common.PlatformAlterationTestKey, "cnffsdiff"), containerID, nodeName)
+	test, err = tnf.NewTest(config.GetTestEnvironment().Context(), nodeOc.GetExpecter(), fsDiffTester, []reel.Handler{fsDiffTester}, nodeOc.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 	return test
 }
 func getMcKernelArguments(context *interactive.Context, mcName string) map[string]string {
-	mcKernelArgumentsTester := mckernelarguments.NewMcKernelArguments(common.DefaultTimeout, mcName)
-	test, err := tnf.NewTest(context.GetExpecter(), mcKernelArgumentsTester, []reel.Handler{mcKernelArgumentsTester}, context.GetErrorChannel())
+	mcKernelArgumentsTester := mckernelarguments.NewMcKernelArguments(common.GetTimeout(common.PlatformAlterationTestKey, "mckernelarguments"), mcName)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), mcKernelArgumentsTester, []reel.Handler{mcKernelArgumentsTester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	mcKernelArguments := mcKernelArgumentsTester.GetKernelArguments()
 	var mcKernelArgumentsJSON []string
 	err = json.Unmarshal([]byte(mcKernelArguments), &mcKernelArgumentsJSON)
@@ -241,36 +368,36 @@ func getMcKernelArguments(context *interactive.Context, mcName string) map[strin
 }
 
 func getMcName(context *interactive.Context, nodeName string) string {
-	mcNameTester := nodemcname.NewNodeMcName(common.DefaultTimeout, nodeName)
-	test, err := tnf.NewTest(context.GetExpecter(), mcNameTester, []reel.Handler{mcNameTester}, context.GetErrorChannel())
+	mcNameTester := nodemcname.NewNodeMcName(common.GetTimeout(common.PlatformAlterationTestKey, "nodemcname"), nodeName)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), mcNameTester, []reel.Handler{mcNameTester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	return mcNameTester.GetMcName()
 }
 
 func getPodNodeName(context *interactive.Context, podName, podNamespace string) string {
-	podNameTester := podnodename.NewPodNodeName(common.DefaultTimeout, podName, podNamespace)
-	test, err := tnf.NewTest(context.GetExpecter(), podNameTester, []reel.Handler{podNameTester}, context.GetErrorChannel())
+	podNameTester := podnodename.NewPodNodeName(common.GetTimeout(common.PlatformAlterationTestKey, "podnodename"), podName, podNamespace)
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), podNameTester, []reel.Handler{podNameTester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	return podNameTester.GetNodeName()
 }
 
 func getCurrentKernelCmdlineArgs(targetContainerOc *interactive.Oc) map[string]string {
-	currentKernelCmdlineArgsTester := currentkernelcmdlineargs.NewCurrentKernelCmdlineArgs(common.DefaultTimeout)
-	test, err := tnf.NewTest(targetContainerOc.GetExpecter(), currentKernelCmdlineArgsTester, []reel.Handler{currentKernelCmdlineArgsTester}, targetContainerOc.GetErrorChannel())
+	currentKernelCmdlineArgsTester := currentkernelcmdlineargs.NewCurrentKernelCmdlineArgs(common.GetTimeout(common.PlatformAlterationTestKey, "currentkernelcmdlineargs"))
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), targetContainerOc.GetExpecter(), currentKernelCmdlineArgsTester, []reel.Handler{currentKernelCmdlineArgsTester}, targetContainerOc.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	currnetKernelCmdlineArgs := currentKernelCmdlineArgsTester.GetKernelArguments()
 	currentSplitKernelCmdlineArgs := strings.Split(currnetKernelCmdlineArgs, " ")
 	return utils.ArgListToMap(currentSplitKernelCmdlineArgs)
 }
 
 func getGrubKernelArgs(context *interactive.Oc) map[string]string {
-	readBootConfigTester := readbootconfig.NewReadBootConfig(common.DefaultTimeout)
-	test, err := tnf.NewTest(context.GetExpecter(), readBootConfigTester, []reel.Handler{readBootConfigTester}, context.GetErrorChannel())
+	readBootConfigTester := readbootconfig.NewReadBootConfig(common.GetTimeout(common.PlatformAlterationTestKey, "readbootconfig"))
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), readBootConfigTester, []reel.Handler{readBootConfigTester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	bootConfig := readBootConfigTester.GetBootConfig()
 
 	splitBootConfig := strings.Split(bootConfig, "\n")
@@ -310,10 +437,10 @@ func parseSysctlSystemOutput(sysctlSystemOutput string) map[string]string {
 }
 
 func getSysctlConfigArgs(context *interactive.Oc) map[string]string {
-	sysctlAllConfigsArgsTester := sysctlallconfigsargs.NewSysctlAllConfigsArgs(common.DefaultTimeout)
-	test, err := tnf.NewTest(context.GetExpecter(), sysctlAllConfigsArgsTester, []reel.Handler{sysctlAllConfigsArgsTester}, context.GetErrorChannel())
+	sysctlAllConfigsArgsTester := sysctlallconfigsargs.NewSysctlAllConfigsArgs(common.GetTimeout(common.PlatformAlterationTestKey, "sysctlallconfigsargs"))
+	test, err := tnf.NewTest(config.GetTestEnvironment().Context(), context.GetExpecter(), sysctlAllConfigsArgsTester, []reel.Handler{sysctlAllConfigsArgsTester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	common.RunAndValidateTest(test)
 	sysctlAllConfigsArgs := sysctlAllConfigsArgsTester.GetSysctlAllConfigsArgs()
 
 	return parseSysctlSystemOutput(sysctlAllConfigsArgs)
@@ -323,7 +450,7 @@ func testBootParams(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestUnalteredStartupBootParamsIdentifier)
 	ginkgo.It(testID, func() {
 		context := common.GetContext()
-		for _, cut := range env.ContainersUnderTest {
+		for _, cut := range getTestedContainers(env, testID) {
 			podName := cut.Oc.GetPodName()
 			podNameSpace := cut.Oc.GetPodNamespace()
 			targetContainerOc := cut.Oc
@@ -331,6 +458,18 @@ func testBootParams(env *config.TestEnvironment) {
 		}
 	})
 }
+
+// getTestedContainers returns the containers under test which are not opted out of the test case testID.
+func getTestedContainers(env *config.TestEnvironment, testID string) []*config.Container {
+	var cuts []*config.Container
+	for _, cut := range env.ContainersUnderTest {
+		if !env.SkipsContainerTest(testID, &cut.ContainerIdentifier) {
+			cuts = append(cuts, cut)
+		}
+	}
+	return cuts
+}
+
 func testBootParamsHelper(context *interactive.Context, podName, podNamespace string, targetContainerOc *interactive.Oc) {
 	ginkgo.By(fmt.Sprintf("Testing boot params for the pod's node %s/%s", podNamespace, podName))
 	nodeName := getPodNodeName(context, podName, podNamespace)
@@ -397,12 +536,12 @@ func testTainted(env *config.TestEnvironment) {
 		var taintedNodes []string
 		var errNodes []string
 		for _, node := range env.NodesUnderTest {
-			if !node.HasDebugPod() {
+			if !node.HasDebugPod() || !common.RunsOnNode(env, node, testID, true) {
 				continue
 			}
 			context := node.Oc
-			tester := nodetainted.NewNodeTainted(common.DefaultTimeout)
-			test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+			tester := nodetainted.NewNodeTainted(common.GetTimeout(common.PlatformAlterationTestKey, "nodetainted"))
+			test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 			gomega.Expect(err).To(gomega.BeNil())
 			var message string
 			test.RunWithCallbacks(func() {
@@ -426,11 +565,123 @@ func testTainted(env *config.TestEnvironment) {
 				log.Errorf("Ginkgo writer could not write because: %s", err)
 			}
 		}
+		results.RecordFailedTargets(taintedNodes...)
+		results.RecordFailedTargets(errNodes...)
 		gomega.Expect(taintedNodes).To(gomega.BeNil())
 		gomega.Expect(errNodes).To(gomega.BeNil())
 	})
 }
 
+// testTimezone tests the nodes with a debug pod use UTC, and the containers under test do not override the timezone.
+func testTimezone(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestTimezoneIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Testing the timezone of the nodes and containers under test")
+		var badNodes []string
+		for _, node := range env.NodesUnderTest {
+			if !node.HasDebugPod() || !common.RunsOnNode(env, node, testID, true) {
+				continue
+			}
+			context := interactive.NewContext(node.Oc.GetExpecter(), node.Oc.GetErrorChannel())
+			timezone := strings.TrimSpace(common.ExecuteCommand(nodeTimezoneCommand, commandTimeout, context, nil))
+			if timezone != utcTimezone {
+				log.Errorf("Node %s uses the %s timezone instead of %s", node.Name, timezone, utcTimezone)
+				badNodes = append(badNodes, node.Name)
+			}
+		}
+		var badContainers []string
+		for _, cut := range getTestedContainers(env, testID) {
+			context := interactive.NewContext(cut.Oc.GetExpecter(), cut.Oc.GetErrorChannel())
+			out := strings.TrimSpace(common.ExecuteCommand(containerTimezoneCommand, commandTimeout, context, nil))
+			if timezone := strings.TrimPrefix(out, "TZ="); !isUTC(timezone) {
+				log.Errorf("Container %s/%s/%s overrides the timezone with TZ=%s", cut.Oc.GetPodNamespace(),
+					cut.Oc.GetPodName(), cut.Oc.GetPodContainerName(), timezone)
+				badContainers = append(badContainers, cut.Oc.GetPodNamespace()+"/"+cut.Oc.GetPodName()+"/"+
+					cut.Oc.GetPodContainerName())
+			}
+		}
+		results.RecordFailedTargets(badNodes...)
+		results.RecordFailedTargets(badContainers...)
+		gomega.Expect(badNodes).To(gomega.BeEmpty())
+		gomega.Expect(badContainers).To(gomega.BeEmpty())
+	})
+}
+
+// testPlatformRequirements tests the nodes with a debug pod provide the platform features required by the CNF.
+func testPlatformRequirements(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPlatformRequirementsIdentifier)
+	ginkgo.It(testID, func() {
+		required := env.Config.PlatformRequirements
+		if required.IsEmpty() {
+			ginkgo.Skip("No platform requirement declared in the configuration file")
+		}
+		platformRequirements = nil
+		var unsatisfied []string
+		nodeNames := make([]string, 0, len(env.NodesUnderTest))
+		for name := range env.NodesUnderTest {
+			nodeNames = append(nodeNames, name)
+		}
+		sort.Strings(nodeNames)
+		checked := false
+		for _, name := range nodeNames {
+			node := env.NodesUnderTest[name]
+			if !common.RunsOnNode(env, node, testID, true) {
+				continue
+			}
+			if !node.HasDebugPod() {
+				log.Warnf("Node %s has no debug pod, its platform features cannot be checked", node.Name)
+				continue
+			}
+			checked = true
+			ginkgo.By(fmt.Sprintf("Testing the platform features of node %s", node.Name))
+			for _, row := range requirements.Check(node.Name, &required, getProvidedFeatures(node, &required)) {
+				platformRequirements = append(platformRequirements, row)
+				log.Infof("Node %s %s: required %s, provided %s", row.Node, row.Requirement, row.Required, row.Provided)
+				if !row.Satisfied {
+					results.RecordFailedTargets(row.Node)
+					unsatisfied = append(unsatisfied, fmt.Sprintf("%s %s %s (provided: %s)", row.Node, row.Requirement,
+						row.Required, row.Provided))
+				}
+			}
+		}
+		if !checked {
+			ginkgo.Skip("No node with a debug pod to check the platform requirements on")
+		}
+		gomega.Expect(unsatisfied).To(gomega.BeEmpty())
+	})
+}
+
+// getProvidedFeatures gathers the features of a node needed to check the required ones.
+func getProvidedFeatures(node *config.NodeConfig, required *configsections.PlatformRequirements) *requirements.Provided {
+	context := interactive.NewContext(node.Oc.GetExpecter(), node.Oc.GetErrorChannel())
+	run := func(command string) string {
+		return common.ExecuteCommand(command, commandTimeout, context, nil)
+	}
+	provided := &requirements.Provided{}
+	if required.MinKernelVersion != "" {
+		provided.KernelVersion = strings.TrimSpace(run(kernelVersionCommand))
+	}
+	if required.SCTP || len(required.KernelModules) > 0 {
+		provided.KernelModules = requirements.ParseLsmod(run(lsmodCommand))
+	}
+	if len(required.HugepageSizes) > 0 {
+		provided.HugepageSizesKB = requirements.ParseHugepageSizes(run(hugepagesCommand))
+	}
+	if required.SRIOV {
+		provided.SRIOVTotalVFs, _ = strconv.Atoi(strings.TrimSpace(run(sriovTotalVFsCommand)))
+	}
+	return provided
+}
+
+// isUTC returns true unless the TZ environment variable selects another timezone than UTC.
+func isUTC(timezone string) bool {
+	switch timezone {
+	case "", utcTimezone, "Etc/UTC", ":UTC", ":Etc/UTC":
+		return true
+	}
+	return false
+}
+
 func hugepageSizeToInt(s string) int {
 	num, _ := strconv.Atoi(s[:len(s)-1])
 	unit := s[len(s)-1]
@@ -507,7 +758,7 @@ func getNodeNumaHugePages(node *config.NodeConfig) (hugepages numaHugePagesPerSi
 	// This command must run inside the node, so we'll need the node's context to run commands inside the debug daemonset pod.
 	context := interactive.NewContext(node.Oc.GetExpecter(), node.Oc.GetErrorChannel())
 	var commandErr error
-	hugepagesCmdOut := utils.ExecuteCommand(cmd, commandTimeout, context, func() {
+	hugepagesCmdOut := common.ExecuteCommand(cmd, commandTimeout, context, func() {
 		commandErr = fmt.Errorf("failed to get node %s hugepages per numa", node.Name)
 	})
 	if commandErr != nil {
@@ -549,7 +800,7 @@ func getMachineConfig(mcName string) (machineConfig, error) {
 
 	// Local shell context is needed for the command handler.
 	context := common.GetContext()
-	mcJSON := utils.ExecuteCommand(fmt.Sprintf("oc get mc %s -o json", mcName), commandTimeout, context, func() {
+	mcJSON := common.ExecuteCommand(fmt.Sprintf("oc get mc %s -o json", mcName), commandTimeout, context, func() {
 		commandErr = fmt.Errorf("failed to get json machineconfig %s", mcName)
 	})
 	if commandErr != nil {
@@ -695,7 +946,7 @@ func testHugepages(env *config.TestEnvironment) {
 		var badNodes []string
 
 		for _, node := range env.NodesUnderTest {
-			if !node.IsWorker() || !node.HasDebugPod() {
+			if !node.HasDebugPod() || !common.RunsOnNode(env, node, testID, node.IsWorker()) {
 				continue
 			}
 
@@ -736,3 +987,365 @@ func testHugepages(env *config.TestEnvironment) {
 		gomega.Expect(badNodes).To(gomega.BeNil())
 	})
 }
+
+// recordWritableLayerBaseline measures the writable layer of the containers under test at the start of the run.  The
+// containers which cannot be measured are left out of the writable layer growth test.
+func recordWritableLayerBaseline(env *config.TestEnvironment) {
+	if err := env.LoadAndRefresh(); err != nil {
+		log.Warnf("Cannot record the writable layer baseline: %v", err)
+		return
+	}
+	for id, cut := range env.ContainersUnderTest {
+		usedBytes, err := measureWritableLayer(env, cut)
+		if err != nil {
+			log.Warnf("Cannot measure the writable layer of %s/%s/%s: %v", id.Namespace, id.PodName, id.ContainerName, err)
+			continue
+		}
+		writableLayerBaseline[id] = usedBytes
+	}
+}
+
+// getContainerIDAndNode returns the id of cut, read from inside the container, and the node it runs on, which must have
+// a debug pod.
+func getContainerIDAndNode(env *config.TestEnvironment, cut *config.Container) (string, *config.NodeConfig, error) {
+	containerIDTester := containerid.NewContainerID(common.GetTimeout(common.PlatformAlterationTestKey, "containerid"))
+	test, err := tnf.NewTest(env.Context(), cut.Oc.GetExpecter(), containerIDTester, []reel.Handler{containerIDTester}, cut.Oc.GetErrorChannel())
+	if err != nil {
+		return "", nil, err
+	}
+	if err = test.RunAndCheck(nil); err != nil {
+		return "", nil, err
+	}
+	nodeName := cut.ContainerConfiguration.NodeName
+	node, ok := env.NodesUnderTest[nodeName]
+	if !ok || !node.HasDebugPod() {
+		return "", nil, fmt.Errorf("node %s has no debug pod", nodeName)
+	}
+	return containerIDTester.GetID(), node, nil
+}
+
+// measureWritableLayer returns the disk usage of the writable layer of cut, measured from the debug pod of its node.
+func measureWritableLayer(env *config.TestEnvironment, cut *config.Container) (uint64, error) {
+	containerID, node, err := getContainerIDAndNode(env, cut)
+	if err != nil {
+		return 0, err
+	}
+	tester := writablelayer.NewWritableLayer(common.GetTimeout(common.PlatformAlterationTestKey, "writablelayer"), containerID)
+	test, err := tnf.NewTest(env.Context(), node.Oc.GetExpecter(), tester, []reel.Handler{tester}, node.Oc.GetErrorChannel())
+	if err != nil {
+		return 0, err
+	}
+	if err = test.RunAndCheck(nil); err != nil {
+		return 0, err
+	}
+	return tester.GetUsedBytes(), nil
+}
+
+// testWritableLayerGrowth fails the containers whose writable layer grew by more than the accepted growth since the start
+// of the run, up to this test:  as the suites run in a random order, the growth caused by the tests running afterwards is
+// not measured.
+func testWritableLayerGrowth(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestWritableLayerGrowthIdentifier)
+	ginkgo.It(testID, func() {
+		if len(writableLayerBaseline) == 0 {
+			ginkgo.Skip("No writable layer could be measured at the start of the run")
+		}
+		maxGrowth := env.Config.WritableLayer.GetMaxGrowthBytes()
+		var badContainers, errContainers []string
+		var badPods []string
+		for id, cut := range env.ContainersUnderTest {
+			if env.SkipsContainerTest(testID, &cut.ContainerIdentifier) {
+				continue
+			}
+			name := fmt.Sprintf("%s/%s/%s", id.Namespace, id.PodName, id.ContainerName)
+			start, ok := writableLayerBaseline[id]
+			if !ok {
+				// e.g. the containers of the pods recreated by the intrusive tests.
+				log.Warnf("The writable layer of %s was not measured at the start of the run", name)
+				continue
+			}
+			end, err := measureWritableLayer(env, cut)
+			if err != nil {
+				log.Errorf("Cannot measure the writable layer of %s: %v", name, err)
+				errContainers = append(errContainers, name)
+				continue
+			}
+			log.Infof("The writable layer of %s went from %d to %d bytes", name, start, end)
+			if end > start && end-start > maxGrowth {
+				log.Errorf("The writable layer of %s grew by %d bytes, more than %d", name, end-start, maxGrowth)
+				badContainers = append(badContainers, name)
+				badPods = append(badPods, id.Namespace+"/"+id.PodName)
+			}
+		}
+		results.RecordFailedTargets(badPods...)
+		gomega.Expect(badContainers).To(gomega.BeEmpty())
+		gomega.Expect(errContainers).To(gomega.BeEmpty())
+	})
+}
+
+// testPidsLimit counts the processes of the containers under test, and fails the containers and pods which use more
+// than the accepted share of their pids limit.
+func testPidsLimit(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPidsLimitIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should keep the processes of the containers under test below their pids limit")
+		processCounts = nil
+		pidsLimit := &env.Config.PidsLimit
+		podPidsLimits := map[string]int{}
+		podProcesses := map[string]int{}
+		var badContainers, badPods []string
+		for id, cut := range env.ContainersUnderTest {
+			if env.SkipsContainerTest(testID, &cut.ContainerIdentifier) {
+				continue
+			}
+			tester := processcount.NewProcessCount(common.GetTimeout(common.PlatformAlterationTestKey, "processcount"))
+			test, err := tnf.NewTest(env.Context(), cut.Oc.GetExpecter(), tester, []reel.Handler{tester}, cut.Oc.GetErrorChannel())
+			gomega.Expect(err).To(gomega.BeNil())
+			common.RunAndValidateTest(test)
+			nodeName := cut.ContainerConfiguration.NodeName
+			podPidsLimit, ok := podPidsLimits[nodeName]
+			if !ok {
+				podPidsLimit = getPodPidsLimit(env, nodeName)
+				podPidsLimits[nodeName] = podPidsLimit
+			}
+			count := ProcessCount{
+				Pod:          id.Namespace + "/" + id.PodName,
+				Container:    id.ContainerName,
+				Processes:    tester.GetProcesses(),
+				Zombies:      tester.GetZombies(),
+				PidsLimit:    tester.GetPidsLimit(),
+				PodPidsLimit: podPidsLimit,
+			}
+			processCounts = append(processCounts, count)
+			podProcesses[count.Pod] += count.Processes
+			log.Infof("Container %s/%s runs %d processes, %d zombies, with a pids limit of %d", count.Pod, count.Container,
+				count.Processes, count.Zombies, count.PidsLimit)
+			if count.Zombies > 0 {
+				log.Warnf("Container %s/%s has %d zombie processes, which are not reaped", count.Pod, count.Container, count.Zombies)
+			}
+			if pidsLimit.ApproachesLimit(count.Processes, count.PidsLimit) {
+				log.Errorf("Container %s/%s runs %d processes, more than %d%% of its pids limit of %d", count.Pod,
+					count.Container, count.Processes, pidsLimit.GetMaxUsagePercent(), count.PidsLimit)
+				badContainers = append(badContainers, count.Pod+"/"+count.Container)
+			}
+		}
+		// the pod limit covers all the containers of a pod, only the containers under test are counted.
+		checkedPods := map[string]bool{}
+		for i := range processCounts {
+			count := &processCounts[i]
+			if checkedPods[count.Pod] {
+				continue
+			}
+			checkedPods[count.Pod] = true
+			if pidsLimit.ApproachesLimit(podProcesses[count.Pod], count.PodPidsLimit) {
+				log.Errorf("Pod %s runs %d processes, more than %d%% of its pids limit of %d", count.Pod,
+					podProcesses[count.Pod], pidsLimit.GetMaxUsagePercent(), count.PodPidsLimit)
+				badPods = append(badPods, count.Pod)
+			}
+		}
+		sort.Slice(processCounts, func(i, j int) bool {
+			if processCounts[i].Pod != processCounts[j].Pod {
+				return processCounts[i].Pod < processCounts[j].Pod
+			}
+			return processCounts[i].Container < processCounts[j].Container
+		})
+		results.RecordFailedTargets(badContainers...)
+		results.RecordFailedTargets(badPods...)
+		gomega.Expect(badContainers).To(gomega.BeEmpty())
+		gomega.Expect(badPods).To(gomega.BeEmpty())
+	})
+}
+
+// testDeprecatedAPIs checks that the CRDs and the operators under test do not use the APIs removed in the Kubernetes
+// releases after the version of the cluster.
+func testDeprecatedAPIs(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestDeprecatedAPIsIdentifier)
+	ginkgo.It(testID, func() {
+		if len(env.Crds) == 0 && len(env.OperatorsUnderTest) == 0 {
+			ginkgo.Skip("No CRD nor operator found.")
+		}
+		ginkgo.By("Should not use the APIs removed in the upcoming Kubernetes releases")
+		table, err := deprecatedapis.Load()
+		gomega.Expect(err).To(gomega.BeNil())
+		cluster := occompat.GetVersions().Server
+		upcoming := table.Upcoming(cluster)
+		log.Infof("Checking the APIs removed after Kubernetes %s, %d of %d", cluster, len(upcoming), len(table))
+		deprecatedAPIUsages = nil
+		var badResources []string
+		check := func(resource, command string, checkManifest func([]byte) ([]deprecatedapis.Usage, error)) {
+			manifest := common.ExecuteCommand(command, commandTimeout, common.GetContext(), nil)
+			usages, checkErr := checkManifest([]byte(manifest))
+			if checkErr != nil {
+				log.Errorf("Cannot check the APIs used by %s: %v", resource, checkErr)
+			}
+			for i := range usages {
+				log.Errorf("The %s", usages[i].String())
+			}
+			if len(usages) > 0 {
+				deprecatedAPIUsages = append(deprecatedAPIUsages, usages...)
+				badResources = append(badResources, resource)
+			}
+		}
+		for i := range env.Crds {
+			crdName := env.Crds[i].Name
+			check("crd/"+crdName, fmt.Sprintf("oc get crd %s -o json", crdName), upcoming.CheckCRD)
+		}
+		for _, op := range env.OperatorsUnderTest {
+			check(fmt.Sprintf("csv/%s/%s", op.Namespace, op.Name), fmt.Sprintf("oc get csv %s -n %s -o json", op.Name,
+				op.Namespace), upcoming.CheckCSV)
+		}
+		results.RecordFailedTargets(badResources...)
+		gomega.Expect(badResources).To(gomega.BeEmpty())
+	})
+}
+
+// getPodPidsLimit returns the pids limit of the pods set in the kubelet configuration of a node, 0 when unlimited or
+// when the node has no debug pod.
+func getPodPidsLimit(env *config.TestEnvironment, nodeName string) int {
+	node, ok := env.NodesUnderTest[nodeName]
+	if !ok || !node.HasDebugPod() {
+		return 0
+	}
+	context := interactive.NewContext(node.Oc.GetExpecter(), node.Oc.GetErrorChannel())
+	out := common.ExecuteCommand(podPidsLimitCommand, commandTimeout, context, nil)
+	matched := podPidsLimitRegex.FindStringSubmatch(out)
+	if matched == nil {
+		return 0
+	}
+	limit, err := strconv.Atoi(matched[1])
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// testSELinux reads, from the debug pod of their node, the SELinux label of the containers under test, and the
+// SELinux mode of the node.  It fails the nodes which do not enforce SELinux and the containers running with another
+// type than container_t, unless allowed.
+func testSELinux(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestSELinuxIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should run the containers under test as container_t on SELinux enforcing nodes")
+		seLinuxNodes = nil
+		allowlist := &env.Config.SELinux
+		byNode := map[string]*SELinuxNode{}
+		var badNodes, badContainers, errContainers []string
+		for id, cut := range env.ContainersUnderTest {
+			if env.SkipsContainerTest(testID, &cut.ContainerIdentifier) {
+				continue
+			}
+			name := id.Namespace + "/" + id.PodName + "/" + id.ContainerName
+			containerID, node, err := getContainerIDAndNode(env, cut)
+			if err != nil {
+				log.Errorf("Cannot find the container %s on its node: %v", name, err)
+				errContainers = append(errContainers, name)
+				continue
+			}
+			tester := selinux.NewSELinux(common.GetTimeout(common.PlatformAlterationTestKey, "selinux"), containerID)
+			test, err := tnf.NewTest(env.Context(), node.Oc.GetExpecter(), tester, []reel.Handler{tester}, node.Oc.GetErrorChannel())
+			gomega.Expect(err).To(gomega.BeNil())
+			if err = test.RunAndCheck(nil); err != nil {
+				log.Errorf("Cannot read the SELinux label of %s: %v", name, err)
+				errContainers = append(errContainers, name)
+				continue
+			}
+			nodeName := cut.ContainerConfiguration.NodeName
+			seLinuxNode, ok := byNode[nodeName]
+			if !ok {
+				seLinuxNode = &SELinuxNode{Node: nodeName, Mode: tester.GetMode()}
+				byNode[nodeName] = seLinuxNode
+				if seLinuxNode.Mode != selinux.Enforcing {
+					log.Errorf("Node %s runs SELinux in %s mode instead of %s", nodeName, seLinuxNode.Mode, selinux.Enforcing)
+					badNodes = append(badNodes, nodeName)
+				}
+			}
+			seLinuxNode.Containers = append(seLinuxNode.Containers, SELinuxContainer{
+				Pod:       id.Namespace + "/" + id.PodName,
+				Container: id.ContainerName,
+				Label:     tester.GetLabel(),
+			})
+			labelType := tester.GetLabelType()
+			container := id
+			if labelType == "" {
+				log.Errorf("Cannot read the SELinux label of %s, its process was not found", name)
+				errContainers = append(errContainers, name)
+			} else if !allowlist.AllowsLabelType(labelType, &container) {
+				log.Errorf("Container %s runs with the SELinux label %s instead of type %s", name, tester.GetLabel(),
+					configsections.DefaultSELinuxLabelType)
+				badContainers = append(badContainers, name)
+			}
+		}
+		for _, seLinuxNode := range byNode {
+			sort.Slice(seLinuxNode.Containers, func(i, j int) bool {
+				return seLinuxNode.Containers[i].Pod+"/"+seLinuxNode.Containers[i].Container <
+					seLinuxNode.Containers[j].Pod+"/"+seLinuxNode.Containers[j].Container
+			})
+			seLinuxNodes = append(seLinuxNodes, *seLinuxNode)
+		}
+		sort.Slice(seLinuxNodes, func(i, j int) bool { return seLinuxNodes[i].Node < seLinuxNodes[j].Node })
+		results.RecordFailedTargets(badNodes...)
+		results.RecordFailedTargets(badContainers...)
+		gomega.Expect(badNodes).To(gomega.BeEmpty())
+		gomega.Expect(badContainers).To(gomega.BeEmpty())
+		gomega.Expect(errContainers).To(gomega.BeEmpty())
+	})
+}
+
+// testImageProvenance reads the labels of the images of the containers under test, and reports the images which do not
+// set the OCI labels tracing them back to their sources.  It is informative: the missing labels are only reported.
+func testImageProvenance(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestImageProvenanceIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should trace the images under test back to their sources")
+		imageProvenance = nil
+		byImage := map[string]*ImageProvenance{}
+		var errContainers []string
+		for id, cut := range env.ContainersUnderTest {
+			if env.SkipsContainerTest(testID, &cut.ContainerIdentifier) {
+				continue
+			}
+			name := id.Namespace + "/" + id.PodName + "/" + id.ContainerName
+			containerID, node, err := getContainerIDAndNode(env, cut)
+			if err != nil {
+				log.Errorf("Cannot find the container %s on its node: %v", name, err)
+				errContainers = append(errContainers, name)
+				continue
+			}
+			tester := imagelabels.NewImageLabels(common.GetTimeout(common.PlatformAlterationTestKey, "imagelabels"), containerID)
+			test, err := tnf.NewTest(env.Context(), node.Oc.GetExpecter(), tester, []reel.Handler{tester}, node.Oc.GetErrorChannel())
+			gomega.Expect(err).To(gomega.BeNil())
+			if err = test.RunAndCheck(nil); err != nil {
+				log.Errorf("Cannot read the image labels of %s: %v", name, err)
+				errContainers = append(errContainers, name)
+				continue
+			}
+			provenance, ok := byImage[tester.GetImage()]
+			if !ok {
+				labels := tester.GetLabels()
+				provenance = &ImageProvenance{
+					Image:    tester.GetImage(),
+					Revision: labels[imagelabels.RevisionLabel],
+					Source:   labels[imagelabels.SourceLabel],
+					Version:  labels[imagelabels.VersionLabel],
+					Missing:  tester.GetMissingProvenance(),
+				}
+				byImage[provenance.Image] = provenance
+			}
+			provenance.Containers = append(provenance.Containers, name)
+		}
+		for _, provenance := range byImage {
+			sort.Strings(provenance.Containers)
+			if len(provenance.Missing) > 0 {
+				msg := fmt.Sprintf("The image %s of %s does not set the provenance labels %s", provenance.Image,
+					strings.Join(provenance.Containers, ", "), strings.Join(provenance.Missing, ", "))
+				log.Warn(msg)
+				if _, err := ginkgo.GinkgoWriter.Write([]byte(msg + "\n")); err != nil {
+					log.Errorf("Ginkgo writer could not write because: %s", err)
+				}
+			}
+			imageProvenance = append(imageProvenance, *provenance)
+		}
+		sort.Slice(imageProvenance, func(i, j int) bool { return imageProvenance[i].Image < imageProvenance[j].Image })
+		gomega.Expect(errContainers).To(gomega.BeEmpty())
+	})
+}
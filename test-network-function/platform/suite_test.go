@@ -20,9 +20,27 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 )
 
 func Test_printTainted(t *testing.T) {
 	assert.Equal(t, printTainted(2048), "workaround for bug in platform firmware applied, ")
 	assert.Equal(t, printTainted(32769), "proprietary module was loaded, kernel has been live patched, ")
 }
+
+func Test_getTestedContainers(t *testing.T) {
+	app := configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test-0", ContainerName: "app"}
+	sidecar := configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test-0", ContainerName: "istio-proxy"}
+	env := &config.TestEnvironment{ContainersUnderTest: map[configsections.ContainerIdentifier]*config.Container{
+		app:     {ContainerIdentifier: app},
+		sidecar: {ContainerIdentifier: sidecar},
+	}}
+	env.Config.ContainerTestSkips = configsections.ContainerTestSkips{"platform-alteration-*": {"tnf/*/istio-proxy"}}
+
+	// the opted out sidecar is not tested.
+	cuts := getTestedContainers(env, "platform-alteration-timezone")
+	assert.Len(t, cuts, 1)
+	assert.Equal(t, app, cuts[0].ContainerIdentifier)
+	assert.Len(t, getTestedContainers(env, "lifecycle-container-shutdown"), 2)
+}
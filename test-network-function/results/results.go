@@ -19,15 +19,28 @@ package results
 import (
 	"fmt"
 	"strings"
+	"sync"
 
+	"github.com/onsi/ginkgo"
 	ginkgoTypes "github.com/onsi/ginkgo/types"
 	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
 )
 
 // results is the results map
 var results = map[string][]claim.Result{}
 
+// failedTargets are the targets, e.g. pods or nodes, which failed each test case, by test case name.
+var failedTargets = map[string][]string{}
+
+// infraErrors are the infrastructure errors of the tests which could not execute, by test case name.
+var (
+	infraErrors     = map[string][]tnf.InfraError{}
+	infraErrorsLock sync.Mutex
+)
+
 // RecordResult is a hook provided to save aspects of the ginkgo.GinkgoTestDescription for a given claim.Identifier.
 // Multiple results for a given identifier are aggregated as an array under the same key.
 func RecordResult(report ginkgoTypes.SpecReport) { //nolint:gocritic // From Ginkgo
@@ -56,6 +69,45 @@ func RecordResult(report ginkgoTypes.SpecReport) { //nolint:gocritic // From Gin
 	}
 }
 
+// RecordFailedTargets records the targets, e.g. "namespace/pod" or node names, which failed the running spec, so that
+// the remediation summary points at the targets failing the most.  It must be called from the spec, not from the
+// RunInParallel workers.
+func RecordFailedTargets(targets ...string) {
+	if claimID, ok := identifiers.TestIDToClaimID[ginkgo.CurrentSpecReport().LeafNodeText]; ok {
+		name := groups.TestCaseName(&claimID)
+		failedTargets[name] = append(failedTargets[name], targets...)
+	}
+}
+
+// GetFailedTargets returns the targets recorded by RecordFailedTargets, by test case name.
+func GetFailedTargets() map[string][]string {
+	return failedTargets
+}
+
+// RecordInfraError records the infrastructure error of a test which could not execute during the running spec, so that
+// the claim tells the test cases which could not execute from the test cases which failed.  It is meant to be set with
+// tnf.SetInfraErrorHandler, and can thus be called by the RunInParallel workers.
+func RecordInfraError(infraErr *tnf.InfraError) {
+	if claimID, ok := identifiers.TestIDToClaimID[ginkgo.CurrentSpecReport().LeafNodeText]; ok {
+		name := groups.TestCaseName(&claimID)
+		infraErrorsLock.Lock()
+		defer infraErrorsLock.Unlock()
+		infraErrors[name] = append(infraErrors[name], *infraErr)
+	}
+}
+
+// GetInfraErrors returns the infrastructure errors recorded by RecordInfraError, by test case name.
+func GetInfraErrors() map[string][]tnf.InfraError {
+	infraErrorsLock.Lock()
+	defer infraErrorsLock.Unlock()
+	return infraErrors
+}
+
+// GetRecordedResults returns the results recorded so far, keyed by the spec hierarchy.
+func GetRecordedResults() map[string][]claim.Result {
+	return results
+}
+
 // GetReconciledResults is a function added to aggregate a Claim's results.  Due to the limitations of
 // test-network-function-claim's Go Client, results are generalized to map[string]interface{}.  This method is needed
 // to take the results gleaned from JUnit output, and to combine them with the contexts built up by subsequent calls to
@@ -0,0 +1,164 @@
+// Copyright (C) 2020-2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package securitycontext
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/securitycontext"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+	"github.com/test-network-function/test-network-function/test-network-function/common"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+	"github.com/test-network-function/test-network-function/test-network-function/results"
+)
+
+// restrictedCapabilities are the capabilities granting control over the node or the other workloads, which the
+// containers under test may only add when allowed by the securityContext section of the configuration.
+var restrictedCapabilities = map[string]bool{
+	"ALL":        true,
+	"BPF":        true,
+	"IPC_LOCK":   true,
+	"NET_ADMIN":  true,
+	"NET_RAW":    true,
+	"SYS_ADMIN":  true,
+	"SYS_MODULE": true,
+	"SYS_PTRACE": true,
+}
+
+var _ = ginkgo.Describe(common.SecurityContextTestKey, func() {
+	conf, _ := ginkgo.GinkgoConfiguration()
+	if testcases.IsInFocus(conf.FocusStrings, common.SecurityContextTestKey) {
+		env := config.GetTestEnvironment()
+		ginkgo.BeforeEach(func() {
+			gomega.Expect(env.LoadAndRefresh()).To(gomega.Succeed())
+			gomega.Expect(len(env.PodsUnderTest)).ToNot(gomega.Equal(0))
+		})
+
+		ginkgo.ReportAfterEach(results.RecordResult)
+
+		testPrivilegedContainers(env)
+
+		testAddedCapabilities(env)
+
+		testRunAsNonRoot(env)
+	}
+})
+
+func testPrivilegedContainers(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPrivilegedContainersIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should not run privileged containers unless allowed")
+		allowlist := &env.Config.SecurityContext
+		checkContainers(env, testID, func(id *configsections.ContainerIdentifier, container *securitycontext.ContainerSecurityContext) string {
+			if container.Privileged && !allowlist.AllowsPrivileged(id) {
+				return "runs privileged"
+			}
+			return ""
+		})
+	})
+}
+
+func testAddedCapabilities(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestAddedCapabilitiesIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should not add restricted capabilities unless allowed")
+		allowlist := &env.Config.SecurityContext
+		checkContainers(env, testID, func(id *configsections.ContainerIdentifier, container *securitycontext.ContainerSecurityContext) string {
+			if added := getRestrictedCapabilities(id, container, allowlist); len(added) > 0 {
+				return "adds the capabilities " + strings.Join(added, ", ")
+			}
+			return ""
+		})
+	})
+}
+
+func testRunAsNonRoot(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestRunAsNonRootIdentifier)
+	ginkgo.It(testID, func() {
+		ginkgo.By("Should set runAsNonRoot or a non-root runAsUser unless allowed")
+		allowlist := &env.Config.SecurityContext
+		checkContainers(env, testID, func(id *configsections.ContainerIdentifier, container *securitycontext.ContainerSecurityContext) string {
+			if !container.IsNonRoot() && !allowlist.AllowsRoot(id) {
+				return "may run as root"
+			}
+			return ""
+		})
+	})
+}
+
+// getRestrictedCapabilities returns the restricted capabilities added by the container which are not allowed.
+func getRestrictedCapabilities(id *configsections.ContainerIdentifier, container *securitycontext.ContainerSecurityContext,
+	allowlist *configsections.SecurityContext) []string {
+	var added []string
+	for _, capability := range container.AddedCapabilities {
+		if restrictedCapabilities[configsections.NormalizeCapability(capability)] && !allowlist.AllowsCapability(capability, id) {
+			added = append(added, capability)
+		}
+	}
+	return added
+}
+
+// checkContainers reads the security context of the containers of each pod under test, and fails the spec with the
+// containers for which violation returns a description, except the containers opted out of the test case testID.
+func checkContainers(env *config.TestEnvironment, testID string, violation func(id *configsections.ContainerIdentifier, container *securitycontext.ContainerSecurityContext) string) {
+	pods := env.PodsUnderTest
+	var mutex sync.Mutex
+	var badContainers []string
+	defer func() {
+		results.RecordFailedTargets(badContainers...)
+	}()
+	common.RunInParallel(len(pods), func(i int, context *interactive.Context) error {
+		pod := &pods[i]
+		tester := securitycontext.NewSecurityContext(common.GetTimeout(common.SecurityContextTestKey, "securitycontext"), pod.Name, pod.Namespace)
+		test, err := tnf.NewTest(env.Context(), context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+		if err != nil {
+			return err
+		}
+		if err := test.RunAndCheck(nil); err != nil {
+			return fmt.Errorf("pod %s: %w", pod.FullName(), err)
+		}
+		var violations []string
+		containers := tester.GetContainers()
+		for j := range containers {
+			id := &configsections.ContainerIdentifier{Namespace: pod.Namespace, PodName: pod.Name, ContainerName: containers[j].Name}
+			if env.SkipsContainerTest(testID, id) {
+				continue
+			}
+			if description := violation(id, &containers[j]); description != "" {
+				name := id.Namespace + "/" + id.PodName + "/" + id.ContainerName
+				log.Errorf("Container %s %s", name, description)
+				violations = append(violations, fmt.Sprintf("container %s %s", name, description))
+				mutex.Lock()
+				badContainers = append(badContainers, name)
+				mutex.Unlock()
+			}
+		}
+		if len(violations) > 0 {
+			return fmt.Errorf("%s", strings.Join(violations, "; "))
+		}
+		return nil
+	})
+}
@@ -17,10 +17,17 @@
 package suite
 
 import (
+	"context"
 	j "encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -30,22 +37,46 @@ import (
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/archive"
+	"github.com/test-network-function/test-network-function/pkg/canary"
+	tnfclaim "github.com/test-network-function/test-network-function/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claim/applications"
+	"github.com/test-network-function/test-network-function/pkg/claim/groups"
+	"github.com/test-network-function/test-network-function/pkg/claim/remediation"
+	"github.com/test-network-function/test-network-function/pkg/claim/rerun"
+	"github.com/test-network-function/test-network-function/pkg/claim/waiver"
 	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/dashboard"
+	"github.com/test-network-function/test-network-function/pkg/failurediag"
+	"github.com/test-network-function/test-network-function/pkg/images"
 	"github.com/test-network-function/test-network-function/pkg/junit"
+	"github.com/test-network-function/test-network-function/pkg/metrics"
+	"github.com/test-network-function/test-network-function/pkg/progress"
+	"github.com/test-network-function/test-network-function/pkg/release"
+	"github.com/test-network-function/test-network-function/pkg/sink"
+	"github.com/test-network-function/test-network-function/pkg/statebundle"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	tnfcommon "github.com/test-network-function/test-network-function/pkg/tnf/handlers/common"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/occompat"
+	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+	"github.com/test-network-function/test-network-function/pkg/upload"
 
 	utils "github.com/test-network-function/test-network-function/pkg/utils"
 	_ "github.com/test-network-function/test-network-function/test-network-function/accesscontrol"
-	_ "github.com/test-network-function/test-network-function/test-network-function/certification"
+	"github.com/test-network-function/test-network-function/test-network-function/certification"
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/diagnostic"
 	_ "github.com/test-network-function/test-network-function/test-network-function/generic"
-	_ "github.com/test-network-function/test-network-function/test-network-function/lifecycle"
-	_ "github.com/test-network-function/test-network-function/test-network-function/networking"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+	"github.com/test-network-function/test-network-function/test-network-function/lifecycle"
+	"github.com/test-network-function/test-network-function/test-network-function/networking"
 	_ "github.com/test-network-function/test-network-function/test-network-function/observability"
-	_ "github.com/test-network-function/test-network-function/test-network-function/operator"
-	_ "github.com/test-network-function/test-network-function/test-network-function/platform"
+	"github.com/test-network-function/test-network-function/test-network-function/operator"
+	"github.com/test-network-function/test-network-function/test-network-function/platform"
+	_ "github.com/test-network-function/test-network-function/test-network-function/securitycontext"
 )
 
 const (
@@ -56,6 +87,31 @@ const (
 	defaultClaimPath                     = ".."
 	defaultCliArgValue                   = ""
 	junitFlagKey                         = "junit"
+	junitPerSuiteFlagKey                 = "junit-per-suite"
+	rerunFailedFlagKey                   = "rerun-failed"
+	waiversFlagKey                       = "waivers"
+	deadlineFlagKey                      = "deadline"
+	imagesPreflightFlagKey               = "images-preflight"
+	canaryVerdictFlagKey                 = "canary-verdict"
+	releaseMetadataURLFlagKey            = "release-metadata-url"
+	requireCatalogVersionFlagKey         = "require-catalog-version"
+	stateBundlesFlagKey                  = "state-bundles"
+	dashboardFlagKey                     = "dashboard"
+	sessionTranscriptsFlagKey            = "session-transcripts"
+	failureDiagnosticsFlagKey            = "failure-diagnostics"
+	archiveFlagKey                       = "archive"
+	uploadFlagKey                        = "upload"
+	includeTagsFlagKey                   = "include-tags"
+	excludeTagsFlagKey                   = "exclude-tags"
+	testCasePacksFlagKey                 = "testcase-packs"
+	kubeconfigFlagKey                    = "kubeconfig"
+	kubeContextFlagKey                   = "kube-context"
+	clusterFlagKey                       = "cluster"
+	discoverySnapshotFlagKey             = "discovery-snapshot"
+	saveDiscoverySnapshotFlagKey         = "save-discovery-snapshot"
+	validateConfigFlagKey                = "validate-config"
+	setFlagKey                           = "set"
+	ginkgoFocusFlagKey                   = "ginkgo.focus"
 	TNFJunitXMLFileName                  = "cnf-certification-tests_junit.xml"
 	TNFReportKey                         = "cnf-certification-test"
 	CNFFeatureValidationJunitXMLFileName = "validation_junit.xml"
@@ -63,11 +119,137 @@ const (
 	// dateTimeFormatDirective is the directive used to format date/time according to ISO 8601.
 	dateTimeFormatDirective = "2006-01-02T15:04:05+00:00"
 	extraInfoKey            = "testsExtraInfo"
+	testGroupsKey           = "testGroups"
+	platformRequirementsKey = "platformRequirements"
+	failedTargetsKey        = "failedTargets"
+	canaryKey               = "canary"
+	throughputKey           = "throughput"
+	catalogVersionKey       = "catalogVersion"
+	infraErrorsKey          = "infrastructureErrors"
+	processCountsKey        = "processCounts"
+	seLinuxKey              = "selinux"
+	startupOrderingKey      = "startupOrdering"
+	applicationsKey         = "applications"
+	probesKey               = "probes"
+	egressKey               = "egress"
+	terminationTimeKey      = "terminationTime"
+	imageProvenanceKey      = "imageProvenance"
+	scalingKey              = "scaling"
+	labelDomainKey          = "labelDomain"
+	podDeleteRecoveryKey    = "podDeleteRecovery"
+	imageCertificationKey   = "imageCertification"
+	certifiedOperatorsKey   = "operatorCertification"
+	operatorGroupsKey       = "operatorGroups"
+	deprecatedAPIsKey       = "deprecatedAPIs"
+	clusterInfoKey          = "clusterInfo"
+	sessionTranscriptsKey   = "sessionTranscripts"
+	failureDiagnosticsKey   = "failureDiagnostics"
+	testCasePacksKey        = "testCasePacks"
+	discoverySnapshotKey    = "discoverySnapshot"
+	configOverridesKey      = "configurationOverrides"
+	configSourceKey         = "configurationSource"
+	// releaseMetadataTimeout bounds the request of the release metadata, the check is skipped in disconnected
+	// environments.
+	releaseMetadataTimeout = 10 * time.Second
+	// remediationTopTargets is the number of targets failing the most shown per theme in the remediation summary.
+	remediationTopTargets = 5
+	// stateBundlesDirName is the directory of the state bundles of the failed specs, in the claim directory.
+	stateBundlesDirName = "state-bundles"
+	// sessionTranscriptsDirName is the directory of the session transcripts of the specs, in the claim directory.
+	sessionTranscriptsDirName = "session-transcripts"
+	// failureDiagnosticsDirName is the directory of the diagnostics of the failed specs, in the claim directory.
+	failureDiagnosticsDirName = "failure-diagnostics"
+	// discoverySnapshotFileName is the file of the discovery snapshot of the run, in the claim directory.
+	discoverySnapshotFileName = "discovery-snapshot.json"
+	// dashboardLogFileName is the file of the logs while the dashboard is shown, in the claim directory.
+	dashboardLogFileName = "tnf-execution.log"
+	// dashboardTailLines is the number of lines of the output of the running test shown by the dashboard.
+	dashboardTailLines = 10
+	// progressEventsCloseTimeout bounds the wait for the progress events still queued at the end of the run.
+	progressEventsCloseTimeout = 30 * time.Second
+	// metricsReadHeaderTimeout bounds the reading of the headers of the requests of the metrics endpoint.
+	metricsReadHeaderTimeout = 10 * time.Second
+	// metricsPushTimeout bounds the push of the metrics to the Pushgateway.
+	metricsPushTimeout = time.Minute
+	// dashboardRefreshInterval is the interval between two redraws of the dashboard.
+	dashboardRefreshInterval = time.Second
 )
 
 var (
 	claimPath *string
 	junitPath *string
+	// junitPerSuite enables writing one JUnit XML report per test suite
+	junitPerSuite *bool
+	// rerunFailed is the path of a previous claim file whose failed tests only are run
+	rerunFailed *string
+	// includeTags and excludeTags select the test cases to run by their tags, comma-separated
+	includeTags *string
+	excludeTags *string
+	// allowIntrusive enables the intrusive and destructive tests
+	allowIntrusive *bool
+	// allowLoad enables the load-generating tests
+	allowLoad *bool
+	// waiversPath is the path of the waivers file accepting the risk of known failures
+	waiversPath *string
+	// deadline is the maximum duration of the run, the running tests are aborted once it expires
+	deadline *time.Duration
+	// imagesPreflight enables checking the auxiliary images can be pulled before the suites start
+	imagesPreflight *bool
+	// canaryVerdictPath is the path of the verdict of the reference workload run, recorded in the claim
+	canaryVerdictPath *string
+	// releaseMetadataURL is the URL of the release metadata the catalog version is checked against
+	releaseMetadataURL *string
+	// requireCatalogVersion is the minimum catalog version of an official run
+	requireCatalogVersion *string
+	// stateBundles enables persisting the state of each failed spec
+	stateBundles *bool
+	// dashboardEnabled shows the live dashboard of the run instead of the logs
+	dashboardEnabled *bool
+	// liveDashboard is the dashboard of the run, nil unless it is enabled
+	liveDashboard *dashboard.Dashboard
+	// stateBundleRecorder collects the transcripts of the tests of the running spec when stateBundles is set
+	stateBundleRecorder *statebundle.Recorder
+	// sessionTranscripts enables recording the traffic of the interactive sessions of each spec
+	sessionTranscripts *bool
+	// sessionTranscriptRecorder writes the session transcript of each spec when sessionTranscripts is set
+	sessionTranscriptRecorder *interactive.SessionTranscriptRecorder
+	// sessionTranscriptPaths are the session transcripts of the specs, relative to the claim directory, by test case
+	sessionTranscriptPaths = map[string][]string{}
+	// failureDiagnostics enables gathering diagnostics on the targets of the failed specs
+	failureDiagnostics *bool
+	// failureDiagnosticsCollector gathers the diagnostics of the failed specs when failureDiagnostics is set
+	failureDiagnosticsCollector *failurediag.Collector
+	// archiveEnabled enables packaging the artifacts of the run into a single archive at the end of the run
+	archiveEnabled *bool
+	// testCasePacksDir is the directory of the user-defined test case packs, run alongside the built-in test cases
+	testCasePacksDir *string
+	// kubeconfigPath and kubeContext are the kubeconfig file and context of the cluster under test, overriding the
+	// configuration
+	kubeconfigPath *string
+	kubeContext    *string
+	// clusterName selects the cluster under test among the clusters section of the configuration
+	clusterName *string
+	// discoverySnapshotPath is the path of a saved discovery snapshot replacing the autodiscovery
+	discoverySnapshotPath *string
+	// saveDiscoverySnapshot enables writing the discovery snapshot of the run into the claim path
+	saveDiscoverySnapshot *bool
+	// validateConfig enables validating the configuration file and exiting without running the tests
+	validateConfig *bool
+	// configSettings are the overrides of the fields of the configuration set with -set, as path=value
+	configSettings settingsFlag
+	// testCasePacks are the test case packs loaded from testCasePacksDir
+	testCasePacks []testcases.Pack
+	// uploadEnabled enables the upload of the claim to the collector of the claimUpload section at the end of the run
+	uploadEnabled *bool
+	// progressStreamer streams the lifecycle events of the specs when the progressEvents section configures a sink
+	progressStreamer *progress.Streamer
+	// progressStates counts the specs per state for the final progress event
+	progressStates = map[string]int{}
+	// runMetrics are the Prometheus metrics of the run when the metrics section configures an endpoint or a Pushgateway
+	runMetrics *metrics.Metrics
+	// failureDiagnosticsPaths are the diagnostics directories of the failed specs, relative to the claim directory, by
+	// test case
+	failureDiagnosticsPaths = map[string][]string{}
 	// GitCommit is the latest commit in the current git branch
 	GitCommit string
 	// GitRelease is the list of tags (if any) applied to the latest commit
@@ -86,6 +268,374 @@ func init() {
 		"the path where the claimfile will be output")
 	junitPath = flag.String(junitFlagKey, defaultCliArgValue,
 		"the path for the junit format report")
+	junitPerSuite = flag.Bool(junitPerSuiteFlagKey, false,
+		"write one <suite>_junit.xml report per test suite into the junit path")
+	includeTags = flag.String(includeTagsFlagKey, defaultCliArgValue,
+		"only run the test cases having one of these comma-separated tags, e.g. telco,security, see \"tnf catalog list\"")
+	excludeTags = flag.String(excludeTagsFlagKey, defaultCliArgValue,
+		"do not run the test cases having one of these comma-separated tags, e.g. intrusive")
+	testCasePacksDir = flag.String(testCasePacksFlagKey, defaultCliArgValue,
+		"the directory of the YAML and JSON files of user-defined test case packs, run alongside the built-in test cases")
+	rerunFailed = flag.String(rerunFailedFlagKey, defaultCliArgValue,
+		"the path of a previous claim file, only the tests that failed in it are run")
+	allowIntrusive = flag.Bool(common.AllowIntrusiveFlagKey, false,
+		"run the intrusive and destructive tests, e.g. deployment scaling and node draining, which are skipped otherwise")
+	allowLoad = flag.Bool(common.AllowLoadFlagKey, false,
+		"run the load-generating tests, e.g. the throughput measurement, which are skipped otherwise")
+	waiversPath = flag.String(waiversFlagKey, defaultCliArgValue,
+		"the path of a waivers file, failures matching an active waiver are reported as waived")
+	deadline = flag.Duration(deadlineFlagKey, 0,
+		"the maximum duration of the run, e.g. 2h, the running test is aborted and the remaining ones skipped once it expires")
+	imagesPreflight = flag.Bool(imagesPreflightFlagKey, false,
+		"check the auxiliary images the suites may deploy can be pulled before running them")
+	canaryVerdictPath = flag.String(canaryVerdictFlagKey, defaultCliArgValue,
+		"the path of the verdict of the reference workload run, as written by \"tnf run --canary\"")
+	releaseMetadataURL = flag.String(releaseMetadataURLFlagKey, release.DefaultMetadataURL,
+		"the URL of the release metadata the catalog version is checked against at startup, empty to skip the check")
+	requireCatalogVersion = flag.String(requireCatalogVersionFlagKey, defaultCliArgValue,
+		"the minimum catalog version of an official run, or \"published\" for the published certification policy version, "+
+			"the run does not start with an older catalog")
+	stateBundles = flag.Bool(stateBundlesFlagKey, false,
+		"write a bundle of the commands run, their outputs, the target objects and the environment of each failed spec "+
+			"into the state-bundles directory of the claim path")
+	sessionTranscripts = flag.Bool(sessionTranscriptsFlagKey, false,
+		"record the commands sent to the interactive sessions and their raw output, with timestamps, into one file per "+
+			"test in the session-transcripts directory of the claim path")
+	failureDiagnostics = flag.Bool(failureDiagnosticsFlagKey, false,
+		"gather the description, the recent logs and the events of the targets of each failed test into the "+
+			"failure-diagnostics directory of the claim path")
+	archiveEnabled = flag.Bool(archiveFlagKey, false,
+		"package the claim, the JUnit reports, the transcripts, the state bundles and the diagnostics of the run "+
+			"into a single timestamped tar.gz file with a manifest in the claim path")
+	uploadEnabled = flag.Bool(uploadFlagKey, false,
+		"upload the claim to the collector of the claimUpload section of the configuration at the end of the run, "+
+			"the claim is not uploaded by default")
+	kubeconfigPath = flag.String(kubeconfigFlagKey, defaultCliArgValue,
+		"the path of the kubeconfig file of the cluster under test, overriding the configuration and $KUBECONFIG")
+	kubeContext = flag.String(kubeContextFlagKey, defaultCliArgValue,
+		"the kubeconfig context of the cluster under test, overriding the configuration and the current context")
+	clusterName = flag.String(clusterFlagKey, defaultCliArgValue,
+		"the name of the cluster under test among the clusters section of the configuration, overriding targetCluster")
+	discoverySnapshotPath = flag.String(discoverySnapshotFlagKey, defaultCliArgValue,
+		"the path of a discovery snapshot saved by a previous run, the resources under test are taken from it instead of "+
+			"being discovered, e.g. to re-run the tests against the same resources")
+	saveDiscoverySnapshot = flag.Bool(saveDiscoverySnapshotFlagKey, false,
+		"write the result of the autodiscovery of the run into the "+discoverySnapshotFileName+" file of the claim path")
+	validateConfig = flag.Bool(validateConfigFlagKey, false,
+		"validate the configuration file, reporting its unknown fields, its values of the wrong type and its missing "+
+			"required fields, and exit without running the tests")
+	flag.Var(&configSettings, setFlagKey, "override a field of the configuration as path=value, e.g. "+
+		"timeouts.default=1m or targetPodLabels[0].value=target, taking precedence over the "+
+		configsections.OverrideEnvPrefix+" environment variables and the configuration file, may be repeated")
+	dashboardEnabled = flag.Bool(dashboardFlagKey, false,
+		"show a live dashboard of the run in the terminal, the logs are written to the "+dashboardLogFileName+
+			" file of the claim path instead")
+}
+
+// the transcripts of the tests run outside of the specs, e.g. by the BeforeSuite nodes, are not part of the bundles.
+var _ = ginkgo.ReportBeforeEach(func(report ginkgo.SpecReport) {
+	if stateBundleRecorder != nil {
+		stateBundleRecorder.Flush()
+	}
+	if liveDashboard != nil {
+		liveDashboard.SpecStarted(specSuite(report), report.LeafNodeText)
+	}
+	if progressStreamer != nil {
+		progressStreamer.Emit(newProgressEvent(progress.EventSpecStarted, report))
+	}
+})
+
+// the specs outside the cluster versions their test case supports, or requiring an OpenShift cluster on an upstream
+// Kubernetes cluster, are skipped before the setup of their suite.
+var _ = ginkgo.BeforeEach(func() {
+	if claimID, ok := identifiers.TestIDToClaimID[ginkgo.CurrentSpecReport().LeafNodeText]; ok {
+		autodiscover.DetectVersions()
+		common.SkipUnlessSupportedVersion(identifiers.GetSupportedVersions(claimID))
+		if identifiers.RequiresOpenShift(claimID) {
+			autodiscover.DetectFlavor()
+			common.SkipUnlessOpenShift()
+		}
+	}
+})
+
+// the transcripts are started once the specs are sure to run, the skipped and pending specs have none.
+var _ = ginkgo.BeforeEach(func() {
+	if sessionTranscriptRecorder != nil {
+		startSessionTranscript(ginkgo.CurrentSpecReport())
+	}
+})
+
+var _ = ginkgo.ReportAfterEach(recordStateBundle)
+
+var _ = ginkgo.ReportAfterEach(collectFailureDiagnostics)
+
+var _ = ginkgo.ReportAfterEach(func(report ginkgo.SpecReport) {
+	if sessionTranscriptRecorder == nil {
+		return
+	}
+	if err := sessionTranscriptRecorder.Stop(); err != nil {
+		log.Errorf("Cannot close the session transcript of %s: %v", report.FullText(), err)
+	}
+})
+
+var _ = ginkgo.ReportAfterEach(func(report ginkgo.SpecReport) {
+	if liveDashboard != nil {
+		liveDashboard.SpecFinished(specSuite(report), report.State.String())
+	}
+	if progressStreamer != nil {
+		event := newProgressEvent(progress.EventSpecFinished, report)
+		event.State = report.State.String()
+		event.DurationSeconds = report.RunTime.Seconds()
+		event.FailureMessage = report.FailureMessage()
+		progressStreamer.Emit(event)
+		progressStates[event.State]++
+	}
+	if runMetrics != nil {
+		runMetrics.RecordTest(specSuite(report), report.State.String(), report.RunTime)
+		recordDiscoveryMetrics()
+	}
+})
+
+// recordDiscoveryMetrics records the sizes of the discovery in the metrics of the run.
+func recordDiscoveryMetrics() {
+	env := config.GetTestEnvironment()
+	runMetrics.SetDiscovered("pods", len(env.PodsUnderTest))
+	runMetrics.SetDiscovered("containers", len(env.ContainersUnderTest))
+	runMetrics.SetDiscovered("deployments", len(env.DeploymentsUnderTest))
+	runMetrics.SetDiscovered("statefulsets", len(env.StatefulSetsUnderTest))
+	runMetrics.SetDiscovered("operators", len(env.OperatorsUnderTest))
+	runMetrics.SetDiscovered("nodes", len(env.NodesUnderTest))
+}
+
+// startMetrics collects the metrics of the run and serves them on the endpoint of the metrics section of the
+// configuration, if any.  The returned function pushes the final metrics to the Pushgateway of the section, if any,
+// and stops the endpoint once the linger duration elapsed.  In the event of an error, this method fatally fails.
+func startMetrics() func() {
+	testConfig, err := config.ReadConfigurationFile()
+	if err != nil {
+		log.Fatalf("Error reading the configuration of the metrics: %v", err)
+	}
+	conf := testConfig.Metrics
+	if conf.ListenAddress == "" && conf.PushgatewayURL == "" {
+		return func() {}
+	}
+	runMetrics = metrics.New()
+	var server *http.Server
+	if conf.ListenAddress != "" {
+		mux := http.NewServeMux()
+		mux.Handle(metrics.Path, runMetrics)
+		server = &http.Server{Addr: conf.ListenAddress, Handler: mux, ReadHeaderTimeout: metricsReadHeaderTimeout}
+		go func() {
+			log.Infof("Serving the metrics on %s%s", conf.ListenAddress, metrics.Path)
+			if serveErr := server.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+				log.Errorf("Error serving the metrics: %v", serveErr)
+			}
+		}()
+	}
+	return func() {
+		runMetrics.Finish(time.Now())
+		if conf.PushgatewayURL != "" {
+			client := &http.Client{Timeout: metricsPushTimeout}
+			if pushErr := runMetrics.Push(client, conf.PushgatewayURL, conf.GetJob()); pushErr != nil {
+				log.Errorf("Error pushing the metrics: %v", pushErr)
+			} else {
+				log.Infof("Metrics pushed to %s", conf.PushgatewayURL)
+			}
+		}
+		if server == nil {
+			return
+		}
+		if conf.Linger > 0 {
+			log.Infof("Serving the final metrics for %s", conf.Linger)
+			time.Sleep(conf.Linger)
+		}
+		_ = server.Close()
+	}
+}
+
+// newProgressEvent returns the progress event of a spec.
+func newProgressEvent(kind string, report ginkgo.SpecReport) *progress.Event { //nolint:gocritic // From Ginkgo
+	event := &progress.Event{Event: kind, Time: time.Now().UTC(), Suite: specSuite(report), Spec: report.LeafNodeText}
+	if claimID, ok := identifiers.TestIDToClaimID[report.LeafNodeText]; ok {
+		event.TestCase = groups.TestCaseName(&claimID)
+	}
+	return event
+}
+
+// startProgressEvents streams the lifecycle events of the specs to the sinks of the progressEvents section of the
+// configuration, if any, until the returned function is called.  In the event of an error, this method fatally fails.
+func startProgressEvents() func() {
+	testConfig, err := config.ReadConfigurationFile()
+	if err != nil {
+		log.Fatalf("Error reading the configuration of the progress events: %v", err)
+	}
+	sinks, err := progress.NewSinks(&testConfig.ProgressEvents, os.Getenv)
+	if err != nil {
+		log.Fatalf("Error configuring the progress events: %v", err)
+	}
+	if len(sinks) == 0 {
+		return func() {}
+	}
+	progressStreamer = progress.NewStreamer(sinks...)
+	progressStreamer.Emit(&progress.Event{Event: progress.EventRunStarted, Time: time.Now().UTC()})
+	return func() {
+		progressStreamer.Emit(&progress.Event{Event: progress.EventRunFinished, Time: time.Now().UTC(),
+			States: progressStates})
+		progressStreamer.Close(progressEventsCloseTimeout)
+	}
+}
+
+// specSuite returns the name of the suite of a spec, i.e. its outermost container.
+func specSuite(report ginkgo.SpecReport) string {
+	if len(report.ContainerHierarchyTexts) == 0 {
+		return CnfCertificationTestSuiteName
+	}
+	return report.ContainerHierarchyTexts[0]
+}
+
+// startDashboard shows the live dashboard of the run until the returned function is called, the logs are written to
+// the dashboard log file of the claim path meanwhile.  In the event of an error, this method fatally fails.
+func startDashboard(ctx context.Context) func() {
+	logFilePath := filepath.Join(*claimPath, dashboardLogFileName)
+	logFile, err := os.Create(logFilePath)
+	if err != nil {
+		log.Fatalf("Error creating the log file %s: %v", logFilePath, err)
+	}
+	log.Infof("Showing the dashboard, the logs are written to %s", logFilePath)
+	log.SetOutput(logFile)
+
+	liveDashboard = dashboard.New(dashboardTailLines)
+	tnf.SetExchangeHandler(liveDashboard.RecordExchange)
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		liveDashboard.Run(ctx, os.Stdout, dashboardRefreshInterval, func() int {
+			return dashboard.TerminalWidth(os.Stdout)
+		})
+	}()
+	return func() {
+		cancel()
+		<-done
+		tnf.SetExchangeHandler(nil)
+		log.SetOutput(os.Stderr)
+		logFile.Close()
+	}
+}
+
+// checkImages checks the auxiliary images of the manifest can be pulled.  In the event of an error, this method fatally
+// fails.
+func checkImages() {
+	if occompat.UsesKubectl() {
+		log.Warn("Cannot check the auxiliary images without oc, kubectl has no equivalent of \"oc image info\"")
+		return
+	}
+	manifest := images.GetManifest()
+	errs := images.Check(manifest, images.OcImageInfo)
+	for _, err := range errs {
+		log.Error(err)
+	}
+	if len(errs) > 0 {
+		log.Fatalf("%d of %d auxiliary images cannot be pulled, see \"tnf images list\"", len(errs), len(manifest))
+	}
+	log.Infof("All %d auxiliary images can be pulled", len(manifest))
+}
+
+// newTestContext returns the context of the tests, canceled on SIGINT or SIGTERM, or once the deadline expires
+// unless it is zero.
+func newTestContext(deadline time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	if deadline == 0 {
+		return ctx, stop
+	}
+	ctx, cancel := context.WithTimeout(ctx, deadline)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// focusOnFailedTests sets the Ginkgo focus to the tests that failed in the given claim file.  It returns false when
+// there is nothing to re-run.  In the event of an error, this method fatally fails.
+func focusOnFailedTests(claimFilePath string) bool {
+	suiteConfig, _ := ginkgo.GinkgoConfiguration()
+	if len(suiteConfig.FocusStrings) > 0 {
+		log.Fatalf("-%s cannot be combined with -%s", rerunFailedFlagKey, ginkgoFocusFlagKey)
+	}
+	focus, err := rerun.FocusStringsFromFile(claimFilePath)
+	if err != nil {
+		log.Fatalf("Error reading failed tests from %s: %v", claimFilePath, err)
+	}
+	if len(focus) == 0 {
+		return false
+	}
+	setFocus(focus)
+	log.Infof("Re-running the failed tests of %s, focus: %v", claimFilePath, focus)
+	return true
+}
+
+// registerTestCasePacks loads the test case packs of dir and registers them alongside the built-in test cases, the
+// run does not start with an invalid pack.
+func registerTestCasePacks(dir string) {
+	packs, err := testcases.LoadPacks(dir, common.RelativePackSchemaPath)
+	if err != nil {
+		log.Fatalf("Cannot load the test case packs of %s: %v", dir, err)
+	}
+	for i := range packs {
+		if err = testcases.RegisterPack(&packs[i]); err != nil {
+			log.Fatalf("Cannot register the test case pack %s: %v", packs[i].Name, err)
+		}
+		log.Infof("Registered the test case pack %s with %d test cases", packs[i].Name, len(packs[i].TestCase))
+	}
+	testCasePacks = packs
+}
+
+// focusOnTags sets the Ginkgo focus to the test cases having one of the comma-separated include tags, or to all the
+// test cases when empty, and none of the exclude tags.  It returns false when no test case matches.  In the event of an
+// error, this method fatally fails.
+func focusOnTags(include, exclude string) bool {
+	suiteConfig, _ := ginkgo.GinkgoConfiguration()
+	if len(suiteConfig.FocusStrings) > 0 {
+		log.Fatalf("-%s and -%s cannot be combined with -%s", includeTagsFlagKey, excludeTagsFlagKey,
+			ginkgoFocusFlagKey)
+	}
+	known := identifiers.GetKnownTags()
+	includeTags, err := testcases.ParseTags(splitTags(include), known)
+	if err != nil {
+		log.Fatalf("Invalid -%s: %v", includeTagsFlagKey, err)
+	}
+	excludeTags, err := testcases.ParseTags(splitTags(exclude), known)
+	if err != nil {
+		log.Fatalf("Invalid -%s: %v", excludeTagsFlagKey, err)
+	}
+	selected := testcases.SelectByTags(identifiers.GetTaggedTestCases(), includeTags, excludeTags)
+	if len(selected) == 0 {
+		return false
+	}
+	setFocus(testcases.FocusStrings(selected))
+	log.Infof("Running the %d test cases matching the tags", len(selected))
+	return true
+}
+
+// setFocus sets the Ginkgo focus to the regular expressions of focus.  In the event of an error, this method fatally
+// fails.
+func setFocus(focus []string) {
+	for _, f := range focus {
+		if err := flag.Set(ginkgoFocusFlagKey, f); err != nil {
+			log.Fatalf("Error setting the Ginkgo focus: %v", err)
+		}
+	}
+}
+
+// splitTags returns the comma-separated tags of list.
+func splitTags(list string) []string {
+	var tags []string
+	for _, tag := range strings.Split(list, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
 }
 
 // createClaimRoot creates the claim based on the model created in
@@ -121,6 +671,10 @@ func loadJUnitXMLIntoMap(result map[string]interface{}, junitFilename, key strin
 func TestTest(t *testing.T) {
 	// set up input flags and register failure handlers.
 	flag.Parse()
+	// the overrides apply to all the readings of the configuration, the log level included.
+	if err := config.SetConfigurationOverrides(configSettings); err != nil {
+		log.Fatalf("Invalid -%s: %v", setFlagKey, err)
+	}
 
 	// Checking if output directories exist
 	utils.CheckFileExists(*claimPath, "claim")
@@ -142,8 +696,38 @@ func TestTest(t *testing.T) {
 		gitDisplayRelease = GitRelease
 	}
 	log.Info("Version: ", gitDisplayRelease, " ( ", GitCommit, " )")
+	if *validateConfig {
+		validateConfiguration()
+		return
+	}
+	checkCatalogVersion()
+	removeKubeconfig := selectCluster()
+	defer removeKubeconfig()
+	if *discoverySnapshotPath != "" {
+		if err := autodiscover.LoadSnapshot(*discoverySnapshotPath); err != nil {
+			log.Fatalf("Error loading the discovery snapshot: %v", err)
+		}
+	}
 
 	tnfcommon.OcDebugImageID = common.GetOcDebugImageID()
+	common.AllowIntrusive = *allowIntrusive
+	common.AllowLoad = *allowLoad
+	if *testCasePacksDir != "" {
+		registerTestCasePacks(*testCasePacksDir)
+	}
+
+	if *rerunFailed != "" && !focusOnFailedTests(*rerunFailed) {
+		log.Infof("No failed test found in %s, nothing to re-run", *rerunFailed)
+		return
+	}
+	if (*includeTags != "" || *excludeTags != "") && !focusOnTags(*includeTags, *excludeTags) {
+		log.Infof("No test case matches the tags, nothing to run")
+		return
+	}
+
+	if *imagesPreflight {
+		checkImages()
+	}
 
 	// Initialize the claim with the start time, tnf version, etc.
 	claimRoot := createClaimRoot()
@@ -151,9 +735,48 @@ func TestTest(t *testing.T) {
 	claimData.Configurations = make(map[string]interface{})
 	claimData.Nodes = make(map[string]interface{})
 
+	// the tests are aborted, and their sessions closed, on SIGINT or once the deadline expires.
+	ctx, cancel := newTestContext(*deadline)
+	defer cancel()
+	config.GetTestEnvironment().SetContext(ctx)
+	// the tests which could not execute are recorded with the category of their error.
+	tnf.SetInfraErrorHandler(results.RecordInfraError)
+	if *stateBundles {
+		stateBundleRecorder = statebundle.NewRecorder(filepath.Join(*claimPath, stateBundlesDirName))
+		tnf.SetTranscriptHandler(stateBundleRecorder.RecordTranscript)
+	}
+	if *sessionTranscripts {
+		sessionTranscriptRecorder = interactive.NewSessionTranscriptRecorder(filepath.Join(*claimPath,
+			sessionTranscriptsDirName))
+	}
+	if *failureDiagnostics {
+		failureDiagnosticsCollector = failurediag.NewCollector(filepath.Join(*claimPath, failureDiagnosticsDirName),
+			statebundle.RunOc)
+	}
+
+	stopDashboard := func() {}
+	if *dashboardEnabled {
+		stopDashboard = startDashboard(ctx)
+	}
+	stopProgressEvents := startProgressEvents()
+	stopMetrics := startMetrics()
+
 	// run the test suite
 	ginkgo.RunSpecs(t, CnfCertificationTestSuiteName)
 	endTime := time.Now()
+	if sessionTranscriptRecorder != nil {
+		if err := sessionTranscriptRecorder.Stop(); err != nil {
+			log.Errorf("Cannot close the session transcript: %v", err)
+		}
+	}
+	stopProgressEvents()
+	stopDashboard()
+	common.CloseSessions()
+	if *saveDiscoverySnapshot {
+		if err := autodiscover.SaveSnapshot(filepath.Join(*claimPath, discoverySnapshotFileName)); err != nil {
+			log.Errorf("Cannot save the discovery snapshot: %v", err)
+		}
+	}
 
 	incorporateVersions(claimData)
 	// process the test results from this test suite, the cnf-features-deploy test suite, and any extra informational
@@ -166,7 +789,96 @@ func TestTest(t *testing.T) {
 
 	// fill out the remaining claim information.
 	claimData.RawResults = junitMap
+	var expiredWaivers []waiver.Waiver
+	if *waiversPath != "" {
+		junitMap[waiver.RawResultsKey], expiredWaivers = applyWaivers(*waiversPath, endTime)
+	}
 	claimData.Results = results.GetReconciledResults()
+	if summaries := summarizeTestGroups(); len(summaries) > 0 {
+		junitMap[testGroupsKey] = summaries
+	}
+	printRemediationSummary()
+	if summaries := summarizeApplications(); len(summaries) > 0 {
+		junitMap[applicationsKey] = summaries
+	}
+	if *canaryVerdictPath != "" {
+		junitMap[canaryKey] = loadCanaryVerdict(*canaryVerdictPath)
+	}
+	if table := platform.GetPlatformRequirements(); len(table) > 0 {
+		junitMap[platformRequirementsKey] = table
+	}
+	junitMap[catalogVersionKey] = identifiers.CatalogVersion
+	junitMap[tnfclaim.FormatVersionKey] = tnfclaim.FormatVersion
+	if len(testCasePacks) > 0 {
+		junitMap[testCasePacksKey] = testCasePacks
+	}
+	if info := diagnostic.GetClusterInfo(); info.K8sVersion != "" {
+		junitMap[clusterInfoKey] = info
+	}
+	junitMap[labelDomainKey] = autodiscover.GetLabelDomain()
+	if *discoverySnapshotPath != "" {
+		junitMap[discoverySnapshotKey] = *discoverySnapshotPath
+	}
+	if source := config.GetConfigurationFilePath(); config.IsRemoteConfigurationSource(source) {
+		junitMap[configSourceKey] = source
+	}
+	if overrides := config.GetConfigurationOverrides(); len(overrides) > 0 {
+		junitMap[configOverridesKey] = overrides
+	}
+	if measurements := networking.GetThroughput(); len(measurements) > 0 {
+		junitMap[throughputKey] = measurements
+	}
+	if destinations := networking.GetEgress(); len(destinations) > 0 {
+		junitMap[egressKey] = destinations
+	}
+	if counts := platform.GetProcessCounts(); len(counts) > 0 {
+		junitMap[processCountsKey] = counts
+	}
+	if nodes := platform.GetSELinuxNodes(); len(nodes) > 0 {
+		junitMap[seLinuxKey] = nodes
+	}
+	if provenance := platform.GetImageProvenance(); len(provenance) > 0 {
+		junitMap[imageProvenanceKey] = provenance
+	}
+	if usages := platform.GetDeprecatedAPIUsages(); len(usages) > 0 {
+		junitMap[deprecatedAPIsKey] = usages
+	}
+	if certifications := certification.GetImageCertifications(); len(certifications) > 0 {
+		junitMap[imageCertificationKey] = certifications
+	}
+	if certifications := certification.GetOperatorCertifications(); len(certifications) > 0 {
+		junitMap[certifiedOperatorsKey] = certifications
+	}
+	if coverages := operator.GetOperatorGroupCoverages(); len(coverages) > 0 {
+		junitMap[operatorGroupsKey] = coverages
+	}
+	if timings := lifecycle.GetScalingTimings(); len(timings) > 0 {
+		junitMap[scalingKey] = timings
+	}
+	if recoveries := lifecycle.GetPodDeleteRecoveries(); len(recoveries) > 0 {
+		junitMap[podDeleteRecoveryKey] = recoveries
+	}
+	if ordering := lifecycle.GetStartupOrdering(); ordering != nil {
+		junitMap[startupOrderingKey] = ordering
+	}
+	if shutdown := lifecycle.GetTerminationTime(); shutdown != nil {
+		junitMap[terminationTimeKey] = shutdown
+	}
+	if workloadProbes := lifecycle.GetProbes(); workloadProbes != nil {
+		junitMap[probesKey] = workloadProbes
+	}
+	if failedTargets := results.GetFailedTargets(); len(failedTargets) > 0 {
+		junitMap[failedTargetsKey] = failedTargets
+	}
+	if infraErrors := results.GetInfraErrors(); len(infraErrors) > 0 {
+		junitMap[infraErrorsKey] = infraErrors
+	}
+	if len(sessionTranscriptPaths) > 0 {
+		junitMap[sessionTranscriptsKey] = sessionTranscriptPaths
+	}
+	if len(failureDiagnosticsPaths) > 0 {
+		junitMap[failureDiagnosticsKey] = failureDiagnosticsPaths
+	}
 	configurations := marshalConfigurations()
 	claimData.Nodes = generateNodes()
 	unmarshalConfigurations(configurations, claimData.Configurations)
@@ -176,11 +888,249 @@ func TestTest(t *testing.T) {
 	payload := marshalClaimOutput(claimRoot)
 	claimOutputFile := filepath.Join(*claimPath, claimFileName)
 	writeClaimOutput(claimOutputFile, payload)
+
+	if *junitPerSuite {
+		writeSuiteJUnitReports(*junitPath)
+	}
+	if *archiveEnabled {
+		writeArchive(endTime)
+	}
+	for _, err := range publishArtifacts(payload) {
+		t.Errorf("Error publishing the artifacts: %v", err)
+	}
+	if *uploadEnabled {
+		if err := uploadClaim(payload); err != nil {
+			t.Errorf("Error uploading the claim: %v", err)
+		}
+	}
+	stopMetrics()
+
+	for i := range expiredWaivers {
+		t.Errorf("Waiver for %s expired on %s: %s", expiredWaivers[i].TestID, expiredWaivers[i].Expiry,
+			expiredWaivers[i].Justification)
+	}
+}
+
+// applyWaivers turns the recorded failures matching an active waiver of the waivers file into waived results.  It
+// returns the active waivers, to be recorded in the claim, and the expired ones.  In the event of an error, this method
+// fatally fails.
+func applyWaivers(filePath string, now time.Time) ([]waiver.Applied, []waiver.Waiver) {
+	waivers, err := waiver.LoadFile(filePath)
+	if err != nil {
+		log.Fatalf("Error reading the waivers: %v", err)
+	}
+	applied, expired := waiver.Apply(waivers, results.GetRecordedResults(), results.GetFailedTargets(), now)
+	for i := range applied {
+		log.Infof("Waiver for %s (target: %q, expiry: %s) waived %v", applied[i].TestID, applied[i].Target,
+			applied[i].Expiry, applied[i].WaivedTests)
+	}
+	for i := range expired {
+		log.Errorf("Waiver for %s expired on %s", expired[i].TestID, expired[i].Expiry)
+	}
+	return applied, expired
+}
+
+// writeSuiteJUnitReports writes one JUnit XML report per test suite.  In the event of an error, this method fatally
+// fails.
+func writeSuiteJUnitReports(outputDir string) {
+	files, err := junit.WriteSuiteReports(results.GetRecordedResults(), outputDir)
+	if err != nil {
+		log.Fatalf("Error writing per suite JUnit reports: %v", err)
+	}
+	for _, f := range files {
+		log.Infof("JUnit report written: %s", f)
+	}
+}
+
+// writeArchive packages the artifacts of the run into an archive of the claim directory named after endTime.  In the
+// event of an error, this method fatally fails.
+func writeArchive(endTime time.Time) {
+	sources := []archive.Source{{Path: filepath.Join(*claimPath, claimFileName), Name: claimFileName}}
+	reports, err := filepath.Glob(filepath.Join(*junitPath, "*.xml"))
+	if err != nil {
+		log.Fatalf("Error listing the JUnit reports to archive: %v", err)
+	}
+	for _, report := range reports {
+		sources = append(sources, archive.Source{Path: report, Name: filepath.Base(report)})
+	}
+	for _, name := range []string{stateBundlesDirName, sessionTranscriptsDirName, failureDiagnosticsDirName,
+		dashboardLogFileName} {
+		sources = append(sources, archive.Source{Path: filepath.Join(*claimPath, name), Name: name})
+	}
+	archivePath := filepath.Join(*claimPath, archive.FileName(endTime))
+	manifest, err := archive.Write(archivePath, endTime, sources)
+	if err != nil {
+		log.Fatalf("Error writing the archive of the artifacts: %v", err)
+	}
+	log.Infof("Archive of %d artifacts written: %s", len(manifest.Files), archivePath)
+}
+
+// uploadClaim POSTs the claim to the collector of the configuration.
+func uploadClaim(claimPayload []byte) error {
+	conf := &config.GetTestEnvironment().Config.ClaimUpload
+	client, err := upload.New(conf, os.Getenv)
+	if err != nil {
+		return err
+	}
+	log.Infof("Uploading the claim to %s", client.URL)
+	if err = client.Upload(claimPayload); err != nil {
+		return err
+	}
+	log.Infof("Claim uploaded to %s", client.URL)
+	return nil
+}
+
+// publishArtifacts writes the claim and the JUnit reports to the output sinks of the configuration, if any, and returns
+// the errors of the sinks which failed.  The local files are written regardless.
+func publishArtifacts(claimPayload []byte) []error {
+	sinks, err := sink.NewAll(config.GetTestEnvironment().Config.OutputSinks, os.Getenv)
+	if err != nil {
+		return []error{err}
+	}
+	if len(sinks) == 0 {
+		return nil
+	}
+	log.Infof("Publishing the artifacts to %v", sinks)
+	errs := sink.WriteAll(sinks, claimFileName, claimPayload)
+	reports, err := filepath.Glob(filepath.Join(*junitPath, "*.xml"))
+	if err != nil {
+		return append(errs, err)
+	}
+	for _, report := range reports {
+		payload, err := os.ReadFile(report)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		errs = append(errs, sink.WriteAll(sinks, filepath.Base(report), payload)...)
+	}
+	return errs
+}
+
+// summarizeTestGroups breaks down the results per test group of the configuration, and logs each group summary.  In
+// the event of an error, this method fatally fails.
+func summarizeTestGroups() []groups.Summary {
+	summaries, err := groups.Summarize(config.GetTestEnvironment().Config.TestGroups, results.GetRecordedResults())
+	if err != nil {
+		log.Fatalf("Error summarizing the test groups: %v", err)
+	}
+	for _, s := range summaries {
+		log.Infof("Test group %s (owners: %v): %d passed, %d failed, %d skipped", s.Name, s.Owners, s.Passed, s.Failed, s.Skipped)
+	}
+	return summaries
+}
+
+// summarizeApplications breaks down the results per application of the pods under test, as grouped by the configured
+// grouping label, and prints the pass rate of each application on the console.
+func summarizeApplications() []applications.Summary {
+	env := config.GetTestEnvironment()
+	summaries := applications.Summarize(env.Config.Applications.Group(env.PodsUnderTest), results.GetRecordedResults(),
+		results.GetFailedTargets())
+	applications.Print(os.Stdout, summaries)
+	return summaries
+}
+
+// loadCanaryVerdict loads the verdict of the reference workload run, and warns on the console when the environment is
+// suspect.  In the event of an error, this method fatally fails.
+func loadCanaryVerdict(path string) *canary.Verdict {
+	verdict, err := canary.ReadVerdict(path)
+	if err != nil {
+		log.Fatalf("error reading the canary verdict: %v", err)
+	}
+	if verdict.IsSuspect() {
+		fmt.Printf("Environment suspect: %s, the failures may come from the cluster or the test tooling rather than "+
+			"from the CNF.\n", verdict.Reason)
+	}
+	return verdict
+}
+
+// printRemediationSummary prints the failures grouped by remediation theme on the console, with the targets failing
+// the most, so that users know what to fix first without reading the claim.
+func printRemediationSummary() {
+	summaries := remediation.Summarize(results.GetRecordedResults(), identifiers.GetRemediationTheme,
+		results.GetFailedTargets(), remediationTopTargets)
+	remediation.Print(os.Stdout, summaries)
+}
+
+// selectCluster makes the oc commands of the run access the cluster selected by the -cluster flag or by the
+// configuration, the -kubeconfig and -kube-context flags taking precedence over its kubeconfig and context, and spawns
+// the sessions through its bastion, if any.  It returns the function removing the kubeconfig files it writes, see
+// config.UseCluster.  In the event of an error, this method fatally fails.
+func selectCluster() (removeKubeconfig func()) {
+	testConfig, err := config.ReadConfigurationFile()
+	if err != nil {
+		log.Fatalf("Error reading the configuration of the cluster under test: %v", err)
+	}
+	cluster, err := testConfig.SelectCluster(*clusterName)
+	if err != nil {
+		log.Fatalf("Error selecting the cluster under test: %v", err)
+	}
+	if *kubeconfigPath != "" {
+		cluster.Kubeconfig = *kubeconfigPath
+	}
+	if *kubeContext != "" {
+		cluster.Context = *kubeContext
+	}
+	removeKubeconfig, err = config.UseCluster(cluster)
+	if err != nil {
+		log.Fatalf("Error selecting the kubeconfig of the cluster under test: %v", err)
+	}
+	return removeKubeconfig
+}
+
+// settingsFlag is a flag which may be repeated, e.g. -set a=1 -set b=2.
+type settingsFlag []string
+
+func (s *settingsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *settingsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// validateConfiguration validates the configuration file, fatally failing when it has problems, see
+// configsections.Validate.
+func validateConfiguration() {
+	filePath := config.GetConfigurationFilePath()
+	if err := config.CheckConfigurationFile(filePath); err != nil {
+		log.Fatal(err)
+	}
+	log.Infof("The configuration file %s is valid", filePath)
+}
+
+// checkCatalogVersion warns when the catalog is older than the published certification policy version, and fatally
+// fails when it is older than the required catalog version, if any.
+func checkCatalogVersion() {
+	log.Info("Catalog version: ", identifiers.CatalogVersion)
+	var metadata *release.Metadata
+	if *releaseMetadataURL != "" {
+		var err error
+		metadata, err = release.Fetch(&http.Client{Timeout: releaseMetadataTimeout}, *releaseMetadataURL)
+		if err != nil {
+			log.Warnf("cannot check the catalog version against the release metadata: %v", err)
+		} else if older, _ := release.IsOlder(identifiers.CatalogVersion, metadata.PolicyVersion); older {
+			log.Warnf("the catalog version %s is older than the published certification policy version %s, "+
+				"update the suite to the latest release %s %s", identifiers.CatalogVersion, metadata.PolicyVersion,
+				metadata.LatestRelease, metadata.ReleaseURL)
+		}
+	}
+	if *requireCatalogVersion != "" {
+		if err := release.Require(identifiers.CatalogVersion, *requireCatalogVersion, metadata); err != nil {
+			log.Fatalf("-%s %s: %v", requireCatalogVersionFlagKey, *requireCatalogVersion, err)
+		}
+	}
 }
 
 // incorporateTNFVersion adds the TNF version to the claim.
 func incorporateVersions(claimData *claim.Claim) {
-	claimData.Versions = &claim.Versions{
+	claimData.Versions = getVersions()
+}
+
+// getVersions returns the versions of TNF, of the oc client and of the cluster.
+func getVersions() *claim.Versions {
+	return &claim.Versions{
 		Tnf:          gitDisplayRelease,
 		TnfGitCommit: GitCommit,
 		OcClient:     diagnostic.GetVersionsOcp().Oc,
@@ -189,6 +1139,83 @@ func incorporateVersions(claimData *claim.Claim) {
 	}
 }
 
+// startSessionTranscript records the sessions into a new transcript for the spec of report, named after its test case
+// or its spec and the current time, as the start time of the report is not set yet, and records its path under that name.
+func startSessionTranscript(report ginkgo.SpecReport) { //nolint:gocritic // From Ginkgo
+	name := report.FullText()
+	if claimID, ok := identifiers.TestIDToClaimID[report.LeafNodeText]; ok {
+		name = groups.TestCaseName(&claimID)
+	}
+	path, err := sessionTranscriptRecorder.Start(name, time.Now())
+	if err != nil {
+		log.Errorf("Cannot record the session transcript of %s: %v", report.FullText(), err)
+		return
+	}
+	if relative, err := filepath.Rel(*claimPath, path); err == nil {
+		path = relative
+	}
+	sessionTranscriptPaths[name] = append(sessionTranscriptPaths[name], path)
+}
+
+// recordStateBundle writes the state bundle of the spec of report when it failed, the transcripts of its tests are
+// forgotten otherwise.
+func recordStateBundle(report ginkgo.SpecReport) { //nolint:gocritic // From Ginkgo
+	if stateBundleRecorder == nil {
+		return
+	}
+	transcripts := stateBundleRecorder.Flush()
+	if !report.Failed() {
+		return
+	}
+	bundle := &statebundle.Bundle{
+		Spec:            report.FullText(),
+		State:           report.State.String(),
+		FailureMessage:  report.FailureMessage(),
+		FailureLocation: report.FailureLocation().String(),
+		StartTime:       report.StartTime,
+		EndTime:         report.EndTime,
+		Transcripts:     transcripts,
+		Environment:     statebundle.NewFingerprint(getVersions(), config.GetTestEnvironment().Config),
+	}
+	if claimID, ok := identifiers.TestIDToClaimID[report.LeafNodeText]; ok {
+		bundle.TestCase = groups.TestCaseName(&claimID)
+		bundle.FailedTargets = results.GetFailedTargets()[bundle.TestCase]
+	}
+	bundle.Objects = statebundle.Snapshot(statebundle.OcGet,
+		statebundle.GetTargets(config.GetTestEnvironment(), bundle.FailedTargets))
+	path, err := stateBundleRecorder.Write(bundle)
+	if err != nil {
+		log.Errorf("Cannot write the state bundle of %s: %v", bundle.Spec, err)
+		return
+	}
+	log.Infof("State bundle of %s written to %s", bundle.Spec, path)
+}
+
+// collectFailureDiagnostics gathers the diagnostics of the targets of the spec of report when it failed, and records
+// the path of their directory.
+func collectFailureDiagnostics(report ginkgo.SpecReport) { //nolint:gocritic // From Ginkgo
+	if failureDiagnosticsCollector == nil || !report.Failed() {
+		return
+	}
+	name := report.FullText()
+	var failedTargets []string
+	if claimID, ok := identifiers.TestIDToClaimID[report.LeafNodeText]; ok {
+		name = groups.TestCaseName(&claimID)
+		failedTargets = results.GetFailedTargets()[name]
+	}
+	path, err := failureDiagnosticsCollector.Collect(name, report.StartTime,
+		statebundle.GetTargets(config.GetTestEnvironment(), failedTargets))
+	if err != nil {
+		log.Errorf("Cannot write the failure diagnostics of %s: %v", report.FullText(), err)
+		return
+	}
+	log.Infof("Failure diagnostics of %s written to %s", report.FullText(), path)
+	if relative, err := filepath.Rel(*claimPath, path); err == nil {
+		path = relative
+	}
+	failureDiagnosticsPaths[name] = append(failureDiagnosticsPaths[name], path)
+}
+
 // appendCNFFeatureValidationReportResults is a helper method to add the results of running the cnf-features-deploy
 // test suite to the claim file.
 func appendCNFFeatureValidationReportResults(junitPath *string, junitMap map[string]interface{}) {
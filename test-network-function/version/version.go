@@ -19,11 +19,11 @@ package version
 import (
 	"encoding/json"
 	"os"
-	"path"
+	"path/filepath"
 )
 
 var (
-	defaultVersionFile = path.Join("..", "version.json")
+	defaultVersionFile = filepath.Join("..", "version.json")
 )
 
 // Version refers to the `test-network-function` version tag.